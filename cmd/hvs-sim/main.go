@@ -0,0 +1,43 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/hvssim"
+	commLog "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
+	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
+	commLogInt "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/setup"
+	"github.com/sirupsen/logrus"
+)
+
+var defaultLog = commLog.GetDefaultLogger()
+
+const LogFile = "hvs-sim.log"
+
+func configureLogs(logWriter io.Writer) {
+	formattedLog := commLog.LogFormatter{MaxLength: 300}
+	commLogInt.SetLogger(commLog.DefaultLoggerName, logrus.InfoLevel, &formattedLog, logWriter, false)
+	defaultLog.Info(commLogMsg.LogInit)
+}
+
+func main() {
+	logFile, err := os.OpenFile(LogFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Println("Failed to initialize logs")
+		configureLogs(os.Stderr)
+	} else {
+		defer logFile.Close()
+		configureLogs(logFile)
+	}
+
+	if err = hvssim.Run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package cms
+
+import "github.com/intel-secl/intel-secl/v3/pkg/model/cms"
+
+type IssuedCertificate cms.IssuedCertificate
+
+// swagger:parameters RevokeCertificateRequest
+type RevokeCertificateRequest struct {
+	// in:body
+	Body cms.RevokeCertificateRequest
+}
+
+// swagger:response IssuedCertificate
+type IssuedCertificateInfo struct {
+	// in:body
+	Body IssuedCertificate
+}
+
+// swagger:operation POST /certificates/{serial_number}/revoke Certificate RevokeCertificate
+// ---
+// description: |
+//   Revokes a certificate issued by CMS. The revocation is published right away in the CRL of the issuing CA and
+//   reported by the OCSP responder. The reason is optional and defaults to unspecified, accepted values are
+//   unspecified, keyCompromise, caCompromise, affiliationChanged, superseded and cessationOfOperation.
+//   A valid bearer token with the CMS Administrator role is required to authorize this REST call.
+//
+// security:
+//  - bearerAuth: []
+// consumes:
+// - application/json
+// produces:
+// - application/json
+// parameters:
+// - name: serial_number
+//   description: Serial number of the issued certificate, in decimal.
+//   in: path
+//   required: true
+//   type: string
+// - name: request body
+//   required: false
+//   in: body
+//   schema:
+//     "$ref": "#/definitions/RevokeCertificateRequest"
+// responses:
+//   '200':
+//     description: Successfully revoked the certificate.
+//     schema:
+//       "$ref": "#/definitions/IssuedCertificate"
+//   '400':
+//     description: Invalid serial number or revocation reason provided
+//   '404':
+//     description: Certificate with given serial number does not exist
+//
+// x-sample-call-endpoint: https://cms.com:8445/cms/v1/certificates/12/revoke
+// x-sample-call-input: |
+//    {
+//        "reason": "keyCompromise"
+//    }
+// x-sample-call-output: |
+//    {
+//        "serial_number": "12",
+//        "subject": "HVS TLS Certificate",
+//        "cert_type": "TLS",
+//        "issuing_ca": "TLS",
+//        "not_before": "2021-03-01T10:12:41Z",
+//        "not_after": "2022-03-01T10:12:41Z",
+//        "revoked": true,
+//        "revoked_at": "2021-04-12T08:21:03.412934Z",
+//        "revocation_reason": "keyCompromise"
+//    }
+// ---
+
+// swagger:operation GET /crl/{issuing_ca} CRL GetCrl
+// ---
+// description: |
+//   Retrieves the DER encoded CRL of an issuing CA. The CRLs are regenerated periodically and whenever a certificate
+//   is revoked. The URL of this API is published in the CRL distribution point extension of the issued certificates.
+//
+// produces:
+// - application/pkix-crl
+// parameters:
+// - name: issuing_ca
+//   description: Issuing CA such as root, TLS, TLS-Client and Signing.
+//   in: path
+//   required: true
+//   type: string
+// responses:
+//   '200':
+//     description: Successfully retrieved the CRL.
+//   '400':
+//     description: Invalid issuing CA provided
+//
+// x-sample-call-endpoint: https://cms.com:8445/cms/v1/crl/TLS
+// ---
+
+// swagger:operation POST /ocsp OCSP Ocsp
+// ---
+// description: |
+//   Minimal OCSP responder as per RFC 6960, reporting the status of the certificates issued by CMS. Responses are
+//   signed by the issuing CA. The URL of this API is published in the authority information access extension of the
+//   issued certificates.
+//
+// consumes:
+// - application/ocsp-request
+// produces:
+// - application/ocsp-response
+// responses:
+//   '200':
+//     description: DER encoded OCSP response.
+//
+// x-sample-call-endpoint: https://cms.com:8445/cms/v1/ocsp
+// ---
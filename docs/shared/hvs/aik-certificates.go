@@ -0,0 +1,283 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+
+// AikCertificate response payload
+// swagger:parameters AikCertificate
+type AikCertificate struct {
+	// in:body
+	Body hvs.AikCertificate
+}
+
+// AikCertificateCollection response payload
+// swagger:parameters AikCertificateCollection
+type AikCertificateCollection struct {
+	//	in:body
+	Body hvs.AikCertificateCollection
+}
+
+// AikCertificateRevocation request payload
+// swagger:parameters AikCertificateRevocation
+type AikCertificateRevocation struct {
+	// in:body
+	Body hvs.AikCertificateRevocation
+}
+
+// ---
+
+// swagger:operation GET /aik-certificates AikCertificates Search-AikCertificate
+// ---
+// description: |
+//   Searches the inventory of the AIK certificates issued by the privacy CA. The AIK certificates are ordered by
+//   issue date. When a host is issued a new AIK certificate for the same EK, its previous AIK certificates are
+//   revoked as superseded.
+//
+// x-permissions: aik_certificates:search
+// security:
+//   - bearerAuth: []
+// produces:
+//   - application/json
+// parameters:
+//   - name: id
+//     description: AIK certificate ID
+//     in: query
+//     type: string
+//     format: uuid
+//     required: false
+//   - name: hardwareUuid
+//     description: Hardware UUID of the host.
+//     in: query
+//     type: string
+//     format: uuid
+//     required: false
+//   - name: serialNumber
+//     description: Hex encoded serial number of the AIK certificate.
+//     in: query
+//     type: string
+//     required: false
+//   - name: ekCertificateDigest
+//     description: Hex encoded SHA384 digest of the EK certificate the AIK was certified with.
+//     in: query
+//     type: string
+//     required: false
+//   - name: revoked
+//     description: Boolean value to filter the revoked or valid AIK certificates.
+//     in: query
+//     type: boolean
+//     required: false
+//   - name: expiresBefore
+//     description: Returns the AIK certificates expiring before this date. Date formats supported are
+//       YYYY-MM-DD, YYYY-MM-DD hh:mm:ss and YYYY-MM-DDThh:mm:ss.000Z
+//     in: query
+//     type: string
+//     required: false
+//   - name: Accept
+//     description: Accept header
+//     in: header
+//     type: string
+//     required: true
+//     enum:
+//       - application/json
+// responses:
+//   "200":
+//     description: Successfully searched the AIK certificates.
+//     content: application/json
+//     schema:
+//       $ref: "#/definitions/AikCertificateCollection"
+//   '400':
+//     description: Invalid search criteria provided
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/aik-certificates?hardwareUuid=00964993-89c1-e711-906e-00163566263e
+// x-sample-call-output: |
+//   {
+//       "aik_certificates": [
+//           {
+//               "id"                    : "7c1b8d3e-0f4a-4e55-8a59-2b1f7e0d9c41",
+//               "hardware_uuid"         : "00964993-89c1-e711-906e-00163566263e",
+//               "serial_number"         : "177e3c2a9b1",
+//               "ek_certificate_digest" : "8f1b6a0e3c5d...",
+//               "certificate"           : "MIIDTjCCAbagAwIBAgIGAXfjwqmxMA0GCSqGSIb3DQEBDAUAMBsxGTAXBgNVBAMTEEhWUyBQcml2YWN5...",
+//               "issued"                : "2021-03-10T09:21:43.117Z",
+//               "expiry"                : "2026-03-10T09:21:43.117Z",
+//               "revoked"               : true,
+//               "revocation_time"       : "2021-06-02T11:04:12.532Z",
+//               "revocation_reason"     : "superseded"
+//           },
+//           {
+//               "id"                    : "d9e2a1f0-6b7c-4c0e-9d35-5e8f0a4b7c12",
+//               "hardware_uuid"         : "00964993-89c1-e711-906e-00163566263e",
+//               "serial_number"         : "179cc4f3a27",
+//               "ek_certificate_digest" : "8f1b6a0e3c5d...",
+//               "certificate"           : "MIIDTjCCAbagAwIBAgIGAXnMTzonMA0GCSqGSIb3DQEBDAUAMBsxGTAXBgNVBAMTEEhWUyBQcml2YWN5...",
+//               "issued"                : "2021-06-02T11:04:12.532Z",
+//               "expiry"                : "2026-06-02T11:04:12.532Z",
+//               "revoked"               : false
+//           }
+//       ]
+//   }
+
+// ---
+
+// swagger:operation GET /aik-certificates/{aik_certificate_id} AikCertificates Retrieve-AikCertificate
+// ---
+// description: |
+//   Retrieves an AIK certificate from the inventory of the privacy CA.
+//
+// x-permissions: aik_certificates:retrieve
+// security:
+//   - bearerAuth: []
+// produces:
+//   - application/json
+// parameters:
+//   - name: aik_certificate_id
+//     description: Unique ID of the AIK certificate.
+//     in: path
+//     required: true
+//     type: string
+//     format: uuid
+//   - name: Accept
+//     description: Accept header
+//     in: header
+//     type: string
+//     required: true
+//     enum:
+//       - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the AIK certificate.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/AikCertificate"
+//   '404':
+//     description: No relevant AIK certificate found
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/aik-certificates/d9e2a1f0-6b7c-4c0e-9d35-5e8f0a4b7c12
+
+// ---
+
+// swagger:operation POST /aik-certificates/{aik_certificate_id}/revoke AikCertificates Revoke-AikCertificate
+// ---
+// description: |
+//   Revokes an AIK certificate. The revoked AIK certificate is listed in the CRL of the privacy CA until it expires,
+//   and the hosts attesting with it are reported untrusted. The hosts with the hardware UUID of the AIK certificate
+//   are added to the flavor-verify queue, so that their trust reports are updated.
+//
+//    | Attribute                      | Description|
+//    |--------------------------------|------------|
+//    | reason                         | Revocation reason, one of unspecified, key_compromise, affiliation_changed, superseded, cessation_of_operation. Default is unspecified. (Optional) |
+//
+// x-permissions: aik_certificates:revoke
+// security:
+//   - bearerAuth: []
+// produces:
+//   - application/json
+// consumes:
+//   - application/json
+// parameters:
+//   - name: aik_certificate_id
+//     description: Unique ID of the AIK certificate.
+//     in: path
+//     required: true
+//     type: string
+//     format: uuid
+//   - name: request body
+//     required: false
+//     in: body
+//     schema:
+//       "$ref": "#/definitions/AikCertificateRevocation"
+//   - name: Content-Type
+//     description: Content-Type header
+//     in: header
+//     type: string
+//     required: true
+//     enum:
+//       - application/json
+//   - name: Accept
+//     description: Accept header
+//     in: header
+//     type: string
+//     required: true
+//     enum:
+//       - application/json
+// responses:
+//   '200':
+//     description: Successfully revoked the AIK certificate.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/AikCertificate"
+//   '400':
+//     description: Invalid revocation reason provided or the AIK certificate is already revoked
+//   '404':
+//     description: No relevant AIK certificate found
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error, or the hosts attesting with the revoked AIK certificate could not be added
+//       to the flavor-verify queue
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/aik-certificates/d9e2a1f0-6b7c-4c0e-9d35-5e8f0a4b7c12/revoke
+// x-sample-call-input: |
+//   {
+//       "reason" : "key_compromise"
+//   }
+// x-sample-call-output: |
+//   {
+//       "id"                    : "d9e2a1f0-6b7c-4c0e-9d35-5e8f0a4b7c12",
+//       "hardware_uuid"         : "00964993-89c1-e711-906e-00163566263e",
+//       "serial_number"         : "179cc4f3a27",
+//       "ek_certificate_digest" : "8f1b6a0e3c5d...",
+//       "certificate"           : "MIIDTjCCAbagAwIBAgIGAXnMTzonMA0GCSqGSIb3DQEBDAUAMBsxGTAXBgNVBAMTEEhWUyBQcml2YWN5...",
+//       "issued"                : "2021-06-02T11:04:12.532Z",
+//       "expiry"                : "2026-06-02T11:04:12.532Z",
+//       "revoked"               : true,
+//       "revocation_time"       : "2021-07-14T08:30:55.204Z",
+//       "revocation_reason"     : "key_compromise"
+//   }
+
+// ---
+
+// swagger:operation GET /privacyca/crl AikCertificates Retrieve-PrivacyCaCrl
+// ---
+// description: |
+//   Retrieves the CRL of the privacy CA. The CRL is signed by the privacy CA and lists the revoked AIK certificates
+//   that are not expired. It is valid for 24 hours, the same CRL is returned until it is 12 hours old or an AIK
+//   certificate is revoked. No authentication is required, so that the relying parties such as KBS can check the
+//   revocation of the AIK certificates.
+//
+// produces:
+//   - application/x-pem-file
+// parameters:
+//   - name: Accept
+//     description: Accept header
+//     in: header
+//     type: string
+//     required: true
+//     enum:
+//       - application/x-pem-file
+// responses:
+//   '200':
+//     description: Successfully retrieved the PEM encoded CRL of the privacy CA.
+//     content: application/x-pem-file
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/privacyca/crl
+// x-sample-call-output: |
+//   -----BEGIN X509 CRL-----
+//   MIICOzCBpAIBATANBgkqhkiG9w0BAQsFADAiMSAwHgYDVQQDExdIVlMgUHJpdmFj...
+//   -----END X509 CRL-----
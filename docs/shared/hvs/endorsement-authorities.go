@@ -0,0 +1,323 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+
+// EndorsementAuthority response payload
+// swagger:parameters EndorsementAuthority
+type EndorsementAuthority struct {
+	// in:body
+	Body hvs.EndorsementAuthority
+}
+
+// EndorsementAuthorityUpdateRequest request payload
+// swagger:parameters EndorsementAuthorityUpdateRequest
+type EndorsementAuthorityUpdateRequest struct {
+	// in:body
+	Body hvs.EndorsementAuthorityUpdateRequest
+}
+
+// EndorsementAuthorityCollection response payload
+// swagger:parameters EndorsementAuthorityCollection
+type EndorsementAuthorityCollection struct {
+	//	in:body
+	Body hvs.EndorsementAuthorityCollection
+}
+
+// ---
+
+// swagger:operation POST /endorsement-authorities EndorsementAuthorities Create-EndorsementAuthority
+// ---
+// description: |
+//   Creates an endorsement authority. An endorsement authority is a bundle of root and intermediate CA certificates
+//   of a TPM manufacturer, along with the CRLs it publishes. The EK certificate of a host is trusted when it chains
+//   up to the certificates of an enabled endorsement authority and is not revoked by its CRLs. When the bundle has
+//   no self-signed certificate, its certificates are used as trust anchors.
+//
+//    | Attribute                      | Description|
+//    |--------------------------------|------------|
+//    | name                           | Unique name of the endorsement authority. |
+//    | manufacturer                   | TPM manufacturer. (Optional) |
+//    | certificates                   | PEM encoded CA certificates of the bundle. Only CA certificates are accepted. |
+//    | crls                           | PEM encoded X509 CRLs, each signed by a certificate of the bundle. (Optional) |
+//    | disabled                       | Disabled endorsement authorities are not used to verify EK certificates. Default is false. (Optional) |
+//
+// x-permissions: endorsement_authorities:create
+// security:
+//   - bearerAuth: []
+// produces:
+//   - application/json
+// consumes:
+//   - application/json
+// parameters:
+//   - name: request body
+//     required: true
+//     in: body
+//     schema:
+//       "$ref": "#/definitions/EndorsementAuthority"
+//   - name: Content-Type
+//     description: Content-Type header
+//     in: header
+//     type: string
+//     required: true
+//     enum:
+//       - application/json
+//   - name: Accept
+//     description: Accept header
+//     in: header
+//     type: string
+//     required: true
+//     enum:
+//       - application/json
+// responses:
+//   '201':
+//     description: Successfully created the endorsement authority.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/EndorsementAuthority"
+//   '400':
+//     description: Invalid request body provided
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/endorsement-authorities
+// x-sample-call-input: |
+//   {
+//       "name"         : "Infineon OPTIGA RSA",
+//       "manufacturer" : "Infineon",
+//       "certificates" : "-----BEGIN CERTIFICATE-----\nMIIFqzCCA5OgAwIBAgIBAzANBgkqhkiG9w0BAQsFADB3...\n-----END CERTIFICATE-----\n-----BEGIN CERTIFICATE-----\nMIIFszCCA5ugAwIBAgIEasM5FDANBgkqhkiG9w0BAQsF...\n-----END CERTIFICATE-----\n",
+//       "crls"         : "-----BEGIN X509 CRL-----\nMIIDCzCB9AIBATANBgkqhkiG9w0BAQsFADCBgzELMAkG...\n-----END X509 CRL-----\n"
+//   }
+// x-sample-call-output: |
+//   {
+//       "id"           : "5ba8a4e6-5b3a-4a1b-9c5e-3c4b8e3f2a10",
+//       "name"         : "Infineon OPTIGA RSA",
+//       "manufacturer" : "Infineon",
+//       "certificates" : "-----BEGIN CERTIFICATE-----\nMIIFqzCCA5OgAwIBAgIBAzANBgkqhkiG9w0BAQsFADB3...\n-----END CERTIFICATE-----\n-----BEGIN CERTIFICATE-----\nMIIFszCCA5ugAwIBAgIEasM5FDANBgkqhkiG9w0BAQsF...\n-----END CERTIFICATE-----\n",
+//       "crls"         : "-----BEGIN X509 CRL-----\nMIIDCzCB9AIBATANBgkqhkiG9w0BAQsFADCBgzELMAkG...\n-----END X509 CRL-----\n",
+//       "disabled"     : false,
+//       "created"      : "2021-03-10T09:21:43.117Z",
+//       "updated"      : "2021-03-10T09:21:43.117Z"
+//   }
+
+// ---
+
+// swagger:operation GET /endorsement-authorities EndorsementAuthorities Search-EndorsementAuthority
+// ---
+// description: |
+//   Searches the endorsement authorities. The endorsement authorities are ordered by name.
+//
+// x-permissions: endorsement_authorities:search
+// security:
+//   - bearerAuth: []
+// produces:
+//   - application/json
+// parameters:
+//   - name: id
+//     description: Endorsement authority ID
+//     in: query
+//     type: string
+//     format: uuid
+//     required: false
+//   - name: nameEqualTo
+//     description: Name of the endorsement authority.
+//     in: query
+//     type: string
+//     required: false
+//   - name: nameContains
+//     description: Substring of the name of the endorsement authority.
+//     in: query
+//     type: string
+//     required: false
+//   - name: manufacturerEqualTo
+//     description: TPM manufacturer.
+//     in: query
+//     type: string
+//     required: false
+//   - name: disabled
+//     description: Boolean value to filter the enabled or disabled endorsement authorities.
+//     in: query
+//     type: boolean
+//     required: false
+//   - name: Accept
+//     description: Accept header
+//     in: header
+//     type: string
+//     required: true
+//     enum:
+//       - application/json
+// responses:
+//   "200":
+//     description: Successfully searched the endorsement authorities.
+//     content: application/json
+//     schema:
+//       $ref: "#/definitions/EndorsementAuthorityCollection"
+//   '400':
+//     description: Invalid search criteria provided
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/endorsement-authorities?manufacturerEqualTo=Infineon
+// x-sample-call-output: |
+//   {
+//       "endorsement_authorities": [
+//           {
+//               "id"           : "5ba8a4e6-5b3a-4a1b-9c5e-3c4b8e3f2a10",
+//               "name"         : "Infineon OPTIGA RSA",
+//               "manufacturer" : "Infineon",
+//               "certificates" : "-----BEGIN CERTIFICATE-----\nMIIFqzCCA5OgAwIBAgIBAzANBgkqhkiG9w0BAQsFADB3...\n-----END CERTIFICATE-----\n",
+//               "disabled"     : false,
+//               "created"      : "2021-03-10T09:21:43.117Z",
+//               "updated"      : "2021-03-10T09:21:43.117Z"
+//           }
+//       ]
+//   }
+
+// ---
+
+// swagger:operation GET /endorsement-authorities/{endorsement_authority_id} EndorsementAuthorities Retrieve-EndorsementAuthority
+// ---
+// description: |
+//   Retrieves an endorsement authority.
+//
+// x-permissions: endorsement_authorities:retrieve
+// security:
+//   - bearerAuth: []
+// produces:
+//   - application/json
+// parameters:
+//   - name: endorsement_authority_id
+//     description: Unique ID of the endorsement authority.
+//     in: path
+//     required: true
+//     type: string
+//     format: uuid
+//   - name: Accept
+//     description: Accept header
+//     in: header
+//     type: string
+//     required: true
+//     enum:
+//       - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the endorsement authority.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/EndorsementAuthority"
+//   '404':
+//     description: No relevant endorsement authority found
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/endorsement-authorities/5ba8a4e6-5b3a-4a1b-9c5e-3c4b8e3f2a10
+
+// ---
+
+// swagger:operation PUT /endorsement-authorities/{endorsement_authority_id} EndorsementAuthorities Update-EndorsementAuthority
+// ---
+// description: |
+//   Updates an endorsement authority. The attributes are only updated when provided, so that an endorsement authority
+//   can be disabled or enabled again without uploading its bundle.
+//
+//    | Attribute                      | Description|
+//    |--------------------------------|------------|
+//    | name                           | Unique name of the endorsement authority. (Optional) |
+//    | manufacturer                   | TPM manufacturer of the endorsement authority. (Optional) |
+//    | certificates                   | PEM encoded bundle of root and intermediate CA certificates, replaces the current bundle. (Optional) |
+//    | crls                           | PEM encoded CRLs issued by the CA certificates, replaces the current CRLs. An empty string removes the CRLs. (Optional) |
+//    | disabled                       | Boolean value to disable or enable the endorsement authority. (Optional) |
+//
+// x-permissions: endorsement_authorities:store
+// security:
+//   - bearerAuth: []
+// produces:
+//   - application/json
+// consumes:
+//   - application/json
+// parameters:
+//   - name: endorsement_authority_id
+//     description: Unique ID of the endorsement authority.
+//     in: path
+//     required: true
+//     type: string
+//     format: uuid
+//   - name: request body
+//     required: true
+//     in: body
+//     schema:
+//       "$ref": "#/definitions/EndorsementAuthorityUpdateRequest"
+//   - name: Content-Type
+//     description: Content-Type header
+//     in: header
+//     type: string
+//     required: true
+//     enum:
+//       - application/json
+//   - name: Accept
+//     description: Accept header
+//     in: header
+//     type: string
+//     required: true
+//     enum:
+//       - application/json
+// responses:
+//   '200':
+//     description: Successfully updated the endorsement authority.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/EndorsementAuthority"
+//   '400':
+//     description: Invalid request body provided
+//   '404':
+//     description: No relevant endorsement authority found
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/endorsement-authorities/5ba8a4e6-5b3a-4a1b-9c5e-3c4b8e3f2a10
+// x-sample-call-input: |
+//   {
+//       "disabled" : true
+//   }
+
+// ---
+
+// swagger:operation DELETE /endorsement-authorities/{endorsement_authority_id} EndorsementAuthorities Delete-EndorsementAuthority
+// ---
+// description: |
+//   Deletes an endorsement authority.
+//
+// x-permissions: endorsement_authorities:delete
+// security:
+//   - bearerAuth: []
+// parameters:
+//   - name: endorsement_authority_id
+//     description: Unique ID of the endorsement authority.
+//     in: path
+//     required: true
+//     type: string
+//     format: uuid
+// responses:
+//   '204':
+//     description: Successfully deleted the endorsement authority.
+//   '404':
+//     description: No relevant endorsement authority found
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/endorsement-authorities/5ba8a4e6-5b3a-4a1b-9c5e-3c4b8e3f2a10
@@ -79,6 +79,17 @@ type SignedFlavorCollection struct {
 //   in: query
 //   type: string
 //   required: false
+// - name: limit
+//   description: Maximum number of flavors in a page of the response. When the page is full, the `next` field of the response links to the following page. All the flavors are returned when not set.
+//   in: query
+//   type: integer
+//   minimum: 1
+//   required: false
+// - name: after
+//   description: Opaque cursor of the last record of the previous page, as given in the `next` link of the previous page.
+//   in: query
+//   type: string
+//   required: false
 // - name: Accept
 //   description: Accept header
 //   in: header
@@ -151,7 +162,7 @@ type SignedFlavorCollection struct {
 //
 //   Partial flavor types can be specified as an array input. In this fashion, the user can choose which flavor types to import from a host. Only flavor types that are defined in the flavor group flavor match policy can be specified. If no partial flavor types are provided, the default action is to attempt retrieval of all flavor types. The response will contain all flavor types that it was able to create.
 //
-//   If generic flavors are created, all hosts in the flavor group will be added to the backend queue, flavor verification process to re-evaluate their trust status. If host unique flavors are created, the individual affected hosts are added to the flavor verification process.
+//   If generic flavors are created, the hosts in the flavor group whose last host manifest matches the description of a new flavor will be added to the backend queue, flavor verification process to re-evaluate their trust status. If host unique flavors are created, the individual affected hosts are added to the flavor verification process. The hosts impacted by a flavor change can be evaluated beforehand with the POST /flavors/impact API.
 //
 //   The serialized FlavorCreateRequest Go struct object represents the content of the request body.
 //
@@ -543,7 +554,8 @@ type SignedFlavorCollection struct {
 // ---
 //
 // description: |
-//   Deletes a flavor.
+//   Deletes a flavor. The hosts that trusted the flavor, or whose last host manifest matches its description, are
+//   added to the flavor verification process to re-evaluate their trust status.
 // x-permissions: flavors:delete
 // security:
 //  - bearerAuth: []
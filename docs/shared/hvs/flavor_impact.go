@@ -0,0 +1,106 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+)
+
+// Flavor impact API request payload
+// swagger:parameters FlavorImpactRequest
+type FlavorImpactRequest struct {
+	// in:body
+	Body hvs.FlavorImpactRequest
+}
+
+// Flavor impact API response payload
+// swagger:parameters FlavorImpact
+type FlavorImpact struct {
+	// in:body
+	Body hvs.FlavorImpact
+}
+
+// ---
+
+// swagger:operation POST /flavors/impact Flavors Evaluate-FlavorImpact
+// ---
+//
+// description: |
+//   Evaluates a flavor change without applying it, and returns the hosts that would be re-verified once the
+//   change is made. When a flavor is linked to a flavor group, only the hosts of the flavor group whose last host
+//   manifest matches the description of the flavor are re-verified. When a flavor is unlinked from a flavor group
+//   or deleted, only the hosts that trusted the flavor, according to their trust cache, or whose last host manifest
+//   matches it are re-verified. The hosts that have not been verified yet are always re-verified.
+//
+//    | Attribute                      | Description                                     |
+//    |--------------------------------|-------------------------------------------------|
+//    | operation                      | The flavor change, one of link, unlink or delete. |
+//    | flavor_id                      | The flavor to link, unlink or delete. |
+//    | flavorgroup_id                 | The flavor group the flavor is linked to or unlinked from. Required for link and unlink. |
+//    | flavor                         | (Optional) The content of a flavor that has not been created yet, instead of flavor_id. Only for link. |
+//
+// x-permissions: flavors:search
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/FlavorImpactRequest"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully evaluated the flavor change.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/FlavorImpact"
+//   '400':
+//     description: Invalid request body provided, or the flavor or flavor group does not exist
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavors/impact
+// x-sample-call-input: |
+//    {
+//        "operation": "unlink",
+//        "flavor_id": "f66ac31d-124d-418e-8200-2abf414a9adf",
+//        "flavorgroup_id": "d0b2a5b8-7cf7-47e3-a1b4-6e1a3d4e7b9c"
+//    }
+// x-sample-call-output: |
+//    {
+//        "operation": "unlink",
+//        "flavor_id": "f66ac31d-124d-418e-8200-2abf414a9adf",
+//        "flavorgroup_ids": [
+//            "d0b2a5b8-7cf7-47e3-a1b4-6e1a3d4e7b9c"
+//        ],
+//        "linked_host_count": 12,
+//        "impacted_host_count": 2,
+//        "impacted_hosts": [
+//            "47a3b602-f321-4e03-b3b2-8f3ca3cde128",
+//            "ee37c360-7eae-4250-a677-6ee12adce8e2"
+//        ]
+//    }
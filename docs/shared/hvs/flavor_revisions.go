@@ -0,0 +1,330 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+)
+
+// Flavor update API request payload
+// swagger:parameters FlavorUpdateRequest
+type FlavorUpdateRequest struct {
+	// in:body
+	Body hvs.FlavorUpdateRequest
+}
+
+// Flavor rollback API request payload
+// swagger:parameters FlavorRollbackRequest
+type FlavorRollbackRequest struct {
+	// in:body
+	Body hvs.FlavorRollbackRequest
+}
+
+// Flavor revision API response payload
+// swagger:parameters FlavorRevision
+type FlavorRevision struct {
+	// in:body
+	Body hvs.FlavorRevision
+}
+
+// Flavor revisions API response payload
+// swagger:parameters FlavorRevisionCollection
+type FlavorRevisionCollection struct {
+	// in:body
+	Body hvs.FlavorRevisionCollection
+}
+
+// ---
+
+// swagger:operation PUT /flavors/{flavor_id} Flavors Update-Flavor
+// ---
+//
+// description: |
+//   Replaces the content of an existing flavor. The flavor is signed again with the flavor signing key and the
+//   new content is recorded as a new revision of the flavor, prior revisions are kept and can be queried.
+//   The flavor part of a flavor cannot be changed.
+//
+//   All hosts linked to the flavor through its flavor groups are added to the flavor verification queue
+//   to re-evaluate their trust status against the new content.
+//
+//    | Attribute                      | Description                                     |
+//    |--------------------------------|-------------------------------------------------|
+//    | flavor                         | The new flavor content in the defined flavor format. |
+//    | comment                        | (Optional) Reason for the change, recorded with the revision. |
+//
+// x-permissions: flavors:update
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: flavor_id
+//   description: Unique UUID of the flavor.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/FlavorUpdateRequest"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully updated the flavor.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/FlavorRevision"
+//   '400':
+//     description: Invalid request body provided
+//   '404':
+//     description: No flavor with the provided flavor ID found.
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavors/f66ac31d-124d-418e-8200-2abf414a9adf
+// x-sample-call-input: |
+//    {
+//        "flavor": {
+//            "meta": {
+//                "id": "f66ac31d-124d-418e-8200-2abf414a9adf",
+//                "description": {
+//                    "flavor_part": "SOFTWARE",
+//                    "label": "ISecL_Default_Application_Flavor_v3.6_TPM2.0",
+//                    "digest_algorithm": "SHA384"
+//                }
+//            },
+//            "software": { ... }
+//        },
+//        "comment": "Added measurement of trust agent scripts"
+//    }
+// x-sample-call-output: |
+//    {
+//        "flavor_id": "f66ac31d-124d-418e-8200-2abf414a9adf",
+//        "revision": 2,
+//        "signed_flavor": {
+//            "flavor": { ... },
+//            "signature": "aas8/Nv7yYuwx2ZIOMrXFpNf333tBJgr87Dpo7Z5jjUR36Estlb8pYaTGN4Dz9JtbXZy2uIBLr1wjhkHVWm2r1FQq..."
+//        },
+//        "created_by": "admin@hvs",
+//        "comment": "Added measurement of trust agent scripts",
+//        "created": "2021-03-04T10:26:28.618913Z"
+//    }
+
+// ---
+
+// swagger:operation GET /flavors/{flavor_id}/revisions Flavors Search-FlavorRevisions
+// ---
+//
+// description: |
+//   Retrieves all revisions of a flavor, latest revision first.
+//   Returns - The serialized FlavorRevisionCollection Go struct object that was retrieved.
+// x-permissions: flavors:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: flavor_id
+//   description: Unique UUID of the flavor.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the flavor revisions.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/FlavorRevisionCollection"
+//   '404':
+//     description: No flavor with the provided flavor ID found.
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavors/f66ac31d-124d-418e-8200-2abf414a9adf/revisions
+// x-sample-call-output: |
+//    {
+//        "flavor_revisions": [
+//            {
+//                "flavor_id": "f66ac31d-124d-418e-8200-2abf414a9adf",
+//                "revision": 2,
+//                "signed_flavor": { ... },
+//                "created_by": "admin@hvs",
+//                "comment": "Added measurement of trust agent scripts",
+//                "created": "2021-03-04T10:26:28.618913Z"
+//            },
+//            {
+//                "flavor_id": "f66ac31d-124d-418e-8200-2abf414a9adf",
+//                "revision": 1,
+//                "signed_flavor": { ... },
+//                "created": "2021-02-11T08:12:45.101231Z"
+//            }
+//        ]
+//    }
+
+// ---
+
+// swagger:operation GET /flavors/{flavor_id}/revisions/{revision} Flavors Retrieve-FlavorRevision
+// ---
+//
+// description: |
+//   Retrieves a single revision of a flavor.
+//   Returns - The serialized FlavorRevision Go struct object that was retrieved.
+// x-permissions: flavors:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: flavor_id
+//   description: Unique UUID of the flavor.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: revision
+//   description: Revision number of the flavor.
+//   in: path
+//   required: true
+//   type: integer
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the flavor revision.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/FlavorRevision"
+//   '400':
+//     description: Invalid revision provided
+//   '404':
+//     description: No flavor revision with the provided flavor ID and revision found.
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavors/f66ac31d-124d-418e-8200-2abf414a9adf/revisions/1
+
+// ---
+
+// swagger:operation POST /flavors/{flavor_id}/rollback Flavors Rollback-Flavor
+// ---
+//
+// description: |
+//   Restores a flavor to the content of one of its prior revisions. The restored content is recorded as a new
+//   revision, the revision history is never rewritten. If no comment is provided, the new revision is commented
+//   with the revision it was restored from.
+//
+//   All hosts linked to the flavor through its flavor groups are added to the flavor verification queue
+//   to re-evaluate their trust status against the restored content.
+//
+//    | Attribute                      | Description                                     |
+//    |--------------------------------|-------------------------------------------------|
+//    | revision                       | The revision number to restore. |
+//    | comment                        | (Optional) Reason for the rollback, recorded with the new revision. |
+//
+// x-permissions: flavors:update
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: flavor_id
+//   description: Unique UUID of the flavor.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/FlavorRollbackRequest"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully rolled back the flavor.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/FlavorRevision"
+//   '400':
+//     description: Invalid request body provided, the revision does not exist or is the latest revision
+//   '404':
+//     description: No flavor with the provided flavor ID found.
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavors/f66ac31d-124d-418e-8200-2abf414a9adf/rollback
+// x-sample-call-input: |
+//    {
+//        "revision": 1
+//    }
+// x-sample-call-output: |
+//    {
+//        "flavor_id": "f66ac31d-124d-418e-8200-2abf414a9adf",
+//        "revision": 3,
+//        "signed_flavor": { ... },
+//        "created_by": "admin@hvs",
+//        "comment": "Rollback to revision 1",
+//        "created": "2021-03-05T14:02:11.402188Z"
+//    }
+
+// ---
@@ -0,0 +1,141 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+)
+
+// Flavor simulation API request payload
+// swagger:parameters FlavorSimulationRequest
+type FlavorSimulationRequest struct {
+	// in:body
+	Body hvs.FlavorSimulationRequest
+}
+
+// Flavor simulation API response payload
+// swagger:parameters FlavorSimulation
+type FlavorSimulation struct {
+	// in:body
+	Body hvs.FlavorSimulation
+}
+
+// ---
+
+// swagger:operation POST /flavors/simulate Flavors Simulate-Flavors
+// ---
+//
+// description: |
+//   Verifies candidate flavors against the last host manifest of the selected hosts, and returns the trust of each
+//   host against the flavors. The candidate flavors are either provided as unsigned flavor content, or generated
+//   from a reference host manifest with flavor templates. Nothing is persisted: the flavors are not created, and
+//   no report or audit log entry is written. The hosts are selected by flavor group, by label selector or both, and
+//   no more than 100 hosts can be selected.
+//   The results of the candidate flavors matching the host manifest are evaluated with the flavor match policies of
+//   the flavor group, or with those of the automatic flavor group when no flavor group is selected. The candidate
+//   flavors replace the flavors of the flavor parts they define, the other flavor parts are not evaluated. A defined
+//   flavor part that is required, or required if defined, must be matched by one of the candidate flavors, it is
+//   listed in missing_flavor_parts otherwise. The hosts without host manifest, or whose manifest cannot be verified,
+//   are skipped.
+//
+//    | Attribute                      | Description                                     |
+//    |--------------------------------|-------------------------------------------------|
+//    | flavor_collection              | The unsigned candidate flavors. |
+//    | host_manifest                  | The reference host manifest the candidate flavors are generated from, instead of flavor_collection. |
+//    | flavor_template_ids            | (Optional) The flavor templates applied to the reference host manifest. The templates matching the manifest are applied by default. |
+//    | flavor_parts                   | (Optional) The flavor parts generated from the reference host manifest, PLATFORM and OS by default. Only PLATFORM, OS and HOST_UNIQUE are supported. |
+//    | flavorgroup_id                 | Selects the hosts linked to the flavor group, whose flavor match policies are applied. |
+//    | host_label_selector            | Selects the hosts with matching labels, e.g. "env=prod,rack!=r1". |
+//
+// x-permissions: flavors:simulate
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/FlavorSimulationRequest"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully simulated the flavors.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/FlavorSimulation"
+//   '400':
+//     description: Invalid request body provided, the flavor group or flavor template does not exist, or more than
+//       100 hosts are selected
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavors/simulate
+// x-sample-call-input: |
+//    {
+//        "host_manifest": { ... },
+//        "flavor_parts": ["PLATFORM"],
+//        "host_label_selector": "env=prod"
+//    }
+// x-sample-call-output: |
+//    {
+//        "flavors": [ ... ],
+//        "host_count": 2,
+//        "trusted_host_count": 1,
+//        "untrusted_host_count": 0,
+//        "skipped_host_count": 1,
+//        "hosts": [
+//            {
+//                "host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//                "host_name": "computepurley1",
+//                "trusted": true,
+//                "flavor_results": [
+//                    {
+//                        "flavor_id": "c36b5412-8c02-4e08-8a74-8bfa40425cf3",
+//                        "flavor_part": "PLATFORM",
+//                        "label": "INTEL_IntelCorporation_SE5C620.86B.00.01.6016.032720190737_TXT_TPM_06-16-2020",
+//                        "matched": true,
+//                        "trusted": true,
+//                        "rule_results": [
+//                            {
+//                                "rule": {
+//                                    "rule_name": "com.intel.mtwilson.core.verifier.policy.rule.PcrMatchesConstant",
+//                                    "markers": ["PLATFORM"]
+//                                },
+//                                "flavor_id": "c36b5412-8c02-4e08-8a74-8bfa40425cf3",
+//                                "trusted": true
+//                            }
+//                        ]
+//                    }
+//                ]
+//            },
+//            {
+//                "host_id": "e57e5ea0-d465-461e-882d-1600090caa0d",
+//                "host_name": "computepurley2",
+//                "trusted": false,
+//                "error": "Host manifest is not available"
+//            }
+//        ]
+//    }
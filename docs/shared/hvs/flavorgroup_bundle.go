@@ -0,0 +1,202 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+)
+
+// Signed flavorgroup bundle payload
+// swagger:parameters SignedFlavorgroupBundle
+type SignedFlavorgroupBundle struct {
+	// in:body
+	Body hvs.SignedFlavorgroupBundle
+}
+
+// Flavorgroup import response payload
+// swagger:parameters FlavorgroupImport
+type FlavorgroupImport struct {
+	// in:body
+	Body hvs.FlavorgroupImport
+}
+
+// ---
+
+// swagger:operation GET /flavorgroups/{flavorgroup_id}/export Flavorgroups Export
+// ---
+//
+// description: |
+//   Exports a flavor group as a self-contained bundle, that can be imported in another HVS. The bundle holds the
+//   flavor group definition with its flavor match policies, the flavors linked to the flavor group, the flavor
+//   templates the flavors are generated from and the flavor signing certificate chain. The flavors and the bundle
+//   are signed with the flavor signing key of HVS. The export fails when the signature of a stored flavor is not
+//   valid.
+// x-permissions: flavorgroups:export
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: flavorgroup_id
+//   description: Unique ID of the flavorgroup.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully exported the flavorgroup.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/SignedFlavorgroupBundle"
+//   '404':
+//     description: Flavorgroup record not found
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavorgroups/826501bd-3c75-4839-a08f-db5f744f8498/export
+// x-sample-call-output: |
+//    {
+//        "bundle": {
+//            "flavorgroup": {
+//                "id": "826501bd-3c75-4839-a08f-db5f744f8498",
+//                "name": "datacenter1",
+//                "flavor_match_policy_collection": {
+//                    "flavor_match_policies": [
+//                        {
+//                            "flavor_part": "PLATFORM",
+//                            "match_policy": {
+//                                "match_type": "ANY_OF",
+//                                "required": "REQUIRED"
+//                            }
+//                        }
+//                    ]
+//                }
+//            },
+//            "signed_flavors": [
+//                {
+//                    "flavor": { ... },
+//                    "signature": "EyuFK0QoSs..."
+//                }
+//            ],
+//            "flavor_templates": [ ... ],
+//            "signing_certificates": [
+//                "-----BEGIN CERTIFICATE-----\nMIIELDCCApSgAwIBAgIBADANBgkqhkiG9w0BAQwFADBQMQswCQYDVQQGEwJVUzEL\n...\n-----END CERTIFICATE-----\n"
+//            ],
+//            "created": "2021-03-16T10:05:27.164Z"
+//        },
+//        "signature": "Tk4kG0L8Hr..."
+//    }
+
+// ---
+
+// swagger:operation POST /flavorgroups/import Flavorgroups Import
+// ---
+//
+// description: |
+//   Imports a flavor group bundle exported by another HVS, or created offline with flavorgen. The signing certificate
+//   of the bundle must chain up to one of the flavor CA certificates of HVS, and the bundle and its flavors must be
+//   signed with its key.
+//   The flavor group, flavors and flavor templates of the bundle that already exist are reused when they have the
+//   same content. The flavor groups are matched by name and compared by flavor match policies, the flavors are
+//   matched by digest, the flavor templates are matched by ID. When an item of the bundle exists with a different
+//   content, or a flavor has the ID or label of an existing flavor with a different digest, nothing is imported and
+//   the conflicts are reported.
+//   The imported flavors are signed with the flavor signing key of HVS, and linked to the flavor group. The items are
+//   created in one transaction, nothing is imported when the creation of an item fails.
+//
+// x-permissions: flavorgroups:import
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/SignedFlavorgroupBundle"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '201':
+//     description: Successfully imported the flavorgroup.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/FlavorgroupImport"
+//   '400':
+//     description: Invalid request body provided, or the bundle could not be verified
+//   '409':
+//     description: The bundle conflicts with existing items, nothing is imported
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/FlavorgroupImport"
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavorgroups/import
+// x-sample-call-input: |
+//    {
+//        "bundle": { ... },
+//        "signature": "Tk4kG0L8Hr..."
+//    }
+// x-sample-call-output: |
+//    {
+//        "flavorgroup_id": "b5f2cd9b-4c7e-4ab0-9a4c-5f2b8b1c46a3",
+//        "imported": true,
+//        "created_count": 2,
+//        "duplicate_count": 1,
+//        "conflict_count": 0,
+//        "items": [
+//            {
+//                "type": "flavorgroup",
+//                "id": "826501bd-3c75-4839-a08f-db5f744f8498",
+//                "name": "datacenter1",
+//                "status": "created"
+//            },
+//            {
+//                "type": "flavor_template",
+//                "id": "426912bd-39b0-4daa-ad21-0c6933230b50",
+//                "name": "default-uefi",
+//                "status": "duplicate",
+//                "existing_id": "426912bd-39b0-4daa-ad21-0c6933230b50"
+//            },
+//            {
+//                "type": "flavor",
+//                "id": "c36b5412-8c02-4e08-8a74-8bfa40425cf3",
+//                "name": "INTEL_IntelCorporation_SE5C620.86B.00.01.6016.032720190737_TXT_TPM_06-16-2020",
+//                "status": "created"
+//            }
+//        ]
+//    }
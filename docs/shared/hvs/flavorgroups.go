@@ -198,6 +198,8 @@ type FlavorgroupFlavorLinkCollection struct {
 //    |--------------------------------|------------|
 //    | name                           | Name of the flavorgroup to be created. |
 //    | flavor_match_policy_collection | Collection of flavor match policies. Each flavor match policy contains two <br> parts: <br><b>flavor_part</b>:The type or classification of the flavor.<br> <b>match_policy</b>:The policy which defines how the host is verified against the <br> flavors in the flavor group for the specified flavor part. |
+//    | host_label_selector            | Optional label selector, e.g. "env=prod,rack in (r1,r2)". The flavorgroup is linked to the hosts whose labels match the selector when they are created or relabeled. |
+//    | custom_rules                   | Optional site specific rules evaluated against the host manifest of the hosts linked to the flavorgroup. Each rule contains <br><b>name</b>: The name of the rule, reported as "com.intel.mtwilson.core.verifier.policy.rule.custom.&lt;name&gt;".<br><b>type</b>: The type of the rule, "jsonquery" by default.<br><b>query</b>: The 'jsonquery' statement, the rule is satisfied when it selects at least one node of the host manifest.<br><b>description</b>: Optional description, added to the fault when the rule is not satisfied.<br><b>flavor_part</b>: The flavor part the rule result is marked with, PLATFORM by default. |
 //
 // x-permissions: flavorgroups:create
 // security:
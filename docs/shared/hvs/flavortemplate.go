@@ -130,6 +130,8 @@ type FlavorTemplate struct {
 //    |--------------------------------|------------|
 //    | Meta                           | Provides the template-author the option to populate arbitrary key/value pairs that will be copied to flavor-part’s “meta/description” entity. |
 //    | PcrRules                       | Instructs the flavor creation engine to copy PCR bank values from the host-manifest to the resulting flavor-part. |
+//    | CustomRules                    | Optional site specific rules copied to the flavor-part, and evaluated against the host-manifest during flavor verification. |
+//    | HostInfoPolicy                 | Optional hardware features, minimum versions and host-info values required by the PLATFORM flavor-part. It is ignored in the other flavor-parts. |
 //
 //   PcrRules: An array of verification rules that will be applied to a PCR.
 //
@@ -140,6 +142,24 @@ type FlavorTemplate struct {
 //    | EventLogEquals                 | Event log equals contains “eventlog_equals” section will update the flavor-part to enforce “PCR Event Log Equals” rules during verification.  The optional “excluding_tags” element can be used to omit events with a one or more “tags” during verification. |
 //    | EventLogIncludes               | EventLogInclude contains “eventlog_includes” section will update the flavor-part to enforce “PCR Event Log Includes” rules during verification. |
 //
+//   CustomRules: An array of site specific rules, reported in the trust report as "com.intel.mtwilson.core.verifier.policy.rule.custom.<name>" with the flavor part as marker.
+//
+//    | Attribute                      | Description|
+//    |--------------------------------|------------|
+//    | Name                           | Name of the rule, unique in the flavor-part. |
+//    | Type                           | Type of the rule in the custom rule registry of the verifier, “jsonquery” by default. |
+//    | Query                          | A 'jsonquery' statement, the rule is satisfied when it selects at least one node of the host-manifest. For example, “//host_info/hardware_features/UEFI/meta[secure_boot_enabled='true']”. |
+//    | Description                    | Optional description, added to the “CustomRuleNotSatisfied” fault. |
+//
+//   HostInfoPolicy: The requirements on the host-info of the host-manifest, copied to the “host_info_policy” section of the PLATFORM flavor-part.
+//   The host-info attributes are os_name, os_version, bios_name, bios_version, vmm_name, vmm_version, processor_info, tpm_version, cbnt_profile and bmc_firmware_version.
+//
+//    | Attribute                      | Description|
+//    |--------------------------------|------------|
+//    | FeaturesEnabled                | Hardware features that must be enabled, among TXT, TPM, CBNT, UEFI, SECURE_BOOT, PFR and BMC. Verified by the “HardwareFeatureEnabled” rule, with the “HardwareFeatureNotSupported” and “HardwareFeatureNotEnabled” faults. |
+//    | MinimumVersions                | Host-info attributes that must be at or above a “minimum” version. The “format” of the versions is “dotted” (default), “semver” or “intel_bios”. Verified by the “HostInfoVersionAtLeast” rule, with the “HostInfoVersionBelowMinimum”, “HostInfoVersionInvalid” and “HostInfoMissing” faults. |
+//    | Matches                        | Host-info attributes that must be equal to a “value”, or match a regular expression “pattern”. Verified by the “HostInfoMatches” rule, with the “HostInfoMismatch” and “HostInfoMissing” faults. |
+//
 //   Creates a Flavor template and stores it in the database.
 //
 // x-permissions: flavor-template:create
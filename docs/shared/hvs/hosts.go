@@ -27,6 +27,27 @@ type HostCreateRequest struct {
 	Body hvs.HostCreateRequest
 }
 
+// HostBulkCreateRequest request payload
+// swagger:parameters HostBulkCreateRequest
+type HostBulkCreateRequest struct {
+	// in:body
+	Body hvs.HostBulkCreateRequest
+}
+
+// HostFlavorgroupBulkCreateRequest request payload
+// swagger:parameters HostFlavorgroupBulkCreateRequest
+type HostFlavorgroupBulkCreateRequest struct {
+	// in:body
+	Body hvs.HostFlavorgroupBulkCreateRequest
+}
+
+// HostLabels request/response payload
+// swagger:parameters HostLabels
+type HostLabels struct {
+	// in:body
+	Body hvs.HostLabels
+}
+
 // HostFlavorgroup response payload
 // swagger:parameters HostFlavorgroup
 type HostFlavorgroup struct {
@@ -77,6 +98,7 @@ type HostFlavorgroupCreateRequest struct {
 //    | connection_string | The host connection string. |
 //    | flavorgroup_names | List of flavor group names that the created host will be associated. |
 //    | description       | Host description. |
+//    | labels            | Key/value labels of the host. Flavor groups with a matching host_label_selector are linked to the host. |
 //
 // x-permissions: hosts:create
 // security:
@@ -125,7 +147,11 @@ type HostFlavorgroupCreateRequest struct {
 //        "host_name": "Purley host1",
 //        "connection_string": "intel:https://trustagent.server.com:1443",
 //        "flavorgroup_names": [""],
-//        "description": "RHEL TPM2.0 Purley"
+//        "description": "RHEL TPM2.0 Purley",
+//        "labels": {
+//            "env": "prod",
+//            "rack": "r1"
+//        }
 //    }
 // x-sample-call-output: |
 //    {
@@ -320,6 +346,122 @@ type HostFlavorgroupCreateRequest struct {
 
 // ---
 
+// swagger:operation POST /hosts/bulk Hosts CreateHostsInBulk
+// ---
+//
+// description: |
+//   <b>Registers hosts in bulk.</b>
+//   <pre>
+//   All the hosts of the request are validated before any of them is registered: each entry must have a host name
+//   and a connection string, and the host names must not be duplicated in the request nor already registered. When
+//   an entry is invalid, the request is rejected with the reasons of all the invalid entries.</br>
+//   The hosts are then registered asynchronously by a job, which connects to each host, links it to its flavor
+//   groups and host unique flavors and finally adds all the registered hosts to the flavor verification queue. The
+//   progress of the job can be polled with GET /jobs/{job_id}. The jobs that are not finished when HVS is stopped are
+//   recorded as FAILED, the hosts that were not registered yet have to be registered again.</br>
+//   At most 1000 hosts can be registered by a request.</br>
+//   </pre>
+//
+//   The hosts are given either as a serialized HostBulkCreateRequest Go struct object, or as a CSV file with a header
+//   row naming its columns. The CSV columns are:
+//
+//    | Column            | Description |
+//    |-------------------|-------------|
+//    | host_name         | HVS name for the host. Mandatory. |
+//    | connection_string | The host connection string. Mandatory. |
+//    | description       | Host description. |
+//    | flavorgroup_names | Flavor group names separated by semicolons. |
+//    | labels            | key=value labels separated by semicolons. |
+//
+//   Returns - The serialized Job Go struct object of the registration job.
+//
+// x-permissions: hosts:create
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// - text/csv
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/HostBulkCreateRequest"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+//     - text/csv
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '202':
+//     description: Successfully started the registration of the hosts.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/Job"
+//   '400':
+//     description: Invalid request body provided
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//   '503':
+//     description: Too many jobs are queued or HVS is stopping, the request can be retried later
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/hosts/bulk
+// x-sample-call-input: |
+//    {
+//        "hosts": [
+//            {
+//                "host_name": "Purley host1",
+//                "connection_string": "intel:https://trustagent1.server.com:1443",
+//                "labels": {
+//                    "rack": "r1"
+//                }
+//            },
+//            {
+//                "host_name": "Purley host2",
+//                "connection_string": "intel:https://trustagent2.server.com:1443",
+//                "flavorgroup_names": ["automatic", "rack_r1"]
+//            }
+//        ]
+//    }
+// x-sample-call-output: |
+//    {
+//        "id": "6b4e0b2c-3d09-4c39-8b7e-3e0e9e5a2f61",
+//        "type": "HOST_BULK_REGISTRATION",
+//        "status": "QUEUED",
+//        "total": 2,
+//        "succeeded": 0,
+//        "failed": 0,
+//        "hosts": [
+//            {
+//                "host_name": "Purley host1",
+//                "status": "QUEUED"
+//            },
+//            {
+//                "host_name": "Purley host2",
+//                "status": "QUEUED"
+//            }
+//        ],
+//        "created": "2021-03-08T10:21:32.351426Z",
+//        "updated": "2021-03-08T10:21:32.351426Z"
+//    }
+
+// ---
+
 // swagger:operation GET /hosts Hosts SearchHost
 // ---
 //
@@ -397,6 +539,22 @@ type HostFlavorgroupCreateRequest struct {
 //      - asc
 //      - desc
 //   required: false
+// - name: labelSelector
+//   description: Selects the hosts by their labels, e.g. "env=prod,rack in (r1,r2),!maintenance". Supported operators are =, ==, !=, in, notin, exists (the key alone) and does not exist (!key). Requirements are comma separated and must all be met.
+//   in: query
+//   type: string
+//   required: false
+// - name: limit
+//   description: Maximum number of hosts in a page of the response. When the page is full, the `next` field of the response links to the following page. All the hosts are returned when not set.
+//   in: query
+//   type: integer
+//   minimum: 1
+//   required: false
+// - name: after
+//   description: Opaque cursor of the last record of the previous page, as given in the `next` link of the previous page.
+//   in: query
+//   type: string
+//   required: false
 // - name: Accept
 //   description: Accept header
 //   in: header
@@ -513,6 +671,86 @@ type HostFlavorgroupCreateRequest struct {
 
 // ---
 
+// swagger:operation POST /hosts/flavorgroups/bulk HostFlavorgroupLinks CreateHostFlavorgroupLinksInBulk
+// ---
+//
+// description: |
+//   Links each of the hosts to all the flavorgroups specified in HostFlavorgroupBulkCreateRequest Go struct object.
+//   All the hosts and flavorgroups must exist, the links that already exist are kept. Once linked, the hosts are
+//   added to the flavor verification queue at once. At most 1000 hosts can be linked by a request.
+//
+//   The serialized HostFlavorgroupBulkCreateRequest Go struct object represents the content of the request body.
+//
+//    | Attribute       | Description |
+//    |-----------------|-------------|
+//    | host_ids        | Unique IDs of the hosts to be linked to the flavorgroups. |
+//    | flavorgroup_ids | Unique IDs of the flavorgroups to be linked to the hosts. |
+//
+//   Returns - The serialized HostFlavorgroupCollection Go struct object of the links that were created.
+//
+// x-permissions: hosts:create
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/HostFlavorgroupBulkCreateRequest"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '201':
+//     description: Successfully created the host flavorgroup links.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/HostFlavorgroupCollection"
+//   '400':
+//     description: Invalid request body provided, or hosts or flavorgroups that do not exist
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/hosts/flavorgroups/bulk
+// x-sample-call-input: |
+//    {
+//        "host_ids": ["fc0cc779-22b6-4741-b0d9-e2e69635ad1e", "2a6b2a1e-6d4c-4c1e-8f0a-0f8e7f1b3c52"],
+//        "flavorgroup_ids": ["c96da83d-b202-49b0-b266-fc6018883e12"]
+//    }
+// x-sample-call-output: |
+//    {
+//        "flavorgroup_host_links": [
+//            {
+//                "host_id": "fc0cc779-22b6-4741-b0d9-e2e69635ad1e",
+//                "flavorgroup_id": "c96da83d-b202-49b0-b266-fc6018883e12"
+//            },
+//            {
+//                "host_id": "2a6b2a1e-6d4c-4c1e-8f0a-0f8e7f1b3c52",
+//                "flavorgroup_id": "c96da83d-b202-49b0-b266-fc6018883e12"
+//            }
+//        ]
+//    }
+
+// ---
+
 // swagger:operation GET /hosts/{host_id}/flavorgroups/{flavorgroup_id} HostFlavorgroupLinks RetrieveHostFlavorgroupLink
 // ---
 //
@@ -649,3 +887,164 @@ type HostFlavorgroupCreateRequest struct {
 //            }
 //        ]
 //    }
+
+// ---
+
+// swagger:operation GET /hosts/{host_id}/labels Hosts RetrieveHostLabels
+// ---
+//
+// description: |
+//   Retrieves the labels of a host.
+//   Returns - The serialized HostLabels Go struct object that was retrieved.
+//
+// x-permissions: hosts:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// parameters:
+// - name: host_id
+//   description: Unique ID of the host.
+//   in: path
+//   type: string
+//   format: uuid
+//   required: true
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the host labels.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/HostLabels"
+//   '404':
+//     description: Host record not found
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/hosts/fc0cc779-22b6-4741-b0d9-e2e69635ad1e/labels
+// x-sample-call-output: |
+//    {
+//        "labels": {
+//            "env": "prod",
+//            "rack": "r1"
+//        }
+//    }
+
+// ---
+
+// swagger:operation PUT /hosts/{host_id}/labels Hosts UpdateHostLabels
+// ---
+//
+// description: |
+//   Replaces the labels of a host. Label keys are an optional DNS subdomain prefix and a name of at most 63 characters
+//   separated by a slash, label values are at most 63 characters. A host can have at most 64 labels.
+//   The flavor groups with a host_label_selector matching the new labels are linked to the host, and the host is
+//   queued for verification. Existing flavor group links are not removed.
+//   Returns - The serialized HostLabels Go struct object that was updated.
+//
+// x-permissions: hosts:store
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// consumes:
+//  - application/json
+// parameters:
+// - name: host_id
+//   description: Unique ID of the host.
+//   in: path
+//   type: string
+//   format: uuid
+//   required: true
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/HostLabels"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully updated the host labels.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/HostLabels"
+//   '400':
+//     description: Invalid request body provided
+//   '404':
+//     description: Host record not found
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/hosts/fc0cc779-22b6-4741-b0d9-e2e69635ad1e/labels
+// x-sample-call-input: |
+//    {
+//        "labels": {
+//            "env": "prod",
+//            "rack": "r1"
+//        }
+//    }
+// x-sample-call-output: |
+//    {
+//        "labels": {
+//            "env": "prod",
+//            "rack": "r1"
+//        }
+//    }
+
+// ---
+
+// swagger:operation DELETE /hosts/{host_id}/labels/{key} Hosts DeleteHostLabel
+// ---
+//
+// description: |
+//   Removes a label from a host. Flavor group links created from the label are not removed.
+// x-permissions: hosts:store
+// security:
+//  - bearerAuth: []
+// parameters:
+// - name: host_id
+//   description: Unique ID of the host.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: key
+//   description: Key of the label to remove.
+//   in: path
+//   required: true
+//   type: string
+// responses:
+//   '204':
+//     description: Successfully deleted the host label.
+//   '400':
+//     description: Invalid label key
+//   '404':
+//     description: Host or label not found
+//   '500':
+//     description: Internal server error
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/hosts/fc0cc779-22b6-4741-b0d9-e2e69635ad1e/labels/rack
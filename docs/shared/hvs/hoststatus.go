@@ -113,13 +113,23 @@ type HostStatusCollection struct {
 //      type: integer
 //      minimum: 1
 //      required: false
+//    - name: labelSelector
+//      description: Returns only the HostStatus records of the hosts whose labels match the selector, e.g. "env=prod,rack in (r1,r2)".
+//      in: query
+//      type: string
+//      required: false
 //    - name: limit
-//      description: Limits the number of HostStatus records in the response.
+//      description: Limits the number of HostStatus records in the response. When the limit is reached, the `next` field of the response links to the following page.
 //      in: query
 //      type: integer
 //      minimum: 1
 //      default: 10000
 //      required: false
+//    - name: after
+//      description: Opaque cursor of the last record of the previous page, as given in the `next` link of the previous page.
+//      in: query
+//      type: string
+//      required: false
 //    - name: Accept
 //      description: Accept header
 //      in: header
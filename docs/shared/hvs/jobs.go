@@ -0,0 +1,85 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvs
+
+import "github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+
+// Job response payload
+// swagger:parameters Job
+type Job struct {
+	// in:body
+	Body hvs.Job
+}
+
+// ---
+
+// swagger:operation GET /jobs/{job_id} Jobs RetrieveJob
+// ---
+//
+// description: |
+//   Retrieves the progress of an asynchronous job, such as the registration of hosts in bulk.
+//   The job status is QUEUED, RUNNING, COMPLETED or FAILED. A job is COMPLETED once all its entries have been
+//   processed, the status of each host and the reason of its failure are given in the hosts list. A job is FAILED
+//   when it could not be run to completion, the reason is given in the error field. The jobs that are not finished
+//   when HVS is stopped are recorded as FAILED when it is started again, with their unfinished entries.
+//   Returns - The serialized Job Go struct object that was retrieved.
+//
+// x-permissions: jobs:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// parameters:
+// - name: job_id
+//   description: Unique ID of the job.
+//   in: path
+//   type: string
+//   format: uuid
+//   required: true
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the job.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/Job"
+//   '404':
+//     description: Job record not found
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/jobs/6b4e0b2c-3d09-4c39-8b7e-3e0e9e5a2f61
+// x-sample-call-output: |
+//    {
+//        "id": "6b4e0b2c-3d09-4c39-8b7e-3e0e9e5a2f61",
+//        "type": "HOST_BULK_REGISTRATION",
+//        "status": "COMPLETED",
+//        "total": 2,
+//        "succeeded": 1,
+//        "failed": 1,
+//        "hosts": [
+//            {
+//                "host_name": "Purley host1",
+//                "status": "COMPLETED",
+//                "host_id": "fc0cc779-22b6-4741-b0d9-e2e69635ad1e"
+//            },
+//            {
+//                "host_name": "Purley host2",
+//                "status": "FAILED",
+//                "error": "Could not fetch TLS certificate from the host"
+//            }
+//        ],
+//        "created": "2021-03-08T10:21:32.351426Z",
+//        "updated": "2021-03-08T10:21:45.103982Z"
+//    }
@@ -0,0 +1,422 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvs
+
+import "github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+
+// NotificationSubscription request/response payload
+// swagger:parameters NotificationSubscription
+type NotificationSubscription struct {
+	// in:body
+	Body hvs.NotificationSubscription
+}
+
+// NotificationSubscriptionCollection response payload
+// swagger:parameters NotificationSubscriptionCollection
+type NotificationSubscriptionCollection struct {
+	// in:body
+	Body hvs.NotificationSubscriptionCollection
+}
+
+// NotificationDeadLetterCollection response payload
+// swagger:parameters NotificationDeadLetterCollection
+type NotificationDeadLetterCollection struct {
+	// in:body
+	Body hvs.NotificationDeadLetterCollection
+}
+
+// NotificationEvent response payload
+// swagger:parameters NotificationEvent
+type NotificationEvent struct {
+	// in:body
+	Body hvs.NotificationEvent
+}
+
+// ---
+//
+// swagger:operation POST /notification-subscriptions NotificationSubscriptions CreateNotificationSubscription
+// ---
+//
+// description: |
+//   Registers a webhook that is called whenever the trust status of a host, the trust status of one of its flavor
+//   parts or its connection state changes. Events are posted as JSON (see NotificationEvent) to the callback URL.
+//
+//   Every delivery carries the following headers:
+//    X-Hvs-Event-Id       The event ID, identical across retries of the same event
+//    X-Hvs-Event-Type     HOST_TRUST_CHANGED, FLAVOR_PART_TRUST_CHANGED or HOST_STATE_CHANGED
+//    X-Hvs-Timestamp      Unix time at which the delivery was signed
+//    X-Hvs-Signature      "sha384=" followed by the hex encoded HMAC-SHA384 of "<X-Hvs-Timestamp>.<request body>",
+//                         keyed with the subscription secret
+//
+//   Deliveries answered with a non 2xx status code are retried with exponential backoff. Events that still cannot be
+//   delivered are kept as dead letters, see GET /notification-dead-letters.
+//
+//   The serialized NotificationSubscription Go struct object represents the content of the request body.
+//
+//    | Attribute    | Description |
+//    |--------------|-------------|
+//    | callback_url | HTTPS URL events are posted to. |
+//    | secret       | Shared secret used to sign the deliveries, at least 16 characters. It is never returned by the API. |
+//    | event_types  | (Optional) Event types to deliver. All event types are delivered if not specified. |
+//    | host_ids     | (Optional) Hosts to deliver events for. Events of all hosts are delivered if not specified. |
+//    | flavor_parts | (Optional) Flavor parts to deliver FLAVOR_PART_TRUST_CHANGED events for. |
+//
+// x-permissions: notification_subscriptions:create
+// security:
+//  - bearerAuth: []
+// consumes:
+//  - application/json
+// produces:
+//  - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/NotificationSubscription"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '201':
+//     description: Successfully created the notification subscription.
+//     schema:
+//       "$ref": "#/definitions/NotificationSubscription"
+//   '400':
+//     description: Invalid request body provided
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/notification-subscriptions
+// x-sample-call-input: |
+//    {
+//        "callback_url": "https://siem.example.com/hvs/events",
+//        "secret": "1c0d2b7a9e8f4c35b6d1",
+//        "event_types": ["HOST_TRUST_CHANGED", "FLAVOR_PART_TRUST_CHANGED"]
+//    }
+// x-sample-call-output: |
+//    {
+//        "id": "3c1a0b5e-4d6f-4a1e-9d2b-8f1e2c3d4a5b",
+//        "callback_url": "https://siem.example.com/hvs/events",
+//        "event_types": ["HOST_TRUST_CHANGED", "FLAVOR_PART_TRUST_CHANGED"],
+//        "created": "2021-03-10T11:32:07.254163Z"
+//    }
+// ---
+
+// ---
+//
+// swagger:operation GET /notification-subscriptions NotificationSubscriptions SearchNotificationSubscriptions
+// ---
+//
+// description: |
+//   Searches for notification subscriptions. Secrets are not returned.
+//   Returns - The serialized NotificationSubscriptionCollection Go struct object that was retrieved.
+//
+// x-permissions: notification_subscriptions:search
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// parameters:
+// - name: id
+//   description: Notification subscription ID
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: callbackUrl
+//   description: Callback URL of the subscription
+//   in: query
+//   type: string
+//   required: false
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully searched the notification subscriptions.
+//     schema:
+//       "$ref": "#/definitions/NotificationSubscriptionCollection"
+//   '400':
+//     description: Invalid values for search criteria
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/notification-subscriptions
+// x-sample-call-output: |
+//    {
+//        "notification_subscriptions": [
+//            {
+//                "id": "3c1a0b5e-4d6f-4a1e-9d2b-8f1e2c3d4a5b",
+//                "callback_url": "https://siem.example.com/hvs/events",
+//                "event_types": ["HOST_TRUST_CHANGED", "FLAVOR_PART_TRUST_CHANGED"],
+//                "created": "2021-03-10T11:32:07.254163Z"
+//            }
+//        ]
+//    }
+// ---
+
+// ---
+//
+// swagger:operation GET /notification-subscriptions/{subscription_id} NotificationSubscriptions RetrieveNotificationSubscription
+// ---
+//
+// description: |
+//   Retrieves a notification subscription. The secret is not returned.
+//
+// x-permissions: notification_subscriptions:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// parameters:
+// - name: subscription_id
+//   description: Unique ID of the notification subscription.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the notification subscription.
+//     schema:
+//       "$ref": "#/definitions/NotificationSubscription"
+//   '404':
+//     description: No relevant notification subscription found
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/notification-subscriptions/3c1a0b5e-4d6f-4a1e-9d2b-8f1e2c3d4a5b
+// ---
+
+// ---
+//
+// swagger:operation DELETE /notification-subscriptions/{subscription_id} NotificationSubscriptions DeleteNotificationSubscription
+// ---
+//
+// description: |
+//   Deletes a notification subscription and its dead letters.
+//
+// x-permissions: notification_subscriptions:delete
+// security:
+//  - bearerAuth: []
+// parameters:
+// - name: subscription_id
+//   description: Unique ID of the notification subscription.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// responses:
+//   '204':
+//     description: Successfully deleted the notification subscription.
+//   '404':
+//     description: No relevant notification subscription found
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/notification-subscriptions/3c1a0b5e-4d6f-4a1e-9d2b-8f1e2c3d4a5b
+// ---
+
+// ---
+//
+// swagger:operation GET /notification-dead-letters NotificationDeadLetters SearchNotificationDeadLetters
+// ---
+//
+// description: |
+//   Searches for events that could not be delivered to a webhook after the configured number of attempts.
+//   Returns - The serialized NotificationDeadLetterCollection Go struct object that was retrieved, latest first.
+//
+// x-permissions: notification_dead_letters:search
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// parameters:
+// - name: id
+//   description: Dead letter ID
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: subscriptionId
+//   description: Notification subscription ID
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: limit
+//   description: Limits the number of records in the response.
+//   in: query
+//   type: integer
+//   minimum: 1
+//   default: 10000
+//   required: false
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully searched the notification dead letters.
+//     schema:
+//       "$ref": "#/definitions/NotificationDeadLetterCollection"
+//   '400':
+//     description: Invalid values for search criteria
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/notification-dead-letters?subscriptionId=3c1a0b5e-4d6f-4a1e-9d2b-8f1e2c3d4a5b
+// x-sample-call-output: |
+//    {
+//        "notification_dead_letters": [
+//            {
+//                "id": "9a8b7c6d-5e4f-4a3b-8c1d-0e9f8a7b6c5d",
+//                "subscription_id": "3c1a0b5e-4d6f-4a1e-9d2b-8f1e2c3d4a5b",
+//                "callback_url": "https://siem.example.com/hvs/events",
+//                "event": {
+//                    "id": "1b2c3d4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e",
+//                    "type": "HOST_TRUST_CHANGED",
+//                    "host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//                    "host_name": "computepurley1",
+//                    "previous_trusted": true,
+//                    "trusted": false,
+//                    "created": "2021-03-10T11:40:12.482731Z"
+//                },
+//                "attempts": 5,
+//                "last_error": "Webhook responded with status code 503",
+//                "created": "2021-03-10T11:41:27.103512Z"
+//            }
+//        ]
+//    }
+// ---
+
+// ---
+//
+// swagger:operation DELETE /notification-dead-letters/{dead_letter_id} NotificationDeadLetters DeleteNotificationDeadLetter
+// ---
+//
+// description: |
+//   Deletes a notification dead letter.
+//
+// x-permissions: notification_dead_letters:delete
+// security:
+//  - bearerAuth: []
+// parameters:
+// - name: dead_letter_id
+//   description: Unique ID of the dead letter.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// responses:
+//   '204':
+//     description: Successfully deleted the dead letter.
+//   '404':
+//     description: No relevant dead letter found
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/notification-dead-letters/9a8b7c6d-5e4f-4a3b-8c1d-0e9f8a7b6c5d
+// ---
+
+// ---
+//
+// swagger:operation GET /notifications/stream Notifications StreamNotifications
+// ---
+//
+// description: |
+//   Opens a Server-Sent Events stream of notification events. Each event is sent with the event ID as "id", the
+//   event type as "event" and the serialized NotificationEvent Go struct object as "data". A comment line is sent
+//   every 15 seconds while the stream is idle. The stream is closed once the server write timeout has elapsed and
+//   clients are expected to reconnect. Events published while the client is disconnected are not replayed, clients
+//   that require guaranteed delivery should register a webhook instead.
+//
+//   Every filter parameter can be repeated or contain a comma separated list of values.
+//
+// x-permissions: notifications:stream
+// security:
+//  - bearerAuth: []
+// produces:
+//  - text/event-stream
+// parameters:
+// - name: eventType
+//   description: Event types to stream
+//   in: query
+//   type: string
+//   enum:
+//     - HOST_TRUST_CHANGED
+//     - FLAVOR_PART_TRUST_CHANGED
+//     - HOST_STATE_CHANGED
+//   required: false
+// - name: hostId
+//   description: Host UUID
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: flavorPart
+//   description: Flavor part of FLAVOR_PART_TRUST_CHANGED events
+//   in: query
+//   type: string
+//   required: false
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - text/event-stream
+// responses:
+//   '200':
+//     description: Event stream opened.
+//     schema:
+//       "$ref": "#/definitions/NotificationEvent"
+//   '400':
+//     description: Invalid values for filter criteria
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/notifications/stream?eventType=HOST_TRUST_CHANGED
+// x-sample-call-output: |
+//    id: 1b2c3d4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e
+//    event: HOST_TRUST_CHANGED
+//    data: {"id":"1b2c3d4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e","type":"HOST_TRUST_CHANGED","host_id":"ee37c360-7eae-4250-a677-6ee12adce8e2","host_name":"computepurley1","report_id":"a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d","previous_trusted":true,"trusted":false,"created":"2021-03-10T11:40:12.482731Z"}
+// ---
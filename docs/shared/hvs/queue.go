@@ -0,0 +1,138 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvs
+
+import "github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+
+// VerificationQueueEntryCollection response payload
+// swagger:parameters VerificationQueueEntryCollection
+type VerificationQueueEntryCollection struct {
+	// in:body
+	Body hvs.VerificationQueueEntryCollection
+}
+
+// ---
+
+// swagger:operation GET /queue Queue SearchQueue
+// ---
+//
+// description: |
+//   Lists the host trust verifications waiting or in progress in the verification queue of HVS. The queue is
+//   persisted, the verifications left in it when HVS is stopped are resumed when it is started again.
+//   The stage of an entry is the progress of its verification:
+//
+//    | Stage                  | Description                                                    |
+//    |------------------------|----------------------------------------------------------------|
+//    | GET_HOST_DATA_QUEUED   | Waiting for the host data to be fetched from the host          |
+//    | GET_HOST_DATA_STARTED  | Fetching the host data, retried while the host is unreachable  |
+//    | FLAVOR_VERIFY_QUEUED   | Waiting for the host data to be verified against the flavors   |
+//    | FLAVOR_VERIFY_STARTED  | Verifying the host data and creating the report                |
+//
+//   The verifications requested through the API have a HIGH priority and are processed ahead of the LOW priority
+//   refreshes of the expiring reports. The entries are listed in the order in which they are processed, by priority
+//   and then by creation time.
+//   Returns - The serialized VerificationQueueEntryCollection Go struct object.
+//
+// x-permissions: queue:search
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// parameters:
+// - name: stage
+//   description: Stage of the verification.
+//   in: query
+//   type: string
+//   required: false
+//   enum: [GET_HOST_DATA_QUEUED, GET_HOST_DATA_STARTED, FLAVOR_VERIFY_QUEUED, FLAVOR_VERIFY_STARTED]
+// - name: hostId
+//   description: Host ID of the verification.
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: olderThan
+//   description: Selects the verifications queued for longer than the duration, for instance 15m or 2h.
+//   in: query
+//   type: string
+//   required: false
+// - name: priority
+//   description: Priority of the verification.
+//   in: query
+//   type: string
+//   required: false
+//   enum: [HIGH, LOW]
+// - name: limit
+//   description: Maximum number of entries returned, 10000 by default.
+//   in: query
+//   type: integer
+//   required: false
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully searched the verification queue.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/VerificationQueueEntryCollection"
+//   '400':
+//     description: Invalid search criteria provided
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/queue?olderThan=30m
+// x-sample-call-output: |
+//    {
+//        "queue_entries": [
+//            {
+//                "id": "4d1e3a0e-5f7c-4b73-9f3e-2b5b8f1f6c2a",
+//                "host_id": "fc0cc779-22b6-4741-b0d9-e2e69635ad1e",
+//                "stage": "GET_HOST_DATA_STARTED",
+//                "priority": "LOW",
+//                "fetch_host_data": true,
+//                "prefer_hash_match": true,
+//                "created": "2021-03-08T10:21:32.351426Z",
+//                "updated": "2021-03-08T10:21:33.103982Z"
+//            }
+//        ]
+//    }
+// ---
+
+// swagger:operation DELETE /queue/{queue_id} Queue CancelVerification
+// ---
+//
+// description: |
+//   Cancels a queued host trust verification and removes it from the queue. Only the verifications in the
+//   GET_HOST_DATA_QUEUED and FLAVOR_VERIFY_QUEUED stages can be cancelled, the verifications that are fetching the
+//   host data or verifying it have already started.
+// x-permissions: queue:delete
+// security:
+//  - bearerAuth: []
+// parameters:
+// - name: queue_id
+//   description: Unique ID of the queue entry.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// responses:
+//   '204':
+//     description: Successfully cancelled the verification.
+//   '404':
+//     description: Queue entry not found
+//   '409':
+//     description: The verification has already started
+//   '500':
+//     description: Internal server error
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/queue/4d1e3a0e-5f7c-4b73-9f3e-2b5b8f1f6c2a
+// ---
@@ -28,6 +28,27 @@ type ReportCreateRequest struct {
 	Body hvs.ReportCreateRequest
 }
 
+// ReportDiff response payload
+// swagger:parameters ReportDiff
+type ReportDiff struct {
+	// in:body
+	Body hvs.ReportDiff
+}
+
+// ReportExportRow response payload
+// swagger:parameters ReportExportRow
+type ReportExportRow struct {
+	// in:body
+	Body hvs.ReportExportRow
+}
+
+// SignedComplianceReport response payload
+// swagger:parameters SignedComplianceReport
+type SignedComplianceReport struct {
+	// in:body
+	Body hvs.SignedComplianceReport
+}
+
 // ---
 
 // swagger:operation GET /reports Reports Search-Reports
@@ -117,6 +138,11 @@ type ReportCreateRequest struct {
 //   type: boolean
 //   required: false
 //   default: true
+// - name: labelSelector
+//   description: Returns only the reports of the hosts whose labels match the selector, e.g. "env=prod,rack in (r1,r2)".
+//   in: query
+//   type: string
+//   required: false
 // - name: limit
 //   description: This limits the overall number of results (all hosts included).
 //   in: query
@@ -420,3 +446,359 @@ type ReportCreateRequest struct {
 //       "expiration": "2018-07-23T17:39:52-0700"
 //     }
 //   }
+
+// ---
+
+// swagger:operation GET /reports/{report_id}/diff Reports Diff-Report
+// ---
+//
+// description: |
+//   Compares a report with an older report of the same host and returns what changed between them.
+//   Reports that were replaced by a newer report of the host are looked up in the report history.
+//
+//   The diff contains the rules that were added, removed or whose outcome changed, along with the faults and
+//   mismatch fields that were added or resolved, the PCR values that changed per PCR bank, the event log entries
+//   that were added, removed or modified per PCR and the host info fields of the host manifest that changed.
+//   Entries that are the same in both reports are omitted.
+//   Returns - The serialized ReportDiff Go struct object.
+// x-permissions: reports:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: report_id
+//   description: Unique ID of the Report.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: against
+//   description: |
+//     ID of the report to compare against, or 'previous' to compare against the latest report of the host
+//     created before the report. Defaults to 'previous'.
+//   in: query
+//   type: string
+//   required: false
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully computed the Report diff.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/ReportDiff"
+//   '400':
+//     description: Invalid against parameter provided or the reports belong to different hosts.
+//   '404':
+//     description: No relevant report record found, or no report to compare against found.
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error.
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/reports/8a545a4f-d282-4d91-8ec5-bcbe439dcfbc/diff?against=previous
+// x-sample-call-output: |
+//   {
+//     "report_id": "8a545a4f-d282-4d91-8ec5-bcbe439dcfbc",
+//     "against_report_id": "2cf28f1e-7e48-4b27-a8b3-fd39a5c4a1f7",
+//     "host_id": "94824cb6-d6c8-4faf-83b0-125996ceebe2",
+//     "trusted": false,
+//     "against_trusted": true,
+//     "rule_results": [
+//       {
+//         "change": "CHANGED",
+//         "rule_name": "com.intel.mtwilson.core.verifier.policy.rule.PcrMatchesConstant",
+//         "markers": [
+//           "PLATFORM"
+//         ],
+//         "pcr": {
+//           "index": 0,
+//           "bank": "SHA256"
+//         },
+//         "flavor_id": "a774ddad-fca1-4670-86b2-605c88a16dab",
+//         "against_flavor_id": "a774ddad-fca1-4670-86b2-605c88a16dab",
+//         "trusted": false,
+//         "against_trusted": true,
+//         "faults_added": [
+//           {
+//             "fault_name": "com.intel.mtwilson.core.verifier.policy.fault.PcrValueMismatchSHA256",
+//             "description": "Host PCR 0 with value '5e0a7e1e6fd7a7ae1e2e3d0e8b2c0f3a4f0c56df94f66a1d4f42b5e16c8c2bd4' does not match expected value 'b2b2a5a4d4c3f0e1d5e7b1c2a3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f6'",
+//             "pcr_index": "pcr_0",
+//             "expected_pcr": { ... },
+//             "actual_pcr": { ... }
+//           }
+//         ]
+//       }
+//     ],
+//     "pcr_values": [
+//       {
+//         "change": "CHANGED",
+//         "pcr_bank": "SHA256",
+//         "pcr_index": "pcr_0",
+//         "value": "5e0a7e1e6fd7a7ae1e2e3d0e8b2c0f3a4f0c56df94f66a1d4f42b5e16c8c2bd4",
+//         "against_value": "b2b2a5a4d4c3f0e1d5e7b1c2a3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f6"
+//       }
+//     ],
+//     "host_manifest": [
+//       {
+//         "change": "CHANGED",
+//         "field": "host_info.bios_version",
+//         "value": "SE5C620.86B.00.01.0016.020120190930",
+//         "against_value": "SE5C620.86B.00.01.0015.110720180833"
+//       }
+//     ]
+//   }
+
+// ---
+
+// swagger:operation GET /reports/export Reports Export-Reports
+// ---
+//
+// description: |
+//   Streams the reports matching the search criteria for audit evidence, flattened in one row per host per rule.
+//   Each row holds the report, the host, the overall trust status of the report, and the name, flavor part, flavor,
+//   outcome and faults of a rule. A report without rule results is exported as a single row without rule.
+//
+//   The reports are exported in CSV (text/csv), with a header line and the faults separated by semicolons, or in
+//   JSON-lines (application/x-ndjson), with one ReportExportRow JSON document per line.
+//   The search parameters are the same as the ones of the report search API. To export all the reports of a time
+//   window, and not only the latest report of each host, set latestPerHost to false. The export is truncated when it
+//   does not complete within the server write timeout, large time windows should be exported in several requests.
+// x-permissions: reports:search
+// security:
+//  - bearerAuth: []
+// produces:
+//  - text/csv
+//  - application/x-ndjson
+// parameters:
+// - name: hostId
+//   description: Exports only the reports of the host with the specified host id.
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: hostName
+//   description: Exports only the reports of the host with the specified host name.
+//   in: query
+//   type: string
+//   required: false
+// - name: hostHardwareId
+//   description: Exports only the reports of the host with the specified host hardware uuid.
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: labelSelector
+//   description: Exports only the reports of the hosts whose labels match the selector, e.g. "env=prod,rack in (r1,r2)".
+//   in: query
+//   type: string
+//   required: false
+// - name: numberOfDays
+//   description: Exports the reports created between the current date and number of days prior. min 0, max 365.
+//   in: query
+//   type: integer
+//   required: false
+// - name: fromDate
+//   description: Exports the reports created after this date, in the date formats of the report search API.
+//   in: query
+//   type: string
+//   format: date-time
+//   required: false
+// - name: toDate
+//   description: Exports the reports created before this date, in the date formats of the report search API.
+//   in: query
+//   type: string
+//   format: date-time
+//   required: false
+// - name: latestPerHost
+//   description: Exports only the latest report for each host.
+//   in: query
+//   type: boolean
+//   required: false
+//   default: true
+// - name: limit
+//   description: This limits the overall number of reports exported (all hosts included).
+//   in: query
+//   type: integer
+//   required: false
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - text/csv
+//     - application/x-ndjson
+// responses:
+//   '200':
+//     description: Successfully exported the reports.
+//     content:
+//       application/x-ndjson
+//     schema:
+//       $ref: "#/definitions/ReportExportRow"
+//   '400':
+//     description: Invalid search criteria provided
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/reports/export?numberOfDays=30&latestPerHost=false
+// x-sample-call-output: |
+//   report_id,host_id,host_name,hardware_uuid,created,expiration,trusted,flavor_part,rule_name,rule_trusted,flavor_id,fault_names,fault_descriptions
+//   8a545a4f-d282-4d91-8ec5-bcbe439dcfbc,94824cb6-d6c8-4faf-83b0-125996ceebe2,computepurley1,00e4d709-8d72-44c3-89ae-c5edc395d6fe,2020-06-21T07:18:00Z,2020-06-22T07:18:00Z,false,PLATFORM,com.intel.mtwilson.core.verifier.policy.rule.PcrMatchesConstant,false,a774ddad-fca1-4670-86b2-605c88a16dab,com.intel.mtwilson.core.verifier.policy.fault.PcrValueMismatchSHA256,Host PCR 0 with value '5e0a...' does not match expected value 'b2b2...'
+//   8a545a4f-d282-4d91-8ec5-bcbe439dcfbc,94824cb6-d6c8-4faf-83b0-125996ceebe2,computepurley1,00e4d709-8d72-44c3-89ae-c5edc395d6fe,2020-06-21T07:18:00Z,2020-06-22T07:18:00Z,false,OS,com.intel.mtwilson.core.verifier.policy.rule.PcrMatchesConstant,true,b37580d8-a2b5-4e8f-9d9d-7e1c8d0f6a3c,,
+
+// ---
+
+// swagger:operation GET /reports/compliance Reports Compliance-Report
+// ---
+//
+// description: |
+//   Summarizes the trust status of the hosts matching the host selector for compliance audits, from the latest
+//   report of each host in the time window. The current reports of the hosts are summarized when no time window is
+//   given. The compliance report contains:
+//     - the number of hosts trusted, untrusted and never attested,
+//     - the number of hosts trusted and untrusted per flavor part,
+//     - the rules that failed for the most hosts, along with their faults,
+//     - the untrusted hosts, and the hosts never attested, that have no report in the time window.
+//
+//   The compliance report is returned in JSON, along with its signature and the certificate of the SAML signing
+//   key it is signed with. It can also be rendered in a HTML (text/html) or PDF (application/pdf) document, the
+//   base64 encoded signature of the document is then returned in the X-Signature response header. The signatures
+//   are RSA PKCS#1 v1.5 signatures of the SHA-384 hash of the JSON encoded report or of the document, and can be
+//   verified with the SAML certificate of the Host Verification Service, so that the reports are tamper-evident.
+// x-permissions: reports:search
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+//  - text/html
+//  - application/pdf
+// parameters:
+// - name: hostId
+//   description: Summarizes only the host with the specified host id.
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: hostName
+//   description: Summarizes only the host with the specified host name.
+//   in: query
+//   type: string
+//   required: false
+// - name: hostHardwareId
+//   description: Summarizes only the host with the specified host hardware uuid.
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: labelSelector
+//   description: Summarizes only the hosts whose labels match the selector, e.g. "env=prod,rack in (r1,r2)".
+//   in: query
+//   type: string
+//   required: false
+// - name: numberOfDays
+//   description: The time window is between the current date and number of days prior. min 0, max 365.
+//   in: query
+//   type: integer
+//   required: false
+// - name: fromDate
+//   description: Start of the time window, in the date formats of the report search API.
+//   in: query
+//   type: string
+//   format: date-time
+//   required: false
+// - name: toDate
+//   description: End of the time window, in the date formats of the report search API.
+//   in: query
+//   type: string
+//   format: date-time
+//   required: false
+// - name: top
+//   description: Maximum number of failing rules listed. min 0, max 100.
+//   in: query
+//   type: integer
+//   required: false
+//   default: 10
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+//     - text/html
+//     - application/pdf
+// responses:
+//   '200':
+//     description: Successfully created the compliance report.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/SignedComplianceReport"
+//   '400':
+//     description: Invalid host selector or time window provided
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/reports/compliance?labelSelector=env%3Dprod&numberOfDays=30
+// x-sample-call-output: |
+//   {
+//     "report": {
+//       "created": "2021-03-01T10:00:00Z",
+//       "from_date": "2021-01-30T10:00:00Z",
+//       "to_date": "2021-03-01T10:00:00Z",
+//       "host_selector": "labelSelector=env=prod",
+//       "host_count": 3,
+//       "trusted_host_count": 1,
+//       "untrusted_host_count": 1,
+//       "never_attested_host_count": 1,
+//       "flavor_parts": [
+//         {
+//           "flavor_part": "PLATFORM",
+//           "trusted_count": 1,
+//           "untrusted_count": 1
+//         },
+//         {
+//           "flavor_part": "OS",
+//           "trusted_count": 2,
+//           "untrusted_count": 0
+//         }
+//       ],
+//       "top_failing_rules": [
+//         {
+//           "rule_name": "com.intel.mtwilson.core.verifier.policy.rule.PcrMatchesConstant",
+//           "flavor_part": "PLATFORM",
+//           "host_count": 1,
+//           "fault_names": [
+//             "com.intel.mtwilson.core.verifier.policy.fault.PcrValueMismatchSHA256"
+//           ]
+//         }
+//       ],
+//       "untrusted_hosts": [
+//         {
+//           "host_id": "94824cb6-d6c8-4faf-83b0-125996ceebe2",
+//           "host_name": "computepurley1",
+//           "report_id": "8a545a4f-d282-4d91-8ec5-bcbe439dcfbc",
+//           "last_attested": "2021-03-01T09:12:45Z"
+//         }
+//       ],
+//       "hosts_never_attested": [
+//         {
+//           "host_id": "4b34f4a6-8ea4-4b4c-a0c3-5b5e1f2c8a10",
+//           "host_name": "computepurley3"
+//         }
+//       ]
+//     },
+//     "signature": "ZGVhZGJlZWY...",
+//     "signing_certificate": "-----BEGIN CERTIFICATE-----\nMIIEJjCCAo6gAwIBAgIBADANBgkqhkiG9w0BAQwFADA...\n-----END CERTIFICATE-----\n"
+//   }
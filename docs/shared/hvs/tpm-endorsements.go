@@ -72,6 +72,17 @@ type TpmEndorsementCollection struct {
 //     in: query
 //     type: string
 //     required: false
+//   - name: limit
+//     description: Maximum number of TPM endorsements in a page of the response. When the page is full, the `next` field of the response links to the following page. All the TPM endorsements are returned when not set.
+//     in: query
+//     type: integer
+//     minimum: 1
+//     required: false
+//   - name: after
+//     description: Opaque cursor of the last record of the previous page, as given in the `next` link of the previous page.
+//     in: query
+//     type: string
+//     required: false
 //   - name: Accept
 //     description: Accept header
 //     in: header
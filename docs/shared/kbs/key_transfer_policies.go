@@ -46,6 +46,7 @@ type KeyTransferPolicyCollection struct {
 //    | tls_client_certificate_san_allof             | Array of Subject Alternative Name to expect in client certificate's extensions. Expect client certificate to have all of these names. |
 //    | attestation_type_anyof                       | Array of Attestation Type identifiers that client must support to get the key expect client to advertise these with the key request e.g. "SGX", "KPT2" (note that if key server needs to restrict technologies, then it should list only the ones that can receive the key). |
 //    | sgx_enforce_tcb_up_to_date                   | Boolean. |
+//    | key_rotation_interval_days                   | (Optional) Number of days after which keys using this policy are rotated automatically. Keys are not rotated automatically if not set. |
 //
 // x-permissions: keys-transfer-policies:create
 // security:
@@ -184,7 +184,8 @@ type KeyTransferAttributes struct {
 // ---
 //
 // description: |
-//   Transfers a key.
+//   Transfers a key. The latest version of the key is transferred unless a version is requested,
+//   versions replaced by a rotation can be transferred until the end of their grace period.
 //   Returns - The serialized KeyTransferAttributes Go struct object that was retrieved.
 // x-permissions: keys:transfer
 // security:
@@ -200,6 +201,11 @@ type KeyTransferAttributes struct {
 //   required: true
 //   type: string
 //   format: uuid
+// - name: version
+//   description: Version of the key to transfer, a positive integer or 'latest'. Defaults to 'latest'.
+//   in: query
+//   type: string
+//   required: false
 // - name: Content-Type
 //   description: Content-Type header
 //   in: header
@@ -221,8 +227,10 @@ type KeyTransferAttributes struct {
 //       application/json
 //     schema:
 //       $ref: "#/definitions/KeyTransferAttributes"
+//   '400':
+//     description: Invalid request body or version provided
 //   '404':
-//     description: Key record not found
+//     description: Key record or key version not found
 //   '415':
 //     description: Invalid Content-Type/Accept Header in Request
 //   '500':
@@ -244,7 +252,76 @@ type KeyTransferAttributes struct {
 // x-sample-call-output: |
 //    {
 //        "id": "fc0cc779-22b6-4741-b0d9-e2e69635ad1e",
-//        "payload": "F+nUVyejh2Cp0wkLFvqNkhBydtnKY8v5eJ5zbl9gHoPbqvjwuSafx4LwnHOT6DJDqa8LO5ufVyLqqXVfyAdf88s1VnKLCE0Udbn8Zjnq4CHnR2KqDPWTauYLnuYJH2lVGf4Ke4mTcvOfBO9YRTop0WzfTBSuEFKrAsE67ERogtCvD7hf5LhJ2sxv0ej48uZ5KLHRVAzbWMttRZXbL10xTC+dZM9SIAWg2s0aq7Mb49h2rcaI307e3GQgsXhbopwSTC7L7Sy1RYUf4XvHl+/XMmVmvKWjOFIfOXTg8cA+COTBjzOQXVJiXF/xv5/idny0sOeyebFfnxfj7ZXJhqT8pYtiyRm0kzU35jtFTpJR8+aMkOjI/4KdbM6zoY+7JiRD2A0VNEAvQzEoKnY2H9/fIRlkYLtjCI/n5CSPg5Ap0wghqZAmmCeaOH48D0NgjpVQPhc/OQHq/k0HRUXvmUgQe/D4T3WIUdJCctSBGsjIn3WrusH+cb5eaof5Aqq7NT4W"
+//        "payload": "F+nUVyejh2Cp0wkLFvqNkhBydtnKY8v5eJ5zbl9gHoPbqvjwuSafx4LwnHOT6DJDqa8LO5ufVyLqqXVfyAdf88s1VnKLCE0Udbn8Zjnq4CHnR2KqDPWTauYLnuYJH2lVGf4Ke4mTcvOfBO9YRTop0WzfTBSuEFKrAsE67ERogtCvD7hf5LhJ2sxv0ej48uZ5KLHRVAzbWMttRZXbL10xTC+dZM9SIAWg2s0aq7Mb49h2rcaI307e3GQgsXhbopwSTC7L7Sy1RYUf4XvHl+/XMmVmvKWjOFIfOXTg8cA+COTBjzOQXVJiXF/xv5/idny0sOeyebFfnxfj7ZXJhqT8pYtiyRm0kzU35jtFTpJR8+aMkOjI/4KdbM6zoY+7JiRD2A0VNEAvQzEoKnY2H9/fIRlkYLtjCI/n5CSPg5Ap0wghqZAmmCeaOH48D0NgjpVQPhc/OQHq/k0HRUXvmUgQe/D4T3WIUdJCctSBGsjIn3WrusH+cb5eaof5Aqq7NT4W",
+//        "version": 1
+//    }
+
+// ---
+
+// swagger:operation POST /keys/{id}/rotate Keys RotateKey
+// ---
+//
+// description: |
+//   Rotates a key. New key material is created in the backing key manager and becomes the latest version of the key,
+//   the key ID and transfer link do not change. The replaced version remains available for transfer until the key
+//   rotation grace period configured for the service ends.
+//
+//   Keys are also rotated automatically when the key_rotation_interval_days of their key transfer policy has elapsed
+//   since the last rotation.
+//   Returns - The serialized KeyResponse Go struct object of the rotated key.
+// x-permissions: keys:rotate
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: id
+//   description: Unique ID of the key.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully rotated the key.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/KeyResponse"
+//   '404':
+//     description: Key record not found
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://kbs.com:9443/kbs/v1/keys/fc0cc779-22b6-4741-b0d9-e2e69635ad1e/rotate
+// x-sample-call-output: |
+//    {
+//        "key_information": {
+//            "id": "fc0cc779-22b6-4741-b0d9-e2e69635ad1e",
+//            "algorithm": "AES",
+//            "key_length": 256
+//        },
+//        "transfer_policy_id": "3ce27bbd-3c5f-4b15-8c0a-44310f0f83d9",
+//        "transfer_link": "https://kbs.com:9443/kbs/v1/keys/fc0cc779-22b6-4741-b0d9-e2e69635ad1e/transfer",
+//        "created_at": "2021-03-01T10:36:24.163931Z",
+//        "version": 2,
+//        "rotated_at": "2021-03-08T09:12:51.702113Z",
+//        "previous_versions": [
+//            {
+//                "version": 1,
+//                "created_at": "2021-03-01T10:36:24.163931Z",
+//                "expires_at": "2021-03-15T09:12:51.702113Z"
+//            }
+//        ]
 //    }
 
 // ---
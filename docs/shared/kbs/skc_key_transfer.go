@@ -33,6 +33,11 @@ type KeyTransferResponse struct {
 //   required: true
 //   type: string
 //   format: uuid
+// - name: version
+//   description: Version of the key to transfer, a positive integer or 'latest'. Defaults to 'latest'.
+//   in: query
+//   type: string
+//   required: false
 // - name: Accept-Challenge
 //   description: SKC Challenge Type
 //   in: header
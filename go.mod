@@ -16,6 +16,7 @@ require (
 	github.com/onsi/ginkgo v1.13.0
 	github.com/onsi/gomega v1.10.1
 	github.com/pkg/errors v0.9.1
+	github.com/prometheus/client_golang v0.9.3
 	github.com/russellhaering/goxmldsig v1.1.0
 	github.com/sirupsen/logrus v1.4.0
 	github.com/spf13/viper v1.7.0
@@ -19,6 +19,8 @@ func init() {
 	viper.SetDefault("tls-key-file", constants.DefaultTLSKeyFile)
 	viper.SetDefault("tls-common-name", constants.DefaultAasTlsCn)
 	viper.SetDefault("tls-san-list", constants.DefaultAasTlsSan)
+	viper.SetDefault("tls-key-algorithm", constants.DefaultKeyAlgorithm)
+	viper.SetDefault("tls-key-length", constants.DefaultKeyLength)
 
 	// set default values for log
 	viper.SetDefault("log-max-length", constants.DefaultLogEntryMaxLength)
@@ -46,6 +48,8 @@ func init() {
 	//set default for JWT and JWT signing cert
 	viper.SetDefault("jwt-include-kid", true)
 	viper.SetDefault("jwt-cert-common-name", constants.DefaultAasJwtCn)
+	viper.SetDefault("jwt-key-algorithm", constants.DefaultKeyAlgorithm)
+	viper.SetDefault("jwt-key-length", constants.DefaultKeyLength)
 	viper.SetDefault("jwt-token-duration-mins", constants.DefaultAasJwtDurationMins)
 
 	viper.SetDefault("auth-defender-max-attempts", constants.DefaultAuthDefendMaxAttempts)
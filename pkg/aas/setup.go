@@ -132,8 +132,8 @@ func (a *App) setupTaskRunner() (*setup.Runner, error) {
 	runner.AddTask("download-cert-tls", "tls", &setup.DownloadCert{
 		KeyFile:      viper.GetString("tls-key-file"),
 		CertFile:     viper.GetString("tls-cert-file"),
-		KeyAlgorithm: constants.DefaultKeyAlgorithm,
-		KeyLength:    constants.DefaultKeyLength,
+		KeyAlgorithm: viper.GetString("tls-key-algorithm"),
+		KeyLength:    viper.GetInt("tls-key-length"),
 		Subject: pkix.Name{
 			CommonName: viper.GetString("tls-common-name"),
 		},
@@ -188,8 +188,8 @@ func (a *App) setupTaskRunner() (*setup.Runner, error) {
 	runner.AddTask("jwt", "", &setup.DownloadCert{
 		KeyFile:      constants.TokenSignKeyFile,
 		CertFile:     constants.TokenSignCertFile,
-		KeyAlgorithm: constants.DefaultKeyAlgorithm,
-		KeyLength:    constants.DefaultKeyLength,
+		KeyAlgorithm: viper.GetString("jwt-key-algorithm"),
+		KeyLength:    viper.GetInt("jwt-key-length"),
 		Subject: pkix.Name{
 			CommonName: viper.GetString("jwt-cert-common-name"),
 		},
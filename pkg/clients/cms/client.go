@@ -7,8 +7,11 @@ package cms
 import (
 	"bytes"
 	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"github.com/intel-secl/intel-secl/v3/pkg/clients"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/cms"
+	"io/ioutil"
 	"net/http"
 )
 
@@ -19,8 +22,10 @@ type Client struct {
 }
 
 var (
-	ErrFailToGetRootCA = errors.New("Failed to retrieve root CA")
-	ErrSignCSRFailed   = errors.New("Failed to sign certificate with CMS")
+	ErrFailToGetRootCA  = errors.New("Failed to retrieve root CA")
+	ErrSignCSRFailed    = errors.New("Failed to sign certificate with CMS")
+	ErrRevokeCertFailed = errors.New("Failed to revoke certificate with CMS")
+	ErrFailToGetCRL     = errors.New("Failed to retrieve CRL")
 )
 
 func (c *Client) httpClient() *http.Client {
@@ -86,3 +91,50 @@ func (c *Client) PostCSR(csr []byte) (string, error) {
 	resStr := resBuf.String()
 	return resStr, nil
 }
+
+func (c *Client) RevokeCertificate(serialNumber string, reason string) (*cms.IssuedCertificate, error) {
+
+	url := clients.ResolvePath(c.BaseURL, "cms/v1/certificates/"+serialNumber+"/revoke")
+	reqBytes, err := json.Marshal(cms.RevokeCertificateRequest{Reason: reason})
+	if err != nil {
+		return nil, err
+	}
+	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(reqBytes))
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	req.Header.Add("Authorization", "Bearer "+string(c.JWTToken))
+	if c.HTTPClient == nil {
+		return nil, errors.New("cmsClient.RevokeCertificate: HTTPClient should not be null")
+	}
+	rsp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return nil, ErrRevokeCertFailed
+	}
+	var issuedCert cms.IssuedCertificate
+	if err = json.NewDecoder(rsp.Body).Decode(&issuedCert); err != nil {
+		return nil, err
+	}
+	return &issuedCert, nil
+}
+
+func (c *Client) GetCRL(issuingCa string) ([]byte, error) {
+
+	url := clients.ResolvePath(c.BaseURL, "cms/v1/crl/"+issuingCa)
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("Accept", "application/pkix-crl")
+	rsp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return nil, ErrFailToGetCRL
+	}
+	return ioutil.ReadAll(rsp.Body)
+}
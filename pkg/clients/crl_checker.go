@@ -0,0 +1,126 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package clients
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maxCRLLength bounds the size of the CRLs downloaded from the distribution points
+const maxCRLLength = 10 << 20
+
+// CRLChecker verifies that none of the certificates presented by a TLS server have been revoked, using the CRL
+// distribution points that CMS publishes in the certificates it issues. Downloaded CRLs are cached until their
+// next update time.
+type CRLChecker struct {
+	// HTTPClient is used to download the CRLs, it must not itself use the CRLChecker
+	HTTPClient *http.Client
+
+	mutex sync.Mutex
+	cache map[string]*pkix.CertificateList
+}
+
+func NewCRLChecker(caCertificates []x509.Certificate) (*CRLChecker, error) {
+	httpClient, err := HTTPClientWithCA(caCertificates)
+	if err != nil {
+		return nil, err
+	}
+	return &CRLChecker{HTTPClient: httpClient}, nil
+}
+
+// VerifyPeerCertificate can be set as tls.Config.VerifyPeerCertificate, it is invoked after the regular chain
+// verification and rejects the connection if any certificate of a verified chain has been revoked
+func (c *CRLChecker) VerifyPeerCertificate(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	for _, chain := range verifiedChains {
+		for i := 0; i < len(chain)-1; i++ {
+			if err := c.CheckRevocation(chain[i], chain[i+1]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// CheckRevocation returns an error if the certificate is listed in the CRL of its issuer, or if that CRL cannot be
+// obtained. Certificates without CRL distribution points are not checked.
+func (c *CRLChecker) CheckRevocation(cert, issuer *x509.Certificate) error {
+	for _, url := range cert.CRLDistributionPoints {
+		crl, err := c.getCRL(url, issuer)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to get CRL of %s", issuer.Subject.CommonName)
+		}
+		for _, entry := range crl.TBSCertList.RevokedCertificates {
+			if entry.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return errors.Errorf("Certificate %s with serial number %s has been revoked",
+					cert.Subject.CommonName, cert.SerialNumber.String())
+			}
+		}
+	}
+	return nil
+}
+
+func (c *CRLChecker) getCRL(url string, issuer *x509.Certificate) (*pkix.CertificateList, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if crl, ok := c.cache[url]; ok && !crl.HasExpired(time.Now()) {
+		return crl, nil
+	}
+
+	rsp, err := c.HTTPClient.Get(url)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to download CRL from %s", url)
+	}
+	defer func() {
+		_ = rsp.Body.Close()
+	}()
+	if rsp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("Failed to download CRL from %s, status code %d", url, rsp.StatusCode)
+	}
+	der, err := ioutil.ReadAll(http.MaxBytesReader(nil, rsp.Body, maxCRLLength))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to read CRL from %s", url)
+	}
+
+	crl, err := x509.ParseDERCRL(der)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to parse CRL from %s", url)
+	}
+	if err = issuer.CheckCRLSignature(crl); err != nil {
+		return nil, errors.Wrapf(err, "Invalid signature on CRL from %s", url)
+	}
+
+	if c.cache == nil {
+		c.cache = map[string]*pkix.CertificateList{}
+	}
+	c.cache[url] = crl
+	return crl, nil
+}
+
+// HTTPClientWithCAAndCRLCheck returns a client which verifies the server certificates against the given CAs and
+// rejects the ones that have been revoked
+func HTTPClientWithCAAndCRLCheck(caCertificates []x509.Certificate) (*http.Client, error) {
+	checker, err := NewCRLChecker(caCertificates)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		MinVersion:            tls.VersionTLS12,
+		InsecureSkipVerify:    false,
+		RootCAs:               GetCertPool(caCertificates),
+		VerifyPeerCertificate: checker.VerifyPeerCertificate,
+	}
+	tr := &http.Transport{TLSClientConfig: config}
+
+	return &http.Client{Transport: tr}, nil
+}
@@ -8,6 +8,7 @@ package hvsclient
 import (
 	"bytes"
 	"encoding/json"
+	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
@@ -25,6 +26,9 @@ import (
 
 type FlavorsClient interface {
 	CreateFlavor(flavorCreateRequest *models.FlavorCreateRequest) (hvs.FlavorCollection, error)
+
+	// Iterates over all the flavors matching the criteria, fetching them one page of criteria Limit flavors at a time.
+	IterateFlavors(flavorFilterCriteria *models.FlavorFilterCriteria) *FlavorIterator
 }
 
 //-------------------------------------------------------------------------------------------------
@@ -90,3 +94,26 @@ func (client *flavorsClientImpl) CreateFlavor(flavorCreateRequest *models.Flavor
 	}
 	return flavors, nil
 }
+
+func (client *flavorsClientImpl) IterateFlavors(flavorFilterCriteria *models.FlavorFilterCriteria) *FlavorIterator {
+	log.Trace("hvsclient/flavors_client:IterateFlavors() Entering")
+	defer log.Trace("hvsclient/flavors_client:IterateFlavors() Leaving")
+
+	query := url.Values{}
+	for _, id := range flavorFilterCriteria.Ids {
+		query.Add("id", id.String())
+	}
+	if flavorFilterCriteria.Key != "" && flavorFilterCriteria.Value != "" {
+		query.Add("key", flavorFilterCriteria.Key)
+		query.Add("value", flavorFilterCriteria.Value)
+	}
+	if flavorFilterCriteria.FlavorgroupID != uuid.Nil {
+		query.Add("flavorgroupId", flavorFilterCriteria.FlavorgroupID.String())
+	}
+	for _, flavorPart := range flavorFilterCriteria.FlavorParts {
+		query.Add("flavorParts", flavorPart.String())
+	}
+	addPageQueryParams(query, flavorFilterCriteria.Limit, flavorFilterCriteria.After)
+
+	return &FlavorIterator{pager: newPager(client.httpClient, client.cfg, "flavors", query)}
+}
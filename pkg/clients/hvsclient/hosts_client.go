@@ -16,6 +16,8 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
+	"time"
 )
 
 //-------------------------------------------------------------------------------------------------
@@ -32,6 +34,13 @@ type HostsClient interface {
 
 	//  Updates the host with the specified attributes. Except for the host name, all other attributes can be updated.
 	UpdateHost(host *hvs.Host) (*hvs.Host, error)
+
+	// Iterates over all the hosts matching the criteria, fetching them one page of criteria Limit hosts at a time.
+	IterateHosts(*models.HostFilterCriteria) *HostIterator
+
+	// Iterates over all the host statuses matching the criteria, fetching them one page of criteria Limit host
+	// statuses at a time.
+	IterateHostStatuses(*models.HostStatusFilterCriteria) *HostStatusIterator
 }
 
 //-------------------------------------------------------------------------------------------------
@@ -63,30 +72,7 @@ func (client *hostsClientImpl) SearchHosts(hostFilterCriteria *models.HostFilter
 	request.Header.Set("Authorization", "Bearer "+client.cfg.BearerToken)
 	request.Header.Set("Accept", "application/json")
 
-	query := request.URL.Query()
-
-	if hostFilterCriteria.Id != uuid.Nil {
-		query.Add("id", hostFilterCriteria.Id.String())
-	}
-
-	if hostFilterCriteria.NameEqualTo != "" {
-		query.Add("nameEqualTo", hostFilterCriteria.NameEqualTo)
-	}
-
-	if hostFilterCriteria.NameContains != "" {
-		query.Add("nameContains", hostFilterCriteria.NameContains)
-	}
-
-	if hostFilterCriteria.HostHardwareId != uuid.Nil {
-		query.Add("hostHardwareId", hostFilterCriteria.HostHardwareId.String())
-	}
-
-	if hostFilterCriteria.Key != "" && hostFilterCriteria.Value != "" {
-		query.Add("key", hostFilterCriteria.Key)
-		query.Add("value", hostFilterCriteria.Value)
-	}
-
-	request.URL.RawQuery = query.Encode()
+	request.URL.RawQuery = hostSearchQuery(hostFilterCriteria).Encode()
 
 	log.Debugf("SearchHosts: %s", request.URL.RawQuery)
 
@@ -240,3 +226,99 @@ func (client *hostsClientImpl) UpdateHost(host *hvs.Host) (*hvs.Host, error) {
 
 	return &updatedHost, nil
 }
+
+func (client *hostsClientImpl) IterateHosts(hostFilterCriteria *models.HostFilterCriteria) *HostIterator {
+	log.Trace("hvsclient/hosts_client:IterateHosts() Entering")
+	defer log.Trace("hvsclient/hosts_client:IterateHosts() Leaving")
+
+	return &HostIterator{pager: newPager(client.httpClient, client.cfg, "hosts", hostSearchQuery(hostFilterCriteria))}
+}
+
+func (client *hostsClientImpl) IterateHostStatuses(hostStatusFilterCriteria *models.HostStatusFilterCriteria) *HostStatusIterator {
+	log.Trace("hvsclient/hosts_client:IterateHostStatuses() Entering")
+	defer log.Trace("hvsclient/hosts_client:IterateHostStatuses() Leaving")
+
+	return &HostStatusIterator{pager: newPager(client.httpClient, client.cfg, "host-status", hostStatusSearchQuery(hostStatusFilterCriteria))}
+}
+
+// hostSearchQuery returns the query parameters of a host search with the given criteria
+func hostSearchQuery(hostFilterCriteria *models.HostFilterCriteria) url.Values {
+	query := url.Values{}
+
+	if hostFilterCriteria.Id != uuid.Nil {
+		query.Add("id", hostFilterCriteria.Id.String())
+	}
+
+	if hostFilterCriteria.NameEqualTo != "" {
+		query.Add("nameEqualTo", hostFilterCriteria.NameEqualTo)
+	}
+
+	if hostFilterCriteria.NameContains != "" {
+		query.Add("nameContains", hostFilterCriteria.NameContains)
+	}
+
+	if hostFilterCriteria.HostHardwareId != uuid.Nil {
+		query.Add("hostHardwareId", hostFilterCriteria.HostHardwareId.String())
+	}
+
+	if hostFilterCriteria.Key != "" && hostFilterCriteria.Value != "" {
+		query.Add("key", hostFilterCriteria.Key)
+		query.Add("value", hostFilterCriteria.Value)
+	}
+
+	if hostFilterCriteria.Trusted != nil {
+		query.Add("trusted", strconv.FormatBool(*hostFilterCriteria.Trusted))
+	}
+
+	if hostFilterCriteria.OrderBy != "" {
+		query.Add("orderBy", hostFilterCriteria.OrderBy.String())
+	}
+
+	addPageQueryParams(query, hostFilterCriteria.Limit, hostFilterCriteria.After)
+	return query
+}
+
+// hostStatusSearchQuery returns the query parameters of a host status search with the given criteria
+func hostStatusSearchQuery(hostStatusFilterCriteria *models.HostStatusFilterCriteria) url.Values {
+	query := url.Values{}
+
+	if hostStatusFilterCriteria.Id != uuid.Nil {
+		query.Add("id", hostStatusFilterCriteria.Id.String())
+	}
+
+	if hostStatusFilterCriteria.HostId != uuid.Nil {
+		query.Add("hostId", hostStatusFilterCriteria.HostId.String())
+	}
+
+	if hostStatusFilterCriteria.HostHardwareId != uuid.Nil {
+		query.Add("hostHardwareId", hostStatusFilterCriteria.HostHardwareId.String())
+	}
+
+	if hostStatusFilterCriteria.HostName != "" {
+		query.Add("hostName", hostStatusFilterCriteria.HostName)
+	}
+
+	if hostStatusFilterCriteria.HostStatus != "" {
+		query.Add("hostStatus", hostStatusFilterCriteria.HostStatus)
+	}
+
+	if !hostStatusFilterCriteria.FromDate.IsZero() {
+		query.Add("fromDate", hostStatusFilterCriteria.FromDate.UTC().Format(time.RFC3339Nano))
+	}
+
+	if !hostStatusFilterCriteria.ToDate.IsZero() {
+		query.Add("toDate", hostStatusFilterCriteria.ToDate.UTC().Format(time.RFC3339Nano))
+	}
+
+	if hostStatusFilterCriteria.NumberOfDays != 0 {
+		query.Add("numberOfDays", strconv.Itoa(hostStatusFilterCriteria.NumberOfDays))
+	}
+
+	// latestPerHost defaults to true on the server, so it is only sent when set
+	if hostStatusFilterCriteria.LatestPerHost {
+		query.Add("latestPerHost", strconv.FormatBool(hostStatusFilterCriteria.LatestPerHost))
+	}
+
+	addPageQueryParams(query, hostStatusFilterCriteria.Limit, hostStatusFilterCriteria.After)
+	return query
+}
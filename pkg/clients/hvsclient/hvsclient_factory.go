@@ -21,6 +21,7 @@ type HVSClientFactory interface {
 	ReportsClient() (ReportsClient, error)
 	CertifyHostKeysClient() (CertifyHostKeysClient, error)
 	CACertificatesClient() (CACertificatesClient, error)
+	TpmEndorsementsClient() (TpmEndorsementsClient, error)
 }
 
 type hvsClientConfig struct {
@@ -136,6 +137,15 @@ func (vsClientFactory *defaultVSClientFactory) CACertificatesClient() (CACertifi
 	return &caCertificatesClientImpl{httpClient, vsClientFactory.cfg}, nil
 }
 
+func (vsClientFactory *defaultVSClientFactory) TpmEndorsementsClient() (TpmEndorsementsClient, error) {
+	httpClient, err := vsClientFactory.createHttpClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return &tpmEndorsementsClientImpl{httpClient, vsClientFactory.cfg}, nil
+}
+
 func (vsClientFactory *defaultVSClientFactory) createHttpClient() (*http.Client, error) {
 	log.Trace("hvsclient/hvsclient_factory:createHttpClient() Entering")
 	defer log.Trace("hvsclient/hvsclient_factory:createHttpClient() Leaving")
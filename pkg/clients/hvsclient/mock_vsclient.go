@@ -22,6 +22,7 @@ type MockedVSClientFactory struct {
 	MockedFlavorsClient         FlavorsClient
 	MockedManifestsClient       ManifestsClient
 	MockedPrivacyCAClient       PrivacyCAClient
+	MockedTpmEndorsementsClient TpmEndorsementsClient
 }
 
 func (factory MockedVSClientFactory) HostsClient() (HostsClient, error) {
@@ -52,6 +53,10 @@ func (factory MockedVSClientFactory) ReportsClient() (ReportsClient, error) {
 	return factory.MockedReportsClient, nil
 }
 
+func (factory MockedVSClientFactory) TpmEndorsementsClient() (TpmEndorsementsClient, error) {
+	return factory.MockedTpmEndorsementsClient, nil
+}
+
 //-------------------------------------------------------------------------------------------------
 // Mocked Hosts interface
 //-------------------------------------------------------------------------------------------------
@@ -62,7 +67,7 @@ type MockedHostsClient struct {
 // Can be mocked in unit tests similar to...
 // mockedHostsClient := new(hvsclient.MockedHostsClient)
 // mockedHostsClient.On("SearchHosts", mock.Anything).Return(&hvsclient.HostCollection {Hosts: []hvsclient.Host{}}, nil)
-func (mock MockedHostsClient) SearchHosts(hostFilterCriteria *models.HostFilterCriteria) (*hvs.HostCollection, error) {
+func (mock *MockedHostsClient) SearchHosts(hostFilterCriteria *models.HostFilterCriteria) (*hvs.HostCollection, error) {
 	args := mock.Called(hostFilterCriteria)
 	return args.Get(0).(*hvs.HostCollection), args.Error(1)
 }
@@ -70,16 +75,26 @@ func (mock MockedHostsClient) SearchHosts(hostFilterCriteria *models.HostFilterC
 // Can be mocked in unit tests similar to...
 // mockedHostsClient := new(hvsclient.MockedHostsClient)
 // mockedHostsClient.On("CreateHost", mock.Anything).Return(&hvsclient.Host{Id:"068b5e88-1886-4ac2-a908-175cf723723f"}, nil)
-func (mock MockedHostsClient) CreateHost(hostCreateRequest *hvs.HostCreateRequest) (*hvs.Host, error) {
+func (mock *MockedHostsClient) CreateHost(hostCreateRequest *hvs.HostCreateRequest) (*hvs.Host, error) {
 	args := mock.Called(hostCreateRequest)
 	return args.Get(0).(*hvs.Host), args.Error(1)
 }
 
-func (mock MockedHostsClient) UpdateHost(host *hvs.Host) (*hvs.Host, error) {
+func (mock *MockedHostsClient) UpdateHost(host *hvs.Host) (*hvs.Host, error) {
 	args := mock.Called(host)
 	return args.Get(0).(*hvs.Host), args.Error(1)
 }
 
+func (mock *MockedHostsClient) IterateHosts(hostFilterCriteria *models.HostFilterCriteria) *HostIterator {
+	args := mock.Called(hostFilterCriteria)
+	return args.Get(0).(*HostIterator)
+}
+
+func (mock *MockedHostsClient) IterateHostStatuses(hostStatusFilterCriteria *models.HostStatusFilterCriteria) *HostStatusIterator {
+	args := mock.Called(hostStatusFilterCriteria)
+	return args.Get(0).(*HostStatusIterator)
+}
+
 //-------------------------------------------------------------------------------------------------
 // Mocked Flavors interface
 //-------------------------------------------------------------------------------------------------
@@ -87,11 +102,16 @@ type MockedFlavorsClient struct {
 	mock.Mock
 }
 
-func (mock MockedFlavorsClient) CreateFlavor(flavorCreateRequest *models.FlavorCreateRequest) (hvs.FlavorCollection, error) {
+func (mock *MockedFlavorsClient) CreateFlavor(flavorCreateRequest *models.FlavorCreateRequest) (hvs.FlavorCollection, error) {
 	args := mock.Called(flavorCreateRequest)
 	return args.Get(0).(hvs.FlavorCollection), args.Error(0)
 }
 
+func (mock *MockedFlavorsClient) IterateFlavors(flavorFilterCriteria *models.FlavorFilterCriteria) *FlavorIterator {
+	args := mock.Called(flavorFilterCriteria)
+	return args.Get(0).(*FlavorIterator)
+}
+
 //-------------------------------------------------------------------------------------------------
 // Mocked Manifests interface
 //-------------------------------------------------------------------------------------------------
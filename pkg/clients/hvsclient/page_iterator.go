@@ -0,0 +1,238 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvsclient
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// DefaultPageSize is the number of records fetched in each page by the iterators when the search criteria have no limit
+const DefaultPageSize = 1000
+
+// pager fetches the pages of a paginated search, following the next link of each page
+type pager struct {
+	httpClient *http.Client
+	cfg        *hvsClientConfig
+	// next is the URL of the next page, empty once the last page is fetched
+	next string
+	err  error
+}
+
+// newPager returns a pager starting at the first page of the search of the resource with the given query parameters
+func newPager(httpClient *http.Client, cfg *hvsClientConfig, resource string, query url.Values) *pager {
+	p := &pager{httpClient: httpClient, cfg: cfg}
+
+	parsedUrl, err := url.Parse(cfg.BaseURL)
+	if err != nil {
+		p.err = errors.Wrap(err, "hvsclient/page_iterator:newPager() error parsing base url")
+		return p
+	}
+	parsedUrl.Path = path.Join(parsedUrl.Path, resource)
+	if query.Get("limit") == "" {
+		query.Set("limit", strconv.Itoa(DefaultPageSize))
+	}
+	parsedUrl.RawQuery = query.Encode()
+	p.next = parsedUrl.String()
+	return p
+}
+
+// fetch reads the next page into the collection and moves to the page following it, whose link is read from the
+// collection by nextLink. It returns false when there are no more pages or on error.
+func (p *pager) fetch(collection interface{}, nextLink func() string) bool {
+	log.Trace("hvsclient/page_iterator:fetch() Entering")
+	defer log.Trace("hvsclient/page_iterator:fetch() Leaving")
+
+	if p.err != nil || p.next == "" {
+		return false
+	}
+
+	request, err := http.NewRequest("GET", p.next, nil)
+	if err != nil {
+		p.err = errors.Wrap(err, "hvsclient/page_iterator:fetch() error creating request")
+		return false
+	}
+	request.Header.Set("Authorization", "Bearer "+p.cfg.BearerToken)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := p.httpClient.Do(request)
+	if err != nil {
+		secLog.Warn(message.BadConnection)
+		p.err = errors.Wrapf(err, "hvsclient/page_iterator:fetch() Error making request to %s", p.next)
+		return false
+	}
+	defer func() {
+		derr := response.Body.Close()
+		if derr != nil {
+			log.WithError(derr).Error("Error closing response body")
+		}
+	}()
+
+	if response.StatusCode != http.StatusOK {
+		p.err = errors.Errorf("hvsclient/page_iterator:fetch() Request made to %s returned status %d", p.next, response.StatusCode)
+		return false
+	}
+
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		p.err = errors.Wrap(err, "hvsclient/page_iterator:fetch() Error reading response")
+		return false
+	}
+	if err := json.Unmarshal(data, collection); err != nil {
+		p.err = errors.Wrap(err, "hvsclient/page_iterator:fetch() Error while unmarshaling the response")
+		return false
+	}
+
+	// the next link is relative to the HVS base URL
+	p.next = ""
+	if link := nextLink(); link != "" {
+		nextUrl, err := request.URL.Parse(link)
+		if err != nil {
+			p.err = errors.Wrap(err, "hvsclient/page_iterator:fetch() error parsing next page link")
+			return false
+		}
+		p.next = nextUrl.String()
+	}
+	return true
+}
+
+// HostIterator iterates over the hosts matching a search, fetching them one page at a time
+type HostIterator struct {
+	pager *pager
+	hosts []*hvs.Host
+	host  *hvs.Host
+}
+
+// Next moves to the next host, it returns false after the last host or on error
+func (it *HostIterator) Next() bool {
+	for len(it.hosts) == 0 {
+		var page hvs.HostCollection
+		if !it.pager.fetch(&page, func() string { return page.Next }) {
+			return false
+		}
+		it.hosts = page.Hosts
+	}
+	it.host, it.hosts = it.hosts[0], it.hosts[1:]
+	return true
+}
+
+// Host returns the current host
+func (it *HostIterator) Host() *hvs.Host {
+	return it.host
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *HostIterator) Err() error {
+	return it.pager.err
+}
+
+// HostStatusIterator iterates over the host statuses matching a search, fetching them one page at a time
+type HostStatusIterator struct {
+	pager        *pager
+	hostStatuses []hvs.HostStatus
+	hostStatus   *hvs.HostStatus
+}
+
+// Next moves to the next host status, it returns false after the last host status or on error
+func (it *HostStatusIterator) Next() bool {
+	for len(it.hostStatuses) == 0 {
+		var page hvs.HostStatusCollection
+		if !it.pager.fetch(&page, func() string { return page.Next }) {
+			return false
+		}
+		it.hostStatuses = page.HostStatuses
+	}
+	it.hostStatus, it.hostStatuses = &it.hostStatuses[0], it.hostStatuses[1:]
+	return true
+}
+
+// HostStatus returns the current host status
+func (it *HostStatusIterator) HostStatus() *hvs.HostStatus {
+	return it.hostStatus
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *HostStatusIterator) Err() error {
+	return it.pager.err
+}
+
+// FlavorIterator iterates over the flavors matching a search, fetching them one page at a time
+type FlavorIterator struct {
+	pager   *pager
+	flavors []hvs.SignedFlavor
+	flavor  *hvs.SignedFlavor
+}
+
+// Next moves to the next flavor, it returns false after the last flavor or on error
+func (it *FlavorIterator) Next() bool {
+	for len(it.flavors) == 0 {
+		var page hvs.SignedFlavorCollection
+		if !it.pager.fetch(&page, func() string { return page.Next }) {
+			return false
+		}
+		it.flavors = page.SignedFlavors
+	}
+	it.flavor, it.flavors = &it.flavors[0], it.flavors[1:]
+	return true
+}
+
+// Flavor returns the current flavor
+func (it *FlavorIterator) Flavor() *hvs.SignedFlavor {
+	return it.flavor
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *FlavorIterator) Err() error {
+	return it.pager.err
+}
+
+// TpmEndorsementIterator iterates over the TPM endorsements matching a search, fetching them one page at a time
+type TpmEndorsementIterator struct {
+	pager           *pager
+	tpmEndorsements []*hvs.TpmEndorsement
+	tpmEndorsement  *hvs.TpmEndorsement
+}
+
+// Next moves to the next TPM endorsement, it returns false after the last TPM endorsement or on error
+func (it *TpmEndorsementIterator) Next() bool {
+	for len(it.tpmEndorsements) == 0 {
+		var page hvs.TpmEndorsementCollection
+		if !it.pager.fetch(&page, func() string { return page.Next }) {
+			return false
+		}
+		it.tpmEndorsements = page.TpmEndorsement
+	}
+	it.tpmEndorsement, it.tpmEndorsements = it.tpmEndorsements[0], it.tpmEndorsements[1:]
+	return true
+}
+
+// TpmEndorsement returns the current TPM endorsement
+func (it *TpmEndorsementIterator) TpmEndorsement() *hvs.TpmEndorsement {
+	return it.tpmEndorsement
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *TpmEndorsementIterator) Err() error {
+	return it.pager.err
+}
+
+// addPageQueryParams sets the limit and after query parameters of a paginated search
+func addPageQueryParams(query url.Values, limit int, after *models.PageCursor) {
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if after != nil {
+		query.Set("after", after.String())
+	}
+}
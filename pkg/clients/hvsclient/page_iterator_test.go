@@ -0,0 +1,75 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvsclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostIterator(t *testing.T) {
+	assertions := assert.New(t)
+
+	hosts := []*hvs.Host{
+		{Id: uuid.New(), HostName: "host1"},
+		{Id: uuid.New(), HostName: "host2"},
+		{Id: uuid.New(), HostName: "host3"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertions.Equal("/hvs/v2/hosts", r.URL.Path)
+		assertions.Equal("Bearer token", r.Header.Get("Authorization"))
+		assertions.Equal("2", r.URL.Query().Get("limit"))
+		assertions.Equal("host", r.URL.Query().Get("nameContains"))
+
+		page := hvs.HostCollection{Hosts: hosts[:2]}
+		if after := r.URL.Query().Get("after"); after != "" {
+			cursor, err := models.ParsePageCursor(after)
+			assertions.NoError(err)
+			assertions.Equal(hosts[1].Id, cursor.Id)
+			page.Hosts = hosts[2:]
+		} else {
+			page.Next = "/hvs/v2/hosts?" + r.URL.RawQuery + "&after=" + models.PageCursor{Key: "host2", Id: hosts[1].Id}.String()
+		}
+		assertions.NoError(json.NewEncoder(w).Encode(page))
+	}))
+	defer server.Close()
+
+	client := hostsClientImpl{httpClient: server.Client(), cfg: &hvsClientConfig{BaseURL: server.URL + "/hvs/v2/", BearerToken: "token"}}
+	it := client.IterateHosts(&models.HostFilterCriteria{NameContains: "host", Limit: 2})
+	var hostNames []string
+	for it.Next() {
+		hostNames = append(hostNames, it.Host().HostName)
+	}
+	assertions.NoError(it.Err())
+	assertions.Equal([]string{"host1", "host2", "host3"}, hostNames)
+}
+
+func TestHostIteratorError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := hostsClientImpl{httpClient: server.Client(), cfg: &hvsClientConfig{BaseURL: server.URL + "/hvs/v2/"}}
+	it := client.IterateHosts(&models.HostFilterCriteria{})
+	assert.False(t, it.Next())
+	assert.Error(t, it.Err())
+}
+
+func TestHostStatusSearchQueryLatestPerHost(t *testing.T) {
+	query := hostStatusSearchQuery(&models.HostStatusFilterCriteria{HostName: "host1"})
+	_, ok := query["latestPerHost"]
+	assert.False(t, ok)
+
+	query = hostStatusSearchQuery(&models.HostStatusFilterCriteria{HostName: "host1", LatestPerHost: true})
+	assert.Equal(t, "true", query.Get("latestPerHost"))
+}
@@ -8,11 +8,15 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"github.com/pkg/errors"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
 //-------------------------------------------------------------------------------------------------
@@ -22,6 +26,7 @@ import (
 type TpmEndorsementsClient interface {
 	IsEkRegistered(hardwareUUID string) (bool, error)
 	RegisterEk(tpmEndorsement *hvs.TpmEndorsement) error
+	IterateTpmEndorsements(tpmEndorsementFilterCriteria *models.TpmEndorsementFilterCriteria) *TpmEndorsementIterator
 }
 
 //-------------------------------------------------------------------------------------------------
@@ -103,3 +108,38 @@ func (client *tpmEndorsementsClientImpl) RegisterEk(tpmEndorsement *hvs.TpmEndor
 
 	return nil
 }
+
+// IterateTpmEndorsements iterates over all the TPM endorsements matching the criteria, fetching them one page of
+// criteria Limit TPM endorsements at a time
+func (client *tpmEndorsementsClientImpl) IterateTpmEndorsements(tpmEndorsementFilterCriteria *models.TpmEndorsementFilterCriteria) *TpmEndorsementIterator {
+	log.Trace("hvsclient/tpm_endorsement_client:IterateTpmEndorsements() Entering")
+	defer log.Trace("hvsclient/tpm_endorsement_client:IterateTpmEndorsements() Leaving")
+
+	criteria := tpmEndorsementFilterCriteria
+	query := url.Values{}
+	if criteria.Id != uuid.Nil {
+		query.Add("id", criteria.Id.String())
+	}
+	if criteria.HardwareUuidEqualTo != uuid.Nil {
+		query.Add("hardwareUuidEqualTo", criteria.HardwareUuidEqualTo.String())
+	}
+	if criteria.IssuerEqualTo != "" {
+		query.Add("issuerEqualTo", criteria.IssuerEqualTo)
+	}
+	if criteria.IssuerContains != "" {
+		query.Add("issuerContains", criteria.IssuerContains)
+	}
+	if criteria.CommentEqualTo != "" {
+		query.Add("commentEqualTo", criteria.CommentEqualTo)
+	}
+	if criteria.CommentContains != "" {
+		query.Add("commentContains", criteria.CommentContains)
+	}
+	if criteria.CertificateDigestEqualTo != "" {
+		query.Add("certificateDigestEqualTo", criteria.CertificateDigestEqualTo)
+	}
+	query.Add("revokedEqualTo", strconv.FormatBool(criteria.RevokedEqualTo))
+	addPageQueryParams(query, criteria.Limit, criteria.After)
+
+	return &TpmEndorsementIterator{pager: newPager(client.httpClient, client.cfg, "tpm-endorsements", query)}
+}
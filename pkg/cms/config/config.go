@@ -12,6 +12,8 @@ import (
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v2"
 	"os"
+	"strings"
+	"time"
 )
 
 // Configuration is the global configuration struct that is marshalled/unmarshalled to a persisted yaml file
@@ -26,6 +28,9 @@ type Configuration struct {
 	AasJwtCn          string                  `yaml:"aas-jwt-cn" mapstructure:"aas-jwt-cn"`
 	AasTlsCn          string                  `yaml:"aas-tls-cn" mapstructure:"aas-tls-cn"`
 	AasTlsSan         string                  `yaml:"aas-tls-san" mapstructure:"aas-tls-san"`
+	Revocation        RevocationConfig        `yaml:"revocation" mapstructure:"revocation"`
+	// CsrSignatureAlgorithms lists the signature algorithms accepted in CSRs for each certificate type
+	CsrSignatureAlgorithms map[string][]string `yaml:"csr-signature-algorithms" mapstructure:"csr-signature-algorithms"`
 }
 
 type CACertConfig struct {
@@ -36,6 +41,45 @@ type CACertConfig struct {
 	Country      string `yaml:"country" mapstructure:"country"`
 }
 
+type RevocationConfig struct {
+	// BaseURL is the externally reachable CMS URL published in the CRL distribution point and OCSP
+	// extensions of the issued certificates, for example https://cms.example.com:8445/cms/v1/
+	BaseURL           string        `yaml:"base-url" mapstructure:"base-url"`
+	CrlUpdateInterval time.Duration `yaml:"crl-update-interval" mapstructure:"crl-update-interval"`
+	CrlValidity       time.Duration `yaml:"crl-validity" mapstructure:"crl-validity"`
+}
+
+// GetCsrSignatureAlgorithms returns the signature algorithms accepted in CSRs for the given certificate type. Cert
+// types are matched case insensitively since viper lower cases map keys, and the defaults apply to the cert types
+// missing from the configuration.
+func (c *Configuration) GetCsrSignatureAlgorithms(certType string) []string {
+	for k, v := range c.CsrSignatureAlgorithms {
+		if strings.EqualFold(k, certType) {
+			return v
+		}
+	}
+	return constants.DefaultCsrSignatureAlgorithms
+}
+
+// CsrSignatureAlgorithmsFromViper returns the signature algorithms accepted in CSRs for all the cert types, read
+// from the csr-signature-algorithms-<cert type> keys holding comma separated lists
+func CsrSignatureAlgorithmsFromViper() map[string][]string {
+	csrSignatureAlgorithms := make(map[string][]string)
+	for _, certType := range constants.GetCertTypes() {
+		var algorithms []string
+		for _, algorithm := range strings.Split(viper.GetString("csr-signature-algorithms-"+strings.ToLower(certType)), ",") {
+			if algorithm = strings.TrimSpace(algorithm); algorithm != "" {
+				algorithms = append(algorithms, algorithm)
+			}
+		}
+		if len(algorithms) == 0 {
+			algorithms = constants.DefaultCsrSignatureAlgorithms
+		}
+		csrSignatureAlgorithms[certType] = algorithms
+	}
+	return csrSignatureAlgorithms
+}
+
 // this function sets the configuration file name and type
 func init() {
 	viper.SetConfigName(constants.ConfigFile)
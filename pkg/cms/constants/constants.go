@@ -32,6 +32,8 @@ const (
 	TLSCertPath                    = ConfigDir + "tls-cert.pem"
 	TLSKeyPath                     = ConfigDir + "tls.key"
 	SerialNumberPath               = ConfigDir + "serial-number"
+	IssuedCertsDirPath             = ConfigDir + "issued-certs/"
+	CrlDirPath                     = ConfigDir + "crl/"
 	ServiceRemoveCmd               = "systemctl disable cms"
 	DefaultRootCACommonName        = "CMSCA"
 	DefaultPort                    = 8445
@@ -43,6 +45,7 @@ const (
 	DefaultKeyAlgorithm            = "rsa"
 	DefaultKeyAlgorithmLength      = 3072
 	CertApproverGroupName          = "CertApprover"
+	AdministratorGroupName         = "Administrator"
 	DefaultAasJwtCn                = "AAS JWT Signing Certificate"
 	DefaultAasTlsCn                = "AAS TLS Certificate"
 	DefaultTlsSan                  = "127.0.0.1,localhost"
@@ -54,12 +57,18 @@ const (
 	DefaultIdleTimeout             = 10 * time.Second
 	DefaultMaxHeaderBytes          = 1 << 20
 	DefaultLogEntryMaxlength       = 300
+	DefaultCrlUpdateInterval       = time.Hour
+	DefaultCrlValidity             = 24 * time.Hour
+	HTTPMediaTypePkixCrl           = "application/pkix-crl"
+	HTTPMediaTypeOcspRequest       = "application/ocsp-request"
+	HTTPMediaTypeOcspResponse      = "application/ocsp-response"
 )
 
 type CaAttrib struct {
 	CommonName string
 	CertPath   string
 	KeyPath    string
+	CrlPath    string
 }
 
 const (
@@ -70,10 +79,29 @@ const (
 )
 
 var mp = map[string]CaAttrib{
-	Root:      {"CMSCA", RootCACertPath, RootCAKeyPath},
-	Tls:       {"CMS TLS CA", IntermediateCADirPath + "tls-ca.pem", IntermediateCADirPath + "tls-ca.key"},
-	TlsClient: {"CMS TLS Client CA", IntermediateCADirPath + "tls-client-ca.pem", IntermediateCADirPath + "tls-client-ca.key"},
-	Signing:   {"CMS Signing CA", IntermediateCADirPath + "signing-ca.pem", IntermediateCADirPath + "signing-ca.key"},
+	Root:      {"CMSCA", RootCACertPath, RootCAKeyPath, CrlDirPath + "root-ca.crl"},
+	Tls:       {"CMS TLS CA", IntermediateCADirPath + "tls-ca.pem", IntermediateCADirPath + "tls-ca.key", CrlDirPath + "tls-ca.crl"},
+	TlsClient: {"CMS TLS Client CA", IntermediateCADirPath + "tls-client-ca.pem", IntermediateCADirPath + "tls-client-ca.key", CrlDirPath + "tls-client-ca.crl"},
+	Signing:   {"CMS Signing CA", IntermediateCADirPath + "signing-ca.pem", IntermediateCADirPath + "signing-ca.key", CrlDirPath + "signing-ca.crl"},
+}
+
+// DefaultCsrSignatureAlgorithms are the signature algorithms accepted in CSRs unless configured otherwise, named as
+// per x509.SignatureAlgorithm.String()
+var DefaultCsrSignatureAlgorithms = []string{
+	"SHA384-RSA",
+	"SHA256-RSAPSS",
+	"SHA384-RSAPSS",
+	"SHA512-RSAPSS",
+	"ECDSA-SHA256",
+	"ECDSA-SHA384",
+}
+
+// GetCertTypes returns all the certificate types that can be requested from CMS
+func GetCertTypes() []string {
+	log.Trace("constants/constants:GetCertTypes() Entering")
+	defer log.Trace("constants/constants:GetCertTypes() Leaving")
+
+	return []string{Tls, TlsClient, Signing, "Flavor-Signing", "JWT-Signing"}
 }
 
 func GetIntermediateCAs() []string {
@@ -83,6 +111,14 @@ func GetIntermediateCAs() []string {
 	return []string{Tls, TlsClient, Signing}
 }
 
+// GetIssuingCAs returns all the CAs that issue certificates and publish a CRL
+func GetIssuingCAs() []string {
+	log.Trace("constants/constants:GetIssuingCAs() Entering")
+	defer log.Trace("constants/constants:GetIssuingCAs() Leaving")
+
+	return []string{Root, Tls, TlsClient, Signing}
+}
+
 func GetCaAttribs(t string) CaAttrib {
 	log.Trace("constants/constants:GetCaAttribs() Entering")
 	defer log.Trace("constants/constants:GetCaAttribs() Leaving")
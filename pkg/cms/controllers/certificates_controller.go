@@ -11,6 +11,7 @@ import (
 	"encoding/pem"
 	"github.com/intel-secl/intel-secl/v3/pkg/cms/config"
 	"github.com/intel-secl/intel-secl/v3/pkg/cms/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/cms/revocation"
 	"github.com/intel-secl/intel-secl/v3/pkg/cms/utils"
 	"github.com/intel-secl/intel-secl/v3/pkg/cms/validation"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/auth"
@@ -26,7 +27,8 @@ import (
 )
 
 type CertificatesController struct {
-	Config *config.Configuration
+	Config    *config.Configuration
+	Inventory *revocation.Inventory
 }
 
 //GetCertificates is used to get the JWT Signing/TLS certificate upon JWT validation
@@ -169,9 +171,6 @@ func (controller CertificatesController) GetCertificates(httpWriter http.Respons
 	}
 
 	clientCRTTemplate := x509.Certificate{
-		Signature:          clientCSR.Signature,
-		SignatureAlgorithm: clientCSR.SignatureAlgorithm,
-
 		PublicKeyAlgorithm: clientCSR.PublicKeyAlgorithm,
 		PublicKey:          clientCSR.PublicKey,
 
@@ -212,6 +211,10 @@ func (controller CertificatesController) GetCertificates(httpWriter http.Respons
 		}
 		return
 	}
+	if baseURL := getRevocationBaseURL(controller.Config); baseURL != "" {
+		clientCRTTemplate.CRLDistributionPoints = []string{baseURL + "crl/" + issuingCa}
+		clientCRTTemplate.OCSPServer = []string{baseURL + "ocsp"}
+	}
 	caAttr := constants.GetCaAttribs(issuingCa)
 
 	caCert, caPrivKey, err := crypt.LoadX509CertAndPrivateKey(caAttr.CertPath, caAttr.KeyPath)
@@ -222,6 +225,18 @@ func (controller CertificatesController) GetCertificates(httpWriter http.Respons
 		if err != nil {
 			log.WithError(err).Errorf("resource/certificates:GetCertificates() Failed to write response")
 		}
+		return
+	}
+	// the certificate is signed by the issuing CA, so the signature algorithm follows the CA key rather than the CSR
+	clientCRTTemplate.SignatureAlgorithm, err = crypt.GetSignatureAlgorithm(caCert.PublicKey)
+	if err != nil {
+		log.WithError(err).Error("resource/certificates:GetCertificates() Unsupported Issuing CA key")
+		httpWriter.WriteHeader(http.StatusInternalServerError)
+		_, err = httpWriter.Write([]byte("Unsupported Issuing CA key"))
+		if err != nil {
+			log.WithError(err).Errorf("resource/certificates:GetCertificates() Failed to write response")
+		}
+		return
 	}
 
 	certificate, err := x509.CreateCertificate(rand.Reader, &clientCRTTemplate, caCert, clientCSR.PublicKey, caPrivKey)
@@ -232,6 +247,22 @@ func (controller CertificatesController) GetCertificates(httpWriter http.Respons
 		if err != nil {
 			log.WithError(err).Errorf("resource/certificates:GetCertificates() Failed to write response")
 		}
+		return
+	}
+
+	// record the issued certificate so that it can be revoked later on
+	issuedCert, err := x509.ParseCertificate(certificate)
+	if err == nil {
+		_, err = controller.Inventory.Add(issuedCert, certType, issuingCa)
+	}
+	if err != nil {
+		log.WithError(err).Error("resource/certificates:GetCertificates() Cannot record issued certificate")
+		httpWriter.WriteHeader(http.StatusInternalServerError)
+		_, err = httpWriter.Write([]byte("Cannot record issued certificate"))
+		if err != nil {
+			log.WithError(err).Errorf("resource/certificates:GetCertificates() Failed to write response")
+		}
+		return
 	}
 
 	httpWriter.Header().Add("Content-Type", "application/x-pem-file")
@@ -0,0 +1,194 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/cms/config"
+	"github.com/intel-secl/intel-secl/v3/pkg/cms/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/cms/revocation"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/auth"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/context"
+	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
+	ct "github.com/intel-secl/intel-secl/v3/pkg/model/aas"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/cms"
+)
+
+// maxOcspRequestLength bounds the size of the OCSP requests read from the request body
+const maxOcspRequestLength = 1 << 13
+
+type RevocationController struct {
+	Config    *config.Configuration
+	Inventory *revocation.Inventory
+}
+
+// RevokeCertificate is used to revoke an issued certificate upon JWT validation of an administrator
+func (controller RevocationController) RevokeCertificate(httpWriter http.ResponseWriter, httpRequest *http.Request) {
+	log.Trace("resource/revocation:RevokeCertificate() Entering")
+	defer log.Trace("resource/revocation:RevokeCertificate() Leaving")
+
+	privileges, err := context.GetUserRoles(httpRequest)
+	if err != nil {
+		slog.WithError(err).Warn("resource/revocation:RevokeCertificate() Failed to read roles and permissions")
+		writeResponse(httpWriter, http.StatusInternalServerError, "Could not get user roles from http context")
+		return
+	}
+	_, foundRole := auth.ValidatePermissionAndGetRoleContext(privileges,
+		[]ct.RoleInfo{{Service: constants.ServiceName, Name: constants.AdministratorGroupName}},
+		false)
+	if !foundRole {
+		slog.Warning(commLogMsg.UnauthorizedAccess)
+		httpWriter.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	serialNumber, ok := new(big.Int).SetString(mux.Vars(httpRequest)["serialNumber"], 10)
+	if !ok {
+		slog.Warning(commLogMsg.InvalidInputBadParam)
+		writeResponse(httpWriter, http.StatusBadRequest, "Invalid serial number provided")
+		return
+	}
+
+	var revokeRequest cms.RevokeCertificateRequest
+	if httpRequest.ContentLength != 0 {
+		if httpRequest.Header.Get("Content-Type") != "application/json" {
+			writeResponse(httpWriter, http.StatusUnsupportedMediaType, "Content type not supported")
+			return
+		}
+		dec := json.NewDecoder(httpRequest.Body)
+		dec.DisallowUnknownFields()
+		if err = dec.Decode(&revokeRequest); err != nil {
+			slog.Warning(commLogMsg.InvalidInputBadParam)
+			log.WithError(err).Error("resource/revocation:RevokeCertificate() Unable to decode request body")
+			writeResponse(httpWriter, http.StatusBadRequest, "Unable to decode request body")
+			return
+		}
+	}
+
+	record, err := controller.Inventory.Revoke(serialNumber, revokeRequest.Reason)
+	if err != nil {
+		switch err {
+		case revocation.ErrCertificateNotFound:
+			writeResponse(httpWriter, http.StatusNotFound, "Certificate with given serial number does not exist")
+		case revocation.ErrInvalidRevocationReason:
+			slog.Warning(commLogMsg.InvalidInputBadParam)
+			writeResponse(httpWriter, http.StatusBadRequest, "Invalid revocation reason provided")
+		default:
+			log.WithError(err).Error("resource/revocation:RevokeCertificate() Failed to revoke certificate")
+			writeResponse(httpWriter, http.StatusInternalServerError, "Failed to revoke certificate")
+		}
+		return
+	}
+	slog.Infof("resource/revocation:RevokeCertificate() Certificate with serial number %s issued to %s revoked, reason %s",
+		record.SerialNumber, record.Subject, record.RevocationReason)
+
+	// publish the revocation right away rather than waiting for the next scheduled CRL update
+	if err = revocation.GenerateCRL(controller.Inventory, record.IssuingCa, controller.Config.Revocation.CrlValidity); err != nil {
+		log.WithError(err).Errorf("resource/revocation:RevokeCertificate() Failed to regenerate CRL for %s", record.IssuingCa)
+	}
+
+	httpWriter.Header().Set("Content-Type", "application/json")
+	httpWriter.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(httpWriter).Encode(record); err != nil {
+		log.WithError(err).Errorf("resource/revocation:RevokeCertificate() Failed to write response")
+	}
+}
+
+// GetCrl is used to get the latest CRL of an issuing CA
+func (controller RevocationController) GetCrl(httpWriter http.ResponseWriter, httpRequest *http.Request) {
+	log.Trace("resource/revocation:GetCrl() Entering")
+	defer log.Trace("resource/revocation:GetCrl() Leaving")
+
+	issuingCa := mux.Vars(httpRequest)["issuingCa"]
+	caAttr := constants.GetCaAttribs(issuingCa)
+	if caAttr.CommonName == "" {
+		slog.Warning(commLogMsg.InvalidInputBadParam)
+		writeResponse(httpWriter, http.StatusBadRequest, "Invalid issuing CA provided")
+		return
+	}
+
+	crl, err := ioutil.ReadFile(caAttr.CrlPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			writeResponse(httpWriter, http.StatusNotFound, "CRL has not been generated yet")
+			return
+		}
+		log.WithError(err).Errorf("resource/revocation:GetCrl() Cannot load CRL of %s", issuingCa)
+		writeResponse(httpWriter, http.StatusInternalServerError, "Cannot load CRL")
+		return
+	}
+
+	httpWriter.Header().Set("Content-Type", constants.HTTPMediaTypePkixCrl)
+	httpWriter.WriteHeader(http.StatusOK)
+	if _, err = httpWriter.Write(crl); err != nil {
+		log.WithError(err).Errorf("resource/revocation:GetCrl() Failed to write response")
+	}
+}
+
+// Ocsp is used to get the revocation status of an issued certificate as per RFC 6960
+func (controller RevocationController) Ocsp(httpWriter http.ResponseWriter, httpRequest *http.Request) {
+	log.Trace("resource/revocation:Ocsp() Entering")
+	defer log.Trace("resource/revocation:Ocsp() Leaving")
+
+	if httpRequest.Header.Get("Content-Type") != constants.HTTPMediaTypeOcspRequest {
+		writeResponse(httpWriter, http.StatusUnsupportedMediaType, "Content type not supported")
+		return
+	}
+
+	request, err := ioutil.ReadAll(http.MaxBytesReader(httpWriter, httpRequest.Body, maxOcspRequestLength))
+	if err != nil {
+		slog.Warning(commLogMsg.InvalidInputBadParam)
+		writeResponse(httpWriter, http.StatusBadRequest, "Cannot read http request body")
+		return
+	}
+
+	responder := revocation.OcspResponder{
+		Inventory: controller.Inventory,
+		Validity:  controller.Config.Revocation.CrlUpdateInterval,
+	}
+	response, err := responder.Respond(request)
+	if err != nil {
+		log.WithError(err).Error("resource/revocation:Ocsp() Failed to create OCSP response")
+		writeResponse(httpWriter, http.StatusInternalServerError, "Failed to create OCSP response")
+		return
+	}
+
+	httpWriter.Header().Set("Content-Type", constants.HTTPMediaTypeOcspResponse)
+	httpWriter.Header().Set("Content-Length", strconv.Itoa(len(response)))
+	httpWriter.WriteHeader(http.StatusOK)
+	if _, err = httpWriter.Write(response); err != nil {
+		log.WithError(err).Errorf("resource/revocation:Ocsp() Failed to write response")
+	}
+}
+
+// getRevocationBaseURL returns the CMS URL published in issued certificates, falling back to the first SAN of the
+// CMS TLS certificate when no base URL is configured
+func getRevocationBaseURL(cfg *config.Configuration) string {
+	baseURL := cfg.Revocation.BaseURL
+	if baseURL == "" {
+		host := strings.TrimSpace(strings.Split(cfg.TlsSanList, ",")[0])
+		if host == "" {
+			return ""
+		}
+		baseURL = "https://" + host + ":" + strconv.Itoa(cfg.Server.Port) + "/" +
+			strings.ToLower(constants.ServiceName) + constants.ApiVersion
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/"
+}
+
+func writeResponse(httpWriter http.ResponseWriter, statusCode int, message string) {
+	httpWriter.WriteHeader(statusCode)
+	if _, err := httpWriter.Write([]byte(message)); err != nil {
+		log.WithError(err).Errorf("resource/revocation:writeResponse() Failed to write response")
+	}
+}
@@ -6,6 +6,7 @@ package cms
 
 import (
 	"os"
+	"strings"
 
 	"github.com/intel-secl/intel-secl/v3/pkg/cms/config"
 	"github.com/intel-secl/intel-secl/v3/pkg/cms/constants"
@@ -19,6 +20,8 @@ func init() {
 	viper.SetDefault("tls-cert-file", constants.TLSCertPath)
 	viper.SetDefault("tls-key-file", constants.TLSKeyPath)
 	viper.SetDefault("san-list", constants.DefaultTlsSan)
+	viper.SetDefault("tls-key-algorithm", constants.DefaultKeyAlgorithm)
+	viper.SetDefault("tls-key-length", constants.DefaultKeyAlgorithmLength)
 
 	// set default values for log
 	viper.SetDefault("log-max-length", constants.DefaultLogEntryMaxlength)
@@ -44,6 +47,13 @@ func init() {
 	viper.SetDefault("aas-tls-san", constants.DefaultTlsSan)
 
 	viper.SetDefault("token-duration-mins", constants.DefaultTokenDurationMins)
+
+	viper.SetDefault("revocation-crl-update-interval", constants.DefaultCrlUpdateInterval)
+	viper.SetDefault("revocation-crl-validity", constants.DefaultCrlValidity)
+
+	for _, certType := range constants.GetCertTypes() {
+		viper.SetDefault("csr-signature-algorithms-"+strings.ToLower(certType), strings.Join(constants.DefaultCsrSignatureAlgorithms, ","))
+	}
 }
 
 func defaultConfig() *config.Configuration {
@@ -67,6 +77,12 @@ func defaultConfig() *config.Configuration {
 		AasTlsSan:         viper.GetString("aas-tls-san"),
 		TlsSanList:        viper.GetString("san-list"),
 		TokenDurationMins: viper.GetInt("token-duration-mins"),
+		Revocation: config.RevocationConfig{
+			BaseURL:           viper.GetString("revocation-base-url"),
+			CrlUpdateInterval: viper.GetDuration("revocation-crl-update-interval"),
+			CrlValidity:       viper.GetDuration("revocation-crl-validity"),
+		},
+		CsrSignatureAlgorithms: config.CsrSignatureAlgorithmsFromViper(),
 	}
 }
 
@@ -80,6 +96,7 @@ func loadAlias() {
 		"server-max-header-bytes":    "CMS_SERVER_MAX_HEADER_BYTES",
 		"log-enable-stdout":          "CMS_ENABLE_CONSOLE_LOG",
 		"aas-base-url":               "AAS_API_URL",
+		"revocation-base-url":        "CMS_BASE_URL",
 	}
 	for k, v := range alias {
 		if env := os.Getenv(v); env != "" {
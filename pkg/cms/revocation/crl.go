@@ -0,0 +1,112 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package revocation
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/cms/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
+	"github.com/pkg/errors"
+)
+
+// GenerateCRL regenerates the DER encoded CRL of the given issuing CA from the inventory
+func GenerateCRL(inv *Inventory, issuingCa string, validity time.Duration) error {
+	defaultLog.Trace("revocation/crl:GenerateCRL() Entering")
+	defer defaultLog.Trace("revocation/crl:GenerateCRL() Leaving")
+
+	caAttr := constants.GetCaAttribs(issuingCa)
+	if caAttr.CommonName == "" {
+		return errors.Errorf("revocation/crl:GenerateCRL() Invalid issuing CA %s", issuingCa)
+	}
+
+	caCert, caSigner, err := loadIssuingCa(issuingCa)
+	if err != nil {
+		return errors.Wrapf(err, "revocation/crl:GenerateCRL() Could not load issuing CA %s", issuingCa)
+	}
+
+	entries, err := inv.RevokedCertificates(issuingCa)
+	if err != nil {
+		return errors.Wrapf(err, "revocation/crl:GenerateCRL() Could not read revoked certificates of %s", issuingCa)
+	}
+
+	crl, err := createCRL(caCert, caSigner, entries, validity)
+	if err != nil {
+		return errors.Wrapf(err, "revocation/crl:GenerateCRL() Could not create CRL for %s", issuingCa)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(caAttr.CrlPath), 0755); err != nil {
+		return errors.Wrap(err, "revocation/crl:GenerateCRL() Could not create CRL directory")
+	}
+	if err = ioutil.WriteFile(caAttr.CrlPath, crl, 0644); err != nil {
+		return errors.Wrapf(err, "revocation/crl:GenerateCRL() Could not write CRL for %s", issuingCa)
+	}
+	defaultLog.Debugf("revocation/crl:GenerateCRL() Generated CRL for %s with %d revoked certificates", issuingCa, len(entries))
+	return nil
+}
+
+// GenerateCRLs regenerates the CRLs of all the issuing CAs, a failure for one CA does not prevent the others
+// from being updated
+func GenerateCRLs(inv *Inventory, validity time.Duration) error {
+	defaultLog.Trace("revocation/crl:GenerateCRLs() Entering")
+	defer defaultLog.Trace("revocation/crl:GenerateCRLs() Leaving")
+
+	var lastErr error
+	for _, issuingCa := range constants.GetIssuingCAs() {
+		if err := GenerateCRL(inv, issuingCa, validity); err != nil {
+			defaultLog.WithError(err).Errorf("revocation/crl:GenerateCRLs() Failed to generate CRL for %s", issuingCa)
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// ScheduleCRLUpdates regenerates the CRLs of all the issuing CAs right away and then at every interval until stop
+// is closed. The CRL validity must be longer than the interval so that clients never see an expired CRL.
+func ScheduleCRLUpdates(inv *Inventory, interval, validity time.Duration, stop <-chan struct{}) {
+	defaultLog.Trace("revocation/crl:ScheduleCRLUpdates() Entering")
+	defer defaultLog.Trace("revocation/crl:ScheduleCRLUpdates() Leaving")
+
+	if validity <= interval {
+		defaultLog.Warnf("revocation/crl:ScheduleCRLUpdates() CRL validity %s is not longer than the update interval %s", validity, interval)
+	}
+	_ = GenerateCRLs(inv, validity)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			_ = GenerateCRLs(inv, validity)
+		}
+	}
+}
+
+func createCRL(caCert *x509.Certificate, caSigner crypto.Signer, entries []pkix.RevokedCertificate, validity time.Duration) ([]byte, error) {
+	now := time.Now().UTC()
+	return caCert.CreateCRL(rand.Reader, caSigner, entries, now, now.Add(validity))
+}
+
+func loadIssuingCa(issuingCa string) (*x509.Certificate, crypto.Signer, error) {
+	caAttr := constants.GetCaAttribs(issuingCa)
+	caCert, caPrivKey, err := crypt.LoadX509CertAndPrivateKey(caAttr.CertPath, caAttr.KeyPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	caSigner, ok := caPrivKey.(crypto.Signer)
+	if !ok {
+		return nil, nil, errors.New("revocation/crl:loadIssuingCa() CA private key does not support signing")
+	}
+	return caCert, caSigner, nil
+}
@@ -0,0 +1,216 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package revocation
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/cms"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ocsp"
+)
+
+var defaultLog = log.GetDefaultLogger()
+
+var ErrCertificateNotFound = errors.New("Certificate not found in inventory")
+var ErrInvalidRevocationReason = errors.New("Invalid revocation reason")
+
+// revocationReasons maps the reasons accepted by the revocation API to their RFC 5280 reason codes
+var revocationReasons = map[string]int{
+	"unspecified":          ocsp.Unspecified,
+	"keyCompromise":        ocsp.KeyCompromise,
+	"caCompromise":         ocsp.CACompromise,
+	"affiliationChanged":   ocsp.AffiliationChanged,
+	"superseded":           ocsp.Superseded,
+	"cessationOfOperation": ocsp.CessationOfOperation,
+}
+
+// oidExtensionReasonCode is the OID of the reason code extension of the CRL entries
+var oidExtensionReasonCode = asn1.ObjectIdentifier{2, 5, 29, 21}
+
+// Inventory persists a record for every issued certificate as a file named after its serial number
+type Inventory struct {
+	Dir   string
+	mutex sync.RWMutex
+}
+
+func NewInventory(dir string) *Inventory {
+	return &Inventory{Dir: dir}
+}
+
+// Add records a newly issued certificate
+func (inv *Inventory) Add(cert *x509.Certificate, certType, issuingCa string) (*cms.IssuedCertificate, error) {
+	defaultLog.Trace("revocation/inventory:Add() Entering")
+	defer defaultLog.Trace("revocation/inventory:Add() Leaving")
+
+	record := cms.IssuedCertificate{
+		SerialNumber: cert.SerialNumber.String(),
+		Subject:      cert.Subject.CommonName,
+		CertType:     certType,
+		IssuingCa:    issuingCa,
+		NotBefore:    cert.NotBefore,
+		NotAfter:     cert.NotAfter,
+	}
+
+	inv.mutex.Lock()
+	defer inv.mutex.Unlock()
+	if err := inv.write(&record); err != nil {
+		return nil, errors.Wrap(err, "revocation/inventory:Add() Failed to store issued certificate record")
+	}
+	return &record, nil
+}
+
+// Retrieve returns the record of the certificate with the given serial number
+func (inv *Inventory) Retrieve(serialNumber *big.Int) (*cms.IssuedCertificate, error) {
+	defaultLog.Trace("revocation/inventory:Retrieve() Entering")
+	defer defaultLog.Trace("revocation/inventory:Retrieve() Leaving")
+
+	inv.mutex.RLock()
+	defer inv.mutex.RUnlock()
+	return inv.read(inv.recordPath(serialNumber.String()))
+}
+
+// Revoke marks the certificate with the given serial number as revoked. Revoking a certificate which is already
+// revoked returns the existing record unchanged.
+func (inv *Inventory) Revoke(serialNumber *big.Int, reason string) (*cms.IssuedCertificate, error) {
+	defaultLog.Trace("revocation/inventory:Revoke() Entering")
+	defer defaultLog.Trace("revocation/inventory:Revoke() Leaving")
+
+	if reason == "" {
+		reason = "unspecified"
+	}
+	if _, ok := revocationReasons[reason]; !ok {
+		return nil, ErrInvalidRevocationReason
+	}
+
+	inv.mutex.Lock()
+	defer inv.mutex.Unlock()
+	record, err := inv.read(inv.recordPath(serialNumber.String()))
+	if err != nil {
+		return nil, err
+	}
+	if record.Revoked {
+		return record, nil
+	}
+
+	revokedAt := time.Now().UTC()
+	record.Revoked = true
+	record.RevokedAt = &revokedAt
+	record.RevocationReason = reason
+	if err = inv.write(record); err != nil {
+		return nil, errors.Wrap(err, "revocation/inventory:Revoke() Failed to update issued certificate record")
+	}
+	return record, nil
+}
+
+// Search returns the records of all the certificates issued by the given CA
+func (inv *Inventory) Search(issuingCa string) ([]cms.IssuedCertificate, error) {
+	defaultLog.Trace("revocation/inventory:Search() Entering")
+	defer defaultLog.Trace("revocation/inventory:Search() Leaving")
+
+	inv.mutex.RLock()
+	defer inv.mutex.RUnlock()
+
+	files, err := ioutil.ReadDir(inv.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []cms.IssuedCertificate{}, nil
+		}
+		return nil, errors.Wrap(err, "revocation/inventory:Search() Failed to read issued certificates directory")
+	}
+
+	records := []cms.IssuedCertificate{}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		record, err := inv.read(filepath.Join(inv.Dir, file.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "revocation/inventory:Search() Failed to read issued certificate record %s", file.Name())
+		}
+		if record.IssuingCa == issuingCa {
+			records = append(records, *record)
+		}
+	}
+	return records, nil
+}
+
+// RevokedCertificates returns the CRL entries for the certificates revoked by the given CA which have not expired yet
+func (inv *Inventory) RevokedCertificates(issuingCa string) ([]pkix.RevokedCertificate, error) {
+	defaultLog.Trace("revocation/inventory:RevokedCertificates() Entering")
+	defer defaultLog.Trace("revocation/inventory:RevokedCertificates() Leaving")
+
+	records, err := inv.Search(issuingCa)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entries := []pkix.RevokedCertificate{}
+	for _, record := range records {
+		if !record.Revoked || record.NotAfter.Before(now) {
+			continue
+		}
+		serialNumber, ok := new(big.Int).SetString(record.SerialNumber, 10)
+		if !ok {
+			return nil, errors.Errorf("revocation/inventory:RevokedCertificates() Invalid serial number %s", record.SerialNumber)
+		}
+		entry := pkix.RevokedCertificate{
+			SerialNumber:   serialNumber,
+			RevocationTime: record.RevokedAt.UTC(),
+		}
+		// RFC 5280 recommends leaving out the reason code when it is unspecified
+		if reasonCode := revocationReasons[record.RevocationReason]; reasonCode != ocsp.Unspecified {
+			value, err := asn1.Marshal(asn1.Enumerated(reasonCode))
+			if err != nil {
+				return nil, errors.Wrap(err, "revocation/inventory:RevokedCertificates() Failed to marshal reason code")
+			}
+			entry.Extensions = []pkix.Extension{{Id: oidExtensionReasonCode, Value: value}}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (inv *Inventory) recordPath(serialNumber string) string {
+	return filepath.Join(inv.Dir, serialNumber+".json")
+}
+
+func (inv *Inventory) read(path string) (*cms.IssuedCertificate, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrCertificateNotFound
+		}
+		return nil, errors.Wrap(err, "revocation/inventory:read() Failed to read issued certificate record")
+	}
+	var record cms.IssuedCertificate
+	if err = json.Unmarshal(bytes, &record); err != nil {
+		return nil, errors.Wrap(err, "revocation/inventory:read() Failed to unmarshal issued certificate record")
+	}
+	return &record, nil
+}
+
+func (inv *Inventory) write(record *cms.IssuedCertificate) error {
+	if err := os.MkdirAll(inv.Dir, 0700); err != nil {
+		return errors.Wrap(err, "revocation/inventory:write() Failed to create issued certificates directory")
+	}
+	bytes, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "revocation/inventory:write() Failed to marshal issued certificate record")
+	}
+	return ioutil.WriteFile(inv.recordPath(record.SerialNumber), bytes, 0600)
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package revocation
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/cms/constants"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ocsp"
+)
+
+// OcspResponder answers OCSP requests for the certificates issued by the CMS CAs. Responses are signed directly
+// by the CA that issued the certificate in question.
+type OcspResponder struct {
+	Inventory *Inventory
+	// Validity is the period after which clients should fetch a fresh response
+	Validity time.Duration
+}
+
+// Respond returns the DER encoded OCSP response for a DER encoded OCSP request. Requests which cannot be parsed or
+// refer to an unknown issuer get the corresponding OCSP error response, as mandated by RFC 6960.
+func (r *OcspResponder) Respond(request []byte) ([]byte, error) {
+	defaultLog.Trace("revocation/ocsp:Respond() Entering")
+	defer defaultLog.Trace("revocation/ocsp:Respond() Leaving")
+
+	ocspRequest, err := ocsp.ParseRequest(request)
+	if err != nil {
+		defaultLog.WithError(err).Debug("revocation/ocsp:Respond() Malformed OCSP request")
+		return ocsp.MalformedRequestErrorResponse, nil
+	}
+
+	issuingCa, caCert, caSigner, err := findIssuingCa(ocspRequest)
+	if err != nil {
+		return nil, errors.Wrap(err, "revocation/ocsp:Respond() Failed to load issuing CAs")
+	}
+	if caCert == nil {
+		return ocsp.UnauthorizedErrorResponse, nil
+	}
+
+	return r.createResponse(ocspRequest, issuingCa, caCert, caSigner)
+}
+
+func (r *OcspResponder) createResponse(ocspRequest *ocsp.Request, issuingCa string, caCert *x509.Certificate, caSigner crypto.Signer) ([]byte, error) {
+	now := time.Now().UTC()
+	template := ocsp.Response{
+		SerialNumber: ocspRequest.SerialNumber,
+		ThisUpdate:   now,
+		NextUpdate:   now.Add(r.Validity),
+	}
+
+	record, err := r.Inventory.Retrieve(ocspRequest.SerialNumber)
+	switch {
+	case err == ErrCertificateNotFound || (err == nil && record.IssuingCa != issuingCa):
+		template.Status = ocsp.Unknown
+	case err != nil:
+		return nil, errors.Wrap(err, "revocation/ocsp:createResponse() Failed to retrieve issued certificate record")
+	case record.Revoked:
+		template.Status = ocsp.Revoked
+		template.RevokedAt = *record.RevokedAt
+		template.RevocationReason = revocationReasons[record.RevocationReason]
+	default:
+		template.Status = ocsp.Good
+	}
+
+	response, err := ocsp.CreateResponse(caCert, caCert, template, caSigner)
+	if err != nil {
+		return nil, errors.Wrap(err, "revocation/ocsp:createResponse() Failed to create OCSP response")
+	}
+	return response, nil
+}
+
+// findIssuingCa returns the CA matching the issuer name and key hashes of the request, or a nil certificate when
+// none of the CMS CAs match
+func findIssuingCa(ocspRequest *ocsp.Request) (string, *x509.Certificate, crypto.Signer, error) {
+	if !ocspRequest.HashAlgorithm.Available() {
+		return "", nil, nil, nil
+	}
+	for _, issuingCa := range constants.GetIssuingCAs() {
+		caCert, caSigner, err := loadIssuingCa(issuingCa)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		matches, err := issuerMatches(ocspRequest, caCert)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		if matches {
+			return issuingCa, caCert, caSigner, nil
+		}
+	}
+	return "", nil, nil, nil
+}
+
+func issuerMatches(ocspRequest *ocsp.Request, caCert *x509.Certificate) (bool, error) {
+	var publicKeyInfo struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(caCert.RawSubjectPublicKeyInfo, &publicKeyInfo); err != nil {
+		return false, errors.Wrap(err, "revocation/ocsp:issuerMatches() Failed to parse CA public key")
+	}
+
+	hash := ocspRequest.HashAlgorithm.New()
+	hash.Write(caCert.RawSubject)
+	nameHash := hash.Sum(nil)
+
+	hash.Reset()
+	hash.Write(publicKeyInfo.PublicKey.RightAlign())
+	keyHash := hash.Sum(nil)
+
+	return bytes.Equal(nameHash, ocspRequest.IssuerNameHash) && bytes.Equal(keyHash, ocspRequest.IssuerKeyHash), nil
+}
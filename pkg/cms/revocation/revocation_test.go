@@ -0,0 +1,138 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package revocation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ocsp"
+)
+
+func createTestCertificates(t *testing.T) (*x509.Certificate, crypto.Signer, *x509.Certificate) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+	caTemplate := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDer, err := x509.CreateCertificate(rand.Reader, &caTemplate, &caTemplate, &caKey.PublicKey, caKey)
+	assert.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDer)
+	assert.NoError(t, err)
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+	leafTemplate := x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "Test Leaf"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	leafDer, err := x509.CreateCertificate(rand.Reader, &leafTemplate, caCert, &leafKey.PublicKey, caKey)
+	assert.NoError(t, err)
+	leafCert, err := x509.ParseCertificate(leafDer)
+	assert.NoError(t, err)
+
+	return caCert, caKey, leafCert
+}
+
+func TestRevocation(t *testing.T) {
+	assertions := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "issued-certs")
+	assertions.NoError(err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	caCert, caSigner, leafCert := createTestCertificates(t)
+	inv := NewInventory(dir)
+
+	record, err := inv.Add(leafCert, "TLS", "TLS")
+	assertions.NoError(err)
+	assertions.Equal("42", record.SerialNumber)
+	assertions.Equal("Test Leaf", record.Subject)
+	assertions.False(record.Revoked)
+
+	_, err = inv.Revoke(big.NewInt(43), "keyCompromise")
+	assertions.Equal(ErrCertificateNotFound, err)
+	_, err = inv.Revoke(leafCert.SerialNumber, "unknownReason")
+	assertions.Equal(ErrInvalidRevocationReason, err)
+
+	entries, err := inv.RevokedCertificates("TLS")
+	assertions.NoError(err)
+	assertions.Empty(entries)
+
+	record, err = inv.Revoke(leafCert.SerialNumber, "keyCompromise")
+	assertions.NoError(err)
+	assertions.True(record.Revoked)
+	assertions.NotNil(record.RevokedAt)
+
+	entries, err = inv.RevokedCertificates("TLS")
+	assertions.NoError(err)
+	assertions.Len(entries, 1)
+	assertions.Len(entries[0].Extensions, 1)
+	assertions.Equal(oidExtensionReasonCode, entries[0].Extensions[0].Id)
+	var reasonCode asn1.Enumerated
+	_, err = asn1.Unmarshal(entries[0].Extensions[0].Value, &reasonCode)
+	assertions.NoError(err)
+	assertions.Equal(asn1.Enumerated(ocsp.KeyCompromise), reasonCode)
+	entries, err = inv.RevokedCertificates("Signing")
+	assertions.NoError(err)
+	assertions.Empty(entries)
+
+	// the CRL signed by the issuing CA lists the revoked certificate
+	entries, err = inv.RevokedCertificates("TLS")
+	assertions.NoError(err)
+	crlDer, err := createCRL(caCert, caSigner, entries, time.Hour)
+	assertions.NoError(err)
+	crl, err := x509.ParseCRL(crlDer)
+	assertions.NoError(err)
+	assertions.NoError(caCert.CheckCRLSignature(crl))
+	assertions.Len(crl.TBSCertList.RevokedCertificates, 1)
+	assertions.Equal(0, crl.TBSCertList.RevokedCertificates[0].SerialNumber.Cmp(leafCert.SerialNumber))
+
+	// the OCSP response reports the certificate as revoked
+	ocspRequestDer, err := ocsp.CreateRequest(leafCert, caCert, nil)
+	assertions.NoError(err)
+	ocspRequest, err := ocsp.ParseRequest(ocspRequestDer)
+	assertions.NoError(err)
+	matches, err := issuerMatches(ocspRequest, caCert)
+	assertions.NoError(err)
+	assertions.True(matches)
+
+	responder := OcspResponder{Inventory: inv, Validity: time.Hour}
+	ocspResponseDer, err := responder.createResponse(ocspRequest, "TLS", caCert, caSigner)
+	assertions.NoError(err)
+	ocspResponse, err := ocsp.ParseResponseForCert(ocspResponseDer, leafCert, caCert)
+	assertions.NoError(err)
+	assertions.Equal(ocsp.Revoked, ocspResponse.Status)
+	assertions.Equal(ocsp.KeyCompromise, ocspResponse.RevocationReason)
+
+	// certificates issued by another CA are unknown to this one
+	ocspResponseDer, err = responder.createResponse(ocspRequest, "Signing", caCert, caSigner)
+	assertions.NoError(err)
+	ocspResponse, err = ocsp.ParseResponseForCert(ocspResponseDer, leafCert, caCert)
+	assertions.NoError(err)
+	assertions.Equal(ocsp.Unknown, ocspResponse.Status)
+}
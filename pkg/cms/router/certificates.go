@@ -9,15 +9,19 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/intel-secl/intel-secl/v3/pkg/cms/config"
 	"github.com/intel-secl/intel-secl/v3/pkg/cms/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/cms/revocation"
 	log "github.com/sirupsen/logrus"
 )
 
 // SetCertificatesRoutes is used to set the endpoints for certificate handling APIs
-func SetCertificatesRoutes(router *mux.Router, config *config.Configuration) *mux.Router {
+func SetCertificatesRoutes(router *mux.Router, config *config.Configuration, inventory *revocation.Inventory) *mux.Router {
 	log.Trace("router/certificates:SetCertificatesRoutes() Entering")
 	defer log.Trace("router/certificates:SetCertificatesRoutes() Leaving")
 
-	certController := controllers.CertificatesController{Config: config}
+	certController := controllers.CertificatesController{Config: config, Inventory: inventory}
 	router.HandleFunc("/certificates", certController.GetCertificates).Methods("POST")
+
+	revocationController := controllers.RevocationController{Config: config, Inventory: inventory}
+	router.HandleFunc("/certificates/{serialNumber:[0-9]+}/revoke", revocationController.RevokeCertificate).Methods("POST")
 	return router
 }
@@ -0,0 +1,25 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package router
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/cms/config"
+	"github.com/intel-secl/intel-secl/v3/pkg/cms/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/cms/revocation"
+	log "github.com/sirupsen/logrus"
+)
+
+// SetRevocationRoutes is used to set the public endpoints clients use to check the revocation status of certificates
+func SetRevocationRoutes(router *mux.Router, config *config.Configuration, inventory *revocation.Inventory) *mux.Router {
+	log.Trace("router/revocation:SetRevocationRoutes() Entering")
+	defer log.Trace("router/revocation:SetRevocationRoutes() Leaving")
+
+	revocationController := controllers.RevocationController{Config: config, Inventory: inventory}
+	router.HandleFunc("/crl/{issuingCa}", revocationController.GetCrl).Methods("GET")
+	router.HandleFunc("/ocsp", revocationController.Ocsp).Methods("POST")
+	return router
+}
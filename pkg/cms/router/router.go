@@ -10,6 +10,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/intel-secl/intel-secl/v3/pkg/cms/config"
 	"github.com/intel-secl/intel-secl/v3/pkg/cms/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/cms/revocation"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/middleware"
@@ -28,7 +29,7 @@ type Router struct {
 }
 
 // InitRoutes registers all routes for the application.
-func InitRoutes(cfg *config.Configuration) *mux.Router {
+func InitRoutes(cfg *config.Configuration, inventory *revocation.Inventory) *mux.Router {
 	defaultLog.Trace("router/router:InitRoutes() Entering")
 	defer defaultLog.Trace("router/router:InitRoutes() Leaving")
 
@@ -36,11 +37,11 @@ func InitRoutes(cfg *config.Configuration) *mux.Router {
 	router := mux.NewRouter()
 
 	router.SkipClean(true)
-	defineSubRoutes(router, strings.ToLower(constants.ServiceName), cfg)
+	defineSubRoutes(router, strings.ToLower(constants.ServiceName), cfg, inventory)
 	return router
 }
 
-func defineSubRoutes(router *mux.Router, service string, cfg *config.Configuration) {
+func defineSubRoutes(router *mux.Router, service string, cfg *config.Configuration, inventory *revocation.Inventory) {
 	defaultLog.Trace("router/router:defineSubRoutes() Entering")
 	defer defaultLog.Trace("router/router:defineSubRoutes() Leaving")
 
@@ -48,12 +49,13 @@ func defineSubRoutes(router *mux.Router, service string, cfg *config.Configurati
 	subRouter := router.PathPrefix(serviceApi).Subrouter()
 	subRouter = SetVersionRoutes(subRouter)
 	subRouter = SetCACertificatesRoutes(subRouter)
+	subRouter = SetRevocationRoutes(subRouter, cfg, inventory)
 
 	subRouter = router.PathPrefix(serviceApi).Subrouter()
 	cfgRouter := Router{cfg: cfg}
 	subRouter.Use(middleware.NewTokenAuth(constants.TrustedJWTSigningCertsDir, constants.ConfigDir, cfgRouter.fnGetJwtCerts,
 		time.Minute*constants.DefaultJwtValidateCacheKeyMins))
-	subRouter = SetCertificatesRoutes(subRouter, cfg)
+	subRouter = SetCertificatesRoutes(subRouter, cfg, inventory)
 }
 
 // Fetch JWT certificate from AAS
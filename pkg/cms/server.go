@@ -8,6 +8,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"github.com/intel-secl/intel-secl/v3/pkg/cms/revocation"
 	"github.com/intel-secl/intel-secl/v3/pkg/cms/router"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
@@ -43,7 +44,17 @@ func (a *App) startServer() error {
 	}
 
 	// Initialize routes
-	routes := router.InitRoutes(c)
+	inventory := revocation.NewInventory(constants.IssuedCertsDirPath)
+	routes := router.InitRoutes(c, inventory)
+
+	// Regenerate the CRLs of the issuing CAs periodically so that they never expire
+	stopCrlUpdates := make(chan struct{})
+	defer close(stopCrlUpdates)
+	if c.Revocation.CrlUpdateInterval > 0 {
+		go revocation.ScheduleCRLUpdates(inventory, c.Revocation.CrlUpdateInterval, c.Revocation.CrlValidity, stopCrlUpdates)
+	} else if err := revocation.GenerateCRLs(inventory, c.Revocation.CrlValidity); err != nil {
+		defaultLog.WithError(err).Error("app:startServer() Failed to generate CRLs")
+	}
 
 	tlsconfig := &tls.Config{
 		MinVersion: tls.VersionTLS12,
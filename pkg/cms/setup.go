@@ -139,6 +139,8 @@ func (a *App) setupTaskRunner() (*setup.Runner, error) {
 		ConsoleWriter:    a.consoleWriter(),
 		TLSCertDigestPtr: &a.Config.TlsCertDigest,
 		TLSSanList:       a.Config.TlsSanList,
+		KeyAlgorithm:     viper.GetString("tls-key-algorithm"),
+		KeyLength:        viper.GetInt("tls-key-length"),
 	})
 	runner.AddTask("cms-auth-token", "", &tasks.CmsAuthToken{
 		ConsoleWriter: a.consoleWriter(),
@@ -30,6 +30,8 @@ type TLS struct {
 	ConsoleWriter    io.Writer
 	TLSCertDigestPtr *string
 	TLSSanList       string
+	KeyAlgorithm     string
+	KeyLength        int
 	envPrefix        string
 	commandName      string
 }
@@ -37,7 +39,9 @@ type TLS struct {
 const tlsEnvHelpPrompt = "Following environment variables are required for tls setup:"
 
 var tlsEnvHelp = map[string]string{
-	"SAN_LIST": "TLS SAN list",
+	"SAN_LIST":          "TLS SAN list",
+	"TLS_KEY_ALGORITHM": "The algorithm of the TLS key pair, one of rsa, rsa-pss or ecdsa",
+	"TLS_KEY_LENGTH":    "The length of the TLS key pair, 3072 or 4096 for rsa and rsa-pss, 256 or 384 for ecdsa",
 }
 
 func outboundHost() (string, error) {
@@ -58,7 +62,7 @@ func outboundHost() (string, error) {
 	return (conn.LocalAddr().(*net.UDPAddr)).IP.String(), nil
 }
 
-func createTLSCert(hosts string, ca *x509.Certificate, caKey interface{}) (key []byte, cert []byte, err error) {
+func createTLSCert(hosts, keyAlgorithm string, keyLength int, ca *x509.Certificate, caKey interface{}) (key []byte, cert []byte, err error) {
 	log.Trace("tasks/tls:createTLSCert() Entering")
 	defer log.Trace("tasks/tls:createTLSCert() Leaving")
 
@@ -68,7 +72,7 @@ func createTLSCert(hosts string, ca *x509.Certificate, caKey interface{}) (key [
 		Locality:     []string{constants.DefaultLocality},
 		Province:     []string{constants.DefaultProvince},
 		CommonName:   "CMS",
-	}, hosts, constants.DefaultKeyAlgorithm, constants.DefaultKeyAlgorithmLength)
+	}, hosts, keyAlgorithm, keyLength)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "tasks/tls:createTLSCert() Could not create CSR")
 	}
@@ -84,9 +88,6 @@ func createTLSCert(hosts string, ca *x509.Certificate, caKey interface{}) (key [
 	}
 
 	clientCRTTemplate := x509.Certificate{
-		Signature:          clientCSR.Signature,
-		SignatureAlgorithm: clientCSR.SignatureAlgorithm,
-
 		PublicKeyAlgorithm: clientCSR.PublicKeyAlgorithm,
 		PublicKey:          clientCSR.PublicKey,
 
@@ -101,6 +102,10 @@ func createTLSCert(hosts string, ca *x509.Certificate, caKey interface{}) (key [
 		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageContentCommitment,
 		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
 	}
+	clientCRTTemplate.SignatureAlgorithm, err = crypt.GetSignatureAlgorithm(ca.PublicKey)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "tasks/tls:createTLSCert() Unsupported TLS CA key")
+	}
 
 	cert, err = x509.CreateCertificate(rand.Reader, &clientCRTTemplate, ca, clientCSR.PublicKey, caKey)
 	if err != nil {
@@ -127,7 +132,11 @@ func (ts TLS) Run() error {
 
 	tlsCaAttr := constants.GetCaAttribs(constants.Tls)
 	tlsCaCert, tlsCaPrivKey, err := crypt.LoadX509CertAndPrivateKey(tlsCaAttr.CertPath, tlsCaAttr.KeyPath)
-	key, cert, err := createTLSCert(ts.TLSSanList, tlsCaCert, tlsCaPrivKey)
+	keyAlgorithm, keyLength := ts.KeyAlgorithm, ts.KeyLength
+	if keyAlgorithm == "" {
+		keyAlgorithm, keyLength = constants.DefaultKeyAlgorithm, constants.DefaultKeyAlgorithmLength
+	}
+	key, cert, err := createTLSCert(ts.TLSSanList, keyAlgorithm, keyLength, tlsCaCert, tlsCaPrivKey)
 	if err != nil {
 		return errors.Wrap(err, "tasks/tls:Run() Could not create TLS certificate")
 	}
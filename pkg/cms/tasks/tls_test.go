@@ -5,9 +5,12 @@
 package tasks
 
 import (
+	"crypto/ecdsa"
 	"crypto/x509"
 	"encoding/pem"
 	"github.com/intel-secl/intel-secl/v3/pkg/cms/config"
+	"github.com/intel-secl/intel-secl/v3/pkg/cms/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -130,7 +133,7 @@ func TestTlsCertCreation(t *testing.T) {
 	assertions.NoError(err)
 
 	//TODO: need to fix this test. New parameters.. need to pass in issuing CA cert and key
-	keyData, certData, err := createTLSCert("intel.com", testGetRootCACert(), testGetPrivateRootkey())
+	keyData, certData, err := createTLSCert("intel.com", constants.DefaultKeyAlgorithm, constants.DefaultKeyAlgorithmLength, testGetRootCACert(), testGetPrivateRootkey())
 	assertions.NoError(err)
 	_, err = x509.ParsePKCS8PrivateKey(keyData)
 	assertions.NoError(err)
@@ -138,6 +141,16 @@ func TestTlsCertCreation(t *testing.T) {
 	assertions.NoError(err)
 	assertions.Contains(cert.DNSNames, "intel.com")
 	assertions.NoError(cert.VerifyHostname("intel.com"))
+
+	keyData, certData, err = createTLSCert("intel.com", crypt.KeyTypeECDSA, 384, testGetRootCACert(), testGetPrivateRootkey())
+	assertions.NoError(err)
+	key, err := x509.ParsePKCS8PrivateKey(keyData)
+	assertions.NoError(err)
+	assertions.IsType(&ecdsa.PrivateKey{}, key)
+	cert, err = x509.ParseCertificate(certData)
+	assertions.NoError(err)
+	assertions.Equal(x509.ECDSA, cert.PublicKeyAlgorithm)
+	assertions.Equal(x509.SHA384WithRSA, cert.SignatureAlgorithm)
 }
 
 func TestTlsSetupTaskRun(t *testing.T) {
@@ -26,17 +26,25 @@ type UpdateServiceConfig struct {
 const envHelpPrompt = "Following environment variables are required for update-service-config setup:"
 
 var envHelp = map[string]string{
-	"LOG_LEVEL":                  "Log level",
-	"LOG_MAX_LENGTH":             "Max length of log statement",
-	"LOG_ENABLE_STDOUT":          "Enable console log",
-	"AAS_BASE_URL":               "AAS Base URL",
-	"TOKEN_DURATION_MINS":        "Validity of token duration",
-	"SERVER_PORT":                "The Port on which Server Listens to",
-	"SERVER_READ_TIMEOUT":        "Request Read Timeout Duration in Seconds",
-	"SERVER_READ_HEADER_TIMEOUT": "Request Read Header Timeout Duration in Seconds",
-	"SERVER_WRITE_TIMEOUT":       "Request Write Timeout Duration in Seconds",
-	"SERVER_IDLE_TIMEOUT":        "Request Idle Timeout in Seconds",
-	"SERVER_MAX_HEADER_BYTES":    "Max Length Of Request Header in Bytes",
+	"LOG_LEVEL":                               "Log level",
+	"LOG_MAX_LENGTH":                          "Max length of log statement",
+	"LOG_ENABLE_STDOUT":                       "Enable console log",
+	"AAS_BASE_URL":                            "AAS Base URL",
+	"TOKEN_DURATION_MINS":                     "Validity of token duration",
+	"SERVER_PORT":                             "The Port on which Server Listens to",
+	"SERVER_READ_TIMEOUT":                     "Request Read Timeout Duration in Seconds",
+	"SERVER_READ_HEADER_TIMEOUT":              "Request Read Header Timeout Duration in Seconds",
+	"SERVER_WRITE_TIMEOUT":                    "Request Write Timeout Duration in Seconds",
+	"SERVER_IDLE_TIMEOUT":                     "Request Idle Timeout in Seconds",
+	"SERVER_MAX_HEADER_BYTES":                 "Max Length Of Request Header in Bytes",
+	"CMS_BASE_URL":                            "Externally reachable CMS Base URL published in the CRL distribution point and OCSP extensions of issued certificates",
+	"REVOCATION_CRL_UPDATE_INTERVAL":          "Interval at which the CRLs of the issuing CAs are regenerated",
+	"REVOCATION_CRL_VALIDITY":                 "Validity of the generated CRLs",
+	"CSR_SIGNATURE_ALGORITHMS_TLS":            "Comma separated list of signature algorithms accepted in TLS CSRs, e.g. SHA384-RSA,SHA384-RSAPSS,ECDSA-SHA384",
+	"CSR_SIGNATURE_ALGORITHMS_TLS_CLIENT":     "Comma separated list of signature algorithms accepted in TLS-Client CSRs",
+	"CSR_SIGNATURE_ALGORITHMS_SIGNING":        "Comma separated list of signature algorithms accepted in Signing CSRs",
+	"CSR_SIGNATURE_ALGORITHMS_FLAVOR_SIGNING": "Comma separated list of signature algorithms accepted in Flavor-Signing CSRs",
+	"CSR_SIGNATURE_ALGORITHMS_JWT_SIGNING":    "Comma separated list of signature algorithms accepted in JWT-Signing CSRs",
 }
 
 func (uc UpdateServiceConfig) Run() error {
@@ -52,6 +60,12 @@ func (uc UpdateServiceConfig) Run() error {
 	(*uc.AppConfig).AASApiUrl = viper.GetString("aas-base-url")
 
 	(*uc.AppConfig).TokenDurationMins = viper.GetInt("token-duration-mins")
+	(*uc.AppConfig).Revocation = config.RevocationConfig{
+		BaseURL:           viper.GetString("revocation-base-url"),
+		CrlUpdateInterval: viper.GetDuration("revocation-crl-update-interval"),
+		CrlValidity:       viper.GetDuration("revocation-crl-validity"),
+	}
+	(*uc.AppConfig).CsrSignatureAlgorithms = config.CsrSignatureAlgorithmsFromViper()
 	if uc.ServerConfig.Port < 1024 ||
 		uc.ServerConfig.Port > 65535 {
 		uc.ServerConfig.Port = uc.DefaultPort
@@ -5,6 +5,8 @@
 package validation
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/x509"
 	"fmt"
 	"github.com/intel-secl/intel-secl/v3/pkg/cms/config"
@@ -28,9 +30,9 @@ func ValidateCertificateRequest(conf *config.Configuration, csr *x509.Certificat
 	log.Trace("validation/validate_CSR:ValidateCertificateRequest() Entering")
 	defer log.Trace("validation/validate_CSR:ValidateCertificateRequest() Leaving")
 
-	// TODO: We should be able to support other signature algorithms... such as ECDSA with SHA384
-	if csr.SignatureAlgorithm != x509.SHA384WithRSA {
-		return fmt.Errorf("validation/validate_CSR:ValidateCertificateRequest() Incorrect Signature Algorithm used (should be SHA 384 with RSA): %v", csr.SignatureAlgorithm)
+	err := validateSignatureAlgorithm(conf, csr, certType)
+	if err != nil {
+		return errors.Wrap(err, "validation/validate_CSR:ValidateCertificateRequest() Unsupported key or signature algorithm in CSR")
 	}
 	if len(csr.Subject.Names) != 1 {
 		return errors.New("validation/validate_CSR:ValidateCertificateRequest() Only Common Name is supported in Subject")
@@ -94,6 +96,32 @@ func ValidateCertificateRequest(conf *config.Configuration, csr *x509.Certificat
 	return nil
 }
 
+// validateSignatureAlgorithm checks the CSR signature algorithm against the ones configured for the cert type.
+// ECDSA keys are further restricted to the P-256 and P-384 curves.
+func validateSignatureAlgorithm(conf *config.Configuration, csr *x509.CertificateRequest, certType string) error {
+	log.Trace("validation/validate_CSR:validateSignatureAlgorithm() Entering")
+	defer log.Trace("validation/validate_CSR:validateSignatureAlgorithm() Leaving")
+
+	isAlgorithmAllowed := false
+	for _, algorithm := range conf.GetCsrSignatureAlgorithms(certType) {
+		if strings.EqualFold(algorithm, csr.SignatureAlgorithm.String()) {
+			isAlgorithmAllowed = true
+			break
+		}
+	}
+	if !isAlgorithmAllowed {
+		return fmt.Errorf("validation/validate_CSR:validateSignatureAlgorithm() Signature algorithm %v is not allowed for cert type %s", csr.SignatureAlgorithm, certType)
+	}
+
+	if publicKey, ok := csr.PublicKey.(*ecdsa.PublicKey); ok {
+		curve := publicKey.Curve.Params().Name
+		if curve != elliptic.P256().Params().Name && curve != elliptic.P384().Params().Name {
+			return fmt.Errorf("validation/validate_CSR:validateSignatureAlgorithm() Unsupported elliptic curve %s, only P-256 and P-384 are supported", curve)
+		}
+	}
+	return nil
+}
+
 func validateDNSNames(list []string) error {
 	log.Trace("validation/validate_CSR:validateDNSNames() Entering")
 	defer log.Trace("validation/validate_CSR:validateDNSNames() Leaving")
@@ -1,54 +1,121 @@
-/*
- * Copyright (C) 2021 Intel Corporation
- * SPDX-License-Identifier: BSD-3-Clause
- */
-package flavorgen
-
-import (
-	"encoding/json"
-	"fmt"
-
-	commLog "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
-	commFlavor "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
-	flavorType "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/types"
-	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
-	"github.com/pkg/errors"
-)
-
-var defaultLog = commLog.GetDefaultLogger()
-
-//create the flavorpart json
-func createFlavor(platformFlavor flavorType.PlatformFlavor) error {
-	defaultLog.Trace("flavorgen/flavor_create:createFlavor() Entering")
-	defer defaultLog.Trace("flavorgen/flavor_create:createFlavor() Leaving")
-
-	var flavors []hvs.Flavors
-	var err error
-
-	flavorParts := []commFlavor.FlavorPart{commFlavor.FlavorPartPlatform, commFlavor.FlavorPartOs, commFlavor.FlavorPartHostUnique}
-	for _, flavorPart := range flavorParts {
-		unSignedFlavors, err := platformFlavor.GetFlavorPartRaw(flavorPart)
-		if err != nil {
-			return errors.Wrapf(err, "flavorgen/flavor_create:createFlavor() Unable to create flavor part %s", flavorPart)
-		}
-		for _, flvr := range unSignedFlavors {
-			flavor := hvs.Flavors{
-				Flavor: flvr,
-			}
-			flavors = append(flavors, flavor)
-		}
-	}
-
-	flavorCollection := hvs.FlavorCollection{
-		Flavors: flavors,
-	}
-
-	flavorJSON, err := json.Marshal(flavorCollection)
-	if err != nil {
-		return errors.Wrapf(err, "flavorgen/flavor_create:createFlavor() Couldn't marshal signedflavorCollection")
-	}
-	flavorPartJSON := string(flavorJSON)
-	fmt.Println(flavorPartJSON)
-
-	return nil
-}
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package flavorgen
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
+	commLog "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
+	commFlavor "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	flavorModel "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	flavorType "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+var defaultLog = commLog.GetDefaultLogger()
+
+//create the flavorpart json
+func createFlavor(platformFlavor flavorType.PlatformFlavor) error {
+	defaultLog.Trace("flavorgen/flavor_create:createFlavor() Entering")
+	defer defaultLog.Trace("flavorgen/flavor_create:createFlavor() Leaving")
+
+	var flavors []hvs.Flavors
+	var err error
+
+	flavorParts := []commFlavor.FlavorPart{commFlavor.FlavorPartPlatform, commFlavor.FlavorPartOs, commFlavor.FlavorPartHostUnique}
+	for _, flavorPart := range flavorParts {
+		unSignedFlavors, err := platformFlavor.GetFlavorPartRaw(flavorPart)
+		if err != nil {
+			return errors.Wrapf(err, "flavorgen/flavor_create:createFlavor() Unable to create flavor part %s", flavorPart)
+		}
+		for _, flvr := range unSignedFlavors {
+			flavor := hvs.Flavors{
+				Flavor: flvr,
+			}
+			flavors = append(flavors, flavor)
+		}
+	}
+
+	flavorCollection := hvs.FlavorCollection{
+		Flavors: flavors,
+	}
+
+	flavorJSON, err := json.Marshal(flavorCollection)
+	if err != nil {
+		return errors.Wrapf(err, "flavorgen/flavor_create:createFlavor() Couldn't marshal signedflavorCollection")
+	}
+	flavorPartJSON := string(flavorJSON)
+	fmt.Println(flavorPartJSON)
+
+	return nil
+}
+
+//create the signed flavorgroup bundle json, with the PLATFORM and OS flavors signed with the flavor signing key
+func createFlavorgroupBundle(platformFlavor flavorType.PlatformFlavor, flavorTemplates []hvs.FlavorTemplate, flavorgroupName, signingKeyFile, signingCertFile string) error {
+	defaultLog.Trace("flavorgen/flavor_create:createFlavorgroupBundle() Entering")
+	defer defaultLog.Trace("flavorgen/flavor_create:createFlavorgroupBundle() Leaving")
+
+	key, err := crypt.GetPrivateKeyFromPKCS8File(signingKeyFile)
+	if err != nil {
+		return errors.Wrap(err, "flavorgen/flavor_create:createFlavorgroupBundle() Unable to load flavor signing key")
+	}
+	signingKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return errors.New("flavorgen/flavor_create:createFlavorgroupBundle() Flavor signing key is not a RSA key")
+	}
+
+	signingCerts, err := crypt.GetSubjectCertsMapFromPemFile(signingCertFile)
+	if err != nil {
+		return errors.Wrap(err, "flavorgen/flavor_create:createFlavorgroupBundle() Unable to load flavor signing certificate chain")
+	}
+	if len(signingCerts) == 0 {
+		return errors.New("flavorgen/flavor_create:createFlavorgroupBundle() Flavor signing certificate file has no certificate")
+	}
+
+	var signedFlavors []hvs.SignedFlavor
+	flavorParts := []commFlavor.FlavorPart{commFlavor.FlavorPartPlatform, commFlavor.FlavorPartOs}
+	for _, flavorPart := range flavorParts {
+		unSignedFlavors, err := platformFlavor.GetFlavorPartRaw(flavorPart)
+		if err != nil {
+			return errors.Wrapf(err, "flavorgen/flavor_create:createFlavorgroupBundle() Unable to create flavor part %s", flavorPart)
+		}
+		for i := range unSignedFlavors {
+			signedFlavor, err := flavorModel.NewSignedFlavor(&unSignedFlavors[i], signingKey)
+			if err != nil {
+				return errors.Wrapf(err, "flavorgen/flavor_create:createFlavorgroupBundle() Unable to sign flavor part %s", flavorPart)
+			}
+			signedFlavors = append(signedFlavors, *signedFlavor)
+		}
+	}
+
+	// the flavorgroup gets the automatic flavor match policy of the flavorgroups created by HVS
+	flavorgroup := hvs.FlavorGroup{
+		Name: flavorgroupName,
+		MatchPolicies: []hvs.FlavorMatchPolicy{
+			hvs.NewFlavorMatchPolicy(commFlavor.FlavorPartPlatform, hvs.NewMatchPolicy(hvs.MatchTypeAnyOf, hvs.FlavorRequired)),
+			hvs.NewFlavorMatchPolicy(commFlavor.FlavorPartOs, hvs.NewMatchPolicy(hvs.MatchTypeAnyOf, hvs.FlavorRequired)),
+			hvs.NewFlavorMatchPolicy(commFlavor.FlavorPartSoftware, hvs.NewMatchPolicy(hvs.MatchTypeAllOf, hvs.FlavorRequiredIfDefined)),
+			hvs.NewFlavorMatchPolicy(commFlavor.FlavorPartAssetTag, hvs.NewMatchPolicy(hvs.MatchTypeLatest, hvs.FlavorRequiredIfDefined)),
+			hvs.NewFlavorMatchPolicy(commFlavor.FlavorPartHostUnique, hvs.NewMatchPolicy(hvs.MatchTypeLatest, hvs.FlavorRequiredIfDefined)),
+		},
+	}
+	bundle := hvs.NewFlavorgroupBundle(flavorgroup, signedFlavors, flavorTemplates, signingCerts)
+	signedBundle, err := hvs.NewSignedFlavorgroupBundle(bundle, signingKey)
+	if err != nil {
+		return errors.Wrap(err, "flavorgen/flavor_create:createFlavorgroupBundle() Unable to sign flavorgroup bundle")
+	}
+
+	bundleJSON, err := json.Marshal(signedBundle)
+	if err != nil {
+		return errors.Wrapf(err, "flavorgen/flavor_create:createFlavorgroupBundle() Couldn't marshal flavorgroup bundle")
+	}
+	fmt.Println(string(bundleJSON))
+
+	return nil
+}
@@ -165,6 +165,10 @@ flavorgen <command> [arguments]
 Available Commands:
 	-f                     To provide Flavor template json file
 	-m                     To provide Hostmanifest json file
+	-bundle                To create a signed flavorgroup bundle with the given flavorgroup name, that can be
+	                       imported in HVS. The bundle holds the PLATFORM and OS flavors and the flavor templates
+	-key                   To provide the PKCS8 flavor signing key file, required with -bundle
+	-cert                  To provide the flavor signing certificate chain pem file, required with -bundle
 	help|-h|--help         Show this help message
 	-log                   To log the execution
 	-version               print the current version
@@ -213,6 +217,9 @@ func (flavorgen FlavorGen) GenerateFlavors() {
 	// the flag's name, the default value, and a short description (displayed whith the option --help)
 	flag.Var(&flavortemplateargs, "f", "flavor-template json file")
 	manifestFilePath := flag.String("m", "", "host-manifest json file")
+	bundleFlavorgroup := flag.String("bundle", "", "flavorgroup name of the signed flavorgroup bundle")
+	signingKeyFilePath := flag.String("key", "", "flavor signing key file")
+	signingCertFilePath := flag.String("cert", "", "flavor signing certificate chain file")
 	versionFlag := flag.Bool("version", false, "Print the current version and exit")
 
 	// Showing useful information when the user enters the --help option
@@ -237,6 +244,11 @@ func (flavorgen FlavorGen) GenerateFlavors() {
 		exitGracefully(errors.New("Flavor template path missing"))
 	}
 
+	// Check for the signing key and certificate of the bundle
+	if *bundleFlavorgroup != "" && (*signingKeyFilePath == "" || *signingCertFilePath == "") {
+		exitGracefully(errors.New("Flavor signing key and certificate paths are required to create a flavorgroup bundle"))
+	}
+
 	// Validating the Manifest file entered
 	if valid, err := checkIfValidFile(*manifestFilePath); err != nil && !valid {
 		defaultLog.Info("flavorgen/flavor_gen:main() Not a valid hostmanifest file %s", err)
@@ -267,6 +279,16 @@ func (flavorgen FlavorGen) GenerateFlavors() {
 	var rp types.PlatformFlavor
 	rp = types.NewHostPlatformFlavor(&hostmanifest, nil, flavorTemplates)
 
+	if *bundleFlavorgroup != "" {
+		// Create the signed flavorgroup bundle json
+		err = createFlavorgroupBundle(rp, flavorTemplates, *bundleFlavorgroup, *signingKeyFilePath, *signingCertFilePath)
+		if err != nil {
+			defaultLog.WithError(err).Info("flavorgen/flavor_gen:main() Unable to create flavorgroup bundle")
+			exitGracefully(errors.New("Unable to create flavorgroup bundle"))
+		}
+		return
+	}
+
 	// Create the flavor json
 	err = createFlavor(rp)
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hrrs"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/notification"
 	commConfig "github.com/intel-secl/intel-secl/v3/pkg/lib/common/config"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -35,6 +36,9 @@ type Configuration struct {
 
 	Dek             string `yaml:"data-encryption-key" mapstructure:"data-encryption-key"`
 	AikCertValidity int    `yaml:"aik-certificate-validity-years" mapstructure:"aik-certificate-validity-years"`
+	// AikCertExpiryWarningDays is the number of days before the expiry of an AIK certificate the host trust report
+	// raises a fault, 0 disables the warning
+	AikCertExpiryWarningDays int `yaml:"aik-certificate-expiry-warning-days" mapstructure:"aik-certificate-expiry-warning-days"`
 
 	Server commConfig.ServerConfig `yaml:"server" mapstructure:"server"`
 	Log    commConfig.LogConfig    `yaml:"log" mapstructure:"log"`
@@ -42,6 +46,10 @@ type Configuration struct {
 	HRRS   hrrs.HRRSConfig         `yaml:"hrrs" mapstructure:"hrrs"`
 	FVS    FVSConfig               `yaml:"fvs" mapstructure:"fvs"`
 	VCSS   VCSSConfig              `yaml:"vcss" mapstructure:"vcss"`
+
+	Simulator SimulatorConfig `yaml:"simulator" mapstructure:"simulator"`
+
+	Notification notification.NotificationConfig `yaml:"notification" mapstructure:"notification"`
 }
 
 type FVSConfig struct {
@@ -68,6 +76,15 @@ type VCSSConfig struct {
 	RefreshPeriod time.Duration `yaml:"refresh-period" mapstructure:"refresh-period"`
 }
 
+type SimulatorConfig struct {
+	// Enabled allows the registration of simulated hosts, with the connection string simulator:sim://<host>/<profile>.
+	// It must only be enabled for scale and demo environments. The AIK certificates of the simulated hosts are issued by
+	// a simulator CA created at startup, which is trusted by the host verification only while this is enabled.
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// ProfileDir holds the JSON files of the simulated host profiles, in addition to the built-in profiles
+	ProfileDir string `yaml:"profile-dir" mapstructure:"profile-dir"`
+}
+
 // this function sets the configure file name and type
 func init() {
 	viper.SetConfigName(constants.ConfigFile)
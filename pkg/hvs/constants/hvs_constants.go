@@ -92,6 +92,9 @@ const (
 	HostSigningKeyCertificateCN    = "Signing_Key_Certificate"
 	HostBindingKeyCertificateCN    = "Binding_Key_Certificate"
 	DefaultPrivacyCaIdentityIssuer = "hvs-pca-aik"
+
+	DefaultAikCertificateExpiryWarningDays = 30
+	DefaultAikCrlValidity                  = 24 * time.Hour
 )
 
 // general constants for certificates
@@ -163,6 +166,13 @@ const (
 	DefaultVcssRefreshPeriod = time.Duration(2) * time.Minute
 )
 
+// simulator constants
+const (
+	DefaultSimulatorProfileDir   = ConfigDir + "simulator-profiles/"
+	DefaultSimulatorCaCommonName = "HVS Simulator AIK CA"
+	DefaultSimulatorCaValidity   = 365 * 24 * time.Hour
+)
+
 // audit log constants
 const (
 	DefaultMaxRowCount       = 10000
@@ -170,11 +180,40 @@ const (
 	DefaultChannelBufferSize = 5000
 )
 
+// bulk host registration constants
+const (
+	MaxBulkHostRegistrationEntries     = 1000
+	DefaultBulkHostRegistrationWorkers = 10
+)
+
+// job runner constants
+const (
+	DefaultJobRunnerWorkers   = 2
+	DefaultJobRunnerQueueSize = 16
+	JobRunnerStopTimeout      = 30 * time.Second
+)
+
+// flavor simulation constants
+const (
+	MaxFlavorSimulationHosts = 100
+)
+
 // Search APIs filter constants
 const (
 	MaxNumDaysSearchLimit = 365
 )
 
+// compliance report constants
+const (
+	DefaultComplianceTopFailingRules = 10
+	MaxComplianceTopFailingRules     = 100
+	// ComplianceSignatureHeader holds the base64 encoded signature of the compliance report documents, made with
+	// the SAML signing key
+	ComplianceSignatureHeader          = "X-Signature"
+	ComplianceSignatureAlgorithmHeader = "X-Signature-Algorithm"
+	ComplianceSignatureAlgorithm       = "RSA-PKCS1v15-SHA384"
+)
+
 //Schema location constansts
 const (
 	CommonDefinitionsSchema = "/etc/hvs/schema/common.schema.json"
@@ -190,4 +229,12 @@ const (
 	FvsSkipFlavorSignatureVerification = "fvs-skip-flavor-signature-verification"
 	HrrsRefreshPeriod                  = "hrrs-refresh-period"
 	VcssRefreshPeriod                  = "vcss-refresh-period"
+	SimulatorEnabled                   = "simulator-enabled"
+	SimulatorProfileDir                = "simulator-profile-dir"
+
+	NotificationNumberOfDeliveryWorkers = "notification-number-of-delivery-workers"
+	NotificationMaxDeliveryAttempts     = "notification-max-delivery-attempts"
+	NotificationRetryBackoff            = "notification-retry-backoff"
+	NotificationDeliveryTimeout         = "notification-delivery-timeout"
+	NotificationEventBufferSize         = "notification-event-buffer-size"
 )
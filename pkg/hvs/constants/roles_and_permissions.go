@@ -13,6 +13,8 @@ const (
 	FlavorGroupRetrieve = "flavorgroups:retrieve"
 	FlavorGroupSearch   = "flavorgroups:search"
 	FlavorGroupDelete   = "flavorgroups:delete"
+	FlavorGroupExport   = "flavorgroups:export"
+	FlavorGroupImport   = "flavorgroups:import"
 
 	CertifyAik = "host_aiks:certify"
 
@@ -29,6 +31,11 @@ const (
 	HostDelete   = "hosts:delete"
 	HostSearch   = "hosts:search"
 
+	JobRetrieve = "jobs:retrieve"
+
+	QueueSearch = "queue:search"
+	QueueDelete = "queue:delete"
+
 	//FlavorTemplate Permissions.
 	FlavorTemplateCreate   = "flavor-template:create"
 	FlavorTemplateRetrieve = "flavor-template:retrieve"
@@ -39,6 +46,8 @@ const (
 	FlavorRetrieve = "flavors:retrieve"
 	FlavorSearch   = "flavors:search"
 	FlavorDelete   = "flavors:delete"
+	FlavorUpdate   = "flavors:update"
+	FlavorSimulate = "flavors:simulate"
 
 	TagFlavorCreate        = "tag_flavors:create"
 	HostUniqueFlavorCreate = "host_unique_flavors:create"
@@ -57,10 +66,30 @@ const (
 	TpmEndorsementSearch   = "tpm_endorsements:search"
 	TpmEndorsementDelete   = "tpm_endorsements:delete"
 
+	EndorsementAuthorityCreate   = "endorsement_authorities:create"
+	EndorsementAuthorityStore    = "endorsement_authorities:store"
+	EndorsementAuthorityRetrieve = "endorsement_authorities:retrieve"
+	EndorsementAuthoritySearch   = "endorsement_authorities:search"
+	EndorsementAuthorityDelete   = "endorsement_authorities:delete"
+
+	AikCertificateRetrieve = "aik_certificates:retrieve"
+	AikCertificateSearch   = "aik_certificates:search"
+	AikCertificateRevoke   = "aik_certificates:revoke"
+
 	ReportCreate   = "reports:create"
 	ReportRetrieve = "reports:retrieve"
 	ReportSearch   = "reports:search"
 
+	NotificationSubscriptionCreate   = "notification_subscriptions:create"
+	NotificationSubscriptionRetrieve = "notification_subscriptions:retrieve"
+	NotificationSubscriptionSearch   = "notification_subscriptions:search"
+	NotificationSubscriptionDelete   = "notification_subscriptions:delete"
+	NotificationDeadLetterSearch     = "notification_dead_letters:search"
+	NotificationDeadLetterDelete     = "notification_dead_letters:delete"
+	NotificationStream               = "notifications:stream"
+
+	MetricsRetrieve = "metrics:retrieve"
+
 	// AssetTagAPI
 	TagCertificateCreate = "tag_certificates:create"
 	TagCertificateDelete = "tag_certificates:delete"
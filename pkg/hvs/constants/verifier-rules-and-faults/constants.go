@@ -22,23 +22,38 @@ const (
 	RuleXmlMeasurementLogEquals     = RulePrefix + "XmlMeasurementLogEquals"
 	RulePcrEventLogEqualsExcluding  = RulePrefix + "PcrEventLogEqualsExcluding"
 	RuleXmlMeasurementLogIntegrity  = RulePrefix + "XmlMeasurementLogIntegrity"
+	RuleHardwareFeatureEnabled      = RulePrefix + "HardwareFeatureEnabled"
+	RuleHostInfoVersionAtLeast      = RulePrefix + "HostInfoVersionAtLeast"
+	RuleHostInfoMatches             = RulePrefix + "HostInfoMatches"
+	// RuleCustomPrefix prefixes the name of the custom rules in the trust report
+	RuleCustomPrefix = RulePrefix + "custom."
 )
 
 // Verifier Faults
 const (
 	FaultPrefix                                     = PolicyPrefix + "fault."
 	FaultAikCertificateExpired                      = FaultPrefix + "AikCertificateExpired"
+	FaultAikCertificateExpiringSoon                 = FaultPrefix + "AikCertificateExpiringSoon"
 	FaultAikCertificateMissing                      = FaultPrefix + "AikCertificateMissing"
 	FaultAikCertificateNotTrusted                   = FaultPrefix + "AikCertificateNotTrusted"
 	FaultAikCertificateNotYetValid                  = FaultPrefix + "AikCertificateNotYetValid"
+	FaultAikCertificateRevoked                      = FaultPrefix + "AikCertificateRevoked"
 	FaultAllofFlavorsMissing                        = FaultPrefix + "AllOfFlavorsMissing"
 	FaultAssetTagMismatch                           = FaultPrefix + "AssetTagMismatch"
 	FaultAssetTagMissing                            = FaultPrefix + "AssetTagMissing"
 	FaultAssetTagNotProvisioned                     = FaultPrefix + "AssetTagNotProvisioned"
+	FaultCustomRuleEvaluationFailed                 = FaultPrefix + "CustomRuleEvaluationFailed"
+	FaultCustomRuleNotSatisfied                     = FaultPrefix + "CustomRuleNotSatisfied"
 	FaultFlavorSignatureMissing                     = FaultPrefix + "FlavorSignatureMissing"
 	FaultRequiredFlavorTypeMissing                  = FaultPrefix + "RequiredFlavorTypeMissing"
 	FaultFlavorSignatureNotTrusted                  = FaultPrefix + "FlavorSignatureNotTrusted"
 	FaultFlavorSignatureVerificationFailed          = FaultPrefix + "FlavorSignatureVerificationFailed"
+	FaultHardwareFeatureNotEnabled                  = FaultPrefix + "HardwareFeatureNotEnabled"
+	FaultHardwareFeatureNotSupported                = FaultPrefix + "HardwareFeatureNotSupported"
+	FaultHostInfoMismatch                           = FaultPrefix + "HostInfoMismatch"
+	FaultHostInfoMissing                            = FaultPrefix + "HostInfoMissing"
+	FaultHostInfoVersionBelowMinimum                = FaultPrefix + "HostInfoVersionBelowMinimum"
+	FaultHostInfoVersionInvalid                     = FaultPrefix + "HostInfoVersionInvalid"
 	FaultPcrEventLogContainsUnexpectedEntries       = FaultPrefix + "PcrEventLogContainsUnexpectedEntries"
 	FaultPcrEventLogInvalid                         = FaultPrefix + "PcrEventLogInvalid"
 	FaultPcrEventLogMissing                         = FaultPrefix + "PcrEventLogMissing"
@@ -0,0 +1,290 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/groupcache/lru"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+type AikCertificateController struct {
+	Store          domain.AikCertificateStore
+	CertStore      *models.CertificatesStore
+	CrlValidity    time.Duration
+	CrlCache       *utils.AikCrlCache
+	HostStore      domain.HostStore
+	HTManager      domain.HostTrustManager
+	HostTrustCache *lru.Cache
+}
+
+func NewAikCertificateController(store domain.AikCertificateStore, certStore *models.CertificatesStore, crlValidity time.Duration,
+	crlCache *utils.AikCrlCache, hostStore domain.HostStore, htManager domain.HostTrustManager, hostTrustCache *lru.Cache) *AikCertificateController {
+	return &AikCertificateController{
+		Store:          store,
+		CertStore:      certStore,
+		CrlValidity:    crlValidity,
+		CrlCache:       crlCache,
+		HostStore:      hostStore,
+		HTManager:      htManager,
+		HostTrustCache: hostTrustCache,
+	}
+}
+
+var aikCertificateSearchParams = map[string]bool{"id": true, "hardwareUuid": true, "serialNumber": true,
+	"ekCertificateDigest": true, "revoked": true, "expiresBefore": true}
+
+func (controller AikCertificateController) Retrieve(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/aik_certificate_controller:Retrieve() Entering")
+	defer defaultLog.Trace("controllers/aik_certificate_controller:Retrieve() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+
+	aikCertificate, err := controller.Store.Retrieve(id)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			defaultLog.WithError(err).WithField("id", id).Info(
+				"controllers/aik_certificate_controller:Retrieve() AIK certificate with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "AIK certificate with given ID does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", id).Error(
+			"controllers/aik_certificate_controller:Retrieve() Failed to retrieve AIK certificate")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve AIK certificate"}
+	}
+
+	secLog.WithField("id", id).Infof("AIK certificate retrieved by: %s", r.RemoteAddr)
+	return aikCertificate, http.StatusOK, nil
+}
+
+func (controller AikCertificateController) Search(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/aik_certificate_controller:Search() Entering")
+	defer defaultLog.Trace("controllers/aik_certificate_controller:Search() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), aikCertificateSearchParams); err != nil {
+		secLog.Errorf("controllers/aik_certificate_controller:Search() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	filter, err := getAikCertificateFilterCriteria(r.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/aik_certificate_controller:Search() %s", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	aikCertificates, err := controller.Store.Search(filter)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/aik_certificate_controller:Search() AIK certificate search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search AIK certificates"}
+	}
+
+	secLog.Infof("%s: Return AIK certificate query result to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return hvs.AikCertificateCollection{AikCertificates: aikCertificates}, http.StatusOK, nil
+}
+
+// Revoke marks the AIK certificate as revoked, so that it is listed in the next CRL of the privacy CA. The hosts with the
+// hardware UUID of the AIK certificate are dropped from the host trust cache and queued for verification.
+func (controller AikCertificateController) Revoke(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/aik_certificate_controller:Revoke() Entering")
+	defer defaultLog.Trace("controllers/aik_certificate_controller:Revoke() Leaving")
+
+	if r.Header.Get("Content-Type") != consts.HTTPMediaTypeJson {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+	id := uuid.MustParse(mux.Vars(r)["id"])
+
+	revocation := hvs.AikCertificateRevocation{}
+	if r.ContentLength != 0 {
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&revocation); err != nil {
+			secLog.WithError(err).Errorf("controllers/aik_certificate_controller:Revoke() %s :  Failed to decode"+
+				" request body as AIK certificate revocation", commLogMsg.InvalidInputBadEncoding)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+		}
+	}
+	if revocation.Reason == "" {
+		revocation.Reason = hvs.AikRevocationReasonUnspecified
+	}
+	if _, ok := revocation.Reason.CrlReasonCode(); !ok {
+		secLog.Errorf("controllers/aik_certificate_controller:Revoke() %s : Invalid revocation reason %s",
+			commLogMsg.InvalidInputBadParam, revocation.Reason)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid revocation reason"}
+	}
+
+	aikCertificate, err := controller.Store.Retrieve(id)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			defaultLog.WithError(err).WithField("id", id).Info(
+				"controllers/aik_certificate_controller:Revoke() AIK certificate with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "AIK certificate with given ID does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", id).Error(
+			"controllers/aik_certificate_controller:Revoke() Failed to retrieve AIK certificate")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to revoke AIK certificate"}
+	}
+	if aikCertificate.Revoked {
+		secLog.WithField("id", id).Warningf("%s: Trying to revoke an already revoked AIK certificate from addr: %s",
+			commLogMsg.InvalidInputBadParam, r.RemoteAddr)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "AIK certificate is already revoked"}
+	}
+
+	revocationTime := time.Now().UTC()
+	aikCertificate.Revoked = true
+	aikCertificate.RevocationTime = &revocationTime
+	aikCertificate.RevocationReason = revocation.Reason
+	revokedAikCertificate, err := controller.Store.Update(aikCertificate)
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error(
+			"controllers/aik_certificate_controller:Revoke() Failed to update AIK certificate")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to revoke AIK certificate"}
+	}
+	controller.CrlCache.Invalidate()
+
+	secLog.WithField("id", id).WithField("reason", revocation.Reason).Infof("%s: AIK certificate revoked by: %s",
+		commLogMsg.PrivilegeModified, r.RemoteAddr)
+
+	if err := controller.reverifyHosts(revokedAikCertificate); err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error(
+			"controllers/aik_certificate_controller:Revoke() Failed to re-verify hosts attesting with the AIK certificate")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to re-verify hosts " +
+			"associated with revoked AIK certificate"}
+	}
+	return revokedAikCertificate, http.StatusOK, nil
+}
+
+// reverifyHosts drops the hosts with the hardware UUID of the AIK certificate from the host trust cache, so that their
+// last quote is not trusted again, and adds them to the flavor-verify queue
+func (controller AikCertificateController) reverifyHosts(aikCertificate *hvs.AikCertificate) error {
+	if aikCertificate.HardwareUUID == nil || *aikCertificate.HardwareUUID == uuid.Nil {
+		return nil
+	}
+	hosts, err := controller.HostStore.Search(&models.HostFilterCriteria{HostHardwareId: *aikCertificate.HardwareUUID}, nil)
+	if err != nil {
+		return errors.Wrap(err, "Error while searching the hosts with the hardware UUID of the AIK certificate")
+	}
+	if len(hosts) == 0 {
+		return nil
+	}
+	hostIds := make([]uuid.UUID, 0, len(hosts))
+	for _, host := range hosts {
+		controller.HostTrustCache.Remove(host.Id)
+		hostIds = append(hostIds, host.Id)
+	}
+	defaultLog.Debugf("Found %v hosts to be added to flavor-verify queue", len(hostIds))
+	return controller.HTManager.VerifyHostsAsync(hostIds, false, false)
+}
+
+// Crl returns the PEM encoded CRL of the privacy CA, listing the revoked AIK certificates that are not expired yet. The
+// CRL is cached, it is signed again after a revocation or once half of its validity has elapsed.
+func (controller AikCertificateController) Crl(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/aik_certificate_controller:Crl() Entering")
+	defer defaultLog.Trace("controllers/aik_certificate_controller:Crl() Leaving")
+
+	crl, err := controller.CrlCache.Get(controller.CrlValidity, controller.createCrl)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/aik_certificate_controller:Crl() Error while creating CRL")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create CRL"}
+	}
+	return string(crl), http.StatusOK, nil
+}
+
+func (controller AikCertificateController) createCrl() ([]byte, error) {
+	pcaKey, pcaCerts, err := controller.CertStore.GetKeyAndCertificates(models.CaCertTypesPrivacyCa.String())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error while retrieving privacy CA key and certificate")
+	}
+	if pcaKey == nil || len(pcaCerts) == 0 {
+		return nil, errors.New("Privacy CA key and certificate are not available")
+	}
+	pcaSigner, ok := pcaKey.(crypto.Signer)
+	if !ok || pcaCerts[0].KeyUsage&x509.KeyUsageCRLSign == 0 {
+		return nil, errors.New("Privacy CA can not sign CRLs")
+	}
+
+	revoked := true
+	revokedAikCertificates, err := controller.Store.Search(&models.AikCertificateFilterCriteria{
+		Revoked:      &revoked,
+		ExpiresAfter: time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "AIK certificate search failed")
+	}
+
+	crl, err := utils.CreateAikCrl(revokedAikCertificates, &pcaCerts[0], pcaSigner, controller.CrlValidity)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crl}), nil
+}
+
+func getAikCertificateFilterCriteria(params url.Values) (*models.AikCertificateFilterCriteria, error) {
+	defaultLog.Trace("controllers/aik_certificate_controller:getAikCertificateFilterCriteria() Entering")
+	defer defaultLog.Trace("controllers/aik_certificate_controller:getAikCertificateFilterCriteria() Leaving")
+
+	criteria := models.AikCertificateFilterCriteria{}
+	if id := strings.TrimSpace(params.Get("id")); id != "" {
+		parsedId, err := uuid.Parse(id)
+		if err != nil {
+			return nil, errors.New("Invalid id query param value, must be UUID")
+		}
+		criteria.Id = parsedId
+	}
+	if hardwareUuid := strings.TrimSpace(params.Get("hardwareUuid")); hardwareUuid != "" {
+		parsedHardwareUuid, err := uuid.Parse(hardwareUuid)
+		if err != nil {
+			return nil, errors.New("Invalid hardwareUuid query param value, must be UUID")
+		}
+		criteria.HardwareUUID = parsedHardwareUuid
+	}
+	if serialNumber := strings.TrimSpace(params.Get("serialNumber")); serialNumber != "" {
+		parsedSerialNumber, ok := new(big.Int).SetString(serialNumber, 16)
+		if !ok || parsedSerialNumber.Sign() < 0 {
+			return nil, errors.New("Invalid serialNumber query param value, must be hex")
+		}
+		criteria.SerialNumberEqualTo = parsedSerialNumber.Text(16)
+	}
+	if digest := strings.ToLower(strings.TrimSpace(params.Get("ekCertificateDigest"))); digest != "" {
+		if err := validation.ValidateHexString(digest); err != nil {
+			return nil, errors.New("Invalid ekCertificateDigest query param value, must be hex")
+		}
+		criteria.EkCertificateDigestEqualTo = digest
+	}
+	if revoked := strings.TrimSpace(params.Get("revoked")); revoked != "" {
+		parsedRevoked, err := strconv.ParseBool(revoked)
+		if err != nil {
+			return nil, errors.New("Invalid revoked query param value, must be true or false")
+		}
+		criteria.Revoked = &parsedRevoked
+	}
+	if expiresBefore := strings.TrimSpace(params.Get("expiresBefore")); expiresBefore != "" {
+		parsedExpiresBefore, err := utils.ParseDateQueryParam(expiresBefore)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid expiresBefore query param value")
+		}
+		criteria.ExpiresBefore = parsedExpiresBefore
+	}
+	return &criteria, nil
+}
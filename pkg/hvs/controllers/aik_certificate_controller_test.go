@@ -0,0 +1,324 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/golang/groupcache/lru"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	hvsRoutes "github.com/intel-secl/intel-secl/v3/pkg/hvs/router"
+	smocks "github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// newCrlSigningCertStore returns a certificate store with a privacy CA allowed to sign CRLs
+func newCrlSigningCertStore() *models.CertificatesStore {
+	pcaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).NotTo(HaveOccurred())
+	pcaTemplate := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "HVS Privacy Certificate"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(5, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	pcaCert := createTestCertificate(&pcaTemplate, &pcaTemplate, &pcaKey.PublicKey, pcaKey)
+	return &models.CertificatesStore{
+		models.CaCertTypesPrivacyCa.String(): &models.CertificateStore{Key: pcaKey, Certificates: []x509.Certificate{*pcaCert}},
+	}
+}
+
+var _ = Describe("AikCertificateController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var aikStore *mocks.MockAikCertificateStore
+	var aikCertificateController *controllers.AikCertificateController
+	var hostTrustCache *lru.Cache
+	var hostId, hardwareUuid uuid.UUID
+	var validAik, revokedAik, expiredAik *hvs.AikCertificate
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		aikStore = mocks.NewMockAikCertificateStore()
+		hostTrustCache = lru.New(10)
+		aikCertificateController = controllers.NewAikCertificateController(aikStore, newCrlSigningCertStore(), time.Hour,
+			utils.NewAikCrlCache(), mocks.NewMockHostStore(), &smocks.MockHostTrustManager{}, hostTrustCache)
+		// host localhost1 of the mock host store
+		hostId = uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+		hardwareUuid = uuid.MustParse("e57e5ea0-d465-461e-882d-1600090caa0d")
+
+		now := time.Now().UTC()
+		revocationTime := now.Add(-time.Hour)
+		var err error
+		validAik, err = aikStore.Create(&hvs.AikCertificate{HardwareUUID: &hardwareUuid, SerialNumber: "1a2b",
+			EkCertificateDigest: "ab01", Issued: now.Add(-time.Hour), Expiry: now.AddDate(1, 0, 0)})
+		Expect(err).NotTo(HaveOccurred())
+		revokedAik, err = aikStore.Create(&hvs.AikCertificate{HardwareUUID: &hardwareUuid, SerialNumber: "1a2a",
+			EkCertificateDigest: "ab01", Issued: now.Add(-2 * time.Hour), Expiry: now.AddDate(1, 0, 0), Revoked: true,
+			RevocationTime: &revocationTime, RevocationReason: hvs.AikRevocationReasonSuperseded})
+		Expect(err).NotTo(HaveOccurred())
+		expiredAik, err = aikStore.Create(&hvs.AikCertificate{SerialNumber: "ff", EkCertificateDigest: "cd02",
+			Issued: now.AddDate(-2, 0, 0), Expiry: now.AddDate(-1, 0, 0), Revoked: true,
+			RevocationTime: &revocationTime, RevocationReason: hvs.AikRevocationReasonKeyCompromise})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	// Specs for HTTP Get to "/aik-certificates"
+	Describe("Search AIK certificates", func() {
+		search := func(query string) {
+			router.Handle("/aik-certificates", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(aikCertificateController.Search))).Methods("GET")
+			req, err := http.NewRequest("GET", "/aik-certificates"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+		}
+
+		Context("Search AIK certificates of a host", func() {
+			It("Should return the AIK certificates ordered by issue date", func() {
+				search("?hardwareUuid=" + hardwareUuid.String())
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var collection hvs.AikCertificateCollection
+				err := json.Unmarshal(w.Body.Bytes(), &collection)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(collection.AikCertificates).To(HaveLen(2))
+				Expect(collection.AikCertificates[0].ID).To(Equal(revokedAik.ID))
+				Expect(collection.AikCertificates[1].ID).To(Equal(validAik.ID))
+			})
+		})
+		Context("Search revoked AIK certificates by serial number", func() {
+			It("Should ignore the case and the leading zeros of the serial number", func() {
+				search("?revoked=true&serialNumber=001A2A")
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var collection hvs.AikCertificateCollection
+				err := json.Unmarshal(w.Body.Bytes(), &collection)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(collection.AikCertificates).To(HaveLen(1))
+				Expect(collection.AikCertificates[0].ID).To(Equal(revokedAik.ID))
+			})
+		})
+		Context("Search AIK certificates expiring before a date", func() {
+			It("Should return the expired AIK certificate", func() {
+				search("?expiresBefore=" + time.Now().UTC().Format("2006-01-02"))
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var collection hvs.AikCertificateCollection
+				err := json.Unmarshal(w.Body.Bytes(), &collection)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(collection.AikCertificates).To(HaveLen(1))
+				Expect(collection.AikCertificates[0].ID).To(Equal(expiredAik.ID))
+			})
+		})
+		Context("Provide an invalid serial number", func() {
+			It("Should get HTTP Status: 400", func() {
+				search("?serialNumber=xyz")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide an unknown query parameter", func() {
+			It("Should get HTTP Status: 400", func() {
+				search("?issuer=pca")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/aik-certificates/{id}"
+	Describe("Retrieve AIK certificate", func() {
+		retrieve := func(id uuid.UUID) {
+			router.Handle("/aik-certificates/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(aikCertificateController.Retrieve))).Methods("GET")
+			req, err := http.NewRequest("GET", "/aik-certificates/"+id.String(), nil)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+		}
+
+		Context("Retrieve an existing AIK certificate", func() {
+			It("Should return the AIK certificate", func() {
+				retrieve(validAik.ID)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var aikCertificate hvs.AikCertificate
+				err := json.Unmarshal(w.Body.Bytes(), &aikCertificate)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(aikCertificate.SerialNumber).To(Equal(validAik.SerialNumber))
+			})
+		})
+		Context("Retrieve a non-existent AIK certificate", func() {
+			It("Should get HTTP Status: 404", func() {
+				retrieve(uuid.New())
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	// Specs for HTTP Post to "/aik-certificates/{id}/revoke"
+	Describe("Revoke AIK certificate", func() {
+		revoke := func(id uuid.UUID, body string) {
+			router.Handle("/aik-certificates/{id}/revoke", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(aikCertificateController.Revoke))).Methods("POST")
+			req, err := http.NewRequest("POST", "/aik-certificates/"+id.String()+"/revoke", bytes.NewBufferString(body))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+		}
+
+		Context("Revoke a valid AIK certificate", func() {
+			It("Should revoke the AIK certificate with the given reason", func() {
+				revoke(validAik.ID, `{"reason": "key_compromise"}`)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				revoked, err := aikStore.Retrieve(validAik.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(revoked.Revoked).To(BeTrue())
+				Expect(revoked.RevocationTime).NotTo(BeNil())
+				Expect(revoked.RevocationReason).To(Equal(hvs.AikRevocationReasonKeyCompromise))
+			})
+		})
+		Context("Revoke the AIK certificate of a host in the host trust cache", func() {
+			It("Should drop the host from the host trust cache", func() {
+				hostTrustCache.Add(hostId, &models.QuoteReportCache{QuoteDigest: "abcd"})
+				revoke(validAik.ID, `{"reason": "key_compromise"}`)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				_, ok := hostTrustCache.Get(hostId)
+				Expect(ok).To(BeFalse())
+			})
+		})
+		Context("Revoke a valid AIK certificate without a reason", func() {
+			It("Should revoke the AIK certificate with an unspecified reason", func() {
+				revoke(validAik.ID, "")
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				revoked, err := aikStore.Retrieve(validAik.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(revoked.RevocationReason).To(Equal(hvs.AikRevocationReasonUnspecified))
+			})
+		})
+		Context("Revoke an already revoked AIK certificate", func() {
+			It("Should get HTTP Status: 400", func() {
+				revoke(revokedAik.ID, `{"reason": "key_compromise"}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide an invalid revocation reason", func() {
+			It("Should get HTTP Status: 400", func() {
+				revoke(validAik.ID, `{"reason": "remove_from_crl"}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Revoke a non-existent AIK certificate", func() {
+			It("Should get HTTP Status: 404", func() {
+				revoke(uuid.New(), "")
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/privacyca/crl"
+	Describe("Retrieve CRL of the privacy CA", func() {
+		retrieveCrl := func() {
+			router.Handle("/privacyca/crl", hvsRoutes.ErrorHandler(hvsRoutes.ResponseHandler(aikCertificateController.Crl))).Methods("GET")
+			req, err := http.NewRequest("GET", "/privacyca/crl", nil)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypePemFile)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+		}
+
+		Context("Privacy CA is allowed to sign CRLs", func() {
+			It("Should return the signed CRL listing the revoked AIK certificates that are not expired", func() {
+				retrieveCrl()
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				block, _ := pem.Decode(w.Body.Bytes())
+				Expect(block).NotTo(BeNil())
+				Expect(block.Type).To(Equal("X509 CRL"))
+				crl, err := x509.ParseDERCRL(block.Bytes)
+				Expect(err).NotTo(HaveOccurred())
+				pcaCerts := (*aikCertificateController.CertStore)[models.CaCertTypesPrivacyCa.String()].Certificates
+				Expect(pcaCerts[0].CheckCRLSignature(crl)).To(Succeed())
+				revokedCerts := crl.TBSCertList.RevokedCertificates
+				Expect(revokedCerts).To(HaveLen(1))
+				Expect(revokedCerts[0].SerialNumber.Text(16)).To(Equal(revokedAik.SerialNumber))
+				Expect(revokedCerts[0].Extensions).To(HaveLen(1))
+				Expect(revokedCerts[0].Extensions[0].Id).To(Equal(asn1.ObjectIdentifier{2, 5, 29, 21}))
+				var reasonCode asn1.Enumerated
+				_, err = asn1.Unmarshal(revokedCerts[0].Extensions[0].Value, &reasonCode)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reasonCode).To(Equal(asn1.Enumerated(4)))
+			})
+		})
+		Context("Retrieve the CRL twice", func() {
+			It("Should return the cached CRL", func() {
+				retrieveCrl()
+				Expect(w.Code).To(Equal(http.StatusOK))
+				firstCrl := w.Body.String()
+
+				retrieveCrl()
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(firstCrl))
+			})
+		})
+		Context("Retrieve the CRL after a revocation", func() {
+			It("Should return a new CRL listing the revoked AIK certificate", func() {
+				retrieveCrl()
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				router.Handle("/aik-certificates/{id}/revoke", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(aikCertificateController.Revoke))).Methods("POST")
+				req, err := http.NewRequest("POST", "/aik-certificates/"+validAik.ID.String()+"/revoke", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				retrieveCrl()
+				Expect(w.Code).To(Equal(http.StatusOK))
+				block, _ := pem.Decode(w.Body.Bytes())
+				Expect(block).NotTo(BeNil())
+				crl, err := x509.ParseDERCRL(block.Bytes)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(crl.TBSCertList.RevokedCertificates).To(HaveLen(2))
+			})
+		})
+		Context("Privacy CA is not allowed to sign CRLs", func() {
+			It("Should get HTTP Status: 500", func() {
+				aikCertificateController = controllers.NewAikCertificateController(aikStore, certStore, time.Hour,
+					utils.NewAikCrlCache(), mocks.NewMockHostStore(), &smocks.MockHostTrustManager{}, hostTrustCache)
+				retrieveCrl()
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+})
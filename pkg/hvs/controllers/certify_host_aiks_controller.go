@@ -15,31 +15,35 @@ import (
 	"encoding/asn1"
 	"encoding/hex"
 	"encoding/json"
+	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
 	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
 	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
 	libPrivacyca "github.com/intel-secl/intel-secl/v3/pkg/lib/privacyca"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
 	"github.com/pkg/errors"
 	"io/ioutil"
 	"math/big"
 	"net/http"
 	"os"
-	"strings"
 	"time"
 )
 
 type CertifyHostAiksController struct {
 	CertStore          *models.CertificatesStore
 	ECStore            domain.TpmEndorsementStore
+	EAStore            domain.EndorsementAuthorityStore
+	AikStore           domain.AikCertificateStore
 	AikCertValidity    int
 	AikRequestsDirPath string
 }
 
-func NewCertifyHostAiksController(certStore *models.CertificatesStore, ecstore domain.TpmEndorsementStore, aikCertValidity int, aikReqsDir string) *CertifyHostAiksController {
+func NewCertifyHostAiksController(certStore *models.CertificatesStore, ecstore domain.TpmEndorsementStore, eaStore domain.EndorsementAuthorityStore, aikStore domain.AikCertificateStore, aikCertValidity int, aikReqsDir string) *CertifyHostAiksController {
 	defaultLog.Trace("controllers/certify_host_aiks_controller:NewCertifyHostAiksController() Entering")
 	defer defaultLog.Trace("controllers/certify_host_aiks_controller:NewCertifyHostAiksController() Leaving")
 	// CertStore should have an entry for Privacyca key
@@ -55,7 +59,7 @@ func NewCertifyHostAiksController(certStore *models.CertificatesStore, ecstore d
 		return nil
 	}
 
-	return &CertifyHostAiksController{CertStore: certStore, ECStore: ecstore, AikCertValidity: aikCertValidity, AikRequestsDirPath: aikReqsDir}
+	return &CertifyHostAiksController{CertStore: certStore, ECStore: ecstore, EAStore: eaStore, AikStore: aikStore, AikCertValidity: aikCertValidity, AikRequestsDirPath: aikReqsDir}
 }
 
 func (certifyHostAiksController *CertifyHostAiksController) StoreEkCerts(identityRequestChallenge, ekCertBytes []byte, identityChallengePayload taModel.IdentityChallengePayload) error {
@@ -188,20 +192,11 @@ func (certifyHostAiksController *CertifyHostAiksController) getIdentityProofRequ
 	if err != nil {
 		return taModel.IdentityProofRequest{}, http.StatusBadRequest, err
 	}
-	endorsementCerts := (*certifyHostAiksController.CertStore)[models.CaCertTypesEndorsementCa.String()].Certificates
-
-	defaultLog.Debugf("controllers/certify_host_aiks_controller:getIdentityProofRequest() ekCert Issuer Name :%s", ekCert.Issuer.CommonName)
-	var endorsementCertsToVerify x509.Certificate
-	for _, cert := range endorsementCerts {
-		if cert.Issuer.CommonName == strings.ReplaceAll(ekCert.Issuer.CommonName, "\\x00", "") {
-			endorsementCertsToVerify = cert
-			break
-		}
-	}
-	if !certifyHostAiksController.isEkCertificateVerifiedByAuthority(ekCert, &endorsementCertsToVerify) && !certifyHostAiksController.isEkCertificateVerifiedByAnyAuthority(ekCert, endorsementCerts) && !certifyHostAiksController.isEkCertRegistered(ekCert) {
-		secLog.Errorf("controllers/certify_host_aiks_controller:getIdentityProofRequest() EC is not trusted, Please verify Endorsement Authority certificate is present in EndorsementCA file or ekcert is not registered with hvs")
-		return taModel.IdentityProofRequest{}, http.StatusBadRequest, errors.Wrap(err, "controllers/certify_host_aiks_controller:getIdentityProofRequest() EC is not trusted")
+	provenance, status, err := certifyHostAiksController.verifyEkCertificate(ekCert)
+	if err != nil {
+		return taModel.IdentityProofRequest{}, status, err
 	}
+	defaultLog.Debugf("controllers/certify_host_aiks_controller:getIdentityProofRequest() EC is trusted by %s", provenance.Source)
 
 	identityRequestChallenge, err := crypt.GetRandomBytes(32)
 	if err != nil {
@@ -227,22 +222,6 @@ func (certifyHostAiksController *CertifyHostAiksController) getIdentityProofRequ
 	return proofReq, http.StatusOK, nil
 }
 
-func (certifyHostAiksController *CertifyHostAiksController) isEkCertificateVerifiedByAuthority(cert *x509.Certificate, authority *x509.Certificate) bool {
-	defaultLog.Trace("controllers/certify_host_aiks_controller:isEkCertificateVerifiedByAuthority() Entering")
-	defer defaultLog.Trace("controllers/certify_host_aiks_controller:isEkCertificateVerifiedByAuthority() Leaving")
-	if authority.Raw == nil {
-		return false
-	}
-
-	err := cert.CheckSignatureFrom(authority)
-	if err != nil {
-		defaultLog.Debugf("controllers/certify_host_aiks_controller:isEkCertificateVerifiedByAuthority() %v", err)
-		return false
-	}
-
-	return true
-}
-
 func (certifyHostAiksController *CertifyHostAiksController) IdentityRequestSubmitChallengeResponse(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
 	defaultLog.Trace("controllers/certify_host_aiks_controller:IdentityRequestSubmitChallengeResponse() Entering")
 	defer defaultLog.Trace("controllers/certify_host_aiks_controller:IdentityRequestSubmitChallengeResponse() Leaving")
@@ -320,9 +299,75 @@ func (certifyHostAiksController *CertifyHostAiksController) getIdentityProofRequ
 		return taModel.IdentityProofRequest{}, http.StatusInternalServerError, errors.Wrap(err, "controllers/certify_host_aiks_controller:getIdentityProofRequestResponse() Error while generating identityProofRequest")
 	}
 
+	err = certifyHostAiksController.recordAikCertificate(aikCert, ekx509Cert)
+	if err != nil {
+		return taModel.IdentityProofRequest{}, http.StatusInternalServerError, errors.Wrap(err, "controllers/certify_host_aiks_controller:getIdentityProofRequestResponse() Error while recording AIK certificate")
+	}
+
 	return proofReq, http.StatusOK, nil
 }
 
+// recordAikCertificate adds the AIK certificate issued for the EK certificate to the AIK certificate inventory. The AIK
+// certificates previously issued for the same EK certificate are renewed by it, so they are revoked as superseded.
+func (certifyHostAiksController *CertifyHostAiksController) recordAikCertificate(aikCertBytes []byte, ekCert *x509.Certificate) error {
+	defaultLog.Trace("controllers/certify_host_aiks_controller:recordAikCertificate() Entering")
+	defer defaultLog.Trace("controllers/certify_host_aiks_controller:recordAikCertificate() Leaving")
+
+	aikCert, err := x509.ParseCertificate(aikCertBytes)
+	if err != nil {
+		return errors.Wrap(err, "Error while parsing AIK certificate")
+	}
+	ekCertDigest, err := crypt.GetCertHashInHex(ekCert, crypto.SHA384)
+	if err != nil {
+		return errors.Wrap(err, "Error while creating digest for EC")
+	}
+
+	aikCertificate := hvs.AikCertificate{
+		SerialNumber:        utils.GetAikCertificateSerialNumber(aikCert),
+		EkCertificateDigest: ekCertDigest,
+		Certificate:         aikCertBytes,
+		Issued:              aikCert.NotBefore.UTC(),
+		Expiry:              aikCert.NotAfter.UTC(),
+	}
+	if registeredCert := certifyHostAiksController.getRegisteredEkCert(ekCert); registeredCert != nil && registeredCert.HardwareUUID != uuid.Nil {
+		hardwareUUID := registeredCert.HardwareUUID
+		aikCertificate.HardwareUUID = &hardwareUUID
+	}
+
+	revoked := false
+	previousAikCertificates, err := certifyHostAiksController.AikStore.Search(&models.AikCertificateFilterCriteria{
+		EkCertificateDigestEqualTo: ekCertDigest,
+		Revoked:                    &revoked,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Error while searching AIK certificates issued for the EC")
+	}
+	// the renewed AIK certificate belongs to the same host as the ones it supersedes
+	for _, previous := range previousAikCertificates {
+		if aikCertificate.HardwareUUID == nil && previous.HardwareUUID != nil {
+			aikCertificate.HardwareUUID = previous.HardwareUUID
+		}
+	}
+
+	if _, err = certifyHostAiksController.AikStore.Create(&aikCertificate); err != nil {
+		return errors.Wrap(err, "Error while creating AIK certificate")
+	}
+	secLog.Infof("%s: AIK certificate %s issued for EC %s", commLogMsg.PrivilegeModified, aikCertificate.SerialNumber, ekCertDigest)
+
+	revocationTime := time.Now().UTC()
+	for i := range previousAikCertificates {
+		previous := previousAikCertificates[i]
+		previous.Revoked = true
+		previous.RevocationTime = &revocationTime
+		previous.RevocationReason = hvs.AikRevocationReasonSuperseded
+		if _, err = certifyHostAiksController.AikStore.Update(&previous); err != nil {
+			return errors.Wrapf(err, "Error while revoking superseded AIK certificate %s", previous.SerialNumber)
+		}
+		secLog.Infof("%s: AIK certificate %s superseded by %s", commLogMsg.PrivilegeModified, previous.SerialNumber, aikCertificate.SerialNumber)
+	}
+	return nil
+}
+
 func (certifyHostAiksController *CertifyHostAiksController) CertifyAik(aikPubKey *rsa.PublicKey, aikName []byte, privacycaKey *rsa.PrivateKey, privacycaCert *x509.Certificate, validity int) ([]byte, error) {
 	defaultLog.Trace("controllers/certify_host_aiks_controller:CertifyAik() Entering")
 	defer defaultLog.Trace("controllers/certify_host_aiks_controller:CertifyAik() Leaving")
@@ -359,39 +404,68 @@ func (certifyHostAiksController *CertifyHostAiksController) CertifyAik(aikPubKey
 	return aikCert, nil
 }
 
-func (certifyHostAiksController *CertifyHostAiksController) isEkCertRegistered(cert *x509.Certificate) bool {
-	defaultLog.Trace("controllers/certify_host_aiks_controller:isEkCertRegistered() Entering")
-	defer defaultLog.Trace("controllers/certify_host_aiks_controller:isEkCertRegistered() Leaving")
-	certDigest, err := crypt.GetCertHashInHex(cert, crypto.SHA384)
+// verifyEkCertificate establishes the trust of the EK certificate against the endorsement authorities, the endorsement CA
+// certificates and the registered TpmEndorsements, and records its provenance on the TpmEndorsement of the TPM
+func (certifyHostAiksController *CertifyHostAiksController) verifyEkCertificate(ekCert *x509.Certificate) (*hvs.EndorsementProvenance, int, error) {
+	defaultLog.Trace("controllers/certify_host_aiks_controller:verifyEkCertificate() Entering")
+	defer defaultLog.Trace("controllers/certify_host_aiks_controller:verifyEkCertificate() Leaving")
+
+	defaultLog.Debugf("controllers/certify_host_aiks_controller:verifyEkCertificate() ekCert Issuer Name :%s", ekCert.Issuer.CommonName)
+	authorities, err := certifyHostAiksController.EAStore.Search(&models.EndorsementAuthorityFilterCriteria{Disabled: new(bool)})
 	if err != nil {
-		defaultLog.WithError(err).Errorf("Error while creating digest for EC")
-		return false
+		return nil, http.StatusInternalServerError, errors.Wrap(err, "controllers/certify_host_aiks_controller:verifyEkCertificate() Error while searching endorsement authorities")
 	}
-	registeredCerts, err := certifyHostAiksController.ECStore.Search(&models.TpmEndorsementFilterCriteria{CertificateDigestEqualTo: certDigest})
+	endorsementCerts := (*certifyHostAiksController.CertStore)[models.CaCertTypesEndorsementCa.String()].Certificates
+	provenance, err := utils.NewEkCertificateVerifier(authorities, endorsementCerts).Verify(ekCert)
 	if err != nil {
-		defaultLog.WithError(err).Errorf("Error while searching registered EC for issuer %s", cert.Issuer)
-		return false
+		secLog.WithError(err).Errorf("controllers/certify_host_aiks_controller:verifyEkCertificate() %s EC is revoked", commLogMsg.UnauthorizedAccess)
+		return nil, http.StatusBadRequest, errors.Wrap(err, "controllers/certify_host_aiks_controller:verifyEkCertificate() EC is not trusted")
 	}
-	if len(registeredCerts.TpmEndorsement) == 0 {
-		defaultLog.Debugf("There is no EC present for given issuer %s", cert.Issuer)
-		return false
+
+	registeredCert := certifyHostAiksController.getRegisteredEkCert(ekCert)
+	if registeredCert != nil && registeredCert.Revoked {
+		secLog.Errorf("controllers/certify_host_aiks_controller:verifyEkCertificate() %s EC %s is revoked", commLogMsg.UnauthorizedAccess, registeredCert.ID)
+		return nil, http.StatusBadRequest, errors.New("controllers/certify_host_aiks_controller:verifyEkCertificate() EC is revoked")
+	}
+	if provenance == nil {
+		if registeredCert == nil {
+			secLog.Errorf("controllers/certify_host_aiks_controller:verifyEkCertificate() EC is not trusted, Please verify Endorsement Authority certificate is present in EndorsementCA file or endorsement authorities, or ekcert is registered with hvs")
+			return nil, http.StatusBadRequest, errors.New("controllers/certify_host_aiks_controller:verifyEkCertificate() EC is not trusted")
+		}
+		provenance = &hvs.EndorsementProvenance{
+			Source:   hvs.EndorsementSourceRegistered,
+			Verified: time.Now().UTC(),
+		}
 	}
 
-	if registeredCerts.TpmEndorsement[0].Revoked {
-		defaultLog.Debugf("EC for given issuer %s is revoked", cert.Issuer)
-		return false
+	if registeredCert != nil {
+		registeredCert.EndorsementProvenance = provenance
+		if _, err := certifyHostAiksController.ECStore.Update(registeredCert); err != nil {
+			defaultLog.WithError(err).Errorf("controllers/certify_host_aiks_controller:verifyEkCertificate() Error while recording provenance of EC %s", registeredCert.ID)
+		}
 	}
-	return true
+	return provenance, http.StatusOK, nil
 }
 
-func (certifyHostAiksController *CertifyHostAiksController) isEkCertificateVerifiedByAnyAuthority(cert *x509.Certificate, certs []x509.Certificate) bool {
-	defaultLog.Trace("controllers/certify_host_aiks_controller:isEkCertificateVerifiedByAnyAuthority() Entering")
-	defer defaultLog.Trace("controllers/certify_host_aiks_controller:isEkCertificateVerifiedByAnyAuthority() Leaving")
-
-	for _, authority := range certs {
-		if certifyHostAiksController.isEkCertificateVerifiedByAuthority(cert, &authority) {
-			return true
+// getRegisteredEkCert returns the TpmEndorsement registered with the EK certificate, nil if there is none
+func (certifyHostAiksController *CertifyHostAiksController) getRegisteredEkCert(cert *x509.Certificate) *hvs.TpmEndorsement {
+	defaultLog.Trace("controllers/certify_host_aiks_controller:getRegisteredEkCert() Entering")
+	defer defaultLog.Trace("controllers/certify_host_aiks_controller:getRegisteredEkCert() Leaving")
+	certDigest, err := crypt.GetCertHashInHex(cert, crypto.SHA384)
+	if err != nil {
+		defaultLog.WithError(err).Errorf("Error while creating digest for EC")
+		return nil
+	}
+	for _, revoked := range []bool{false, true} {
+		registeredCerts, err := certifyHostAiksController.ECStore.Search(&models.TpmEndorsementFilterCriteria{CertificateDigestEqualTo: certDigest, RevokedEqualTo: revoked})
+		if err != nil {
+			defaultLog.WithError(err).Errorf("Error while searching registered EC for issuer %s", cert.Issuer)
+			return nil
+		}
+		if len(registeredCerts.TpmEndorsement) > 0 {
+			return registeredCerts.TpmEndorsement[0]
 		}
 	}
-	return false
+	defaultLog.Debugf("There is no EC present for given issuer %s", cert.Issuer)
+	return nil
 }
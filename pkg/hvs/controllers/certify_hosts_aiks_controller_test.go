@@ -6,6 +6,7 @@ package controllers_test
 
 import (
 	"bytes"
+	"crypto"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/base64"
@@ -15,11 +16,14 @@ import (
 	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
 	hvsRoutes "github.com/intel-secl/intel-secl/v3/pkg/hvs/router"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/privacyca"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
 
 	"github.com/gorilla/mux"
@@ -32,12 +36,14 @@ var _ = Describe("CertifyHostAiksController", func() {
 	var w *httptest.ResponseRecorder
 	var certifyHostAiksController *controllers.CertifyHostAiksController
 	var cacert *x509.Certificate
+	var aikStore *mocks.MockAikCertificateStore
 	ecStore := mocks.MockTpmEndorsementStore{}
 
 	BeforeEach(func() {
 		router = mux.NewRouter()
 		cacert = &(*certStore)[models.CaCertTypesPrivacyCa.String()].Certificates[0]
-		certifyHostAiksController = controllers.NewCertifyHostAiksController(certStore, &ecStore, 2, "../domain/mocks/resources/aik-reqs-dir/")
+		aikStore = mocks.NewMockAikCertificateStore()
+		certifyHostAiksController = controllers.NewCertifyHostAiksController(certStore, &ecStore, mocks.NewMockEndorsementAuthorityStore(), aikStore, 2, "../domain/mocks/resources/aik-reqs-dir/")
 	})
 
 	Describe("Create Identity Proof request", func() {
@@ -83,7 +89,7 @@ var _ = Describe("CertifyHostAiksController", func() {
 			It("Return Identity Proof request", func() {
 				// mockEndorsement is having the ekcert
 				mockEndorsement := mocks.NewFakeTpmEndorsementStore()
-				certifyHostAiksController = controllers.NewCertifyHostAiksController(certStore, mockEndorsement, 2, "../domain/mocks/resources/aik-reqs-dir/")
+				certifyHostAiksController = controllers.NewCertifyHostAiksController(certStore, mockEndorsement, mocks.NewMockEndorsementAuthorityStore(), mocks.NewMockAikCertificateStore(), 2, "../domain/mocks/resources/aik-reqs-dir/")
 				router.Handle("/privacyca/identity-challenge-request", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(certifyHostAiksController.IdentityRequestGetChallenge))).Methods("POST")
 
 				// Mock TA Flow for generating data for identityChallengeRequest
@@ -223,6 +229,16 @@ var _ = Describe("CertifyHostAiksController", func() {
 				certifyHostAiksController.StoreEkCerts(identityRequestChallenge, ekCertBytes, identityChallengeRequest)
 				jsonData, err := json.Marshal(identityChallengeRequest)
 
+				// an AIK certificate previously issued for the same EC is superseded by the new one
+				ekCert, err := x509.ParseCertificate(ekCertBytes)
+				Expect(err).NotTo(HaveOccurred())
+				ekCertDigest, err := crypt.GetCertHashInHex(ekCert, crypto.SHA384)
+				Expect(err).NotTo(HaveOccurred())
+				hardwareUuid := uuid.New()
+				previousAik, err := aikStore.Create(&hvs.AikCertificate{HardwareUUID: &hardwareUuid, SerialNumber: "1a2b",
+					EkCertificateDigest: ekCertDigest, Issued: time.Now().AddDate(-1, 0, 0), Expiry: time.Now().AddDate(1, 0, 0)})
+				Expect(err).NotTo(HaveOccurred())
+
 				req, err := http.NewRequest(
 					"POST",
 					"/privacyca/identity-challenge-response",
@@ -234,6 +250,15 @@ var _ = Describe("CertifyHostAiksController", func() {
 				w = httptest.NewRecorder()
 				router.ServeHTTP(w, req)
 				Expect(w.Code).To(Equal(200))
+
+				aikCertificates, err := aikStore.Search(&models.AikCertificateFilterCriteria{EkCertificateDigestEqualTo: ekCertDigest})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(aikCertificates).To(HaveLen(2))
+				Expect(aikCertificates[0].ID).To(Equal(previousAik.ID))
+				Expect(aikCertificates[0].Revoked).To(BeTrue())
+				Expect(aikCertificates[0].RevocationReason).To(Equal(hvs.AikRevocationReasonSuperseded))
+				Expect(aikCertificates[1].Revoked).To(BeFalse())
+				Expect(*aikCertificates[1].HardwareUUID).To(Equal(hardwareUuid))
 			})
 		})
 
@@ -0,0 +1,302 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+type EndorsementAuthorityController struct {
+	Store domain.EndorsementAuthorityStore
+}
+
+func NewEndorsementAuthorityController(store domain.EndorsementAuthorityStore) *EndorsementAuthorityController {
+	return &EndorsementAuthorityController{Store: store}
+}
+
+var endorsementAuthoritySearchParams = map[string]bool{"id": true, "nameEqualTo": true, "nameContains": true,
+	"manufacturerEqualTo": true, "disabled": true}
+
+func (controller EndorsementAuthorityController) Create(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/endorsement_authority_controller:Create() Entering")
+	defer defaultLog.Trace("controllers/endorsement_authority_controller:Create() Leaving")
+
+	if r.Header.Get("Content-Type") != consts.HTTPMediaTypeJson {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/endorsement_authority_controller:Create() The request body is not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	var reqAuthority hvs.EndorsementAuthority
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reqAuthority); err != nil {
+		secLog.WithError(err).Errorf("controllers/endorsement_authority_controller:Create() %s :  Failed to decode"+
+			" request body as endorsement authority", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	if err := validateEndorsementAuthority(reqAuthority); err != nil {
+		secLog.WithError(err).Errorf("controllers/endorsement_authority_controller:Create() %s Error while validating"+
+			" the endorsement authority", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	existingAuthorities, err := controller.Store.Search(&models.EndorsementAuthorityFilterCriteria{NameEqualTo: reqAuthority.Name})
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/endorsement_authority_controller:Create() Endorsement authority search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error while creating endorsement authority"}
+	}
+	if len(existingAuthorities) > 0 {
+		secLog.WithField("Name", reqAuthority.Name).Warningf("%s: Trying to create duplicated endorsement authority from addr: %s",
+			commLogMsg.InvalidInputBadParam, r.RemoteAddr)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Endorsement authority with same name already exist"}
+	}
+
+	reqAuthority.ID = uuid.Nil
+	newAuthority, err := controller.Store.Create(&reqAuthority)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/endorsement_authority_controller:Create() Endorsement authority creation failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error while creating endorsement authority"}
+	}
+
+	secLog.WithField("Name", newAuthority.Name).Infof("%s: Endorsement authority created by: %s",
+		commLogMsg.PrivilegeModified, r.RemoteAddr)
+	return newAuthority, http.StatusCreated, nil
+}
+
+func (controller EndorsementAuthorityController) Update(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/endorsement_authority_controller:Update() Entering")
+	defer defaultLog.Trace("controllers/endorsement_authority_controller:Update() Leaving")
+
+	if r.Header.Get("Content-Type") != consts.HTTPMediaTypeJson {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/endorsement_authority_controller:Update() The request body is not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+	id := uuid.MustParse(mux.Vars(r)["id"])
+
+	var reqAuthority hvs.EndorsementAuthorityUpdateRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reqAuthority); err != nil {
+		secLog.WithError(err).Errorf("controllers/endorsement_authority_controller:Update() %s :  Failed to decode"+
+			" request body as endorsement authority update request", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	authority, err := controller.Store.Retrieve(id)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			defaultLog.WithError(err).WithField("id", id).Info(
+				"controllers/endorsement_authority_controller:Update() Endorsement authority with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Endorsement authority with given ID does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", id).Error(
+			"controllers/endorsement_authority_controller:Update() Failed to retrieve endorsement authority")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to update endorsement authority"}
+	}
+
+	if reqAuthority.Name != "" {
+		authority.Name = reqAuthority.Name
+	}
+	if reqAuthority.Manufacturer != "" {
+		authority.Manufacturer = reqAuthority.Manufacturer
+	}
+	if reqAuthority.Certificates != "" {
+		authority.Certificates = reqAuthority.Certificates
+	}
+	// the CRLs are replaced, so that imported CRLs superseded by a newer CRL of the manufacturer are dropped
+	if reqAuthority.Crls != nil {
+		authority.Crls = *reqAuthority.Crls
+	}
+	if reqAuthority.Disabled != nil {
+		authority.Disabled = *reqAuthority.Disabled
+	}
+
+	if err := validateEndorsementAuthority(*authority); err != nil {
+		secLog.WithError(err).Errorf("controllers/endorsement_authority_controller:Update() %s Error while validating"+
+			" the endorsement authority", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	existingAuthorities, err := controller.Store.Search(&models.EndorsementAuthorityFilterCriteria{NameEqualTo: authority.Name})
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/endorsement_authority_controller:Update() Endorsement authority search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to update endorsement authority"}
+	}
+	if len(existingAuthorities) > 0 && existingAuthorities[0].ID != id {
+		secLog.WithField("Name", authority.Name).Warningf("%s: Trying to rename endorsement authority to an existing"+
+			" name from addr: %s", commLogMsg.InvalidInputBadParam, r.RemoteAddr)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Endorsement authority with same name already exist"}
+	}
+
+	updatedAuthority, err := controller.Store.Update(authority)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/endorsement_authority_controller:Update() Endorsement authority update failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to update endorsement authority"}
+	}
+
+	secLog.WithField("Name", updatedAuthority.Name).Infof("%s: Endorsement authority updated by: %s",
+		commLogMsg.PrivilegeModified, r.RemoteAddr)
+	return updatedAuthority, http.StatusOK, nil
+}
+
+func (controller EndorsementAuthorityController) Retrieve(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/endorsement_authority_controller:Retrieve() Entering")
+	defer defaultLog.Trace("controllers/endorsement_authority_controller:Retrieve() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+
+	authority, err := controller.Store.Retrieve(id)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			defaultLog.WithError(err).WithField("id", id).Info(
+				"controllers/endorsement_authority_controller:Retrieve() Endorsement authority with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Endorsement authority with given ID does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", id).Error(
+			"controllers/endorsement_authority_controller:Retrieve() Failed to retrieve endorsement authority")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve endorsement authority"}
+	}
+
+	secLog.WithField("id", id).Infof("Endorsement authority retrieved by: %s", r.RemoteAddr)
+	return authority, http.StatusOK, nil
+}
+
+func (controller EndorsementAuthorityController) Search(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/endorsement_authority_controller:Search() Entering")
+	defer defaultLog.Trace("controllers/endorsement_authority_controller:Search() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), endorsementAuthoritySearchParams); err != nil {
+		secLog.Errorf("controllers/endorsement_authority_controller:Search() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	filter, err := getEndorsementAuthorityFilterCriteria(r.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/endorsement_authority_controller:Search() %s", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	authorities, err := controller.Store.Search(filter)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/endorsement_authority_controller:Search() Endorsement authority search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search endorsement authorities"}
+	}
+
+	secLog.Infof("%s: Return endorsement authority query result to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return hvs.EndorsementAuthorityCollection{EndorsementAuthorities: authorities}, http.StatusOK, nil
+}
+
+func (controller EndorsementAuthorityController) Delete(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/endorsement_authority_controller:Delete() Entering")
+	defer defaultLog.Trace("controllers/endorsement_authority_controller:Delete() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+
+	if _, err := controller.Store.Retrieve(id); err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			defaultLog.WithError(err).WithField("id", id).Info(
+				"controllers/endorsement_authority_controller:Delete() Endorsement authority with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Endorsement authority with given ID does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", id).Error(
+			"controllers/endorsement_authority_controller:Delete() Attempt to delete invalid endorsement authority")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to delete endorsement authority"}
+	}
+
+	if err := controller.Store.Delete(id); err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error(
+			"controllers/endorsement_authority_controller:Delete() Failed to delete endorsement authority")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to delete endorsement authority"}
+	}
+	secLog.WithField("id", id).Infof("Endorsement authority deleted by: %s", r.RemoteAddr)
+	return nil, http.StatusNoContent, nil
+}
+
+func getEndorsementAuthorityFilterCriteria(params url.Values) (*models.EndorsementAuthorityFilterCriteria, error) {
+	defaultLog.Trace("controllers/endorsement_authority_controller:getEndorsementAuthorityFilterCriteria() Entering")
+	defer defaultLog.Trace("controllers/endorsement_authority_controller:getEndorsementAuthorityFilterCriteria() Leaving")
+
+	criteria := models.EndorsementAuthorityFilterCriteria{}
+	if id := strings.TrimSpace(params.Get("id")); id != "" {
+		parsedId, err := uuid.Parse(id)
+		if err != nil {
+			return nil, errors.New("Invalid id query param value, must be UUID")
+		}
+		criteria.Id = parsedId
+	}
+	if name := strings.TrimSpace(params.Get("nameEqualTo")); name != "" {
+		if err := validation.ValidateTextString(name); err != nil {
+			return nil, errors.New("Valid contents for nameEqualTo must be specified")
+		}
+		criteria.NameEqualTo = name
+	}
+	if name := strings.TrimSpace(params.Get("nameContains")); name != "" {
+		if err := validation.ValidateTextString(name); err != nil {
+			return nil, errors.New("Valid contents for nameContains must be specified")
+		}
+		criteria.NameContains = name
+	}
+	if manufacturer := strings.TrimSpace(params.Get("manufacturerEqualTo")); manufacturer != "" {
+		if err := validation.ValidateTextString(manufacturer); err != nil {
+			return nil, errors.New("Valid contents for manufacturerEqualTo must be specified")
+		}
+		criteria.ManufacturerEqualTo = manufacturer
+	}
+	if disabled := strings.TrimSpace(params.Get("disabled")); disabled != "" {
+		parsedDisabled, err := strconv.ParseBool(disabled)
+		if err != nil {
+			return nil, errors.New("Invalid disabled query param value, must be true or false")
+		}
+		criteria.Disabled = &parsedDisabled
+	}
+	return &criteria, nil
+}
+
+func validateEndorsementAuthority(authority hvs.EndorsementAuthority) error {
+	defaultLog.Trace("controllers/endorsement_authority_controller:validateEndorsementAuthority() Entering")
+	defer defaultLog.Trace("controllers/endorsement_authority_controller:validateEndorsementAuthority() Leaving")
+
+	if authority.Name == "" || authority.Certificates == "" {
+		return errors.New("name and certificates must be specified")
+	}
+	if err := validation.ValidateTextString(authority.Name); err != nil {
+		return errors.New("Valid contents for name must be specified")
+	}
+	if authority.Manufacturer != "" {
+		if err := validation.ValidateTextString(authority.Manufacturer); err != nil {
+			return errors.New("Valid contents for manufacturer must be specified")
+		}
+	}
+	if _, err := utils.ParseEndorsementAuthority(authority); err != nil {
+		return errors.Wrap(err, "Invalid endorsement authority bundle")
+	}
+	return nil
+}
@@ -0,0 +1,413 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	hvsRoutes "github.com/intel-secl/intel-secl/v3/pkg/hvs/router"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/privacyca"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// testEndorsementPki is a TPM manufacturer PKI with a root CA, an intermediate CA and an EK certificate issued by the
+// intermediate CA
+type testEndorsementPki struct {
+	root            *x509.Certificate
+	intermediate    *x509.Certificate
+	intermediateKey *rsa.PrivateKey
+	ekCert          *x509.Certificate
+}
+
+func newTestEndorsementPki(manufacturer string) *testEndorsementPki {
+	rootKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).NotTo(HaveOccurred())
+	rootTemplate := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: manufacturer + " TPM Root CA", Organization: []string{manufacturer}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	root := createTestCertificate(&rootTemplate, &rootTemplate, &rootKey.PublicKey, rootKey)
+
+	intermediateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).NotTo(HaveOccurred())
+	intermediateTemplate := rootTemplate
+	intermediateTemplate.SerialNumber = big.NewInt(2)
+	intermediateTemplate.Subject = pkix.Name{CommonName: manufacturer + " TPM Manufacturing CA", Organization: []string{manufacturer}}
+	intermediate := createTestCertificate(&intermediateTemplate, root, &intermediateKey.PublicKey, rootKey)
+
+	// EK certificates have an empty subject and a critical subject alternative name holding the TPM manufacturer,
+	// model and version as a directory name
+	ekKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).NotTo(HaveOccurred())
+	tpmName, err := asn1.Marshal(pkix.Name{CommonName: "id:" + manufacturer}.ToRDNSequence())
+	Expect(err).NotTo(HaveOccurred())
+	san, err := asn1.Marshal([]asn1.RawValue{{Class: asn1.ClassContextSpecific, Tag: 4, IsCompound: true, Bytes: tpmName}})
+	Expect(err).NotTo(HaveOccurred())
+	ekTemplate := x509.Certificate{
+		SerialNumber:    big.NewInt(time.Now().UnixNano()),
+		NotBefore:       time.Now().Add(-time.Hour),
+		NotAfter:        time.Now().AddDate(10, 0, 0),
+		KeyUsage:        x509.KeyUsageKeyEncipherment,
+		ExtraExtensions: []pkix.Extension{{Id: asn1.ObjectIdentifier{2, 5, 29, 17}, Critical: true, Value: san}},
+	}
+	ekCert := createTestCertificate(&ekTemplate, intermediate, &ekKey.PublicKey, intermediateKey)
+
+	return &testEndorsementPki{root: root, intermediate: intermediate, intermediateKey: intermediateKey, ekCert: ekCert}
+}
+
+func createTestCertificate(template, parent *x509.Certificate, pub *rsa.PublicKey, priv *rsa.PrivateKey) *x509.Certificate {
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, priv)
+	Expect(err).NotTo(HaveOccurred())
+	cert, err := x509.ParseCertificate(der)
+	Expect(err).NotTo(HaveOccurred())
+	return cert
+}
+
+func toPem(certs ...*x509.Certificate) string {
+	var certsPem []byte
+	for _, cert := range certs {
+		certsPem = append(certsPem, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	return string(certsPem)
+}
+
+// crl returns a PEM encoded CRL of the intermediate CA revoking the certificates
+func (pki *testEndorsementPki) crl(revoked ...*x509.Certificate) string {
+	var revokedCerts []pkix.RevokedCertificate
+	for _, cert := range revoked {
+		revokedCerts = append(revokedCerts, pkix.RevokedCertificate{SerialNumber: cert.SerialNumber, RevocationTime: time.Now()})
+	}
+	crl, err := pki.intermediate.CreateCRL(rand.Reader, pki.intermediateKey, revokedCerts, time.Now().Add(-time.Hour),
+		time.Now().AddDate(0, 1, 0))
+	Expect(err).NotTo(HaveOccurred())
+	return string(pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crl}))
+}
+
+var _ = Describe("EndorsementAuthorityController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var authorityStore *mocks.MockEndorsementAuthorityStore
+	var endorsementAuthorityController *controllers.EndorsementAuthorityController
+	var pki *testEndorsementPki
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		authorityStore = mocks.NewMockEndorsementAuthorityStore()
+		endorsementAuthorityController = controllers.NewEndorsementAuthorityController(authorityStore)
+		pki = newTestEndorsementPki("Infineon")
+	})
+
+	createAuthority := func(authority hvs.EndorsementAuthority) {
+		router.Handle("/endorsement-authorities", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(endorsementAuthorityController.Create))).Methods("POST")
+		body, err := json.Marshal(authority)
+		Expect(err).NotTo(HaveOccurred())
+		req, err := http.NewRequest("POST", "/endorsement-authorities", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+		req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+	}
+
+	// Specs for HTTP Post to "/endorsement-authorities"
+	Describe("Create endorsement authority", func() {
+		Context("Provide a bundle with root and intermediate certificates and a CRL", func() {
+			It("Should create the endorsement authority", func() {
+				createAuthority(hvs.EndorsementAuthority{
+					Name:         "Infineon RSA",
+					Manufacturer: "Infineon",
+					Certificates: toPem(pki.root, pki.intermediate),
+					Crls:         pki.crl(),
+				})
+				Expect(w.Code).To(Equal(http.StatusCreated))
+
+				var authority hvs.EndorsementAuthority
+				err := json.Unmarshal(w.Body.Bytes(), &authority)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(authority.ID).NotTo(Equal(uuid.Nil))
+				Expect(authority.Disabled).To(BeFalse())
+			})
+		})
+		Context("Provide a bundle with an EK certificate", func() {
+			It("Should get HTTP Status: 400", func() {
+				createAuthority(hvs.EndorsementAuthority{
+					Name:         "Infineon RSA",
+					Certificates: toPem(pki.root, pki.ekCert),
+				})
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide a CRL that is not issued by the bundle", func() {
+			It("Should get HTTP Status: 400", func() {
+				createAuthority(hvs.EndorsementAuthority{
+					Name:         "Infineon RSA",
+					Certificates: toPem(pki.root, pki.intermediate),
+					Crls:         newTestEndorsementPki("Nuvoton").crl(),
+				})
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide an endorsement authority with an existing name", func() {
+			It("Should get HTTP Status: 400", func() {
+				_, err := authorityStore.Create(&hvs.EndorsementAuthority{Name: "Infineon RSA", Certificates: toPem(pki.root)})
+				Expect(err).NotTo(HaveOccurred())
+				createAuthority(hvs.EndorsementAuthority{
+					Name:         "Infineon RSA",
+					Certificates: toPem(pki.root, pki.intermediate),
+				})
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/endorsement-authorities"
+	Describe("Search endorsement authorities", func() {
+		BeforeEach(func() {
+			_, err := authorityStore.Create(&hvs.EndorsementAuthority{Name: "Infineon RSA", Manufacturer: "Infineon", Certificates: toPem(pki.root)})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = authorityStore.Create(&hvs.EndorsementAuthority{Name: "Nuvoton", Manufacturer: "Nuvoton", Certificates: toPem(pki.root), Disabled: true})
+			Expect(err).NotTo(HaveOccurred())
+			router.Handle("/endorsement-authorities", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(endorsementAuthorityController.Search))).Methods("GET")
+		})
+		Context("Search the disabled endorsement authorities", func() {
+			It("Should return the disabled endorsement authority", func() {
+				req, err := http.NewRequest("GET", "/endorsement-authorities?disabled=true", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var collection hvs.EndorsementAuthorityCollection
+				err = json.Unmarshal(w.Body.Bytes(), &collection)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(collection.EndorsementAuthorities).To(HaveLen(1))
+				Expect(collection.EndorsementAuthorities[0].Name).To(Equal("Nuvoton"))
+			})
+		})
+		Context("Search with an invalid query parameter", func() {
+			It("Should get HTTP Status: 400", func() {
+				req, err := http.NewRequest("GET", "/endorsement-authorities?disabled=maybe", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Put to "/endorsement-authorities/{id}"
+	Describe("Update endorsement authority", func() {
+		Context("Disable an endorsement authority", func() {
+			It("Should disable the endorsement authority and keep its certificates", func() {
+				authority, err := authorityStore.Create(&hvs.EndorsementAuthority{Name: "Infineon RSA", Certificates: toPem(pki.root, pki.intermediate)})
+				Expect(err).NotTo(HaveOccurred())
+				router.Handle("/endorsement-authorities/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(endorsementAuthorityController.Update))).Methods("PUT")
+				req, err := http.NewRequest("PUT", "/endorsement-authorities/"+authority.ID.String(), strings.NewReader(`{"disabled": true}`))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				stored, err := authorityStore.Retrieve(authority.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stored.Disabled).To(BeTrue())
+				Expect(stored.Certificates).To(Equal(toPem(pki.root, pki.intermediate)))
+			})
+		})
+		Context("Rename a disabled endorsement authority", func() {
+			It("Should keep the endorsement authority disabled", func() {
+				authority, err := authorityStore.Create(&hvs.EndorsementAuthority{Name: "Infineon RSA", Certificates: toPem(pki.root, pki.intermediate), Disabled: true})
+				Expect(err).NotTo(HaveOccurred())
+				router.Handle("/endorsement-authorities/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(endorsementAuthorityController.Update))).Methods("PUT")
+				req, err := http.NewRequest("PUT", "/endorsement-authorities/"+authority.ID.String(), strings.NewReader(`{"name": "Infineon RSA 2048"}`))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				stored, err := authorityStore.Retrieve(authority.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stored.Name).To(Equal("Infineon RSA 2048"))
+				Expect(stored.Disabled).To(BeTrue())
+			})
+		})
+		Context("Remove the CRLs of an endorsement authority", func() {
+			It("Should clear the CRLs and keep the certificates", func() {
+				authority, err := authorityStore.Create(&hvs.EndorsementAuthority{Name: "Infineon RSA",
+					Certificates: toPem(pki.root, pki.intermediate), Crls: pki.crl()})
+				Expect(err).NotTo(HaveOccurred())
+				router.Handle("/endorsement-authorities/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(endorsementAuthorityController.Update))).Methods("PUT")
+				req, err := http.NewRequest("PUT", "/endorsement-authorities/"+authority.ID.String(), strings.NewReader(`{"crls": ""}`))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				stored, err := authorityStore.Retrieve(authority.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stored.Crls).To(BeEmpty())
+				Expect(stored.Certificates).To(Equal(toPem(pki.root, pki.intermediate)))
+			})
+		})
+		Context("Update a non-existent endorsement authority", func() {
+			It("Should get HTTP Status: 404", func() {
+				router.Handle("/endorsement-authorities/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(endorsementAuthorityController.Update))).Methods("PUT")
+				req, err := http.NewRequest("PUT", "/endorsement-authorities/"+uuid.New().String(), strings.NewReader(`{"disabled": true}`))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	// Specs for HTTP Delete to "/endorsement-authorities/{id}"
+	Describe("Delete endorsement authority", func() {
+		Context("Delete an existing endorsement authority", func() {
+			It("Should delete the endorsement authority", func() {
+				authority, err := authorityStore.Create(&hvs.EndorsementAuthority{Name: "Infineon RSA", Certificates: toPem(pki.root)})
+				Expect(err).NotTo(HaveOccurred())
+				router.Handle("/endorsement-authorities/{id}", hvsRoutes.ErrorHandler(hvsRoutes.ResponseHandler(endorsementAuthorityController.Delete))).Methods("DELETE")
+				req, err := http.NewRequest("DELETE", "/endorsement-authorities/"+authority.ID.String(), nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNoContent))
+
+				_, err = authorityStore.Retrieve(authority.ID)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	// Specs for the EK certificate verification of HTTP Post to "/privacyca/identity-challenge-request"
+	Describe("Verify EK certificate against endorsement authorities", func() {
+		var ecStore *mocks.MockTpmEndorsementStore
+		var registeredId uuid.UUID
+
+		BeforeEach(func() {
+			ecStore = mocks.NewFakeTpmEndorsementStore()
+			registeredId = uuid.New()
+			_, err := ecStore.Create(&hvs.TpmEndorsement{
+				ID:           registeredId,
+				HardwareUUID: uuid.New(),
+				Issuer:       pki.intermediate.Subject.String(),
+				Certificate:  base64.StdEncoding.EncodeToString([]byte(toPem(pki.ekCert))),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			certifyHostAiksController := controllers.NewCertifyHostAiksController(certStore, ecStore, authorityStore, mocks.NewMockAikCertificateStore(), 2, "../domain/mocks/resources/aik-reqs-dir/")
+			router.Handle("/privacyca/identity-challenge-request", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(certifyHostAiksController.IdentityRequestGetChallenge))).Methods("POST")
+		})
+
+		requestChallenge := func() {
+			cacert := &(*certStore)[models.CaCertTypesPrivacyCa.String()].Certificates[0]
+			aikModulus, _ := base64.StdEncoding.DecodeString("musrA8GOcUtcD3phno/e4XseAdzLG/Ff1qXBIZ/GWdQUKTvOQlUq5P+BJLD1ifp7bpyvXdpesnHZuhXpi4AM8D2uJYTs4MeamMJ2LKAu/zSk9IDz4Z4gnQACSGSWzqafXv8OAh6D7/EOjzUh/sjkZdTVjsKzyHGp7GbY+G+mt9/PdF1e4/TJlp41s6rQ6BAJ0mA4gNdkrJLW2iedM1MZJn2JgYWDtxej5wD6Gm7/BGD+Rn9wqyU4U6fjEsNqeXj0E0DtkreMAi9cAQuoagckvh/ru1o8psyzTM+Bk+EqpFrfg3nz4nDC+Nrz+IBjuJuFGNUUFbxC6FrdtX4c2jnQIQ==")
+			aikName, _ := base64.StdEncoding.DecodeString("AAuTbAaKYOG2opc4QXq0QzsUHFRMsV0m5lcmRK4SLrzdRA==")
+			identityReq := taModel.IdentityRequest{
+				TpmVersion: "2.0",
+				AikModulus: aikModulus,
+				AikName:    aikName,
+			}
+			privacycaTpm2, err := privacyca.NewPrivacyCA(identityReq)
+			Expect(err).NotTo(HaveOccurred())
+			identityChallengeRequest, err := privacycaTpm2.GetIdentityChallengeRequest(pki.ekCert.Raw, cacert.PublicKey.(*rsa.PublicKey), identityReq)
+			Expect(err).NotTo(HaveOccurred())
+			jsonData, err := json.Marshal(identityChallengeRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			req, err := http.NewRequest("POST", "/privacyca/identity-challenge-request", bytes.NewBuffer(jsonData))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+		}
+
+		Context("EK certificate chains up to the root of an endorsement authority", func() {
+			It("Should return the identity proof request and record the provenance", func() {
+				authority, err := authorityStore.Create(&hvs.EndorsementAuthority{Name: "Infineon RSA",
+					Certificates: toPem(pki.root, pki.intermediate), Crls: pki.crl()})
+				Expect(err).NotTo(HaveOccurred())
+				requestChallenge()
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				registered, err := ecStore.Retrieve(registeredId)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(registered.EndorsementProvenance).NotTo(BeNil())
+				Expect(registered.EndorsementProvenance.Source).To(Equal(hvs.EndorsementSourceAuthority))
+				Expect(registered.EndorsementProvenance.AuthorityID).To(Equal(authority.ID))
+				Expect(registered.EndorsementProvenance.Chain).To(Equal([]string{pki.intermediate.Subject.String(), pki.root.Subject.String()}))
+			})
+		})
+		Context("EK certificate is signed by an intermediate shipped without its root", func() {
+			It("Should return the identity proof request", func() {
+				_, err := authorityStore.Create(&hvs.EndorsementAuthority{Name: "Infineon RSA", Certificates: toPem(pki.intermediate)})
+				Expect(err).NotTo(HaveOccurred())
+				requestChallenge()
+				Expect(w.Code).To(Equal(http.StatusOK))
+			})
+		})
+		Context("EK certificate is revoked by a CRL of the endorsement authority", func() {
+			It("Should get HTTP Status: 400", func() {
+				_, err := authorityStore.Create(&hvs.EndorsementAuthority{Name: "Infineon RSA",
+					Certificates: toPem(pki.root, pki.intermediate), Crls: pki.crl(pki.ekCert)})
+				Expect(err).NotTo(HaveOccurred())
+				requestChallenge()
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Endorsement authority of the EK certificate is disabled", func() {
+			It("Should fall back to the registered EK certificate", func() {
+				_, err := authorityStore.Create(&hvs.EndorsementAuthority{Name: "Infineon RSA",
+					Certificates: toPem(pki.root, pki.intermediate), Disabled: true})
+				Expect(err).NotTo(HaveOccurred())
+				requestChallenge()
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				registered, err := ecStore.Retrieve(registeredId)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(registered.EndorsementProvenance.Source).To(Equal(hvs.EndorsementSourceRegistered))
+			})
+		})
+	})
+})
@@ -10,8 +10,10 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/antchfx/jsonquery"
@@ -35,6 +37,7 @@ import (
 	fType "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/types"
 	fu "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/util"
 	hcType "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/verifier"
 	ct "github.com/intel-secl/intel-secl/v3/pkg/model/aas"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"github.com/pkg/errors"
@@ -44,17 +47,21 @@ type FlavorController struct {
 	FStore    domain.FlavorStore
 	FGStore   domain.FlavorGroupStore
 	HStore    domain.HostStore
+	HSStore   domain.HostStatusStore
 	TCStore   domain.TagCertificateStore
 	HTManager domain.HostTrustManager
 	CertStore *dm.CertificatesStore
 	HostCon   HostController
 	FTStore   domain.FlavorTemplateStore
 	IsExsi    bool
+	// FlavorVerifier verifies the host manifests against the simulated flavors
+	FlavorVerifier verifier.Verifier
 }
 
-var flavorSearchParams = map[string]bool{"id": true, "key": true, "value": true, "flavorgroupId": true, "flavorParts": true}
+var flavorSearchParams = map[string]bool{"id": true, "key": true, "value": true, "flavorgroupId": true, "flavorParts": true,
+	"limit": true, "after": true}
 
-func NewFlavorController(fs domain.FlavorStore, fgs domain.FlavorGroupStore, hs domain.HostStore, tcs domain.TagCertificateStore, htm domain.HostTrustManager, certStore *dm.CertificatesStore, hcConfig domain.HostControllerConfig, fts domain.FlavorTemplateStore) *FlavorController {
+func NewFlavorController(fs domain.FlavorStore, fgs domain.FlavorGroupStore, hs domain.HostStore, hss domain.HostStatusStore, tcs domain.TagCertificateStore, htm domain.HostTrustManager, certStore *dm.CertificatesStore, hcConfig domain.HostControllerConfig, fts domain.FlavorTemplateStore) *FlavorController {
 	// certStore should have an entry for Flavor Signing CA
 	if _, found := (*certStore)[dm.CertTypesFlavorSigning.String()]; !found {
 		defaultLog.Errorf("controllers/flavor_controller:NewFlavorController() %s : Flavor Signing KeyPair not found in CertStore", commLogMsg.AppRuntimeErr)
@@ -77,6 +84,7 @@ func NewFlavorController(fs domain.FlavorStore, fgs domain.FlavorGroupStore, hs
 		FStore:    fs,
 		FGStore:   fgs,
 		HStore:    hs,
+		HSStore:   hss,
 		TCStore:   tcs,
 		HTManager: htm,
 		CertStore: certStore,
@@ -427,6 +435,8 @@ func (fcon *FlavorController) addFlavorToFlavorgroup(flavorFlavorPartMap map[fc.
 	var returnSignedFlavors []hvs.SignedFlavor
 	// map of flavorgroup to flavor UUID's to create the association
 	flavorgroupFlavorMap := make(map[uuid.UUID][]uuid.UUID)
+	// map of flavorgroup to the created flavors, to find the hosts impacted by the new flavors
+	flavorgroupFlavors := make(map[uuid.UUID][]hvs.Flavor)
 	var flavorgroupsForQueue []hvs.FlavorGroup
 	fetchHostData := false
 	var fgHostIds []uuid.UUID
@@ -543,6 +553,7 @@ func (fcon *FlavorController) addFlavorToFlavorgroup(flavorFlavorPartMap map[fc.
 				} else {
 					flavorgroupFlavorMap[flavorgroup.ID] = []uuid.UUID{signedFlavorCreated.Flavor.Meta.ID}
 				}
+				flavorgroupFlavors[flavorgroup.ID] = append(flavorgroupFlavors[flavorgroup.ID], signedFlavorCreated.Flavor)
 			}
 		}
 	}
@@ -559,36 +570,45 @@ func (fcon *FlavorController) addFlavorToFlavorgroup(flavorFlavorPartMap map[fc.
 		}
 	}
 	// get all the hosts that belong to the same flavor group and add them to flavor-verify queue
-	go fcon.addFlavorgroupHostsToFlavorVerifyQueue(flavorgroupsForQueue, fgHostIds, flavorgroupFlavorMap, fetchHostData)
+	go fcon.addFlavorgroupHostsToFlavorVerifyQueue(flavorgroupsForQueue, fgHostIds, flavorgroupFlavors, fetchHostData)
 	return returnSignedFlavors, nil
 }
 
+// addFlavorgroupHostsToFlavorVerifyQueue re-verifies the hosts of the flavorgroups. For the flavorgroups with new
+// flavors in flavorgroupFlavors, only the hosts whose manifest matches one of the new flavors are re-verified.
 func (fcon FlavorController) addFlavorgroupHostsToFlavorVerifyQueue(fgs []hvs.FlavorGroup, hostIds []uuid.UUID,
-	flavorgroupFlavorMap map[uuid.UUID][]uuid.UUID, forceUpdate bool) {
+	flavorgroupFlavors map[uuid.UUID][]hvs.Flavor, forceUpdate bool) {
 	defaultLog.Trace("controllers/flavor_controller:addFlavorgroupHostsToFlavorVerifyQueue() Entering")
 	defer defaultLog.Trace("controllers/flavor_controller:addFlavorgroupHostsToFlavorVerifyQueue() Leaving")
 	fgHosts := make(map[uuid.UUID]bool)
+	impactAnalyzer := fcon.impactAnalyzer()
 
 	// for each flavorgroup, find the hosts that belong to the flavorgroup
 	// and add it to the list of host ID's
 	for _, fg := range fgs {
 		defaultLog.Debugf("Adding hosts that belong to %s flavorgroup", fg.Name)
+		var hIds []uuid.UUID
 		if fg.Name == dm.FlavorGroupsHostUnique.String() && len(hostIds) >= 1 {
-			for _, hId := range hostIds {
-				if _, ok := fgHosts[hId]; !ok {
-					fgHosts[hId] = true
-				}
-			}
+			hIds = hostIds
 		} else {
-			hIds, err := fcon.FGStore.SearchHostsByFlavorGroup(fg.ID)
+			var err error
+			hIds, err = fcon.FGStore.SearchHostsByFlavorGroup(fg.ID)
 			if err != nil {
 				defaultLog.Errorf("controllers/flavor_controller:addFlavorgroupHostsToFlavorVerifyQueue(): Failed to fetch hosts linked to FlavorGroup")
 			}
-			for _, hId := range hIds {
-				// adding to the list only if not already added
-				if _, ok := fgHosts[hId]; !ok {
-					fgHosts[hId] = true
-				}
+		}
+
+		// only the hosts matching the new flavors of the flavorgroup can have their trust status changed
+		if flavors, ok := flavorgroupFlavors[fg.ID]; ok {
+			impactedHosts := impactAnalyzer.linkImpact(flavors, hIds)
+			defaultLog.Debugf("%v of %v hosts of %s flavorgroup are impacted by the new flavors", len(impactedHosts), len(hIds), fg.Name)
+			hIds = impactedHosts
+		}
+
+		for _, hId := range hIds {
+			// adding to the list only if not already added
+			if _, ok := fgHosts[hId]; !ok {
+				fgHosts[hId] = true
 			}
 		}
 	}
@@ -671,6 +691,11 @@ func (fcon *FlavorController) Search(w http.ResponseWriter, r *http.Request) (in
 		secLog.Errorf("controllers/flavor_controller:Search()  %s", err.Error())
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
 	}
+	filterCriteria.Limit, filterCriteria.After, err = utils.ParsePageQueryParams(r.URL.Query())
+	if err != nil {
+		secLog.Errorf("controllers/flavor_controller:Search()  %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
 
 	signedFlavors, err := fcon.FStore.Search(&dm.FlavorVerificationFC{
 		FlavorFC: *filterCriteria,
@@ -680,8 +705,14 @@ func (fcon *FlavorController) Search(w http.ResponseWriter, r *http.Request) (in
 		return nil, http.StatusInternalServerError, errors.Errorf("Unable to search Flavors")
 	}
 
+	signedFlavorCollection := hvs.SignedFlavorCollection{SignedFlavors: signedFlavors}
+	if len(signedFlavors) > 0 {
+		signedFlavorCollection.Next = utils.NextPageLink(r, filterCriteria.Limit, len(signedFlavors),
+			dm.PageCursor{Id: signedFlavors[len(signedFlavors)-1].Flavor.Meta.ID})
+	}
+
 	secLog.Infof("%s: Return flavor query to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
-	return hvs.SignedFlavorCollection{SignedFlavors: signedFlavors}, http.StatusOK, nil
+	return signedFlavorCollection, http.StatusOK, nil
 }
 
 func (fcon *FlavorController) Delete(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
@@ -702,13 +733,16 @@ func (fcon *FlavorController) Delete(w http.ResponseWriter, r *http.Request) (in
 		}
 	}
 
-	hostIdsForQueue, err := getHostsAssociatedWithFlavor(fcon.HStore, fcon.FGStore, signedFlavor)
+	fgHostIds, err := getHostsAssociatedWithFlavor(fcon.HStore, fcon.FGStore, signedFlavor)
 	if err != nil {
 		defaultLog.WithError(err).Error("controllers/flavor_controller:Delete() Failed to retrieve hosts " +
 			"associated with flavor")
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve hosts " +
 			"associated with flavor for trust re-verification"}
 	}
+	// only the hosts that trusted the flavor or matched it can have their trust status changed, the trust cache has
+	// to be checked before the flavor is deleted
+	hostIdsForQueue := fcon.impactAnalyzer().deleteImpact(&signedFlavor.Flavor, fgHostIds)
 
 	if err := fcon.FStore.Delete(flavorId); err != nil {
 		defaultLog.WithError(err).WithField("id", flavorId).Info(
@@ -729,7 +763,8 @@ func (fcon *FlavorController) Delete(w http.ResponseWriter, r *http.Request) (in
 	return nil, http.StatusNoContent, nil
 }
 
-func getHostsAssociatedWithFlavor(hStore domain.HostStore, fgStore domain.FlavorGroupStore, flavor *hvs.SignedFlavor) ([]uuid.UUID, error) {
+// getHostsAssociatedWithFlavor returns the hosts of each flavorgroup the flavor is linked to
+func getHostsAssociatedWithFlavor(hStore domain.HostStore, fgStore domain.FlavorGroupStore, flavor *hvs.SignedFlavor) (map[uuid.UUID][]uuid.UUID, error) {
 	defaultLog.Trace("controllers/flavor_controller:getHostsAssociatedWithFlavor() Entering")
 	defer defaultLog.Trace("controllers/flavor_controller:getHostsAssociatedWithFlavor() Leaving")
 
@@ -740,7 +775,7 @@ func getHostsAssociatedWithFlavor(hStore domain.HostStore, fgStore domain.Flavor
 			"associated with flavor %v for trust re-verification", id)
 	}
 
-	var hostIdsForQueue []uuid.UUID
+	fgHostIds := make(map[uuid.UUID][]uuid.UUID)
 	for _, flavorGroup := range flavorGroups {
 		//Host unique flavors are associated with only host_unique flavorgroup and associated with only one host uniquely
 		if flavorGroup.Name == dm.FlavorGroupsHostUnique.String() {
@@ -756,7 +791,7 @@ func getHostsAssociatedWithFlavor(hStore domain.HostStore, fgStore domain.Flavor
 					"associated with flavor %v for trust re-verification", id)
 			}
 			if len(hosts) > 0 {
-				hostIdsForQueue = append(hostIdsForQueue, hosts[0].Id)
+				fgHostIds[flavorGroup.ID] = append(fgHostIds[flavorGroup.ID], hosts[0].Id)
 				break
 			}
 		}
@@ -765,9 +800,171 @@ func getHostsAssociatedWithFlavor(hStore domain.HostStore, fgStore domain.Flavor
 			return nil, errors.Wrapf(err, "controllers/flavor_controller:getHostsAssociatedWithFlavor() Failed to retrieve hosts "+
 				"associated with flavorgroup %v for trust re-verification", flavorGroup.ID)
 		}
-		hostIdsForQueue = append(hostIdsForQueue, hostIds...)
+		fgHostIds[flavorGroup.ID] = append(fgHostIds[flavorGroup.ID], hostIds...)
+	}
+	return fgHostIds, nil
+}
+
+// Impact evaluates a flavor change without applying it, and returns the hosts that would be re-verified
+func (fcon *FlavorController) Impact(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:Impact() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:Impact() Leaving")
+
+	if r.Header.Get("Content-Type") != constants.HTTPMediaTypeJson {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/flavor_controller:Impact() The request body is not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	var impactReq hvs.FlavorImpactRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&impactReq); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:Impact() %s :  Failed to decode request body as FlavorImpactRequest", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	if impactReq.Operation != hvs.FlavorImpactLink && impactReq.Operation != hvs.FlavorImpactUnlink &&
+		impactReq.Operation != hvs.FlavorImpactDelete {
+		secLog.Errorf("controllers/flavor_controller:Impact() %s : Invalid operation %s", commLogMsg.InvalidInputBadParam, impactReq.Operation)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid operation, must be one of link, unlink or delete"}
+	}
+	if impactReq.Operation != hvs.FlavorImpactDelete && impactReq.FlavorgroupID == uuid.Nil {
+		secLog.Errorf("controllers/flavor_controller:Impact() %s : Flavorgroup ID not provided", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavorgroup ID must be provided for link and unlink operations"}
+	}
+
+	var signedFlavor *hvs.SignedFlavor
+	if impactReq.Flavor != nil {
+		if impactReq.FlavorID != uuid.Nil || impactReq.Operation != hvs.FlavorImpactLink {
+			secLog.Errorf("controllers/flavor_controller:Impact() %s : Flavor content provided with flavor ID or for %s operation", commLogMsg.InvalidInputBadParam, impactReq.Operation)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor content can only be provided instead of a flavor ID for link operation"}
+		}
+		if err := validateFlavorMetaContent(&impactReq.Flavor.Meta); err != nil {
+			secLog.WithError(err).Errorf("controllers/flavor_controller:Impact() %s : Invalid flavor content", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+		}
+		signedFlavor = &hvs.SignedFlavor{Flavor: *impactReq.Flavor}
+	} else {
+		if impactReq.FlavorID == uuid.Nil {
+			secLog.Errorf("controllers/flavor_controller:Impact() %s : Flavor ID not provided", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor ID must be provided"}
+		}
+		var err error
+		signedFlavor, err = fcon.FStore.Retrieve(impactReq.FlavorID)
+		if err != nil {
+			if strings.Contains(err.Error(), commErr.RowsNotFound) {
+				secLog.WithError(err).WithField("id", impactReq.FlavorID).Info("controllers/flavor_controller:Impact() Flavor with given ID does not exist")
+				return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor with given ID does not exist"}
+			}
+			defaultLog.WithError(err).WithField("id", impactReq.FlavorID).Error("controllers/flavor_controller:Impact() Failed to retrieve Flavor")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Flavor"}
+		}
+	}
+
+	fgHostIds := make(map[uuid.UUID][]uuid.UUID)
+	if impactReq.Operation == hvs.FlavorImpactDelete {
+		var err error
+		fgHostIds, err = getHostsAssociatedWithFlavor(fcon.HStore, fcon.FGStore, signedFlavor)
+		if err != nil {
+			defaultLog.WithError(err).Error("controllers/flavor_controller:Impact() Failed to retrieve hosts associated with flavor")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve hosts associated with flavor"}
+		}
+	} else {
+		hostIds, status, err := fcon.getFlavorgroupHostsForImpact(impactReq.Operation, impactReq.FlavorgroupID, signedFlavor)
+		if err != nil {
+			return nil, status, err
+		}
+		fgHostIds[impactReq.FlavorgroupID] = hostIds
+	}
+
+	impact := hvs.FlavorImpact{
+		Operation:      impactReq.Operation,
+		FlavorID:       signedFlavor.Flavor.Meta.ID,
+		FlavorgroupIDs: []uuid.UUID{},
+		ImpactedHosts:  []uuid.UUID{},
+	}
+	var linkedHosts []uuid.UUID
+	for fgId, hostIds := range fgHostIds {
+		impact.FlavorgroupIDs = append(impact.FlavorgroupIDs, fgId)
+		linkedHosts = append(linkedHosts, hostIds...)
+	}
+	impact.LinkedHostCount = len(uniqueUUIDs(linkedHosts))
+
+	if impactReq.Operation == hvs.FlavorImpactLink {
+		impact.ImpactedHosts = append(impact.ImpactedHosts, fcon.impactAnalyzer().linkImpact([]hvs.Flavor{signedFlavor.Flavor}, linkedHosts)...)
+	} else {
+		impact.ImpactedHosts = append(impact.ImpactedHosts, fcon.impactAnalyzer().deleteImpact(&signedFlavor.Flavor, fgHostIds)...)
+	}
+	impact.ImpactedHostCount = len(impact.ImpactedHosts)
+
+	secLog.Infof("%s: Return flavor impact to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return impact, http.StatusOK, nil
+}
+
+// getFlavorgroupHostsForImpact returns the hosts of the flavorgroup a flavor is linked to or unlinked from
+func (fcon *FlavorController) getFlavorgroupHostsForImpact(operation hvs.FlavorImpactOperation, fgId uuid.UUID, signedFlavor *hvs.SignedFlavor) ([]uuid.UUID, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:getFlavorgroupHostsForImpact() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:getFlavorgroupHostsForImpact() Leaving")
+
+	flavorgroup, err := fcon.FGStore.Retrieve(fgId)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			secLog.WithError(err).WithField("id", fgId).Info("controllers/flavor_controller:getFlavorgroupHostsForImpact() Flavorgroup with given ID does not exist")
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavorgroup with given ID does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", fgId).Error("controllers/flavor_controller:getFlavorgroupHostsForImpact() Failed to retrieve Flavorgroup")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Flavorgroup"}
+	}
+
+	_, err = fcon.FGStore.RetrieveFlavor(fgId, signedFlavor.Flavor.Meta.ID)
+	linked := err == nil
+	if err != nil && !strings.Contains(err.Error(), commErr.RowsNotFound) {
+		defaultLog.WithError(err).WithField("id", fgId).Error("controllers/flavor_controller:getFlavorgroupHostsForImpact() Failed to retrieve Flavorgroup-Flavor link")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Flavorgroup-Flavor link"}
+	}
+	if operation == hvs.FlavorImpactLink && linked {
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor is already linked to the Flavorgroup"}
+	}
+	if operation == hvs.FlavorImpactUnlink && !linked {
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor is not linked to the Flavorgroup"}
+	}
+
+	// hosts are not linked to the host_unique flavorgroup, host unique flavors apply to the host with the same hardware UUID
+	if flavorgroup.Name == dm.FlavorGroupsHostUnique.String() {
+		hardwareUUID, ok := signedFlavor.Flavor.Meta.Description[fm.HardwareUUID].(string)
+		if !ok {
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Hardware UUID must be specified in the flavor linked to the host_unique flavorgroup"}
+		}
+		hwUUID, err := uuid.Parse(hardwareUUID)
+		if err != nil {
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid hardware UUID in flavor"}
+		}
+		hosts, err := fcon.HStore.Search(&dm.HostFilterCriteria{HostHardwareId: hwUUID}, nil)
+		if err != nil {
+			defaultLog.WithError(err).Error("controllers/flavor_controller:getFlavorgroupHostsForImpact() Failed to retrieve hosts with hardware UUID of flavor")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve hosts associated with flavor"}
+		}
+		var hostIds []uuid.UUID
+		for _, host := range hosts {
+			hostIds = append(hostIds, host.Id)
+		}
+		return hostIds, http.StatusOK, nil
+	}
+
+	hostIds, err := fcon.FGStore.SearchHostsByFlavorGroup(fgId)
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", fgId).Error("controllers/flavor_controller:getFlavorgroupHostsForImpact() Failed to retrieve hosts linked to Flavorgroup")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve hosts linked to Flavorgroup"}
 	}
-	return hostIdsForQueue, nil
+	return hostIds, http.StatusOK, nil
+}
+
+func (fcon FlavorController) impactAnalyzer() flavorImpactAnalyzer {
+	return flavorImpactAnalyzer{HStore: fcon.HStore, HSStore: fcon.HSStore}
 }
 
 func (fcon *FlavorController) Retrieve(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
@@ -811,6 +1008,271 @@ func (fcon *FlavorController) RetrieveEsxiFlavor(w http.ResponseWriter, r *http.
 	return flavor, http.StatusOK, nil
 }
 
+func (fcon *FlavorController) Update(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:Update() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:Update() Leaving")
+
+	if r.Header.Get("Content-Type") != constants.HTTPMediaTypeJson {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/flavor_controller:Update() The request body is not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	var updateReq hvs.FlavorUpdateRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&updateReq); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:Update() %s :  Failed to decode request body as Flavor", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	if err := validateFlavorMetaContent(&updateReq.Flavor.Meta); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:Update() %s : Invalid flavor content", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+	if updateReq.Comment != "" && validation.ValidateTextString(updateReq.Comment) != nil {
+		secLog.Errorf("controllers/flavor_controller:Update() %s : Invalid comment", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid comment"}
+	}
+
+	flavorId := uuid.MustParse(mux.Vars(r)["id"])
+	existingFlavor, status, err := fcon.retrieveFlavorForRevision(flavorId)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if updateReq.Flavor.Meta.ID != uuid.Nil && updateReq.Flavor.Meta.ID != flavorId {
+		secLog.Errorf("controllers/flavor_controller:Update() %s : Flavor ID in request body does not match the path", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor ID in request body does not match the path"}
+	}
+	if existingFlavor.Flavor.Meta.Description[fm.FlavorPart] != updateReq.Flavor.Meta.Description[fm.FlavorPart] {
+		secLog.Errorf("controllers/flavor_controller:Update() %s : Flavor part of a flavor cannot be changed", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor part of a flavor cannot be changed"}
+	}
+	updateReq.Flavor.Meta.ID = flavorId
+
+	flavorSignKey, ok := (*fcon.CertStore)[dm.CertTypesFlavorSigning.String()].Key.(*rsa.PrivateKey)
+	if !ok {
+		defaultLog.Errorf("controllers/flavor_controller:Update() %s : Flavor Signing Key not found in CertStore", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error signing flavor"}
+	}
+	signedFlavor, err := fu.PlatformFlavorUtil{}.GetSignedFlavor(&updateReq.Flavor, flavorSignKey)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_controller:Update() Error signing flavor")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error signing flavor"}
+	}
+
+	flavorRevision, status, err := fcon.createFlavorRevision(r, &hvs.FlavorRevision{
+		FlavorID:     flavorId,
+		SignedFlavor: *signedFlavor,
+		Comment:      updateReq.Comment,
+	})
+	if err != nil {
+		return nil, status, err
+	}
+
+	secLog.WithField("id", flavorId).Infof("Flavor updated to revision %d", flavorRevision.Revision)
+	return flavorRevision, http.StatusOK, nil
+}
+
+func (fcon *FlavorController) Rollback(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:Rollback() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:Rollback() Leaving")
+
+	if r.Header.Get("Content-Type") != constants.HTTPMediaTypeJson {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/flavor_controller:Rollback() The request body is not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	var rollbackReq hvs.FlavorRollbackRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&rollbackReq); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:Rollback() %s :  Failed to decode request body as FlavorRollbackRequest", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	if rollbackReq.Revision < 1 {
+		secLog.Errorf("controllers/flavor_controller:Rollback() %s : Invalid revision", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Valid revision must be specified"}
+	}
+	if rollbackReq.Comment != "" && validation.ValidateTextString(rollbackReq.Comment) != nil {
+		secLog.Errorf("controllers/flavor_controller:Rollback() %s : Invalid comment", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid comment"}
+	}
+
+	flavorId := uuid.MustParse(mux.Vars(r)["id"])
+	if _, status, err := fcon.retrieveFlavorForRevision(flavorId); err != nil {
+		return nil, status, err
+	}
+
+	revisions, err := fcon.FStore.SearchRevisions(flavorId)
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", flavorId).Error("controllers/flavor_controller:Rollback() Failed to retrieve flavor revisions")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve flavor revisions"}
+	}
+
+	var targetRevision *hvs.FlavorRevision
+	for i := range revisions {
+		if revisions[i].Revision == rollbackReq.Revision {
+			targetRevision = &revisions[i]
+			break
+		}
+	}
+	if targetRevision == nil {
+		secLog.WithField("id", flavorId).Errorf("controllers/flavor_controller:Rollback() %s : Flavor revision %d does not exist", commLogMsg.InvalidInputBadParam, rollbackReq.Revision)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor revision with given number does not exist"}
+	}
+	// revisions are ordered latest first
+	if revisions[0].Revision == targetRevision.Revision {
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor is already at the given revision"}
+	}
+
+	comment := rollbackReq.Comment
+	if comment == "" {
+		comment = fmt.Sprintf("Rollback to revision %d", targetRevision.Revision)
+	}
+	flavorRevision, status, err := fcon.createFlavorRevision(r, &hvs.FlavorRevision{
+		FlavorID:     flavorId,
+		SignedFlavor: targetRevision.SignedFlavor,
+		Comment:      comment,
+	})
+	if err != nil {
+		return nil, status, err
+	}
+
+	secLog.WithField("id", flavorId).Infof("Flavor rolled back to revision %d as revision %d", targetRevision.Revision, flavorRevision.Revision)
+	return flavorRevision, http.StatusOK, nil
+}
+
+func (fcon *FlavorController) SearchRevisions(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:SearchRevisions() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:SearchRevisions() Leaving")
+
+	flavorId := uuid.MustParse(mux.Vars(r)["id"])
+	if _, status, err := fcon.retrieveFlavorForRevision(flavorId); err != nil {
+		return nil, status, err
+	}
+
+	revisions, err := fcon.FStore.SearchRevisions(flavorId)
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", flavorId).Error("controllers/flavor_controller:SearchRevisions() Failed to retrieve flavor revisions")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve flavor revisions"}
+	}
+
+	secLog.Infof("%s: Return flavor revisions query to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return hvs.FlavorRevisionCollection{FlavorRevisions: revisions}, http.StatusOK, nil
+}
+
+func (fcon *FlavorController) RetrieveRevision(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:RetrieveRevision() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:RetrieveRevision() Leaving")
+
+	flavorId := uuid.MustParse(mux.Vars(r)["id"])
+	revision, err := strconv.Atoi(mux.Vars(r)["revision"])
+	if err != nil || revision < 1 {
+		secLog.Errorf("controllers/flavor_controller:RetrieveRevision() %s : Invalid revision", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid revision"}
+	}
+
+	flavorRevision, err := fcon.FStore.RetrieveRevision(flavorId, revision)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			secLog.WithError(err).WithField("id", flavorId).Info(
+				"controllers/flavor_controller:RetrieveRevision() Flavor revision does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Flavor revision does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", flavorId).Error("controllers/flavor_controller:RetrieveRevision() Failed to retrieve flavor revision")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve flavor revision"}
+	}
+	return flavorRevision, http.StatusOK, nil
+}
+
+func (fcon *FlavorController) retrieveFlavorForRevision(flavorId uuid.UUID) (*hvs.SignedFlavor, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:retrieveFlavorForRevision() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:retrieveFlavorForRevision() Leaving")
+
+	signedFlavor, err := fcon.FStore.Retrieve(flavorId)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			secLog.WithError(err).WithField("id", flavorId).Info(
+				"controllers/flavor_controller:retrieveFlavorForRevision() Flavor with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Flavor with given ID does not exist"}
+		}
+		secLog.WithError(err).WithField("id", flavorId).Info(
+			"controllers/flavor_controller:retrieveFlavorForRevision() failed to retrieve Flavor")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Flavor with the given ID"}
+	}
+	return signedFlavor, http.StatusOK, nil
+}
+
+// createFlavorRevision persists a new revision of a flavor on behalf of the requesting user and queues
+// the hosts linked to the flavor for re-verification
+func (fcon *FlavorController) createFlavorRevision(r *http.Request, fr *hvs.FlavorRevision) (*hvs.FlavorRevision, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:createFlavorRevision() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:createFlavorRevision() Leaving")
+
+	createdBy, err := comctx.GetTokenSubject(r)
+	if err != nil {
+		defaultLog.WithError(err).Debug("controllers/flavor_controller:createFlavorRevision() Token subject not available")
+	}
+	fr.CreatedBy = createdBy
+
+	flavorRevision, err := fcon.FStore.Update(fr)
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", fr.FlavorID).Error("controllers/flavor_controller:createFlavorRevision() Failed to update flavor")
+		if strings.Contains(err.Error(), "duplicate key") {
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor with same label already exists"}
+		}
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Flavor with given ID does not exist"}
+		}
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to update flavor"}
+	}
+
+	id := fr.FlavorID
+	flavorGroups, err := fcon.FGStore.Search(&dm.FlavorGroupFilterCriteria{FlavorId: &id})
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_controller:createFlavorRevision() Failed to retrieve flavorgroups " +
+			"associated with flavor")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve flavorgroups " +
+			"associated with flavor for trust re-verification"}
+	}
+
+	var hostIds []uuid.UUID
+	if flavorRevision.SignedFlavor.Flavor.Meta.Description[fm.FlavorPart] == fc.FlavorPartHostUnique.String() {
+		if hwUUID, ok := flavorRevision.SignedFlavor.Flavor.Meta.Description[fm.HardwareUUID].(string); ok {
+			hardwareUUID, err := uuid.Parse(hwUUID)
+			if err != nil {
+				defaultLog.WithError(err).Error("controllers/flavor_controller:createFlavorRevision() Failed to parse hardwareUUID")
+				return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve hosts " +
+					"associated with flavor for trust re-verification"}
+			}
+			hosts, err := fcon.HStore.Search(&dm.HostFilterCriteria{HostHardwareId: hardwareUUID}, nil)
+			if err != nil {
+				defaultLog.WithError(err).Error("controllers/flavor_controller:createFlavorRevision() Failed to retrieve hosts " +
+					"associated with flavor")
+				return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve hosts " +
+					"associated with flavor for trust re-verification"}
+			}
+			for _, host := range hosts {
+				hostIds = append(hostIds, host.Id)
+			}
+		}
+	}
+
+	// get all the hosts that are linked to the flavor and add them to flavor-verify queue
+	go fcon.addFlavorgroupHostsToFlavorVerifyQueue(flavorGroups, hostIds, nil, false)
+	return flavorRevision, http.StatusOK, nil
+}
+
 func validateFlavorFilterCriteria(key, value, flavorgroupId string, ids, flavorParts []string) (*dm.FlavorFilterCriteria, error) {
 	defaultLog.Trace("controllers/flavor_controller:validateFlavorFilterCriteria() Entering")
 	defer defaultLog.Trace("controllers/flavor_controller:validateFlavorFilterCriteria() Leaving")
@@ -5,20 +5,29 @@
 package controllers_test
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	hvsConsts "github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	dm "github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
 	hvsRoutes "github.com/intel-secl/intel-secl/v3/pkg/hvs/router"
 	smocks "github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust/mocks"
 	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
 	mocks2 "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/mocks"
+	hcTypes "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/verifier"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -73,6 +82,7 @@ var _ = Describe("FlavorController", func() {
 			FStore:    flavorStore,
 			FGStore:   flavorGroupStore,
 			HStore:    hostStore,
+			HSStore:   hostStatusStore,
 			CertStore: certStore,
 			TCStore:   tagCertStore,
 			HTManager: hostTrustManager,
@@ -127,8 +137,7 @@ var _ = Describe("FlavorController", func() {
 				var sfs *hvs.SignedFlavorCollection
 				err = json.Unmarshal(w.Body.Bytes(), &sfs)
 				Expect(err).NotTo(HaveOccurred())
-				//TODO Requires changes in mock flavor search method for this criteria
-				Expect(len(sfs.SignedFlavors)).To(Equal(0))
+				Expect(len(sfs.SignedFlavors)).To(Equal(1))
 			})
 		})
 	})
@@ -201,6 +210,315 @@ var _ = Describe("FlavorController", func() {
 		})
 	})
 
+	// Specs for HTTP Post to "/flavors/impact"
+	Describe("Evaluate Flavor impact", func() {
+		// the latest host status of this host has a manifest which does not match the PLATFORM flavor of the store
+		reportedHostId := uuid.MustParse("47a3b602-f321-4e03-b3b2-8f3ca3cde128")
+		unverifiedHostId := uuid.MustParse("5ba5a2cb-6b84-4ccc-b7fb-64b7d2c2ba55")
+		flavorId := uuid.MustParse("c36b5412-8c02-4e08-8a74-8bfa40425cf3")
+		fgId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+
+		evaluateImpact := func(body string) *hvs.FlavorImpact {
+			router.Handle("/flavors/impact", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Impact))).Methods("POST")
+			req, err := http.NewRequest("POST", "/flavors/impact", strings.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				return nil
+			}
+			var impact hvs.FlavorImpact
+			Expect(json.Unmarshal(w.Body.Bytes(), &impact)).To(Succeed())
+			return &impact
+		}
+
+		BeforeEach(func() {
+			flavorGroupStore.HostFlavorgroupStore = []*hvs.HostFlavorgroup{
+				{HostId: reportedHostId, FlavorgroupId: fgId},
+				{HostId: unverifiedHostId, FlavorgroupId: fgId},
+			}
+		})
+
+		Context("Link a Flavor which does not match the manifest of a host", func() {
+			It("Should only report the host without manifest as impacted", func() {
+				impact := evaluateImpact(`{"operation": "link", "flavor_id": "c36b5412-8c02-4e08-8a74-8bfa40425cf3",
+					"flavorgroup_id": "ee37c360-7eae-4250-a677-6ee12adce8e2"}`)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(impact.LinkedHostCount).To(Equal(2))
+				Expect(impact.ImpactedHostCount).To(Equal(1))
+				Expect(impact.ImpactedHosts).To(ConsistOf([]uuid.UUID{unverifiedHostId}))
+			})
+		})
+
+		Context("Link a Flavor which matches the manifest of a host", func() {
+			It("Should report the host as impacted", func() {
+				var flavor hvs.Flavor
+				Expect(copyFlavor(flavorStore, &flavor)).To(Succeed())
+				flavor.Meta.Description["bios_name"] = "Intel Corporation"
+				flavor.Meta.Description["bios_version"] = "SE5C620.86B.00.01.6016.032720190737"
+				flavorJson, err := json.Marshal(flavor)
+				Expect(err).NotTo(HaveOccurred())
+
+				impact := evaluateImpact(`{"operation": "link", "flavorgroup_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+					"flavor": ` + string(flavorJson) + `}`)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(impact.ImpactedHosts).To(ConsistOf([]uuid.UUID{reportedHostId, unverifiedHostId}))
+			})
+		})
+
+		Context("Unlink a Flavor", func() {
+			BeforeEach(func() {
+				_, err := flavorGroupStore.AddFlavors(fgId, []uuid.UUID{flavorId})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("Should not report the host that did not trust the Flavor as impacted", func() {
+				impact := evaluateImpact(`{"operation": "unlink", "flavor_id": "c36b5412-8c02-4e08-8a74-8bfa40425cf3",
+					"flavorgroup_id": "ee37c360-7eae-4250-a677-6ee12adce8e2"}`)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(impact.ImpactedHosts).To(ConsistOf([]uuid.UUID{unverifiedHostId}))
+			})
+
+			It("Should report the host with the Flavor in its trust cache as impacted", func() {
+				_, err := hostStore.AddTrustCacheFlavors(reportedHostId, []uuid.UUID{flavorId})
+				Expect(err).NotTo(HaveOccurred())
+
+				impact := evaluateImpact(`{"operation": "unlink", "flavor_id": "c36b5412-8c02-4e08-8a74-8bfa40425cf3",
+					"flavorgroup_id": "ee37c360-7eae-4250-a677-6ee12adce8e2"}`)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(impact.LinkedHostCount).To(Equal(2))
+				Expect(impact.ImpactedHosts).To(ConsistOf([]uuid.UUID{reportedHostId, unverifiedHostId}))
+			})
+
+			It("Should report the impact of deleting the Flavor", func() {
+				_, err := hostStore.AddTrustCacheFlavors(reportedHostId, []uuid.UUID{flavorId})
+				Expect(err).NotTo(HaveOccurred())
+
+				impact := evaluateImpact(`{"operation": "delete", "flavor_id": "c36b5412-8c02-4e08-8a74-8bfa40425cf3"}`)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(impact.FlavorgroupIDs).To(ConsistOf([]uuid.UUID{fgId}))
+				Expect(impact.ImpactedHostCount).To(Equal(2))
+			})
+		})
+
+		Context("Unlink a Flavor which is not linked to the Flavorgroup", func() {
+			It("Should return 400 response code", func() {
+				evaluateImpact(`{"operation": "unlink", "flavor_id": "c36b5412-8c02-4e08-8a74-8bfa40425cf3",
+					"flavorgroup_id": "ee37c360-7eae-4250-a677-6ee12adce8e2"}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Link a Flavor without Flavorgroup", func() {
+			It("Should return 400 response code", func() {
+				evaluateImpact(`{"operation": "link", "flavor_id": "c36b5412-8c02-4e08-8a74-8bfa40425cf3"}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Evaluate an invalid operation", func() {
+			It("Should return 400 response code", func() {
+				evaluateImpact(`{"operation": "update", "flavor_id": "c36b5412-8c02-4e08-8a74-8bfa40425cf3"}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Evaluate the deletion of a non-existent Flavor", func() {
+			It("Should return 400 response code", func() {
+				evaluateImpact(`{"operation": "delete", "flavor_id": "73755fda-c910-46be-821f-e8ddeab189e9"}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Post to "/flavors/simulate"
+	Describe("Simulate Flavors", func() {
+		simulate := func(body string) *hvs.FlavorSimulation {
+			router.Handle("/flavors/simulate", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Simulate))).Methods("POST")
+			req, err := http.NewRequest("POST", "/flavors/simulate", strings.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				return nil
+			}
+			var simulation hvs.FlavorSimulation
+			Expect(json.Unmarshal(w.Body.Bytes(), &simulation)).To(Succeed())
+			return &simulation
+		}
+
+		flavorCollectionJson := func(updateDescription func(description map[string]interface{})) string {
+			var flavor hvs.Flavor
+			Expect(copyFlavor(flavorStore, &flavor)).To(Succeed())
+			updateDescription(flavor.Meta.Description)
+			flavorJson, err := json.Marshal(flavor)
+			Expect(err).NotTo(HaveOccurred())
+			return `{"flavors": [{"flavor": ` + string(flavorJson) + `}]}`
+		}
+
+		matchingFlavorCollectionJson := func() string {
+			return flavorCollectionJson(func(description map[string]interface{}) {
+				description["bios_name"] = "Intel Corporation"
+				description["bios_version"] = "SE5C620.86B.00.01.6016.032720190737"
+			})
+		}
+
+		BeforeEach(func() {
+			flavorController.FlavorVerifier = &fakeFlavorVerifier{trusted: true}
+			flavorController.FTStore = mocks.NewFakeFlavorTemplateStore()
+		})
+
+		Context("Simulate a Flavor which does not match the manifest of the selected host", func() {
+			It("Should report the host as untrusted", func() {
+				simulation := simulate(`{"flavor_collection": ` + flavorCollectionJson(func(map[string]interface{}) {}) +
+					`, "host_label_selector": "env=prod"}`)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(simulation.HostCount).To(Equal(1))
+				Expect(simulation.UntrustedHostCount).To(Equal(1))
+				Expect(simulation.Hosts[0].HostName).To(Equal("localhost1"))
+				Expect(simulation.Hosts[0].Trusted).To(BeFalse())
+				Expect(simulation.Hosts[0].FlavorResults).To(HaveLen(1))
+				Expect(simulation.Hosts[0].FlavorResults[0].Matched).To(BeFalse())
+			})
+		})
+
+		Context("Simulate a Flavor which matches the manifest of the selected host", func() {
+			It("Should report the trust of the host against the Flavor", func() {
+				simulation := simulate(`{"flavor_collection": ` + matchingFlavorCollectionJson() + `, "host_label_selector": "env=prod"}`)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(simulation.TrustedHostCount).To(Equal(1))
+				Expect(simulation.Hosts[0].Trusted).To(BeTrue())
+				Expect(simulation.Hosts[0].FlavorResults[0].Matched).To(BeTrue())
+				Expect(simulation.Hosts[0].FlavorResults[0].FlavorPart).To(Equal("PLATFORM"))
+				Expect(simulation.Hosts[0].FlavorResults[0].RuleResults).To(HaveLen(1))
+
+				flavorController.FlavorVerifier = &fakeFlavorVerifier{trusted: false}
+				simulation = simulate(`{"flavor_collection": ` + matchingFlavorCollectionJson() + `, "host_label_selector": "env=prod"}`)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(simulation.UntrustedHostCount).To(Equal(1))
+				Expect(simulation.Hosts[0].Trusted).To(BeFalse())
+			})
+		})
+
+		Context("Simulate Flavors generated from a reference host manifest", func() {
+			It("Should verify the generated Flavors without storing them", func() {
+				var hostStatus hvs.HostStatus
+				Expect(json.Unmarshal([]byte(mocks.HostStatus1), &hostStatus)).To(Succeed())
+				manifestJson, err := json.Marshal(hostStatus.HostManifest)
+				Expect(err).NotTo(HaveOccurred())
+
+				simulation := simulate(`{"host_manifest": ` + string(manifestJson) + `,
+					"flavor_template_ids": ["426912bd-39b0-4daa-ad21-0c6933230b50"], "flavor_parts": ["PLATFORM"],
+					"host_label_selector": "env=prod"}`)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(simulation.Flavors).To(HaveLen(1))
+				Expect(simulation.Hosts[0].FlavorResults[0].FlavorID).To(Equal(simulation.Flavors[0].Meta.ID))
+				Expect(simulation.Hosts[0].FlavorResults[0].Matched).To(BeTrue())
+				Expect(simulation.TrustedHostCount).To(Equal(1))
+
+				_, err = flavorStore.Retrieve(simulation.Flavors[0].Meta.ID)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("Simulate a Flavor on the hosts of a Flavorgroup", func() {
+			It("Should skip the hosts without manifest", func() {
+				flavorGroupStore.HostFlavorgroupStore = []*hvs.HostFlavorgroup{
+					{HostId: uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2"), FlavorgroupId: uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")},
+					{HostId: uuid.MustParse("e57e5ea0-d465-461e-882d-1600090caa0d"), FlavorgroupId: uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")},
+				}
+				simulation := simulate(`{"flavor_collection": ` + matchingFlavorCollectionJson() +
+					`, "flavorgroup_id": "ee37c360-7eae-4250-a677-6ee12adce8e2"}`)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(simulation.HostCount).To(Equal(2))
+				Expect(simulation.TrustedHostCount).To(Equal(1))
+				Expect(simulation.SkippedHostCount).To(Equal(1))
+			})
+		})
+
+		Context("Simulate Flavors without a match for a flavor part required by the Flavorgroup", func() {
+			It("Should report the host as untrusted", func() {
+				flavorGroupStore.HostFlavorgroupStore = []*hvs.HostFlavorgroup{
+					{HostId: uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2"), FlavorgroupId: uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")},
+				}
+				var osFlavor hvs.Flavor
+				Expect(copyFlavor(flavorStore, &osFlavor)).To(Succeed())
+				osFlavor.Meta.Description["flavor_part"] = "OS"
+				osFlavor.Meta.Description["os_name"] = "NoSuchOS"
+				osFlavorJson, err := json.Marshal(osFlavor)
+				Expect(err).NotTo(HaveOccurred())
+				flavorCollection := strings.TrimSuffix(matchingFlavorCollectionJson(), "]}") + `, {"flavor": ` + string(osFlavorJson) + `}]}`
+
+				simulation := simulate(`{"flavor_collection": ` + flavorCollection + `, "flavorgroup_id": "ee37c360-7eae-4250-a677-6ee12adce8e2"}`)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(simulation.UntrustedHostCount).To(Equal(1))
+				Expect(simulation.Hosts[0].FlavorResults).To(HaveLen(2))
+				Expect(simulation.Hosts[0].MissingFlavorParts).To(ConsistOf("OS"))
+			})
+		})
+
+		Context("Simulate a Flavor on more hosts than allowed", func() {
+			It("Should return 400 response code", func() {
+				for i := 0; i <= hvsConsts.MaxFlavorSimulationHosts; i++ {
+					_, err := hostStore.Create(&hvs.Host{
+						Id:       uuid.New(),
+						HostName: fmt.Sprintf("loadhost%d", i),
+						Labels:   map[string]string{"env": "load"},
+					})
+					Expect(err).NotTo(HaveOccurred())
+				}
+				simulate(`{"flavor_collection": ` + matchingFlavorCollectionJson() + `, "host_label_selector": "env=load"}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Simulate a Flavor on a host whose manifest cannot be verified", func() {
+			It("Should skip the host", func() {
+				// the AIK certificate of the mocked host manifest cannot be parsed by the verifier
+				flavorVerifier, err := verifier.NewVerifier(verifier.VerifierCertificates{
+					PrivacyCACertificates:    x509.NewCertPool(),
+					AssetTagCACertificates:   x509.NewCertPool(),
+					FlavorSigningCertificate: &x509.Certificate{},
+					FlavorCACertificates:     x509.NewCertPool(),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				flavorController.FlavorVerifier = flavorVerifier
+
+				simulation := simulate(`{"flavor_collection": ` + matchingFlavorCollectionJson() + `, "host_label_selector": "env=prod"}`)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(simulation.SkippedHostCount).To(Equal(1))
+				Expect(simulation.Hosts[0].Error).NotTo(BeEmpty())
+			})
+		})
+
+		Context("Simulate a Flavor without selecting hosts", func() {
+			It("Should return 400 response code", func() {
+				simulate(`{"flavor_collection": ` + matchingFlavorCollectionJson() + `}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Simulate with both flavor content and a reference host manifest", func() {
+			It("Should return 400 response code", func() {
+				simulate(`{"flavor_collection": ` + matchingFlavorCollectionJson() +
+					`, "host_manifest": {}, "host_label_selector": "env=prod"}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Simulate with a non-existent flavor template", func() {
+			It("Should return 400 response code", func() {
+				simulate(`{"host_manifest": {}, "flavor_template_ids": ["73755fda-c910-46be-821f-e8ddeab189e9"],
+					"host_label_selector": "env=prod"}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
 	// Specs for HTTP Post to "/flavor"
 	Describe("Create a new flavor", func() {
 		Context("Provide a invalid Create request with XSS Attack Strings", func() {
@@ -962,4 +1280,245 @@ var _ = Describe("FlavorController", func() {
 			})
 		})
 	})
+
+	// Specs for HTTP Put to "/flavors/{flavorId}"
+	Describe("Update Flavor", func() {
+		BeforeEach(func() {
+			(*flavorController.CertStore)[dm.CertTypesFlavorSigning.String()].Key, _ = rsa.GenerateKey(rand.Reader, 3072)
+		})
+
+		var updateRequest = func(flavorPart, comment string) string {
+			var flavor hvs.Flavor
+			Expect(copyFlavor(flavorStore, &flavor)).To(Succeed())
+			flavor.Meta.Description["source"] = "Purley22"
+			flavor.Meta.Description["flavor_part"] = flavorPart
+			body, err := json.Marshal(hvs.FlavorUpdateRequest{Flavor: flavor, Comment: comment})
+			Expect(err).NotTo(HaveOccurred())
+			return string(body)
+		}
+
+		Context("Provide a valid Flavor update request", func() {
+			It("Should return 200 response code and record a new revision", func() {
+				router.Handle("/flavors/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Update))).Methods("PUT")
+				req, err := http.NewRequest("PUT", "/flavors/c36b5412-8c02-4e08-8a74-8bfa40425cf3",
+					strings.NewReader(updateRequest("PLATFORM", "Updated BIOS source")))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var flavorRevision hvs.FlavorRevision
+				err = json.Unmarshal(w.Body.Bytes(), &flavorRevision)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(flavorRevision.Revision).To(Equal(2))
+				Expect(flavorRevision.Comment).To(Equal("Updated BIOS source"))
+				Expect(flavorRevision.SignedFlavor.Signature).NotTo(BeEmpty())
+
+				signedFlavor, err := flavorStore.Retrieve(uuid.MustParse("c36b5412-8c02-4e08-8a74-8bfa40425cf3"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(signedFlavor.Flavor.Meta.Description["source"]).To(Equal("Purley22"))
+			})
+		})
+
+		Context("Provide a Flavor update request that changes the flavor part", func() {
+			It("Should return 400 response code", func() {
+				router.Handle("/flavors/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Update))).Methods("PUT")
+				req, err := http.NewRequest("PUT", "/flavors/c36b5412-8c02-4e08-8a74-8bfa40425cf3",
+					strings.NewReader(updateRequest("OS", "")))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Provide a Flavor update request for a non-existent Flavor", func() {
+			It("Should return 404 response code", func() {
+				router.Handle("/flavors/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Update))).Methods("PUT")
+				req, err := http.NewRequest("PUT", "/flavors/73755fda-c910-46be-821f-e8ddeab189e9",
+					strings.NewReader(updateRequest("PLATFORM", "")))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("Provide a Flavor update request without Content-Type header", func() {
+			It("Should return 415 response code", func() {
+				router.Handle("/flavors/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Update))).Methods("PUT")
+				req, err := http.NewRequest("PUT", "/flavors/c36b5412-8c02-4e08-8a74-8bfa40425cf3",
+					strings.NewReader(updateRequest("PLATFORM", "")))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusUnsupportedMediaType))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/flavors/{flavorId}/revisions"
+	Describe("Search and Retrieve Flavor revisions", func() {
+		Context("Search revisions of an existing Flavor", func() {
+			It("Should return the initial revision", func() {
+				router.Handle("/flavors/{id}/revisions", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.SearchRevisions))).Methods("GET")
+				req, err := http.NewRequest("GET", "/flavors/c36b5412-8c02-4e08-8a74-8bfa40425cf3/revisions", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var flavorRevisions hvs.FlavorRevisionCollection
+				err = json.Unmarshal(w.Body.Bytes(), &flavorRevisions)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(flavorRevisions.FlavorRevisions)).To(Equal(1))
+				Expect(flavorRevisions.FlavorRevisions[0].Revision).To(Equal(1))
+			})
+		})
+
+		Context("Search revisions of a non-existent Flavor", func() {
+			It("Should return 404 response code", func() {
+				router.Handle("/flavors/{id}/revisions", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.SearchRevisions))).Methods("GET")
+				req, err := http.NewRequest("GET", "/flavors/73755fda-c910-46be-821f-e8ddeab189e9/revisions", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("Retrieve an existing revision of a Flavor", func() {
+			It("Should return 200 response code", func() {
+				router.Handle("/flavors/{id}/revisions/{revision}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.RetrieveRevision))).Methods("GET")
+				req, err := http.NewRequest("GET", "/flavors/c36b5412-8c02-4e08-8a74-8bfa40425cf3/revisions/1", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("Retrieve a non-existent revision of a Flavor", func() {
+			It("Should return 404 response code", func() {
+				router.Handle("/flavors/{id}/revisions/{revision}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.RetrieveRevision))).Methods("GET")
+				req, err := http.NewRequest("GET", "/flavors/c36b5412-8c02-4e08-8a74-8bfa40425cf3/revisions/5", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	// Specs for HTTP Post to "/flavors/{flavorId}/rollback"
+	Describe("Rollback Flavor", func() {
+		BeforeEach(func() {
+			var flavor hvs.Flavor
+			Expect(copyFlavor(flavorStore, &flavor)).To(Succeed())
+			flavor.Meta.Description["source"] = "Purley22"
+			_, err := flavorStore.Update(&hvs.FlavorRevision{
+				FlavorID:     flavor.Meta.ID,
+				SignedFlavor: hvs.SignedFlavor{Flavor: flavor, Signature: "c2lnbmF0dXJl"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("Rollback a Flavor to a prior revision", func() {
+			It("Should return 201 response code and restore the prior content", func() {
+				router.Handle("/flavors/{id}/rollback", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Rollback))).Methods("POST")
+				req, err := http.NewRequest("POST", "/flavors/c36b5412-8c02-4e08-8a74-8bfa40425cf3/rollback",
+					strings.NewReader(`{"revision": 1}`))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var flavorRevision hvs.FlavorRevision
+				err = json.Unmarshal(w.Body.Bytes(), &flavorRevision)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(flavorRevision.Revision).To(Equal(3))
+				Expect(flavorRevision.Comment).To(Equal("Rollback to revision 1"))
+
+				signedFlavor, err := flavorStore.Retrieve(uuid.MustParse("c36b5412-8c02-4e08-8a74-8bfa40425cf3"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(signedFlavor.Flavor.Meta.Description["source"]).To(Equal("Purley21"))
+			})
+		})
+
+		Context("Rollback a Flavor to its latest revision", func() {
+			It("Should return 400 response code", func() {
+				router.Handle("/flavors/{id}/rollback", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Rollback))).Methods("POST")
+				req, err := http.NewRequest("POST", "/flavors/c36b5412-8c02-4e08-8a74-8bfa40425cf3/rollback",
+					strings.NewReader(`{"revision": 2}`))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Rollback a Flavor to a non-existent revision", func() {
+			It("Should return 400 response code", func() {
+				router.Handle("/flavors/{id}/rollback", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Rollback))).Methods("POST")
+				req, err := http.NewRequest("POST", "/flavors/c36b5412-8c02-4e08-8a74-8bfa40425cf3/rollback",
+					strings.NewReader(`{"revision": 7}`))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
 })
+
+// copyFlavor returns a deep copy of the PLATFORM flavor in the mock flavor store, so that changes made by a spec
+// do not leak into the stored flavor and its revisions
+func copyFlavor(flavorStore *mocks.MockFlavorStore, flavor *hvs.Flavor) error {
+	signedFlavor, err := flavorStore.Retrieve(uuid.MustParse("c36b5412-8c02-4e08-8a74-8bfa40425cf3"))
+	if err != nil {
+		return err
+	}
+	flavorJson, err := json.Marshal(signedFlavor.Flavor)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(flavorJson, flavor)
+}
+
+// fakeFlavorVerifier returns a trust report with a single rule result, trusted or not
+type fakeFlavorVerifier struct {
+	trusted bool
+}
+
+func (v *fakeFlavorVerifier) Verify(hostManifest *hcTypes.HostManifest, signedFlavor *hvs.SignedFlavor, skipFlavorSignatureVerification bool) (*hvs.TrustReport, error) {
+	return &hvs.TrustReport{
+		Trusted:      v.trusted,
+		HostManifest: *hostManifest,
+		Results: []hvs.RuleResult{{
+			Rule:     hvs.RuleInfo{Name: "PcrMatchesConstant"},
+			FlavorId: &signedFlavor.Flavor.Meta.ID,
+			Trusted:  v.trusted,
+		}},
+	}, nil
+}
+
+func (v *fakeFlavorVerifier) GetVerifierCerts() verifier.VerifierCertificates {
+	return verifier.VerifierCertificates{}
+}
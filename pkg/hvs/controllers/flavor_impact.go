@@ -0,0 +1,124 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers
+
+import (
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+)
+
+// flavorImpactAnalyzer finds the hosts whose trust status can change when a flavor is linked to or unlinked from a
+// flavorgroup, so that only those hosts are re-verified instead of all the hosts of the flavorgroup. When the impact
+// on a host cannot be determined, the host is considered impacted.
+type flavorImpactAnalyzer struct {
+	HStore  domain.HostStore
+	HSStore domain.HostStatusStore
+}
+
+// impactHostBatchSize is the number of hosts whose latest host manifest is retrieved with a single query
+const impactHostBatchSize = 500
+
+// linkImpact returns the hosts whose latest host manifest matches one of the flavors linked to their flavorgroup.
+// The verifier does not select the flavors for the other hosts.
+func (fia flavorImpactAnalyzer) linkImpact(flavors []hvs.Flavor, hostIds []uuid.UUID) []uuid.UUID {
+	defaultLog.Trace("controllers/flavor_impact:linkImpact() Entering")
+	defer defaultLog.Trace("controllers/flavor_impact:linkImpact() Leaving")
+
+	return fia.flavorsMatchHosts(flavors, uniqueUUIDs(hostIds))
+}
+
+// unlinkImpact returns the hosts whose trust cache for the flavorgroup contains the flavor unlinked from it. The
+// hosts whose manifest matches the flavor are returned as well, since they can be untrusted because of the flavor.
+// The trust cache is joined with the flavorgroup links, so it must be called before the flavor is unlinked.
+func (fia flavorImpactAnalyzer) unlinkImpact(flavor *hvs.Flavor, fgId uuid.UUID, hostIds []uuid.UUID) []uuid.UUID {
+	defaultLog.Trace("controllers/flavor_impact:unlinkImpact() Entering")
+	defer defaultLog.Trace("controllers/flavor_impact:unlinkImpact() Leaving")
+
+	hostIds = uniqueUUIDs(hostIds)
+	cachedHosts, err := fia.HStore.SearchTrustCacheHosts(flavor.Meta.ID, fgId)
+	if err != nil {
+		defaultLog.WithError(err).WithField("flavorgroup", fgId).Warn("controllers/flavor_impact:unlinkImpact() " +
+			"Failed to retrieve trust cache of hosts, hosts will be re-verified")
+		return hostIds
+	}
+
+	cached := make(map[uuid.UUID]bool, len(cachedHosts))
+	for _, hostId := range cachedHosts {
+		cached[hostId] = true
+	}
+	var impactedHosts, uncachedHosts []uuid.UUID
+	for _, hostId := range hostIds {
+		if cached[hostId] {
+			impactedHosts = append(impactedHosts, hostId)
+		} else {
+			uncachedHosts = append(uncachedHosts, hostId)
+		}
+	}
+	return append(impactedHosts, fia.flavorsMatchHosts([]hvs.Flavor{*flavor}, uncachedHosts)...)
+}
+
+// deleteImpact returns the hosts impacted by the removal of the flavor from all its flavorgroups, given the hosts
+// of each flavorgroup
+func (fia flavorImpactAnalyzer) deleteImpact(flavor *hvs.Flavor, fgHostIds map[uuid.UUID][]uuid.UUID) []uuid.UUID {
+	defaultLog.Trace("controllers/flavor_impact:deleteImpact() Entering")
+	defer defaultLog.Trace("controllers/flavor_impact:deleteImpact() Leaving")
+
+	var impactedHosts []uuid.UUID
+	for fgId, hostIds := range fgHostIds {
+		impactedHosts = append(impactedHosts, fia.unlinkImpact(flavor, fgId, hostIds)...)
+	}
+	return uniqueUUIDs(impactedHosts)
+}
+
+// flavorsMatchHosts returns the hosts whose latest host manifest matches one of the flavors. The host manifests are
+// retrieved in batches of impactHostBatchSize hosts. A host without a manifest has not been verified yet and is always
+// considered a match.
+func (fia flavorImpactAnalyzer) flavorsMatchHosts(flavors []hvs.Flavor, hostIds []uuid.UUID) []uuid.UUID {
+	var matchingHosts []uuid.UUID
+	for start := 0; start < len(hostIds); start += impactHostBatchSize {
+		end := start + impactHostBatchSize
+		if end > len(hostIds) {
+			end = len(hostIds)
+		}
+		batch := hostIds[start:end]
+
+		hostStatuses, err := fia.HSStore.Search(&models.HostStatusFilterCriteria{
+			HostIds:       batch,
+			LatestPerHost: true,
+			Limit:         len(batch),
+		})
+		if err != nil {
+			defaultLog.WithError(err).Warn("controllers/flavor_impact:flavorsMatchHosts() " +
+				"Failed to retrieve host manifests, hosts will be re-verified")
+			matchingHosts = append(matchingHosts, batch...)
+			continue
+		}
+		hostManifests := make(map[uuid.UUID]*types.HostManifest, len(hostStatuses))
+		for i := range hostStatuses {
+			hostManifests[hostStatuses[i].HostID] = &hostStatuses[i].HostManifest
+		}
+
+		for _, hostId := range batch {
+			if hostManifest, ok := hostManifests[hostId]; !ok || hostManifest.HostInfo.HardwareUUID == "" ||
+				flavorsMatchHostManifest(flavors, hostManifest) {
+				matchingHosts = append(matchingHosts, hostId)
+			}
+		}
+	}
+	return matchingHosts
+}
+
+func flavorsMatchHostManifest(flavors []hvs.Flavor, hostManifest *types.HostManifest) bool {
+	for i := range flavors {
+		if utils.FlavorMatchesHostManifest(&flavors[i], hostManifest) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,384 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
+	dm "github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor"
+	fc "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	fm "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+)
+
+// defaultSimulationFlavorParts are the flavor parts generated from a reference manifest when none are requested
+var defaultSimulationFlavorParts = []fc.FlavorPart{fc.FlavorPartPlatform, fc.FlavorPartOs}
+
+// Simulate verifies candidate flavors against the latest manifests of the selected hosts. The flavors are not
+// signed nor stored, and no report or audit entry is created.
+func (fcon *FlavorController) Simulate(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:Simulate() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:Simulate() Leaving")
+
+	if r.Header.Get("Content-Type") != constants.HTTPMediaTypeJson {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/flavor_controller:Simulate() The request body is not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	var simulationReq hvs.FlavorSimulationRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&simulationReq); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:Simulate() %s :  Failed to decode request body as FlavorSimulationRequest", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	if simulationReq.FlavorgroupID == uuid.Nil && strings.TrimSpace(simulationReq.HostLabelSelector) == "" {
+		secLog.Errorf("controllers/flavor_controller:Simulate() %s : Hosts not selected", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavorgroup ID or host label selector must be provided"}
+	}
+
+	if fcon.FlavorVerifier == nil {
+		defaultLog.Error("controllers/flavor_controller:Simulate() Flavor verifier is not initialized")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Flavor verifier is not available"}
+	}
+
+	flavors, status, err := fcon.getSimulationFlavors(&simulationReq)
+	if err != nil {
+		return nil, status, err
+	}
+
+	matchPolicies, status, err := fcon.getSimulationMatchPolicies(&simulationReq)
+	if err != nil {
+		return nil, status, err
+	}
+
+	hosts, status, err := fcon.getSimulationHosts(&simulationReq)
+	if err != nil {
+		return nil, status, err
+	}
+
+	hostManifests, err := fcon.getSimulationHostManifests(hosts)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_controller:Simulate() Failed to retrieve host manifests")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve host manifests"}
+	}
+
+	simulation := hvs.FlavorSimulation{
+		Flavors:   flavors,
+		HostCount: len(hosts),
+		Hosts:     []hvs.HostFlavorSimulation{},
+	}
+	for _, host := range hosts {
+		hostSimulation := fcon.simulateHost(host, hostManifests[host.Id], flavors, matchPolicies)
+		switch {
+		case hostSimulation.Error != "":
+			simulation.SkippedHostCount++
+		case hostSimulation.Trusted:
+			simulation.TrustedHostCount++
+		default:
+			simulation.UntrustedHostCount++
+		}
+		simulation.Hosts = append(simulation.Hosts, hostSimulation)
+	}
+
+	secLog.Infof("%s: Return flavor simulation to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return simulation, http.StatusOK, nil
+}
+
+// getSimulationFlavors returns the candidate flavors of the simulation, either given as content or generated from the
+// reference host manifest
+func (fcon *FlavorController) getSimulationFlavors(simulationReq *hvs.FlavorSimulationRequest) ([]hvs.Flavor, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:getSimulationFlavors() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:getSimulationFlavors() Leaving")
+
+	hasFlavors := simulationReq.FlavorCollection != nil && len(simulationReq.FlavorCollection.Flavors) > 0
+	if hasFlavors == (simulationReq.HostManifest != nil) {
+		secLog.Errorf("controllers/flavor_controller:getSimulationFlavors() %s : Flavor content and host manifest both provided or missing", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Either flavor content or a reference host manifest must be provided"}
+	}
+	if hasFlavors && (len(simulationReq.FlavorTemplateIDs) > 0 || len(simulationReq.FlavorParts) > 0) {
+		secLog.Errorf("controllers/flavor_controller:getSimulationFlavors() %s : Flavor templates provided with flavor content", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor templates and flavor parts can only be provided with a reference host manifest"}
+	}
+
+	var flavors []hvs.Flavor
+	if hasFlavors {
+		for _, f := range simulationReq.FlavorCollection.Flavors {
+			if err := validateFlavorMetaContent(&f.Flavor.Meta); err != nil {
+				secLog.WithError(err).Errorf("controllers/flavor_controller:getSimulationFlavors() %s : Invalid flavor content", commLogMsg.InvalidInputBadParam)
+				return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+			}
+			// the flavor id identifies the flavor in the rule results
+			if f.Flavor.Meta.ID == uuid.Nil {
+				f.Flavor.Meta.ID = uuid.New()
+			}
+			flavors = append(flavors, f.Flavor)
+		}
+		return flavors, http.StatusOK, nil
+	}
+
+	flavorParts := fc.FilterUniqueFlavorParts(simulationReq.FlavorParts)
+	if len(flavorParts) == 0 {
+		flavorParts = defaultSimulationFlavorParts
+	}
+	for _, flavorPart := range flavorParts {
+		if flavorPart == fc.FlavorPartAssetTag || flavorPart == fc.FlavorPartSoftware {
+			secLog.Errorf("controllers/flavor_controller:getSimulationFlavors() %s : Flavor part %s cannot be generated", commLogMsg.InvalidInputBadParam, flavorPart)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Only PLATFORM, OS and HOST_UNIQUE flavors can be generated from a host manifest"}
+		}
+	}
+
+	var flavorTemplates []hvs.FlavorTemplate
+	if len(simulationReq.FlavorTemplateIDs) > 0 {
+		for _, templateID := range simulationReq.FlavorTemplateIDs {
+			flavorTemplate, err := fcon.FTStore.Retrieve(templateID, false)
+			if err != nil {
+				if _, ok := err.(*commErr.StatusNotFoundError); ok {
+					secLog.WithError(err).WithField("id", templateID).Info("controllers/flavor_controller:getSimulationFlavors() Flavor template with given ID does not exist")
+					return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor template with given ID does not exist"}
+				}
+				defaultLog.WithError(err).WithField("id", templateID).Error("controllers/flavor_controller:getSimulationFlavors() Failed to retrieve flavor template")
+				return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve flavor template"}
+			}
+			flavorTemplates = append(flavorTemplates, *flavorTemplate)
+		}
+	} else {
+		var err error
+		flavorTemplates, err = fcon.findTemplatesToApply(simulationReq.HostManifest)
+		if len(flavorTemplates) == 0 {
+			defaultLog.WithError(err).Info("controllers/flavor_controller:getSimulationFlavors() No templates found to apply")
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "No flavor templates match the host manifest"}
+		}
+	}
+
+	platformFlavorProvider, err := flavor.NewPlatformFlavorProvider(simulationReq.HostManifest, nil, flavorTemplates)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:getSimulationFlavors() %s : Error while creating platform flavor instance from host manifest", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to create flavors from host manifest"}
+	}
+	platformFlavor, err := platformFlavorProvider.GetPlatformFlavor()
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:getSimulationFlavors() %s : Error while creating platform flavors from host manifest", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to create flavors from host manifest"}
+	}
+	for _, flavorPart := range flavorParts {
+		unsignedFlavors, err := (*platformFlavor).GetFlavorPartRaw(flavorPart)
+		if err != nil {
+			secLog.WithError(err).Errorf("controllers/flavor_controller:getSimulationFlavors() %s : Error building a flavor for flavor part %s", commLogMsg.InvalidInputBadParam, flavorPart)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to create " + flavorPart.String() + " flavor from host manifest"}
+		}
+		flavors = append(flavors, unsignedFlavors...)
+	}
+	if len(flavors) == 0 {
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "No flavors created from host manifest"}
+	}
+	return flavors, http.StatusOK, nil
+}
+
+// getSimulationMatchPolicies returns the flavor match policies the candidate flavors are evaluated with, those of the
+// flavorgroup of the simulation request, or the policies of the automatic flavorgroup when no flavorgroup is selected
+func (fcon *FlavorController) getSimulationMatchPolicies(simulationReq *hvs.FlavorSimulationRequest) (hvs.FlavorMatchPolicies, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:getSimulationMatchPolicies() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:getSimulationMatchPolicies() Leaving")
+
+	if simulationReq.FlavorgroupID == uuid.Nil {
+		return utils.GetAutomaticFlavorMatchPolicy(), http.StatusOK, nil
+	}
+	flavorgroup, err := fcon.FGStore.Retrieve(simulationReq.FlavorgroupID)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			secLog.WithError(err).WithField("id", simulationReq.FlavorgroupID).Info("controllers/flavor_controller:getSimulationMatchPolicies() Flavorgroup with given ID does not exist")
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavorgroup with given ID does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", simulationReq.FlavorgroupID).Error("controllers/flavor_controller:getSimulationMatchPolicies() Failed to retrieve Flavorgroup")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Flavorgroup"}
+	}
+	return flavorgroup.MatchPolicies, http.StatusOK, nil
+}
+
+// getSimulationHosts returns the hosts linked to the flavorgroup and matching the label selector of the simulation
+// request. No more than constants.MaxFlavorSimulationHosts hosts can be selected.
+func (fcon *FlavorController) getSimulationHosts(simulationReq *hvs.FlavorSimulationRequest) ([]*hvs.Host, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:getSimulationHosts() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:getSimulationHosts() Leaving")
+
+	// one more host than the maximum is searched to detect the selections exceeding it
+	filterCriteria := dm.HostFilterCriteria{Limit: consts.MaxFlavorSimulationHosts + 1}
+	if simulationReq.FlavorgroupID != uuid.Nil {
+		fgHostIds, err := fcon.FGStore.SearchHostsByFlavorGroup(simulationReq.FlavorgroupID)
+		if err != nil {
+			defaultLog.WithError(err).WithField("id", simulationReq.FlavorgroupID).Error("controllers/flavor_controller:getSimulationHosts() Failed to retrieve hosts linked to Flavorgroup")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve hosts linked to Flavorgroup"}
+		}
+		if len(fgHostIds) == 0 {
+			return nil, http.StatusOK, nil
+		}
+		filterCriteria.IdList = uniqueUUIDs(fgHostIds)
+	}
+
+	if strings.TrimSpace(simulationReq.HostLabelSelector) != "" {
+		labelSelector, err := dm.ParseLabelSelector(simulationReq.HostLabelSelector)
+		if err != nil {
+			secLog.WithError(err).Errorf("controllers/flavor_controller:getSimulationHosts() %s : Invalid host label selector", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid host label selector"}
+		}
+		filterCriteria.LabelSelector = labelSelector
+	}
+
+	hosts, err := fcon.HStore.Search(&filterCriteria, nil)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_controller:getSimulationHosts() Failed to search hosts")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to search hosts"}
+	}
+	if len(hosts) > consts.MaxFlavorSimulationHosts {
+		secLog.Errorf("controllers/flavor_controller:getSimulationHosts() %s : More than %d hosts selected", commLogMsg.InvalidInputBadParam, consts.MaxFlavorSimulationHosts)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: fmt.Sprintf("No more than %d hosts can be selected", consts.MaxFlavorSimulationHosts)}
+	}
+	return hosts, http.StatusOK, nil
+}
+
+// getSimulationHostManifests returns the latest host manifest of each host, the hosts that have not been verified yet
+// have no manifest
+func (fcon *FlavorController) getSimulationHostManifests(hosts []*hvs.Host) (map[uuid.UUID]*types.HostManifest, error) {
+	defaultLog.Trace("controllers/flavor_controller:getSimulationHostManifests() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:getSimulationHostManifests() Leaving")
+
+	hostManifests := make(map[uuid.UUID]*types.HostManifest, len(hosts))
+	if len(hosts) == 0 {
+		return hostManifests, nil
+	}
+	hostIds := make([]uuid.UUID, len(hosts))
+	for i, host := range hosts {
+		hostIds[i] = host.Id
+	}
+	hostStatuses, err := fcon.HSStore.Search(&dm.HostStatusFilterCriteria{
+		HostIds:       hostIds,
+		LatestPerHost: true,
+		Limit:         len(hostIds),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range hostStatuses {
+		if hostStatuses[i].HostManifest.HostInfo.HardwareUUID != "" {
+			hostManifests[hostStatuses[i].HostID] = &hostStatuses[i].HostManifest
+		}
+	}
+	return hostManifests, nil
+}
+
+// simulateHost verifies the candidate flavors against the latest manifest of the host, and evaluates the results with
+// the flavor match policies
+func (fcon *FlavorController) simulateHost(host *hvs.Host, hostManifest *types.HostManifest, flavors []hvs.Flavor,
+	matchPolicies hvs.FlavorMatchPolicies) hvs.HostFlavorSimulation {
+	defaultLog.Trace("controllers/flavor_controller:simulateHost() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:simulateHost() Leaving")
+
+	hostSimulation := hvs.HostFlavorSimulation{
+		HostID:   host.Id,
+		HostName: host.HostName,
+	}
+	if hostManifest == nil {
+		hostSimulation.Error = "Host manifest is not available"
+		return hostSimulation
+	}
+
+	for i := range flavors {
+		// the flavors are not signed, so the verification of their signature is skipped
+		trustReport, err := fcon.FlavorVerifier.Verify(hostManifest, &hvs.SignedFlavor{Flavor: flavors[i]}, true)
+		if err != nil {
+			defaultLog.WithError(err).WithField("host", host.Id).Warn("controllers/flavor_controller:simulateHost() Failed to verify host manifest")
+			return hvs.HostFlavorSimulation{
+				HostID:   host.Id,
+				HostName: host.HostName,
+				Error:    "Failed to verify host manifest against flavor " + flavors[i].Meta.ID.String(),
+			}
+		}
+
+		hostSimulation.FlavorResults = append(hostSimulation.FlavorResults, hvs.FlavorSimulationResult{
+			FlavorID:    flavors[i].Meta.ID,
+			FlavorPart:  flavorDescriptionString(&flavors[i], fm.FlavorPart),
+			Label:       flavorDescriptionString(&flavors[i], fm.Label),
+			Matched:     utils.FlavorMatchesHostManifest(&flavors[i], hostManifest),
+			Trusted:     trustReport.Trusted,
+			RuleResults: trustReport.Results,
+		})
+	}
+	hostSimulation.Trusted, hostSimulation.MissingFlavorParts = evaluateSimulationPolicies(hostSimulation.FlavorResults, matchPolicies)
+	return hostSimulation
+}
+
+// evaluateSimulationPolicies applies the flavor match policies to the results of the candidate flavors, as the host
+// trust verifier applies them to the flavors of a flavorgroup. The candidate flavors replace the flavors of the flavor
+// parts they define, the other flavor parts are not evaluated. A defined flavor part which is required, or required if
+// defined, must be matched by one of its flavors, and the flavor parts without policy are ignored. It returns the trust
+// of the host and the required flavor parts that no flavor matches.
+func evaluateSimulationPolicies(flavorResults []hvs.FlavorSimulationResult, matchPolicies hvs.FlavorMatchPolicies) (bool, []string) {
+	definedParts := make(map[string]bool)
+	matchedResults := make(map[string][]hvs.FlavorSimulationResult)
+	for _, flavorResult := range flavorResults {
+		definedParts[flavorResult.FlavorPart] = true
+		if flavorResult.Matched {
+			matchedResults[flavorResult.FlavorPart] = append(matchedResults[flavorResult.FlavorPart], flavorResult)
+		}
+	}
+
+	trusted := true
+	evaluated := false
+	var missingParts []string
+	for _, policy := range matchPolicies {
+		flavorPart := policy.FlavorPart.String()
+		if !definedParts[flavorPart] {
+			continue
+		}
+		results := matchedResults[flavorPart]
+		if len(results) == 0 {
+			if policy.MatchPolicy.Required == hvs.FlavorRequired || policy.MatchPolicy.Required == hvs.FlavorRequiredIfDefined {
+				trusted = false
+				missingParts = append(missingParts, flavorPart)
+			}
+			continue
+		}
+		evaluated = true
+
+		switch policy.MatchPolicy.MatchType {
+		case hvs.MatchTypeAllOf:
+			for _, result := range results {
+				trusted = trusted && result.Trusted
+			}
+		case hvs.MatchTypeLatest:
+			// the candidate flavors have not been created, the last one in the request is the latest
+			trusted = trusted && results[len(results)-1].Trusted
+		default:
+			anyTrusted := false
+			for _, result := range results {
+				anyTrusted = anyTrusted || result.Trusted
+			}
+			trusted = trusted && anyTrusted
+		}
+	}
+	return trusted && evaluated, missingParts
+}
+
+func flavorDescriptionString(f *hvs.Flavor, key string) string {
+	value, _ := f.Meta.Description[key].(string)
+	return value
+}
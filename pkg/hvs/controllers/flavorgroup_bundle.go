@@ -0,0 +1,479 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
+	fm "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// flavorgroupImportPlan holds the items of a flavorgroup bundle that do not exist yet and are created on import
+type flavorgroupImportPlan struct {
+	// flavorgroup is the existing flavorgroup the flavors are linked to, nil when the flavorgroup is created
+	flavorgroup     *hvs.FlavorGroup
+	flavorTemplates []hvs.FlavorTemplate
+	flavors         []hvs.Flavor
+	// flavorIds are the ids of all the flavors of the bundle once imported, including the duplicates
+	flavorIds []uuid.UUID
+}
+
+// Export returns the flavorgroup with its flavors, the flavor templates they are generated from and the flavor
+// signing certificate chain, in a bundle signed with the flavor signing key
+func (controller FlavorgroupController) Export(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavorgroup_bundle:Export() Entering")
+	defer defaultLog.Trace("controllers/flavorgroup_bundle:Export() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+
+	flavorGroup, err := controller.FlavorGroupStore.Retrieve(id)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			secLog.WithError(err).WithField("id", id).Error(
+				"controllers/flavorgroup_bundle:Export() FlavorGroup with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "FlavorGroup with given ID does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/flavorgroup_bundle:Export() Failed to retrieve FlavorGroup")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve FlavorGroup"}
+	}
+
+	signingKey, signingCerts, err := controller.getFlavorSigningKeyAndCertificates()
+	if err != nil {
+		defaultLog.WithError(err).Errorf("controllers/flavorgroup_bundle:Export() %s", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to export FlavorGroup"}
+	}
+
+	signedFlavors, err := controller.getFlavorgroupFlavors(id)
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", id).Errorf("controllers/flavorgroup_bundle:Export() %s", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to export FlavorGroup"}
+	}
+
+	flavorTemplates, err := controller.getFlavorTemplates(signedFlavors)
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", id).Errorf("controllers/flavorgroup_bundle:Export() %s", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to export FlavorGroup"}
+	}
+
+	// the stored flavors are exported with their signature, that must be made with the current flavor signing key
+	signingPublicKey, ok := signingCerts[0].PublicKey.(*rsa.PublicKey)
+	if !ok {
+		defaultLog.Errorf("controllers/flavorgroup_bundle:Export() %s : Flavor signing certificate does not have a RSA public key", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to export FlavorGroup"}
+	}
+	for i := range signedFlavors {
+		if err := signedFlavors[i].Verify(signingPublicKey); err != nil {
+			defaultLog.WithError(err).WithField("id", id).WithField("flavor", signedFlavors[i].Flavor.Meta.ID).Errorf(
+				"controllers/flavorgroup_bundle:Export() %s : Failed to verify the signature of the flavor", commLogMsg.AppRuntimeErr)
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to export FlavorGroup, a flavor signature is not valid"}
+		}
+	}
+
+	bundle := hvs.NewFlavorgroupBundle(*flavorGroup, signedFlavors, flavorTemplates, signingCerts)
+	signedBundle, err := hvs.NewSignedFlavorgroupBundle(bundle, signingKey)
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", id).Errorf("controllers/flavorgroup_bundle:Export() %s : Failed to sign FlavorGroup bundle", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to export FlavorGroup"}
+	}
+
+	secLog.WithField("id", id).Infof("%s: FlavorGroup exported by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return signedBundle, http.StatusOK, nil
+}
+
+// Import verifies a flavorgroup bundle against the flavor CA certificates, and creates the flavorgroup, flavors and
+// flavor templates of the bundle that do not exist yet. The items that already exist with the same content are
+// reused, the flavors being compared by digest. Nothing is imported when an item conflicts with an existing one.
+func (controller FlavorgroupController) Import(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavorgroup_bundle:Import() Entering")
+	defer defaultLog.Trace("controllers/flavorgroup_bundle:Import() Leaving")
+
+	if r.Header.Get("Content-Type") != consts.HTTPMediaTypeJson {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/flavorgroup_bundle:Import() The request body is not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	var signedBundle hvs.SignedFlavorgroupBundle
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	err := dec.Decode(&signedBundle)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/flavorgroup_bundle:Import() %s :  Failed to decode request body as SignedFlavorgroupBundle", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	bundle := &signedBundle.Bundle
+	if err := ValidateFlavorGroup(bundle.Flavorgroup); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavorgroup_bundle:Import() %s", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid flavorgroup data in bundle"}
+	}
+	for _, signedFlavor := range bundle.SignedFlavors {
+		if signedFlavor.Flavor.Meta.ID == uuid.Nil || flavorDescriptionString(&signedFlavor.Flavor, "label") == "" ||
+			flavorDescriptionString(&signedFlavor.Flavor, "flavor_part") == "" {
+			secLog.Errorf("controllers/flavorgroup_bundle:Import() %s : Flavor without id, label or flavor part in bundle", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid flavor data in bundle"}
+		}
+	}
+
+	signingKey, _, err := controller.getFlavorSigningKeyAndCertificates()
+	if err != nil {
+		defaultLog.WithError(err).Errorf("controllers/flavorgroup_bundle:Import() %s", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to import FlavorGroup"}
+	}
+	verifierCerts, err := utils.GetVerifierCertificates(controller.CertStore)
+	if err != nil {
+		defaultLog.WithError(err).Errorf("controllers/flavorgroup_bundle:Import() %s", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to import FlavorGroup"}
+	}
+
+	if err = signedBundle.Verify(verifierCerts.FlavorCACertificates); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavorgroup_bundle:Import() %s : Failed to verify FlavorGroup bundle", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Failed to verify FlavorGroup bundle"}
+	}
+
+	importReport, plan, err := controller.planImport(bundle)
+	if err != nil {
+		defaultLog.WithError(err).Errorf("controllers/flavorgroup_bundle:Import() %s", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to import FlavorGroup"}
+	}
+	if importReport.ConflictCount > 0 {
+		secLog.WithField("name", bundle.Flavorgroup.Name).Warningf("%s: FlavorGroup bundle conflicts with %d existing items, import requested from addr: %s",
+			commLogMsg.InvalidInputBadParam, importReport.ConflictCount, r.RemoteAddr)
+		return importReport, http.StatusConflict, nil
+	}
+
+	importReport.FlavorgroupID, err = controller.applyImport(bundle, plan, signingKey)
+	if err != nil {
+		defaultLog.WithError(err).Errorf("controllers/flavorgroup_bundle:Import() %s", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to import FlavorGroup"}
+	}
+	importReport.Imported = true
+
+	secLog.WithField("name", bundle.Flavorgroup.Name).Infof("%s: FlavorGroup imported by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	return importReport, http.StatusCreated, nil
+}
+
+// planImport compares the items of the bundle with the existing ones, without creating anything
+func (controller FlavorgroupController) planImport(bundle *hvs.FlavorgroupBundle) (*hvs.FlavorgroupImport, *flavorgroupImportPlan, error) {
+	defaultLog.Trace("controllers/flavorgroup_bundle:planImport() Entering")
+	defer defaultLog.Trace("controllers/flavorgroup_bundle:planImport() Leaving")
+
+	importReport := &hvs.FlavorgroupImport{Items: []hvs.FlavorgroupImportItem{}}
+	plan := &flavorgroupImportPlan{}
+
+	fgItem := hvs.FlavorgroupImportItem{
+		Type:   hvs.FlavorgroupImportItemFlavorgroup,
+		ID:     bundle.Flavorgroup.ID,
+		Name:   bundle.Flavorgroup.Name,
+		Status: hvs.FlavorgroupImportCreated,
+	}
+	existingFlavorGroups, err := controller.FlavorGroupStore.Search(&models.FlavorGroupFilterCriteria{
+		NameEqualTo: bundle.Flavorgroup.Name,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "controllers/flavorgroup_bundle:planImport() Error searching FlavorGroup")
+	}
+	if len(existingFlavorGroups) > 0 {
+		plan.flavorgroup = &existingFlavorGroups[0]
+		fgItem.ExistingID = plan.flavorgroup.ID
+		existingPolicies, _, _ := plan.flavorgroup.GetMatchPolicyMaps()
+		bundlePolicies, _, _ := bundle.Flavorgroup.GetMatchPolicyMaps()
+		sameCustomRules := (len(plan.flavorgroup.CustomRules) == 0 && len(bundle.Flavorgroup.CustomRules) == 0) ||
+			reflect.DeepEqual(plan.flavorgroup.CustomRules, bundle.Flavorgroup.CustomRules)
+		if !reflect.DeepEqual(existingPolicies, bundlePolicies) {
+			fgItem.Status = hvs.FlavorgroupImportConflict
+			fgItem.Message = "FlavorGroup with same name already exists with different flavor match policies"
+		} else if !sameCustomRules {
+			fgItem.Status = hvs.FlavorgroupImportConflict
+			fgItem.Message = "FlavorGroup with same name already exists with different custom rules"
+		} else {
+			fgItem.Status = hvs.FlavorgroupImportDuplicate
+		}
+	}
+	importReport.AddItem(fgItem)
+
+	for _, flavorTemplate := range bundle.FlavorTemplates {
+		ftItem := hvs.FlavorgroupImportItem{
+			Type:   hvs.FlavorgroupImportItemFlavorTemplate,
+			ID:     flavorTemplate.ID,
+			Name:   flavorTemplate.Label,
+			Status: hvs.FlavorgroupImportCreated,
+		}
+		existingTemplate, err := controller.FlavorTemplateStore.Retrieve(flavorTemplate.ID, true)
+		if err != nil {
+			if _, ok := err.(*commErr.StatusNotFoundError); !ok {
+				return nil, nil, errors.Wrapf(err, "controllers/flavorgroup_bundle:planImport() Error retrieving flavor template %s", flavorTemplate.ID)
+			}
+			plan.flavorTemplates = append(plan.flavorTemplates, flavorTemplate)
+		} else {
+			ftItem.ExistingID = existingTemplate.ID
+			if equal, err := jsonEqual(existingTemplate, &flavorTemplate); err != nil {
+				return nil, nil, errors.Wrap(err, "controllers/flavorgroup_bundle:planImport() Error comparing flavor templates")
+			} else if equal {
+				ftItem.Status = hvs.FlavorgroupImportDuplicate
+			} else {
+				ftItem.Status = hvs.FlavorgroupImportConflict
+				ftItem.Message = "Flavor template with same ID already exists with different content"
+			}
+		}
+		importReport.AddItem(ftItem)
+	}
+
+	for _, signedFlavor := range bundle.SignedFlavors {
+		flavor := signedFlavor.Flavor
+		fItem := hvs.FlavorgroupImportItem{
+			Type:   hvs.FlavorgroupImportItemFlavor,
+			ID:     flavor.Meta.ID,
+			Name:   flavorDescriptionString(&flavor, "label"),
+			Status: hvs.FlavorgroupImportCreated,
+		}
+		existingFlavor, err := controller.findExistingFlavor(&flavor)
+		if err != nil {
+			return nil, nil, err
+		}
+		if existingFlavor != nil {
+			fItem.ExistingID = existingFlavor.Meta.ID
+			fItem.Status = hvs.FlavorgroupImportDuplicate
+			plan.flavorIds = append(plan.flavorIds, existingFlavor.Meta.ID)
+		} else {
+			conflictingFlavor, err := controller.findConflictingFlavor(&flavor)
+			if err != nil {
+				return nil, nil, err
+			}
+			if conflictingFlavor != nil {
+				fItem.ExistingID = conflictingFlavor.Meta.ID
+				fItem.Status = hvs.FlavorgroupImportConflict
+				fItem.Message = "Flavor with same ID or label already exists with different content"
+			} else {
+				plan.flavors = append(plan.flavors, flavor)
+				plan.flavorIds = append(plan.flavorIds, flavor.Meta.ID)
+			}
+		}
+		importReport.AddItem(fItem)
+	}
+	return importReport, plan, nil
+}
+
+// applyImport creates the items of the import plan, and links the flavors of the bundle to the flavorgroup, all at
+// once so that nothing is left behind when the import fails. The flavors are signed with the flavor signing key of
+// this HVS, so that they are trusted by its verifier.
+func (controller FlavorgroupController) applyImport(bundle *hvs.FlavorgroupBundle, plan *flavorgroupImportPlan, signingKey *rsa.PrivateKey) (uuid.UUID, error) {
+	defaultLog.Trace("controllers/flavorgroup_bundle:applyImport() Entering")
+	defer defaultLog.Trace("controllers/flavorgroup_bundle:applyImport() Leaving")
+
+	bundleImport := models.FlavorgroupBundleImport{
+		FlavorTemplates: plan.flavorTemplates,
+		FlavorIds:       uniqueUUIDs(plan.flavorIds),
+	}
+	for i := range plan.flavors {
+		signedFlavor, err := fm.NewSignedFlavor(&plan.flavors[i], signingKey)
+		if err != nil {
+			return uuid.Nil, errors.Wrapf(err, "controllers/flavorgroup_bundle:applyImport() Error signing flavor %s", plan.flavors[i].Meta.ID)
+		}
+		bundleImport.SignedFlavors = append(bundleImport.SignedFlavors, *signedFlavor)
+	}
+	if plan.flavorgroup != nil {
+		bundleImport.FlavorGroup = *plan.flavorgroup
+	} else {
+		bundleImport.FlavorGroup = bundle.Flavorgroup
+		bundleImport.FlavorGroup.ID = uuid.Nil
+	}
+
+	flavorGroup, newLinks, err := controller.FlavorgroupBundleStore.Import(&bundleImport)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "controllers/flavorgroup_bundle:applyImport() Error importing FlavorGroup bundle")
+	}
+
+	// re-verify the hosts of an existing flavorgroup impacted by the new flavors
+	if plan.flavorgroup != nil && len(newLinks) > 0 {
+		linkedHosts, err := controller.FlavorGroupStore.SearchHostsByFlavorGroup(flavorGroup.ID)
+		if err != nil {
+			return uuid.Nil, errors.Wrap(err, "controllers/flavorgroup_bundle:applyImport() Failed to fetch hosts linked to FlavorGroup")
+		}
+		var linkedFlavors []hvs.Flavor
+		for _, signedFlavor := range bundle.SignedFlavors {
+			linkedFlavors = append(linkedFlavors, signedFlavor.Flavor)
+		}
+		impactedHosts := controller.impactAnalyzer().linkImpact(linkedFlavors, linkedHosts)
+		if len(impactedHosts) > 0 {
+			if err = controller.HTManager.VerifyHostsAsync(impactedHosts, false, false); err != nil {
+				return uuid.Nil, errors.Wrap(err, "controllers/flavorgroup_bundle:applyImport() Addition of Host to Flavor Verify Queue failed")
+			}
+		}
+	}
+	return flavorGroup.ID, nil
+}
+
+// findExistingFlavor returns the flavor with the same digest as the flavor, regardless of its id. The label is part of
+// the digest and is unique, so the flavor with the same label is the only one that can have the same digest. It
+// returns nil when there is no such flavor.
+func (controller FlavorgroupController) findExistingFlavor(flavor *hvs.Flavor) (*hvs.Flavor, error) {
+	existingFlavors, err := controller.FlavorStore.Search(&models.FlavorVerificationFC{
+		FlavorFC: models.FlavorFilterCriteria{
+			Key:   "label",
+			Value: flavorDescriptionString(flavor, "label"),
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "controllers/flavorgroup_bundle:findExistingFlavor() Error searching flavor %s", flavor.Meta.ID)
+	}
+	for i := range existingFlavors {
+		equal, err := flavorDigestEqual(&existingFlavors[i].Flavor, flavor)
+		if err != nil {
+			return nil, errors.Wrap(err, "controllers/flavorgroup_bundle:findExistingFlavor() Error comparing flavors")
+		}
+		if equal {
+			return &existingFlavors[i].Flavor, nil
+		}
+	}
+	return nil, nil
+}
+
+// findConflictingFlavor returns the flavor with the same id or label as a flavor with a different digest, that can
+// not be created since the ids and labels are unique. It returns nil when there is no such flavor.
+func (controller FlavorgroupController) findConflictingFlavor(flavor *hvs.Flavor) (*hvs.Flavor, error) {
+	existingFlavor, err := controller.FlavorStore.Retrieve(flavor.Meta.ID)
+	if err == nil {
+		return &existingFlavor.Flavor, nil
+	}
+	if !strings.Contains(err.Error(), commErr.RowsNotFound) {
+		return nil, errors.Wrapf(err, "controllers/flavorgroup_bundle:findConflictingFlavor() Error retrieving flavor %s", flavor.Meta.ID)
+	}
+
+	existingFlavors, err := controller.FlavorStore.Search(&models.FlavorVerificationFC{
+		FlavorFC: models.FlavorFilterCriteria{
+			Key:   "label",
+			Value: flavorDescriptionString(flavor, "label"),
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "controllers/flavorgroup_bundle:findConflictingFlavor() Error searching flavor %s", flavor.Meta.ID)
+	}
+	if len(existingFlavors) == 0 {
+		return nil, nil
+	}
+	return &existingFlavors[0].Flavor, nil
+}
+
+// getFlavorgroupFlavors returns the flavors linked to the flavorgroup
+func (controller FlavorgroupController) getFlavorgroupFlavors(fgId uuid.UUID) ([]hvs.SignedFlavor, error) {
+	flavorIds, err := controller.FlavorGroupStore.SearchFlavors(fgId)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			return []hvs.SignedFlavor{}, nil
+		}
+		return nil, errors.Wrap(err, "controllers/flavorgroup_bundle:getFlavorgroupFlavors() Error searching flavors linked to FlavorGroup")
+	}
+	if len(flavorIds) == 0 {
+		return []hvs.SignedFlavor{}, nil
+	}
+
+	signedFlavors, err := controller.FlavorStore.Search(&models.FlavorVerificationFC{
+		FlavorFC: models.FlavorFilterCriteria{Ids: flavorIds},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "controllers/flavorgroup_bundle:getFlavorgroupFlavors() Error searching flavors")
+	}
+	return signedFlavors, nil
+}
+
+// getFlavorTemplates returns the flavor templates the flavors are generated from. The templates deleted since are
+// returned as well.
+func (controller FlavorgroupController) getFlavorTemplates(signedFlavors []hvs.SignedFlavor) ([]hvs.FlavorTemplate, error) {
+	var templateIds []uuid.UUID
+	for i := range signedFlavors {
+		templateIds = append(templateIds, flavorTemplateIds(&signedFlavors[i].Flavor)...)
+	}
+
+	var flavorTemplates []hvs.FlavorTemplate
+	for _, templateId := range uniqueUUIDs(templateIds) {
+		flavorTemplate, err := controller.FlavorTemplateStore.Retrieve(templateId, true)
+		if err != nil {
+			if _, ok := err.(*commErr.StatusNotFoundError); ok {
+				defaultLog.WithField("template", templateId).Warn("controllers/flavorgroup_bundle:getFlavorTemplates() " +
+					"Flavor template of flavor does not exist, it is not exported")
+				continue
+			}
+			return nil, errors.Wrapf(err, "controllers/flavorgroup_bundle:getFlavorTemplates() Error retrieving flavor template %s", templateId)
+		}
+		flavorTemplates = append(flavorTemplates, *flavorTemplate)
+	}
+	return flavorTemplates, nil
+}
+
+// getFlavorSigningKeyAndCertificates returns the flavor signing key and its certificate chain
+func (controller FlavorgroupController) getFlavorSigningKeyAndCertificates() (*rsa.PrivateKey, []x509.Certificate, error) {
+	if controller.CertStore == nil {
+		return nil, nil, errors.New("controllers/flavorgroup_bundle:getFlavorSigningKeyAndCertificates() Certificate store is not loaded")
+	}
+	key, certs, err := controller.CertStore.GetKeyAndCertificates(models.CertTypesFlavorSigning.String())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "controllers/flavorgroup_bundle:getFlavorSigningKeyAndCertificates() Flavor Signing KeyPair not found in CertStore")
+	}
+	signingKey, ok := key.(*rsa.PrivateKey)
+	if !ok || len(certs) == 0 {
+		return nil, nil, errors.New("controllers/flavorgroup_bundle:getFlavorSigningKeyAndCertificates() Flavor Signing Key not found in CertStore")
+	}
+	return signingKey, certs, nil
+}
+
+// flavorTemplateIds returns the ids of the flavor templates the flavor is generated from
+func flavorTemplateIds(flavor *hvs.Flavor) []uuid.UUID {
+	var templateIds []uuid.UUID
+	switch ids := flavor.Meta.Description["flavor_template_ids"].(type) {
+	case []uuid.UUID:
+		templateIds = ids
+	case []interface{}:
+		// the ids are decoded as strings when the flavor is read from json
+		for _, id := range ids {
+			idStr, _ := id.(string)
+			if templateId, err := uuid.Parse(idStr); err == nil {
+				templateIds = append(templateIds, templateId)
+			}
+		}
+	}
+	return templateIds
+}
+
+// flavorDigestEqual returns true if the flavors have the same content, regardless of their id
+func flavorDigestEqual(flavor1, flavor2 *hvs.Flavor) (bool, error) {
+	digest1, err := flavor1.GetFlavorDigest()
+	if err != nil {
+		return false, err
+	}
+	digest2, err := flavor2.GetFlavorDigest()
+	if err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(digest1, digest2), nil
+}
+
+func jsonEqual(v1, v2 interface{}) (bool, error) {
+	json1, err := json.Marshal(v1)
+	if err != nil {
+		return false, err
+	}
+	json2, err := json.Marshal(v2)
+	if err != nil {
+		return false, err
+	}
+	return string(json1) == string(json2), nil
+}
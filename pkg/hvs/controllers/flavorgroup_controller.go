@@ -15,6 +15,7 @@ import (
 	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
 	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/verifier/rules"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"github.com/pkg/errors"
 	"net/http"
@@ -26,7 +27,12 @@ type FlavorgroupController struct {
 	FlavorGroupStore domain.FlavorGroupStore
 	FlavorStore      domain.FlavorStore
 	HostStore        domain.HostStore
+	HostStatusStore  domain.HostStatusStore
 	HTManager        domain.HostTrustManager
+	// FlavorTemplateStore, FlavorgroupBundleStore and CertStore are used to export and import the flavorgroup bundles
+	FlavorTemplateStore    domain.FlavorTemplateStore
+	FlavorgroupBundleStore domain.FlavorgroupBundleStore
+	CertStore              *models.CertificatesStore
 }
 
 var flavorGroupSearchParams = map[string]bool{"id": true, "nameEqualTo": true, "nameContains": true, "includeFlavorContent": true}
@@ -220,6 +226,14 @@ func ValidateFlavorGroup(flavorGroup hvs.FlavorGroup) error {
 	if len(flavorGroup.MatchPolicies) == 0 {
 		return errors.New("Flavor Type Match Policy Collection must be specified")
 	}
+	if flavorGroup.HostLabelSelector != "" {
+		if _, err := models.ParseLabelSelector(flavorGroup.HostLabelSelector); err != nil {
+			return errors.Wrap(err, "Valid Host Label Selector must be specified")
+		}
+	}
+	if err := rules.ValidateCustomRules(flavorGroup.CustomRules); err != nil {
+		return errors.Wrap(err, "Valid Custom Rules must be specified")
+	}
 	return nil
 }
 
@@ -288,7 +302,7 @@ func (controller FlavorgroupController) AddFlavor(w http.ResponseWriter, r *http
 	}
 
 	// check if Flavor exists
-	_, err = controller.FlavorStore.Retrieve(linkRequest.FlavorID)
+	signedFlavor, err := controller.FlavorStore.Retrieve(linkRequest.FlavorID)
 	if err != nil {
 		if strings.Contains(err.Error(), commErr.RowsNotFound) {
 			defaultLog.WithError(err).Errorf("controllers/flavorgroup_controller:AddFlavor() %s :  Flavor %s does not exist", commLogMsg.AppRuntimeErr, linkRequest.FlavorID)
@@ -326,15 +340,18 @@ func (controller FlavorgroupController) AddFlavor(w http.ResponseWriter, r *http
 		defaultLog.WithError(err).WithField("flavorGroup", fgID).WithField("flavor", linkRequest.FlavorID).Errorf("controllers/flavorgroup_controller:AddFlavor() %s : Failed to fetch hosts linked to FlavorGroup", commLogMsg.AppRuntimeErr)
 		return nil, http.StatusInternalServerError, errors.Errorf("Error while inserting a new Flavorgroup-Flavor link")
 	}
+	impactedHosts := controller.impactAnalyzer().linkImpact([]hvs.Flavor{signedFlavor.Flavor}, linkedHosts)
 
-	// Since the host has been updated, add it to the verify queue
-	err = controller.HTManager.VerifyHostsAsync(linkedHosts, false, false)
-	if err != nil {
-		defaultLog.WithError(err).WithField("linkedHosts", linkedHosts).Error("controllers/host_controller:AddFlavor() Addition of Host to Flavor Verify Queue failed")
-		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error while inserting a new Flavorgroup-Flavor link"}
+	// Since the flavorgroup of the hosts has been updated, add the hosts matching the flavor to the verify queue
+	if len(impactedHosts) > 0 {
+		err = controller.HTManager.VerifyHostsAsync(impactedHosts, false, false)
+		if err != nil {
+			defaultLog.WithError(err).WithField("impactedHosts", impactedHosts).Error("controllers/host_controller:AddFlavor() Addition of Host to Flavor Verify Queue failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error while inserting a new Flavorgroup-Flavor link"}
+		}
 	}
 
-	defaultLog.WithField("linkedHosts", linkedHosts).Info("controllers/host_controller:AddFlavor() Added Host to Flavor Verify Queue")
+	defaultLog.WithField("impactedHosts", impactedHosts).Infof("controllers/host_controller:AddFlavor() Added %d of %d hosts linked to FlavorGroup to Flavor Verify Queue", len(impactedHosts), len(linkedHosts))
 
 	var newLink *hvs.FlavorgroupFlavorLink
 	if links != nil {
@@ -369,33 +386,46 @@ func (controller FlavorgroupController) RemoveFlavor(w http.ResponseWriter, r *h
 		}
 	}
 
-	// remove flavor links
-	err = controller.FlavorGroupStore.RemoveFlavors(fgID, []uuid.UUID{fID})
+	signedFlavor, err := controller.FlavorStore.Retrieve(fID)
 	if err != nil {
-		defaultLog.WithField("flavorGroup", fID).WithField("flavor", fID).WithError(err).Errorf("controllers/flavorgroup_controller:RemoveFlavor() %s :  Error removing linked flavors ", commLogMsg.AppRuntimeErr)
-		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error while removing Flavorgroup-Flavor links"}
+		defaultLog.WithError(err).WithField("flavor", fID).Errorf("controllers/flavorgroup_controller:RemoveFlavor() %s :  Error retrieving Flavor", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to remove FlavorGroup-Flavor link"}
 	}
 
-	// Add the affected Hosts to the Flavor Verify queue
+	// Find the affected Hosts before the link is removed, since the trust cache only holds the linked flavors
 	linkedHosts, err := controller.FlavorGroupStore.SearchHostsByFlavorGroup(fgID)
 	if err != nil {
 		defaultLog.WithError(err).WithField("flavorGroup", fgID).WithField("flavor", fID).Errorf("controllers/flavorgroup_controller:RemoveFlavor() %s : Failed to fetch hosts linked to FlavorGroup", commLogMsg.AppRuntimeErr)
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error while removing Flavorgroup-Flavor links"}
 	}
+	impactedHosts := controller.impactAnalyzer().unlinkImpact(&signedFlavor.Flavor, fgID, linkedHosts)
 
-	// Since the host has been updated, add it to the verify queue
-	err = controller.HTManager.VerifyHostsAsync(linkedHosts, false, false)
+	// remove flavor links
+	err = controller.FlavorGroupStore.RemoveFlavors(fgID, []uuid.UUID{fID})
 	if err != nil {
-		defaultLog.WithError(err).WithField("linkedHosts", linkedHosts).Error("controllers/host_controller:RemoveFlavor() Addition of Host to Flavor Verify Queue failed")
+		defaultLog.WithField("flavorGroup", fID).WithField("flavor", fID).WithError(err).Errorf("controllers/flavorgroup_controller:RemoveFlavor() %s :  Error removing linked flavors ", commLogMsg.AppRuntimeErr)
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error while removing Flavorgroup-Flavor links"}
 	}
 
-	defaultLog.WithField("linkedHosts", linkedHosts).Info("controllers/host_controller:RemoveFlavor() Added Host to Flavor Verify Queue")
+	// Since the flavorgroup of the hosts has been updated, add the hosts impacted by the flavor to the verify queue
+	if len(impactedHosts) > 0 {
+		err = controller.HTManager.VerifyHostsAsync(impactedHosts, false, false)
+		if err != nil {
+			defaultLog.WithError(err).WithField("impactedHosts", impactedHosts).Error("controllers/host_controller:RemoveFlavor() Addition of Host to Flavor Verify Queue failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error while removing Flavorgroup-Flavor links"}
+		}
+	}
+
+	defaultLog.WithField("impactedHosts", impactedHosts).Infof("controllers/host_controller:RemoveFlavor() Added %d of %d hosts linked to FlavorGroup to Flavor Verify Queue", len(impactedHosts), len(linkedHosts))
 
 	secLog.WithField("flavorGroup", fID).WithField("flavor", fID).Infof("%s: Flavor-FlavorGroup link deleted by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
 	return nil, http.StatusNoContent, nil
 }
 
+func (controller FlavorgroupController) impactAnalyzer() flavorImpactAnalyzer {
+	return flavorImpactAnalyzer{HStore: controller.HostStore, HSStore: controller.HostStatusStore}
+}
+
 // SearchFlavors returns a list of Flavors linked to a particular FlavorGroup
 func (controller FlavorgroupController) SearchFlavors(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
 	defaultLog.Trace("controllers/flavorgroup_controller:SearchFlavors() Entering")
@@ -5,15 +5,22 @@
 package controllers_test
 
 import (
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/json"
 	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
 	mocks2 "github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
 	hvsRoutes "github.com/intel-secl/intel-secl/v3/pkg/hvs/router"
 	smocks "github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
 	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
+	cf "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	fm "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"net/http"
 	"net/http/httptest"
@@ -52,6 +59,7 @@ var _ = Describe("FlavorgroupController", func() {
 			FlavorGroupStore: flavorgroupStore,
 			FlavorStore:      flavorStore,
 			HostStore:        hostStore,
+			HostStatusStore:  mocks2.NewMockHostStatusStore(),
 			HTManager:        htm,
 		}
 	})
@@ -206,7 +214,8 @@ var _ = Describe("FlavorgroupController", func() {
 											}
 										}
 									]
-								}
+								},
+								"host_label_selector": "env=prod"
 							}`
 
 				req, err := http.NewRequest(
@@ -220,6 +229,10 @@ var _ = Describe("FlavorgroupController", func() {
 				w = httptest.NewRecorder()
 				router.ServeHTTP(w, req)
 				Expect(w.Code).To(Equal(201))
+
+				var flavorgroup hvs.FlavorGroup
+				Expect(json.Unmarshal(w.Body.Bytes(), &flavorgroup)).To(Succeed())
+				Expect(flavorgroup.HostLabelSelector).To(Equal("env=prod"))
 			})
 		})
 
@@ -384,6 +397,52 @@ var _ = Describe("FlavorgroupController", func() {
 				flavorGroup.MatchPolicies = hvs.FlavorMatchPolicies{}
 				err = controllers.ValidateFlavorGroup(flavorGroup)
 				Ω(err).Should(HaveOccurred())
+
+				flavorGroup.MatchPolicies = hvs.FlavorMatchPolicies{{FlavorPart: "HOST_UNIQUE"}}
+				flavorGroup.HostLabelSelector = "env in (prod"
+				err = controllers.ValidateFlavorGroup(flavorGroup)
+				Ω(err).Should(HaveOccurred())
+
+				flavorGroup.HostLabelSelector = ""
+				flavorGroup.CustomRules = []fm.CustomRule{{Name: "SecureBootEnabled", Query: "//host_info/hardware_features/UEFI/meta[secure_boot_enabled"}}
+				err = controllers.ValidateFlavorGroup(flavorGroup)
+				Ω(err).Should(HaveOccurred())
+
+				flavorGroup.CustomRules = []fm.CustomRule{{Name: "SecureBootEnabled", Type: "unknown", Query: "//host_info"}}
+				err = controllers.ValidateFlavorGroup(flavorGroup)
+				Ω(err).Should(HaveOccurred())
+			})
+		})
+		Context("FlavorGroup with custom rules", func() {
+			It("should pass flavorGroup validation", func() {
+				flavorgroupJson := `{
+								"name": "hvs_flavorgroup_custom_rules",
+								"flavor_match_policy_collection": {
+									"flavor_match_policies": [
+										{
+											"flavor_part": "PLATFORM",
+											"match_policy": {
+												"match_type": "ANY_OF",
+												"required": "REQUIRED"
+											}
+										}
+									]
+								},
+								"custom_rules": [
+									{
+										"name": "SecureBootEnabled",
+										"description": "Secure Boot must be enabled",
+										"query": "//host_info/hardware_features/UEFI/meta[secure_boot_enabled='true']"
+									}
+								]
+							}`
+
+				flavorGroup := hvs.FlavorGroup{}
+				err := json.Unmarshal([]byte(flavorgroupJson), &flavorGroup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(flavorGroup.CustomRules)).To(Equal(1))
+				err = controllers.ValidateFlavorGroup(flavorGroup)
+				Ω(err).ShouldNot(HaveOccurred())
 			})
 		})
 	})
@@ -750,4 +809,233 @@ var _ = Describe("FlavorgroupController", func() {
 			})
 		})
 	})
+
+	// Specs for HTTP Get to "/flavorgroups/{id}/export" and HTTP Post to "/flavorgroups/import"
+	Describe("Export and Import FlavorGroups", func() {
+		var flavorTemplateStore *mocks2.MockFlavorTemplateStore
+		var certStore *models.CertificatesStore
+		var untrustedCertStore *models.CertificatesStore
+		BeforeEach(func() {
+			if certStore == nil {
+				certStore = newFlavorSigningCertStore()
+				untrustedCertStore = newFlavorSigningCertStore()
+			}
+			flavorTemplateStore = mocks2.NewFakeFlavorTemplateStore()
+			flavorgroupController.FlavorTemplateStore = flavorTemplateStore
+			flavorgroupController.FlavorgroupBundleStore = mocks2.NewMockFlavorgroupBundleStore(flavorgroupStore, flavorStore, flavorTemplateStore)
+			flavorgroupController.CertStore = certStore
+			// the stored flavors are signed with the flavor signing key of the test
+			key, _, err := certStore.GetKeyAndCertificates(models.CertTypesFlavorSigning.String())
+			Expect(err).NotTo(HaveOccurred())
+			storedFlavor, err := flavorStore.Retrieve(uuid.MustParse("c36b5412-8c02-4e08-8a74-8bfa40425cf3"))
+			Expect(err).NotTo(HaveOccurred())
+			resignedFlavor, err := fm.NewSignedFlavor(&storedFlavor.Flavor, key.(*rsa.PrivateKey))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = flavorStore.Update(&hvs.FlavorRevision{FlavorID: storedFlavor.Flavor.Meta.ID, SignedFlavor: *resignedFlavor})
+			Expect(err).NotTo(HaveOccurred())
+			router.Handle("/flavorgroups/{id}/export", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorgroupController.Export))).Methods("GET")
+			router.Handle("/flavorgroups/import", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorgroupController.Import))).Methods("POST")
+		})
+
+		exportFlavorGroup := func(id string) *hvs.SignedFlavorgroupBundle {
+			req, err := http.NewRequest("GET", "/flavorgroups/"+id+"/export", nil)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var signedBundle hvs.SignedFlavorgroupBundle
+			err = json.Unmarshal(w.Body.Bytes(), &signedBundle)
+			Expect(err).NotTo(HaveOccurred())
+			return &signedBundle
+		}
+
+		importFlavorGroup := func(signedBundle *hvs.SignedFlavorgroupBundle) *hvs.FlavorgroupImport {
+			bundleJson, err := json.Marshal(signedBundle)
+			Expect(err).NotTo(HaveOccurred())
+			req, err := http.NewRequest("POST", "/flavorgroups/import", strings.NewReader(string(bundleJson)))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			var importReport hvs.FlavorgroupImport
+			if w.Code == http.StatusCreated || w.Code == http.StatusConflict {
+				err = json.Unmarshal(w.Body.Bytes(), &importReport)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			return &importReport
+		}
+
+		// newBundle returns a bundle of a new flavorgroup with a copy of the PLATFORM flavor of the mock flavor store
+		newBundle := func(fgName string, signingCertStore *models.CertificatesStore) *hvs.SignedFlavorgroupBundle {
+			signedFlavor, err := flavorStore.Retrieve(uuid.MustParse("c36b5412-8c02-4e08-8a74-8bfa40425cf3"))
+			Expect(err).NotTo(HaveOccurred())
+			flavor := signedFlavor.Flavor
+			flavor.Meta.ID = uuid.New()
+			description := map[string]interface{}{}
+			for key, value := range flavor.Meta.Description {
+				description[key] = value
+			}
+			description["label"] = "imported_platform_flavor"
+			description["flavor_template_ids"] = []uuid.UUID{uuid.MustParse("426912bd-39b0-4daa-ad21-0c6933230b50")}
+			flavor.Meta.Description = description
+
+			key, certs, err := signingCertStore.GetKeyAndCertificates(models.CertTypesFlavorSigning.String())
+			Expect(err).NotTo(HaveOccurred())
+			newSignedFlavor, err := fm.NewSignedFlavor(&flavor, key.(*rsa.PrivateKey))
+			Expect(err).NotTo(HaveOccurred())
+			flavorTemplate, err := flavorTemplateStore.Retrieve(uuid.MustParse("426912bd-39b0-4daa-ad21-0c6933230b50"), false)
+			Expect(err).NotTo(HaveOccurred())
+			flavorTemplate.ID = uuid.New()
+			flavorTemplate.Label = "imported_template"
+
+			flavorgroup := hvs.FlavorGroup{Name: fgName, MatchPolicies: []hvs.FlavorMatchPolicy{
+				hvs.NewFlavorMatchPolicy(cf.FlavorPartPlatform, hvs.NewMatchPolicy(hvs.MatchTypeAnyOf, hvs.FlavorRequired)),
+			}}
+			bundle := hvs.NewFlavorgroupBundle(flavorgroup, []hvs.SignedFlavor{*newSignedFlavor},
+				[]hvs.FlavorTemplate{*flavorTemplate}, certs)
+			signedBundle, err := hvs.NewSignedFlavorgroupBundle(bundle, key.(*rsa.PrivateKey))
+			Expect(err).NotTo(HaveOccurred())
+			return signedBundle
+		}
+
+		Context("Export an existing FlavorGroup", func() {
+			It("Should return a bundle signed with the flavor signing key and 200 response code", func() {
+				signedBundle := exportFlavorGroup("ee37c360-7eae-4250-a677-6ee12adce8e2")
+				Expect(signedBundle.Bundle.Flavorgroup.Name).To(Equal("hvs_flavorgroup_test1"))
+				Expect(signedBundle.Bundle.Flavorgroup.MatchPolicies).To(HaveLen(3))
+				Expect(signedBundle.Bundle.SignedFlavors).To(HaveLen(1))
+				Expect(signedBundle.Bundle.SignedFlavors[0].Flavor.Meta.ID).To(Equal(uuid.MustParse("c36b5412-8c02-4e08-8a74-8bfa40425cf3")))
+				Expect(signedBundle.Bundle.SigningCertificates).To(HaveLen(1))
+
+				verifierCerts, err := utils.GetVerifierCertificates(certStore)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(signedBundle.Verify(verifierCerts.FlavorCACertificates)).To(Succeed())
+			})
+		})
+		Context("Export a FlavorGroup with a flavor modified after it is signed", func() {
+			It("Should return 500 response code", func() {
+				storedFlavor, err := flavorStore.Retrieve(uuid.MustParse("c36b5412-8c02-4e08-8a74-8bfa40425cf3"))
+				Expect(err).NotTo(HaveOccurred())
+				storedFlavor.Flavor.Meta.Description["label"] = "modified_platform_flavor"
+				_, err = flavorStore.Update(&hvs.FlavorRevision{FlavorID: storedFlavor.Flavor.Meta.ID, SignedFlavor: *storedFlavor})
+				Expect(err).NotTo(HaveOccurred())
+
+				req, err := http.NewRequest("GET", "/flavorgroups/ee37c360-7eae-4250-a677-6ee12adce8e2/export", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+		Context("Export a non-existent FlavorGroup", func() {
+			It("Should return 404 response code", func() {
+				req, err := http.NewRequest("GET", "/flavorgroups/73755fda-c910-46be-821f-e8ddeab189e9/export", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+		Context("Import a bundle of a new FlavorGroup", func() {
+			It("Should create the FlavorGroup, flavors and flavor templates and return 201 response code", func() {
+				signedBundle := newBundle("hvs_flavorgroup_imported", certStore)
+				importReport := importFlavorGroup(signedBundle)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+				Expect(importReport.Imported).To(BeTrue())
+				Expect(importReport.CreatedCount).To(Equal(3))
+				Expect(importReport.ConflictCount).To(Equal(0))
+
+				flavorgroups, err := flavorgroupStore.Search(&models.FlavorGroupFilterCriteria{NameEqualTo: "hvs_flavorgroup_imported"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(flavorgroups).To(HaveLen(1))
+				Expect(importReport.FlavorgroupID).To(Equal(flavorgroups[0].ID))
+
+				flavorId := signedBundle.Bundle.SignedFlavors[0].Flavor.Meta.ID
+				linkedFlavors, err := flavorgroupStore.SearchFlavors(flavorgroups[0].ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(linkedFlavors).To(ConsistOf([]uuid.UUID{flavorId}))
+				_, err = flavorStore.Retrieve(flavorId)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = flavorTemplateStore.Retrieve(signedBundle.Bundle.FlavorTemplates[0].ID, false)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		Context("Import the bundle of an existing FlavorGroup", func() {
+			It("Should reuse the existing FlavorGroup and flavors and return 201 response code", func() {
+				signedBundle := exportFlavorGroup("ee37c360-7eae-4250-a677-6ee12adce8e2")
+				importReport := importFlavorGroup(signedBundle)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+				Expect(importReport.Imported).To(BeTrue())
+				Expect(importReport.FlavorgroupID).To(Equal(uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")))
+				Expect(importReport.CreatedCount).To(Equal(0))
+				Expect(importReport.DuplicateCount).To(Equal(2))
+			})
+		})
+		Context("Import a bundle with a flavor conflicting with an existing flavor", func() {
+			It("Should not import anything and return 409 response code", func() {
+				signedBundle := newBundle("hvs_flavorgroup_imported", certStore)
+				// the flavor has the label of the existing flavor with a different content
+				existingFlavor, err := flavorStore.Retrieve(uuid.MustParse("c36b5412-8c02-4e08-8a74-8bfa40425cf3"))
+				Expect(err).NotTo(HaveOccurred())
+				flavor := signedBundle.Bundle.SignedFlavors[0].Flavor
+				flavor.Meta.Description["label"] = existingFlavor.Flavor.Meta.Description["label"]
+				key, certs, err := certStore.GetKeyAndCertificates(models.CertTypesFlavorSigning.String())
+				Expect(err).NotTo(HaveOccurred())
+				conflictingFlavor, err := fm.NewSignedFlavor(&flavor, key.(*rsa.PrivateKey))
+				Expect(err).NotTo(HaveOccurred())
+				bundle := hvs.NewFlavorgroupBundle(signedBundle.Bundle.Flavorgroup, []hvs.SignedFlavor{*conflictingFlavor},
+					signedBundle.Bundle.FlavorTemplates, certs)
+				signedBundle, err = hvs.NewSignedFlavorgroupBundle(bundle, key.(*rsa.PrivateKey))
+				Expect(err).NotTo(HaveOccurred())
+
+				importReport := importFlavorGroup(signedBundle)
+				Expect(w.Code).To(Equal(http.StatusConflict))
+				Expect(importReport.Imported).To(BeFalse())
+				Expect(importReport.ConflictCount).To(Equal(1))
+
+				flavorgroups, err := flavorgroupStore.Search(&models.FlavorGroupFilterCriteria{NameEqualTo: "hvs_flavorgroup_imported"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(flavorgroups).To(BeEmpty())
+				_, err = flavorTemplateStore.Retrieve(signedBundle.Bundle.FlavorTemplates[0].ID, false)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+		Context("Import a bundle signed with an untrusted key", func() {
+			It("Should return 400 response code", func() {
+				importFlavorGroup(newBundle("hvs_flavorgroup_imported", untrustedCertStore))
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Import a bundle modified after it is signed", func() {
+			It("Should return 400 response code", func() {
+				signedBundle := newBundle("hvs_flavorgroup_imported", certStore)
+				signedBundle.Bundle.Flavorgroup.Name = "hvs_flavorgroup_modified"
+				importFlavorGroup(signedBundle)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
 })
+
+// newFlavorSigningCertStore returns a certificate store with a self-signed flavor signing certificate, that is also
+// the root CA certificate
+func newFlavorSigningCertStore() *models.CertificatesStore {
+	certDer, keyDer, err := crypt.CreateKeyPairAndCertificate(constants.DefaultCN, "", constants.DefaultKeyAlgorithm, constants.DefaultKeyLength)
+	Expect(err).NotTo(HaveOccurred())
+	cert, err := x509.ParseCertificate(certDer)
+	Expect(err).NotTo(HaveOccurred())
+	key, err := x509.ParsePKCS8PrivateKey(keyDer)
+	Expect(err).NotTo(HaveOccurred())
+
+	certStore := mocks2.NewFakeCertificatesStore()
+	(*certStore)[models.CaCertTypesRootCa.String()].Certificates = []x509.Certificate{*cert}
+	(*certStore)[models.CertTypesFlavorSigning.String()].Key = key
+	(*certStore)[models.CertTypesFlavorSigning.String()].Certificates = []x509.Certificate{*cert}
+	return certStore
+}
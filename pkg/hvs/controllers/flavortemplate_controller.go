@@ -25,6 +25,7 @@ import (
 	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
 	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/verifier/rules"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"github.com/pkg/errors"
 	"github.com/xeipuuv/gojsonschema"
@@ -303,6 +304,22 @@ func (ftc *FlavorTemplateController) ValidateFlavorTemplateCreateRequest(FlvrTem
 		}
 	}
 
+	//Validate the custom rules of the flavor parts
+	for _, flavorPart := range []*hvs.FlavorPart{FlvrTemp.FlavorParts.Platform, FlvrTemp.FlavorParts.OS, FlvrTemp.FlavorParts.HostUnique} {
+		if flavorPart != nil {
+			if err := rules.ValidateCustomRules(flavorPart.CustomRules); err != nil {
+				return "Invalid custom rule", errors.Wrap(err, "controllers/flavortemplate_controller:validateFlavorTemplateCreateRequest() Invalid custom rule")
+			}
+		}
+	}
+
+	//Validate the host info policy of the platform flavor part
+	if FlvrTemp.FlavorParts.Platform != nil {
+		if err := rules.ValidateHostInfoPolicy(FlvrTemp.FlavorParts.Platform.HostInfoPolicy); err != nil {
+			return "Invalid host info policy", errors.Wrap(err, "controllers/flavortemplate_controller:validateFlavorTemplateCreateRequest() Invalid host info policy")
+		}
+	}
+
 	//Check whether each pcr index is associated with not more than one bank.
 	pcrMap := make(map[*hvs.FlavorPart][]hvs.PCR)
 	flavorParts := []*hvs.FlavorPart{FlvrTemp.FlavorParts.Platform, FlvrTemp.FlavorParts.OS, FlvrTemp.FlavorParts.HostUnique}
@@ -148,6 +148,104 @@ var _ = Describe("FlavorTemplateController", func() {
 			})
 		})
 
+		Context("Provide a FlavorTemplate data that contains an invalid custom rule", func() {
+			It("Should get HTTP Status: 400", func() {
+				router.Handle("/flavor-templates", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorTemplateController.Create))).Methods("POST")
+				flavorTemplateJson := `{
+					"label": "custom-rules-template",
+					"condition": [
+						"//host_info/os_name//*[text()='RedHatEnterprise']"
+					],
+					"flavor_parts": {
+						"PLATFORM": {
+							"meta": {
+								"tpm_version": "2.0"
+							},
+							"pcr_rules": [
+								{
+									"pcr": {
+										"index": 0,
+										"bank": "SHA256"
+									},
+									"pcr_matches": true
+								}
+							],
+							"custom_rules": [
+								{
+									"name": "SecureBootEnabled",
+									"query": "//host_info/hardware_features/UEFI/meta[secure_boot_enabled"
+								}
+							]
+						}
+					}
+				}`
+
+				req, err := http.NewRequest(
+					"POST",
+					"/flavor-templates",
+					strings.NewReader(flavorTemplateJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(400))
+				Expect(w.Body.String()).To(ContainSubstring("Invalid custom rule"))
+			})
+		})
+
+		Context("Provide a FlavorTemplate data that contains an invalid host info policy", func() {
+			It("Should get HTTP Status: 400", func() {
+				router.Handle("/flavor-templates", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorTemplateController.Create))).Methods("POST")
+				flavorTemplateJson := `{
+					"label": "host-info-policy-template",
+					"condition": [
+						"//host_info/os_name//*[text()='RedHatEnterprise']"
+					],
+					"flavor_parts": {
+						"PLATFORM": {
+							"meta": {
+								"tpm_version": "2.0"
+							},
+							"pcr_rules": [
+								{
+									"pcr": {
+										"index": 0,
+										"bank": "SHA256"
+									},
+									"pcr_matches": true
+								}
+							],
+							"host_info_policy": {
+								"features_enabled": ["TXT", "SECURE_BOOT"],
+								"minimum_versions": [
+									{
+										"attribute": "bios_version",
+										"minimum": "latest",
+										"format": "intel_bios"
+									}
+								]
+							}
+						}
+					}
+				}`
+
+				req, err := http.NewRequest(
+					"POST",
+					"/flavor-templates",
+					strings.NewReader(flavorTemplateJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(400))
+				Expect(w.Body.String()).To(ContainSubstring("Invalid host info policy"))
+			})
+		})
+
 		Context("Provide a empty data that should give bad request error", func() {
 			It("Should get HTTP Status: 400", func() {
 				router.Handle("/flavor-templates", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorTemplateController.Create))).Methods("POST")
@@ -0,0 +1,491 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// columns of the CSV bulk host create request, multiple flavorgroup names and labels are separated by semicolons
+const (
+	csvColumnHostName         = "host_name"
+	csvColumnConnectionString = "connection_string"
+	csvColumnDescription      = "description"
+	csvColumnFlavorgroupNames = "flavorgroup_names"
+	csvColumnLabels           = "labels"
+)
+
+var hostBulkCsvColumns = map[string]bool{csvColumnHostName: true, csvColumnConnectionString: true,
+	csvColumnDescription: true, csvColumnFlavorgroupNames: true, csvColumnLabels: true}
+
+type HostBulkController struct {
+	HController HostController
+	JStore      domain.JobStore
+	JRunner     domain.JobRunner
+	// Workers is the number of hosts registered concurrently by a bulk registration job
+	Workers int
+}
+
+func NewHostBulkController(hc HostController, js domain.JobStore, jr domain.JobRunner) *HostBulkController {
+	return &HostBulkController{
+		HController: hc,
+		JStore:      js,
+		JRunner:     jr,
+		Workers:     constants.DefaultBulkHostRegistrationWorkers,
+	}
+}
+
+// Create validates all the hosts of the request and starts a job registering them. The hosts can be provided as a
+// HostBulkCreateRequest JSON document or as a CSV file with a header row.
+func (controller *HostBulkController) Create(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/host_bulk_controller:Create() Entering")
+	defer defaultLog.Trace("controllers/host_bulk_controller:Create() Leaving")
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType != consts.HTTPMediaTypeJson && contentType != consts.HTTPMediaTypeCsv {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/host_bulk_controller:Create() The request body was not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body was not provided"}
+	}
+
+	var reqHosts []hvs.HostCreateRequest
+	if contentType == consts.HTTPMediaTypeCsv {
+		var err error
+		reqHosts, err = parseHostBulkCreateCsv(r.Body)
+		if err != nil {
+			secLog.WithError(err).Errorf("controllers/host_bulk_controller:Create() %s :  Failed to parse request body as CSV", commLogMsg.InvalidInputBadEncoding)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to parse CSV request body: " + err.Error()}
+		}
+	} else {
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+
+		var reqBulk hvs.HostBulkCreateRequest
+		if err := dec.Decode(&reqBulk); err != nil {
+			secLog.WithError(err).Errorf("controllers/host_bulk_controller:Create() %s :  Failed to decode request body as HostBulkCreateRequest", commLogMsg.InvalidInputBadEncoding)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+		}
+		reqHosts = reqBulk.Hosts
+	}
+
+	if len(reqHosts) == 0 || len(reqHosts) > constants.MaxBulkHostRegistrationEntries {
+		secLog.Errorf("controllers/host_bulk_controller:Create() %s : Invalid number of hosts", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: fmt.Sprintf("Between 1 and %d hosts must be specified",
+			constants.MaxBulkHostRegistrationEntries)}
+	}
+
+	invalidEntries, err := controller.validateHosts(reqHosts)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/host_bulk_controller:Create() Host search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to validate hosts"}
+	}
+	if len(invalidEntries) > 0 {
+		secLog.Errorf("controllers/host_bulk_controller:Create() %s : Invalid hosts in request", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid hosts: " + strings.Join(invalidEntries, "; ")}
+	}
+
+	job := &hvs.Job{
+		Type:   hvs.JobTypeHostBulkRegistration,
+		Status: hvs.JobStatusQueued,
+		Total:  len(reqHosts),
+	}
+	for _, reqHost := range reqHosts {
+		job.Hosts = append(job.Hosts, hvs.JobHostEntry{HostName: reqHost.HostName, Status: hvs.JobStatusQueued})
+	}
+	job, err = controller.JStore.Create(job)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/host_bulk_controller:Create() Job create failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create host registration job"}
+	}
+
+	// the job is run on a copy so that the response is not changed while it is being written
+	jobCopy := *job
+	jobCopy.Hosts = append([]hvs.JobHostEntry(nil), job.Hosts...)
+	err = controller.JRunner.Submit(func(ctx context.Context) {
+		controller.registerHosts(ctx, &jobCopy, reqHosts)
+	})
+	if err != nil {
+		defaultLog.WithError(err).WithField("job", job.Id).Error("controllers/host_bulk_controller:Create() Job submission failed")
+		job.Status = hvs.JobStatusFailed
+		job.Error = "Failed to start host registration job"
+		if err := controller.JStore.Update(job); err != nil {
+			defaultLog.WithError(err).WithField("job", job.Id).Error("controllers/host_bulk_controller:Create() Job update failed")
+		}
+		return nil, http.StatusServiceUnavailable, &commErr.ResourceError{Message: "Failed to start host registration job, retry later"}
+	}
+
+	secLog.WithField("job", job.Id).Infof("%s: Bulk host registration of %d hosts started by: %s",
+		commLogMsg.PrivilegeModified, len(reqHosts), r.RemoteAddr)
+	return job, http.StatusAccepted, nil
+}
+
+// validateHosts checks all the hosts of a bulk request before any of them is registered, and returns the reasons of
+// the invalid entries
+func (controller *HostBulkController) validateHosts(reqHosts []hvs.HostCreateRequest) ([]string, error) {
+	defaultLog.Trace("controllers/host_bulk_controller:validateHosts() Entering")
+	defer defaultLog.Trace("controllers/host_bulk_controller:validateHosts() Leaving")
+
+	var invalidEntries []string
+	hostNames := make(map[string]bool)
+	for i, reqHost := range reqHosts {
+		entry := fmt.Sprintf("entry %d", i+1)
+		if reqHost.HostName == "" || reqHost.ConnectionString == "" {
+			invalidEntries = append(invalidEntries, entry+": host connection string and host name must be specified")
+			continue
+		}
+		entry = fmt.Sprintf("entry %d (%s)", i+1, reqHost.HostName)
+		if err := validateHostCreateCriteria(reqHost); err != nil {
+			invalidEntries = append(invalidEntries, entry+": "+err.Error())
+			continue
+		}
+		if hostNames[reqHost.HostName] {
+			invalidEntries = append(invalidEntries, entry+": duplicate host name in request")
+			continue
+		}
+		hostNames[reqHost.HostName] = true
+
+		existingHosts, err := controller.HController.HStore.Search(&models.HostFilterCriteria{
+			NameEqualTo: reqHost.HostName}, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(existingHosts) > 0 {
+			invalidEntries = append(invalidEntries, entry+": host with this name already exist")
+		}
+	}
+	return invalidEntries, nil
+}
+
+// registerHosts registers the hosts of the job and records the status of each of them, then adds all the registered
+// hosts to the flavor-verify queue at once. The hosts that are not registered yet when the context is cancelled are
+// recorded as failed.
+func (controller *HostBulkController) registerHosts(ctx context.Context, job *hvs.Job, reqHosts []hvs.HostCreateRequest) {
+	defaultLog.Trace("controllers/host_bulk_controller:registerHosts() Entering")
+	defer defaultLog.Trace("controllers/host_bulk_controller:registerHosts() Leaving")
+
+	var mtx sync.Mutex
+	updateJob := func() {
+		if err := controller.JStore.Update(job); err != nil {
+			defaultLog.WithError(err).WithField("job", job.Id).Error("controllers/host_bulk_controller:registerHosts() Job update failed")
+		}
+	}
+
+	job.Status = hvs.JobStatusRunning
+	updateJob()
+
+	// create the missing flavorgroups up front, so that concurrent registrations do not create them more than once
+	var fgNames []string
+	fgNameSet := map[string]bool{models.FlavorGroupsAutomatic.String(): true}
+	for _, reqHost := range reqHosts {
+		for _, fgName := range reqHost.FlavorgroupNames {
+			fgNameSet[fgName] = true
+		}
+	}
+	for fgName := range fgNameSet {
+		fgNames = append(fgNames, fgName)
+	}
+	if _, err := CreateMissingFlavorgroups(controller.HController.FGStore, fgNames); err != nil {
+		defaultLog.WithError(err).WithField("job", job.Id).Warn("controllers/host_bulk_controller:registerHosts() Could not create missing flavorgroups")
+	}
+
+	workers := controller.Workers
+	if workers <= 0 {
+		workers = constants.DefaultBulkHostRegistrationWorkers
+	}
+	entries := make(chan int)
+	var wg sync.WaitGroup
+	var hostIds []uuid.UUID
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range entries {
+				createdHost, _, err := controller.HController.registerHost(reqHosts[i])
+
+				mtx.Lock()
+				if err != nil {
+					defaultLog.WithError(err).WithField("job", job.Id).Errorf("controllers/host_bulk_controller:registerHosts() Registration of host %s failed", reqHosts[i].HostName)
+					job.Hosts[i].Status = hvs.JobStatusFailed
+					job.Hosts[i].Error = err.Error()
+					job.Failed++
+				} else {
+					job.Hosts[i].Status = hvs.JobStatusCompleted
+					job.Hosts[i].HostId = &createdHost.Id
+					job.Succeeded++
+					hostIds = append(hostIds, createdHost.Id)
+				}
+				updateJob()
+				mtx.Unlock()
+			}
+		}()
+	}
+feed:
+	for i := range reqHosts {
+		select {
+		case entries <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(entries)
+	wg.Wait()
+
+	job.Status = hvs.JobStatusCompleted
+	if ctx.Err() != nil {
+		for i := range job.Hosts {
+			if job.Hosts[i].Status == hvs.JobStatusQueued {
+				job.Hosts[i].Status = hvs.JobStatusFailed
+				job.Hosts[i].Error = "Registration cancelled by the shutdown of HVS"
+				job.Failed++
+			}
+		}
+		job.Status = hvs.JobStatusFailed
+		job.Error = "The job was cancelled by the shutdown of HVS"
+	}
+	if len(hostIds) > 0 {
+		defaultLog.Debugf("Adding %d hosts to flavor-verify queue", len(hostIds))
+		// Since we are adding new hosts, fetch their latest host manifest to verify against.
+		if err := controller.HController.HTManager.VerifyHostsAsync(hostIds, true, false); err != nil {
+			defaultLog.WithError(err).WithField("job", job.Id).Error("controllers/host_bulk_controller:registerHosts() Hosts to Flavor Verify Queue addition failed")
+			job.Status = hvs.JobStatusFailed
+			job.Error = "Failed to add Hosts to Flavor Verify Queue"
+		}
+	}
+	updateJob()
+	defaultLog.Infof("controllers/host_bulk_controller:registerHosts() Bulk host registration job %s completed, %d of %d hosts registered",
+		job.Id, job.Succeeded, job.Total)
+}
+
+// LinkFlavorgroups links each of the hosts of the request to all its flavorgroups, the existing links are kept. The
+// linked hosts are added to the flavor-verify queue at once.
+func (controller *HostBulkController) LinkFlavorgroups(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/host_bulk_controller:LinkFlavorgroups() Entering")
+	defer defaultLog.Trace("controllers/host_bulk_controller:LinkFlavorgroups() Leaving")
+
+	if r.Header.Get("Content-Type") != consts.HTTPMediaTypeJson {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/host_bulk_controller:LinkFlavorgroups() The request body was not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body was not provided"}
+	}
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	var reqLinks hvs.HostFlavorgroupBulkCreateRequest
+	if err := dec.Decode(&reqLinks); err != nil {
+		secLog.WithError(err).Errorf("controllers/host_bulk_controller:LinkFlavorgroups() %s :  Failed to decode request body as HostFlavorgroupBulkCreateRequest", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	hostIds := uniqueUUIDs(reqLinks.HostIds)
+	fgIds := uniqueUUIDs(reqLinks.FlavorgroupIds)
+	if len(hostIds) == 0 || len(hostIds) > constants.MaxBulkHostRegistrationEntries || len(fgIds) == 0 {
+		secLog.Errorf("controllers/host_bulk_controller:LinkFlavorgroups() %s : Invalid number of hosts or flavorgroups", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: fmt.Sprintf("Between 1 and %d host ids and at least one flavorgroup id must be specified",
+			constants.MaxBulkHostRegistrationEntries)}
+	}
+
+	hosts, err := controller.HController.HStore.Search(&models.HostFilterCriteria{IdList: hostIds}, nil)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/host_bulk_controller:LinkFlavorgroups() Host search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to search Hosts"}
+	}
+	var foundHostIds []uuid.UUID
+	for _, host := range hosts {
+		foundHostIds = append(foundHostIds, host.Id)
+	}
+	if missingHostIds := missingUUIDs(hostIds, foundHostIds); len(missingHostIds) > 0 {
+		secLog.Errorf("controllers/host_bulk_controller:LinkFlavorgroups() %s : Hosts with specified ids do not exist", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Hosts with specified ids do not exist: " +
+			joinUUIDs(missingHostIds)}
+	}
+
+	flavorgroups, err := controller.HController.FGStore.Search(&models.FlavorGroupFilterCriteria{Ids: fgIds})
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/host_bulk_controller:LinkFlavorgroups() Flavorgroup search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to search Flavorgroups"}
+	}
+	var foundFgIds []uuid.UUID
+	for _, fg := range flavorgroups {
+		foundFgIds = append(foundFgIds, fg.ID)
+	}
+	if missingFgIds := missingUUIDs(fgIds, foundFgIds); len(missingFgIds) > 0 {
+		secLog.Errorf("controllers/host_bulk_controller:LinkFlavorgroups() %s : Flavorgroups with specified ids do not exist", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavorgroups with specified ids do not exist: " +
+			joinUUIDs(missingFgIds)}
+	}
+
+	createdLinks := hvs.HostFlavorgroupCollection{HostFlavorgroups: []hvs.HostFlavorgroup{}}
+	var linkedHostIds []uuid.UUID
+	for _, hId := range hostIds {
+		linkedFgIds, err := controller.HController.HStore.SearchFlavorgroups(hId)
+		if err != nil {
+			defaultLog.WithError(err).WithField("id", hId).Error("controllers/host_bulk_controller:LinkFlavorgroups() Host Flavorgroup link search failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create Host Flavorgroup links"}
+		}
+		newFgIds := missingUUIDs(fgIds, linkedFgIds)
+		if len(newFgIds) == 0 {
+			continue
+		}
+
+		defaultLog.Debugf("Linking host %v with flavorgroups %v", hId, newFgIds)
+		if err := controller.HController.HStore.AddFlavorgroups(hId, newFgIds); err != nil {
+			defaultLog.WithError(err).WithField("id", hId).Error("controllers/host_bulk_controller:LinkFlavorgroups() Host Flavorgroup association failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to associate Hosts with Flavorgroups"}
+		}
+		for _, fgId := range newFgIds {
+			createdLinks.HostFlavorgroups = append(createdLinks.HostFlavorgroups, hvs.HostFlavorgroup{HostId: hId, FlavorgroupId: fgId})
+		}
+		linkedHostIds = append(linkedHostIds, hId)
+	}
+
+	if len(linkedHostIds) > 0 {
+		defaultLog.Debugf("Adding %d hosts to flavor-verify queue", len(linkedHostIds))
+		if err := controller.HController.HTManager.VerifyHostsAsync(linkedHostIds, false, false); err != nil {
+			defaultLog.WithError(err).Error("controllers/host_bulk_controller:LinkFlavorgroups() Hosts to Flavor Verify Queue addition failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to add Hosts to Flavor Verify Queue"}
+		}
+	}
+
+	secLog.Infof("%s: %d Host Flavorgroup links created by: %s", commLogMsg.PrivilegeModified, len(createdLinks.HostFlavorgroups), r.RemoteAddr)
+	return createdLinks, http.StatusCreated, nil
+}
+
+// uniqueUUIDs removes the duplicates from a list of ids, preserving their order
+func uniqueUUIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	var unique []uuid.UUID
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+func containsUUID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+// missingUUIDs returns the ids that are not in the found ids, preserving their order
+func missingUUIDs(ids, foundIds []uuid.UUID) []uuid.UUID {
+	var missing []uuid.UUID
+	for _, id := range ids {
+		if !containsUUID(foundIds, id) {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+func joinUUIDs(ids []uuid.UUID) string {
+	var idStrings []string
+	for _, id := range ids {
+		idStrings = append(idStrings, id.String())
+	}
+	return strings.Join(idStrings, ", ")
+}
+
+// parseHostBulkCreateCsv reads the hosts of a CSV bulk host create request. The first row names the columns, the
+// host_name and connection_string columns are mandatory.
+func parseHostBulkCreateCsv(body io.Reader) ([]hvs.HostCreateRequest, error) {
+	defaultLog.Trace("controllers/host_bulk_controller:parseHostBulkCreateCsv() Entering")
+	defer defaultLog.Trace("controllers/host_bulk_controller:parseHostBulkCreateCsv() Leaving")
+
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read header row")
+	}
+	columns := make(map[string]int)
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		if !hostBulkCsvColumns[column] {
+			return nil, errors.Errorf("unknown column '%s'", column)
+		}
+		if _, ok := columns[column]; ok {
+			return nil, errors.Errorf("duplicate column '%s'", column)
+		}
+		columns[column] = i
+	}
+	if _, ok := columns[csvColumnHostName]; !ok {
+		return nil, errors.Errorf("missing column '%s'", csvColumnHostName)
+	}
+	if _, ok := columns[csvColumnConnectionString]; !ok {
+		return nil, errors.Errorf("missing column '%s'", csvColumnConnectionString)
+	}
+
+	var reqHosts []hvs.HostCreateRequest
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		value := func(column string) string {
+			if i, ok := columns[column]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		reqHost := hvs.HostCreateRequest{
+			HostName:         value(csvColumnHostName),
+			ConnectionString: value(csvColumnConnectionString),
+			Description:      value(csvColumnDescription),
+		}
+		if fgNames := value(csvColumnFlavorgroupNames); fgNames != "" {
+			for _, fgName := range strings.Split(fgNames, ";") {
+				reqHost.FlavorgroupNames = append(reqHost.FlavorgroupNames, strings.TrimSpace(fgName))
+			}
+		}
+		if labels := value(csvColumnLabels); labels != "" {
+			reqHost.Labels = make(map[string]string)
+			for _, label := range strings.Split(labels, ";") {
+				kv := strings.SplitN(label, "=", 2)
+				if len(kv) != 2 {
+					return nil, errors.Errorf("invalid label '%s' on row %d, labels must be key=value pairs", label, row)
+				}
+				reqHost.Labels[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+			}
+		}
+		reqHosts = append(reqHosts, reqHost)
+	}
+	return reqHosts, nil
+}
@@ -0,0 +1,274 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	hvsRoutes "github.com/intel-secl/intel-secl/v3/pkg/hvs/router"
+	smocks "github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/jobs"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	mocks2 "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HostBulkController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var hostStore *mocks.MockHostStore
+	var jobStore *mocks.MockJobStore
+	var jobRunner *jobs.JobRunner
+	var hostBulkController *controllers.HostBulkController
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		hostStore = mocks.NewMockHostStore()
+		jobStore = mocks.NewMockJobStore()
+		var err error
+		jobRunner, err = jobs.NewJobRunner(jobStore, 1, 1)
+		Expect(err).NotTo(HaveOccurred())
+
+		dek, err := base64.StdEncoding.DecodeString("gcXqH8YwuJZ3Rx4qVzA/zhVvkTw2TL+iRAC9T3E6lII=")
+		Expect(err).NotTo(HaveOccurred())
+		hostController := controllers.HostController{
+			HStore:    hostStore,
+			HSStore:   mocks.NewMockHostStatusStore(),
+			FStore:    mocks.NewMockFlavorStore(),
+			FGStore:   mocks.NewFakeFlavorgroupStore(),
+			HCStore:   mocks.NewMockHostCredentialStore(),
+			HTManager: &smocks.MockHostTrustManager{},
+			HCConfig: domain.HostControllerConfig{
+				HostConnectorProvider: mocks2.MockHostConnectorFactory{},
+				DataEncryptionKey:     dek,
+				Username:              "fakeuser",
+				Password:              "fakepassword",
+			},
+		}
+		// the mock stores are not safe for concurrent use
+		hostBulkController = &controllers.HostBulkController{
+			HController: hostController,
+			JStore:      jobStore,
+			JRunner:     jobRunner,
+			Workers:     1,
+		}
+		router.Handle("/hosts/bulk", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostBulkController.Create))).Methods("POST")
+		router.Handle("/hosts/flavorgroups/bulk", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostBulkController.LinkFlavorgroups))).Methods("POST")
+	})
+	AfterEach(func() {
+		Expect(jobRunner.Stop(5 * time.Second)).To(Succeed())
+	})
+
+	// waitForJob polls the job store until the job is no longer queued or running
+	waitForJob := func(job hvs.Job) *hvs.Job {
+		var current *hvs.Job
+		Eventually(func() hvs.JobStatus {
+			var err error
+			current, err = jobStore.Retrieve(job.Id)
+			Expect(err).NotTo(HaveOccurred())
+			return current.Status
+		}, "5s", "50ms").Should(Equal(hvs.JobStatusCompleted))
+		return current
+	}
+
+	// Specs for HTTP Post to "/hosts/bulk"
+	Describe("Register hosts in bulk", func() {
+		Context("Provide a valid JSON bulk create request", func() {
+			It("Should start a job registering all the hosts", func() {
+				body := `{"hosts": [
+							{"host_name": "bulkhost1", "connection_string": "intel:https://bulk1.ta.ip.com:1443", "flavorgroup_names": ["hvs_bulk_flavorgroup"]},
+							{"host_name": "bulkhost2", "connection_string": "intel:https://bulk2.ta.ip.com:1443", "labels": {"rack": "r9"}}
+						]}`
+				req, err := http.NewRequest("POST", "/hosts/bulk", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusAccepted))
+
+				var job hvs.Job
+				err = json.Unmarshal(w.Body.Bytes(), &job)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(job.Type).To(Equal(hvs.JobTypeHostBulkRegistration))
+				Expect(job.Total).To(Equal(2))
+
+				completedJob := waitForJob(job)
+				Expect(completedJob.Succeeded).To(Equal(2))
+				Expect(completedJob.Failed).To(Equal(0))
+				for _, entry := range completedJob.Hosts {
+					Expect(entry.Status).To(Equal(hvs.JobStatusCompleted))
+					Expect(entry.HostId).NotTo(BeNil())
+				}
+
+				hosts, err := hostStore.Search(&models.HostFilterCriteria{NameEqualTo: "bulkhost2"}, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hosts).To(HaveLen(1))
+				Expect(hosts[0].Labels).To(HaveKeyWithValue("rack", "r9"))
+			})
+		})
+		Context("Provide a valid CSV bulk create request", func() {
+			It("Should start a job registering all the hosts", func() {
+				body := "host_name,connection_string,description,flavorgroup_names,labels\n" +
+					"bulkhost1,intel:https://bulk1.ta.ip.com:1443,Rack 9 host,automatic;hvs_bulk_flavorgroup,env=prod;rack=r9\n" +
+					"bulkhost2,intel:https://bulk2.ta.ip.com:1443,,,\n"
+				req, err := http.NewRequest("POST", "/hosts/bulk", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeCsv)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusAccepted))
+
+				var job hvs.Job
+				err = json.Unmarshal(w.Body.Bytes(), &job)
+				Expect(err).NotTo(HaveOccurred())
+				completedJob := waitForJob(job)
+				Expect(completedJob.Succeeded).To(Equal(2))
+
+				hosts, err := hostStore.Search(&models.HostFilterCriteria{NameEqualTo: "bulkhost1"}, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hosts).To(HaveLen(1))
+				Expect(hosts[0].Description).To(Equal("Rack 9 host"))
+				Expect(hosts[0].Labels).To(HaveKeyWithValue("env", "prod"))
+			})
+		})
+		Context("Provide a CSV bulk create request without the connection_string column", func() {
+			It("Should get HTTP Status: 400", func() {
+				body := "host_name,description\nbulkhost1,Rack 9 host\n"
+				req, err := http.NewRequest("POST", "/hosts/bulk", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeCsv)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide a bulk create request with an existing and a duplicate host", func() {
+			It("Should get HTTP Status: 400 and not register any host", func() {
+				body := `{"hosts": [
+							{"host_name": "localhost1", "connection_string": "intel:https://bulk1.ta.ip.com:1443"},
+							{"host_name": "bulkhost2", "connection_string": "intel:https://bulk2.ta.ip.com:1443"},
+							{"host_name": "bulkhost2", "connection_string": "intel:https://bulk3.ta.ip.com:1443"}
+						]}`
+				req, err := http.NewRequest("POST", "/hosts/bulk", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(ContainSubstring("entry 1 (localhost1)"))
+				Expect(w.Body.String()).To(ContainSubstring("entry 3 (bulkhost2)"))
+
+				hosts, err := hostStore.Search(&models.HostFilterCriteria{NameEqualTo: "bulkhost2"}, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hosts).To(BeEmpty())
+			})
+		})
+		Context("Provide an empty bulk create request", func() {
+			It("Should get HTTP Status: 400", func() {
+				req, err := http.NewRequest("POST", "/hosts/bulk", strings.NewReader(`{"hosts": []}`))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide a bulk create request after the job runner is stopped", func() {
+			It("Should get HTTP Status: 503 and record the job failed", func() {
+				Expect(jobRunner.Stop(5 * time.Second)).To(Succeed())
+
+				body := `{"hosts": [{"host_name": "bulkhost1", "connection_string": "intel:https://bulk1.ta.ip.com:1443"}]}`
+				req, err := http.NewRequest("POST", "/hosts/bulk", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusServiceUnavailable))
+
+				hosts, err := hostStore.Search(&models.HostFilterCriteria{NameEqualTo: "bulkhost1"}, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hosts).To(BeEmpty())
+			})
+		})
+	})
+
+	// Specs for HTTP Post to "/hosts/flavorgroups/bulk"
+	Describe("Link hosts to flavorgroups in bulk", func() {
+		Context("Provide valid host and flavorgroup ids", func() {
+			It("Should link each host to all the flavorgroups, keeping the existing links", func() {
+				body := `{"host_ids": ["ee37c360-7eae-4250-a677-6ee12adce8e2", "e57e5ea0-d465-461e-882d-1600090caa0d"],
+						"flavorgroup_ids": ["e57e5ea0-d465-461e-882d-1600090caa0d", "ee37c360-7eae-4250-a677-6ee12adce8e2"]}`
+				req, err := http.NewRequest("POST", "/hosts/flavorgroups/bulk", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+
+				var links hvs.HostFlavorgroupCollection
+				err = json.Unmarshal(w.Body.Bytes(), &links)
+				Expect(err).NotTo(HaveOccurred())
+				// host ee37c360 was already linked to flavorgroup e57e5ea0
+				Expect(links.HostFlavorgroups).To(HaveLen(3))
+
+				for _, hostId := range []string{"ee37c360-7eae-4250-a677-6ee12adce8e2", "e57e5ea0-d465-461e-882d-1600090caa0d"} {
+					fgIds, err := hostStore.SearchFlavorgroups(uuid.MustParse(hostId))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fgIds).To(HaveLen(2))
+				}
+			})
+		})
+		Context("Provide a flavorgroup id that does not exist", func() {
+			It("Should get HTTP Status: 400 and not link any host", func() {
+				body := `{"host_ids": ["e57e5ea0-d465-461e-882d-1600090caa0d"],
+						"flavorgroup_ids": ["ee37c360-7eae-4250-a677-6ee12adce8e2", "73755fda-c910-46be-821f-e8ddeab189e9"]}`
+				req, err := http.NewRequest("POST", "/hosts/flavorgroups/bulk", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(ContainSubstring("73755fda-c910-46be-821f-e8ddeab189e9"))
+
+				fgIds, err := hostStore.SearchFlavorgroups(uuid.MustParse("e57e5ea0-d465-461e-882d-1600090caa0d"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fgIds).To(BeEmpty())
+			})
+		})
+		Context("Provide no host ids", func() {
+			It("Should get HTTP Status: 400", func() {
+				body := `{"host_ids": [], "flavorgroup_ids": ["ee37c360-7eae-4250-a677-6ee12adce8e2"]}`
+				req, err := http.NewRequest("POST", "/hosts/flavorgroups/bulk", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+})
@@ -52,7 +52,8 @@ func NewHostController(hs domain.HostStore, hss domain.HostStatusStore, fs domai
 }
 
 var hostSearchParams = map[string]bool{"id": true, "nameEqualTo": true, "nameContains": true, "hostHardwareId": true,
-	"key": true, "value": true, "trusted": true, "getTrustStatus": true, "getHostStatus": true, "orderBy": true}
+	"key": true, "value": true, "trusted": true, "getTrustStatus": true, "getHostStatus": true, "orderBy": true,
+	"labelSelector": true, "limit": true, "after": true}
 
 var hostRetrieveParams = map[string]bool{"getReport": true, "getHostStatus": true}
 
@@ -144,6 +145,7 @@ func (hc *HostController) Update(w http.ResponseWriter, r *http.Request) (interf
 		Description:      reqHost.Description,
 		ConnectionString: reqHost.ConnectionString,
 		FlavorgroupNames: reqHost.FlavorgroupNames,
+		Labels:           reqHost.Labels,
 	}
 
 	if err := validateHostCreateCriteria(criteria); err != nil {
@@ -233,6 +235,11 @@ func (hc *HostController) Search(w http.ResponseWriter, r *http.Request) (interf
 		return nil, http.StatusInternalServerError, errors.Errorf("Failed to search Hosts")
 	}
 	hostCollection := hvs.HostCollection{Hosts: hosts}
+	if len(hosts) > 0 {
+		last := hosts[len(hosts)-1]
+		hostCollection.Next = utils.NextPageLink(r, hostFilterCriteria.Limit, len(hosts),
+			models.PageCursor{Key: last.HostName, Id: last.Id})
+	}
 
 	secLog.Infof("%s: Hosts searched by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
 	return hostCollection, http.StatusOK, nil
@@ -242,20 +249,43 @@ func (hc *HostController) CreateHost(reqHost hvs.HostCreateRequest) (interface{}
 	defaultLog.Trace("controllers/host_controller:CreateHost() Entering")
 	defer defaultLog.Trace("controllers/host_controller:CreateHost() Leaving")
 
+	createdHost, status, err := hc.registerHost(reqHost)
+	if err != nil {
+		return nil, status, err
+	}
+
+	defaultLog.Debugf("Adding host %s to flavor-verify queue", reqHost.HostName)
+	// Since we are adding a new host, the forceUpdate flag should be set to true so that
+	// we connect to the host and get the latest host manifest to verify against.
+	err = hc.HTManager.VerifyHostsAsync([]uuid.UUID{createdHost.Id}, true, false)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/host_controller:CreateHost() Host to Flavor Verify Queue addition failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to add Host to Flavor Verify Queue"}
+	}
+
+	return createdHost, http.StatusCreated, nil
+}
+
+// registerHost creates the host and its credential, and links it to its flavorgroups and host unique flavors.
+// The host is not added to the flavor-verify queue.
+func (hc *HostController) registerHost(reqHost hvs.HostCreateRequest) (*hvs.Host, int, error) {
+	defaultLog.Trace("controllers/host_controller:registerHost() Entering")
+	defer defaultLog.Trace("controllers/host_controller:registerHost() Leaving")
+
 	if reqHost.HostName == "" || reqHost.ConnectionString == "" {
-		secLog.Error("controllers/host_controller:CreateHost() Host connection string and host name must be specified")
+		secLog.Error("controllers/host_controller:registerHost() Host connection string and host name must be specified")
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Host connection string and host name must be specified"}
 	}
 
 	if err := validateHostCreateCriteria(reqHost); err != nil {
-		secLog.WithError(err).Errorf("controllers/host_controller:CreateHost() %s Invalid host data", commLogMsg.InvalidInputBadParam)
+		secLog.WithError(err).Errorf("controllers/host_controller:registerHost() %s Invalid host data", commLogMsg.InvalidInputBadParam)
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid host data"}
 	}
 
 	existingHosts, err := hc.HStore.Search(&models.HostFilterCriteria{
 		NameEqualTo: reqHost.HostName}, nil)
 	if err != nil {
-		defaultLog.WithError(err).Error("controllers/host_controller:CreateHost() Host search failed")
+		defaultLog.WithError(err).Error("controllers/host_controller:registerHost() Host search failed")
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create Host"}
 	}
 
@@ -270,7 +300,7 @@ func (hc *HostController) CreateHost(reqHost hvs.HostCreateRequest) (interface{}
 		hc.HCStore)
 
 	if err != nil {
-		defaultLog.WithError(err).Error("controllers/host_controller:CreateHost() Could not generate formatted connection string")
+		defaultLog.WithError(err).Error("controllers/host_controller:registerHost() Could not generate formatted connection string")
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
 	}
 
@@ -315,11 +345,12 @@ func (hc *HostController) CreateHost(reqHost hvs.HostCreateRequest) (interface{}
 		ConnectionString: csWithoutCredentials,
 		HardwareUuid:     hwUuid,
 		FlavorgroupNames: fgNames,
+		Labels:           reqHost.Labels,
 	}
 
 	createdHost, err := hc.HStore.Create(host)
 	if err != nil {
-		defaultLog.WithError(err).Error("controllers/host_controller:CreateHost() Host create failed")
+		defaultLog.WithError(err).Error("controllers/host_controller:registerHost() Host create failed")
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create Host"}
 	}
 
@@ -334,31 +365,28 @@ func (hc *HostController) CreateHost(reqHost hvs.HostCreateRequest) (interface{}
 
 	_, err = hc.HCStore.Create(&hostCredential)
 	if err != nil {
-		defaultLog.WithError(err).Error("controllers/host_controller:CreateHost() Host Credential create failed")
+		defaultLog.WithError(err).Error("controllers/host_controller:registerHost() Host Credential create failed")
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create Host Credential"}
 	}
 
 	defaultLog.Debugf("Associating host %s with flavorgroups %+q", reqHost.HostName, fgNames)
 	if err := hc.linkFlavorgroupsToHost(fgNames, createdHost.Id); err != nil {
-		defaultLog.WithError(err).Error("controllers/host_controller:CreateHost() Host FlavorGroup association failed")
+		defaultLog.WithError(err).Error("controllers/host_controller:registerHost() Host FlavorGroup association failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to associate Host with flavorgroups"}
+	}
+
+	defaultLog.Debugf("Associating host %s with flavorgroups selecting its labels", reqHost.HostName)
+	if _, err := hc.linkLabelSelectedFlavorgroupsToHost(createdHost); err != nil {
+		defaultLog.WithError(err).Error("controllers/host_controller:registerHost() Host FlavorGroup association by label selector failed")
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to associate Host with flavorgroups"}
 	}
 
 	defaultLog.Debugf("Associating host %s with all host unique flavors", reqHost.HostName)
 	if err := hc.linkHostUniqueFlavorsToHost(createdHost); err != nil {
-		defaultLog.WithError(err).Error("controllers/host_controller:CreateHost() Host Unique flavor association failed")
+		defaultLog.WithError(err).Error("controllers/host_controller:registerHost() Host Unique flavor association failed")
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to associate Host with host unique flavors"}
 	}
 
-	defaultLog.Debugf("Adding host %s to flavor-verify queue", reqHost.HostName)
-	// Since we are adding a new host, the forceUpdate flag should be set to true so that
-	// we connect to the host and get the latest host manifest to verify against.
-	err = hc.HTManager.VerifyHostsAsync([]uuid.UUID{createdHost.Id}, true, false)
-	if err != nil {
-		defaultLog.WithError(err).Error("controllers/host_controller:CreateHost() Host to Flavor Verify Queue addition failed")
-		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to add Host to Flavor Verify Queue"}
-	}
-
 	return createdHost, http.StatusCreated, nil
 }
 
@@ -429,6 +457,14 @@ func (hc *HostController) UpdateHost(reqHost hvs.Host) (interface{}, int, error)
 		updatedHost.FlavorgroupNames = reqHost.FlavorgroupNames
 	}
 
+	if reqHost.Labels != nil {
+		defaultLog.Debugf("Associating host %s with flavorgroups selecting its labels", updatedHost.HostName)
+		if _, err := hc.linkLabelSelectedFlavorgroupsToHost(updatedHost); err != nil {
+			defaultLog.WithError(err).Error("controllers/host_controller:UpdateHost() Host FlavorGroup association by label selector failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to associate Host with flavorgroups"}
+		}
+	}
+
 	return updatedHost, http.StatusOK, nil
 }
 
@@ -461,7 +497,8 @@ func GenerateConnectionString(cs, username, password string, hc domain.HostCrede
 	}
 
 	var credential string
-	if vc.Vendor != hcConstants.VendorVMware {
+	// VMware and agentless Linux hosts are accessed with credentials of the host, other hosts with the service credentials
+	if vc.Vendor != hcConstants.VendorVMware && vc.Vendor != hcConstants.VendorLinux {
 		credential = fmt.Sprintf("u=%s;p=%s", username, password)
 		cs = fmt.Sprintf("%s;%s", cs, credential)
 	} else {
@@ -469,11 +506,12 @@ func GenerateConnectionString(cs, username, password string, hc domain.HostCrede
 		if !strings.Contains(cs, "u=") || !strings.Contains(cs, "p=") {
 			var hostname string
 			// If the connection string is for VMware, we would have this substring from which we need to extract
-			// the host name. Otherwise we can extract the host name after the https:// in the connection string.
+			// the host name. Otherwise we can extract the host name from the URL before the first ; in the connection
+			// string, as the options following it such as the SSH host key can contain a :
 			if strings.Contains(cs, "h=") {
 				hostname = vc.Configuration.Hostname
-			} else {
-				hostname = strings.Split(strings.Split(cs, "//")[1], ":")[0]
+			} else if hostUrl, err := url.Parse(vc.Url); err == nil {
+				hostname = hostUrl.Hostname()
 			}
 
 			if hostname == "" {
@@ -553,6 +591,46 @@ func (hc *HostController) linkFlavorgroupsToHost(flavorgroupNames []string, host
 	return nil
 }
 
+// linkLabelSelectedFlavorgroupsToHost links the host to the flavorgroups whose host label selector matches the labels
+// of the host and returns the ids of the newly linked flavorgroups
+func (hc *HostController) linkLabelSelectedFlavorgroupsToHost(h *hvs.Host) ([]uuid.UUID, error) {
+	defaultLog.Trace("controllers/host_controller:linkLabelSelectedFlavorgroupsToHost() Entering")
+	defer defaultLog.Trace("controllers/host_controller:linkLabelSelectedFlavorgroupsToHost() Leaving")
+
+	flavorgroups, err := hc.FGStore.Search(&models.FlavorGroupFilterCriteria{HasHostLabelSelector: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not search flavorgroups with a host label selector")
+	}
+
+	flavorgroupIds := []uuid.UUID{}
+	for _, flavorgroup := range flavorgroups {
+		selector, err := models.ParseLabelSelector(flavorgroup.HostLabelSelector)
+		if err != nil {
+			defaultLog.WithError(err).Warnf("Ignoring invalid host label selector of flavorgroup %s", flavorgroup.Name)
+			continue
+		}
+		if !selector.Matches(h.Labels) {
+			continue
+		}
+		linkExists, err := hc.flavorGroupHostLinkExists(h.Id, flavorgroup.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "Could not check host-flavorgroup link existence")
+		}
+		if !linkExists {
+			flavorgroupIds = append(flavorgroupIds, flavorgroup.ID)
+		}
+	}
+
+	if len(flavorgroupIds) == 0 {
+		return nil, nil
+	}
+	defaultLog.Debugf("Linking host %v with flavorgroups %+q", h.Id, flavorgroupIds)
+	if err := hc.HStore.AddFlavorgroups(h.Id, flavorgroupIds); err != nil {
+		return nil, errors.Wrap(err, "Could not create host-flavorgroup links")
+	}
+	return flavorgroupIds, nil
+}
+
 func (hc *HostController) linkHostUniqueFlavorsToHost(newHost *hvs.Host) error {
 	defaultLog.Trace("controllers/host_controller:linkHostUniqueFlavorsToHost() Entering")
 	defer defaultLog.Trace("controllers/host_controller:linkHostUniqueFlavorsToHost() Leaving")
@@ -667,6 +745,9 @@ func validateHostCreateCriteria(host hvs.HostCreateRequest) error {
 			return errors.Wrap(err, "Valid Flavorgroup Names must be specified")
 		}
 	}
+	if err := models.ValidateLabels(host.Labels); err != nil {
+		return errors.Wrap(err, "Valid Host Labels must be specified")
+	}
 	return nil
 }
 
@@ -720,6 +801,12 @@ func populateHostFilterCriteria(params url.Values) (*models.HostFilterCriteria,
 		criteria.Trusted = &trustStatus
 	}
 
+	labelSelector, err := utils.ParseLabelSelectorQueryParam(params)
+	if err != nil {
+		return nil, err
+	}
+	criteria.LabelSelector = labelSelector
+
 	if params.Get("orderBy") != "" {
 		orderType, err := models.GetOrderType(params.Get("orderBy"))
 		if err != nil {
@@ -728,6 +815,13 @@ func populateHostFilterCriteria(params url.Values) (*models.HostFilterCriteria,
 		criteria.OrderBy = orderType
 	}
 
+	limit, after, err := utils.ParsePageQueryParams(params)
+	if err != nil {
+		return nil, err
+	}
+	criteria.Limit = limit
+	criteria.After = after
+
 	return &criteria, nil
 }
 
@@ -913,6 +1007,126 @@ func (hc *HostController) SearchFlavorgroups(w http.ResponseWriter, r *http.Requ
 	return hostFlavorgroupCollection, http.StatusOK, nil
 }
 
+// RetrieveLabels returns the labels of a host
+func (hc *HostController) RetrieveLabels(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/host_controller:RetrieveLabels() Entering")
+	defer defaultLog.Trace("controllers/host_controller:RetrieveLabels() Leaving")
+
+	hId := uuid.MustParse(mux.Vars(r)["hId"])
+	host, status, err := hc.retrieveHost(hId, nil)
+	if err != nil {
+		return nil, status, err
+	}
+
+	hostLabels := hvs.HostLabels{Labels: host.(*hvs.Host).Labels}
+	if hostLabels.Labels == nil {
+		hostLabels.Labels = map[string]string{}
+	}
+
+	secLog.WithField("host", hId).Infof("%s: Host labels retrieved by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return hostLabels, http.StatusOK, nil
+}
+
+// UpdateLabels replaces the labels of a host and links the host to the flavorgroups selecting the new labels
+func (hc *HostController) UpdateLabels(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/host_controller:UpdateLabels() Entering")
+	defer defaultLog.Trace("controllers/host_controller:UpdateLabels() Leaving")
+
+	if r.Header.Get("Content-Type") != consts.HTTPMediaTypeJson {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/host_controller:UpdateLabels() The request body was not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body was not provided"}
+	}
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	var reqLabels hvs.HostLabels
+	err := dec.Decode(&reqLabels)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/host_controller:UpdateLabels() %s :  Failed to decode request body as HostLabels", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	if err := models.ValidateLabels(reqLabels.Labels); err != nil {
+		secLog.WithError(err).Errorf("controllers/host_controller:UpdateLabels() %s : Invalid host labels", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+	if reqLabels.Labels == nil {
+		reqLabels.Labels = map[string]string{}
+	}
+
+	hId := uuid.MustParse(mux.Vars(r)["hId"])
+	host, status, err := hc.retrieveHost(hId, nil)
+	if err != nil {
+		return nil, status, err
+	}
+	return hc.updateLabels(r, host.(*hvs.Host), reqLabels.Labels)
+}
+
+// DeleteLabel removes a single label from a host
+func (hc *HostController) DeleteLabel(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/host_controller:DeleteLabel() Entering")
+	defer defaultLog.Trace("controllers/host_controller:DeleteLabel() Leaving")
+
+	hId := uuid.MustParse(mux.Vars(r)["hId"])
+	key := mux.Vars(r)["key"]
+	if err := models.ValidateLabelKey(key); err != nil {
+		secLog.WithError(err).Errorf("controllers/host_controller:DeleteLabel() %s : Invalid host label key", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	host, status, err := hc.retrieveHost(hId, nil)
+	if err != nil {
+		return nil, status, err
+	}
+
+	labels := host.(*hvs.Host).Labels
+	if _, exists := labels[key]; !exists {
+		defaultLog.WithField("host", hId).WithField("key", key).Error("controllers/host_controller:DeleteLabel() Host label with specified key could not be located")
+		return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Host label with specified key does not exist"}
+	}
+	delete(labels, key)
+
+	if _, status, err := hc.updateLabels(r, host.(*hvs.Host), labels); err != nil {
+		return nil, status, err
+	}
+	return nil, http.StatusNoContent, nil
+}
+
+func (hc *HostController) updateLabels(r *http.Request, updatedHost *hvs.Host, labels map[string]string) (interface{}, int, error) {
+	defaultLog.Trace("controllers/host_controller:updateLabels() Entering")
+	defer defaultLog.Trace("controllers/host_controller:updateLabels() Leaving")
+
+	hId := updatedHost.Id
+	updatedHost.Labels = labels
+	if err := hc.HStore.Update(updatedHost); err != nil {
+		defaultLog.WithError(err).Error("controllers/host_controller:updateLabels() Host labels update failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to update Host labels"}
+	}
+
+	linkedFlavorgroups, err := hc.linkLabelSelectedFlavorgroupsToHost(updatedHost)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/host_controller:updateLabels() Host FlavorGroup association by label selector failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to associate Host with flavorgroups"}
+	}
+
+	if len(linkedFlavorgroups) > 0 {
+		defaultLog.Debugf("Adding host %v to flavor-verify queue", hId)
+		err = hc.HTManager.VerifyHostsAsync([]uuid.UUID{hId}, false, false)
+		if err != nil {
+			defaultLog.WithError(err).Error("controllers/host_controller:updateLabels() Host to Flavor Verify Queue addition failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to add Host to Flavor Verify Queue"}
+		}
+	}
+
+	secLog.WithField("host", hId).WithField("labels", labels).Infof("%s: Host labels updated by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	return hvs.HostLabels{Labels: labels}, http.StatusOK, nil
+}
+
 func (hc *HostController) retrieveFlavorgroup(hId, fgId uuid.UUID) (interface{}, int, error) {
 	defaultLog.Trace("controllers/host_controller:retrieveFlavorgroup() Entering")
 	defer defaultLog.Trace("controllers/host_controller:retrieveFlavorgroup() Leaving")
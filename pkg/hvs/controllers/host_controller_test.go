@@ -10,6 +10,7 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
 	hvsRoutes "github.com/intel-secl/intel-secl/v3/pkg/hvs/router"
 	smocks "github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust/mocks"
 	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
@@ -17,8 +18,10 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -89,6 +92,62 @@ var _ = Describe("HostController", func() {
 				Expect(w.Code).To(Equal(http.StatusCreated))
 			})
 		})
+		Context("Provide a Create request with labels matching the host label selector of a flavorgroup", func() {
+			It("Should create a new Host linked to the flavorgroup", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Create))).Methods("POST")
+				flavorgroup, err := flavorGroupStore.Create(&hvs.FlavorGroup{
+					Name:              "hvs_flavorgroup_prod",
+					HostLabelSelector: "env=prod,rack in (r3,r4)",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				hostJson := `{
+								"host_name": "localhost3",
+								"connection_string": "intel:https://another.ta.ip.com:1443",
+								"labels": {"env": "prod", "rack": "r3"}
+							}`
+
+				req, err := http.NewRequest(
+					"POST",
+					"/hosts",
+					strings.NewReader(hostJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+
+				var host hvs.Host
+				err = json.Unmarshal(w.Body.Bytes(), &host)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(host.Labels).To(Equal(map[string]string{"env": "prod", "rack": "r3"}))
+				_, err = hostStore.RetrieveFlavorgroup(host.Id, flavorgroup.ID)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		Context("Provide a Create request that contains invalid labels", func() {
+			It("Should fail to create new Host", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Create))).Methods("POST")
+				hostJson := `{
+								"host_name": "localhost3",
+								"connection_string": "intel:https://another.ta.ip.com:1443",
+								"labels": {"env prod": "yes"}
+							}`
+
+				req, err := http.NewRequest(
+					"POST",
+					"/hosts",
+					strings.NewReader(hostJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
 		Context("Provide a Create request that contains duplicate hostname", func() {
 			It("Should fail to create new Host", func() {
 				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Create))).Methods("POST")
@@ -515,6 +574,72 @@ var _ = Describe("HostController", func() {
 				Expect(w.Code).To(Equal(http.StatusBadRequest))
 			})
 		})
+		Context("Get the Hosts one page at a time", func() {
+			It("Should follow the next link until the last page", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods("GET")
+				var hostNames []string
+				link := "/hosts?limit=1"
+				for pages := 0; link != ""; pages++ {
+					Expect(pages).To(BeNumerically("<", 3))
+					req, err := http.NewRequest("GET", link, nil)
+					Expect(err).NotTo(HaveOccurred())
+					req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+					w = httptest.NewRecorder()
+					router.ServeHTTP(w, req)
+					Expect(w.Code).To(Equal(http.StatusOK))
+
+					var hostCollection hvs.HostCollection
+					err = json.Unmarshal(w.Body.Bytes(), &hostCollection)
+					Expect(err).NotTo(HaveOccurred())
+					for _, host := range hostCollection.Hosts {
+						hostNames = append(hostNames, host.HostName)
+					}
+					link = hostCollection.Next
+				}
+				// Verifying mocked data of 2 hosts, the last page being empty
+				Expect(hostNames).To(Equal([]string{"localhost1", "localhost2"}))
+			})
+		})
+		Context("Get the Hosts with an invalid after param", func() {
+			It("Should fail to get Hosts", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/hosts?limit=1&after=invalid", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Get all the Hosts with a labelSelector param", func() {
+			It("Should get list of all the Hosts with matching labels", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/hosts?labelSelector="+url.QueryEscape("rack in (r1,r2),env!=prod"), nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var hostCollection hvs.HostCollection
+				err = json.Unmarshal(w.Body.Bytes(), &hostCollection)
+				Expect(err).NotTo(HaveOccurred())
+				// Verifying mocked data of 1 host labelled with env=dev
+				Expect(len(hostCollection.Hosts)).To(Equal(1))
+				Expect(hostCollection.Hosts[0].HostName).To(Equal("localhost2"))
+			})
+		})
+		Context("Get all the Hosts with an invalid labelSelector param", func() {
+			It("Should fail to get Hosts", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/hosts?labelSelector="+url.QueryEscape("rack in (r1"), nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
 		Context("Get all the Hosts with invalid hostHardwareId param", func() {
 			It("Should fail to get Hosts", func() {
 				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods("GET")
@@ -723,4 +848,88 @@ var _ = Describe("HostController", func() {
 			})
 		})
 	})
+
+	// Specs for HTTP Get, Put and Delete to "/hosts/{hId}/labels"
+	Describe("Manage the labels of a Host", func() {
+		Context("Replace the labels of a Host", func() {
+			It("Should update and retrieve the labels of the Host", func() {
+				router.Handle("/hosts/{hId}/labels", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.UpdateLabels))).Methods("PUT")
+				router.Handle("/hosts/{hId}/labels", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.RetrieveLabels))).Methods("GET")
+				labelsJson := `{"labels": {"env": "staging", "example.com/team": "infra"}}`
+
+				req, err := http.NewRequest("PUT", "/hosts/ee37c360-7eae-4250-a677-6ee12adce8e2/labels", strings.NewReader(labelsJson))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				req, err = http.NewRequest("GET", "/hosts/ee37c360-7eae-4250-a677-6ee12adce8e2/labels", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var hostLabels hvs.HostLabels
+				err = json.Unmarshal(w.Body.Bytes(), &hostLabels)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hostLabels.Labels).To(Equal(map[string]string{"env": "staging", "example.com/team": "infra"}))
+			})
+		})
+		Context("Provide labels with an invalid key", func() {
+			It("Should fail to update the labels of the Host", func() {
+				router.Handle("/hosts/{hId}/labels", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.UpdateLabels))).Methods("PUT")
+				labelsJson := `{"labels": {"-env": "staging"}}`
+
+				req, err := http.NewRequest("PUT", "/hosts/ee37c360-7eae-4250-a677-6ee12adce8e2/labels", strings.NewReader(labelsJson))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Delete a label of a Host", func() {
+			It("Should remove the label from the Host", func() {
+				router.Handle("/hosts/{hId}/labels/{key:.+}", hvsRoutes.ErrorHandler(hvsRoutes.ResponseHandler(hostController.DeleteLabel))).Methods("DELETE")
+				req, err := http.NewRequest("DELETE", "/hosts/ee37c360-7eae-4250-a677-6ee12adce8e2/labels/rack", nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNoContent))
+
+				host, err := hostStore.Retrieve(uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2"), nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(host.Labels).To(Equal(map[string]string{"env": "prod"}))
+			})
+		})
+		Context("Delete a non-existent label of a Host", func() {
+			It("Should fail to delete the label", func() {
+				router.Handle("/hosts/{hId}/labels/{key:.+}", hvsRoutes.ErrorHandler(hvsRoutes.ResponseHandler(hostController.DeleteLabel))).Methods("DELETE")
+				req, err := http.NewRequest("DELETE", "/hosts/ee37c360-7eae-4250-a677-6ee12adce8e2/labels/example.com/team", nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("Generate the connection string of a Host", func() {
+		Context("Provide an agentless Linux connection string with a host key and without a port", func() {
+			It("Should add the credentials stored for the host name", func() {
+				_, err := hostCredentialStore.Create(&models.HostCredential{HostName: "appliance.com", Credential: "u=root;p=password"})
+				Expect(err).NotTo(HaveOccurred())
+
+				cs, credential, err := controllers.GenerateConnectionString("linux:ssh://appliance.com;k=SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8",
+					"", "", hostCredentialStore)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(credential).To(Equal("u=root;p=password"))
+				Expect(cs).To(Equal("linux:ssh://appliance.com;k=SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8;u=root;p=password"))
+			})
+		})
+	})
 })
@@ -30,7 +30,8 @@ type HostStatusController struct {
 }
 
 var hostStatusSearchParams = map[string]bool{"id": true, "hostId": true, "hostHardwareId": true, "hostName": true, "hostStatus": true,
-	"fromDate": true, "toDate": true, "latestPerHost": true, "numberOfDays": true, "limit": true}
+	"fromDate": true, "toDate": true, "latestPerHost": true, "numberOfDays": true, "limit": true, "after": true,
+	"labelSelector": true}
 
 // Search returns a collection of HostStatus based on HostStatusFilter criteria
 func (controller HostStatusController) Search(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
@@ -55,8 +56,15 @@ func (controller HostStatusController) Search(w http.ResponseWriter, r *http.Req
 		return nil, http.StatusInternalServerError, errors.Errorf("Host Status search operation failed")
 	}
 
+	collection := hvs.HostStatusCollection{HostStatuses: hostStatusCollection}
+	if len(hostStatusCollection) > 0 {
+		last := hostStatusCollection[len(hostStatusCollection)-1]
+		collection.Next = utils.NextPageLink(r, filter.Limit, len(hostStatusCollection),
+			models.PageCursor{Key: last.Created.Format(time.RFC3339Nano), Id: last.ID})
+	}
+
 	secLog.Infof("%s: Return Host Status Search query to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
-	return hvs.HostStatusCollection{HostStatuses: hostStatusCollection}, http.StatusOK, nil
+	return collection, http.StatusOK, nil
 }
 
 // Retrieve returns an existing HostStatus entry from the HostStatusStore
@@ -189,17 +197,31 @@ func getHSFilterCriteria(params url.Values) (*models.HostStatusFilterCriteria, e
 		hfc.NumberOfDays = numDays
 	}
 
+	// labelSelector - selects the host statuses of the hosts with matching labels
+	labelSelector, err := utils.ParseLabelSelectorQueryParam(params)
+	if err != nil {
+		return nil, err
+	}
+	hfc.LabelSelector = labelSelector
+
 	// rowLimit - defaults per set limit
-	rowLimit := strings.TrimSpace(params.Get("limit"))
-	if rowLimit != "" {
-		rLimit, err := strconv.Atoi(rowLimit)
-		if err != nil || rLimit <= 0 {
-			return nil, errors.New("Limit must be an integer > 0")
-		}
-		hfc.Limit = rLimit
+	limit, after, err := utils.ParsePageQueryParams(params)
+	if err != nil {
+		return nil, err
+	}
+	if limit != 0 {
+		hfc.Limit = limit
 	} else {
 		hfc.Limit = constants.DefaultSearchResultRowLimit
 	}
 
+	// after - the cursor of a host status holds its created time
+	if after != nil {
+		if _, err := time.Parse(time.RFC3339Nano, after.Key); err != nil {
+			return nil, errors.Wrap(err, "Invalid after query parameter")
+		}
+		hfc.After = after
+	}
+
 	return &hfc, nil
 }
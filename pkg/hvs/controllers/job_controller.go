@@ -0,0 +1,47 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+)
+
+type JobController struct {
+	JStore domain.JobStore
+}
+
+func NewJobController(js domain.JobStore) *JobController {
+	return &JobController{JStore: js}
+}
+
+// Retrieve returns the progress of an asynchronous job
+func (controller JobController) Retrieve(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/job_controller:Retrieve() Entering")
+	defer defaultLog.Trace("controllers/job_controller:Retrieve() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+
+	job, err := controller.JStore.Retrieve(id)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			defaultLog.WithError(err).WithField("id", id).Info(
+				"controllers/job_controller:Retrieve() Job with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Job with given ID does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", id).Error(
+			"controllers/job_controller:Retrieve() Failed to retrieve job")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve job"}
+	}
+
+	secLog.WithField("id", id).Infof("Job retrieved by: %s", r.RemoteAddr)
+	return job, http.StatusOK, nil
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	hvsRoutes "github.com/intel-secl/intel-secl/v3/pkg/hvs/router"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JobController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var jobController *controllers.JobController
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		jobController = controllers.NewJobController(mocks.NewMockJobStore())
+		router.Handle("/jobs/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(jobController.Retrieve))).Methods("GET")
+	})
+
+	// Specs for HTTP Get to "/jobs/{id}"
+	Describe("Retrieve a Job", func() {
+		Context("Retrieve an existing Job", func() {
+			It("Should return the progress of the job", func() {
+				req, err := http.NewRequest("GET", "/jobs/6b4e0b2c-3d09-4c39-8b7e-3e0e9e5a2f61", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var job hvs.Job
+				err = json.Unmarshal(w.Body.Bytes(), &job)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(job.Status).To(Equal(hvs.JobStatusCompleted))
+				Expect(job.Hosts).To(HaveLen(2))
+				Expect(job.Hosts[1].Error).NotTo(BeEmpty())
+			})
+		})
+		Context("Retrieve a non-existent Job", func() {
+			It("Should get HTTP Status: 404", func() {
+				req, err := http.NewRequest("GET", "/jobs/73755fda-c910-46be-821f-e8ddeab189e9", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+})
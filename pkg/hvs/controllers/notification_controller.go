@@ -0,0 +1,409 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+const (
+	// minimum length of the secret used to sign webhook payloads
+	notificationSecretMinLength = 16
+	// interval at which a comment is written on idle event streams so that proxies keep the connection open
+	eventStreamKeepAliveInterval = 15 * time.Second
+)
+
+type NotificationController struct {
+	SubscriptionStore   domain.NotificationSubscriptionStore
+	DeadLetterStore     domain.NotificationDeadLetterStore
+	NotificationManager domain.NotificationManager
+}
+
+func NewNotificationController(ss domain.NotificationSubscriptionStore, dls domain.NotificationDeadLetterStore,
+	nm domain.NotificationManager) *NotificationController {
+	return &NotificationController{
+		SubscriptionStore:   ss,
+		DeadLetterStore:     dls,
+		NotificationManager: nm,
+	}
+}
+
+var notificationSubscriptionSearchParams = map[string]bool{"id": true, "callbackUrl": true}
+var notificationDeadLetterSearchParams = map[string]bool{"id": true, "subscriptionId": true, "limit": true}
+var notificationStreamParams = map[string]bool{"eventType": true, "hostId": true, "flavorPart": true}
+
+func (controller NotificationController) CreateSubscription(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/notification_controller:CreateSubscription() Entering")
+	defer defaultLog.Trace("controllers/notification_controller:CreateSubscription() Leaving")
+
+	if r.Header.Get("Content-Type") != consts.HTTPMediaTypeJson {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/notification_controller:CreateSubscription() The request body is not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	var reqSubscription hvs.NotificationSubscription
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reqSubscription); err != nil {
+		secLog.WithError(err).Errorf("controllers/notification_controller:CreateSubscription() %s :  Failed to decode"+
+			" request body as notification subscription", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	if err := validateNotificationSubscription(&reqSubscription); err != nil {
+		secLog.WithError(err).Errorf("controllers/notification_controller:CreateSubscription() %s Error while validating"+
+			" the notification subscription", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	reqSubscription.ID = uuid.Nil
+	newSubscription, err := controller.SubscriptionStore.Create(&reqSubscription)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/notification_controller:CreateSubscription() Notification subscription creation failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error while creating notification subscription"}
+	}
+	newSubscription.Secret = ""
+
+	secLog.WithField("Callback URL", newSubscription.CallbackURL).Infof("%s: Notification subscription created by: %s",
+		commLogMsg.PrivilegeModified, r.RemoteAddr)
+	return newSubscription, http.StatusCreated, nil
+}
+
+func (controller NotificationController) RetrieveSubscription(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/notification_controller:RetrieveSubscription() Entering")
+	defer defaultLog.Trace("controllers/notification_controller:RetrieveSubscription() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+
+	subscription, err := controller.SubscriptionStore.Retrieve(id)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			defaultLog.WithError(err).WithField("id", id).Info(
+				"controllers/notification_controller:RetrieveSubscription() Notification subscription with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Notification subscription with given ID does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", id).Info(
+			"controllers/notification_controller:RetrieveSubscription() Failed to retrieve notification subscription")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve notification subscription"}
+	}
+	subscription.Secret = ""
+
+	secLog.WithField("id", id).Infof("Notification subscription retrieved by: %s", r.RemoteAddr)
+	return subscription, http.StatusOK, nil
+}
+
+func (controller NotificationController) SearchSubscriptions(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/notification_controller:SearchSubscriptions() Entering")
+	defer defaultLog.Trace("controllers/notification_controller:SearchSubscriptions() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), notificationSubscriptionSearchParams); err != nil {
+		secLog.Errorf("controllers/notification_controller:SearchSubscriptions() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	filter := models.NotificationSubscriptionFilterCriteria{}
+	if id := strings.TrimSpace(r.URL.Query().Get("id")); id != "" {
+		parsedId, err := uuid.Parse(id)
+		if err != nil {
+			secLog.WithError(err).Errorf("controllers/notification_controller:SearchSubscriptions() %s", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid id query param value, must be UUID"}
+		}
+		filter.Id = parsedId
+	}
+	if callbackUrl := strings.TrimSpace(r.URL.Query().Get("callbackUrl")); callbackUrl != "" {
+		if err := validateCallbackURL(callbackUrl); err != nil {
+			secLog.WithError(err).Errorf("controllers/notification_controller:SearchSubscriptions() %s", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid callbackUrl query param value"}
+		}
+		filter.CallbackURL = callbackUrl
+	}
+
+	subscriptions, err := controller.SubscriptionStore.Search(&filter)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/notification_controller:SearchSubscriptions() Notification subscription search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search notification subscriptions"}
+	}
+	for i := range subscriptions {
+		subscriptions[i].Secret = ""
+	}
+
+	secLog.Infof("%s: Return notification subscription query result to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return hvs.NotificationSubscriptionCollection{NotificationSubscriptions: subscriptions}, http.StatusOK, nil
+}
+
+func (controller NotificationController) DeleteSubscription(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/notification_controller:DeleteSubscription() Entering")
+	defer defaultLog.Trace("controllers/notification_controller:DeleteSubscription() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+
+	if _, err := controller.SubscriptionStore.Retrieve(id); err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			defaultLog.WithError(err).WithField("id", id).Info(
+				"controllers/notification_controller:DeleteSubscription() Notification subscription with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Notification subscription with given ID does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", id).Info(
+			"controllers/notification_controller:DeleteSubscription() Attempt to delete invalid notification subscription")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to delete notification subscription"}
+	}
+
+	if err := controller.SubscriptionStore.Delete(id); err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error(
+			"controllers/notification_controller:DeleteSubscription() Failed to delete notification subscription")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to delete notification subscription"}
+	}
+	secLog.WithField("id", id).Infof("Notification subscription deleted by: %s", r.RemoteAddr)
+	return nil, http.StatusNoContent, nil
+}
+
+func (controller NotificationController) SearchDeadLetters(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/notification_controller:SearchDeadLetters() Entering")
+	defer defaultLog.Trace("controllers/notification_controller:SearchDeadLetters() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), notificationDeadLetterSearchParams); err != nil {
+		secLog.Errorf("controllers/notification_controller:SearchDeadLetters() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	filter, err := getNotificationDeadLetterFilterCriteria(r.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/notification_controller:SearchDeadLetters() %s", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	deadLetters, err := controller.DeadLetterStore.Search(filter)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/notification_controller:SearchDeadLetters() Notification dead letter search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search notification dead letters"}
+	}
+
+	secLog.Infof("%s: Return notification dead letter query result to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return hvs.NotificationDeadLetterCollection{NotificationDeadLetters: deadLetters}, http.StatusOK, nil
+}
+
+func (controller NotificationController) DeleteDeadLetter(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/notification_controller:DeleteDeadLetter() Entering")
+	defer defaultLog.Trace("controllers/notification_controller:DeleteDeadLetter() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+
+	deadLetters, err := controller.DeadLetterStore.Search(&models.NotificationDeadLetterFilterCriteria{Id: id})
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error(
+			"controllers/notification_controller:DeleteDeadLetter() Failed to retrieve notification dead letter")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to delete notification dead letter"}
+	}
+	if len(deadLetters) == 0 {
+		defaultLog.WithField("id", id).Info(
+			"controllers/notification_controller:DeleteDeadLetter() Notification dead letter with given ID does not exist")
+		return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Notification dead letter with given ID does not exist"}
+	}
+
+	if err := controller.DeadLetterStore.Delete(id); err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error(
+			"controllers/notification_controller:DeleteDeadLetter() Failed to delete notification dead letter")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to delete notification dead letter"}
+	}
+	secLog.WithField("id", id).Infof("Notification dead letter deleted by: %s", r.RemoteAddr)
+	return nil, http.StatusNoContent, nil
+}
+
+// Stream sends the notification events matching the query filter as Server-Sent Events until the client disconnects
+func (controller NotificationController) Stream(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/notification_controller:Stream() Entering")
+	defer defaultLog.Trace("controllers/notification_controller:Stream() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), notificationStreamParams); err != nil {
+		secLog.Errorf("controllers/notification_controller:Stream() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	filter, err := getNotificationStreamFilter(r.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/notification_controller:Stream() %s", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		defaultLog.Error("controllers/notification_controller:Stream() Response writer does not support streaming")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Streaming is not supported"}
+	}
+
+	events, unsubscribe := controller.NotificationManager.Subscribe(filter)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", consts.HTTPMediaTypeEventStream)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	secLog.Infof("%s: Notification event stream opened by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	keepAlive := time.NewTicker(eventStreamKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			defaultLog.Debugf("controllers/notification_controller:Stream() Event stream closed by: %s", r.RemoteAddr)
+			return nil, http.StatusOK, nil
+		case event, open := <-events:
+			if !open {
+				return nil, http.StatusOK, nil
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				defaultLog.WithError(err).Errorf("controllers/notification_controller:Stream() Failed to marshal event %s", event.ID)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+				defaultLog.WithError(err).Debug("controllers/notification_controller:Stream() Failed to write event, closing stream")
+				return nil, http.StatusOK, nil
+			}
+			flusher.Flush()
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return nil, http.StatusOK, nil
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// validateNotificationSubscription validates the subscription and normalizes its event types and flavor parts to the
+// values used in the published events
+func validateNotificationSubscription(subscription *hvs.NotificationSubscription) error {
+	defaultLog.Trace("controllers/notification_controller:validateNotificationSubscription() Entering")
+	defer defaultLog.Trace("controllers/notification_controller:validateNotificationSubscription() Leaving")
+
+	if err := validateCallbackURL(subscription.CallbackURL); err != nil {
+		return err
+	}
+	if len(subscription.Secret) < notificationSecretMinLength {
+		return errors.Errorf("Secret must be at least %d characters long", notificationSecretMinLength)
+	}
+	for i, eventType := range subscription.EventTypes {
+		et := hvs.NotificationEventType(strings.ToUpper(string(eventType)))
+		if !et.Valid() {
+			return errors.Errorf("Invalid event type %s", eventType)
+		}
+		subscription.EventTypes[i] = et
+	}
+	for i, flavorPart := range subscription.FlavorParts {
+		var fp common.FlavorPart
+		if err := fp.Parse(flavorPart); err != nil {
+			return errors.Errorf("Invalid flavor part %s", flavorPart)
+		}
+		subscription.FlavorParts[i] = fp.String()
+	}
+	return nil
+}
+
+func validateCallbackURL(callbackUrl string) error {
+	parsedUrl, err := url.Parse(callbackUrl)
+	if err != nil || parsedUrl.Host == "" {
+		return errors.New("Valid callback URL must be specified")
+	}
+	if parsedUrl.Scheme != "https" {
+		return errors.New("Callback URL must use https")
+	}
+	return nil
+}
+
+func getNotificationDeadLetterFilterCriteria(params url.Values) (*models.NotificationDeadLetterFilterCriteria, error) {
+	defaultLog.Trace("controllers/notification_controller:getNotificationDeadLetterFilterCriteria() Entering")
+	defer defaultLog.Trace("controllers/notification_controller:getNotificationDeadLetterFilterCriteria() Leaving")
+
+	filter := models.NotificationDeadLetterFilterCriteria{}
+	if id := strings.TrimSpace(params.Get("id")); id != "" {
+		parsedId, err := uuid.Parse(id)
+		if err != nil {
+			return nil, errors.New("Invalid id query param value, must be UUID")
+		}
+		filter.Id = parsedId
+	}
+	if subscriptionId := strings.TrimSpace(params.Get("subscriptionId")); subscriptionId != "" {
+		parsedId, err := uuid.Parse(subscriptionId)
+		if err != nil {
+			return nil, errors.New("Invalid subscriptionId query param value, must be UUID")
+		}
+		filter.SubscriptionId = parsedId
+	}
+	if rowLimit := strings.TrimSpace(params.Get("limit")); rowLimit != "" {
+		limit, err := strconv.Atoi(rowLimit)
+		if err != nil || limit <= 0 {
+			return nil, errors.New("Limit must be an integer > 0")
+		}
+		filter.Limit = limit
+	}
+	return &filter, nil
+}
+
+// getNotificationStreamFilter builds the event filter of an event stream. Every param can be repeated or
+// be a comma separated list of values.
+func getNotificationStreamFilter(params url.Values) (*hvs.NotificationSubscription, error) {
+	defaultLog.Trace("controllers/notification_controller:getNotificationStreamFilter() Entering")
+	defer defaultLog.Trace("controllers/notification_controller:getNotificationStreamFilter() Leaving")
+
+	filter := hvs.NotificationSubscription{}
+	for _, eventType := range splitQueryParam(params["eventType"]) {
+		et := hvs.NotificationEventType(strings.ToUpper(eventType))
+		if !et.Valid() {
+			return nil, errors.New("Invalid eventType query param value")
+		}
+		filter.EventTypes = append(filter.EventTypes, et)
+	}
+	for _, hostId := range splitQueryParam(params["hostId"]) {
+		parsedId, err := uuid.Parse(hostId)
+		if err != nil {
+			return nil, errors.New("Invalid hostId query param value, must be UUID")
+		}
+		filter.HostIDs = append(filter.HostIDs, parsedId)
+	}
+	for _, flavorPart := range splitQueryParam(params["flavorPart"]) {
+		var fp common.FlavorPart
+		if err := fp.Parse(flavorPart); err != nil {
+			return nil, errors.New("Invalid flavorPart query param value")
+		}
+		filter.FlavorParts = append(filter.FlavorParts, fp.String())
+	}
+	return &filter, nil
+}
+
+func splitQueryParam(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				result = append(result, v)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,337 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	hvsRoutes "github.com/intel-secl/intel-secl/v3/pkg/hvs/router"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/notification"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NotificationController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var subscriptionStore *mocks.MockNotificationSubscriptionStore
+	var deadLetterStore *mocks.MockNotificationDeadLetterStore
+	var notificationService *notification.Service
+	var notificationController *controllers.NotificationController
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		subscriptionStore = mocks.NewMockNotificationSubscriptionStore()
+		deadLetterStore = mocks.NewMockNotificationDeadLetterStore()
+		var err error
+		notificationService, err = notification.NewService(notification.NotificationConfig{}, subscriptionStore, deadLetterStore, nil)
+		Expect(err).NotTo(HaveOccurred())
+		notificationController = controllers.NewNotificationController(subscriptionStore, deadLetterStore, notificationService)
+	})
+
+	AfterEach(func() {
+		_ = notificationService.Shutdown()
+	})
+
+	// Specs for HTTP Post to "/notification-subscriptions"
+	Describe("Create notification subscription", func() {
+		Context("Provide a valid subscription", func() {
+			It("Should create the subscription and not return the secret", func() {
+				router.Handle("/notification-subscriptions", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(notificationController.CreateSubscription))).Methods("POST")
+				body := `{"callback_url": "https://soc.example.com/events", "secret": "a-long-enough-secret", "event_types": ["HOST_TRUST_CHANGED"], "flavor_parts": ["PLATFORM"]}`
+				req, err := http.NewRequest("POST", "/notification-subscriptions", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+
+				var subscription hvs.NotificationSubscription
+				err = json.Unmarshal(w.Body.Bytes(), &subscription)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(subscription.ID).NotTo(Equal(uuid.Nil))
+				Expect(subscription.Secret).To(BeEmpty())
+
+				stored, err := subscriptionStore.Retrieve(subscription.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stored.Secret).To(Equal("a-long-enough-secret"))
+			})
+		})
+		Context("Provide a subscription with lower case event types and flavor parts", func() {
+			It("Should store the event types and flavor parts as they are published", func() {
+				router.Handle("/notification-subscriptions", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(notificationController.CreateSubscription))).Methods("POST")
+				body := `{"callback_url": "https://soc.example.com/events", "secret": "a-long-enough-secret", "event_types": ["flavor_part_trust_changed"], "flavor_parts": ["platform"]}`
+				req, err := http.NewRequest("POST", "/notification-subscriptions", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+
+				var subscription hvs.NotificationSubscription
+				err = json.Unmarshal(w.Body.Bytes(), &subscription)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(subscription.EventTypes).To(Equal([]hvs.NotificationEventType{hvs.EventFlavorPartTrustChanged}))
+				Expect(subscription.FlavorParts).To(Equal([]string{"PLATFORM"}))
+				Expect(subscription.Matches(&hvs.NotificationEvent{Type: hvs.EventFlavorPartTrustChanged, FlavorPart: "PLATFORM"})).To(BeTrue())
+			})
+		})
+		Context("Provide a subscription with a plain http callback URL", func() {
+			It("Should get HTTP Status: 400", func() {
+				router.Handle("/notification-subscriptions", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(notificationController.CreateSubscription))).Methods("POST")
+				body := `{"callback_url": "http://soc.example.com/events", "secret": "a-long-enough-secret"}`
+				req, err := http.NewRequest("POST", "/notification-subscriptions", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide a subscription with a short secret", func() {
+			It("Should get HTTP Status: 400", func() {
+				router.Handle("/notification-subscriptions", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(notificationController.CreateSubscription))).Methods("POST")
+				body := `{"callback_url": "https://soc.example.com/events", "secret": "short"}`
+				req, err := http.NewRequest("POST", "/notification-subscriptions", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide a subscription with an invalid event type", func() {
+			It("Should get HTTP Status: 400", func() {
+				router.Handle("/notification-subscriptions", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(notificationController.CreateSubscription))).Methods("POST")
+				body := `{"callback_url": "https://soc.example.com/events", "secret": "a-long-enough-secret", "event_types": ["HOST_DELETED"]}`
+				req, err := http.NewRequest("POST", "/notification-subscriptions", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/notification-subscriptions"
+	Describe("Search notification subscriptions", func() {
+		Context("Search without filter criteria", func() {
+			It("Should return all subscriptions without secrets", func() {
+				router.Handle("/notification-subscriptions", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(notificationController.SearchSubscriptions))).Methods("GET")
+				req, err := http.NewRequest("GET", "/notification-subscriptions", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var collection hvs.NotificationSubscriptionCollection
+				err = json.Unmarshal(w.Body.Bytes(), &collection)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(collection.NotificationSubscriptions)).To(Equal(2))
+				for _, subscription := range collection.NotificationSubscriptions {
+					Expect(subscription.Secret).To(BeEmpty())
+				}
+			})
+		})
+		Context("Search by callback URL", func() {
+			It("Should return the matching subscription", func() {
+				router.Handle("/notification-subscriptions", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(notificationController.SearchSubscriptions))).Methods("GET")
+				req, err := http.NewRequest("GET", "/notification-subscriptions?callbackUrl=https://orchestrator.example.com/trust", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var collection hvs.NotificationSubscriptionCollection
+				err = json.Unmarshal(w.Body.Bytes(), &collection)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(collection.NotificationSubscriptions)).To(Equal(1))
+			})
+		})
+		Context("Search with an invalid query param", func() {
+			It("Should get HTTP Status: 400", func() {
+				router.Handle("/notification-subscriptions", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(notificationController.SearchSubscriptions))).Methods("GET")
+				req, err := http.NewRequest("GET", "/notification-subscriptions?secret=abc", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Get/Delete to "/notification-subscriptions/{id}"
+	Describe("Retrieve and delete notification subscription", func() {
+		Context("Retrieve an existing subscription", func() {
+			It("Should return the subscription", func() {
+				router.Handle("/notification-subscriptions/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(notificationController.RetrieveSubscription))).Methods("GET")
+				req, err := http.NewRequest("GET", "/notification-subscriptions/3c1a0b5e-4d6f-4a1e-9d2b-8f1e2c3d4a5b", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+			})
+		})
+		Context("Retrieve a non-existent subscription", func() {
+			It("Should get HTTP Status: 404", func() {
+				router.Handle("/notification-subscriptions/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(notificationController.RetrieveSubscription))).Methods("GET")
+				req, err := http.NewRequest("GET", "/notification-subscriptions/73755fda-c910-46be-821f-e8ddeab189e9", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+		Context("Delete an existing subscription", func() {
+			It("Should get HTTP Status: 204", func() {
+				router.Handle("/notification-subscriptions/{id}", hvsRoutes.ErrorHandler(hvsRoutes.ResponseHandler(notificationController.DeleteSubscription))).Methods("DELETE")
+				req, err := http.NewRequest("DELETE", "/notification-subscriptions/7e2b1c4d-5f6a-4b8c-9d0e-1f2a3b4c5d6e", nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNoContent))
+			})
+		})
+		Context("Delete a non-existent subscription", func() {
+			It("Should get HTTP Status: 404", func() {
+				router.Handle("/notification-subscriptions/{id}", hvsRoutes.ErrorHandler(hvsRoutes.ResponseHandler(notificationController.DeleteSubscription))).Methods("DELETE")
+				req, err := http.NewRequest("DELETE", "/notification-subscriptions/73755fda-c910-46be-821f-e8ddeab189e9", nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	// Specs for HTTP Get/Delete to "/notification-dead-letters"
+	Describe("Search and delete notification dead letters", func() {
+		Context("Search by subscription ID", func() {
+			It("Should return the undelivered events of the subscription", func() {
+				router.Handle("/notification-dead-letters", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(notificationController.SearchDeadLetters))).Methods("GET")
+				req, err := http.NewRequest("GET", "/notification-dead-letters?subscriptionId=3c1a0b5e-4d6f-4a1e-9d2b-8f1e2c3d4a5b", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var collection hvs.NotificationDeadLetterCollection
+				err = json.Unmarshal(w.Body.Bytes(), &collection)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(collection.NotificationDeadLetters)).To(Equal(1))
+				Expect(collection.NotificationDeadLetters[0].Attempts).To(Equal(5))
+			})
+		})
+		Context("Search with an invalid limit", func() {
+			It("Should get HTTP Status: 400", func() {
+				router.Handle("/notification-dead-letters", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(notificationController.SearchDeadLetters))).Methods("GET")
+				req, err := http.NewRequest("GET", "/notification-dead-letters?limit=-1", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Delete an existing dead letter", func() {
+			It("Should get HTTP Status: 204", func() {
+				router.Handle("/notification-dead-letters/{id}", hvsRoutes.ErrorHandler(hvsRoutes.ResponseHandler(notificationController.DeleteDeadLetter))).Methods("DELETE")
+				req, err := http.NewRequest("DELETE", "/notification-dead-letters/9a8b7c6d-5e4f-4a3b-8c1d-0e9f8a7b6c5d", nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNoContent))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/notifications/stream"
+	Describe("Stream notification events", func() {
+		Context("Request the stream with an invalid Accept type", func() {
+			It("Should get HTTP Status: 415", func() {
+				router.Handle("/notifications/stream", hvsRoutes.ErrorHandler(hvsRoutes.EventStreamResponseHandler(notificationController.Stream))).Methods("GET")
+				req, err := http.NewRequest("GET", "/notifications/stream", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusUnsupportedMediaType))
+			})
+		})
+		Context("Request the stream with an invalid event type filter", func() {
+			It("Should get HTTP Status: 400", func() {
+				router.Handle("/notifications/stream", hvsRoutes.ErrorHandler(hvsRoutes.EventStreamResponseHandler(notificationController.Stream))).Methods("GET")
+				req, err := http.NewRequest("GET", "/notifications/stream?eventType=HOST_DELETED", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeEventStream)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Subscribe to host trust changes of a host", func() {
+			It("Should receive only the matching events", func() {
+				router.Handle("/notifications/stream", hvsRoutes.ErrorHandler(hvsRoutes.EventStreamResponseHandler(notificationController.Stream))).Methods("GET")
+				server := httptest.NewServer(router)
+				defer server.Close()
+
+				hostId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+				req, err := http.NewRequest("GET", server.URL+"/notifications/stream?eventType=HOST_TRUST_CHANGED&hostId="+hostId.String(), nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeEventStream)
+				resp, err := http.DefaultClient.Do(req)
+				Expect(err).NotTo(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+				Expect(resp.Header.Get("Content-Type")).To(Equal(consts.HTTPMediaTypeEventStream))
+
+				trusted := true
+				notificationService.Publish(&hvs.NotificationEvent{Type: hvs.EventHostStateChanged, HostID: hostId, HostState: "CONNECTED"})
+				notificationService.Publish(&hvs.NotificationEvent{Type: hvs.EventHostTrustChanged, HostID: uuid.New(), Trusted: &trusted})
+				notificationService.Publish(&hvs.NotificationEvent{Type: hvs.EventHostTrustChanged, HostID: hostId, Trusted: &trusted})
+
+				reader := bufio.NewReader(resp.Body)
+				var lines []string
+				for len(lines) < 3 {
+					line, err := reader.ReadString('\n')
+					Expect(err).NotTo(HaveOccurred())
+					lines = append(lines, strings.TrimSpace(line))
+				}
+				Expect(lines[0]).To(HavePrefix("id: "))
+				Expect(lines[1]).To(Equal("event: HOST_TRUST_CHANGED"))
+				Expect(lines[2]).To(HavePrefix("data: "))
+
+				var event hvs.NotificationEvent
+				err = json.Unmarshal([]byte(strings.TrimPrefix(lines[2], "data: ")), &event)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(event.HostID).To(Equal(hostId))
+				Expect(*event.Trusted).To(BeTrue())
+			})
+		})
+	})
+})
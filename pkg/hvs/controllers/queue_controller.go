@@ -0,0 +1,166 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models/taskstage"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// QueueController contains logic for inspecting and cancelling the host trust verifications queued in HVS
+type QueueController struct {
+	QStore    domain.QueueStore
+	HTManager domain.HostTrustManager
+}
+
+func NewQueueController(qs domain.QueueStore, htm domain.HostTrustManager) *QueueController {
+	return &QueueController{QStore: qs, HTManager: htm}
+}
+
+var queueSearchParams = map[string]bool{"stage": true, "hostId": true, "olderThan": true, "priority": true, "limit": true}
+
+// Search returns the queued verifications matching the filter criteria, the high priority ones first
+func (controller QueueController) Search(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/queue_controller:Search() Entering")
+	defer defaultLog.Trace("controllers/queue_controller:Search() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), queueSearchParams); err != nil {
+		secLog.Errorf("controllers/queue_controller:Search() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	filter, err := getQueueFilterCriteria(r.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Warnf("controllers/queue_controller:Search() %s", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	records, err := controller.QStore.Search(filter)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/queue_controller:Search() Queue search operation failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Queue search operation failed"}
+	}
+
+	collection := hvs.VerificationQueueEntryCollection{QueueEntries: []hvs.VerificationQueueEntry{}}
+	for _, record := range records {
+		entry, err := toVerificationQueueEntry(record)
+		if err != nil {
+			defaultLog.WithError(err).Warnf("controllers/queue_controller:Search() Skipping invalid queue record %s", record.Id)
+			continue
+		}
+		collection.QueueEntries = append(collection.QueueEntries, *entry)
+	}
+
+	secLog.Infof("%s: Return Queue Search query to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return collection, http.StatusOK, nil
+}
+
+// Delete cancels a queued verification
+func (controller QueueController) Delete(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/queue_controller:Delete() Entering")
+	defer defaultLog.Trace("controllers/queue_controller:Delete() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+
+	if err := controller.HTManager.CancelVerification(id); err != nil {
+		switch errors.Cause(err) {
+		case domain.ErrQueueEntryNotFound:
+			defaultLog.WithField("id", id).Info("controllers/queue_controller:Delete() Queue entry with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Queue entry with given ID does not exist"}
+		case domain.ErrVerificationStarted:
+			defaultLog.WithField("id", id).Info("controllers/queue_controller:Delete() Verification has already started")
+			return nil, http.StatusConflict, &commErr.ResourceError{Message: "Verification has already started and cannot be cancelled"}
+		}
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/queue_controller:Delete() Failed to cancel verification")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to cancel verification"}
+	}
+
+	secLog.WithField("id", id).Infof("Queued verification cancelled by: %s", r.RemoteAddr)
+	return nil, http.StatusNoContent, nil
+}
+
+// getQueueFilterCriteria checks for set filter params in the Search request and returns a valid QueueFilterCriteria
+func getQueueFilterCriteria(params url.Values) (*models.QueueFilterCriteria, error) {
+	defaultLog.Trace("controllers/queue_controller:getQueueFilterCriteria() Entering")
+	defer defaultLog.Trace("controllers/queue_controller:getQueueFilterCriteria() Leaving")
+
+	qfc := models.QueueFilterCriteria{Limit: constants.DefaultSearchResultRowLimit}
+
+	if stageName := strings.TrimSpace(params.Get("stage")); stageName != "" {
+		stage, ok := taskstage.Parse(stageName)
+		if !ok {
+			return nil, errors.New("Invalid stage specified")
+		}
+		qfc.Stages = []taskstage.Stage{stage}
+	}
+
+	if hostId := strings.TrimSpace(params.Get("hostId")); hostId != "" {
+		id, err := uuid.Parse(hostId)
+		if err != nil {
+			return nil, errors.New("Invalid UUID format of the Host Identifier specified")
+		}
+		qfc.HostId = id
+	}
+
+	// olderThan selects the entries queued for longer than the duration, for instance 15m or 2h
+	if olderThan := strings.TrimSpace(params.Get("olderThan")); olderThan != "" {
+		age, err := time.ParseDuration(olderThan)
+		if err != nil || age < 0 {
+			return nil, errors.New("Invalid olderThan duration specified")
+		}
+		qfc.CreatedBefore = time.Now().Add(-age)
+	}
+
+	if priority := strings.TrimSpace(params.Get("priority")); priority != "" {
+		p, ok := models.ParseQueuePriority(priority)
+		if !ok {
+			return nil, errors.New("Invalid priority specified, must be HIGH or LOW")
+		}
+		qfc.Priority = p
+	}
+
+	if limit := strings.TrimSpace(params.Get("limit")); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l < 1 || l > constants.DefaultSearchResultRowLimit {
+			return nil, errors.Errorf("limit must be an integer between 1 and %d", constants.DefaultSearchResultRowLimit)
+		}
+		qfc.Limit = l
+	}
+
+	return &qfc, nil
+}
+
+func toVerificationQueueEntry(record *models.Queue) (*hvs.VerificationQueueEntry, error) {
+	hostId, err := record.UUIDParam("host_id")
+	if err != nil {
+		return nil, err
+	}
+	return &hvs.VerificationQueueEntry{
+		Id:              record.Id,
+		HostId:          hostId,
+		Stage:           record.Stage.String(),
+		Priority:        record.Priority.String(),
+		FetchHostData:   record.BoolParam("fetch_host_data"),
+		PreferHashMatch: record.BoolParam("prefer_hash_match"),
+		Created:         record.Created,
+		Updated:         record.Updated,
+	}, nil
+}
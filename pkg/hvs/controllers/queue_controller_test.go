@@ -0,0 +1,171 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models/taskstage"
+	hvsRoutes "github.com/intel-secl/intel-secl/v3/pkg/hvs/router"
+	smocks "github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust/mocks"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// queueHostTrustManager cancels the verifications of the records of a queue store, except the started one
+type queueHostTrustManager struct {
+	smocks.MockHostTrustManager
+	qStore  domain.QueueStore
+	started uuid.UUID
+}
+
+func (htm *queueHostTrustManager) CancelVerification(queueId uuid.UUID) error {
+	if queueId == htm.started {
+		return domain.ErrVerificationStarted
+	}
+	if _, err := htm.qStore.Retrieve(queueId); err != nil {
+		return domain.ErrQueueEntryNotFound
+	}
+	return htm.qStore.Delete(queueId)
+}
+
+var _ = Describe("QueueController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var queueStore domain.QueueStore
+	var queueController *controllers.QueueController
+	var refreshHostId, requestedHostId uuid.UUID
+	var refresh, requested *models.Queue
+	BeforeEach(func() {
+		var err error
+		router = mux.NewRouter()
+		queueStore = mocks.NewQueueStore()
+
+		refreshHostId = uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+		refresh, err = queueStore.Create(&models.Queue{Action: "flavor-verify",
+			Params:   map[string]interface{}{"host_id": refreshHostId.String(), "fetch_host_data": true, "prefer_hash_match": true},
+			State:    models.QueueStatePending,
+			Priority: models.QueuePriorityLow,
+			Stage:    taskstage.GetHostDataStarted,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		requestedHostId = uuid.MustParse("e57e5ea0-d465-461e-882d-1600090caa0d")
+		requested, err = queueStore.Create(&models.Queue{Action: "flavor-verify",
+			Params:   map[string]interface{}{"host_id": requestedHostId.String(), "fetch_host_data": false, "prefer_hash_match": false},
+			State:    models.QueueStatePending,
+			Priority: models.QueuePriorityHigh,
+			Stage:    taskstage.FlavorVerifyStarted,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		queueController = controllers.NewQueueController(queueStore,
+			&queueHostTrustManager{qStore: queueStore, started: requested.Id})
+		router.Handle("/queue", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(queueController.Search))).Methods("GET")
+		router.Handle("/queue/{id}", hvsRoutes.ErrorHandler(hvsRoutes.ResponseHandler(queueController.Delete))).Methods("DELETE")
+	})
+
+	search := func(query string) (int, *hvs.VerificationQueueEntryCollection) {
+		req, err := http.NewRequest("GET", "/queue"+query, nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			return w.Code, nil
+		}
+		var collection hvs.VerificationQueueEntryCollection
+		Expect(json.Unmarshal(w.Body.Bytes(), &collection)).To(Succeed())
+		return w.Code, &collection
+	}
+
+	// Specs for HTTP Get to "/queue"
+	Describe("Search the verification queue", func() {
+		Context("Search without filter criteria", func() {
+			It("Should return all the queued verifications, the high priority ones first", func() {
+				code, collection := search("")
+				Expect(code).To(Equal(http.StatusOK))
+				Expect(collection.QueueEntries).To(HaveLen(2))
+				Expect(collection.QueueEntries[0].Id).To(Equal(requested.Id))
+				Expect(collection.QueueEntries[0].Stage).To(Equal("FLAVOR_VERIFY_STARTED"))
+				Expect(collection.QueueEntries[0].Priority).To(Equal("HIGH"))
+				Expect(collection.QueueEntries[1].HostId).To(Equal(refreshHostId))
+				Expect(collection.QueueEntries[1].Priority).To(Equal("LOW"))
+				Expect(collection.QueueEntries[1].FetchHostData).To(BeTrue())
+			})
+		})
+		Context("Search by state, host and priority", func() {
+			It("Should return the matching queued verifications", func() {
+				code, collection := search("?stage=GET_HOST_DATA_STARTED")
+				Expect(code).To(Equal(http.StatusOK))
+				Expect(collection.QueueEntries).To(HaveLen(1))
+				Expect(collection.QueueEntries[0].Id).To(Equal(refresh.Id))
+
+				code, collection = search("?hostId=" + requestedHostId.String() + "&priority=low")
+				Expect(code).To(Equal(http.StatusOK))
+				Expect(collection.QueueEntries).To(BeEmpty())
+			})
+		})
+		Context("Search by age", func() {
+			It("Should only return the verifications queued for longer than the duration", func() {
+				code, collection := search("?olderThan=1h")
+				Expect(code).To(Equal(http.StatusOK))
+				Expect(collection.QueueEntries).To(BeEmpty())
+
+				time.Sleep(10 * time.Millisecond)
+				code, collection = search("?olderThan=5ms")
+				Expect(code).To(Equal(http.StatusOK))
+				Expect(collection.QueueEntries).To(HaveLen(2))
+			})
+		})
+		Context("Search with invalid filter criteria", func() {
+			It("Should get HTTP Status: 400", func() {
+				for _, query := range []string{"?stage=DONE", "?state=GET_HOST_DATA_STARTED", "?olderThan=yesterday", "?priority=urgent", "?limit=0", "?name=queue"} {
+					code, _ := search(query)
+					Expect(code).To(Equal(http.StatusBadRequest), query)
+				}
+			})
+		})
+	})
+
+	// Specs for HTTP Delete to "/queue/{id}"
+	Describe("Cancel a queued verification", func() {
+		deleteEntry := func(id uuid.UUID) int {
+			req, err := http.NewRequest("DELETE", "/queue/"+id.String(), nil)
+			Expect(err).NotTo(HaveOccurred())
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w.Code
+		}
+		Context("Cancel a queued verification", func() {
+			It("Should remove it from the queue", func() {
+				Expect(deleteEntry(refresh.Id)).To(Equal(http.StatusNoContent))
+				_, collection := search("")
+				Expect(collection.QueueEntries).To(HaveLen(1))
+			})
+		})
+		Context("Cancel a started verification", func() {
+			It("Should get HTTP Status: 409", func() {
+				Expect(deleteEntry(requested.Id)).To(Equal(http.StatusConflict))
+			})
+		})
+		Context("Cancel a non-existent queue entry", func() {
+			It("Should get HTTP Status: 404", func() {
+				Expect(deleteEntry(uuid.MustParse("73755fda-c910-46be-821f-e8ddeab189e9"))).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+})
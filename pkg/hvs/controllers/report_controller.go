@@ -30,10 +30,12 @@ type ReportController struct {
 	HostStore       domain.HostStore
 	HostStatusStore domain.HostStatusStore
 	HTManager       domain.HostTrustManager
+	// CertStore is used to sign the compliance reports with the SAML signing key
+	CertStore *models.CertificatesStore
 }
 
 func NewReportController(rs domain.ReportStore, hs domain.HostStore, hsts domain.HostStatusStore, ht domain.HostTrustManager) *ReportController {
-	return &ReportController{rs, hs, hsts, ht}
+	return &ReportController{ReportStore: rs, HostStore: hs, HostStatusStore: hsts, HTManager: ht}
 }
 
 func (controller ReportController) Create(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
@@ -181,6 +183,130 @@ func (controller ReportController) Retrieve(w http.ResponseWriter, r *http.Reque
 	return report, http.StatusOK, nil
 }
 
+// reportDiffParams lists the query parameters accepted by the report diff API
+var reportDiffParams = map[string]bool{"against": true}
+
+const reportDiffAgainstPrevious = "previous"
+
+func (controller ReportController) Diff(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/report_controller:Diff() Entering")
+	defer defaultLog.Trace("controllers/report_controller:Diff() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), reportDiffParams); err != nil {
+		secLog.Errorf("controllers/report_controller:Diff() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	against := strings.TrimSpace(r.URL.Query().Get("against"))
+	if against == "" {
+		against = reportDiffAgainstPrevious
+	}
+	var againstId uuid.UUID
+	if against != reportDiffAgainstPrevious {
+		var err error
+		againstId, err = uuid.Parse(against)
+		if err != nil {
+			secLog.WithError(err).Errorf("controllers/report_controller:Diff() %s : Invalid against parameter %s", commLogMsg.InvalidInputBadParam, against)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid against parameter, must be a report ID or 'previous'"}
+		}
+	}
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	hvsReport, err := controller.retrieveReport(id)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			secLog.WithError(err).WithField("id", id).Info(
+				"controllers/report_controller:Diff() Report with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Report with given ID does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", id).Error(
+			"controllers/report_controller:Diff() Failed to retrieve Report")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Report"}
+	}
+
+	var againstReport *models.HVSReport
+	if against == reportDiffAgainstPrevious {
+		againstReport, err = controller.retrievePreviousReport(hvsReport)
+	} else {
+		againstReport, err = controller.retrieveReport(againstId)
+	}
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			secLog.WithError(err).WithField("against", against).Info(
+				"controllers/report_controller:Diff() Report to compare against does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Report to compare against does not exist"}
+		}
+		defaultLog.WithError(err).WithField("against", against).Error(
+			"controllers/report_controller:Diff() Failed to retrieve Report to compare against")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Report to compare against"}
+	}
+	if againstReport.HostID != hvsReport.HostID {
+		secLog.WithField("against", against).Errorf("controllers/report_controller:Diff() %s : Reports belong to different hosts", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Reports to compare must belong to the same host"}
+	}
+
+	reportDiff := utils.DiffReports(hvsReport, againstReport)
+	secLog.WithField("id", id).Infof("%s: Report diff retrieved by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return reportDiff, http.StatusOK, nil
+}
+
+// retrieveReport looks up a report by ID, reports that were superseded by a newer report of the
+// same host are retrieved from the report history
+func (controller ReportController) retrieveReport(id uuid.UUID) (*models.HVSReport, error) {
+	defaultLog.Trace("controllers/report_controller:retrieveReport() Entering")
+	defer defaultLog.Trace("controllers/report_controller:retrieveReport() Leaving")
+
+	hvsReport, err := controller.ReportStore.Retrieve(id)
+	if err == nil {
+		return hvsReport, nil
+	}
+	if !strings.Contains(err.Error(), commErr.RowsNotFound) {
+		return nil, errors.Wrap(err, "controllers/report_controller:retrieveReport() Error while retrieving report")
+	}
+
+	hvsReports, err := controller.ReportStore.Search(&models.ReportFilterCriteria{
+		ID:    id,
+		Limit: 1,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "controllers/report_controller:retrieveReport() Error while searching report history")
+	}
+	if len(hvsReports) == 0 {
+		return nil, errors.New(commErr.RowsNotFound)
+	}
+	return &hvsReports[0], nil
+}
+
+// retrievePreviousReport returns the latest report of the host created before the given report
+func (controller ReportController) retrievePreviousReport(hvsReport *models.HVSReport) (*models.HVSReport, error) {
+	defaultLog.Trace("controllers/report_controller:retrievePreviousReport() Entering")
+	defer defaultLog.Trace("controllers/report_controller:retrievePreviousReport() Leaving")
+
+	hvsReports, err := controller.ReportStore.Search(&models.ReportFilterCriteria{
+		HostID:        hvsReport.HostID,
+		ToDate:        hvsReport.CreatedAt,
+		LatestPerHost: true,
+		Limit:         consts.DefaultSearchResultRowLimit,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "controllers/report_controller:retrievePreviousReport() Error while searching report history")
+	}
+
+	var previous *models.HVSReport
+	for i := range hvsReports {
+		if hvsReports[i].ID == hvsReport.ID || !hvsReports[i].CreatedAt.Before(hvsReport.CreatedAt) {
+			continue
+		}
+		if previous == nil || hvsReports[i].CreatedAt.After(previous.CreatedAt) {
+			previous = &hvsReports[i]
+		}
+	}
+	if previous == nil {
+		return nil, errors.New(commErr.RowsNotFound)
+	}
+	return previous, nil
+}
+
 func (controller ReportController) Search(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
 	defaultLog.Trace("controllers/report_controller:Search() Entering")
 	defer defaultLog.Trace("controllers/report_controller:Search() Leaving")
@@ -349,6 +475,12 @@ func getReportFilterCriteria(params url.Values) (*models.ReportFilterCriteria, e
 		rfc.NumberOfDays = numDays
 	}
 
+	labelSelector, err := utils.ParseLabelSelectorQueryParam(params)
+	if err != nil {
+		return nil, err
+	}
+	rfc.LabelSelector = labelSelector
+
 	rowLimit := strings.TrimSpace(params.Get("limit"))
 	if rowLimit != "" {
 		rLimit, err := strconv.Atoi(rowLimit)
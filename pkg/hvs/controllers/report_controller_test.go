@@ -5,20 +5,28 @@
 package controllers_test
 
 import (
+	"crypto/x509"
+	"encoding/csv"
 	"encoding/json"
 	"encoding/xml"
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
 	hvsRoutes "github.com/intel-secl/intel-secl/v3/pkg/hvs/router"
 	smocks "github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust/mocks"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"time"
 )
 
 var _ = Describe("ReportController", func() {
@@ -174,6 +182,111 @@ var _ = Describe("ReportController", func() {
 		})
 	})
 
+	// Specs for HTTP Get to "/reports/{rId}/diff"
+	Describe("Diff an existing Report", func() {
+		var olderReportId = uuid.MustParse("15701f03-7b1d-49f9-ac62-6b9b0728bdb2")
+
+		BeforeEach(func() {
+			report, err := reportStore.Retrieve(uuid.MustParse("15701f03-7b1d-49f9-ac62-6b9b0728bdb3"))
+			Expect(err).NotTo(HaveOccurred())
+
+			// copy the trust report so the report in the store is not modified
+			trustReportBytes, err := json.Marshal(report.TrustReport)
+			Expect(err).NotTo(HaveOccurred())
+			var trustReport hvs.TrustReport
+			Expect(json.Unmarshal(trustReportBytes, &trustReport)).To(Succeed())
+			trustReport.HostManifest.HostInfo.BiosVersion = "SE5C620.86B.00.01.0014.070920180847"
+			trustReport.HostManifest.PcrManifest.Sha1Pcrs[0].Value = "3f8e2d1a9c7b6e5d4c3b2a1f0e9d8c7b6a5f4e3d"
+
+			_, err = reportStore.Create(&models.HVSReport{
+				ID:          olderReportId,
+				HostID:      report.HostID,
+				CreatedAt:   report.CreatedAt.Add(-time.Hour),
+				Expiration:  report.Expiration.Add(-time.Hour),
+				TrustReport: trustReport,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			router.Handle("/reports/{id}/diff", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(reportController.Diff))).Methods("GET")
+		})
+
+		Context("Diff Report against the previous Report of the host", func() {
+			It("Should return the differences between the Reports", func() {
+				req, err := http.NewRequest("GET", "/reports/15701f03-7b1d-49f9-ac62-6b9b0728bdb3/diff?against=previous", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var reportDiff hvs.ReportDiff
+				err = json.Unmarshal(w.Body.Bytes(), &reportDiff)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reportDiff.AgainstReportID).To(Equal(olderReportId))
+				Expect(reportDiff.RuleResults).To(BeEmpty())
+				Expect(reportDiff.EventLogs).To(BeEmpty())
+				Expect(reportDiff.PcrValues).To(HaveLen(1))
+				Expect(reportDiff.PcrValues[0].Change).To(Equal(hvs.DiffChanged))
+				Expect(reportDiff.PcrValues[0].PcrBank).To(Equal(types.SHA1))
+				Expect(reportDiff.PcrValues[0].PcrIndex).To(Equal(types.PCR0))
+				Expect(reportDiff.HostManifest).To(HaveLen(1))
+				Expect(reportDiff.HostManifest[0].Field).To(Equal("host_info.bios_version"))
+				Expect(reportDiff.HostManifest[0].AgainstValue).To(Equal("SE5C620.86B.00.01.0014.070920180847"))
+			})
+		})
+
+		Context("Diff Report against a given Report of the host", func() {
+			It("Should return the differences between the Reports", func() {
+				req, err := http.NewRequest("GET", "/reports/"+olderReportId.String()+"/diff?against=15701f03-7b1d-49f9-ac62-6b9b0728bdb3", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var reportDiff hvs.ReportDiff
+				err = json.Unmarshal(w.Body.Bytes(), &reportDiff)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reportDiff.ReportID).To(Equal(olderReportId))
+				Expect(reportDiff.PcrValues).To(HaveLen(1))
+				Expect(reportDiff.PcrValues[0].Value).To(Equal("3f8e2d1a9c7b6e5d4c3b2a1f0e9d8c7b6a5f4e3d"))
+				Expect(reportDiff.HostManifest).To(HaveLen(1))
+			})
+		})
+
+		Context("Diff Report against an invalid Report ID", func() {
+			It("Should respond with bad request", func() {
+				req, err := http.NewRequest("GET", "/reports/15701f03-7b1d-49f9-ac62-6b9b0728bdb3/diff?against=latest", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Diff Report against a Report of another host", func() {
+			It("Should respond with bad request", func() {
+				req, err := http.NewRequest("GET", "/reports/15701f03-7b1d-49f9-ac62-6b9b0728bdb3/diff?against=15701f03-7b1d-49f9-ac62-6b9b0728bdb4", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Diff Report of a host without a previous Report", func() {
+			It("Should fail to find the Report to compare against", func() {
+				req, err := http.NewRequest("GET", "/reports/15701f03-7b1d-49f9-ac62-6b9b0728bdb4/diff", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
 	// Specs for HTTP Get to "/reports"
 	Describe("Search for all the Reports", func() {
 		Context("Get all the Reports", func() {
@@ -347,4 +460,181 @@ var _ = Describe("ReportController", func() {
 			})
 		})
 	})
+
+	// Specs for HTTP Get to "/reports/export"
+	Describe("Export Reports", func() {
+		BeforeEach(func() {
+			router.Handle("/reports/export", hvsRoutes.ErrorHandler(hvsRoutes.StreamResponseHandler(reportController.Export,
+				constants.HTTPMediaTypeCsv, constants.HTTPMediaTypeJsonLines))).Methods("GET")
+		})
+
+		Context("Export Reports in CSV", func() {
+			It("Should export one row per host per rule", func() {
+				req, err := http.NewRequest("GET", "/reports/export", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeCsv)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Header().Get("Content-Type")).To(Equal(constants.HTTPMediaTypeCsv))
+
+				records, err := csv.NewReader(w.Body).ReadAll()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records[0]).To(Equal(hvs.ReportExportCSVHeader))
+				// two reports of 43 rules each
+				Expect(records).To(HaveLen(1 + 2*43))
+				Expect(records[1][2]).To(Equal("computepurley"))
+				Expect(records[1][9]).To(Equal("true"))
+			})
+		})
+
+		Context("Export Reports in JSON-lines", func() {
+			It("Should export one JSON document per host per rule", func() {
+				req, err := http.NewRequest("GET", "/reports/export?hostId=ee37c360-7eae-4250-a677-6ee12adce8e2", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJsonLines)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+				Expect(lines).To(HaveLen(43))
+				var row hvs.ReportExportRow
+				Expect(json.Unmarshal([]byte(lines[0]), &row)).To(Succeed())
+				Expect(row.HostID).To(Equal(uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")))
+				Expect(row.RuleName).NotTo(BeEmpty())
+				Expect(row.RuleTrusted).NotTo(BeNil())
+			})
+		})
+
+		Context("Export Reports with an unsupported Accept header", func() {
+			It("Should respond with unsupported media type", func() {
+				req, err := http.NewRequest("GET", "/reports/export", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusUnsupportedMediaType))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/reports/compliance"
+	Describe("Create a compliance Report", func() {
+		var samlCert *x509.Certificate
+
+		BeforeEach(func() {
+			certStore := newSamlCertStore()
+			reportController.CertStore = certStore
+			samlCert = &(*certStore)[models.CertTypesSaml.String()].Certificates[0]
+
+			// a host that was never attested
+			_, err := hostStore.Create(&hvs.Host{
+				Id:       uuid.MustParse("4b34f4a6-8ea4-4b4c-a0c3-5b5e1f2c8a10"),
+				HostName: "localhost3",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			// copy the trust report of localhost2 so the report in the store is not modified, and make it untrusted
+			report, err := reportStore.Retrieve(uuid.MustParse("15701f03-7b1d-49f9-ac62-6b9b0728bdb4"))
+			Expect(err).NotTo(HaveOccurred())
+			trustReportBytes, err := json.Marshal(report.TrustReport)
+			Expect(err).NotTo(HaveOccurred())
+			var trustReport hvs.TrustReport
+			Expect(json.Unmarshal(trustReportBytes, &trustReport)).To(Succeed())
+			trustReport.Trusted = false
+			trustReport.Results[0].Trusted = false
+			trustReport.Results[0].Faults = []hvs.Fault{{Name: "com.intel.mtwilson.core.verifier.policy.fault.PcrValueMismatch"}}
+			report.TrustReport = trustReport
+			_, err = reportStore.Update(report)
+			Expect(err).NotTo(HaveOccurred())
+
+			router.Handle("/reports/compliance", hvsRoutes.ErrorHandler(hvsRoutes.StreamResponseHandler(reportController.ComplianceDocument,
+				constants.HTTPMediaTypeHtml, constants.HTTPMediaTypePdf))).Methods("GET").HeadersRegexp("Accept", "^(text/html|application/pdf)$")
+			router.Handle("/reports/compliance", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(reportController.ComplianceReport))).Methods("GET")
+		})
+
+		Context("Create a signed compliance Report of all the hosts", func() {
+			It("Should summarize the trust status of the hosts", func() {
+				req, err := http.NewRequest("GET", "/reports/compliance", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var signedReport hvs.SignedComplianceReport
+				Expect(json.Unmarshal(w.Body.Bytes(), &signedReport)).To(Succeed())
+				Expect(signedReport.Verify(samlCert)).To(Succeed())
+
+				complianceReport := signedReport.Report
+				Expect(complianceReport.HostCount).To(Equal(3))
+				Expect(complianceReport.TrustedHostCount).To(Equal(1))
+				Expect(complianceReport.UntrustedHostCount).To(Equal(1))
+				Expect(complianceReport.NeverAttestedHostCount).To(Equal(1))
+				Expect(complianceReport.HostsNeverAttested[0].HostName).To(Equal("localhost3"))
+				Expect(complianceReport.UntrustedHosts[0].HostName).To(Equal("localhost2"))
+				Expect(complianceReport.TopFailingRules).To(HaveLen(1))
+				Expect(complianceReport.TopFailingRules[0].HostCount).To(Equal(1))
+				Expect(complianceReport.FlavorParts).NotTo(BeEmpty())
+
+				// the signature does not match a modified report
+				signedReport.Report.UntrustedHostCount = 0
+				Expect(signedReport.Verify(samlCert)).NotTo(Succeed())
+			})
+		})
+
+		Context("Create a compliance Report in a PDF document", func() {
+			It("Should return the document signed with the SAML key", func() {
+				req, err := http.NewRequest("GET", "/reports/compliance?hostName=localhost2", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypePdf)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Header().Get("Content-Type")).To(Equal(constants.HTTPMediaTypePdf))
+				Expect(w.Body.String()).To(HavePrefix("%PDF-1.4"))
+				Expect(w.Body.String()).To(ContainSubstring("localhost2"))
+				Expect(hvs.VerifyComplianceDocument(w.Body.Bytes(), w.Header().Get(consts.ComplianceSignatureHeader), samlCert)).To(Succeed())
+			})
+		})
+
+		Context("Create a compliance Report in a HTML document", func() {
+			It("Should return the document signed with the SAML key", func() {
+				req, err := http.NewRequest("GET", "/reports/compliance?labelSelector=env%3Dprod", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeHtml)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(ContainSubstring("labelSelector=env=prod"))
+				Expect(hvs.VerifyComplianceDocument(w.Body.Bytes(), w.Header().Get(consts.ComplianceSignatureHeader), samlCert)).To(Succeed())
+			})
+		})
+
+		Context("Create a compliance Report with an invalid top parameter", func() {
+			It("Should respond with bad request", func() {
+				req, err := http.NewRequest("GET", "/reports/compliance?top=1000", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
 })
+
+func newSamlCertStore() *models.CertificatesStore {
+	certDer, keyDer, err := crypt.CreateKeyPairAndCertificate(consts.DefaultCN, "", consts.DefaultKeyAlgorithm, consts.DefaultKeyLength)
+	Expect(err).NotTo(HaveOccurred())
+	cert, err := x509.ParseCertificate(certDer)
+	Expect(err).NotTo(HaveOccurred())
+	key, err := x509.ParsePKCS8PrivateKey(keyDer)
+	Expect(err).NotTo(HaveOccurred())
+
+	certStore := mocks.NewFakeCertificatesStore()
+	(*certStore)[models.CertTypesSaml.String()].Key = key
+	(*certStore)[models.CertTypesSaml.String()].Certificates = []x509.Certificate{*cert}
+	return certStore
+}
@@ -0,0 +1,296 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	consts "github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// complianceReportParams lists the query parameters accepted by the compliance report API
+var complianceReportParams = map[string]bool{"hostId": true, "hostHardwareId": true, "hostName": true,
+	"labelSelector": true, "fromDate": true, "toDate": true, "numberOfDays": true, "top": true}
+
+// complianceHostSelectorParams are the query parameters the hosts of a compliance report are selected with
+var complianceHostSelectorParams = []string{"hostId", "hostHardwareId", "hostName", "labelSelector"}
+
+// Export streams the reports matching the search criteria, flattened in one row per host per rule, in CSV or
+// JSON-lines depending on the Accept header. The reports of a time window are exported with latestPerHost=false.
+func (controller ReportController) Export(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/report_export:Export() Entering")
+	defer defaultLog.Trace("controllers/report_export:Export() Leaving")
+
+	//Search params for the report export are the same as that of the report search API
+	if err := utils.ValidateQueryParams(r.URL.Query(), hostStatusSearchParams); err != nil {
+		secLog.Errorf("controllers/report_export:Export() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	reportFilterCriteria, err := getReportFilterCriteria(r.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Warnf("controllers/report_export:Export() %s", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid Input given in request"}
+	}
+
+	mediaType := r.Header.Get("Accept")
+	// the reports are streamed as they are read from the db, the export must complete within the server write timeout
+	flusher, _ := w.(http.Flusher)
+
+	var csvWriter *csv.Writer
+	var jsonEncoder *json.Encoder
+	streamOpened := false
+	openStream := func() error {
+		streamOpened = true
+		w.Header().Set("Content-Type", mediaType)
+		w.WriteHeader(http.StatusOK)
+		if mediaType == constants.HTTPMediaTypeCsv {
+			csvWriter = csv.NewWriter(w)
+			return csvWriter.Write(hvs.ReportExportCSVHeader)
+		}
+		jsonEncoder = json.NewEncoder(w)
+		return nil
+	}
+
+	err = controller.ReportStore.Iterate(reportFilterCriteria, func(report *models.HVSReport) error {
+		if !streamOpened {
+			if err := openStream(); err != nil {
+				return &streamClosedError{err}
+			}
+		}
+		for _, row := range utils.ExportReportRows(report) {
+			var err error
+			if csvWriter != nil {
+				err = csvWriter.Write(row.CSVRecord())
+			} else {
+				err = jsonEncoder.Encode(row)
+			}
+			if err != nil {
+				return &streamClosedError{err}
+			}
+		}
+		// the reports are sent one by one, so that large exports are not buffered
+		if csvWriter != nil {
+			csvWriter.Flush()
+			if err := csvWriter.Error(); err != nil {
+				return &streamClosedError{err}
+			}
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		if _, ok := err.(*streamClosedError); ok {
+			defaultLog.WithError(err).Debug("controllers/report_export:Export() Failed to write report, closing stream")
+			return nil, http.StatusOK, nil
+		}
+		defaultLog.WithError(err).Warnf("controllers/report_export:Export() HVSReport search operation failed")
+		if !streamOpened {
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "HVSReport search operation failed"}
+		}
+		// the status is already sent, the export is truncated
+		return nil, http.StatusOK, nil
+	}
+	if !streamOpened {
+		if err := openStream(); err != nil {
+			defaultLog.WithError(err).Debug("controllers/report_export:Export() Failed to write CSV header, closing stream")
+			return nil, http.StatusOK, nil
+		}
+	}
+	if csvWriter != nil {
+		csvWriter.Flush()
+	}
+
+	secLog.Infof("%s: Reports exported by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return nil, http.StatusOK, nil
+}
+
+// ComplianceReport returns the compliance report of the hosts matching the host selector, signed with the SAML
+// signing key
+func (controller ReportController) ComplianceReport(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/report_export:ComplianceReport() Entering")
+	defer defaultLog.Trace("controllers/report_export:ComplianceReport() Leaving")
+
+	complianceReport, status, err := controller.createComplianceReport(r.URL.Query())
+	if err != nil {
+		return nil, status, err
+	}
+
+	signingKey, signingCert, err := controller.getSamlSigningKeyAndCertificate()
+	if err != nil {
+		defaultLog.WithError(err).Errorf("controllers/report_export:ComplianceReport() %s", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to sign compliance report"}
+	}
+
+	signedReport, err := hvs.NewSignedComplianceReport(complianceReport, signingKey, signingCert)
+	if err != nil {
+		defaultLog.WithError(err).Errorf("controllers/report_export:ComplianceReport() %s : Failed to sign compliance report", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to sign compliance report"}
+	}
+
+	secLog.Infof("%s: Compliance report retrieved by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return signedReport, http.StatusOK, nil
+}
+
+// ComplianceDocument renders the compliance report of the hosts matching the host selector in a HTML or PDF
+// document depending on the Accept header. The signature of the document, made with the SAML signing key, is
+// returned in the response headers.
+func (controller ReportController) ComplianceDocument(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/report_export:ComplianceDocument() Entering")
+	defer defaultLog.Trace("controllers/report_export:ComplianceDocument() Leaving")
+
+	complianceReport, status, err := controller.createComplianceReport(r.URL.Query())
+	if err != nil {
+		return nil, status, err
+	}
+
+	signingKey, _, err := controller.getSamlSigningKeyAndCertificate()
+	if err != nil {
+		defaultLog.WithError(err).Errorf("controllers/report_export:ComplianceDocument() %s", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to sign compliance report"}
+	}
+
+	mediaType := r.Header.Get("Accept")
+	var document []byte
+	if mediaType == constants.HTTPMediaTypePdf {
+		document, err = utils.RenderComplianceReportPDF(complianceReport)
+	} else {
+		document, err = utils.RenderComplianceReportHTML(complianceReport)
+	}
+	if err != nil {
+		defaultLog.WithError(err).Errorf("controllers/report_export:ComplianceDocument() %s : Failed to render compliance report", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to render compliance report"}
+	}
+
+	signature, err := hvs.SignComplianceDocument(document, signingKey)
+	if err != nil {
+		defaultLog.WithError(err).Errorf("controllers/report_export:ComplianceDocument() %s : Failed to sign compliance report", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to sign compliance report"}
+	}
+
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set(consts.ComplianceSignatureHeader, signature)
+	w.Header().Set(consts.ComplianceSignatureAlgorithmHeader, consts.ComplianceSignatureAlgorithm)
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(document); err != nil {
+		defaultLog.WithError(err).Error("controllers/report_export:ComplianceDocument() Error writing to response")
+	}
+
+	secLog.Infof("%s: Compliance report retrieved by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return nil, http.StatusOK, nil
+}
+
+// createComplianceReport summarizes the latest report in the time window of the hosts matching the host selector.
+// The current reports of the hosts are summarized when no time window is given.
+func (controller ReportController) createComplianceReport(params url.Values) (*hvs.ComplianceReport, int, error) {
+	defaultLog.Trace("controllers/report_export:createComplianceReport() Entering")
+	defer defaultLog.Trace("controllers/report_export:createComplianceReport() Leaving")
+
+	if err := utils.ValidateQueryParams(params, complianceReportParams); err != nil {
+		secLog.Errorf("controllers/report_export:createComplianceReport() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	reportFilterCriteria, err := getReportFilterCriteria(params)
+	if err != nil {
+		secLog.WithError(err).Warnf("controllers/report_export:createComplianceReport() %s", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid Input given in request"}
+	}
+
+	topFailingRules := consts.DefaultComplianceTopFailingRules
+	if top := strings.TrimSpace(params.Get("top")); top != "" {
+		topFailingRules, err = strconv.Atoi(top)
+		if err != nil || topFailingRules < 0 || topFailingRules > consts.MaxComplianceTopFailingRules {
+			secLog.Warnf("controllers/report_export:createComplianceReport() %s : Invalid top parameter", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Top must be an integer >= 0 and <= 100"}
+		}
+	}
+
+	// the time window is resolved here, so that it is reported as searched
+	if reportFilterCriteria.NumberOfDays != 0 {
+		reportFilterCriteria.ToDate = time.Now().UTC()
+		reportFilterCriteria.FromDate = reportFilterCriteria.ToDate.AddDate(0, 0, -reportFilterCriteria.NumberOfDays)
+		reportFilterCriteria.NumberOfDays = 0
+	}
+	// the latest report per host in the time window is searched, without row limit, so that no host selected is
+	// reported as never attested because of a truncated search
+	reportFilterCriteria.LatestPerHost = true
+	reportFilterCriteria.Limit = -1
+
+	hosts, err := controller.HostStore.Search(&models.HostFilterCriteria{
+		Id:             reportFilterCriteria.HostID,
+		HostHardwareId: reportFilterCriteria.HostHardwareID,
+		NameEqualTo:    reportFilterCriteria.HostName,
+		LabelSelector:  reportFilterCriteria.LabelSelector,
+	}, nil)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/report_export:createComplianceReport() Host search operation failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create compliance report"}
+	}
+
+	hvsReportCollection, err := controller.ReportStore.Search(reportFilterCriteria)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/report_export:createComplianceReport() HVSReport search operation failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create compliance report"}
+	}
+
+	complianceReport := utils.NewComplianceReport(hosts, hvsReportCollection, topFailingRules)
+	if !reportFilterCriteria.FromDate.IsZero() {
+		complianceReport.FromDate = &reportFilterCriteria.FromDate
+	}
+	if !reportFilterCriteria.ToDate.IsZero() {
+		complianceReport.ToDate = &reportFilterCriteria.ToDate
+	}
+	var hostSelector []string
+	for _, param := range complianceHostSelectorParams {
+		if value := strings.TrimSpace(params.Get(param)); value != "" {
+			hostSelector = append(hostSelector, param+"="+value)
+		}
+	}
+	complianceReport.HostSelector = strings.Join(hostSelector, "&")
+	return complianceReport, http.StatusOK, nil
+}
+
+// streamClosedError is returned when writing an exported report to the client fails
+type streamClosedError struct {
+	err error
+}
+
+func (e *streamClosedError) Error() string {
+	return e.err.Error()
+}
+
+// getSamlSigningKeyAndCertificate returns the SAML signing key and its certificate
+func (controller ReportController) getSamlSigningKeyAndCertificate() (*rsa.PrivateKey, *x509.Certificate, error) {
+	if controller.CertStore == nil {
+		return nil, nil, errors.New("controllers/report_export:getSamlSigningKeyAndCertificate() Certificate store is not loaded")
+	}
+	key, certs, err := controller.CertStore.GetKeyAndCertificates(models.CertTypesSaml.String())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "controllers/report_export:getSamlSigningKeyAndCertificate() SAML KeyPair not found in CertStore")
+	}
+	signingKey, ok := key.(*rsa.PrivateKey)
+	if !ok || len(certs) == 0 {
+		return nil, nil, errors.New("controllers/report_export:getSamlSigningKeyAndCertificate() SAML Key not found in CertStore")
+	}
+	return signingKey, &certs[0], nil
+}
@@ -31,7 +31,8 @@ type TpmEndorsementController struct {
 }
 
 var tpmEndorsementSearchParams = map[string]bool{"id": true, "hardwareUuidEqualTo": true, "issuerEqualTo": true, "revokedEqualTo": true,
-	"issuerContains": true, "commentEqualTo": true, "commentContains": true, "certificateDigestEqualTo": true}
+	"issuerContains": true, "commentEqualTo": true, "commentContains": true, "certificateDigestEqualTo": true,
+	"limit": true, "after": true}
 
 func (controller TpmEndorsementController) Create(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
 	defaultLog.Trace("controllers/tpm_endorsement_controller:Create() Entering")
@@ -80,6 +81,8 @@ func (controller TpmEndorsementController) Create(w http.ResponseWriter, r *http
 		defaultLog.WithError(err).Error("controllers/tpm_endorsement_controller:Create() Error while generating certificate digest")
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error while generating certificate digest"}
 	}
+	// the provenance is only recorded when the EK certificate is verified during AIK certification
+	reqTpmEndorsement.EndorsementProvenance = nil
 	// Persistence
 	newTpmEndorsement, err := controller.Store.Create(&reqTpmEndorsement)
 	if err != nil {
@@ -160,6 +163,7 @@ func (controller TpmEndorsementController) Update(w http.ResponseWriter, r *http
 			defaultLog.WithError(err).Error("controllers/tpm_endorsement_controller:Update() Error while generating certificate digest")
 			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error while generating certificate digest"}
 		}
+		tpmEndorsement.EndorsementProvenance = nil
 	}
 
 	if reqTpmEndorsement.Comment != "" {
@@ -195,11 +199,20 @@ func (controller TpmEndorsementController) Search(w http.ResponseWriter, r *http
 		secLog.WithError(err).Errorf("controllers/tpm_endorsement_controller:Search() %s Invalid input provided in filter criteria", commLogMsg.InvalidInputBadParam)
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid input provided in filter criteria"}
 	}
+	filter.Limit, filter.After, err = utils.ParsePageQueryParams(r.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/tpm_endorsement_controller:Search() %s Invalid input provided in filter criteria", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid input provided in filter criteria"}
+	}
 	tpmEndorsementCollection, err := controller.Store.Search(filter)
 	if err != nil {
 		secLog.WithError(err).Error("controllers/tpm_endorsement_controller:Search() TpmEndorsement get all failed")
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search TpmEndorsement"}
 	}
+	if count := len(tpmEndorsementCollection.TpmEndorsement); count > 0 {
+		tpmEndorsementCollection.Next = utils.NextPageLink(r, filter.Limit, count,
+			models.PageCursor{Id: tpmEndorsementCollection.TpmEndorsement[count-1].ID})
+	}
 
 	secLog.Infof("%s: Return tpm-endorsement query to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
 	return tpmEndorsementCollection, http.StatusOK, nil
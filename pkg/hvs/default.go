@@ -10,6 +10,7 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/config"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hrrs"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/notification"
 	commConfig "github.com/intel-secl/intel-secl/v3/pkg/lib/common/config"
 	"github.com/spf13/viper"
 )
@@ -30,17 +31,23 @@ func init() {
 	viper.SetDefault("tls-key-file", constants.DefaultTLSKeyFile)
 	viper.SetDefault("tls-common-name", constants.DefaultHvsTlsCn)
 	viper.SetDefault("tls-san-list", constants.DefaultHvsTlsSan)
+	viper.SetDefault("tls-key-algorithm", constants.DefaultKeyAlgorithm)
+	viper.SetDefault("tls-key-length", constants.DefaultKeyLength)
 
 	// set default values for all other certs
 	viper.SetDefault("saml-cert-file", constants.SAMLCertFile)
 	viper.SetDefault("saml-key-file", constants.SAMLKeyFile)
 	viper.SetDefault("saml-common-name", constants.DefaultSAMLCN)
+	viper.SetDefault("saml-key-algorithm", constants.DefaultKeyAlgorithm)
+	viper.SetDefault("saml-key-length", constants.DefaultKeyLength)
 	viper.SetDefault("saml-issuer-name", constants.DefaultSAMLCertIssuer)
 	viper.SetDefault("saml-validity-seconds", constants.DefaultSAMLCertValidity)
 
 	viper.SetDefault("flavor-signing-cert-file", constants.FlavorSigningCertFile)
 	viper.SetDefault("flavor-signing-key-file", constants.FlavorSigningKeyFile)
 	viper.SetDefault("flavor-signing-common-name", constants.DefaultFlavorSigningCN)
+	viper.SetDefault("flavor-signing-key-algorithm", constants.DefaultKeyAlgorithm)
+	viper.SetDefault("flavor-signing-key-length", constants.DefaultKeyLength)
 
 	viper.SetDefault("privacy-ca-cert-file", constants.PrivacyCACertFile)
 	viper.SetDefault("privacy-ca-key-file", constants.PrivacyCAKeyFile)
@@ -76,6 +83,7 @@ func init() {
 
 	// set default value for aik
 	viper.SetDefault("aik-certificate-validity-years", constants.DefaultAikCertificateValidity)
+	viper.SetDefault("aik-certificate-expiry-warning-days", constants.DefaultAikCertificateExpiryWarningDays)
 
 	// set default values for server
 	viper.SetDefault("server-port", constants.DefaultHVSListenerPort)
@@ -104,17 +112,28 @@ func init() {
 	viper.SetDefault(constants.HrrsRefreshPeriod, hrrs.DefaultRefreshPeriod)
 
 	viper.SetDefault(constants.VcssRefreshPeriod, constants.DefaultVcssRefreshPeriod)
+
+	viper.SetDefault(constants.SimulatorEnabled, false)
+	viper.SetDefault(constants.SimulatorProfileDir, constants.DefaultSimulatorProfileDir)
+
+	// set default for notification
+	viper.SetDefault(constants.NotificationNumberOfDeliveryWorkers, notification.DefaultNumberOfDeliveryWorkers)
+	viper.SetDefault(constants.NotificationMaxDeliveryAttempts, notification.DefaultMaxDeliveryAttempts)
+	viper.SetDefault(constants.NotificationRetryBackoff, notification.DefaultRetryBackoff)
+	viper.SetDefault(constants.NotificationDeliveryTimeout, notification.DefaultDeliveryTimeout)
+	viper.SetDefault(constants.NotificationEventBufferSize, notification.DefaultEventBufferSize)
 }
 
 func defaultConfig() *config.Configuration {
 	// support old hvs env
 	loadAlias()
 	return &config.Configuration{
-		AASApiUrl:        viper.GetString("aas-base-url"),
-		CMSBaseURL:       viper.GetString("cms-base-url"),
-		CmsTlsCertDigest: viper.GetString("cms-tls-cert-sha384"),
-		Dek:              viper.GetString("data-encryption-key"),
-		AikCertValidity:  viper.GetInt("aik-certificate-validity-years"),
+		AASApiUrl:                viper.GetString("aas-base-url"),
+		CMSBaseURL:               viper.GetString("cms-base-url"),
+		CmsTlsCertDigest:         viper.GetString("cms-tls-cert-sha384"),
+		Dek:                      viper.GetString("data-encryption-key"),
+		AikCertValidity:          viper.GetInt("aik-certificate-validity-years"),
+		AikCertExpiryWarningDays: viper.GetInt("aik-certificate-expiry-warning-days"),
 		AuditLog: config.AuditLogConfig{
 			MaxRowCount: viper.GetInt("audit-log-max-row-count"),
 			NumRotated:  viper.GetInt("audit-log-number-rotated"),
@@ -176,12 +195,23 @@ func defaultConfig() *config.Configuration {
 		VCSS: config.VCSSConfig{
 			RefreshPeriod: viper.GetDuration(constants.VcssRefreshPeriod),
 		},
+		Simulator: config.SimulatorConfig{
+			Enabled:    viper.GetBool(constants.SimulatorEnabled),
+			ProfileDir: viper.GetString(constants.SimulatorProfileDir),
+		},
 		FVS: config.FVSConfig{
 			NumberOfVerifiers:               viper.GetInt(fvsNumberOfVerifiers),
 			NumberOfDataFetchers:            viper.GetInt(fvsNumberOfDataFetchers),
 			SkipFlavorSignatureVerification: viper.GetBool(fvsSkipFlavorSignatureVerification),
 			HostTrustCacheThreshold:         viper.GetInt(fvsHostTrustCacheThreshold),
 		},
+		Notification: notification.NotificationConfig{
+			NumberOfDeliveryWorkers: viper.GetInt(constants.NotificationNumberOfDeliveryWorkers),
+			MaxDeliveryAttempts:     viper.GetInt(constants.NotificationMaxDeliveryAttempts),
+			RetryBackoff:            viper.GetDuration(constants.NotificationRetryBackoff),
+			DeliveryTimeout:         viper.GetDuration(constants.NotificationDeliveryTimeout),
+			EventBufferSize:         viper.GetInt(constants.NotificationEventBufferSize),
+		},
 		DB: commConfig.DBConfig{
 			Vendor:   viper.GetString("db-vendor"),
 			Host:     viper.GetString("db-host"),
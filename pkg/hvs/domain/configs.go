@@ -23,6 +23,8 @@ type HostTrustVerifierConfig struct {
 	SamlIssuerConfig                saml.IssuerConfiguration
 	SkipFlavorSignatureVerification bool
 	HostTrustCache                  *lru.Cache
+	NotificationManager             NotificationManager
+	AikCertificateStore             AikCertificateStore
 }
 
 type HostTrustMgrConfig struct {
@@ -41,6 +43,7 @@ type HostDataFetcherConfig struct {
 	HostStatusStore       HostStatusStore
 	HostStore             HostStore
 	HostTrustCache        *lru.Cache
+	NotificationManager   NotificationManager
 }
 
 type HostControllerConfig struct {
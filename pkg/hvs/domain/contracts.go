@@ -46,6 +46,9 @@ type (
 		// RetrieveTrustCacheFlavors function takes in host UUID and a flavorgroup uuid. The reason for this
 		// is the trust cache is associated to a flavor group.
 		RetrieveTrustCacheFlavors(uuid.UUID, uuid.UUID) ([]uuid.UUID, error)
+		// SearchTrustCacheHosts takes in a flavor UUID and a flavorgroup UUID and returns the hosts having the flavor in
+		// their trust cache for the flavorgroup
+		SearchTrustCacheHosts(uuid.UUID, uuid.UUID) ([]uuid.UUID, error)
 		// Flavors that are unique to the host such as HOST_UNIQUE and ASSET_TAG should have an association
 		// with the host.
 
@@ -69,6 +72,9 @@ type (
 		Retrieve(uuid.UUID) (*hvs.SignedFlavor, error)
 		Search(*models.FlavorVerificationFC) ([]hvs.SignedFlavor, error)
 		Delete(uuid.UUID) error
+		Update(*hvs.FlavorRevision) (*hvs.FlavorRevision, error)
+		SearchRevisions(uuid.UUID) ([]hvs.FlavorRevision, error)
+		RetrieveRevision(uuid.UUID, int) (*hvs.FlavorRevision, error)
 	}
 
 	TpmEndorsementStore interface {
@@ -79,6 +85,27 @@ type (
 		Delete(uuid.UUID) error
 	}
 
+	EndorsementAuthorityStore interface {
+		Create(*hvs.EndorsementAuthority) (*hvs.EndorsementAuthority, error)
+		Update(*hvs.EndorsementAuthority) (*hvs.EndorsementAuthority, error)
+		Retrieve(uuid.UUID) (*hvs.EndorsementAuthority, error)
+		Search(*models.EndorsementAuthorityFilterCriteria) ([]hvs.EndorsementAuthority, error)
+		Delete(uuid.UUID) error
+	}
+
+	AikCertificateStore interface {
+		Create(*hvs.AikCertificate) (*hvs.AikCertificate, error)
+		Update(*hvs.AikCertificate) (*hvs.AikCertificate, error)
+		Retrieve(uuid.UUID) (*hvs.AikCertificate, error)
+		Search(*models.AikCertificateFilterCriteria) ([]hvs.AikCertificate, error)
+	}
+
+	// FlavorgroupBundleStore creates the items of an imported flavorgroup bundle at once
+	FlavorgroupBundleStore interface {
+		// Import returns the flavorgroup and the ids of the flavors newly linked to it
+		Import(*models.FlavorgroupBundleImport) (*hvs.FlavorGroup, []uuid.UUID, error)
+	}
+
 	// FlavorTemplateStore will do the DB operations related to flavor template CRUD.
 	FlavorTemplateStore interface {
 		Create(*hvs.FlavorTemplate) (*hvs.FlavorTemplate, error)
@@ -108,6 +135,7 @@ type (
 
 	ReportStore interface {
 		Search(*models.ReportFilterCriteria) ([]models.HVSReport, error)
+		Iterate(*models.ReportFilterCriteria, func(*models.HVSReport) error) error
 		Retrieve(uuid.UUID) (*models.HVSReport, error)
 		Create(*models.HVSReport) (*models.HVSReport, error)
 		Update(*models.HVSReport) (*models.HVSReport, error)
@@ -132,6 +160,21 @@ type (
 		Search(*models.TagCertificateFilterCriteria) ([]*hvs.TagCertificate, error)
 	}
 
+	// JobStore persists the progress of asynchronous operations
+	JobStore interface {
+		Create(*hvs.Job) (*hvs.Job, error)
+		Retrieve(uuid.UUID) (*hvs.Job, error)
+		Update(*hvs.Job) error
+		// FailUnfinished marks the queued and running jobs failed with the reason, and returns their number
+		FailUnfinished(reason string) (int, error)
+	}
+
+	// JobRunner runs the asynchronous jobs started by the API requests in the background
+	JobRunner interface {
+		// Submit queues the job, that must return once its context is cancelled
+		Submit(func(context.Context)) error
+	}
+
 	HostTrustManager interface {
 		// Verify the trust of the a host.
 		//Returns the host trust report. For now marking this as interface since we have not defined the report structure
@@ -146,6 +189,15 @@ type (
 		//                   doing a full report.
 		VerifyHostsAsync(hostIds []uuid.UUID, fetchHostData, preferHashMatch bool) error
 
+		// Same as VerifyHostsAsync, queuing the verifications in the lane of the given priority.
+		// The work of the high priority lane is processed ahead of the work of the low priority one.
+		VerifyHostsAsyncWithPriority(hostIds []uuid.UUID, fetchHostData, preferHashMatch bool, priority models.QueuePriority) error
+
+		// Cancel the verification of a queue record and remove it from the queue.
+		// Returns ErrQueueEntryNotFound if there is no such record, and ErrVerificationStarted
+		// if the verification is no longer queued, fetching the host data or verifying it.
+		CancelVerification(queueId uuid.UUID) error
+
 		//Process all records stuck in queue post service restart
 		ProcessQueue() error
 	}
@@ -180,6 +232,29 @@ type (
 		Stop()
 	}
 
+	NotificationSubscriptionStore interface {
+		Create(*hvs.NotificationSubscription) (*hvs.NotificationSubscription, error)
+		Retrieve(uuid.UUID) (*hvs.NotificationSubscription, error)
+		Search(*models.NotificationSubscriptionFilterCriteria) ([]hvs.NotificationSubscription, error)
+		Delete(uuid.UUID) error
+	}
+
+	NotificationDeadLetterStore interface {
+		Create(*hvs.NotificationDeadLetter) (*hvs.NotificationDeadLetter, error)
+		Search(*models.NotificationDeadLetterFilterCriteria) ([]hvs.NotificationDeadLetter, error)
+		Delete(uuid.UUID) error
+	}
+
+	// NotificationManager fans out trust and host state change events to registered webhooks and
+	// event stream listeners
+	NotificationManager interface {
+		// Publish queues the event for delivery. It never blocks the caller on subscriber delivery.
+		Publish(*hvs.NotificationEvent)
+		// Subscribe registers a listener for events matching the filter. The returned function must be
+		// called to release the listener.
+		Subscribe(filter *hvs.NotificationSubscription) (<-chan *hvs.NotificationEvent, func())
+	}
+
 	AuditLogEntryStore interface {
 		Create(*models.AuditLogEntry) (*models.AuditLogEntry, error)
 		Retrieve(*models.AuditLogEntry) ([]models.AuditLogEntry, error)
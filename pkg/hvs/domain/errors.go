@@ -0,0 +1,11 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package domain
+
+import "github.com/pkg/errors"
+
+// errors returned by HostTrustManager.CancelVerification
+var ErrQueueEntryNotFound = errors.New("queue entry not found")
+var ErrVerificationStarted = errors.New("host trust verification already started")
@@ -0,0 +1,96 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package mocks
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// MockAikCertificateStore provides a mocked implementation of interface domain.AikCertificateStore
+type MockAikCertificateStore struct {
+	mtx             sync.Mutex
+	aikCertificates map[uuid.UUID]hvs.AikCertificate
+}
+
+// Create inserts an AikCertificate
+func (store *MockAikCertificateStore) Create(ac *hvs.AikCertificate) (*hvs.AikCertificate, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	if ac.ID == uuid.Nil {
+		ac.ID = uuid.New()
+	}
+	store.aikCertificates[ac.ID] = *ac
+	return ac, nil
+}
+
+// Update replaces an AikCertificate
+func (store *MockAikCertificateStore) Update(ac *hvs.AikCertificate) (*hvs.AikCertificate, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	if _, ok := store.aikCertificates[ac.ID]; !ok {
+		return nil, errors.New(commErr.RowsNotFound)
+	}
+	store.aikCertificates[ac.ID] = *ac
+	return ac, nil
+}
+
+// Retrieve returns AikCertificate
+func (store *MockAikCertificateStore) Retrieve(id uuid.UUID) (*hvs.AikCertificate, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	if ac, ok := store.aikCertificates[id]; ok {
+		return &ac, nil
+	}
+	return nil, errors.New(commErr.RowsNotFound)
+}
+
+// Search returns a filtered list of AikCertificates per the provided AikCertificateFilterCriteria
+func (store *MockAikCertificateStore) Search(criteria *models.AikCertificateFilterCriteria) ([]hvs.AikCertificate, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	aikCertificates := []hvs.AikCertificate{}
+	for _, ac := range store.aikCertificates {
+		if criteria != nil {
+			if criteria.Id != uuid.Nil && criteria.Id != ac.ID {
+				continue
+			}
+			if criteria.HardwareUUID != uuid.Nil && (ac.HardwareUUID == nil || criteria.HardwareUUID != *ac.HardwareUUID) {
+				continue
+			}
+			if criteria.SerialNumberEqualTo != "" && criteria.SerialNumberEqualTo != ac.SerialNumber {
+				continue
+			}
+			if criteria.EkCertificateDigestEqualTo != "" && criteria.EkCertificateDigestEqualTo != ac.EkCertificateDigest {
+				continue
+			}
+			if criteria.Revoked != nil && *criteria.Revoked != ac.Revoked {
+				continue
+			}
+			if !criteria.ExpiresBefore.IsZero() && !ac.Expiry.Before(criteria.ExpiresBefore) {
+				continue
+			}
+			if !criteria.ExpiresAfter.IsZero() && !ac.Expiry.After(criteria.ExpiresAfter) {
+				continue
+			}
+		}
+		aikCertificates = append(aikCertificates, ac)
+	}
+	sort.Slice(aikCertificates, func(i, j int) bool {
+		return aikCertificates[i].Issued.Before(aikCertificates[j].Issued)
+	})
+	return aikCertificates, nil
+}
+
+// NewMockAikCertificateStore provides an empty AikCertificate store
+func NewMockAikCertificateStore() *MockAikCertificateStore {
+	return &MockAikCertificateStore{aikCertificates: make(map[uuid.UUID]hvs.AikCertificate)}
+}
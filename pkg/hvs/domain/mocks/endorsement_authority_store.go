@@ -0,0 +1,106 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package mocks
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// MockEndorsementAuthorityStore provides a mocked implementation of interface domain.EndorsementAuthorityStore
+type MockEndorsementAuthorityStore struct {
+	mtx         sync.Mutex
+	authorities map[uuid.UUID]hvs.EndorsementAuthority
+}
+
+// Create inserts an EndorsementAuthority
+func (store *MockEndorsementAuthorityStore) Create(ea *hvs.EndorsementAuthority) (*hvs.EndorsementAuthority, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	if ea.ID == uuid.Nil {
+		ea.ID = uuid.New()
+	}
+	ea.Created = time.Now().UTC()
+	ea.Updated = ea.Created
+	store.authorities[ea.ID] = *ea
+	return ea, nil
+}
+
+// Update replaces an EndorsementAuthority
+func (store *MockEndorsementAuthorityStore) Update(ea *hvs.EndorsementAuthority) (*hvs.EndorsementAuthority, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	if _, ok := store.authorities[ea.ID]; !ok {
+		return nil, errors.New(commErr.RowsNotFound)
+	}
+	ea.Updated = time.Now().UTC()
+	store.authorities[ea.ID] = *ea
+	return ea, nil
+}
+
+// Retrieve returns EndorsementAuthority
+func (store *MockEndorsementAuthorityStore) Retrieve(id uuid.UUID) (*hvs.EndorsementAuthority, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	if ea, ok := store.authorities[id]; ok {
+		return &ea, nil
+	}
+	return nil, errors.New(commErr.RowsNotFound)
+}
+
+// Search returns a filtered list of EndorsementAuthorities per the provided EndorsementAuthorityFilterCriteria
+func (store *MockEndorsementAuthorityStore) Search(criteria *models.EndorsementAuthorityFilterCriteria) ([]hvs.EndorsementAuthority, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	authorities := []hvs.EndorsementAuthority{}
+	for _, ea := range store.authorities {
+		if criteria != nil {
+			if criteria.Id != uuid.Nil && criteria.Id != ea.ID {
+				continue
+			}
+			if criteria.NameEqualTo != "" && criteria.NameEqualTo != ea.Name {
+				continue
+			}
+			if criteria.NameContains != "" && !strings.Contains(ea.Name, criteria.NameContains) {
+				continue
+			}
+			if criteria.ManufacturerEqualTo != "" && criteria.ManufacturerEqualTo != ea.Manufacturer {
+				continue
+			}
+			if criteria.Disabled != nil && *criteria.Disabled != ea.Disabled {
+				continue
+			}
+		}
+		authorities = append(authorities, ea)
+	}
+	sort.Slice(authorities, func(i, j int) bool {
+		return authorities[i].Name < authorities[j].Name
+	})
+	return authorities, nil
+}
+
+// Delete deletes EndorsementAuthority
+func (store *MockEndorsementAuthorityStore) Delete(id uuid.UUID) error {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	if _, ok := store.authorities[id]; !ok {
+		return errors.New(commErr.RowsNotFound)
+	}
+	delete(store.authorities, id)
+	return nil
+}
+
+// NewMockEndorsementAuthorityStore provides an empty EndorsementAuthority store
+func NewMockEndorsementAuthorityStore() *MockEndorsementAuthorityStore {
+	return &MockEndorsementAuthorityStore{authorities: make(map[uuid.UUID]hvs.EndorsementAuthority)}
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"reflect"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
@@ -23,6 +24,7 @@ type MockFlavorStore struct {
 	flavorStore            []hvs.SignedFlavor
 	FlavorFlavorGroupStore map[uuid.UUID][]uuid.UUID
 	FlavorgroupStore       map[uuid.UUID]*hvs.FlavorGroup
+	flavorRevisions        map[uuid.UUID][]hvs.FlavorRevision
 }
 
 var flavor = ` {
@@ -543,6 +545,7 @@ func (store *MockFlavorStore) Delete(id uuid.UUID) error {
 	for i, f := range store.flavorStore {
 		if f.Flavor.Meta.ID == id {
 			store.flavorStore[i] = hvs.SignedFlavor{}
+			delete(store.flavorRevisions, id)
 			return nil
 		}
 	}
@@ -584,6 +587,13 @@ func (store *MockFlavorStore) Search(criteria *models.FlavorVerificationFC) ([]h
 			}
 		}
 		sfs = sfFiltered
+	} else if criteria.FlavorFC.Key != "" && criteria.FlavorFC.Value != "" {
+		// Flavor meta description filter
+		for _, f := range store.flavorStore {
+			if value, ok := f.Flavor.Meta.Description[criteria.FlavorFC.Key].(string); ok && value == criteria.FlavorFC.Value {
+				sfs = append(sfs, f)
+			}
+		}
 	} else if criteria.FlavorFC.FlavorgroupID != uuid.Nil ||
 		len(criteria.FlavorFC.FlavorParts) >= 1 || len(criteria.FlavorPartsWithLatest) >= 1 {
 		flavorPartsWithLatestMap := getFlavorPartsWithLatestMap(criteria.FlavorFC.FlavorParts, criteria.FlavorPartsWithLatest)
@@ -616,9 +626,57 @@ func (store *MockFlavorStore) Create(sf *hvs.SignedFlavor) (*hvs.SignedFlavor, e
 		Signature: sf.Signature,
 	}
 	store.flavorStore = append(store.flavorStore, rec)
+	if store.flavorRevisions == nil {
+		store.flavorRevisions = make(map[uuid.UUID][]hvs.FlavorRevision)
+	}
+	store.flavorRevisions[sf.Flavor.Meta.ID] = []hvs.FlavorRevision{{
+		FlavorID:     sf.Flavor.Meta.ID,
+		Revision:     1,
+		SignedFlavor: rec,
+		Created:      time.Now(),
+	}}
 	return sf, nil
 }
 
+// Update replaces the content of a Flavor and records a new FlavorRevision
+func (store *MockFlavorStore) Update(fr *hvs.FlavorRevision) (*hvs.FlavorRevision, error) {
+	for i, f := range store.flavorStore {
+		if f.Flavor.Meta.ID == fr.FlavorID {
+			fr.SignedFlavor.Flavor.Meta.ID = fr.FlavorID
+			store.flavorStore[i] = hvs.SignedFlavor{
+				Flavor:    fr.SignedFlavor.Flavor,
+				Signature: fr.SignedFlavor.Signature,
+			}
+			revisions := store.flavorRevisions[fr.FlavorID]
+			rec := *fr
+			rec.Revision = len(revisions) + 1
+			rec.Created = time.Now()
+			store.flavorRevisions[fr.FlavorID] = append(revisions, rec)
+			return &rec, nil
+		}
+	}
+	return nil, errors.New(commErr.RowsNotFound)
+}
+
+// SearchRevisions returns all FlavorRevisions of a Flavor, latest first
+func (store *MockFlavorStore) SearchRevisions(flavorId uuid.UUID) ([]hvs.FlavorRevision, error) {
+	revisions := []hvs.FlavorRevision{}
+	for i := len(store.flavorRevisions[flavorId]) - 1; i >= 0; i-- {
+		revisions = append(revisions, store.flavorRevisions[flavorId][i])
+	}
+	return revisions, nil
+}
+
+// RetrieveRevision returns a single FlavorRevision of a Flavor
+func (store *MockFlavorStore) RetrieveRevision(flavorId uuid.UUID, revision int) (*hvs.FlavorRevision, error) {
+	for _, fr := range store.flavorRevisions[flavorId] {
+		if fr.Revision == revision {
+			return &fr, nil
+		}
+	}
+	return nil, errors.New(commErr.RowsNotFound)
+}
+
 // NewMockFlavorStore provides one dummy data for Flavors
 func NewMockFlavorStore() *MockFlavorStore {
 	store := &MockFlavorStore{}
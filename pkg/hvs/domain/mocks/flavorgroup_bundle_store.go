@@ -0,0 +1,78 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package mocks
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// MockFlavorgroupBundleStore provides a mocked implementation of interface domain.FlavorgroupBundleStore, creating
+// the items of the bundle in the given stores
+type MockFlavorgroupBundleStore struct {
+	FlavorGroupStore    domain.FlavorGroupStore
+	FlavorStore         domain.FlavorStore
+	FlavorTemplateStore domain.FlavorTemplateStore
+}
+
+// NewMockFlavorgroupBundleStore returns a MockFlavorgroupBundleStore creating the items in the given stores
+func NewMockFlavorgroupBundleStore(fgStore domain.FlavorGroupStore, flavorStore domain.FlavorStore, templateStore domain.FlavorTemplateStore) *MockFlavorgroupBundleStore {
+	return &MockFlavorgroupBundleStore{
+		FlavorGroupStore:    fgStore,
+		FlavorStore:         flavorStore,
+		FlavorTemplateStore: templateStore,
+	}
+}
+
+// Import creates the items of the bundle one by one
+func (store *MockFlavorgroupBundleStore) Import(bundleImport *models.FlavorgroupBundleImport) (*hvs.FlavorGroup, []uuid.UUID, error) {
+	for i := range bundleImport.FlavorTemplates {
+		if _, err := store.FlavorTemplateStore.Create(&bundleImport.FlavorTemplates[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+	for i := range bundleImport.SignedFlavors {
+		if _, err := store.FlavorStore.Create(&bundleImport.SignedFlavors[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	fg := bundleImport.FlavorGroup
+	if fg.ID == uuid.Nil {
+		createdFg, err := store.FlavorGroupStore.Create(&fg)
+		if err != nil {
+			return nil, nil, err
+		}
+		fg = *createdFg
+	}
+
+	linkedFlavorIds, err := store.FlavorGroupStore.SearchFlavors(fg.ID)
+	if err != nil && !strings.Contains(err.Error(), commErr.RowsNotFound) {
+		return nil, nil, err
+	}
+	linked := map[uuid.UUID]bool{}
+	for _, fId := range linkedFlavorIds {
+		linked[fId] = true
+	}
+	var newLinks []uuid.UUID
+	for _, fId := range bundleImport.FlavorIds {
+		if !linked[fId] {
+			linked[fId] = true
+			newLinks = append(newLinks, fId)
+		}
+	}
+	if len(newLinks) > 0 {
+		if _, err := store.FlavorGroupStore.AddFlavors(fg.ID, newLinks); err != nil {
+			return nil, nil, errors.Wrap(err, "Error linking flavors to FlavorGroup")
+		}
+	}
+	return &fg, newLinks, nil
+}
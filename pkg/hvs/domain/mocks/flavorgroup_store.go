@@ -57,8 +57,9 @@ func (store *MockFlavorgroupStore) Search(criteria *models.FlavorGroupFilterCrit
 	} else if len(criteria.Ids) > 0 {
 		flavorgroups := []hvs.FlavorGroup{}
 		for _, id := range criteria.Ids {
-			fg, _ := store.Retrieve(id)
-			flavorgroups = append(flavorgroups, *fg)
+			if fg, err := store.Retrieve(id); err == nil {
+				flavorgroups = append(flavorgroups, *fg)
+			}
 		}
 		return flavorgroups, nil
 	} else if criteria.NameEqualTo != "" {
@@ -75,6 +76,25 @@ func (store *MockFlavorgroupStore) Search(criteria *models.FlavorGroupFilterCrit
 			}
 		}
 		return flavorgroups, nil
+	} else if criteria.FlavorId != nil {
+		var flavorgroups []hvs.FlavorGroup
+		for fgId, fIds := range store.FlavorgroupFlavorStore {
+			for _, fId := range fIds {
+				if fId == *criteria.FlavorId {
+					flavorgroups = append(flavorgroups, *store.FlavorgroupStore[fgId])
+					break
+				}
+			}
+		}
+		return flavorgroups, nil
+	} else if criteria.HasHostLabelSelector {
+		var flavorgroups []hvs.FlavorGroup
+		for _, fg := range store.FlavorgroupStore {
+			if fg.HostLabelSelector != "" {
+				flavorgroups = append(flavorgroups, *fg)
+			}
+		}
+		return flavorgroups, nil
 	}
 	return nil, nil
 }
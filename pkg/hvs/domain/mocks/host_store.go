@@ -11,23 +11,35 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"github.com/pkg/errors"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
 )
 
 // MockHostStore provides a mocked implementation of interface domain.HostStore
 type MockHostStore struct {
 	hostStore            []*hvs.Host
 	HostFlavorgroupStore []*hvs.HostFlavorgroup
+	// trust cache flavors per host, the flavorgroup of the flavors is not tracked
+	trustCache map[uuid.UUID][]uuid.UUID
+	// guards the hosts against the concurrent access of the host trust manager and the host fetcher
+	mtx sync.RWMutex
 }
 
 // Create inserts a Host
 func (store *MockHostStore) Create(host *hvs.Host) (*hvs.Host, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
 	store.hostStore = append(store.hostStore, host)
 	return host, nil
 }
 
 // Retrieve returns Host
 func (store *MockHostStore) Retrieve(id uuid.UUID, criteria *models.HostInfoFetchCriteria) (*hvs.Host, error) {
+	store.mtx.RLock()
+	defer store.mtx.RUnlock()
+
 	for _, h := range store.hostStore {
 		if h.Id == id {
 			return h, nil
@@ -38,8 +50,15 @@ func (store *MockHostStore) Retrieve(id uuid.UUID, criteria *models.HostInfoFetc
 
 // Update modifies a Host
 func (store *MockHostStore) Update(host *hvs.Host) error {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
 	for i, h := range store.hostStore {
 		if h.Id == host.Id {
+			// as in the database, the labels are left unchanged when they are not set
+			if host.Labels == nil {
+				host.Labels = h.Labels
+			}
 			store.hostStore[i] = host
 			return nil
 		}
@@ -49,6 +68,9 @@ func (store *MockHostStore) Update(host *hvs.Host) error {
 
 // Delete deletes Host
 func (store *MockHostStore) Delete(id uuid.UUID) error {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
 	for i, h := range store.hostStore {
 		if h.Id == id {
 			store.hostStore[i] = &hvs.Host{}
@@ -59,6 +81,9 @@ func (store *MockHostStore) Delete(id uuid.UUID) error {
 }
 
 func (store *MockHostStore) DeleteByHostName(hostName string) error {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
 	for i, h := range store.hostStore {
 		if h.HostName == hostName {
 			store.hostStore[i] = &hvs.Host{}
@@ -73,6 +98,9 @@ func (store *MockHostStore) Search(criteria *models.HostFilterCriteria, hostInfo
 	if criteria == nil || reflect.DeepEqual(*criteria, models.HostFilterCriteria{}) {
 		return store.hostStore, nil
 	}
+	if criteria.Limit > 0 || criteria.After != nil {
+		return store.searchPage(criteria, hostInfoFetchCriteria)
+	}
 
 	var hosts []*hvs.Host
 	if criteria.Id != uuid.Nil {
@@ -98,10 +126,64 @@ func (store *MockHostStore) Search(criteria *models.HostFilterCriteria, hostInfo
 				hosts = append(hosts, h)
 			}
 		}
+	} else if criteria.IdList != nil {
+		for _, h := range store.hostStore {
+			for _, id := range criteria.IdList {
+				if h.Id == id {
+					hosts = append(hosts, h)
+					break
+				}
+			}
+		}
+	}
+
+	if criteria.LabelSelector != nil {
+		if criteria.Id == uuid.Nil && criteria.HostHardwareId == uuid.Nil && criteria.NameEqualTo == "" &&
+			criteria.NameContains == "" && criteria.IdList == nil {
+			hosts = store.hostStore
+		}
+		var selected []*hvs.Host
+		for _, h := range hosts {
+			if h.Id != uuid.Nil && criteria.LabelSelector.Matches(h.Labels) {
+				selected = append(selected, h)
+			}
+		}
+		hosts = selected
 	}
 	return hosts, nil
 }
 
+// searchPage returns the page of the hosts matching the criteria, sorted on their name
+func (store *MockHostStore) searchPage(criteria *models.HostFilterCriteria, hostInfoFetchCriteria *models.HostInfoFetchCriteria) ([]*hvs.Host, error) {
+	filterCriteria := *criteria
+	filterCriteria.Limit = 0
+	filterCriteria.After = nil
+	filterCriteria.OrderBy = ""
+	hosts, err := store.Search(&filterCriteria, hostInfoFetchCriteria)
+	if err != nil {
+		return nil, err
+	}
+
+	descending := criteria.OrderBy == models.Descending
+	sorted := append([]*hvs.Host(nil), hosts...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return (sorted[i].HostName < sorted[j].HostName) != descending
+	})
+
+	page := []*hvs.Host{}
+	for _, h := range sorted {
+		if criteria.After != nil && (!descending && h.HostName <= criteria.After.Key ||
+			descending && h.HostName >= criteria.After.Key) {
+			continue
+		}
+		if criteria.Limit > 0 && len(page) == criteria.Limit {
+			break
+		}
+		page = append(page, h)
+	}
+	return page, nil
+}
+
 // AddFlavorgroups associate a Host with specified flavorgroups
 func (store *MockHostStore) AddFlavorgroups(hId uuid.UUID, fgIds []uuid.UUID) error {
 	for _, fgId := range fgIds {
@@ -149,19 +231,64 @@ func (store *MockHostStore) SearchFlavorgroups(hId uuid.UUID) ([]uuid.UUID, erro
 	return fgIds, nil
 }
 
-func (store *MockHostStore) AddTrustCacheFlavors(uuid.UUID, []uuid.UUID) ([]uuid.UUID, error) {
-	// TODO: to be implemented
-	return nil, nil
+func (store *MockHostStore) AddTrustCacheFlavors(hId uuid.UUID, fIds []uuid.UUID) ([]uuid.UUID, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	if store.trustCache == nil {
+		store.trustCache = make(map[uuid.UUID][]uuid.UUID)
+	}
+	store.trustCache[hId] = append(store.trustCache[hId], fIds...)
+	return fIds, nil
 }
 
-func (store *MockHostStore) RemoveTrustCacheFlavors(uuid.UUID, []uuid.UUID) error {
-	// TODO: to be implemented
+func (store *MockHostStore) RemoveTrustCacheFlavors(hId uuid.UUID, fIds []uuid.UUID) error {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	if store.trustCache == nil {
+		return nil
+	}
+	var cachedFlavors []uuid.UUID
+	for _, cachedFlavor := range store.trustCache[hId] {
+		removed := false
+		for _, fId := range fIds {
+			if cachedFlavor == fId {
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			cachedFlavors = append(cachedFlavors, cachedFlavor)
+		}
+	}
+	store.trustCache[hId] = cachedFlavors
 	return nil
 }
 
-func (store *MockHostStore) RetrieveTrustCacheFlavors(_, _ uuid.UUID) ([]uuid.UUID, error) {
-	// TODO: to be implemented
-	return nil, nil
+// RetrieveTrustCacheFlavors returns all the flavors cached for the host, whatever their flavorgroup
+func (store *MockHostStore) RetrieveTrustCacheFlavors(hId, _ uuid.UUID) ([]uuid.UUID, error) {
+	store.mtx.RLock()
+	defer store.mtx.RUnlock()
+
+	return append([]uuid.UUID(nil), store.trustCache[hId]...), nil
+}
+
+// SearchTrustCacheHosts returns the hosts having the flavor in their trust cache, whatever its flavorgroup
+func (store *MockHostStore) SearchTrustCacheHosts(fId, _ uuid.UUID) ([]uuid.UUID, error) {
+	store.mtx.RLock()
+	defer store.mtx.RUnlock()
+
+	var hostIds []uuid.UUID
+	for hId, cachedFlavors := range store.trustCache {
+		for _, cachedFlavor := range cachedFlavors {
+			if cachedFlavor == fId {
+				hostIds = append(hostIds, hId)
+				break
+			}
+		}
+	}
+	return hostIds, nil
 }
 
 func (store *MockHostStore) AddHostUniqueFlavors(uuid.UUID, []uuid.UUID) ([]uuid.UUID, error) {
@@ -196,6 +323,7 @@ func NewMockHostStore() *MockHostStore {
 		HardwareUuid:     &uuid1,
 		ConnectionString: "intel:https://ta.ip.com:1443",
 		Description:      "Intel Host",
+		Labels:           map[string]string{"env": "prod", "rack": "r1"},
 	})
 	if err != nil {
 		defaultLog.WithError(err).Errorf("Error creating Host")
@@ -207,6 +335,7 @@ func NewMockHostStore() *MockHostStore {
 		HardwareUuid:     &uuid2,
 		ConnectionString: "vmware:https://vsphere.com:443/sdk;h=hostName;u=admin.local;p=password",
 		Description:      "Vmware Host",
+		Labels:           map[string]string{"env": "dev", "rack": "r2"},
 	})
 	if err != nil {
 		defaultLog.WithError(err).Errorf("Error creating Host")
@@ -148,7 +148,7 @@ func (store *MockHostStatusStore) Search(criteria *models.HostStatusFilterCriter
 	store.Mock.MatchExpectationsInOrder(false)
 
 	// Search No filters
-	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" LIMIT (.+)`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" ORDER BY (.+) LIMIT (.+)`).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "host_id", "status", "host_report", "created"}).
 			AddRow(hs1.ID.String(), hs1.HostID.String(), hsi1, hsm1, hs1.Created).
 			AddRow(hs2.ID.String(), hs2.HostID.String(), hsi2, hsm2, hs2.Created).
@@ -161,14 +161,21 @@ func (store *MockHostStatusStore) Search(criteria *models.HostStatusFilterCriter
 			AddRow(hs1.ID.String(), hs1.HostID.String(), hsi1, hsm1, hs1.Created))
 
 	// Search by an existing Host ID
-	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" WHERE \(host_id = \$1\) LIMIT (.+)`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" WHERE \(host_id = \$1\) ORDER BY (.+) LIMIT (.+)`).
 		WithArgs("47a3b602-f321-4e03-b3b2-8f3ca3cde128").
 		WillReturnRows(sqlmock.NewRows([]string{"id", "host_id", "status", "host_report", "created"}).
 			AddRow(hs1.ID.String(), "47a3b602-f321-4e03-b3b2-8f3ca3cde128", hsi1, hsm1, hs1.Created).
 			AddRow(hs2.ID.String(), "47a3b602-f321-4e03-b3b2-8f3ca3cde128", hsi2, hsm2, hs2.Created))
 
+	// Search the latest host statuses of a list of Host IDs, only 47a3b602-f321-4e03-b3b2-8f3ca3cde128 and
+	// ee37c360-7eae-4250-a677-6ee12adce8e2 have a host status
+	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" WHERE \(host_id IN \((.+)\)\) ORDER BY (.+) LIMIT (.+)`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "host_id", "status", "host_report", "created"}).
+			AddRow(hs1.ID.String(), "47a3b602-f321-4e03-b3b2-8f3ca3cde128", hsi1, hsm1, hs1.Created).
+			AddRow(hs2.ID.String(), "ee37c360-7eae-4250-a677-6ee12adce8e2", hsi1, hsm1, hs1.Created))
+
 	// Search by a non-existent Host ID - empty result
-	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" WHERE \(host_id = \$1\) LIMIT (.+)`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" WHERE \(host_id = \$1\) ORDER BY (.+) LIMIT (.+)`).
 		WithArgs("13885605-a0ee-41f2-b6fc-fd82edc487ad").
 		WillReturnRows(sqlmock.NewRows([]string{"id", "host_id", "status", "host_report", "created"}))
 
@@ -178,20 +185,20 @@ func (store *MockHostStatusStore) Search(criteria *models.HostStatusFilterCriter
 	}
 	// Mock query for Reports Controller
 	// Scenario: Host in Connected State
-	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" WHERE \(host_id = \$1\) LIMIT (.+)`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "host_status" WHERE \(host_id = \$1\) ORDER BY (.+) LIMIT (.+)`).
 		WithArgs("ee37c360-7eae-4250-a677-6ee12adce8e2").
 		WillReturnRows(sqlmock.NewRows([]string{"id", "host_id", "status", "host_report", "created"}).
 			AddRow(newUuid, "e57e5ea0-d465-461e-882d-1600090caa0d", hsi1, hsm1, hs1.Created))
 
 	// Search by existing HostHardareUUID
-	store.Mock.ExpectQuery(`SELECT "host_status"\.\* FROM "host_status" INNER JOIN host h on h\.id = host_id WHERE \(h\.hardware_uuid = \$1\) LIMIT (.+)`).
+	store.Mock.ExpectQuery(`SELECT "host_status"\.\* FROM "host_status" INNER JOIN host h on h\.id = host_id WHERE \(h\.hardware_uuid = \$1\) ORDER BY (.+) LIMIT (.+)`).
 		WithArgs("1ad9c003-b0e0-4319-b2b3-06053dfd1407").
 		WillReturnRows(sqlmock.NewRows([]string{"id", "host_id", "status", "host_report", "created"}).
 			AddRow(hs1.ID.String(), hs1.HostID.String(), hsi1, hsm1, hs1.Created).
 			AddRow(hs2.ID.String(), hs2.HostID.String(), hsi2, hsm2, hs2.Created))
 
 	// Search by non-existent HostHardareUUID
-	store.Mock.ExpectQuery(`SELECT "host_status"\.\* FROM "host_status" INNER JOIN host h on h\.id = host_id WHERE \(h\.hardware_uuid = \$1\) LIMIT (.+)`).
+	store.Mock.ExpectQuery(`SELECT "host_status"\.\* FROM "host_status" INNER JOIN host h on h\.id = host_id WHERE \(h\.hardware_uuid = \$1\) ORDER BY (.+) LIMIT (.+)`).
 		WithArgs("7f71bff0-3c12-4f92-9a77-d380eb9ad2e2").
 		WillReturnRows(sqlmock.NewRows([]string{"id", "host_id", "status", "host_report", "created"}))
 
@@ -207,7 +214,7 @@ func (store *MockHostStatusStore) Search(criteria *models.HostStatusFilterCriter
 			AddRow(hs4.ID.String(), hs4.HostID.String(), hsi4, hsm4, hs4.Created))
 
 	// Search by HostName
-	store.Mock.ExpectQuery(`SELECT "host_status"\.\* FROM "host_status" INNER JOIN host h on h\.id = host_id WHERE \(h\.name = \$1\) LIMIT 10000`).
+	store.Mock.ExpectQuery(`SELECT "host_status"\.\* FROM "host_status" INNER JOIN host h on h\.id = host_id WHERE \(h\.name = \$1\) ORDER BY (.+) LIMIT 10000`).
 		WithArgs("computepurley1").
 		WillReturnRows(sqlmock.NewRows([]string{"id", "host_id", "status", "host_report", "created"}).
 			AddRow(hs1.ID.String(), hs1.HostID.String(), hsi1, hsm1, hs1.Created).
@@ -227,7 +234,7 @@ func (store *MockHostStatusStore) Search(criteria *models.HostStatusFilterCriter
 	}
 	// Search by numberOfDays
 	store.Mock.ExpectQuery(`
-SELECT au.\* FROM audit_log_entry au INNER JOIN \(SELECT entity_id, max\(auj.created\) AS max_date FROM audit_log_entry auj WHERE auj.entity_type = 'host_status' AND CAST\(auj.created AS TIMESTAMP\) >= CAST\('(.+)' AS TIMESTAMP\) AND CAST\(auj.created AS TIMESTAMP\) <= CAST\('(.+)' AS TIMESTAMP\)  GROUP BY entity_id\) a ON a.entity_id = au.entity_id AND a.max_date = au.created ORDER BY au.Created DESC LIMIT (.+)`).
+SELECT au.\* FROM audit_log_entry au INNER JOIN \(SELECT entity_id, max\(auj.created\) AS max_date FROM audit_log_entry auj WHERE auj.entity_type = 'host_status' AND CAST\(auj.created AS TIMESTAMP\) >= CAST\('(.+)' AS TIMESTAMP\) AND CAST\(auj.created AS TIMESTAMP\) <= CAST\('(.+)' AS TIMESTAMP\)  GROUP BY entity_id\) a ON a.entity_id = au.entity_id AND a.max_date = au.created ORDER BY (.+) DESC, au.entity_id DESC LIMIT (.+)`).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_id", "entity_type", "created", "action", "data"}).
 			AddRow(newUuid1.String(), hs1.ID.String(), "host_status", time.Now().AddDate(0, 0, -1), "create", []byte(auditData)).
 			AddRow(newUuid2.String(), hs1.ID.String(), "host_status", time.Now().AddDate(0, 0, -1), "create", []byte(auditData)).
@@ -246,7 +253,7 @@ SELECT au.\* FROM audit_log_entry au INNER JOIN \(SELECT entity_id, max\(auj.cre
 		return nil, errors.Wrap(err, "failed to create new UUID")
 	}
 	// Search by fromDate and toDate
-	store.Mock.ExpectQuery(`SELECT au.\* FROM audit_log_entry au INNER JOIN \(SELECT entity_id, max\(auj.created\) AS max_date FROM audit_log_entry auj WHERE auj.entity_type = 'host_status' AND CAST\(auj.created AS TIMESTAMP\) >= CAST\('(.+)' AS TIMESTAMP\) AND CAST\(auj.created AS TIMESTAMP\) <= CAST\('(.+)' AS TIMESTAMP\)  GROUP BY entity_id\) a ON a.entity_id = au.entity_id AND a.max_date = au.created ORDER BY au.Created DESC LIMIT (.+)`).
+	store.Mock.ExpectQuery(`SELECT au.\* FROM audit_log_entry au INNER JOIN \(SELECT entity_id, max\(auj.created\) AS max_date FROM audit_log_entry auj WHERE auj.entity_type = 'host_status' AND CAST\(auj.created AS TIMESTAMP\) >= CAST\('(.+)' AS TIMESTAMP\) AND CAST\(auj.created AS TIMESTAMP\) <= CAST\('(.+)' AS TIMESTAMP\)  GROUP BY entity_id\) a ON a.entity_id = au.entity_id AND a.max_date = au.created ORDER BY (.+) DESC, au.entity_id DESC LIMIT (.+)`).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_id", "entity_type", "created", "action", "data"}).
 			AddRow(newUuid1.String(), hs1.ID.String(), "host_status", time.Now().AddDate(0, 0, -1), "create", []byte(auditData)).
 			AddRow(newUuid2.String(), hs1.ID.String(), "host_status", time.Now().AddDate(0, 0, -1), "create", []byte(auditData)).
@@ -0,0 +1,111 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package mocks
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// MockJobStore provides a mocked implementation of interface domain.JobStore
+type MockJobStore struct {
+	mtx  sync.Mutex
+	jobs map[uuid.UUID]hvs.Job
+}
+
+// Create inserts a Job
+func (store *MockJobStore) Create(j *hvs.Job) (*hvs.Job, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	j.Id = uuid.New()
+	j.Created = time.Now().UTC()
+	j.Updated = j.Created
+	store.jobs[j.Id] = copyJob(j)
+	return j, nil
+}
+
+// Retrieve returns Job
+func (store *MockJobStore) Retrieve(id uuid.UUID) (*hvs.Job, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	if j, ok := store.jobs[id]; ok {
+		j = copyJob(&j)
+		return &j, nil
+	}
+	return nil, errors.New(commErr.RowsNotFound)
+}
+
+// Update updates Job
+func (store *MockJobStore) Update(j *hvs.Job) error {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	if _, ok := store.jobs[j.Id]; !ok {
+		return errors.New(commErr.RowsNotFound)
+	}
+	j.Updated = time.Now().UTC()
+	store.jobs[j.Id] = copyJob(j)
+	return nil
+}
+
+// FailUnfinished marks the queued and running Jobs as failed
+func (store *MockJobStore) FailUnfinished(reason string) (int, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	count := 0
+	for id, j := range store.jobs {
+		if j.Status != hvs.JobStatusQueued && j.Status != hvs.JobStatusRunning {
+			continue
+		}
+		j = copyJob(&j)
+		for i := range j.Hosts {
+			if j.Hosts[i].Status == hvs.JobStatusQueued || j.Hosts[i].Status == hvs.JobStatusRunning {
+				j.Hosts[i].Status = hvs.JobStatusFailed
+				j.Hosts[i].Error = reason
+				j.Failed++
+			}
+		}
+		j.Status = hvs.JobStatusFailed
+		j.Error = reason
+		j.Updated = time.Now().UTC()
+		store.jobs[id] = j
+		count++
+	}
+	return count, nil
+}
+
+// copyJob copies the job entries so that the stored job is not changed by the caller
+func copyJob(j *hvs.Job) hvs.Job {
+	c := *j
+	c.Hosts = append([]hvs.JobHostEntry(nil), j.Hosts...)
+	return c
+}
+
+// NewMockJobStore provides a dummy completed host bulk registration Job
+func NewMockJobStore() *MockJobStore {
+	store := &MockJobStore{jobs: make(map[uuid.UUID]hvs.Job)}
+
+	hostId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+	created := time.Now().UTC()
+	store.jobs[uuid.MustParse("6b4e0b2c-3d09-4c39-8b7e-3e0e9e5a2f61")] = hvs.Job{
+		Id:        uuid.MustParse("6b4e0b2c-3d09-4c39-8b7e-3e0e9e5a2f61"),
+		Type:      hvs.JobTypeHostBulkRegistration,
+		Status:    hvs.JobStatusCompleted,
+		Total:     2,
+		Succeeded: 1,
+		Failed:    1,
+		Hosts: []hvs.JobHostEntry{
+			{HostName: "localhost1", Status: hvs.JobStatusCompleted, HostId: &hostId},
+			{HostName: "localhost5", Status: hvs.JobStatusFailed, Error: "Host with this name already exist"},
+		},
+		Created: created,
+		Updated: created,
+	}
+	return store
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package mocks
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// MockNotificationSubscriptionStore provides a mocked implementation of interface domain.NotificationSubscriptionStore
+type MockNotificationSubscriptionStore struct {
+	mtx           sync.Mutex
+	subscriptions map[uuid.UUID]hvs.NotificationSubscription
+}
+
+// Create inserts a NotificationSubscription
+func (store *MockNotificationSubscriptionStore) Create(ns *hvs.NotificationSubscription) (*hvs.NotificationSubscription, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	if ns.ID == uuid.Nil {
+		ns.ID = uuid.New()
+	}
+	ns.Created = time.Now().UTC()
+	store.subscriptions[ns.ID] = *ns
+	return ns, nil
+}
+
+// Retrieve returns NotificationSubscription
+func (store *MockNotificationSubscriptionStore) Retrieve(id uuid.UUID) (*hvs.NotificationSubscription, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	if ns, ok := store.subscriptions[id]; ok {
+		return &ns, nil
+	}
+	return nil, errors.New(commErr.RowsNotFound)
+}
+
+// Search returns a filtered list of NotificationSubscriptions per the provided NotificationSubscriptionFilterCriteria
+func (store *MockNotificationSubscriptionStore) Search(criteria *models.NotificationSubscriptionFilterCriteria) ([]hvs.NotificationSubscription, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	subscriptions := []hvs.NotificationSubscription{}
+	for _, ns := range store.subscriptions {
+		if criteria != nil {
+			if criteria.Id != uuid.Nil && criteria.Id != ns.ID {
+				continue
+			}
+			if criteria.CallbackURL != "" && criteria.CallbackURL != ns.CallbackURL {
+				continue
+			}
+		}
+		subscriptions = append(subscriptions, ns)
+	}
+	return subscriptions, nil
+}
+
+// Delete deletes NotificationSubscription
+func (store *MockNotificationSubscriptionStore) Delete(id uuid.UUID) error {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	if _, ok := store.subscriptions[id]; !ok {
+		return errors.New(commErr.RowsNotFound)
+	}
+	delete(store.subscriptions, id)
+	return nil
+}
+
+// NewMockNotificationSubscriptionStore provides two dummy data for NotificationSubscriptions
+func NewMockNotificationSubscriptionStore() *MockNotificationSubscriptionStore {
+	store := &MockNotificationSubscriptionStore{subscriptions: make(map[uuid.UUID]hvs.NotificationSubscription)}
+
+	_, _ = store.Create(&hvs.NotificationSubscription{
+		ID:          uuid.MustParse("3c1a0b5e-4d6f-4a1e-9d2b-8f1e2c3d4a5b"),
+		CallbackURL: "https://siem.example.com/hvs/events",
+		Secret:      "0123456789abcdef0123",
+	})
+	_, _ = store.Create(&hvs.NotificationSubscription{
+		ID:          uuid.MustParse("7e2b1c4d-5f6a-4b8c-9d0e-1f2a3b4c5d6e"),
+		CallbackURL: "https://orchestrator.example.com/trust",
+		Secret:      "fedcba9876543210fedc",
+		EventTypes:  []hvs.NotificationEventType{hvs.EventHostTrustChanged},
+	})
+	return store
+}
+
+// MockNotificationDeadLetterStore provides a mocked implementation of interface domain.NotificationDeadLetterStore
+type MockNotificationDeadLetterStore struct {
+	mtx         sync.Mutex
+	deadLetters map[uuid.UUID]hvs.NotificationDeadLetter
+}
+
+// Create inserts a NotificationDeadLetter
+func (store *MockNotificationDeadLetterStore) Create(dl *hvs.NotificationDeadLetter) (*hvs.NotificationDeadLetter, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	if dl.ID == uuid.Nil {
+		dl.ID = uuid.New()
+	}
+	dl.Created = time.Now().UTC()
+	store.deadLetters[dl.ID] = *dl
+	return dl, nil
+}
+
+// Search returns a filtered list of NotificationDeadLetters per the provided NotificationDeadLetterFilterCriteria
+func (store *MockNotificationDeadLetterStore) Search(criteria *models.NotificationDeadLetterFilterCriteria) ([]hvs.NotificationDeadLetter, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	deadLetters := []hvs.NotificationDeadLetter{}
+	for _, dl := range store.deadLetters {
+		if criteria != nil {
+			if criteria.Id != uuid.Nil && criteria.Id != dl.ID {
+				continue
+			}
+			if criteria.SubscriptionId != uuid.Nil && criteria.SubscriptionId != dl.SubscriptionID {
+				continue
+			}
+			if criteria.Limit > 0 && len(deadLetters) >= criteria.Limit {
+				break
+			}
+		}
+		deadLetters = append(deadLetters, dl)
+	}
+	return deadLetters, nil
+}
+
+// Delete deletes NotificationDeadLetter
+func (store *MockNotificationDeadLetterStore) Delete(id uuid.UUID) error {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	if _, ok := store.deadLetters[id]; !ok {
+		return errors.New(commErr.RowsNotFound)
+	}
+	delete(store.deadLetters, id)
+	return nil
+}
+
+// NewMockNotificationDeadLetterStore provides one dummy data for NotificationDeadLetters
+func NewMockNotificationDeadLetterStore() *MockNotificationDeadLetterStore {
+	store := &MockNotificationDeadLetterStore{deadLetters: make(map[uuid.UUID]hvs.NotificationDeadLetter)}
+
+	trusted := false
+	_, _ = store.Create(&hvs.NotificationDeadLetter{
+		ID:             uuid.MustParse("9a8b7c6d-5e4f-4a3b-8c1d-0e9f8a7b6c5d"),
+		SubscriptionID: uuid.MustParse("3c1a0b5e-4d6f-4a1e-9d2b-8f1e2c3d4a5b"),
+		CallbackURL:    "https://siem.example.com/hvs/events",
+		Event: hvs.NotificationEvent{
+			ID:      uuid.MustParse("1b2c3d4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e"),
+			Type:    hvs.EventHostTrustChanged,
+			HostID:  uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2"),
+			Trusted: &trusted,
+		},
+		Attempts:  5,
+		LastError: "Webhook responded with status code 503",
+	})
+	return store
+}
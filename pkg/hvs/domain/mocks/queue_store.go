@@ -10,23 +10,43 @@ import (
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	"sort"
+	"sync"
 	"time"
 )
 
 type qStore struct {
-	m map[uuid.UUID]models.Queue
+	m   map[uuid.UUID]models.Queue
+	mtx sync.Mutex
 }
 
 func NewQueueStore() domain.QueueStore {
 
-	return &qStore{make(map[uuid.UUID]models.Queue)}
+	return &qStore{m: make(map[uuid.UUID]models.Queue)}
 }
 
 func (qs *qStore) Search(criteria *models.QueueFilterCriteria) ([]*models.Queue, error) {
+	qs.mtx.Lock()
+	defer qs.mtx.Unlock()
+
 	if criteria == nil || criteria.Id == uuid.Nil {
 		rslt := make([]*models.Queue, 0, len(qs.m))
 		for _, v := range qs.m {
-			rslt = append(rslt, &v)
+			if criteria != nil && !queueMatches(criteria, &v) {
+				continue
+			}
+			cp := v
+			rslt = append(rslt, &cp)
+		}
+		sort.Slice(rslt, func(i, j int) bool {
+			if queuePriority(rslt[i]) != queuePriority(rslt[j]) {
+				return queuePriority(rslt[i]) < queuePriority(rslt[j])
+			}
+			return rslt[i].Created.Before(rslt[j].Created)
+		})
+		if criteria != nil && criteria.Limit > 0 && len(rslt) > criteria.Limit {
+			rslt = rslt[:criteria.Limit]
 		}
 		return rslt, nil
 	}
@@ -37,26 +57,76 @@ func (qs *qStore) Search(criteria *models.QueueFilterCriteria) ([]*models.Queue,
 	return nil, errors.New("No Records fouund")
 }
 
+func queuePriority(q *models.Queue) models.QueuePriority {
+	if q.Priority == models.QueuePriorityUnknown {
+		return models.QueuePriorityHigh
+	}
+	return q.Priority
+}
+
+func queueMatches(criteria *models.QueueFilterCriteria, q *models.Queue) bool {
+	if criteria.HostId != uuid.Nil {
+		if hostId, err := q.UUIDParam("host_id"); err != nil || hostId != criteria.HostId {
+			return false
+		}
+	}
+	if len(criteria.Stages) > 0 {
+		found := false
+		for _, stg := range criteria.Stages {
+			if q.Stage == stg {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if criteria.Priority != models.QueuePriorityUnknown && queuePriority(q) != criteria.Priority {
+		return false
+	}
+	if !criteria.CreatedBefore.IsZero() && !q.Created.Before(criteria.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
 func (qs *qStore) Retrieve(uuid uuid.UUID) (*models.Queue, error) {
+	qs.mtx.Lock()
+	defer qs.mtx.Unlock()
+
 	if _, ok := qs.m[uuid]; ok {
 		cp := qs.m[uuid]
 		return &cp, nil
 	}
-	return nil, errors.New("Record not fouund")
+	return nil, errors.New(commErr.RowsNotFound)
 }
 
 func (qs *qStore) Update(queue *models.Queue) error {
+	qs.mtx.Lock()
+	defer qs.mtx.Unlock()
+
 	if rec, ok := qs.m[queue.Id]; ok {
 
+		params := make(map[string]interface{}, len(rec.Params))
+		for k, v := range rec.Params {
+			params[k] = v
+		}
 		for k, v := range queue.Params {
-			rec.Params[k] = v
+			params[k] = v
 		}
+		rec.Params = params
 		if queue.State > 0 {
 			rec.State = queue.State
 		}
 		if queue.Action != "" {
 			rec.Action = queue.Action
 		}
+		if queue.Priority > 0 {
+			rec.Priority = queue.Priority
+		}
+		if queue.Stage > 0 {
+			rec.Stage = queue.Stage
+		}
 		rec.Updated = time.Now()
 		qs.m[queue.Id] = rec
 
@@ -66,6 +136,9 @@ func (qs *qStore) Update(queue *models.Queue) error {
 }
 
 func (qs *qStore) Create(queue *models.Queue) (*models.Queue, error) {
+	qs.mtx.Lock()
+	defer qs.mtx.Unlock()
+
 	rec := *queue
 	newUuid, err := uuid.NewRandom()
 	if err != nil {
@@ -74,12 +147,19 @@ func (qs *qStore) Create(queue *models.Queue) (*models.Queue, error) {
 	rec.Id = newUuid
 	rec.Created = time.Now()
 	rec.Updated = rec.Created
+	rec.Params = make(map[string]interface{}, len(queue.Params))
+	for k, v := range queue.Params {
+		rec.Params[k] = v
+	}
 	qs.m[rec.Id] = rec
 	cp := rec
 	return &cp, nil
 }
 
 func (qs *qStore) Delete(uuid uuid.UUID) error {
+	qs.mtx.Lock()
+	defer qs.mtx.Unlock()
+
 	if _, ok := qs.m[uuid]; ok {
 		delete(qs.m, uuid)
 		return nil
@@ -124,6 +124,20 @@ func (store *MockReportStore) Search(criteria *models.ReportFilterCriteria) ([]m
 	return reports, nil
 }
 
+//Iterate calls visit with each HVSReport filtered as per ReportFilterCriteria
+func (store *MockReportStore) Iterate(criteria *models.ReportFilterCriteria, visit func(*models.HVSReport) error) error {
+	reports, err := store.Search(criteria)
+	if err != nil {
+		return err
+	}
+	for i := range reports {
+		if err := visit(&reports[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (store *MockReportStore) FindHostIdsFromExpiredReports(fromTime time.Time, toTime time.Time) ([]uuid.UUID, error) {
 	hostIDs := []uuid.UUID{}
 
@@ -36,6 +36,9 @@ func (m *MockTpmEndorsementStore) Create(te *hvs.TpmEndorsement) (*hvs.TpmEndors
 }
 
 func (m *MockTpmEndorsementStore) Update(arg0 *hvs.TpmEndorsement) (*hvs.TpmEndorsement, error) {
+	if _, ok := m.tpmEndorsementStores[arg0.ID]; ok {
+		m.tpmEndorsementStores[arg0.ID] = arg0
+	}
 	return arg0, nil
 }
 
@@ -0,0 +1,24 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AikCertificateFilterCriteria struct {
+	Id                         uuid.UUID
+	HardwareUUID               uuid.UUID
+	SerialNumberEqualTo        string
+	EkCertificateDigestEqualTo string
+	Revoked                    *bool
+	// ExpiresBefore selects the AIK certificates that expire before the time, when not zero
+	ExpiresBefore time.Time
+	// ExpiresAfter selects the AIK certificates that expire after the time, when not zero
+	ExpiresAfter time.Time
+}
@@ -0,0 +1,16 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package models
+
+import "github.com/google/uuid"
+
+type EndorsementAuthorityFilterCriteria struct {
+	Id                  uuid.UUID
+	NameEqualTo         string
+	NameContains        string
+	ManufacturerEqualTo string
+	Disabled            *bool
+}
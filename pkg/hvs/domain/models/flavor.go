@@ -29,6 +29,8 @@ type FlavorFilterCriteria struct {
 	Value         string
 	FlavorgroupID uuid.UUID
 	FlavorParts   []cf.FlavorPart
+	Limit         int
+	After         *PageCursor
 }
 
 type FlavorVerificationFC struct {
@@ -0,0 +1,21 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+)
+
+// FlavorgroupBundleImport holds the items of a flavorgroup bundle created on import
+type FlavorgroupBundleImport struct {
+	// FlavorGroup is created when its ID is nil, otherwise the flavors are linked to the existing flavorgroup
+	FlavorGroup     hvs.FlavorGroup
+	FlavorTemplates []hvs.FlavorTemplate
+	SignedFlavors   []hvs.SignedFlavor
+	// FlavorIds are the ids of the flavors linked to the flavorgroup, the ones that are already linked are skipped
+	FlavorIds []uuid.UUID
+}
@@ -11,4 +11,6 @@ type FlavorGroupFilterCriteria struct {
 	FlavorId     *uuid.UUID
 	NameEqualTo  string
 	NameContains string
+	// HasHostLabelSelector selects the flavorgroups bound to a host label selector
+	HasHostLabelSelector bool
 }
@@ -18,7 +18,10 @@ type HostFilterCriteria struct {
 	Value          string
 	IdList         []uuid.UUID
 	Trusted        *bool
+	LabelSelector  *LabelSelector
 	OrderBy        OrderType
+	Limit          int
+	After          *PageCursor
 }
 
 type OrderType string
@@ -13,6 +13,7 @@ import (
 type HostStatusFilterCriteria struct {
 	Id             uuid.UUID
 	HostId         uuid.UUID
+	HostIds        []uuid.UUID
 	HostHardwareId uuid.UUID
 	HostName       string
 	HostStatus     string
@@ -20,5 +21,7 @@ type HostStatusFilterCriteria struct {
 	ToDate         time.Time
 	LatestPerHost  bool
 	NumberOfDays   int
+	LabelSelector  *LabelSelector
 	Limit          int
+	After          *PageCursor
 }
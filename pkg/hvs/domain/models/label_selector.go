@@ -0,0 +1,256 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package models
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SelectorOperator is the operator of a label selector requirement
+type SelectorOperator string
+
+const (
+	SelectorEquals       SelectorOperator = "="
+	SelectorNotEquals    SelectorOperator = "!="
+	SelectorIn           SelectorOperator = "in"
+	SelectorNotIn        SelectorOperator = "notin"
+	SelectorExists       SelectorOperator = "exists"
+	SelectorDoesNotExist SelectorOperator = "!"
+)
+
+const (
+	maxLabelNameLength   = 63
+	maxLabelPrefixLength = 253
+	MaxLabelsPerHost     = 64
+)
+
+var (
+	labelNameRegex   = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	labelPrefixRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+	setRequirementRe = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\(([^()]*)\)$`)
+)
+
+// LabelRequirement is a single condition of a label selector on the value of a label
+type LabelRequirement struct {
+	Key      string
+	Operator SelectorOperator
+	Values   []string
+}
+
+// LabelSelector selects hosts by their labels, with the Kubernetes label selector syntax:
+// env=prod,tier!=db,rack in (a,b),zone notin (z1),gpu,!maintenance
+// A host is selected when it meets all of the requirements.
+type LabelSelector struct {
+	Requirements []LabelRequirement
+}
+
+// ParseLabelSelector parses a comma separated list of label requirements
+func ParseLabelSelector(selector string) (*LabelSelector, error) {
+	terms, err := splitSelectorTerms(selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(terms) == 0 {
+		return nil, errors.New("Label selector must contain at least one requirement")
+	}
+
+	ls := LabelSelector{}
+	for _, term := range terms {
+		requirement, err := parseLabelRequirement(term)
+		if err != nil {
+			return nil, err
+		}
+		ls.Requirements = append(ls.Requirements, *requirement)
+	}
+	return &ls, nil
+}
+
+// splitSelectorTerms splits the selector on the commas that are not within the value set of an in/notin requirement
+func splitSelectorTerms(selector string) ([]string, error) {
+	var terms []string
+	depth := 0
+	start := 0
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, errors.New("Unbalanced parentheses in label selector")
+			}
+		case ',':
+			if depth == 0 {
+				terms = append(terms, strings.TrimSpace(selector[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, errors.New("Unbalanced parentheses in label selector")
+	}
+	last := strings.TrimSpace(selector[start:])
+	if last != "" || len(terms) > 0 {
+		terms = append(terms, last)
+	}
+	for _, term := range terms {
+		if term == "" {
+			return nil, errors.New("Empty requirement in label selector")
+		}
+	}
+	return terms, nil
+}
+
+func parseLabelRequirement(term string) (*LabelRequirement, error) {
+	var requirement LabelRequirement
+
+	if match := setRequirementRe.FindStringSubmatch(term); match != nil {
+		requirement.Key = match[1]
+		requirement.Operator = SelectorOperator(match[2])
+		for _, value := range strings.Split(match[3], ",") {
+			requirement.Values = append(requirement.Values, strings.TrimSpace(value))
+		}
+	} else if strings.HasPrefix(term, "!") && !strings.Contains(term, "=") {
+		requirement.Key = strings.TrimSpace(term[1:])
+		requirement.Operator = SelectorDoesNotExist
+	} else if idx := strings.Index(term, "!="); idx >= 0 {
+		requirement.Key = strings.TrimSpace(term[:idx])
+		requirement.Operator = SelectorNotEquals
+		requirement.Values = []string{strings.TrimSpace(term[idx+2:])}
+	} else if idx := strings.Index(term, "=="); idx >= 0 {
+		requirement.Key = strings.TrimSpace(term[:idx])
+		requirement.Operator = SelectorEquals
+		requirement.Values = []string{strings.TrimSpace(term[idx+2:])}
+	} else if idx := strings.Index(term, "="); idx >= 0 {
+		requirement.Key = strings.TrimSpace(term[:idx])
+		requirement.Operator = SelectorEquals
+		requirement.Values = []string{strings.TrimSpace(term[idx+1:])}
+	} else {
+		requirement.Key = term
+		requirement.Operator = SelectorExists
+	}
+
+	if err := ValidateLabelKey(requirement.Key); err != nil {
+		return nil, errors.Wrapf(err, "Invalid label selector requirement '%s'", term)
+	}
+	for _, value := range requirement.Values {
+		if err := ValidateLabelValue(value); err != nil {
+			return nil, errors.Wrapf(err, "Invalid label selector requirement '%s'", term)
+		}
+	}
+	return &requirement, nil
+}
+
+// Matches returns true if the labels meet all of the requirements of the selector
+func (ls *LabelSelector) Matches(labels map[string]string) bool {
+	for _, requirement := range ls.Requirements {
+		if !requirement.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches returns true if the labels meet the requirement. As in Kubernetes, != and notin also select the labels
+// that do not have the key.
+func (lr *LabelRequirement) Matches(labels map[string]string) bool {
+	value, exists := labels[lr.Key]
+	switch lr.Operator {
+	case SelectorEquals:
+		return exists && value == lr.Values[0]
+	case SelectorNotEquals:
+		return !exists || value != lr.Values[0]
+	case SelectorIn:
+		return exists && containsString(lr.Values, value)
+	case SelectorNotIn:
+		return !exists || !containsString(lr.Values, value)
+	case SelectorExists:
+		return exists
+	case SelectorDoesNotExist:
+		return !exists
+	}
+	return false
+}
+
+// String returns the selector in its canonical form
+func (ls *LabelSelector) String() string {
+	terms := make([]string, 0, len(ls.Requirements))
+	for _, requirement := range ls.Requirements {
+		terms = append(terms, requirement.String())
+	}
+	return strings.Join(terms, ",")
+}
+
+func (lr *LabelRequirement) String() string {
+	switch lr.Operator {
+	case SelectorExists:
+		return lr.Key
+	case SelectorDoesNotExist:
+		return "!" + lr.Key
+	case SelectorIn, SelectorNotIn:
+		values := append([]string(nil), lr.Values...)
+		sort.Strings(values)
+		return lr.Key + " " + string(lr.Operator) + " (" + strings.Join(values, ",") + ")"
+	}
+	return lr.Key + string(lr.Operator) + lr.Values[0]
+}
+
+// ValidateLabelKey checks a label key is an optional DNS subdomain prefix and a name of at most 63 characters,
+// separated by a slash
+func ValidateLabelKey(key string) error {
+	name := key
+	if idx := strings.LastIndex(key, "/"); idx >= 0 {
+		prefix := key[:idx]
+		name = key[idx+1:]
+		if len(prefix) == 0 || len(prefix) > maxLabelPrefixLength || !labelPrefixRegex.MatchString(prefix) {
+			return errors.Errorf("Invalid label key prefix '%s'", prefix)
+		}
+	}
+	if len(name) == 0 || len(name) > maxLabelNameLength || !labelNameRegex.MatchString(name) {
+		return errors.Errorf("Invalid label key '%s'", key)
+	}
+	return nil
+}
+
+// ValidateLabelValue checks a label value is empty or has at most 63 alphanumeric characters, dashes, underscores
+// and dots, beginning and ending with an alphanumeric character
+func ValidateLabelValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > maxLabelNameLength || !labelNameRegex.MatchString(value) {
+		return errors.Errorf("Invalid label value '%s'", value)
+	}
+	return nil
+}
+
+// ValidateLabels checks the keys and values of a set of labels
+func ValidateLabels(labels map[string]string) error {
+	if len(labels) > MaxLabelsPerHost {
+		return errors.Errorf("A host can have at most %d labels", MaxLabelsPerHost)
+	}
+	for key, value := range labels {
+		if err := ValidateLabelKey(key); err != nil {
+			return err
+		}
+		if err := ValidateLabelValue(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
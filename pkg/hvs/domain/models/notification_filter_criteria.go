@@ -0,0 +1,19 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package models
+
+import "github.com/google/uuid"
+
+type NotificationSubscriptionFilterCriteria struct {
+	Id          uuid.UUID
+	CallbackURL string
+}
+
+type NotificationDeadLetterFilterCriteria struct {
+	Id             uuid.UUID
+	SubscriptionId uuid.UUID
+	Limit          int
+}
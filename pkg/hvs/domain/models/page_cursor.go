@@ -0,0 +1,42 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// PageCursor is the position of the last record of a page in the sort order of a search. The next page holds the
+// records sorted after it. Key is the value of the sort column, if the records are not sorted on their Id only.
+type PageCursor struct {
+	Key string    `json:"key,omitempty"`
+	Id  uuid.UUID `json:"id"`
+}
+
+// String encodes the cursor as the opaque value of the after query parameter
+func (c PageCursor) String() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ParsePageCursor decodes the value of the after query parameter
+func ParsePageCursor(cursor string) (*PageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid page cursor encoding")
+	}
+	var c PageCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, errors.Wrap(err, "Invalid page cursor content")
+	}
+	if c.Id == uuid.Nil {
+		return nil, errors.New("Page cursor must contain a record Id")
+	}
+	return &c, nil
+}
@@ -7,10 +7,14 @@ package models
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models/taskstage"
+	"github.com/pkg/errors"
 )
 
 type QueueFilterCriteria struct {
@@ -20,7 +24,12 @@ type QueueFilterCriteria struct {
 	ParamValue  string
 	ParamMap    map[string]string
 	QueueStates []QueueState
-	Limit       int
+	// HostId selects the records whose host_id parameter is the given host
+	HostId        uuid.UUID
+	Stages        []taskstage.Stage
+	Priority      QueuePriority
+	CreatedBefore time.Time
+	Limit         int
 }
 
 type QueueState int
@@ -88,12 +97,85 @@ func (s *QueueState) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// QueuePriority is the lane in which a queued record is processed. Records of a higher priority lane are
+// processed first, the zero value is read as QueuePriorityHigh.
+type QueuePriority int
+
+const (
+	QueuePriorityUnknown QueuePriority = iota
+	// QueuePriorityHigh is the priority of the work requested through the API
+	QueuePriorityHigh
+	// QueuePriorityLow is the priority of the background work such as the refresh of the expiring reports
+	QueuePriorityLow
+)
+
+var qpriorityToString = [...]string{
+	QueuePriorityUnknown: "HIGH",
+	QueuePriorityHigh:    "HIGH",
+	QueuePriorityLow:     "LOW",
+}
+
+func (p QueuePriority) String() string {
+	if p < QueuePriorityUnknown || p > QueuePriorityLow {
+		return qpriorityToString[QueuePriorityUnknown]
+	}
+	return qpriorityToString[p]
+}
+
+// ParseQueuePriority returns the priority with the given name, or false if there is none
+func ParseQueuePriority(name string) (QueuePriority, bool) {
+	switch strings.ToUpper(name) {
+	case "HIGH":
+		return QueuePriorityHigh, true
+	case "LOW":
+		return QueuePriorityLow, true
+	}
+	return QueuePriorityUnknown, false
+}
+
+type priorityKey int
+
+const queuePriorityKey priorityKey = 0
+
+// NewQueuePriorityContext returns a context carrying the priority of the work it is used for
+func NewQueuePriorityContext(ctx context.Context, p QueuePriority) context.Context {
+	return context.WithValue(ctx, queuePriorityKey, p)
+}
+
+// QueuePriorityFromContext returns the priority carried by the context, QueuePriorityHigh if there is none
+func QueuePriorityFromContext(ctx context.Context) QueuePriority {
+	if p, ok := ctx.Value(queuePriorityKey).(QueuePriority); ok && p != QueuePriorityUnknown {
+		return p
+	}
+	return QueuePriorityHigh
+}
+
 type Queue struct {
-	Id      uuid.UUID              `json:"id,omitempty"`
-	Action  string                 `json:"action"`
-	Params  map[string]interface{} `json:"action_params"`
-	Created time.Time              `json:"created,omitempty"`
-	Updated time.Time              `json:"updated,omitempty"`
-	State   QueueState             `json:"state"`
-	Message string                 `json:"message,omitempty"`
+	Id       uuid.UUID              `json:"id,omitempty"`
+	Action   string                 `json:"action"`
+	Params   map[string]interface{} `json:"action_params"`
+	Created  time.Time              `json:"created,omitempty"`
+	Updated  time.Time              `json:"updated,omitempty"`
+	State    QueueState             `json:"state"`
+	Message  string                 `json:"message,omitempty"`
+	Priority QueuePriority          `json:"priority"`
+	// Stage is the progress of the task of the record
+	Stage taskstage.Stage `json:"stage"`
+}
+
+// UUIDParam returns the value of a UUID parameter, stored as a string once read back from the store
+func (q *Queue) UUIDParam(key string) (uuid.UUID, error) {
+	switch v := q.Params[key].(type) {
+	case uuid.UUID:
+		return v, nil
+	case string:
+		return uuid.Parse(v)
+	}
+	return uuid.Nil, errors.New("parameter " + key + " is not a UUID")
+}
+
+// BoolParam returns the value of a boolean parameter, false if it is not set
+func (q *Queue) BoolParam(key string) bool {
+	v, _ := q.Params[key].(bool)
+	return v
 }
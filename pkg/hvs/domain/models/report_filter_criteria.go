@@ -20,6 +20,7 @@ type ReportFilterCriteria struct {
 	FromDate       time.Time
 	ToDate         time.Time
 	LatestPerHost  bool
+	LabelSelector  *LabelSelector
 	Limit          int
 }
 
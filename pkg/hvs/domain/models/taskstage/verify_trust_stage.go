@@ -7,6 +7,8 @@ package taskstage
 
 import (
 	"context"
+	"strings"
+	"sync"
 )
 
 type Stage int
@@ -21,27 +23,74 @@ const (
 	ReportCreationDone
 )
 
+var stageToString = [...]string{
+	DoNotUse:              "UNKNOWN",
+	FlavorVerifyQueued:    "FLAVOR_VERIFY_QUEUED",
+	FlavorVerifyStarted:   "FLAVOR_VERIFY_STARTED",
+	GetHostDataQueued:     "GET_HOST_DATA_QUEUED",
+	GetHostDataStarted:    "GET_HOST_DATA_STARTED",
+	ReportCreationStarted: "REPORT_CREATION_STARTED",
+	ReportCreationDone:    "REPORT_CREATION_DONE",
+}
+
+func (stg Stage) String() string {
+	if stg < DoNotUse || int(stg) >= len(stageToString) {
+		return stageToString[DoNotUse]
+	}
+	return stageToString[stg]
+}
+
+// Parse returns the stage with the given name, or false if there is none
+func Parse(name string) (Stage, bool) {
+	for stg, str := range stageToString {
+		if Stage(stg) != DoNotUse && strings.EqualFold(str, name) {
+			return Stage(stg), true
+		}
+	}
+	return DoNotUse, false
+}
+
 type key int
 
 const stageKey = 0
 
+// stageHolder is shared by the goroutines working on a task
+type stageHolder struct {
+	mtx      sync.Mutex
+	stage    Stage
+	listener func(Stage)
+}
+
 func NewContext(ctx context.Context, stg Stage) context.Context {
-	return context.WithValue(ctx, stageKey, &stg)
+	return context.WithValue(ctx, stageKey, &stageHolder{stage: stg})
+}
+
+// NewContextWithListener returns a context holding the stage, that calls the listener each time a new stage is
+// stored in it. It is used to persist the progress of a task.
+func NewContextWithListener(ctx context.Context, stg Stage, listener func(Stage)) context.Context {
+	return context.WithValue(ctx, stageKey, &stageHolder{stage: stg, listener: listener})
 }
 
 func FromContext(ctx context.Context) (Stage, bool) {
-	if stg, ok := ctx.Value(stageKey).(*Stage); ok {
-		return *stg, ok
+	if h, ok := ctx.Value(stageKey).(*stageHolder); ok {
+		h.mtx.Lock()
+		defer h.mtx.Unlock()
+		return h.stage, ok
 	}
 	return DoNotUse, false
 
 }
 
 func StoreInContext(ctx context.Context, stg Stage) bool {
-	if s, ok := ctx.Value(stageKey).(*Stage); !ok {
+	if h, ok := ctx.Value(stageKey).(*stageHolder); !ok {
 		return ok
 	} else {
-		*s = stg
+		h.mtx.Lock()
+		defer h.mtx.Unlock()
+		h.stage = stg
+		if h.listener != nil {
+			h.listener(stg)
+		}
 		return true
 	}
 }
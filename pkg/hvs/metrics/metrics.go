@@ -0,0 +1,124 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+// metrics package defines the metrics of the HVS verification pipeline, served on the /metrics endpoint
+package metrics
+
+import (
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Registry holds all the HVS metrics
+var Registry = prometheus.NewRegistry()
+
+const (
+	// queue label values of the queue depth gauges
+	QueueFlavorVerify   = "flavor-verify"
+	QueueHostDataVerify = "host-data-verify"
+	QueueHostFetch      = "host-fetch"
+	QueueHostFetchRetry = "host-fetch-retry"
+	// low priority lanes of the queues, holding the work of the host report refresher
+	QueueFlavorVerifyLow   = "flavor-verify-low"
+	QueueHostDataVerifyLow = "host-data-verify-low"
+	QueueHostFetchLow      = "host-fetch-low"
+
+	ResultSuccess = "success"
+	ResultError   = "error"
+	ResultHit     = "hit"
+	ResultMiss    = "miss"
+)
+
+var (
+	QueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hvs_queue_depth",
+		Help: "Number of items waiting in the internal queues of the host trust manager and host fetcher",
+	}, []string{"queue"})
+
+	VerificationWorkers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "hvs_verification_workers",
+		Help: "Number of flavor verification workers",
+	})
+	VerificationsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "hvs_verifications_in_flight",
+		Help: "Number of flavor verifications in progress",
+	})
+	VerificationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "hvs_verification_duration_seconds",
+		Help:    "Latency of flavor verifications by result",
+		Buckets: metrics.DefBuckets,
+	}, []string{"result"})
+
+	HostFetchWorkers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "hvs_host_fetch_workers",
+		Help: "Number of host data fetch workers",
+	})
+	HostFetchesInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "hvs_host_fetches_in_flight",
+		Help: "Number of host data fetches in progress",
+	})
+	HostFetchDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "hvs_host_fetch_duration_seconds",
+		Help:    "Latency of host data fetches by vendor connector and result",
+		Buckets: metrics.DefBuckets,
+	}, []string{"vendor", "result"})
+	HostFetchRetries = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "hvs_host_fetch_retries_total",
+		Help: "Number of host data fetches scheduled for retry after a failure",
+	})
+
+	HostTrustCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hvs_host_trust_cache_requests_total",
+		Help: "Number of host trust cache lookups by result, a hit reuses the cached trust report of an unchanged quote",
+	}, []string{"result"})
+	HostTrustCacheEntries = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "hvs_host_trust_cache_entries",
+		Help: "Number of entries in the host trust cache",
+	})
+	HostTrustCacheCapacity = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "hvs_host_trust_cache_capacity",
+		Help: "Maximum number of entries in the host trust cache, as set by the host trust cache threshold",
+	})
+
+	RuleFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hvs_rule_failures_total",
+		Help: "Number of failed flavor verification rules by rule name",
+	}, []string{"rule"})
+
+	HrrsRefreshes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hvs_hrrs_refreshes_total",
+		Help: "Number of host report refresh cycles by result",
+	}, []string{"result"})
+	HrrsHostsQueued = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "hvs_hrrs_hosts_queued_total",
+		Help: "Number of hosts queued for verification by the host report refresher",
+	})
+	HrrsLastRefresh = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "hvs_hrrs_last_refresh_timestamp_seconds",
+		Help: "Unix time of the last successful host report refresh cycle",
+	})
+)
+
+func init() {
+	Registry.MustRegister(QueueDepth, VerificationWorkers, VerificationsInFlight, VerificationDuration,
+		HostFetchWorkers, HostFetchesInFlight, HostFetchDuration, HostFetchRetries,
+		HostTrustCacheRequests, HostTrustCacheEntries, HostTrustCacheCapacity, RuleFailures,
+		HrrsRefreshes, HrrsHostsQueued, HrrsLastRefresh)
+}
+
+// QueueCallbacks returns the callbacks counting the items of a work queue in its depth gauge, to be passed to
+// chnlworkq.New. procReq is called on the requests before they are queued and can be nil.
+func QueueCallbacks(queue string, procReq func(interface{}) interface{}) (func(interface{}) interface{}, func(interface{})) {
+	depth := QueueDepth.WithLabelValues(queue)
+	return func(r interface{}) interface{} {
+			depth.Inc()
+			if procReq != nil {
+				return procReq(r)
+			}
+			return r
+		}, func(interface{}) {
+			depth.Dec()
+		}
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package postgres
+
+import (
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+type AikCertificateStore struct {
+	Store *DataStore
+}
+
+func NewAikCertificateStore(store *DataStore) *AikCertificateStore {
+	return &AikCertificateStore{store}
+}
+
+func (acs *AikCertificateStore) Create(ac *hvs.AikCertificate) (*hvs.AikCertificate, error) {
+	defaultLog.Trace("postgres/aik_certificate_store:Create() Entering")
+	defer defaultLog.Trace("postgres/aik_certificate_store:Create() Leaving")
+
+	if ac.ID == uuid.Nil {
+		newUuid, err := uuid.NewRandom()
+		if err != nil {
+			return nil, errors.Wrap(err, "postgres/aik_certificate_store:Create() failed to create new UUID")
+		}
+		ac.ID = newUuid
+	}
+
+	dbAikCertificate := toDbAikCertificate(ac)
+	if err := acs.Store.Db.Create(&dbAikCertificate).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/aik_certificate_store:Create() Failed to create AIK certificate")
+	}
+	return ac, nil
+}
+
+func (acs *AikCertificateStore) Update(ac *hvs.AikCertificate) (*hvs.AikCertificate, error) {
+	defaultLog.Trace("postgres/aik_certificate_store:Update() Entering")
+	defer defaultLog.Trace("postgres/aik_certificate_store:Update() Leaving")
+
+	dbAikCertificate := toDbAikCertificate(ac)
+	if err := acs.Store.Db.Save(&dbAikCertificate).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/aik_certificate_store:Update() Failed to save AIK certificate")
+	}
+	return ac, nil
+}
+
+func (acs *AikCertificateStore) Retrieve(id uuid.UUID) (*hvs.AikCertificate, error) {
+	defaultLog.Trace("postgres/aik_certificate_store:Retrieve() Entering")
+	defer defaultLog.Trace("postgres/aik_certificate_store:Retrieve() Leaving")
+
+	row := acs.Store.Db.Model(&aikCertificate{}).Where(&aikCertificate{ID: id}).Row()
+	ac := hvs.AikCertificate{}
+	var revocationReason string
+	if err := row.Scan(&ac.ID, &ac.HardwareUUID, &ac.SerialNumber, &ac.EkCertificateDigest, &ac.Certificate, &ac.Issued,
+		&ac.Expiry, &ac.Revoked, &ac.RevocationTime, &revocationReason); err != nil {
+		return nil, errors.Wrap(err, "postgres/aik_certificate_store:Retrieve() Failed to scan record")
+	}
+	ac.RevocationReason = hvs.AikRevocationReason(revocationReason)
+	return &ac, nil
+}
+
+func (acs *AikCertificateStore) Search(criteria *models.AikCertificateFilterCriteria) ([]hvs.AikCertificate, error) {
+	defaultLog.Trace("postgres/aik_certificate_store:Search() Entering")
+	defer defaultLog.Trace("postgres/aik_certificate_store:Search() Leaving")
+
+	tx := buildAikCertificateSearchQuery(acs.Store.Db, criteria)
+	if tx == nil {
+		return nil, errors.New("postgres/aik_certificate_store:Search() Unexpected Error. Could not build" +
+			" a gorm query object.")
+	}
+
+	rows, err := tx.Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/aik_certificate_store:Search() Failed to retrieve records from db")
+	}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing rows")
+		}
+	}()
+
+	aikCertificates := []hvs.AikCertificate{}
+	for rows.Next() {
+		ac := hvs.AikCertificate{}
+		var revocationReason string
+		if err := rows.Scan(&ac.ID, &ac.HardwareUUID, &ac.SerialNumber, &ac.EkCertificateDigest, &ac.Certificate, &ac.Issued,
+			&ac.Expiry, &ac.Revoked, &ac.RevocationTime, &revocationReason); err != nil {
+			return nil, errors.Wrap(err, "postgres/aik_certificate_store:Search() Failed to scan record")
+		}
+		ac.RevocationReason = hvs.AikRevocationReason(revocationReason)
+		aikCertificates = append(aikCertificates, ac)
+	}
+	return aikCertificates, nil
+}
+
+func toDbAikCertificate(ac *hvs.AikCertificate) aikCertificate {
+	return aikCertificate{
+		ID:                  ac.ID,
+		HardwareUUID:        ac.HardwareUUID,
+		SerialNumber:        ac.SerialNumber,
+		EkCertificateDigest: ac.EkCertificateDigest,
+		Certificate:         ac.Certificate,
+		Issued:              ac.Issued,
+		Expiry:              ac.Expiry,
+		Revoked:             ac.Revoked,
+		RevocationTime:      ac.RevocationTime,
+		RevocationReason:    string(ac.RevocationReason),
+	}
+}
+
+// helper function to build the query object for an AIK certificate search.
+func buildAikCertificateSearchQuery(tx *gorm.DB, criteria *models.AikCertificateFilterCriteria) *gorm.DB {
+	defaultLog.Trace("postgres/aik_certificate_store:buildAikCertificateSearchQuery() Entering")
+	defer defaultLog.Trace("postgres/aik_certificate_store:buildAikCertificateSearchQuery() Leaving")
+
+	if tx == nil {
+		return nil
+	}
+	tx = tx.Model(&aikCertificate{})
+	if criteria == nil {
+		return tx.Order("issued")
+	}
+	if criteria.Id != uuid.Nil {
+		tx = tx.Where("id = ?", criteria.Id)
+	}
+	if criteria.HardwareUUID != uuid.Nil {
+		tx = tx.Where("hardware_uuid = ?", criteria.HardwareUUID)
+	}
+	if criteria.SerialNumberEqualTo != "" {
+		tx = tx.Where("serial_number = ?", criteria.SerialNumberEqualTo)
+	}
+	if criteria.EkCertificateDigestEqualTo != "" {
+		tx = tx.Where("ek_certificate_digest = ?", criteria.EkCertificateDigestEqualTo)
+	}
+	if criteria.Revoked != nil {
+		tx = tx.Where("revoked = ?", *criteria.Revoked)
+	}
+	if !criteria.ExpiresBefore.IsZero() {
+		tx = tx.Where("expiry < ?", criteria.ExpiresBefore)
+	}
+	if !criteria.ExpiresAfter.IsZero() {
+		tx = tx.Where("expiry > ?", criteria.ExpiresAfter)
+	}
+	return tx.Order("issued")
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+type EndorsementAuthorityStore struct {
+	Store *DataStore
+}
+
+func NewEndorsementAuthorityStore(store *DataStore) *EndorsementAuthorityStore {
+	return &EndorsementAuthorityStore{store}
+}
+
+func (eas *EndorsementAuthorityStore) Create(ea *hvs.EndorsementAuthority) (*hvs.EndorsementAuthority, error) {
+	defaultLog.Trace("postgres/endorsement_authority_store:Create() Entering")
+	defer defaultLog.Trace("postgres/endorsement_authority_store:Create() Leaving")
+
+	if ea.ID == uuid.Nil {
+		newUuid, err := uuid.NewRandom()
+		if err != nil {
+			return nil, errors.Wrap(err, "postgres/endorsement_authority_store:Create() failed to create new UUID")
+		}
+		ea.ID = newUuid
+	}
+	ea.Created = time.Now().UTC()
+	ea.Updated = ea.Created
+
+	dbAuthority := toDbEndorsementAuthority(ea)
+	if err := eas.Store.Db.Create(&dbAuthority).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/endorsement_authority_store:Create() Failed to create endorsement authority")
+	}
+	return ea, nil
+}
+
+func (eas *EndorsementAuthorityStore) Update(ea *hvs.EndorsementAuthority) (*hvs.EndorsementAuthority, error) {
+	defaultLog.Trace("postgres/endorsement_authority_store:Update() Entering")
+	defer defaultLog.Trace("postgres/endorsement_authority_store:Update() Leaving")
+
+	ea.Updated = time.Now().UTC()
+	dbAuthority := toDbEndorsementAuthority(ea)
+	if err := eas.Store.Db.Save(&dbAuthority).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/endorsement_authority_store:Update() Failed to save endorsement authority")
+	}
+	return ea, nil
+}
+
+func (eas *EndorsementAuthorityStore) Retrieve(id uuid.UUID) (*hvs.EndorsementAuthority, error) {
+	defaultLog.Trace("postgres/endorsement_authority_store:Retrieve() Entering")
+	defer defaultLog.Trace("postgres/endorsement_authority_store:Retrieve() Leaving")
+
+	row := eas.Store.Db.Model(&endorsementAuthority{}).Where(&endorsementAuthority{ID: id}).Row()
+	ea := hvs.EndorsementAuthority{}
+	if err := row.Scan(&ea.ID, &ea.Name, &ea.Manufacturer, &ea.Certificates, &ea.Crls, &ea.Disabled, &ea.Created,
+		&ea.Updated); err != nil {
+		return nil, errors.Wrap(err, "postgres/endorsement_authority_store:Retrieve() Failed to scan record")
+	}
+	return &ea, nil
+}
+
+func (eas *EndorsementAuthorityStore) Search(criteria *models.EndorsementAuthorityFilterCriteria) ([]hvs.EndorsementAuthority, error) {
+	defaultLog.Trace("postgres/endorsement_authority_store:Search() Entering")
+	defer defaultLog.Trace("postgres/endorsement_authority_store:Search() Leaving")
+
+	tx := buildEndorsementAuthoritySearchQuery(eas.Store.Db, criteria)
+	if tx == nil {
+		return nil, errors.New("postgres/endorsement_authority_store:Search() Unexpected Error. Could not build" +
+			" a gorm query object.")
+	}
+
+	rows, err := tx.Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/endorsement_authority_store:Search() Failed to retrieve records from db")
+	}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing rows")
+		}
+	}()
+
+	authorities := []hvs.EndorsementAuthority{}
+	for rows.Next() {
+		ea := hvs.EndorsementAuthority{}
+		if err := rows.Scan(&ea.ID, &ea.Name, &ea.Manufacturer, &ea.Certificates, &ea.Crls, &ea.Disabled, &ea.Created,
+			&ea.Updated); err != nil {
+			return nil, errors.Wrap(err, "postgres/endorsement_authority_store:Search() Failed to scan record")
+		}
+		authorities = append(authorities, ea)
+	}
+	return authorities, nil
+}
+
+func (eas *EndorsementAuthorityStore) Delete(id uuid.UUID) error {
+	defaultLog.Trace("postgres/endorsement_authority_store:Delete() Entering")
+	defer defaultLog.Trace("postgres/endorsement_authority_store:Delete() Leaving")
+
+	if err := eas.Store.Db.Delete(&endorsementAuthority{ID: id}).Error; err != nil {
+		return errors.Wrap(err, "postgres/endorsement_authority_store:Delete() Failed to delete endorsement authority")
+	}
+	return nil
+}
+
+func toDbEndorsementAuthority(ea *hvs.EndorsementAuthority) endorsementAuthority {
+	return endorsementAuthority{
+		ID:           ea.ID,
+		Name:         ea.Name,
+		Manufacturer: ea.Manufacturer,
+		Certificates: ea.Certificates,
+		Crls:         ea.Crls,
+		Disabled:     ea.Disabled,
+		CreatedAt:    ea.Created,
+		UpdatedAt:    ea.Updated,
+	}
+}
+
+// helper function to build the query object for an endorsement authority search.
+func buildEndorsementAuthoritySearchQuery(tx *gorm.DB, criteria *models.EndorsementAuthorityFilterCriteria) *gorm.DB {
+	defaultLog.Trace("postgres/endorsement_authority_store:buildEndorsementAuthoritySearchQuery() Entering")
+	defer defaultLog.Trace("postgres/endorsement_authority_store:buildEndorsementAuthoritySearchQuery() Leaving")
+
+	if tx == nil {
+		return nil
+	}
+	tx = tx.Model(&endorsementAuthority{})
+	if criteria == nil {
+		return tx.Order("name")
+	}
+	if criteria.Id != uuid.Nil {
+		tx = tx.Where("id = ?", criteria.Id)
+	}
+	if criteria.NameEqualTo != "" {
+		tx = tx.Where("name = ?", criteria.NameEqualTo)
+	}
+	if criteria.NameContains != "" {
+		tx = tx.Where("name like ?", "%"+criteria.NameContains+"%")
+	}
+	if criteria.ManufacturerEqualTo != "" {
+		tx = tx.Where("manufacturer = ?", criteria.ManufacturerEqualTo)
+	}
+	if criteria.Disabled != nil {
+		tx = tx.Where("disabled = ?", *criteria.Disabled)
+	}
+	return tx.Order("name")
+}
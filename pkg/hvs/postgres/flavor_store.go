@@ -10,7 +10,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
 	fc "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
 	flavormodel "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
@@ -19,11 +21,12 @@ import (
 )
 
 type FlavorStore struct {
-	Store *DataStore
+	Store          *DataStore
+	AuditLogWriter domain.AuditLogWriter
 }
 
 func NewFlavorStore(store *DataStore) *FlavorStore {
-	return &FlavorStore{store}
+	return &FlavorStore{Store: store}
 }
 
 // create flavors
@@ -42,6 +45,19 @@ func (f *FlavorStore) Create(signedFlavor *hvs.SignedFlavor) (*hvs.SignedFlavor,
 		signedFlavor.Flavor.Meta.ID = newUuid
 	}
 
+	tx := f.Store.Db.Begin()
+	if err := createFlavor(tx, signedFlavor); err != nil {
+		tx.Rollback()
+		return nil, errors.Wrap(err, "postgres/flavor_store:Create() failed to create flavor")
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/flavor_store:Create() failed to commit flavor")
+	}
+	return signedFlavor, nil
+}
+
+// createFlavor creates the flavor and its first revision in the transaction
+func createFlavor(tx *gorm.DB, signedFlavor *hvs.SignedFlavor) error {
 	dbf := flavor{
 		ID:         signedFlavor.Flavor.Meta.ID,
 		Content:    PGFlavorContent(signedFlavor.Flavor),
@@ -50,11 +66,18 @@ func (f *FlavorStore) Create(signedFlavor *hvs.SignedFlavor) (*hvs.SignedFlavor,
 		FlavorPart: signedFlavor.Flavor.Meta.Description[flavormodel.FlavorPart].(string),
 		Signature:  signedFlavor.Signature,
 	}
-
-	if err := f.Store.Db.Create(&dbf).Error; err != nil {
-		return nil, errors.Wrap(err, "postgres/flavor_store:Create() failed to create flavor")
+	if err := tx.Create(&dbf).Error; err != nil {
+		return err
 	}
-	return signedFlavor, nil
+	// every flavor starts with its first revision
+	dbfr := flavorRevision{
+		FlavorID:  dbf.ID,
+		Revision:  1,
+		Content:   dbf.Content,
+		Signature: dbf.Signature,
+		CreatedAt: dbf.CreatedAt,
+	}
+	return errors.Wrap(tx.Create(&dbfr).Error, "failed to create flavor revision")
 }
 
 func (f *FlavorStore) Search(flavorFilter *models.FlavorVerificationFC) ([]hvs.SignedFlavor, error) {
@@ -91,6 +114,13 @@ func (f *FlavorStore) Search(flavorFilter *models.FlavorVerificationFC) ([]hvs.S
 			" object in flavor Search function")
 	}
 
+	// the flavor part queries are combined with Or conditions, so the page is taken from the flavors they select
+	if flavorFilter.FlavorFC.Limit > 0 || flavorFilter.FlavorFC.After != nil {
+		tx = f.Store.Db.Table("flavor f").Select("f.id, f.content, f.signature").
+			Where("f.id IN ?", tx.Select("f.id").SubQuery())
+		tx = applyPage(tx, "", "f.id", false, flavorFilter.FlavorFC.After, flavorFilter.FlavorFC.Limit)
+	}
+
 	rows, err := tx.Rows()
 	if err != nil {
 		return nil, errors.Wrap(err, "postgres/flavor_store:Search() failed to retrieve records from db")
@@ -340,3 +370,129 @@ func (f *FlavorStore) Delete(flavorId uuid.UUID) error {
 	}
 	return nil
 }
+
+// Update replaces the content of a flavor and records it as a new revision. The trust cache entries of the flavor
+// are cleared so that hosts are verified against the new content
+func (f *FlavorStore) Update(fr *hvs.FlavorRevision) (*hvs.FlavorRevision, error) {
+	defaultLog.Trace("postgres/flavor_store:Update() Entering")
+	defer defaultLog.Trace("postgres/flavor_store:Update() Leaving")
+
+	if fr == nil || fr.FlavorID == uuid.Nil || fr.SignedFlavor.Signature == "" || fr.SignedFlavor.Flavor.Meta.Description == nil {
+		return nil, errors.New("postgres/flavor_store:Update()- invalid input : must have flavor id, content and signature")
+	}
+	label, _ := fr.SignedFlavor.Flavor.Meta.Description[flavormodel.Label].(string)
+	if label == "" {
+		return nil, errors.New("postgres/flavor_store:Update()- invalid input : must have the label for the flavor")
+	}
+	fr.SignedFlavor.Flavor.Meta.ID = fr.FlavorID
+
+	tx := f.Store.Db.Begin()
+	// the flavor row is locked so that concurrent updates of the flavor are serialized and each of them records the
+	// revision following the one read below
+	if err := tx.Set("gorm:query_option", "FOR UPDATE").Where(&flavor{ID: fr.FlavorID}).First(&flavor{}).Error; err != nil {
+		tx.Rollback()
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, errors.Wrap(errors.New(commErr.RowsNotFound), "postgres/flavor_store:Update() - Record not found = id :  "+fr.FlavorID.String())
+		}
+		return nil, errors.Wrap(err, "postgres/flavor_store:Update() failed to lock flavor "+fr.FlavorID.String())
+	}
+	var latest flavorRevision
+	if err := tx.Where(&flavorRevision{FlavorID: fr.FlavorID}).Order("revision desc").First(&latest).Error; err != nil {
+		tx.Rollback()
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, errors.Wrap(errors.New(commErr.RowsNotFound), "postgres/flavor_store:Update() flavor revisions not found")
+		}
+		return nil, errors.Wrap(err, "postgres/flavor_store:Update() failed to retrieve latest flavor revision")
+	}
+
+	dbfr := flavorRevision{
+		FlavorID:  fr.FlavorID,
+		Revision:  latest.Revision + 1,
+		Content:   PGFlavorContent(fr.SignedFlavor.Flavor),
+		Signature: fr.SignedFlavor.Signature,
+		CreatedBy: fr.CreatedBy,
+		Comment:   fr.Comment,
+		CreatedAt: time.Now(),
+	}
+
+	db := tx.Model(&flavor{ID: fr.FlavorID}).Updates(map[string]interface{}{
+		"content":   dbfr.Content,
+		"label":     label,
+		"signature": dbfr.Signature,
+	})
+	if db.Error != nil {
+		tx.Rollback()
+		return nil, errors.Wrap(db.Error, "postgres/flavor_store:Update() failed to update flavor "+fr.FlavorID.String())
+	}
+	if db.RowsAffected != 1 {
+		tx.Rollback()
+		return nil, errors.Wrap(errors.New(commErr.RowsNotFound), "postgres/flavor_store:Update() - no rows affected - Record not found = id :  "+fr.FlavorID.String())
+	}
+	if err := tx.Create(&dbfr).Error; err != nil {
+		tx.Rollback()
+		return nil, errors.Wrap(err, "postgres/flavor_store:Update() failed to create flavor revision")
+	}
+	if err := tx.Where(&trustCache{FlavorId: fr.FlavorID}).Delete(&trustCache{}).Error; err != nil {
+		tx.Rollback()
+		return nil, errors.Wrap(err, "postgres/flavor_store:Update() failed to clear trust cache of flavor")
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/flavor_store:Update() failed to commit flavor revision")
+	}
+
+	newRevision := flavorRevisionFromDb(&dbfr)
+	// log to audit log
+	if f.AuditLogWriter != nil {
+		auditEntry, err := f.AuditLogWriter.CreateEntry("update", flavorRevisionFromDb(&latest), newRevision)
+		if err == nil {
+			f.AuditLogWriter.Log(auditEntry)
+		}
+	}
+	return newRevision, nil
+}
+
+// SearchRevisions returns all revisions of a flavor, latest first
+func (f *FlavorStore) SearchRevisions(flavorId uuid.UUID) ([]hvs.FlavorRevision, error) {
+	defaultLog.Trace("postgres/flavor_store:SearchRevisions() Entering")
+	defer defaultLog.Trace("postgres/flavor_store:SearchRevisions() Leaving")
+
+	var dbRevisions []flavorRevision
+	if err := f.Store.Db.Where(&flavorRevision{FlavorID: flavorId}).Order("revision desc").Find(&dbRevisions).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/flavor_store:SearchRevisions() failed to retrieve flavor revisions")
+	}
+
+	revisions := []hvs.FlavorRevision{}
+	for i := range dbRevisions {
+		revisions = append(revisions, *flavorRevisionFromDb(&dbRevisions[i]))
+	}
+	return revisions, nil
+}
+
+// RetrieveRevision returns a single revision of a flavor
+func (f *FlavorStore) RetrieveRevision(flavorId uuid.UUID, revision int) (*hvs.FlavorRevision, error) {
+	defaultLog.Trace("postgres/flavor_store:RetrieveRevision() Entering")
+	defer defaultLog.Trace("postgres/flavor_store:RetrieveRevision() Leaving")
+
+	var dbfr flavorRevision
+	if err := f.Store.Db.Where(&flavorRevision{FlavorID: flavorId, Revision: revision}).First(&dbfr).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, errors.Wrap(errors.New(commErr.RowsNotFound), "postgres/flavor_store:RetrieveRevision() flavor revision not found")
+		}
+		return nil, errors.Wrap(err, "postgres/flavor_store:RetrieveRevision() failed to retrieve flavor revision")
+	}
+	return flavorRevisionFromDb(&dbfr), nil
+}
+
+func flavorRevisionFromDb(dbfr *flavorRevision) *hvs.FlavorRevision {
+	return &hvs.FlavorRevision{
+		FlavorID: dbfr.FlavorID,
+		Revision: dbfr.Revision,
+		SignedFlavor: hvs.SignedFlavor{
+			Flavor:    hvs.Flavor(dbfr.Content),
+			Signature: dbfr.Signature,
+		},
+		CreatedBy: dbfr.CreatedBy,
+		Comment:   dbfr.Comment,
+		Created:   dbfr.CreatedAt,
+	}
+}
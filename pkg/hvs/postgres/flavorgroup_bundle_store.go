@@ -0,0 +1,104 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package postgres
+
+import (
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// FlavorgroupBundleStore creates the items of the imported flavorgroup bundles in one transaction, so that a failed
+// import leaves no orphan flavors, flavor templates or flavorgroup
+type FlavorgroupBundleStore struct {
+	Store            *DataStore
+	FlavorGroupStore *FlavorGroupStore
+}
+
+func NewFlavorgroupBundleStore(store *DataStore, flavorGroupStore *FlavorGroupStore) *FlavorgroupBundleStore {
+	return &FlavorgroupBundleStore{Store: store, FlavorGroupStore: flavorGroupStore}
+}
+
+// Import creates the flavor templates, the flavors and the flavorgroup of the bundle, and links the flavors to the
+// flavorgroup
+func (b *FlavorgroupBundleStore) Import(bundleImport *models.FlavorgroupBundleImport) (*hvs.FlavorGroup, []uuid.UUID, error) {
+	defaultLog.Trace("postgres/flavorgroup_bundle_store:Import() Entering")
+	defer defaultLog.Trace("postgres/flavorgroup_bundle_store:Import() Leaving")
+
+	tx := b.Store.Db.Begin()
+	if tx.Error != nil {
+		return nil, nil, errors.Wrap(tx.Error, "postgres/flavorgroup_bundle_store:Import() failed to begin transaction")
+	}
+
+	for i := range bundleImport.FlavorTemplates {
+		template := bundleImport.FlavorTemplates[i]
+		if err := tx.Create(&flavorTemplate{ID: template.ID, Content: PGFlavorTemplateContent(template)}).Error; err != nil {
+			tx.Rollback()
+			return nil, nil, errors.Wrapf(err, "postgres/flavorgroup_bundle_store:Import() failed to create flavor template %s", template.ID)
+		}
+	}
+
+	for i := range bundleImport.SignedFlavors {
+		if err := createFlavor(tx, &bundleImport.SignedFlavors[i]); err != nil {
+			tx.Rollback()
+			return nil, nil, errors.Wrapf(err, "postgres/flavorgroup_bundle_store:Import() failed to create flavor %s",
+				bundleImport.SignedFlavors[i].Flavor.Meta.ID)
+		}
+	}
+
+	fg := bundleImport.FlavorGroup
+	if fg.ID == uuid.Nil {
+		newUuid, err := uuid.NewRandom()
+		if err != nil {
+			tx.Rollback()
+			return nil, nil, errors.Wrap(err, "postgres/flavorgroup_bundle_store:Import() failed to create new UUID")
+		}
+		fg.ID = newUuid
+		dbFlavorGroup := flavorGroup{
+			ID:                    fg.ID,
+			Name:                  fg.Name,
+			FlavorTypeMatchPolicy: PGFlavorMatchPolicies(fg.MatchPolicies),
+			HostLabelSelector:     fg.HostLabelSelector,
+			CustomRules:           PGCustomRules(fg.CustomRules),
+		}
+		if err := tx.Create(&dbFlavorGroup).Error; err != nil {
+			tx.Rollback()
+			return nil, nil, errors.Wrap(err, "postgres/flavorgroup_bundle_store:Import() failed to create Flavorgroup")
+		}
+	}
+
+	// only link the flavors that are not linked to the flavorgroup yet
+	var linkedFlavorIds []uuid.UUID
+	if err := tx.Model(&flavorgroupFlavor{}).Where("flavorgroup_id = ?", fg.ID).Pluck("flavor_id", &linkedFlavorIds).Error; err != nil {
+		tx.Rollback()
+		return nil, nil, errors.Wrap(err, "postgres/flavorgroup_bundle_store:Import() failed to search flavorgroup-flavor associations")
+	}
+	linked := make(map[uuid.UUID]bool, len(linkedFlavorIds))
+	for _, fId := range linkedFlavorIds {
+		linked[fId] = true
+	}
+	var newLinks []uuid.UUID
+	for _, fId := range bundleImport.FlavorIds {
+		if linked[fId] {
+			continue
+		}
+		linked[fId] = true
+		if err := tx.Create(&flavorgroupFlavor{FlavorgroupId: fg.ID, FlavorId: fId}).Error; err != nil {
+			tx.Rollback()
+			return nil, nil, errors.Wrap(err, "postgres/flavorgroup_bundle_store:Import() failed to create flavorgroup-flavor association")
+		}
+		newLinks = append(newLinks, fId)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, nil, errors.Wrap(err, "postgres/flavorgroup_bundle_store:Import() failed to commit import")
+	}
+	if len(newLinks) > 0 && b.FlavorGroupStore != nil {
+		// remove cache entry if it exists as the entry is stale with addition of a flavor
+		b.FlavorGroupStore.removeFlavorTypesCacheEntry(fg.ID)
+	}
+	return &fg, newLinks, nil
+}
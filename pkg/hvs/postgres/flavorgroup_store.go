@@ -16,6 +16,8 @@ import (
 	"sync"
 )
 
+const flavorGroupFields = "id, name, flavor_type_match_policy, host_label_selector, custom_rules"
+
 type FlavorGroupStore struct {
 	Store            *DataStore
 	flavorPartsCache map[uuid.UUID]map[fc.FlavorPart]bool
@@ -42,6 +44,8 @@ func (f *FlavorGroupStore) Create(fg *hvs.FlavorGroup) (*hvs.FlavorGroup, error)
 		ID:                    fg.ID,
 		Name:                  fg.Name,
 		FlavorTypeMatchPolicy: PGFlavorMatchPolicies(fg.MatchPolicies),
+		HostLabelSelector:     fg.HostLabelSelector,
+		CustomRules:           PGCustomRules(fg.CustomRules),
 	}
 
 	if err := f.Store.Db.Create(&dbFlavorGroup).Error; err != nil {
@@ -55,8 +59,9 @@ func (f *FlavorGroupStore) Retrieve(flavorGroupId uuid.UUID) (*hvs.FlavorGroup,
 	defer defaultLog.Trace("postgres/flavorgroup_store:Retrieve() Leaving")
 
 	fg := hvs.FlavorGroup{}
-	row := f.Store.Db.Model(&flavorGroup{}).Where(&flavorGroup{ID: flavorGroupId}).Row()
-	if err := row.Scan(&fg.ID, &fg.Name, (*PGFlavorMatchPolicies)(&fg.MatchPolicies)); err != nil {
+	row := f.Store.Db.Model(&flavorGroup{}).Select(flavorGroupFields).Where(&flavorGroup{ID: flavorGroupId}).Row()
+	if err := row.Scan(&fg.ID, &fg.Name, (*PGFlavorMatchPolicies)(&fg.MatchPolicies), &fg.HostLabelSelector,
+		(*PGCustomRules)(&fg.CustomRules)); err != nil {
 		return nil, errors.Wrap(err, "postgres/flavorgroup_store:Retrieve() failed to scan record")
 	}
 	return &fg, nil
@@ -99,7 +104,8 @@ func (f *FlavorGroupStore) Search(fgFilter *models.FlavorGroupFilterCriteria) ([
 	flavorgroupList := []hvs.FlavorGroup{}
 	for rows.Next() {
 		fg := hvs.FlavorGroup{}
-		if err := rows.Scan(&fg.ID, &fg.Name, (*PGFlavorMatchPolicies)(&fg.MatchPolicies)); err != nil {
+		if err := rows.Scan(&fg.ID, &fg.Name, (*PGFlavorMatchPolicies)(&fg.MatchPolicies), &fg.HostLabelSelector,
+			(*PGCustomRules)(&fg.CustomRules)); err != nil {
 			return nil, errors.Wrap(err, "postgres/flavorgroup_store:Search() failed to scan record")
 		}
 		flavorgroupList = append(flavorgroupList, fg)
@@ -149,7 +155,7 @@ func buildFlavorGroupSearchQuery(tx *gorm.DB, fgFilter *models.FlavorGroupFilter
 		return nil
 	}
 
-	tx = tx.Model(&flavorGroup{})
+	tx = tx.Model(&flavorGroup{}).Select(flavorGroupFields)
 	if fgFilter == nil {
 		return tx
 	}
@@ -161,6 +167,10 @@ func buildFlavorGroupSearchQuery(tx *gorm.DB, fgFilter *models.FlavorGroupFilter
 	} else if fgFilter.NameContains != "" {
 		tx = tx.Where("name like ? ", "%"+fgFilter.NameContains+"%")
 	}
+
+	if fgFilter.HasHostLabelSelector {
+		tx = tx.Where("host_label_selector <> ''")
+	}
 	return tx
 }
 
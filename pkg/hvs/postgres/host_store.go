@@ -25,7 +25,7 @@ func NewHostStore(store *DataStore) *HostStore {
 }
 
 const (
-	hostFields = "host.id, host.name, host.description, host.connection_string, host.hardware_uuid"
+	hostFields = "host.id, host.name, host.description, host.connection_string, host.hardware_uuid, host.labels"
 )
 
 func (hs *HostStore) Create(h *hvs.Host) (*hvs.Host, error) {
@@ -42,6 +42,7 @@ func (hs *HostStore) Create(h *hvs.Host) (*hvs.Host, error) {
 		Name:             h.HostName,
 		Description:      h.Description,
 		ConnectionString: h.ConnectionString,
+		Labels:           PGHostLabels(h.Labels),
 	}
 
 	if h.HardwareUuid != nil {
@@ -58,7 +59,7 @@ func (hs *HostStore) Retrieve(id uuid.UUID, criteria *models.HostInfoFetchCriter
 	defaultLog.Trace("postgres/host_store:Retrieve() Entering")
 	defer defaultLog.Trace("postgres/host_store:Retrieve() Leaving")
 
-	tx := hs.Store.Db.Model(&host{}).Where(&host{Id: id})
+	tx := hs.Store.Db.Model(&host{}).Select(hostFields).Where(&host{Id: id})
 
 	h := hvs.Host{}
 	report := hvs.TrustReport{}
@@ -67,27 +68,27 @@ func (hs *HostStore) Retrieve(id uuid.UUID, criteria *models.HostInfoFetchCriter
 	if criteria != nil && (criteria.GetReport || criteria.GetHostStatus) {
 		row := buildInfoFetchQuery(tx, criteria, nil).Row()
 		if criteria.GetReport && criteria.GetHostStatus {
-			if err := row.Scan(&h.Id, &h.HostName, &h.Description, &h.ConnectionString, &h.HardwareUuid,
+			if err := row.Scan(&h.Id, &h.HostName, &h.Description, &h.ConnectionString, &h.HardwareUuid, (*PGHostLabels)(&h.Labels),
 				(*PGTrustReport)(&report), (*PGHostStatusInformation)(&connectionStatus)); err != nil {
 				return nil, errors.Wrap(err, "postgres/host_store:Retrieve() failed to scan record")
 			}
 			h.Report = &report
 			h.ConnectionStatus = &connectionStatus
 		} else if criteria.GetReport {
-			if err := row.Scan(&h.Id, &h.HostName, &h.Description, &h.ConnectionString, &h.HardwareUuid,
+			if err := row.Scan(&h.Id, &h.HostName, &h.Description, &h.ConnectionString, &h.HardwareUuid, (*PGHostLabels)(&h.Labels),
 				(*PGTrustReport)(&report)); err != nil {
 				return nil, errors.Wrap(err, "postgres/host_store:Retrieve() failed to scan record")
 			}
 			h.Report = &report
 		} else if criteria.GetHostStatus {
-			if err := row.Scan(&h.Id, &h.HostName, &h.Description, &h.ConnectionString, &h.HardwareUuid,
+			if err := row.Scan(&h.Id, &h.HostName, &h.Description, &h.ConnectionString, &h.HardwareUuid, (*PGHostLabels)(&h.Labels),
 				(*PGHostStatusInformation)(&connectionStatus)); err != nil {
 				return nil, errors.Wrap(err, "postgres/host_store:Retrieve() failed to scan record")
 			}
 			h.ConnectionStatus = &connectionStatus
 		}
 	} else {
-		if err := tx.Row().Scan(&h.Id, &h.HostName, &h.Description, &h.ConnectionString, &h.HardwareUuid); err != nil {
+		if err := tx.Row().Scan(&h.Id, &h.HostName, &h.Description, &h.ConnectionString, &h.HardwareUuid, (*PGHostLabels)(&h.Labels)); err != nil {
 			return nil, errors.Wrap(err, "postgres/host_store:Retrieve() failed to scan record")
 		}
 	}
@@ -104,6 +105,7 @@ func (hs *HostStore) Update(h *hvs.Host) error {
 		Name:             h.HostName,
 		Description:      h.Description,
 		ConnectionString: h.ConnectionString,
+		Labels:           PGHostLabels(h.Labels),
 	}
 
 	if h.HardwareUuid != nil {
@@ -171,7 +173,7 @@ func (hs *HostStore) Search(filterCriteria *models.HostFilterCriteria, infoFetch
 	} else {
 		for rows.Next() {
 			host := hvs.Host{}
-			if err := rows.Scan(&host.Id, &host.HostName, &host.Description, &host.ConnectionString, &host.HardwareUuid); err != nil {
+			if err := rows.Scan(&host.Id, &host.HostName, &host.Description, &host.ConnectionString, &host.HardwareUuid, (*PGHostLabels)(&host.Labels)); err != nil {
 				return nil, errors.Wrap(err, "postgres/host_store:Search() failed to scan record")
 			}
 			hosts = append(hosts, &host)
@@ -189,7 +191,7 @@ func buildHostSearchQuery(tx *gorm.DB, criteria *models.HostFilterCriteria) *gor
 		return nil
 	}
 
-	tx = tx.Model(&host{})
+	tx = tx.Model(&host{}).Select(hostFields)
 
 	if criteria == nil || reflect.DeepEqual(*criteria, models.HostFilterCriteria{}) {
 		tx = tx.Order("name asc")
@@ -210,12 +212,13 @@ func buildHostSearchQuery(tx *gorm.DB, criteria *models.HostFilterCriteria) *gor
 		tx = tx.Joins("join report on report.host_id = host.id AND report.trusted = ?", criteria.Trusted)
 	}
 
-	if criteria.OrderBy == models.Descending {
-		tx = tx.Order("name desc")
-	} else {
-		tx = tx.Order("name asc")
+	if criteria.LabelSelector != nil {
+		condition, args := labelSelectorCondition("host.labels", criteria.LabelSelector)
+		tx = tx.Where(condition, args...)
 	}
-	return tx
+
+	// hosts are sorted on their unique name, the id only keeps the page cursor in the same form as other searches
+	return applyPage(tx, "host.name", "host.id", criteria.OrderBy == models.Descending, criteria.After, criteria.Limit)
 }
 
 func buildInfoFetchQuery(tx *gorm.DB, infoFetchCriteria *models.HostInfoFetchCriteria,
@@ -256,18 +259,18 @@ func getAdditionalHostInfo(criteria *models.HostInfoFetchCriteria, rows *sql.Row
 		host := hvs.Host{}
 		connectionStatus := hvs.HostStatusInformation{}
 		if criteria.GetTrustStatus && criteria.GetHostStatus {
-			if err := rows.Scan(&host.Id, &host.HostName, &host.Description, &host.ConnectionString, &host.HardwareUuid,
+			if err := rows.Scan(&host.Id, &host.HostName, &host.Description, &host.ConnectionString, &host.HardwareUuid, (*PGHostLabels)(&host.Labels),
 				&host.Trusted, (*PGHostStatusInformation)(&connectionStatus)); err != nil {
 				return nil, errors.Wrap(err, "postgres/host_store:Search() failed to scan record")
 			}
 			host.ConnectionStatus = &connectionStatus
 		} else if criteria.GetTrustStatus {
-			if err := rows.Scan(&host.Id, &host.HostName, &host.Description, &host.ConnectionString, &host.HardwareUuid,
+			if err := rows.Scan(&host.Id, &host.HostName, &host.Description, &host.ConnectionString, &host.HardwareUuid, (*PGHostLabels)(&host.Labels),
 				&host.Trusted); err != nil {
 				return nil, errors.Wrap(err, "postgres/host_store:Search() failed to scan record")
 			}
 		} else if criteria.GetHostStatus {
-			if err := rows.Scan(&host.Id, &host.HostName, &host.Description, &host.ConnectionString, &host.HardwareUuid,
+			if err := rows.Scan(&host.Id, &host.HostName, &host.Description, &host.ConnectionString, &host.HardwareUuid, (*PGHostLabels)(&host.Labels),
 				(*PGHostStatusInformation)(&connectionStatus)); err != nil {
 				return nil, errors.Wrap(err, "postgres/host_store:Search() failed to scan record")
 			}
@@ -435,6 +438,37 @@ func (hs *HostStore) RetrieveTrustCacheFlavors(hId, fgId uuid.UUID) ([]uuid.UUID
 	return flavorIds, nil
 }
 
+// SearchTrustCacheHosts function returns the list of host ID's having the flavor in the trust cache of the flavorgroup
+func (hs *HostStore) SearchTrustCacheHosts(fId, fgId uuid.UUID) ([]uuid.UUID, error) {
+	defaultLog.Trace("postgres/host_store:SearchTrustCacheHosts() Entering")
+	defer defaultLog.Trace("postgres/host_store:SearchTrustCacheHosts() Leaving")
+
+	if fId == uuid.Nil || fgId == uuid.Nil {
+		return nil, errors.New("postgres/host_store:SearchTrustCacheHosts() Flavor ID and Flavorgroup ID must be set to get the list of hosts having the flavor in their trust cache")
+	}
+
+	rows, err := hs.Store.Db.Model(&trustCache{}).Select("DISTINCT trust_cache.host_id").Joins("INNER JOIN flavorgroup_flavor ON trust_cache.flavor_id = flavorgroup_flavor.flavor_id").Where("flavorgroup_flavor.flavorgroup_id = ? AND trust_cache.flavor_id = ?", fgId, fId).Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/host_store:SearchTrustCacheHosts() failed to retrieve records from db")
+	}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing rows")
+		}
+	}()
+
+	hostIds := []uuid.UUID{}
+	for rows.Next() {
+		hostId := uuid.UUID{}
+		if err := rows.Scan(&hostId); err != nil {
+			return nil, errors.Wrap(err, "postgres/host_store:SearchTrustCacheHosts() failed to scan record")
+		}
+		hostIds = append(hostIds, hostId)
+	}
+	return hostIds, nil
+}
+
 func (hs *HostStore) AddHostUniqueFlavors(hId uuid.UUID, fIds []uuid.UUID) ([]uuid.UUID, error) {
 	defaultLog.Trace("postgres/host_store:AddHostUniqueFlavors() Entering")
 	defer defaultLog.Trace("postgres/host_store:AddHostUniqueFlavors() Leaving")
@@ -252,6 +252,7 @@ func buildHostStatusSearchQuery(tx *gorm.DB, hsFilter *models.HostStatusFilterCr
 	defer defaultLog.Trace("postgres/hoststatus_store:buildHostStatusSearchQuery() Leaving")
 
 	var tableJoinString, additionalOptionsQueryString string
+	var queryArgs []interface{}
 
 	// define joins
 	auditLogAbbrv := "au"
@@ -304,6 +305,17 @@ func buildHostStatusSearchQuery(tx *gorm.DB, hsFilter *models.HostStatusFilterCr
 		}
 	}
 
+	//Build host IDs partial query string and add it to the additional options query string
+	if len(hsFilter.HostIds) > 0 {
+		hostIds := make([]string, len(hsFilter.HostIds))
+		for i, hostId := range hsFilter.HostIds {
+			hostIds[i] = hostId.String()
+		}
+		hostIdsQueryString := fmt.Sprintf("%s.data -> 'Columns' -> 1 ->> 'Value' IN (?)", auditLogAbbrv)
+		additionalOptionsQueryString = fmt.Sprintf("%s AND %s", additionalOptionsQueryString, hostIdsQueryString)
+		queryArgs = append(queryArgs, hostIds)
+	}
+
 	//Build host state partial query string and add it to the additional options query string
 	if hsFilter.HostStatus != "" {
 		hostStateQueryString := fmt.Sprintf("%s.data -> 'Columns' -> 2 -> 'Value' ->> 'host_state' = '%s'", auditLogAbbrv, strings.ToUpper(hsFilter.HostStatus))
@@ -316,6 +328,14 @@ func buildHostStatusSearchQuery(tx *gorm.DB, hsFilter *models.HostStatusFilterCr
 		additionalOptionsQueryString = fmt.Sprintf("%s AND %s", additionalOptionsQueryString, hostStatusIDQueryString)
 	}
 
+	//Build host label selector partial query string and add it to the additional options query string
+	if hsFilter.LabelSelector != nil {
+		labelSelectorQueryString, args := labelSelectorHostCondition(auditLogAbbrv+".data -> 'Columns' -> 1 ->> 'Value'",
+			"CAST(host.id AS VARCHAR)", hsFilter.LabelSelector)
+		additionalOptionsQueryString = fmt.Sprintf("%s AND %s", additionalOptionsQueryString, labelSelectorQueryString)
+		queryArgs = append(queryArgs, args...)
+	}
+
 	// Number of days and Date Filters are supposed to be mutually exclusive
 	if hsFilter.NumberOfDays != 0 {
 		// first parse numDays
@@ -337,6 +357,20 @@ func buildHostStatusSearchQuery(tx *gorm.DB, hsFilter *models.HostStatusFilterCr
 		}
 	}
 
+	// keyset pagination on the created time and Id of the host statuses, newest first
+	createdQueryString := "CAST(au.data -> 'Columns' -> 4 ->> 'Value' AS TIMESTAMPTZ)"
+	var pageQueryString string
+	if hsFilter.After != nil {
+		afterCreated, err := time.Parse(time.RFC3339Nano, hsFilter.After.Key)
+		if err != nil {
+			defaultLog.WithError(err).Error("postgres/hoststatus_store:buildHostStatusSearchQuery() Invalid page cursor")
+			return nil
+		}
+		pageQueryString = fmt.Sprintf("(%s, au.entity_id) < (CAST('%s' AS TIMESTAMPTZ), '%s')", createdQueryString,
+			afterCreated.Format(time.RFC3339Nano), hsFilter.After.Id.String())
+	}
+	orderQueryString := fmt.Sprintf("ORDER BY %s DESC, au.entity_id DESC", createdQueryString)
+
 	if tableJoinString != "" {
 		additionalOptionsQueryString = strings.Join([]string{tableJoinString, additionalOptionsQueryString}, " ")
 	}
@@ -346,9 +380,15 @@ func buildHostStatusSearchQuery(tx *gorm.DB, hsFilter *models.HostStatusFilterCr
 			"FROM audit_log_entry auj %s GROUP BY entity_id) a "+
 			"ON a.entity_id = au.entity_id "+
 			"AND a.max_date = au.created", additionalOptionsQueryString)
-		formattedQuery = fmt.Sprintf("%s %s ORDER BY au.Created DESC", formattedQuery, maxDateQueryString)
+		if pageQueryString != "" {
+			maxDateQueryString = fmt.Sprintf("%s WHERE %s", maxDateQueryString, pageQueryString)
+		}
+		formattedQuery = fmt.Sprintf("%s %s %s", formattedQuery, maxDateQueryString, orderQueryString)
 	} else {
-		formattedQuery = fmt.Sprintf("%s %s", formattedQuery, additionalOptionsQueryString)
+		if pageQueryString != "" {
+			additionalOptionsQueryString = fmt.Sprintf("%s AND %s", additionalOptionsQueryString, pageQueryString)
+		}
+		formattedQuery = fmt.Sprintf("%s %s %s", formattedQuery, additionalOptionsQueryString, orderQueryString)
 	}
 
 	if hsFilter.Limit == 0 {
@@ -356,7 +396,7 @@ func buildHostStatusSearchQuery(tx *gorm.DB, hsFilter *models.HostStatusFilterCr
 	}
 
 	// finalize query
-	tx = tx.Raw(formattedQuery).Limit(hsFilter.Limit)
+	tx = tx.Raw(formattedQuery, queryArgs...).Limit(hsFilter.Limit)
 
 	return tx
 }
@@ -394,6 +434,11 @@ func buildLatestHostStatusSearchQuery(tx *gorm.DB, hsFilter *models.HostStatusFi
 		tx = tx.Where("host_id = ?", hsFilter.HostId.String())
 	}
 
+	// Host UUIDs
+	if len(hsFilter.HostIds) > 0 {
+		tx = tx.Where("host_id IN (?)", hsFilter.HostIds)
+	}
+
 	// HWUUID
 	if hsFilter.HostHardwareId != uuid.Nil {
 		tx = tx.Where("h.hardware_uuid = ?", hsFilter.HostHardwareId.String())
@@ -409,15 +454,19 @@ func buildLatestHostStatusSearchQuery(tx *gorm.DB, hsFilter *models.HostStatusFi
 		tx = tx.Where(`status @> '{"host_state": "` + strings.ToUpper(hsFilter.HostStatus) + `"}'`)
 	}
 
+	// Host labels
+	if hsFilter.LabelSelector != nil {
+		condition, args := labelSelectorHostCondition("host_status.host_id", "host.id", hsFilter.LabelSelector)
+		tx = tx.Where(condition, args...)
+	}
+
 	// Apply default row limit when called internally
 	if hsFilter.Limit == 0 {
 		hsFilter.Limit = constants.DefaultSearchResultRowLimit
 	}
 
-	// apply result limits
-	tx = tx.Limit(hsFilter.Limit)
-
-	return tx
+	// apply result limits, newest host statuses first
+	return applyPage(tx, "host_status.created", "host_status.id", true, hsFilter.After, hsFilter.Limit)
 }
 
 func auditlogEntryToHostStatus(auRecord models.AuditLogEntry) (*hvs.HostStatus, error) {
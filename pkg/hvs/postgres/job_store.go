@@ -0,0 +1,127 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+type JobStore struct {
+	Store *DataStore
+}
+
+func NewJobStore(store *DataStore) *JobStore {
+	return &JobStore{store}
+}
+
+func (js *JobStore) Create(j *hvs.Job) (*hvs.Job, error) {
+	defaultLog.Trace("postgres/job_store:Create() Entering")
+	defer defaultLog.Trace("postgres/job_store:Create() Leaving")
+
+	newUuid, err := uuid.NewRandom()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/job_store:Create() failed to create new UUID")
+	}
+	j.Id = newUuid
+	j.Created = time.Now().UTC()
+	j.Updated = j.Created
+
+	dbJob := toDbJob(j)
+	if err := js.Store.Db.Create(&dbJob).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/job_store:Create() Failed to create job")
+	}
+	return j, nil
+}
+
+func (js *JobStore) Retrieve(id uuid.UUID) (*hvs.Job, error) {
+	defaultLog.Trace("postgres/job_store:Retrieve() Entering")
+	defer defaultLog.Trace("postgres/job_store:Retrieve() Leaving")
+
+	row := js.Store.Db.Model(&job{}).Where(&job{ID: id}).Row()
+	dbJob := job{}
+	if err := row.Scan(&dbJob.ID, &dbJob.Type, &dbJob.Status, &dbJob.Total, &dbJob.Succeeded, &dbJob.Failed,
+		&dbJob.Hosts, &dbJob.Error, &dbJob.CreatedAt, &dbJob.UpdatedAt); err != nil {
+		return nil, errors.Wrap(err, "postgres/job_store:Retrieve() Failed to scan record")
+	}
+	return &hvs.Job{
+		Id:        dbJob.ID,
+		Type:      hvs.JobType(dbJob.Type),
+		Status:    hvs.JobStatus(dbJob.Status),
+		Total:     dbJob.Total,
+		Succeeded: dbJob.Succeeded,
+		Failed:    dbJob.Failed,
+		Hosts:     dbJob.Hosts,
+		Error:     dbJob.Error,
+		Created:   dbJob.CreatedAt,
+		Updated:   dbJob.UpdatedAt,
+	}, nil
+}
+
+// Update saves the status and the entries of the job
+func (js *JobStore) Update(j *hvs.Job) error {
+	defaultLog.Trace("postgres/job_store:Update() Entering")
+	defer defaultLog.Trace("postgres/job_store:Update() Leaving")
+
+	if j.Id == uuid.Nil {
+		return errors.New("postgres/job_store:Update() - ID is invalid")
+	}
+	j.Updated = time.Now().UTC()
+
+	dbJob := toDbJob(j)
+	if db := js.Store.Db.Save(&dbJob); db.Error != nil {
+		return errors.Wrap(db.Error, "postgres/job_store:Update() Failed to update job "+j.Id.String())
+	}
+	return nil
+}
+
+// FailUnfinished marks the jobs that are still queued or running as failed, along with their unfinished entries. It is
+// called at startup, as the jobs are run by the HVS process and are not resumed once it has been stopped.
+func (js *JobStore) FailUnfinished(reason string) (int, error) {
+	defaultLog.Trace("postgres/job_store:FailUnfinished() Entering")
+	defer defaultLog.Trace("postgres/job_store:FailUnfinished() Leaving")
+
+	var dbJobs []job
+	if err := js.Store.Db.Where("status IN (?)", []string{string(hvs.JobStatusQueued), string(hvs.JobStatusRunning)}).
+		Find(&dbJobs).Error; err != nil {
+		return 0, errors.Wrap(err, "postgres/job_store:FailUnfinished() Failed to search unfinished jobs")
+	}
+
+	for _, dbJob := range dbJobs {
+		for i := range dbJob.Hosts {
+			if dbJob.Hosts[i].Status == hvs.JobStatusQueued || dbJob.Hosts[i].Status == hvs.JobStatusRunning {
+				dbJob.Hosts[i].Status = hvs.JobStatusFailed
+				dbJob.Hosts[i].Error = reason
+				dbJob.Failed++
+			}
+		}
+		dbJob.Status = string(hvs.JobStatusFailed)
+		dbJob.Error = reason
+		dbJob.UpdatedAt = time.Now().UTC()
+		if err := js.Store.Db.Save(&dbJob).Error; err != nil {
+			return 0, errors.Wrap(err, "postgres/job_store:FailUnfinished() Failed to update job "+dbJob.ID.String())
+		}
+	}
+	return len(dbJobs), nil
+}
+
+func toDbJob(j *hvs.Job) job {
+	return job{
+		ID:        j.Id,
+		Type:      string(j.Type),
+		Status:    string(j.Status),
+		Total:     j.Total,
+		Succeeded: j.Succeeded,
+		Failed:    j.Failed,
+		Hosts:     PGJobHostEntries(j.Hosts),
+		Error:     j.Error,
+		CreatedAt: j.Created,
+		UpdatedAt: j.Updated,
+	}
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+)
+
+// labelSelectorCondition translates a label selector into a SQL condition on the JSONB labels column of the host
+// table, along with its arguments. As in Kubernetes, != and notin also select the hosts without the label.
+func labelSelectorCondition(labelsColumn string, selector *models.LabelSelector) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	for _, requirement := range selector.Requirements {
+		value := fmt.Sprintf("%s ->> ?", labelsColumn)
+		switch requirement.Operator {
+		case models.SelectorEquals:
+			conditions = append(conditions, value+" = ?")
+			args = append(args, requirement.Key, requirement.Values[0])
+		case models.SelectorNotEquals:
+			conditions = append(conditions, value+" IS DISTINCT FROM ?")
+			args = append(args, requirement.Key, requirement.Values[0])
+		case models.SelectorIn:
+			conditions = append(conditions, value+" IN (?)")
+			args = append(args, requirement.Key, requirement.Values)
+		case models.SelectorNotIn:
+			conditions = append(conditions, fmt.Sprintf("(%s IS NULL OR %s NOT IN (?))", value, value))
+			args = append(args, requirement.Key, requirement.Key, requirement.Values)
+		case models.SelectorExists:
+			conditions = append(conditions, value+" IS NOT NULL")
+			args = append(args, requirement.Key)
+		case models.SelectorDoesNotExist:
+			conditions = append(conditions, value+" IS NULL")
+			args = append(args, requirement.Key)
+		}
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// labelSelectorHostCondition returns a SQL condition selecting the records whose host, referenced by hostIdColumn, has
+// labels matching the selector. hostIdExpr is the expression of the host id compared to hostIdColumn.
+func labelSelectorHostCondition(hostIdColumn, hostIdExpr string, selector *models.LabelSelector) (string, []interface{}) {
+	condition, args := labelSelectorCondition("host.labels", selector)
+	return fmt.Sprintf("%s IN (SELECT %s FROM host WHERE %s)", hostIdColumn, hostIdExpr, condition), args
+}
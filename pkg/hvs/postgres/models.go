@@ -14,6 +14,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models/taskstage"
+	flavormodel "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
 	"github.com/pkg/errors"
 )
@@ -26,11 +28,19 @@ type (
 	PGHostStatusInformation hvs.HostStatusInformation
 	PGFlavorContent         hvs.Flavor
 	PGFlavorTemplateContent hvs.FlavorTemplate
+	PGNotificationEvent     hvs.NotificationEvent
+	PGNotificationFilter    notificationFilter
+	PGHostLabels            map[string]string
+	PGJobHostEntries        []hvs.JobHostEntry
+	PGCustomRules           []flavormodel.CustomRule
+	PGEndorsementProvenance hvs.EndorsementProvenance
 
 	flavorGroup struct {
 		ID                    uuid.UUID             `json:"id" gorm:"primary_key;type:uuid"`
 		Name                  string                `json:"name" gorm:"type:varchar(255);not null;index:idx_flavorgroup_name"`
 		FlavorTypeMatchPolicy PGFlavorMatchPolicies `json:"flavor_type_match_policy,omitempty" sql:"type:JSONB"`
+		HostLabelSelector     string                `json:"host_label_selector,omitempty" sql:"type:varchar(1024) NOT NULL DEFAULT ''"`
+		CustomRules           PGCustomRules         `json:"custom_rules,omitempty" sql:"type:JSONB NOT NULL DEFAULT '[]'::JSONB"`
 	}
 
 	flavor struct {
@@ -42,12 +52,23 @@ type (
 		Signature  string          `json:"signature"`
 	}
 
+	flavorRevision struct {
+		FlavorID  uuid.UUID       `gorm:"column:flavor_id;type:uuid REFERENCES flavor(Id) ON UPDATE CASCADE ON DELETE CASCADE;not null;unique_index:idx_flavor_revision"`
+		Revision  int             `gorm:"column:revision;not null;unique_index:idx_flavor_revision"`
+		Content   PGFlavorContent `gorm:"column:content;not null" sql:"type:JSONB"`
+		Signature string          `gorm:"column:signature;not null"`
+		CreatedBy string          `gorm:"column:created_by"`
+		Comment   string          `gorm:"column:comment"`
+		CreatedAt time.Time       `gorm:"column:created;not null"`
+	}
+
 	host struct {
 		Id               uuid.UUID `gorm:"primary_key;type:uuid"`
 		Name             string    `gorm:"unique;type:varchar(255);not null"`
 		Description      string
 		ConnectionString string        `gorm:"not null"`
 		HardwareUuid     models.HwUUID `gorm:"type:uuid;index:idx_host_hardware_uuid"`
+		Labels           PGHostLabels  `sql:"type:JSONB NOT NULL DEFAULT '{}'::JSONB"`
 	}
 
 	hostFlavorgroup struct {
@@ -107,13 +128,15 @@ type (
 	}
 
 	queue struct {
-		Id        uuid.UUID         `json:"id,omitempty" gorm:"primary_key; unique;type:uuid"`
-		Action    string            `json:"action"`
-		Params    PGJsonStrMap      `json:"-" sql:"type:JSONB NOT NULL DEFAULT '{}'::JSONB"`
-		CreatedAt time.Time         `json:"created"`
-		UpdatedAt time.Time         `json:"updated"`
-		State     models.QueueState `json:"state"`
-		Message   string            `json:"message,omitempty"`
+		Id        uuid.UUID            `json:"id,omitempty" gorm:"primary_key; unique;type:uuid"`
+		Action    string               `json:"action"`
+		Params    PGJsonStrMap         `json:"-" sql:"type:JSONB NOT NULL DEFAULT '{}'::JSONB"`
+		CreatedAt time.Time            `json:"created"`
+		UpdatedAt time.Time            `json:"updated"`
+		State     models.QueueState    `json:"state"`
+		Message   string               `json:"message,omitempty"`
+		Priority  models.QueuePriority `json:"priority" sql:"type:integer NOT NULL DEFAULT 1"`
+		Stage     taskstage.Stage      `json:"stage" sql:"type:integer NOT NULL DEFAULT 0"`
 	}
 
 	PGTrustReport hvs.TrustReport
@@ -135,6 +158,32 @@ type (
 		Certificate       string    `gorm:"column:certificate;not null"`
 		Comment           string    `gorm:"column:comment"`
 		CertificateDigest string    `gorm:"column:certificate_digest;not null"`
+		// Provenance is stored as JSON null when the EK certificate was not verified yet
+		Provenance *PGEndorsementProvenance `gorm:"column:provenance" sql:"type:JSONB"`
+	}
+
+	endorsementAuthority struct {
+		ID           uuid.UUID `gorm:"primary_key;type:uuid"`
+		Name         string    `gorm:"column:name;not null;unique"`
+		Manufacturer string    `gorm:"column:manufacturer"`
+		Certificates string    `gorm:"column:certificates;not null"`
+		Crls         string    `gorm:"column:crls"`
+		Disabled     bool      `gorm:"column:disabled;not null"`
+		CreatedAt    time.Time `gorm:"column:created;not null"`
+		UpdatedAt    time.Time `gorm:"column:updated;not null"`
+	}
+
+	aikCertificate struct {
+		ID                  uuid.UUID  `gorm:"primary_key;type:uuid"`
+		HardwareUUID        *uuid.UUID `gorm:"column:hardware_uuid;type:uuid;index:idx_aik_certificate_hardware_uuid"`
+		SerialNumber        string     `gorm:"column:serial_number;not null;unique"`
+		EkCertificateDigest string     `gorm:"column:ek_certificate_digest;not null;index:idx_aik_certificate_ek_digest"`
+		Certificate         []byte     `gorm:"column:certificate;not null;type:bytea"`
+		Issued              time.Time  `gorm:"column:issued;not null"`
+		Expiry              time.Time  `gorm:"column:expiry;not null"`
+		Revoked             bool       `gorm:"column:revoked;not null"`
+		RevocationTime      *time.Time `gorm:"column:revocation_time"`
+		RevocationReason    string     `gorm:"column:revocation_reason"`
 	}
 
 	//TODO add triggers
@@ -148,6 +197,43 @@ type (
 		Data       PGAuditLogData `sql:"type:JSONB"`
 	}
 
+	notificationFilter struct {
+		EventTypes  []hvs.NotificationEventType `json:"event_types,omitempty"`
+		HostIDs     []uuid.UUID                 `json:"host_ids,omitempty"`
+		FlavorParts []string                    `json:"flavor_parts,omitempty"`
+	}
+
+	notificationSubscription struct {
+		ID          uuid.UUID            `gorm:"primary_key;type:uuid"`
+		CallbackURL string               `gorm:"column:callback_url;not null"`
+		Secret      string               `gorm:"column:secret"`
+		Filter      PGNotificationFilter `gorm:"column:filter" sql:"type:JSONB"`
+		CreatedAt   time.Time            `gorm:"column:created;not null"`
+	}
+
+	notificationDeadLetter struct {
+		ID             uuid.UUID           `gorm:"primary_key;type:uuid"`
+		SubscriptionID uuid.UUID           `gorm:"column:subscription_id;type:uuid REFERENCES notification_subscription(Id) ON UPDATE CASCADE ON DELETE CASCADE;not null;index:idx_dead_letter_subscription_id"`
+		CallbackURL    string              `gorm:"column:callback_url;not null"`
+		Event          PGNotificationEvent `gorm:"column:event" sql:"type:JSONB"`
+		Attempts       int                 `gorm:"column:attempts"`
+		LastError      string              `gorm:"column:last_error"`
+		CreatedAt      time.Time           `gorm:"column:created;not null"`
+	}
+
+	job struct {
+		ID        uuid.UUID        `gorm:"primary_key;type:uuid"`
+		Type      string           `gorm:"column:type;not null"`
+		Status    string           `gorm:"column:status;not null"`
+		Total     int              `gorm:"column:total"`
+		Succeeded int              `gorm:"column:succeeded"`
+		Failed    int              `gorm:"column:failed"`
+		Hosts     PGJobHostEntries `gorm:"column:hosts" sql:"type:JSONB"`
+		Error     string           `gorm:"column:error"`
+		CreatedAt time.Time        `gorm:"column:created;not null"`
+		UpdatedAt time.Time        `gorm:"column:updated;not null"`
+	}
+
 	tagCertificate struct {
 		ID           uuid.UUID `gorm:"primary_key; type:uuid"`
 		HardwareUUID uuid.UUID `gorm:"not null; type:uuid; column:hardware_uuid"`
@@ -187,6 +273,22 @@ func (phm *PGHostManifest) Scan(value interface{}) error {
 	return json.Unmarshal(b, &phm)
 }
 
+func (hl PGHostLabels) Value() (driver.Value, error) {
+	if hl == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(hl)
+}
+
+func (hl *PGHostLabels) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("postgres/models:PGHostLabels_Scan() - type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &hl)
+}
+
 func (hm PGHostStatusInformation) Value() (driver.Value, error) {
 	return json.Marshal(hm)
 }
@@ -200,6 +302,21 @@ func (hm *PGHostStatusInformation) Scan(value interface{}) error {
 	return json.Unmarshal(b, &hm)
 }
 
+func (cr PGCustomRules) Value() (driver.Value, error) {
+	if cr == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(cr)
+}
+
+func (cr *PGCustomRules) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("postgres/models:PGCustomRules_Scan() - type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &cr)
+}
+
 func (fmp PGFlavorMatchPolicies) Value() (driver.Value, error) {
 	return json.Marshal(fmp)
 }
@@ -259,3 +376,51 @@ func (fl *PGFlavorTemplateContent) Scan(value interface{}) error {
 	}
 	return json.Unmarshal(b, &fl)
 }
+
+func (ne PGNotificationEvent) Value() (driver.Value, error) {
+	return json.Marshal(ne)
+}
+
+func (ne *PGNotificationEvent) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("postgres/models:PGNotificationEvent_Scan() - type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &ne)
+}
+
+func (nf PGNotificationFilter) Value() (driver.Value, error) {
+	return json.Marshal(nf)
+}
+
+func (nf *PGNotificationFilter) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("postgres/models:PGNotificationFilter_Scan() - type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &nf)
+}
+
+func (ep PGEndorsementProvenance) Value() (driver.Value, error) {
+	return json.Marshal(ep)
+}
+
+func (ep *PGEndorsementProvenance) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("postgres/models:PGEndorsementProvenance_Scan() - type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &ep)
+}
+
+func (je PGJobHostEntries) Value() (driver.Value, error) {
+	return json.Marshal(je)
+}
+
+func (je *PGJobHostEntries) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("postgres/models:PGJobHostEntries_Scan() - type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &je)
+}
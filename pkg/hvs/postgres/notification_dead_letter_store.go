@@ -0,0 +1,118 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+type NotificationDeadLetterStore struct {
+	Store *DataStore
+}
+
+func NewNotificationDeadLetterStore(store *DataStore) *NotificationDeadLetterStore {
+	return &NotificationDeadLetterStore{store}
+}
+
+func (nds *NotificationDeadLetterStore) Create(dl *hvs.NotificationDeadLetter) (*hvs.NotificationDeadLetter, error) {
+	defaultLog.Trace("postgres/notification_dead_letter_store:Create() Entering")
+	defer defaultLog.Trace("postgres/notification_dead_letter_store:Create() Leaving")
+
+	newUuid, err := uuid.NewRandom()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/notification_dead_letter_store:Create() failed to create new UUID")
+	}
+	dl.ID = newUuid
+	dl.Created = time.Now().UTC()
+
+	dbDeadLetter := notificationDeadLetter{
+		ID:             dl.ID,
+		SubscriptionID: dl.SubscriptionID,
+		CallbackURL:    dl.CallbackURL,
+		Event:          PGNotificationEvent(dl.Event),
+		Attempts:       dl.Attempts,
+		LastError:      dl.LastError,
+		CreatedAt:      dl.Created,
+	}
+	if err := nds.Store.Db.Create(&dbDeadLetter).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/notification_dead_letter_store:Create() Failed to create notification dead letter")
+	}
+	return dl, nil
+}
+
+func (nds *NotificationDeadLetterStore) Search(criteria *models.NotificationDeadLetterFilterCriteria) ([]hvs.NotificationDeadLetter, error) {
+	defaultLog.Trace("postgres/notification_dead_letter_store:Search() Entering")
+	defer defaultLog.Trace("postgres/notification_dead_letter_store:Search() Leaving")
+
+	tx := buildNotificationDeadLetterSearchQuery(nds.Store.Db, criteria)
+	if tx == nil {
+		return nil, errors.New("postgres/notification_dead_letter_store:Search() Unexpected Error. Could not build" +
+			" a gorm query object.")
+	}
+
+	rows, err := tx.Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/notification_dead_letter_store:Search() Failed to retrieve records from db")
+	}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing rows")
+		}
+	}()
+
+	deadLetters := []hvs.NotificationDeadLetter{}
+	for rows.Next() {
+		dl := hvs.NotificationDeadLetter{}
+		if err := rows.Scan(&dl.ID, &dl.SubscriptionID, &dl.CallbackURL, (*PGNotificationEvent)(&dl.Event),
+			&dl.Attempts, &dl.LastError, &dl.Created); err != nil {
+			return nil, errors.Wrap(err, "postgres/notification_dead_letter_store:Search() Failed to scan record")
+		}
+		deadLetters = append(deadLetters, dl)
+	}
+	return deadLetters, nil
+}
+
+func (nds *NotificationDeadLetterStore) Delete(id uuid.UUID) error {
+	defaultLog.Trace("postgres/notification_dead_letter_store:Delete() Entering")
+	defer defaultLog.Trace("postgres/notification_dead_letter_store:Delete() Leaving")
+
+	if err := nds.Store.Db.Delete(&notificationDeadLetter{ID: id}).Error; err != nil {
+		return errors.Wrap(err, "postgres/notification_dead_letter_store:Delete() Failed to delete notification dead letter")
+	}
+	return nil
+}
+
+// helper function to build the query object for a notification dead letter search.
+func buildNotificationDeadLetterSearchQuery(tx *gorm.DB, criteria *models.NotificationDeadLetterFilterCriteria) *gorm.DB {
+	defaultLog.Trace("postgres/notification_dead_letter_store:buildNotificationDeadLetterSearchQuery() Entering")
+	defer defaultLog.Trace("postgres/notification_dead_letter_store:buildNotificationDeadLetterSearchQuery() Leaving")
+
+	if tx == nil {
+		return nil
+	}
+	tx = tx.Model(&notificationDeadLetter{})
+	limit := constants.DefaultSearchResultRowLimit
+	if criteria != nil {
+		if criteria.Id != uuid.Nil {
+			tx = tx.Where("id = ?", criteria.Id)
+		}
+		if criteria.SubscriptionId != uuid.Nil {
+			tx = tx.Where("subscription_id = ?", criteria.SubscriptionId)
+		}
+		if criteria.Limit > 0 {
+			limit = criteria.Limit
+		}
+	}
+	return tx.Order("created desc").Limit(limit)
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+type NotificationSubscriptionStore struct {
+	Store *DataStore
+	Dek   []byte
+}
+
+func NewNotificationSubscriptionStore(store *DataStore, dek []byte) *NotificationSubscriptionStore {
+	return &NotificationSubscriptionStore{store, dek}
+}
+
+// Create persists a subscription. The HMAC secret is stored encrypted with the data encryption key.
+func (nss *NotificationSubscriptionStore) Create(ns *hvs.NotificationSubscription) (*hvs.NotificationSubscription, error) {
+	defaultLog.Trace("postgres/notification_subscription_store:Create() Entering")
+	defer defaultLog.Trace("postgres/notification_subscription_store:Create() Leaving")
+
+	if ns.ID == uuid.Nil {
+		newUuid, err := uuid.NewRandom()
+		if err != nil {
+			return nil, errors.Wrap(err, "postgres/notification_subscription_store:Create() failed to create new UUID")
+		}
+		ns.ID = newUuid
+	}
+	ns.Created = time.Now().UTC()
+
+	var encSecret string
+	if ns.Secret != "" {
+		var err error
+		encSecret, err = utils.EncryptString(ns.Secret, nss.Dek)
+		if err != nil {
+			return nil, errors.Wrap(err, "postgres/notification_subscription_store:Create() Failed to encrypt subscription secret")
+		}
+	}
+
+	dbSubscription := notificationSubscription{
+		ID:          ns.ID,
+		CallbackURL: ns.CallbackURL,
+		Secret:      encSecret,
+		Filter: PGNotificationFilter{
+			EventTypes:  ns.EventTypes,
+			HostIDs:     ns.HostIDs,
+			FlavorParts: ns.FlavorParts,
+		},
+		CreatedAt: ns.Created,
+	}
+	if err := nss.Store.Db.Create(&dbSubscription).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/notification_subscription_store:Create() Failed to create notification subscription")
+	}
+	return ns, nil
+}
+
+func (nss *NotificationSubscriptionStore) Retrieve(id uuid.UUID) (*hvs.NotificationSubscription, error) {
+	defaultLog.Trace("postgres/notification_subscription_store:Retrieve() Entering")
+	defer defaultLog.Trace("postgres/notification_subscription_store:Retrieve() Leaving")
+
+	row := nss.Store.Db.Model(&notificationSubscription{}).Where(&notificationSubscription{ID: id}).Row()
+	dbSubscription := notificationSubscription{}
+	if err := row.Scan(&dbSubscription.ID, &dbSubscription.CallbackURL, &dbSubscription.Secret,
+		&dbSubscription.Filter, &dbSubscription.CreatedAt); err != nil {
+		return nil, errors.Wrap(err, "postgres/notification_subscription_store:Retrieve() Failed to scan record")
+	}
+	return nss.toNotificationSubscription(dbSubscription)
+}
+
+func (nss *NotificationSubscriptionStore) Search(criteria *models.NotificationSubscriptionFilterCriteria) ([]hvs.NotificationSubscription, error) {
+	defaultLog.Trace("postgres/notification_subscription_store:Search() Entering")
+	defer defaultLog.Trace("postgres/notification_subscription_store:Search() Leaving")
+
+	tx := buildNotificationSubscriptionSearchQuery(nss.Store.Db, criteria)
+	if tx == nil {
+		return nil, errors.New("postgres/notification_subscription_store:Search() Unexpected Error. Could not build" +
+			" a gorm query object.")
+	}
+
+	rows, err := tx.Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/notification_subscription_store:Search() Failed to retrieve records from db")
+	}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing rows")
+		}
+	}()
+
+	subscriptions := []hvs.NotificationSubscription{}
+	for rows.Next() {
+		dbSubscription := notificationSubscription{}
+		if err := rows.Scan(&dbSubscription.ID, &dbSubscription.CallbackURL, &dbSubscription.Secret,
+			&dbSubscription.Filter, &dbSubscription.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "postgres/notification_subscription_store:Search() Failed to scan record")
+		}
+		subscription, err := nss.toNotificationSubscription(dbSubscription)
+		if err != nil {
+			return nil, errors.Wrap(err, "postgres/notification_subscription_store:Search() Failed to convert record")
+		}
+		subscriptions = append(subscriptions, *subscription)
+	}
+	return subscriptions, nil
+}
+
+func (nss *NotificationSubscriptionStore) Delete(id uuid.UUID) error {
+	defaultLog.Trace("postgres/notification_subscription_store:Delete() Entering")
+	defer defaultLog.Trace("postgres/notification_subscription_store:Delete() Leaving")
+
+	if err := nss.Store.Db.Delete(&notificationSubscription{ID: id}).Error; err != nil {
+		return errors.Wrap(err, "postgres/notification_subscription_store:Delete() Failed to delete notification subscription")
+	}
+	return nil
+}
+
+func (nss *NotificationSubscriptionStore) toNotificationSubscription(dbSubscription notificationSubscription) (*hvs.NotificationSubscription, error) {
+	var secret string
+	if dbSubscription.Secret != "" {
+		var err error
+		secret, err = utils.DecryptString(dbSubscription.Secret, nss.Dek)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to decrypt subscription secret")
+		}
+	}
+	return &hvs.NotificationSubscription{
+		ID:          dbSubscription.ID,
+		CallbackURL: dbSubscription.CallbackURL,
+		Secret:      secret,
+		EventTypes:  dbSubscription.Filter.EventTypes,
+		HostIDs:     dbSubscription.Filter.HostIDs,
+		FlavorParts: dbSubscription.Filter.FlavorParts,
+		Created:     dbSubscription.CreatedAt,
+	}, nil
+}
+
+// helper function to build the query object for a notification subscription search.
+func buildNotificationSubscriptionSearchQuery(tx *gorm.DB, criteria *models.NotificationSubscriptionFilterCriteria) *gorm.DB {
+	defaultLog.Trace("postgres/notification_subscription_store:buildNotificationSubscriptionSearchQuery() Entering")
+	defer defaultLog.Trace("postgres/notification_subscription_store:buildNotificationSubscriptionSearchQuery() Leaving")
+
+	if tx == nil {
+		return nil
+	}
+	tx = tx.Model(&notificationSubscription{})
+	if criteria == nil {
+		return tx
+	}
+	if criteria.Id != uuid.Nil {
+		tx = tx.Where("id = ?", criteria.Id)
+	}
+	if criteria.CallbackURL != "" {
+		tx = tx.Where("callback_url = ?", criteria.CallbackURL)
+	}
+	return tx
+}
@@ -0,0 +1,37 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/jinzhu/gorm"
+)
+
+// applyPage sorts a search query on keyColumn and idColumn, keeps the records sorted after the cursor and returns at
+// most limit of them. The records are sorted on idColumn only when keyColumn is empty. The query must not have Or
+// conditions, as the conditions added here would bind to the last of them.
+func applyPage(tx *gorm.DB, keyColumn, idColumn string, descending bool, after *models.PageCursor, limit int) *gorm.DB {
+	order, operator := "asc", ">"
+	if descending {
+		order, operator = "desc", "<"
+	}
+
+	if keyColumn != "" {
+		if after != nil {
+			tx = tx.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", keyColumn, idColumn, operator), after.Key, after.Id)
+		}
+		tx = tx.Order(keyColumn + " " + order)
+	} else if after != nil {
+		tx = tx.Where(fmt.Sprintf("%s %s ?", idColumn, operator), after.Id)
+	}
+	tx = tx.Order(idColumn + " " + order)
+
+	if limit > 0 {
+		tx = tx.Limit(limit)
+	}
+	return tx
+}
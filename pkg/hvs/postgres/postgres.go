@@ -142,9 +142,16 @@ func (ds *DataStore) Migrate() {
 	defaultLog.Trace("postgres/postgres:Migrate() Entering")
 	defer defaultLog.Trace("postgres/postgres:Migrate() Leaving")
 
-	ds.Db.AutoMigrate(flavorGroup{}, host{}, flavor{}, trustCache{}, hostuniqueFlavor{}, flavorgroupFlavor{}, hostStatus{}, esxiCluster{},
+	ds.Db.AutoMigrate(flavorGroup{}, host{}, flavor{}, flavorRevision{}, trustCache{}, hostuniqueFlavor{}, flavorgroupFlavor{}, hostStatus{}, esxiCluster{},
 		esxiClusterHost{}, tagCertificate{}, tpmEndorsement{}, report{}, hostCredential{}, hostFlavorgroup{}, auditLogEntry{},
-		queue{}, flavorTemplate{})
+		queue{}, flavorTemplate{}, notificationSubscription{}, notificationDeadLetter{}, job{}, endorsementAuthority{}, aikCertificate{})
+
+	// flavors created before revisions were introduced get their current content recorded as the first revision
+	if err := ds.Db.Exec("INSERT INTO flavor_revision (flavor_id, revision, content, signature, created_by, comment, created) " +
+		"SELECT f.id, 1, f.content, f.signature, '', '', f.created_at FROM flavor f " +
+		"WHERE NOT EXISTS (SELECT 1 FROM flavor_revision fr WHERE fr.flavor_id = f.id)").Error; err != nil {
+		defaultLog.WithError(err).Error("postgres/postgres:Migrate() Failed to record initial flavor revisions")
+	}
 }
 
 func (ds *DataStore) Close() {
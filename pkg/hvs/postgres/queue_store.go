@@ -14,6 +14,9 @@ import (
 	"github.com/pkg/errors"
 )
 
+// columns of the queue table in the order in which they are scanned
+const queueColumns = "id, action, params, created_at, updated_at, state, message, priority, stage"
+
 type QueueStore struct {
 	store *DataStore
 }
@@ -41,6 +44,11 @@ func (qr *QueueStore) Create(q *models.Queue) (*models.Queue, error) {
 		Message:   q.Message,
 		Params:    PGJsonStrMap(q.Params),
 		CreatedAt: time.Now().UTC(),
+		Priority:  q.Priority,
+		Stage:     q.Stage,
+	}
+	if dbq.Priority == models.QueuePriorityUnknown {
+		dbq.Priority = models.QueuePriorityHigh
 	}
 
 	if err := qr.store.Db.Create(&dbq).Error; err != nil {
@@ -54,9 +62,10 @@ func (qr *QueueStore) Create(q *models.Queue) (*models.Queue, error) {
 func (qr *QueueStore) Retrieve(id uuid.UUID) (*models.Queue, error) {
 	defaultLog.Trace("postgres/queue_store:Retrieve() Entering")
 	defer defaultLog.Trace("postgres/queue_store:Retrieve() Leaving")
-	row := qr.store.Db.Model(&queue{}).Where(&queue{Id: id}).Row()
+	row := qr.store.Db.Model(&queue{}).Select(queueColumns).Where(&queue{Id: id}).Row()
 	q := models.Queue{}
-	if err := row.Scan(&q.Id, &q.Action, (*PGJsonStrMap)(&q.Params), &q.Created, &q.Updated, &q.State, &q.Message); err != nil {
+	if err := row.Scan(&q.Id, &q.Action, (*PGJsonStrMap)(&q.Params), &q.Created, &q.Updated, &q.State, &q.Message,
+		&q.Priority, &q.Stage); err != nil {
 		return nil, errors.Wrap(err, "postgres/queue_store:Retrieve() - Could not scan record ")
 	}
 
@@ -88,7 +97,8 @@ func (qr *QueueStore) Search(qf *models.QueueFilterCriteria) ([]*models.Queue, e
 
 	for rows.Next() {
 		q := models.Queue{}
-		if err := rows.Scan(&q.Id, &q.Action, (*PGJsonStrMap)(&q.Params), &q.Created, &q.Updated, &q.State, &q.Message); err != nil {
+		if err := rows.Scan(&q.Id, &q.Action, (*PGJsonStrMap)(&q.Params), &q.Created, &q.Updated, &q.State, &q.Message,
+			&q.Priority, &q.Stage); err != nil {
 			return nil, errors.Wrap(err, "postgres/queue_store:Retrieve() - Could not scan record ")
 		}
 		result = append(result, &q)
@@ -111,6 +121,8 @@ func (qr *QueueStore) Update(q *models.Queue) error {
 		Message:   q.Message,
 		CreatedAt: q.Created,
 		UpdatedAt: time.Now().UTC(),
+		Priority:  q.Priority,
+		Stage:     q.Stage,
 	}
 	if q.Params != nil {
 		dbq.Params = PGJsonStrMap(q.Params)
@@ -147,7 +159,7 @@ func buildQueueSearchQuery(tx *gorm.DB, qf *models.QueueFilterCriteria) *gorm.DB
 	if tx == nil {
 		return nil
 	}
-	tx = tx.Model(&queue{})
+	tx = tx.Model(&queue{}).Select(queueColumns).Order("priority, created_at")
 	if qf == nil {
 		return tx
 	}
@@ -168,6 +180,18 @@ func buildQueueSearchQuery(tx *gorm.DB, qf *models.QueueFilterCriteria) *gorm.DB
 	if len(qf.QueueStates) > 0 {
 		tx = tx.Where("state in (?)", qf.QueueStates)
 	}
+	if qf.HostId != uuid.Nil {
+		tx = tx.Where("params ->> 'host_id' = ?", qf.HostId.String())
+	}
+	if len(qf.Stages) > 0 {
+		tx = tx.Where("stage in (?)", qf.Stages)
+	}
+	if qf.Priority != models.QueuePriorityUnknown {
+		tx = tx.Where("priority = ?", qf.Priority)
+	}
+	if !qf.CreatedBefore.IsZero() {
+		tx = tx.Where("created_at < ?", qf.CreatedBefore)
+	}
 
 	// apply limit
 	if qf.Limit > 0 {
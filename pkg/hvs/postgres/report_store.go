@@ -122,6 +122,23 @@ func (r *ReportStore) Search(criteria *models.ReportFilterCriteria) ([]models.HV
 	defaultLog.Trace("postgres/report_store:Search() Entering")
 	defer defaultLog.Trace("postgres/report_store:Search() Leaving")
 
+	var reports []models.HVSReport
+	err := r.Iterate(criteria, func(report *models.HVSReport) error {
+		reports = append(reports, *report)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// Iterate calls visit with each HVSReport pertaining to a user-provided ReportFilterCriteria, as the records are read
+// from the db. The iteration stops on the first error returned by visit. A negative limit searches all the records.
+func (r *ReportStore) Iterate(criteria *models.ReportFilterCriteria, visit func(*models.HVSReport) error) error {
+	defaultLog.Trace("postgres/report_store:Iterate() Entering")
+	defer defaultLog.Trace("postgres/report_store:Iterate() Leaving")
+
 	var reportID uuid.UUID
 	var hostID uuid.UUID
 	var hostName string
@@ -164,16 +181,16 @@ func (r *ReportStore) Search(criteria *models.ReportFilterCriteria) ([]models.HV
 
 	var tx *gorm.DB
 	if fromDate.IsZero() && toDate.IsZero() && criteria.LatestPerHost {
-		tx = buildLatestReportSearchQuery(r.Store.Db, reportID, hostID, hostHardwareUUID, hostName, hostStatus, criteria.Limit)
+		tx = buildLatestReportSearchQuery(r.Store.Db, reportID, hostID, hostHardwareUUID, hostName, hostStatus, criteria.LabelSelector, criteria.Limit)
 
 		if tx == nil {
-			return nil, errors.New("postgres/report_store:Search() Unexpected Error. Could not build" +
+			return errors.New("postgres/report_store:Iterate() Unexpected Error. Could not build" +
 				" a gorm query object in HVSReport Search function.")
 		}
 
 		rows, err := tx.Rows()
 		if err != nil {
-			return nil, errors.Wrap(err, "postgres/report_store:Search() failed to retrieve records from db")
+			return errors.Wrap(err, "postgres/report_store:Iterate() failed to retrieve records from db")
 		}
 		defer func() {
 			derr := rows.Close()
@@ -182,28 +199,28 @@ func (r *ReportStore) Search(criteria *models.ReportFilterCriteria) ([]models.HV
 			}
 		}()
 
-		var reports []models.HVSReport
-
 		for rows.Next() {
 			result := models.HVSReport{}
 			ignoreMe := false //The new 'Trusted' field was introduced to v3.5, ignore that field in the query so it returns the correct results
 			if err := rows.Scan(&result.ID, &result.HostID, (*PGTrustReport)(&result.TrustReport), &ignoreMe, &result.CreatedAt, &result.Expiration, &result.Saml); err != nil {
-				return nil, errors.Wrap(err, "postgres/report_store:Search() failed to scan record")
+				return errors.Wrap(err, "postgres/report_store:Iterate() failed to scan record")
+			}
+			if err := visit(&result); err != nil {
+				return err
 			}
-			reports = append(reports, result)
 		}
 
-		return reports, nil
+		return nil
 	} else {
-		tx = buildReportSearchQuery(r.Store.Db, hostID, hostHardwareUUID, hostName, hostStatus, fromDate, toDate, latestPerHost, criteria.Limit)
+		tx = buildReportSearchQuery(r.Store.Db, reportID, hostHardwareUUID, hostID, hostName, hostStatus, fromDate, toDate, latestPerHost, criteria.LabelSelector, criteria.Limit)
 		if tx == nil {
-			return nil, errors.New("postgres/report_store:Search() Unexpected Error. Could not build" +
+			return errors.New("postgres/report_store:Iterate() Unexpected Error. Could not build" +
 				" a gorm query object in HVSReport Search function.")
 		}
 
 		rows, err := tx.Rows()
 		if err != nil {
-			return nil, errors.Wrap(err, "postgres/report_store:Search() failed to retrieve records from db")
+			return errors.Wrap(err, "postgres/report_store:Iterate() failed to retrieve records from db")
 		}
 		defer func() {
 			derr := rows.Close()
@@ -212,23 +229,24 @@ func (r *ReportStore) Search(criteria *models.ReportFilterCriteria) ([]models.HV
 			}
 		}()
 
-		var reports []models.HVSReport
 		for rows.Next() {
 			result := models.AuditLogEntry{}
 			if err := rows.Scan(&result.ID, &result.EntityID, &result.EntityType, &result.CreatedAt, &result.Action, (*PGAuditLogData)(&result.Data)); err != nil {
-				return nil, errors.Wrap(err, "postgres/report_store:Search() failed to scan record")
+				return errors.Wrap(err, "postgres/report_store:Iterate() failed to scan record")
 			}
 			if reflect.DeepEqual(models.AuditTableData{}, result.Data) || len(result.Data.Columns) == 0 {
 				continue
 			}
 			hvsReport, err := auditlogEntryToReport(result)
 			if err != nil {
-				return nil, errors.Wrap(err, "postgres/report_store:Search() convert auditloag entry into report")
+				return errors.Wrap(err, "postgres/report_store:Iterate() convert auditloag entry into report")
+			}
+			if err := visit(hvsReport); err != nil {
+				return err
 			}
-			reports = append(reports, *hvsReport)
 		}
 
-		return reports, nil
+		return nil
 	}
 }
 
@@ -339,7 +357,7 @@ func (r *ReportStore) Delete(reportId uuid.UUID) error {
 }
 
 // buildReportSearchQuery is a helper function to build the query object for a report search.
-func buildReportSearchQuery(tx *gorm.DB, hostHardwareID, hostID uuid.UUID, hostName, hostState string, fromDate, toDate time.Time, latestPerHost bool, limit int) *gorm.DB {
+func buildReportSearchQuery(tx *gorm.DB, reportID, hostHardwareID, hostID uuid.UUID, hostName, hostState string, fromDate, toDate time.Time, latestPerHost bool, labelSelector *models.LabelSelector, limit int) *gorm.DB {
 	defaultLog.Trace("postgres/report_store:buildReportSearchQuery() Entering")
 	defer defaultLog.Trace("postgres/report_store:buildReportSearchQuery() Leaving")
 	if tx == nil {
@@ -348,20 +366,20 @@ func buildReportSearchQuery(tx *gorm.DB, hostHardwareID, hostID uuid.UUID, hostN
 	if latestPerHost {
 		entity := "auj"
 		txSubQuery := tx.Table("audit_log_entry auj").Select("data -> 'Columns' -> 1 ->> 'Value' AS host_id, max(auj.created) AS max_date ")
-		txSubQuery = buildReportSearchQueryWithCriteria(txSubQuery, hostHardwareID, hostID, entity, hostName, hostState, fromDate, toDate)
+		txSubQuery = buildReportSearchQueryWithCriteria(txSubQuery, reportID, hostHardwareID, hostID, entity, hostName, hostState, fromDate, toDate, labelSelector)
 		txSubQuery = txSubQuery.Group("host_id")
 		subQuery := txSubQuery.SubQuery()
 		tx = tx.Table("audit_log_entry au").Select("au.*").Joins("INNER JOIN ? a ON a.host_id = au.data -> 'Columns' -> 1 ->> 'Value' AND a.max_date = au.created", subQuery)
 	} else {
 		entity := "au"
 		tx = tx.Table("audit_log_entry au").Select("au.*")
-		tx = buildReportSearchQueryWithCriteria(tx, hostHardwareID, hostID, entity, hostName, hostState, fromDate, toDate)
+		tx = buildReportSearchQueryWithCriteria(tx, reportID, hostHardwareID, hostID, entity, hostName, hostState, fromDate, toDate, labelSelector)
 	}
 	tx = tx.Limit(limit)
 	return tx
 }
 
-func buildReportSearchQueryWithCriteria(tx *gorm.DB, hostHardwareID, hostID uuid.UUID, entity, hostName string, hostState string, fromDate, toDate time.Time) *gorm.DB {
+func buildReportSearchQueryWithCriteria(tx *gorm.DB, reportID, hostHardwareID, hostID uuid.UUID, entity, hostName string, hostState string, fromDate, toDate time.Time, labelSelector *models.LabelSelector) *gorm.DB {
 	defaultLog.Trace("postgres/report_store:buildReportSearchQueryWithCriteria() Entering")
 	defer defaultLog.Trace("postgres/report_store:buildReportSearchQueryWithCriteria() Leaving")
 
@@ -376,6 +394,10 @@ func buildReportSearchQueryWithCriteria(tx *gorm.DB, hostHardwareID, hostID uuid
 	//TODO rename after testing
 	tx = tx.Where(entity + ".entity_type = 'report'")
 
+	if reportID != uuid.Nil {
+		tx = tx.Where(entity+".entity_id = ?", reportID)
+	}
+
 	if hostName != "" {
 		tx = tx.Where("h.name = ?", hostName)
 	}
@@ -400,11 +422,16 @@ func buildReportSearchQueryWithCriteria(tx *gorm.DB, hostHardwareID, hostID uuid
 		tx = tx.Where("CAST("+entity+".created AS TIMESTAMP) < CAST(? AS TIMESTAMP)", toDate)
 	}
 
+	if labelSelector != nil {
+		condition, args := labelSelectorHostCondition(entity+".data -> 'Columns' -> 1 ->> 'Value'", "CAST(host.id AS VARCHAR)", labelSelector)
+		tx = tx.Where(condition, args...)
+	}
+
 	return tx
 }
 
 // buildLatestReportSearchQuery is a helper function to build the query object for a latest report search.
-func buildLatestReportSearchQuery(tx *gorm.DB, reportID, hostID, hostHardwareID uuid.UUID, hostName, hostState string, limit int) *gorm.DB {
+func buildLatestReportSearchQuery(tx *gorm.DB, reportID, hostID, hostHardwareID uuid.UUID, hostName, hostState string, labelSelector *models.LabelSelector, limit int) *gorm.DB {
 	defaultLog.Trace("postgres/report_store:buildLatestReportSearchQuery() Entering")
 	defer defaultLog.Trace("postgres/report_store:buildLatestReportSearchQuery() Leaving")
 
@@ -440,6 +467,11 @@ func buildLatestReportSearchQuery(tx *gorm.DB, reportID, hostID, hostHardwareID
 		tx = tx.Where("host_id = ?", hostID.String())
 	}
 
+	if labelSelector != nil {
+		condition, args := labelSelectorHostCondition("report.host_id", "host.id", labelSelector)
+		tx = tx.Where(condition, args...)
+	}
+
 	tx = tx.Limit(limit)
 	return tx
 }
@@ -39,6 +39,7 @@ func (t *TpmEndorsementStore) Create(te *hvs.TpmEndorsement) (*hvs.TpmEndorsemen
 		Certificate:       te.Certificate,
 		Comment:           te.Comment,
 		CertificateDigest: te.CertificateDigest,
+		Provenance:        (*PGEndorsementProvenance)(te.EndorsementProvenance),
 	}
 
 	if err := t.Store.Db.Create(&dbTpmEndorsement).Error; err != nil {
@@ -57,6 +58,7 @@ func (t *TpmEndorsementStore) Update(te *hvs.TpmEndorsement) (*hvs.TpmEndorsemen
 		Certificate:       te.Certificate,
 		Comment:           te.Comment,
 		CertificateDigest: te.CertificateDigest,
+		Provenance:        (*PGEndorsementProvenance)(te.EndorsementProvenance),
 	}
 	if err := t.Store.Db.Save(&dbTpmEndorsement).Error; err != nil {
 		return nil, errors.Wrap(err, "postgres/tpm_endorsement_store:Update() failed to save TpmEndorsement")
@@ -70,9 +72,11 @@ func (t *TpmEndorsementStore) Retrieve(id uuid.UUID) (*hvs.TpmEndorsement, error
 
 	row := t.Store.Db.Model(tpmEndorsement{}).Where(tpmEndorsement{ID: id}).Row()
 	te := hvs.TpmEndorsement{}
-	if err := row.Scan(&te.ID, &te.HardwareUUID, &te.Issuer, &te.Revoked, &te.Certificate, &te.Comment, &te.CertificateDigest); err != nil {
+	var provenance *PGEndorsementProvenance
+	if err := row.Scan(&te.ID, &te.HardwareUUID, &te.Issuer, &te.Revoked, &te.Certificate, &te.Comment, &te.CertificateDigest, &provenance); err != nil {
 		return nil, errors.Wrap(err, "postgres/tpm_endorsement_store:Retrieve() - Could not scan record ")
 	}
+	te.EndorsementProvenance = (*hvs.EndorsementProvenance)(provenance)
 
 	return &te, nil
 }
@@ -113,9 +117,11 @@ func (t *TpmEndorsementStore) Search(teFilter *models.TpmEndorsementFilterCriter
 
 	for rows.Next() {
 		te := hvs.TpmEndorsement{}
-		if err := rows.Scan(&te.ID, &te.HardwareUUID, &te.Issuer, &te.Revoked, &te.Certificate, &te.Comment, &te.CertificateDigest); err != nil {
+		var provenance *PGEndorsementProvenance
+		if err := rows.Scan(&te.ID, &te.HardwareUUID, &te.Issuer, &te.Revoked, &te.Certificate, &te.Comment, &te.CertificateDigest, &provenance); err != nil {
 			return nil, errors.Wrap(err, "postgres/tpm_endorsement_store:Search() - Could not scan record ")
 		}
+		te.EndorsementProvenance = (*hvs.EndorsementProvenance)(provenance)
 		tpmEndorsementCollection.TpmEndorsement = append(tpmEndorsementCollection.TpmEndorsement, &te)
 	}
 
@@ -151,5 +157,5 @@ func buildTpmEndorsementSearchQuery(tx *gorm.DB, teFilter *models.TpmEndorsement
 	} else if teFilter.CertificateDigestEqualTo != "" {
 		tx = tx.Where("certificate_digest = ? ", teFilter.CertificateDigestEqualTo)
 	}
-	return tx
+	return applyPage(tx, "", "id", false, teFilter.After, teFilter.Limit)
 }
@@ -0,0 +1,60 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package router
+
+import (
+	"fmt"
+
+	"github.com/golang/groupcache/lru"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/postgres"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
+)
+
+// SetAikCertificateRoutes registers routes for aik-certificates
+func SetAikCertificateRoutes(router *mux.Router, store *postgres.DataStore, certStore *models.CertificatesStore, crlCache *utils.AikCrlCache,
+	hostTrustManager domain.HostTrustManager, hostTrustCache *lru.Cache) *mux.Router {
+	defaultLog.Trace("router/aik_certificates:SetAikCertificateRoutes() Entering")
+	defer defaultLog.Trace("router/aik_certificates:SetAikCertificateRoutes() Leaving")
+
+	aikCertificateStore := postgres.NewAikCertificateStore(store)
+	hostStore := postgres.NewHostStore(store)
+	aikCertificateController := controllers.NewAikCertificateController(aikCertificateStore, certStore, constants.DefaultAikCrlValidity,
+		crlCache, hostStore, hostTrustManager, hostTrustCache)
+
+	aikCertificateExpr := "/aik-certificates"
+	aikCertificateIdExpr := fmt.Sprintf("%s/%s", aikCertificateExpr, validation.IdReg)
+
+	router.Handle(aikCertificateExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(aikCertificateController.Search),
+		[]string{constants.AikCertificateSearch}))).Methods("GET")
+	router.Handle(aikCertificateIdExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(aikCertificateController.Retrieve),
+		[]string{constants.AikCertificateRetrieve}))).Methods("GET")
+	router.Handle(aikCertificateIdExpr+"/revoke", ErrorHandler(permissionsHandler(JsonResponseHandler(aikCertificateController.Revoke),
+		[]string{constants.AikCertificateRevoke}))).Methods("POST")
+
+	return router
+}
+
+// SetAikCrlRoutes registers the route for the CRL of the privacy CA, which is available without authentication so
+// that the relying parties can check the revocation of the AIK certificates. The CRL is served from crlCache, which
+// must be the cache invalidated by the revocations of the aik-certificates routes.
+func SetAikCrlRoutes(router *mux.Router, store *postgres.DataStore, certStore *models.CertificatesStore, crlCache *utils.AikCrlCache) *mux.Router {
+	defaultLog.Trace("router/aik_certificates:SetAikCrlRoutes() Entering")
+	defer defaultLog.Trace("router/aik_certificates:SetAikCrlRoutes() Leaving")
+
+	aikCertificateStore := postgres.NewAikCertificateStore(store)
+	aikCertificateController := controllers.NewAikCertificateController(aikCertificateStore, certStore, constants.DefaultAikCrlValidity,
+		crlCache, nil, nil, nil)
+
+	router.Handle("/privacyca/crl", ErrorHandler(ResponseHandler(aikCertificateController.Crl))).Methods("GET").
+		Headers("Accept", consts.HTTPMediaTypePemFile)
+	return router
+}
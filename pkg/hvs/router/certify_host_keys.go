@@ -31,7 +31,8 @@ func SetCertifyAiksRoutes(router *mux.Router, store *postgres.DataStore, certSto
 	defer defaultLog.Trace("router/certify_host_aiks:SetCertifyAiksRoutes() Leaving")
 
 	tpmEndorsementStore := postgres.NewTpmEndorsementStore(store)
-	certifyHostAiksController := controllers.NewCertifyHostAiksController(certStore, tpmEndorsementStore, aikCertValidity, consts.AikRequestsDir)
+	endorsementAuthorityStore := postgres.NewEndorsementAuthorityStore(store)
+	certifyHostAiksController := controllers.NewCertifyHostAiksController(certStore, tpmEndorsementStore, endorsementAuthorityStore, postgres.NewAikCertificateStore(store), aikCertValidity, consts.AikRequestsDir)
 	if certifyHostAiksController != nil {
 		router.Handle("/privacyca/identity-challenge-request", ErrorHandler(permissionsHandler(JsonResponseHandler(certifyHostAiksController.IdentityRequestGetChallenge),
 			[]string{consts.CertifyAik}))).Methods("POST")
@@ -0,0 +1,40 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package router
+
+import (
+	"fmt"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/postgres"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
+)
+
+// SetEndorsementAuthorityRoutes registers routes for endorsement-authorities
+func SetEndorsementAuthorityRoutes(router *mux.Router, store *postgres.DataStore) *mux.Router {
+	defaultLog.Trace("router/endorsement_authorities:SetEndorsementAuthorityRoutes() Entering")
+	defer defaultLog.Trace("router/endorsement_authorities:SetEndorsementAuthorityRoutes() Leaving")
+
+	endorsementAuthorityStore := postgres.NewEndorsementAuthorityStore(store)
+	endorsementAuthorityController := controllers.NewEndorsementAuthorityController(endorsementAuthorityStore)
+
+	authorityExpr := "/endorsement-authorities"
+	authorityIdExpr := fmt.Sprintf("%s/%s", authorityExpr, validation.IdReg)
+
+	router.Handle(authorityExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(endorsementAuthorityController.Create),
+		[]string{constants.EndorsementAuthorityCreate}))).Methods("POST")
+	router.Handle(authorityExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(endorsementAuthorityController.Search),
+		[]string{constants.EndorsementAuthoritySearch}))).Methods("GET")
+	router.Handle(authorityIdExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(endorsementAuthorityController.Retrieve),
+		[]string{constants.EndorsementAuthorityRetrieve}))).Methods("GET")
+	router.Handle(authorityIdExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(endorsementAuthorityController.Update),
+		[]string{constants.EndorsementAuthorityStore}))).Methods("PUT")
+	router.Handle(authorityIdExpr, ErrorHandler(permissionsHandler(ResponseHandler(endorsementAuthorityController.Delete),
+		[]string{constants.EndorsementAuthorityDelete}))).Methods("DELETE")
+
+	return router
+}
@@ -22,9 +22,10 @@ func SetFlavorFromAppManifestRoute(router *mux.Router, store *postgres.DataStore
 
 	flavorStore := postgres.NewFlavorStore(store)
 	hostStore := postgres.NewHostStore(store)
+	hostStatusStore := postgres.NewHostStatusStore(store)
 	tagCertStore := postgres.NewTagCertificateStore(store)
 	flavorTemplateStore := postgres.NewFlavorTemplateStore(store)
-	flavorController := controllers.NewFlavorController(flavorStore, flavorGroupStore, hostStore, tagCertStore, hostTrustManager, certStore, hcConfig, flavorTemplateStore)
+	flavorController := controllers.NewFlavorController(flavorStore, flavorGroupStore, hostStore, hostStatusStore, tagCertStore, hostTrustManager, certStore, hcConfig, flavorTemplateStore)
 	flavorFromAppManifestController := controllers.NewFlavorFromAppManifestController(*flavorController)
 
 	router.Handle("/flavor-from-app-manifest",
@@ -10,22 +10,29 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/postgres"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
 )
 
 // SetFlavorGroupRoutes registers routes for flavorgroups
-func SetFlavorGroupRoutes(router *mux.Router, store *postgres.DataStore, flavorgroupStore *postgres.FlavorGroupStore, hostTrustManager domain.HostTrustManager) *mux.Router {
+func SetFlavorGroupRoutes(router *mux.Router, store *postgres.DataStore, flavorgroupStore *postgres.FlavorGroupStore, certStore *models.CertificatesStore, hostTrustManager domain.HostTrustManager) *mux.Router {
 	defaultLog.Trace("router/flavorgroups:SetFlavorGroupRoutes() Entering")
 	defer defaultLog.Trace("router/flavorgroups:SetFlavorGroupRoutes() Leaving")
 
 	flavorStore := postgres.NewFlavorStore(store)
 	hostStore := postgres.NewHostStore(store)
+	hostStatusStore := postgres.NewHostStatusStore(store)
+	flavorTemplateStore := postgres.NewFlavorTemplateStore(store)
 	flavorgroupController := controllers.FlavorgroupController{
-		FlavorGroupStore: flavorgroupStore,
-		FlavorStore:      flavorStore,
-		HostStore:        hostStore,
-		HTManager:        hostTrustManager,
+		FlavorGroupStore:       flavorgroupStore,
+		FlavorStore:            flavorStore,
+		HostStore:              hostStore,
+		HostStatusStore:        hostStatusStore,
+		HTManager:              hostTrustManager,
+		FlavorTemplateStore:    flavorTemplateStore,
+		FlavorgroupBundleStore: postgres.NewFlavorgroupBundleStore(store, flavorgroupStore),
+		CertStore:              certStore,
 	}
 
 	flavorGroupIdExpr := fmt.Sprintf("%s%s", "/flavorgroups/", validation.IdReg)
@@ -37,6 +44,10 @@ func SetFlavorGroupRoutes(router *mux.Router, store *postgres.DataStore, flavorg
 		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorgroupController.Search),
 			[]string{constants.FlavorGroupSearch}))).Methods("GET")
 
+	router.Handle("/flavorgroups/import",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorgroupController.Import),
+			[]string{constants.FlavorGroupImport}))).Methods("POST")
+
 	router.Handle(flavorGroupIdExpr,
 		ErrorHandler(permissionsHandler(ResponseHandler(flavorgroupController.Delete),
 			[]string{constants.FlavorGroupDelete}))).Methods("DELETE")
@@ -45,6 +56,10 @@ func SetFlavorGroupRoutes(router *mux.Router, store *postgres.DataStore, flavorg
 		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorgroupController.Retrieve),
 			[]string{constants.FlavorGroupRetrieve}))).Methods("GET")
 
+	router.Handle(fmt.Sprintf("/flavorgroups/{id:%s}/export", validation.UUIDReg),
+		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorgroupController.Export),
+			[]string{constants.FlavorGroupExport}))).Methods("GET")
+
 	// routes for FlavorGroupFlavorLink APIs
 	fgFlavorLinkCreateSearchExpr := fmt.Sprintf("/flavorgroups/{fgID:%s}/flavors", validation.UUIDReg)
 	fgFlavorLinkRetrieveDeleteExpr := fmt.Sprintf("/flavorgroups/{fgID:%s}/flavors/{fID:%s}", validation.UUIDReg, validation.UUIDReg)
@@ -13,21 +13,29 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/postgres"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/verifier"
 )
 
 // SetFlavorRoutes registers routes for flavors
-func SetFlavorRoutes(router *mux.Router, store *postgres.DataStore, flavorGroupStore *postgres.FlavorGroupStore, certStore *models.CertificatesStore, hostTrustManager domain.HostTrustManager, flavorControllerConfig domain.HostControllerConfig) *mux.Router {
+func SetFlavorRoutes(router *mux.Router, store *postgres.DataStore, flavorGroupStore *postgres.FlavorGroupStore, certStore *models.CertificatesStore, hostTrustManager domain.HostTrustManager, flavorControllerConfig domain.HostControllerConfig, auditLogWriter domain.AuditLogWriter) *mux.Router {
 	defaultLog.Trace("router/flavors:SetFlavorRoutes() Entering")
 	defer defaultLog.Trace("router/flavors:SetFlavorRoutes() Leaving")
 
 	hostStore := postgres.NewHostStore(store)
+	hostStatusStore := postgres.NewHostStatusStore(store)
 	flavorStore := postgres.NewFlavorStore(store)
+	flavorStore.AuditLogWriter = auditLogWriter
 	tagCertStore := postgres.NewTagCertificateStore(store)
 	flavorTemplateStore := postgres.NewFlavorTemplateStore(store)
-	flavorController := controllers.NewFlavorController(flavorStore, flavorGroupStore, hostStore, tagCertStore, hostTrustManager, certStore, flavorControllerConfig, flavorTemplateStore)
+	flavorController := controllers.NewFlavorController(flavorStore, flavorGroupStore, hostStore, hostStatusStore, tagCertStore, hostTrustManager, certStore, flavorControllerConfig, flavorTemplateStore)
+	if flavorController != nil {
+		flavorController.FlavorVerifier = newFlavorVerifier(certStore)
+	}
 
 	flavorIdExpr := fmt.Sprintf("%s%s", "/flavors/", validation.IdReg)
+	flavorRevisionsExpr := fmt.Sprintf("%s%s", flavorIdExpr, "/revisions")
 
 	router.Handle("/flavors",
 		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.Create),
@@ -38,6 +46,14 @@ func SetFlavorRoutes(router *mux.Router, store *postgres.DataStore, flavorGroupS
 		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.Search),
 			[]string{constants.FlavorSearch}))).Methods("GET")
 
+	router.Handle("/flavors/impact",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.Impact),
+			[]string{constants.FlavorSearch}))).Methods("POST")
+
+	router.Handle("/flavors/simulate",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.Simulate),
+			[]string{constants.FlavorSimulate}))).Methods("POST")
+
 	router.Handle(flavorIdExpr,
 		ErrorHandler(permissionsHandler(ResponseHandler(flavorController.Delete),
 			[]string{constants.FlavorDelete}))).Methods("DELETE")
@@ -46,5 +62,37 @@ func SetFlavorRoutes(router *mux.Router, store *postgres.DataStore, flavorGroupS
 		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.Retrieve),
 			[]string{constants.FlavorRetrieve}))).Methods("GET")
 
+	router.Handle(flavorIdExpr,
+		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.Update),
+			[]string{constants.FlavorUpdate}))).Methods("PUT")
+
+	router.Handle(flavorIdExpr+"/rollback",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.Rollback),
+			[]string{constants.FlavorUpdate}))).Methods("POST")
+
+	router.Handle(flavorRevisionsExpr,
+		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.SearchRevisions),
+			[]string{constants.FlavorRetrieve}))).Methods("GET")
+
+	router.Handle(flavorRevisionsExpr+"/{revision:[0-9]+}",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.RetrieveRevision),
+			[]string{constants.FlavorRetrieve}))).Methods("GET")
+
 	return router
 }
+
+// newFlavorVerifier creates the verifier used to simulate flavors, the simulation is not available when the verifier
+// certificates are not loaded
+func newFlavorVerifier(certStore *models.CertificatesStore) verifier.Verifier {
+	verifierCerts, err := utils.GetVerifierCertificates(certStore)
+	if err != nil {
+		defaultLog.WithError(err).Error("router/flavors:newFlavorVerifier() Flavor simulation is not available")
+		return nil
+	}
+	flavorVerifier, err := verifier.NewVerifier(*verifierCerts)
+	if err != nil {
+		defaultLog.WithError(err).Error("router/flavors:newFlavorVerifier() Flavor simulation is not available")
+		return nil
+	}
+	return flavorVerifier
+}
@@ -96,6 +96,54 @@ func XMLResponseHandler(h func(http.ResponseWriter, *http.Request) (interface{},
 	}
 }
 
+// EventStreamResponseHandler handler for endpoints that stream text/event-stream responses. The application
+// handler writes the stream itself, only errors raised before the stream is opened are formatted
+func EventStreamResponseHandler(h func(http.ResponseWriter, *http.Request) (interface{}, int, error)) endpointHandler {
+	defaultLog.Trace("router/handlers:EventStreamResponseHandler() Entering")
+	defer defaultLog.Trace("router/handlers:EventStreamResponseHandler() Leaving")
+
+	return func(w http.ResponseWriter, r *http.Request) error {
+		if r.Header.Get("Accept") != constants.HTTPMediaTypeEventStream {
+			return errorFormatter(&commErr.EndpointError{
+				Message: "Invalid Accept type",
+			}, http.StatusUnsupportedMediaType)
+		}
+		_, status, err := h(w, r) // execute application handler
+		if err != nil {
+			return errorFormatter(err, status)
+		}
+		return nil
+	}
+}
+
+// StreamResponseHandler handler for endpoints that write their response body themselves, in one of the given media
+// types. The application handler selects the media type from the Accept header, only errors raised before the
+// response is written are formatted
+func StreamResponseHandler(h func(http.ResponseWriter, *http.Request) (interface{}, int, error), mediaTypes ...string) endpointHandler {
+	defaultLog.Trace("router/handlers:StreamResponseHandler() Entering")
+	defer defaultLog.Trace("router/handlers:StreamResponseHandler() Leaving")
+
+	return func(w http.ResponseWriter, r *http.Request) error {
+		accepted := false
+		for _, mediaType := range mediaTypes {
+			if r.Header.Get("Accept") == mediaType {
+				accepted = true
+				break
+			}
+		}
+		if !accepted {
+			return errorFormatter(&commErr.EndpointError{
+				Message: "Invalid Accept type",
+			}, http.StatusUnsupportedMediaType)
+		}
+		_, status, err := h(w, r) // execute application handler
+		if err != nil {
+			return errorFormatter(err, status)
+		}
+		return nil
+	}
+}
+
 func errorFormatter(err error, status int) error {
 	defaultLog.Trace("router/handlers:errorFormatter() Entering")
 	defer defaultLog.Trace("router/handlers:errorFormatter() Leaving")
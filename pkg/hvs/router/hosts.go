@@ -16,7 +16,8 @@ import (
 )
 
 // SetHostRoutes registers routes for hosts
-func SetHostRoutes(router *mux.Router, store *postgres.DataStore, hostTrustManager domain.HostTrustManager, hostControllerConfig domain.HostControllerConfig) *mux.Router {
+func SetHostRoutes(router *mux.Router, store *postgres.DataStore, hostTrustManager domain.HostTrustManager, hostControllerConfig domain.HostControllerConfig,
+	jobRunner domain.JobRunner) *mux.Router {
 	defaultLog.Trace("router/hosts:SetHostRoutes() Entering")
 	defer defaultLog.Trace("router/hosts:SetHostRoutes() Leaving")
 
@@ -29,11 +30,17 @@ func SetHostRoutes(router *mux.Router, store *postgres.DataStore, hostTrustManag
 	hostController := controllers.NewHostController(hostStore, hostStatusStore,
 		flavorStore, flavorGroupStore, hostCredentialStore,
 		hostTrustManager, hostControllerConfig)
+	hostBulkController := controllers.NewHostBulkController(*hostController, postgres.NewJobStore(store), jobRunner)
 
 	hostExpr := "/hosts"
+	hostBulkExpr := fmt.Sprintf("%s/bulk", hostExpr)
+	flavorgroupBulkExpr := fmt.Sprintf("%s/flavorgroups/bulk", hostExpr)
 	hostIdExpr := fmt.Sprintf("%s/{hId:%s}", hostExpr, validation.UUIDReg)
 	flavorgroupExpr := fmt.Sprintf("%s/flavorgroups", hostIdExpr)
 	flavorgroupIdExpr := fmt.Sprintf("%s/{fgId:%s}", flavorgroupExpr, validation.UUIDReg)
+	labelExpr := fmt.Sprintf("%s/labels", hostIdExpr)
+	// label keys can have a prefix separated by a slash
+	labelKeyExpr := fmt.Sprintf("%s/{key:.+}", labelExpr)
 
 	router.Handle(hostExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.Create),
 		[]string{constants.HostCreate}))).Methods("POST")
@@ -45,6 +52,10 @@ func SetHostRoutes(router *mux.Router, store *postgres.DataStore, hostTrustManag
 		[]string{constants.HostDelete}))).Methods("DELETE")
 	router.Handle(hostExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.Search),
 		[]string{constants.HostSearch}))).Methods("GET")
+	router.Handle(hostBulkExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostBulkController.Create),
+		[]string{constants.HostCreate}))).Methods("POST")
+	router.Handle(flavorgroupBulkExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostBulkController.LinkFlavorgroups),
+		[]string{constants.HostCreate}))).Methods("POST")
 
 	router.Handle(flavorgroupExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.AddFlavorgroup),
 		[]string{constants.HostCreate}))).Methods("POST")
@@ -55,5 +66,12 @@ func SetHostRoutes(router *mux.Router, store *postgres.DataStore, hostTrustManag
 	router.Handle(flavorgroupExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.SearchFlavorgroups),
 		[]string{constants.HostSearch}))).Methods("GET")
 
+	router.Handle(labelExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.RetrieveLabels),
+		[]string{constants.HostRetrieve}))).Methods("GET")
+	router.Handle(labelExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.UpdateLabels),
+		[]string{constants.HostUpdate}))).Methods("PUT")
+	router.Handle(labelKeyExpr, ErrorHandler(permissionsHandler(ResponseHandler(hostController.DeleteLabel),
+		[]string{constants.HostUpdate}))).Methods("DELETE")
+
 	return router
 }
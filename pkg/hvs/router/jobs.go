@@ -0,0 +1,30 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package router
+
+import (
+	"fmt"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/postgres"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
+)
+
+// SetJobRoutes registers routes for the progress of asynchronous jobs
+func SetJobRoutes(router *mux.Router, store *postgres.DataStore) *mux.Router {
+	defaultLog.Trace("router/jobs:SetJobRoutes() Entering")
+	defer defaultLog.Trace("router/jobs:SetJobRoutes() Leaving")
+
+	jobController := controllers.NewJobController(postgres.NewJobStore(store))
+
+	jobIdExpr := fmt.Sprintf("%s/%s", "/jobs", validation.IdReg)
+
+	router.Handle(jobIdExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(jobController.Retrieve),
+		[]string{constants.JobRetrieve}))).Methods("GET")
+
+	return router
+}
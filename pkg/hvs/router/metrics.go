@@ -0,0 +1,28 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package router
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/metrics"
+	commMetrics "github.com/intel-secl/intel-secl/v3/pkg/lib/common/metrics"
+)
+
+// SetMetricsRoutes registers the route serving the verification pipeline metrics in the Prometheus text format, the
+// scrapers must present a token with the metrics:retrieve permission
+func SetMetricsRoutes(router *mux.Router) *mux.Router {
+	defaultLog.Trace("router/metrics:SetMetricsRoutes() Entering")
+	defer defaultLog.Trace("router/metrics:SetMetricsRoutes() Leaving")
+
+	metricsHandler := commMetrics.Handler(metrics.Registry)
+	router.Handle("/metrics", ErrorHandler(permissionsHandler(func(w http.ResponseWriter, r *http.Request) error {
+		metricsHandler.ServeHTTP(w, r)
+		return nil
+	}, []string{constants.MetricsRetrieve}))).Methods("GET")
+	return router
+}
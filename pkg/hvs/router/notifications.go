@@ -0,0 +1,50 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package router
+
+import (
+	"fmt"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/postgres"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
+)
+
+// SetNotificationRoutes registers routes for notification subscriptions, dead letters and the event stream
+func SetNotificationRoutes(router *mux.Router, store *postgres.DataStore, notificationManager domain.NotificationManager, dek []byte) *mux.Router {
+	defaultLog.Trace("router/notifications:SetNotificationRoutes() Entering")
+	defer defaultLog.Trace("router/notifications:SetNotificationRoutes() Leaving")
+
+	subscriptionStore := postgres.NewNotificationSubscriptionStore(store, dek)
+	deadLetterStore := postgres.NewNotificationDeadLetterStore(store)
+	notificationController := controllers.NewNotificationController(subscriptionStore, deadLetterStore, notificationManager)
+
+	subscriptionExpr := "/notification-subscriptions"
+	subscriptionIdExpr := fmt.Sprintf("%s/%s", subscriptionExpr, validation.IdReg)
+	deadLetterExpr := "/notification-dead-letters"
+	deadLetterIdExpr := fmt.Sprintf("%s/%s", deadLetterExpr, validation.IdReg)
+
+	router.Handle(subscriptionExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(notificationController.CreateSubscription),
+		[]string{constants.NotificationSubscriptionCreate}))).Methods("POST")
+	router.Handle(subscriptionExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(notificationController.SearchSubscriptions),
+		[]string{constants.NotificationSubscriptionSearch}))).Methods("GET")
+	router.Handle(subscriptionIdExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(notificationController.RetrieveSubscription),
+		[]string{constants.NotificationSubscriptionRetrieve}))).Methods("GET")
+	router.Handle(subscriptionIdExpr, ErrorHandler(permissionsHandler(ResponseHandler(notificationController.DeleteSubscription),
+		[]string{constants.NotificationSubscriptionDelete}))).Methods("DELETE")
+
+	router.Handle(deadLetterExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(notificationController.SearchDeadLetters),
+		[]string{constants.NotificationDeadLetterSearch}))).Methods("GET")
+	router.Handle(deadLetterIdExpr, ErrorHandler(permissionsHandler(ResponseHandler(notificationController.DeleteDeadLetter),
+		[]string{constants.NotificationDeadLetterDelete}))).Methods("DELETE")
+
+	router.Handle("/notifications/stream", ErrorHandler(permissionsHandler(EventStreamResponseHandler(notificationController.Stream),
+		[]string{constants.NotificationStream}))).Methods("GET")
+
+	return router
+}
@@ -0,0 +1,34 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package router
+
+import (
+	"fmt"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/postgres"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
+)
+
+// SetQueueRoutes registers routes for the host trust verification queue
+func SetQueueRoutes(router *mux.Router, store *postgres.DataStore, hostTrustManager domain.HostTrustManager) *mux.Router {
+	defaultLog.Trace("router/queue:SetQueueRoutes() Entering")
+	defer defaultLog.Trace("router/queue:SetQueueRoutes() Leaving")
+
+	queueController := controllers.NewQueueController(postgres.NewDBQueueStore(store), hostTrustManager)
+
+	queueExpr := "/queue"
+	queueIdExpr := fmt.Sprintf("%s/%s", queueExpr, validation.IdReg)
+
+	router.Handle(queueExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(queueController.Search),
+		[]string{constants.QueueSearch}))).Methods("GET")
+	router.Handle(queueIdExpr, ErrorHandler(permissionsHandler(ResponseHandler(queueController.Delete),
+		[]string{constants.QueueDelete}))).Methods("DELETE")
+
+	return router
+}
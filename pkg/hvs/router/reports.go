@@ -11,13 +11,14 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/postgres"
 	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
 )
 
 // SetReportRoutes registers routes for reports
-func SetReportRoutes(router *mux.Router, store *postgres.DataStore, hostTrustManager domain.HostTrustManager) *mux.Router {
+func SetReportRoutes(router *mux.Router, store *postgres.DataStore, certStore *models.CertificatesStore, hostTrustManager domain.HostTrustManager) *mux.Router {
 	defaultLog.Trace("router/reports:SetReportRoutes() Entering")
 	defer defaultLog.Trace("router/reports:SetReportRoutes() Leaving")
 
@@ -25,6 +26,7 @@ func SetReportRoutes(router *mux.Router, store *postgres.DataStore, hostTrustMan
 	hostStore := postgres.NewHostStore(store)
 	hostStatusStore := postgres.NewHostStatusStore(store)
 	reportController := controllers.NewReportController(reportStore, hostStore, hostStatusStore, hostTrustManager)
+	reportController.CertStore = certStore
 
 	reportIdExpr := fmt.Sprintf("%s%s", "/reports/", validation.IdReg)
 
@@ -40,6 +42,24 @@ func SetReportRoutes(router *mux.Router, store *postgres.DataStore, hostTrustMan
 		ErrorHandler(permissionsHandler(ResponseHandler(reportController.SearchSaml),
 			[]string{constants.ReportSearch}))).Methods("GET").Headers("Accept", consts.HTTPMediaTypeSaml)
 
+	router.Handle("/reports/export",
+		ErrorHandler(permissionsHandler(StreamResponseHandler(reportController.Export,
+			consts.HTTPMediaTypeCsv, consts.HTTPMediaTypeJsonLines),
+			[]string{constants.ReportSearch}))).Methods("GET")
+
+	router.Handle("/reports/compliance",
+		ErrorHandler(permissionsHandler(StreamResponseHandler(reportController.ComplianceDocument,
+			consts.HTTPMediaTypeHtml, consts.HTTPMediaTypePdf),
+			[]string{constants.ReportSearch}))).Methods("GET").HeadersRegexp("Accept", "^(text/html|application/pdf)$")
+
+	router.Handle("/reports/compliance",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(reportController.ComplianceReport),
+			[]string{constants.ReportSearch}))).Methods("GET")
+
+	router.Handle(reportIdExpr+"/diff",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(reportController.Diff),
+			[]string{constants.ReportRetrieve}))).Methods("GET")
+
 	router.Handle(reportIdExpr,
 		ErrorHandler(permissionsHandler(JsonResponseHandler(reportController.Retrieve),
 			[]string{constants.ReportRetrieve}))).Methods("GET")
@@ -14,13 +14,17 @@ import (
 
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
 
+	"github.com/golang/groupcache/lru"
 	"github.com/gorilla/mux"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/config"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/metrics"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/postgres"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
+	commMetrics "github.com/intel-secl/intel-secl/v3/pkg/lib/common/metrics"
 	cmw "github.com/intel-secl/intel-secl/v3/pkg/lib/common/middleware"
 	cos "github.com/intel-secl/intel-secl/v3/pkg/lib/common/os"
 	"github.com/pkg/errors"
@@ -34,7 +38,7 @@ type Router struct {
 }
 
 // InitRoutes registers all routes for the application.
-func InitRoutes(cfg *config.Configuration, dataStore *postgres.DataStore, fgs *postgres.FlavorGroupStore, certStore *models.CertificatesStore, hostTrustManager domain.HostTrustManager, hostControllerConfig domain.HostControllerConfig) (*mux.Router, error) {
+func InitRoutes(cfg *config.Configuration, dataStore *postgres.DataStore, fgs *postgres.FlavorGroupStore, certStore *models.CertificatesStore, hostTrustManager domain.HostTrustManager, hostControllerConfig domain.HostControllerConfig, auditLogWriter domain.AuditLogWriter, notificationManager domain.NotificationManager, hostTrustCache *lru.Cache, jobRunner domain.JobRunner) (*mux.Router, error) {
 	defaultLog.Trace("router/router:InitRoutes() Entering")
 	defer defaultLog.Trace("router/router:InitRoutes() Leaving")
 
@@ -43,18 +47,21 @@ func InitRoutes(cfg *config.Configuration, dataStore *postgres.DataStore, fgs *p
 
 	// ISECL-8715 - Prevent potential open redirects to external URLs
 	router.SkipClean(true)
-	err := defineSubRoutes(router, constants.OldServiceName, cfg, dataStore, fgs, certStore, hostTrustManager, hostControllerConfig)
+	router.Use(commMetrics.NewHTTPMiddleware(metrics.Registry, "hvs"))
+	// the CRL of the privacy CA is shared by the routes of both service names, so that a revocation invalidates it
+	aikCrlCache := utils.NewAikCrlCache()
+	err := defineSubRoutes(router, constants.OldServiceName, cfg, dataStore, fgs, certStore, hostTrustManager, hostControllerConfig, auditLogWriter, notificationManager, hostTrustCache, aikCrlCache, jobRunner)
 	if err != nil {
 		return nil, errors.Wrap(err, "Could not define sub routes")
 	}
-	err = defineSubRoutes(router, strings.ToLower(constants.ServiceName), cfg, dataStore, fgs, certStore, hostTrustManager, hostControllerConfig)
+	err = defineSubRoutes(router, strings.ToLower(constants.ServiceName), cfg, dataStore, fgs, certStore, hostTrustManager, hostControllerConfig, auditLogWriter, notificationManager, hostTrustCache, aikCrlCache, jobRunner)
 	if err != nil {
 		return nil, errors.Wrap(err, "Could not define sub routes")
 	}
 	return router, nil
 }
 
-func defineSubRoutes(router *mux.Router, service string, cfg *config.Configuration, dataStore *postgres.DataStore, fgs *postgres.FlavorGroupStore, certStore *models.CertificatesStore, hostTrustManager domain.HostTrustManager, hostControllerConfig domain.HostControllerConfig) error {
+func defineSubRoutes(router *mux.Router, service string, cfg *config.Configuration, dataStore *postgres.DataStore, fgs *postgres.FlavorGroupStore, certStore *models.CertificatesStore, hostTrustManager domain.HostTrustManager, hostControllerConfig domain.HostControllerConfig, auditLogWriter domain.AuditLogWriter, notificationManager domain.NotificationManager, hostTrustCache *lru.Cache, aikCrlCache *utils.AikCrlCache, jobRunner domain.JobRunner) error {
 	defaultLog.Trace("router/router:defineSubRoutes() Entering")
 	defer defaultLog.Trace("router/router:defineSubRoutes() Leaving")
 
@@ -62,6 +69,7 @@ func defineSubRoutes(router *mux.Router, service string, cfg *config.Configurati
 	subRouter := router.PathPrefix(serviceApi).Subrouter()
 	subRouter = SetVersionRoutes(subRouter)
 	subRouter = SetCaCertificatesRoutes(subRouter, certStore)
+	subRouter = SetAikCrlRoutes(subRouter, dataStore, certStore, aikCrlCache)
 
 	subRouter = router.PathPrefix(serviceApi).Subrouter()
 	cfgRouter := Router{cfg: cfg}
@@ -72,21 +80,27 @@ func defineSubRoutes(router *mux.Router, service string, cfg *config.Configurati
 	subRouter.Use(cmw.NewTokenAuth(constants.TrustedJWTSigningCertsDir,
 		constants.TrustedRootCACertsDir, cfgRouter.fnGetJwtCerts,
 		cacheTime))
-	subRouter = SetFlavorGroupRoutes(subRouter, dataStore, fgs, hostTrustManager)
+	subRouter = SetFlavorGroupRoutes(subRouter, dataStore, fgs, certStore, hostTrustManager)
 	subRouter = SetFlavorTemplateRoutes(subRouter, dataStore, fgs, certStore, hostTrustManager, hostControllerConfig)
-	subRouter = SetFlavorRoutes(subRouter, dataStore, fgs, certStore, hostTrustManager, hostControllerConfig)
+	subRouter = SetFlavorRoutes(subRouter, dataStore, fgs, certStore, hostTrustManager, hostControllerConfig, auditLogWriter)
 	subRouter = SetTpmEndorsementRoutes(subRouter, dataStore)
+	subRouter = SetEndorsementAuthorityRoutes(subRouter, dataStore)
+	subRouter = SetAikCertificateRoutes(subRouter, dataStore, certStore, aikCrlCache, hostTrustManager, hostTrustCache)
 	subRouter = SetCertifyAiksRoutes(subRouter, dataStore, certStore, cfg.AikCertValidity)
 	subRouter = SetHostStatusRoutes(subRouter, dataStore)
 	subRouter = SetCertifyHostKeysRoutes(subRouter, certStore)
-	subRouter = SetHostRoutes(subRouter, dataStore, hostTrustManager, hostControllerConfig)
-	subRouter = SetReportRoutes(subRouter, dataStore, hostTrustManager)
+	subRouter = SetHostRoutes(subRouter, dataStore, hostTrustManager, hostControllerConfig, jobRunner)
+	subRouter = SetJobRoutes(subRouter, dataStore)
+	subRouter = SetQueueRoutes(subRouter, dataStore, hostTrustManager)
+	subRouter = SetReportRoutes(subRouter, dataStore, certStore, hostTrustManager)
 	subRouter = SetCreateCaCertificatesRoutes(subRouter, certStore)
 	subRouter = SetTagCertificateRoutes(subRouter, cfg, fgs, certStore, hostTrustManager, dataStore)
 	subRouter = SetESXiClusterRoutes(subRouter, dataStore, hostTrustManager, hostControllerConfig)
 	subRouter = SetDeploySoftwareManifestRoute(subRouter, dataStore, hostTrustManager, hostControllerConfig)
 	subRouter = SetManifestsRoute(subRouter, dataStore)
 	subRouter = SetFlavorFromAppManifestRoute(subRouter, dataStore, fgs, certStore, hostTrustManager, hostControllerConfig)
+	subRouter = SetNotificationRoutes(subRouter, dataStore, notificationManager, hostControllerConfig.DataEncryptionKey)
+	subRouter = SetMetricsRoutes(subRouter)
 	return nil
 }
 
@@ -6,12 +6,16 @@ package hvs
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/rsa"
 	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/base64"
 	"fmt"
 	"github.com/golang/groupcache/lru"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/vcss"
+	"math/big"
 	"net/http"
 	"os"
 	"os/signal"
@@ -22,13 +26,16 @@ import (
 
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/config"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/metrics"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/postgres"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/auditlog"
 	hostfetcher "github.com/intel-secl/intel-secl/v3/pkg/hvs/services/host-fetcher"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hrrs"
-	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/jobs"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/notification"
 	hostconnector "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/simulator"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/saml"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/verifier"
 
@@ -73,9 +80,32 @@ func (a *App) startServer() error {
 	// Load Certificates
 	certStore := utils.LoadCertificates(a.loadCertPathStore())
 
+	// The simulated hosts have their AIK certificates issued by a dedicated CA, never by the privacy CA
+	var simulatorCa *models.CertificateStore
+	if c.Simulator.Enabled {
+		simulatorCa, err = createSimulatorCa()
+		if err != nil {
+			return errors.Wrap(err, "An error occurred while creating the simulator CA")
+		}
+	}
+
+	// Initialize the host connector factory shared by the host controllers and the host trust manager
+	hcFactory, err := initHostConnectorFactory(c, certStore, simulatorCa)
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while initializing host connector factory")
+	}
+
+	// Initialize notification service
+	notificationService, err := initNotificationService(c, dataStore, certStore)
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while initializing notification service")
+	}
+
 	// Initialize Host trust manager
 	fgs := postgres.NewFlavorGroupStore(dataStore)
-	hostTrustManager := initHostTrustManager(c, dataStore, fgs, certStore, alw)
+	hostQuoteTrustCache := lru.New(c.FVS.HostTrustCacheThreshold)
+	metrics.HostTrustCacheCapacity.Set(float64(c.FVS.HostTrustCacheThreshold))
+	hostTrustManager := initHostTrustManager(c, dataStore, fgs, certStore, simulatorCa, hcFactory, alw, notificationService, hostQuoteTrustCache)
 	go hostTrustManager.ProcessQueue()
 
 	// create an instance of the HRRS and start it...
@@ -92,7 +122,7 @@ func (a *App) startServer() error {
 	}
 
 	// Initialize Host controller config
-	hostControllerConfig := initHostControllerConfig(c, certStore)
+	hostControllerConfig := initHostControllerConfig(c, hcFactory)
 
 	//Create an instance of VCSS and start the service
 	vcenterClusterSyncer, err := vcss.NewVCenterClusterSyncer(c.VCSS, hostControllerConfig, dataStore, hostTrustManager)
@@ -105,8 +135,14 @@ func (a *App) startServer() error {
 		return errors.Wrap(err, "An error occurred while initializing vCenter Cluster Syncer")
 	}
 
+	// Initialize the runner of the asynchronous jobs, failing the jobs interrupted by the last shutdown
+	jobRunner, err := jobs.NewJobRunner(postgres.NewJobStore(dataStore), constants.DefaultJobRunnerWorkers, constants.DefaultJobRunnerQueueSize)
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while initializing job runner")
+	}
+
 	// Initialize routes
-	routes, err := router.InitRoutes(c, dataStore, fgs, certStore, hostTrustManager, hostControllerConfig)
+	routes, err := router.InitRoutes(c, dataStore, fgs, certStore, hostTrustManager, hostControllerConfig, alw, notificationService, hostQuoteTrustCache, jobRunner)
 	if err != nil {
 		return errors.Wrap(err, "An error occurred while initializing routes")
 	}
@@ -161,16 +197,88 @@ func (a *App) startServer() error {
 		defaultLog.WithError(err).Info("Failed to gracefully shutdown webserver")
 		return err
 	}
+
+	if err := jobRunner.Stop(constants.JobRunnerStopTimeout); err != nil {
+		defaultLog.WithError(err).Info("Failed to gracefully stop job runner")
+	}
+
+	if err := notificationService.Shutdown(); err != nil {
+		defaultLog.WithError(err).Info("Failed to gracefully shutdown notification service")
+	}
 	secLog.Info(commLogMsg.ServiceStop)
 	return nil
 }
 
-func initHostControllerConfig(cfg *config.Configuration, certStore *models.CertificatesStore) domain.HostControllerConfig {
-	defaultLog.Trace("server:initHostControllerConfig() Entering")
-	defer defaultLog.Trace("server:initHostControllerConfig() Leaving")
+// initHostConnectorFactory creates the factory of the host connectors. The simulated hosts are enabled by the
+// configuration, their AIK certificates are issued by the privacy CA so that they can be trusted.
+// createSimulatorCa creates the CA issuing the AIK certificates of the simulated hosts. It is kept in memory only, so
+// that the relying parties trusting the privacy CA, such as KBS, never trust a simulated host.
+func createSimulatorCa() (*models.CertificateStore, error) {
+	defaultLog.Trace("server:createSimulatorCa() Entering")
+	defer defaultLog.Trace("server:createSimulatorCa() Leaving")
+
+	caKey, err := rsa.GenerateKey(rand.Reader, 3072)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error generating simulator CA key")
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, errors.Wrap(err, "Error generating simulator CA serial number")
+	}
+	notBefore := time.Now()
+	template := x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: constants.DefaultSimulatorCaCommonName},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(constants.DefaultSimulatorCaValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caCertDer, err := x509.CreateCertificate(rand.Reader, &template, &template, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating simulator CA certificate")
+	}
+	caCert, err := x509.ParseCertificate(caCertDer)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing simulator CA certificate")
+	}
+	return &models.CertificateStore{Key: caKey, Certificates: []x509.Certificate{*caCert}}, nil
+}
+
+func initHostConnectorFactory(cfg *config.Configuration, certStore *models.CertificatesStore, simulatorCa *models.CertificateStore) (*hostconnector.HostConnectorFactory, error) {
+	defaultLog.Trace("server:initHostConnectorFactory() Entering")
+	defer defaultLog.Trace("server:initHostConnectorFactory() Leaving")
 
 	rootCAs := (*certStore)[models.CaCertTypesRootCa.String()]
-	hcProvider := hostconnector.NewHostConnectorFactory(cfg.AASApiUrl, rootCAs.Certificates)
+	hcFactory := hostconnector.NewHostConnectorFactory(cfg.AASApiUrl, rootCAs.Certificates)
+	if !cfg.Simulator.Enabled {
+		return hcFactory, nil
+	}
+
+	profiles := simulator.DefaultProfiles()
+	if _, err := os.Stat(cfg.Simulator.ProfileDir); err == nil {
+		customProfiles, err := simulator.LoadProfiles(cfg.Simulator.ProfileDir)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error loading simulated host profiles")
+		}
+		profiles = append(profiles, customProfiles...)
+	}
+	if simulatorCa == nil || simulatorCa.Key == nil || len(simulatorCa.Certificates) == 0 {
+		return nil, errors.New("The simulator CA is required for the simulated hosts")
+	}
+	sim, err := simulator.NewSimulator(profiles, &simulatorCa.Certificates[0], simulatorCa.Key)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating host simulator")
+	}
+	hcFactory.EnableSimulator(sim)
+	defaultLog.Warnf("server:initHostConnectorFactory() Simulated hosts are enabled with %d profiles", len(profiles))
+	return hcFactory, nil
+}
+
+func initHostControllerConfig(cfg *config.Configuration, hcProvider hostconnector.HostConnectorProvider) domain.HostControllerConfig {
+	defaultLog.Trace("server:initHostControllerConfig() Entering")
+	defer defaultLog.Trace("server:initHostControllerConfig() Leaving")
 
 	hcc := domain.HostControllerConfig{
 		HostConnectorProvider: hcProvider,
@@ -193,7 +301,7 @@ func getDecodedDek(cfg *config.Configuration) []byte {
 	return dek
 }
 
-func initHostTrustManager(cfg *config.Configuration, dataStore *postgres.DataStore, fgs *postgres.FlavorGroupStore, certStore *models.CertificatesStore, alw domain.AuditLogWriter) domain.HostTrustManager {
+func initHostTrustManager(cfg *config.Configuration, dataStore *postgres.DataStore, fgs *postgres.FlavorGroupStore, certStore *models.CertificatesStore, simulatorCa *models.CertificateStore, htcFactory hostconnector.HostConnectorProvider, alw domain.AuditLogWriter, nm domain.NotificationManager, hostQuoteTrustCache *lru.Cache) domain.HostTrustManager {
 	defaultLog.Trace("server:InitHostTrustManager() Entering")
 	defer defaultLog.Trace("server:InitHostTrustManager() Leaving")
 
@@ -206,25 +314,21 @@ func initHostTrustManager(cfg *config.Configuration, dataStore *postgres.DataSto
 	hss.AuditLogWriter = alw
 	rs := postgres.NewReportStore(dataStore)
 	rs.AuditLogWriter = alw
+	acs := postgres.NewAikCertificateStore(dataStore)
 
 	//Load certificates
-	rootCAs := (*certStore)[models.CaCertTypesRootCa.String()]
-	tagCAs := (*certStore)[models.CaCertTypesTagCa.String()]
 	samlCert := (*certStore)[models.CertTypesSaml.String()]
-	privacyCAs := (*certStore)[models.CaCertTypesPrivacyCa.String()]
-	signingCerts := (*certStore)[models.CertTypesFlavorSigning.String()]
-	rootCApool := crypt.GetCertPool(rootCAs.Certificates)
-	for _, val := range signingCerts.Certificates[1:] {
-		rootCApool.AddCert(&val) //Add intermediate CA
+	verifierCerts, err := utils.GetVerifierCertificates(certStore)
+	if err != nil {
+		defaultLog.WithError(err).Error("Error loading verifier certificates")
+		verifierCerts = &verifier.VerifierCertificates{}
 	}
-
-	verifierCerts := verifier.VerifierCertificates{
-		PrivacyCACertificates:    crypt.GetCertPool(privacyCAs.Certificates),
-		AssetTagCACertificates:   crypt.GetCertPool(tagCAs.Certificates),
-		FlavorSigningCertificate: &signingCerts.Certificates[0],
-		FlavorCACertificates:     rootCApool,
+	if simulatorCa != nil && verifierCerts.PrivacyCACertificates != nil {
+		verifierCerts.PrivacyCACertificates.AddCert(&simulatorCa.Certificates[0])
 	}
-	libVerifier, _ := verifier.NewVerifier(verifierCerts)
+	verifierCerts.AikCertificateStatus = &utils.AikCertificateInventory{Store: acs}
+	verifierCerts.AikCertificateExpiryWarning = time.Duration(cfg.AikCertExpiryWarningDays) * 24 * time.Hour
+	libVerifier, _ := verifier.NewVerifier(*verifierCerts)
 	samlKey := samlCert.Key.(*rsa.PrivateKey)
 	samlIssuerConfig := saml.IssuerConfiguration{
 		IssuerName:        cfg.SAML.Issuer,
@@ -234,7 +338,6 @@ func initHostTrustManager(cfg *config.Configuration, dataStore *postgres.DataSto
 		Certificate:       &samlCert.Certificates[0],
 	}
 
-	hostQuoteTrustCache := lru.New(cfg.FVS.HostTrustCacheThreshold)
 	htv := domain.HostTrustVerifierConfig{
 		FlavorStore:                     fs,
 		FlavorGroupStore:                fgs,
@@ -245,11 +348,11 @@ func initHostTrustManager(cfg *config.Configuration, dataStore *postgres.DataSto
 		SamlIssuerConfig:                samlIssuerConfig,
 		SkipFlavorSignatureVerification: cfg.FVS.SkipFlavorSignatureVerification,
 		HostTrustCache:                  hostQuoteTrustCache,
+		NotificationManager:             nm,
+		AikCertificateStore:             acs,
 	}
 
 	// Initialize Host Fetcher service
-	htcFactory := hostconnector.NewHostConnectorFactory(cfg.AASApiUrl, rootCAs.Certificates)
-
 	c := domain.HostDataFetcherConfig{
 		HostConnectorProvider: htcFactory,
 		HostConnectionConfig: domain.HostConnectionConfig{
@@ -257,10 +360,11 @@ func initHostTrustManager(cfg *config.Configuration, dataStore *postgres.DataSto
 			ServiceUsername: cfg.HVS.Username,
 			ServicePassword: cfg.HVS.Password,
 		},
-		RetryTimeMinutes: 5,
-		HostStatusStore:  hss,
-		HostStore:        hs,
-		HostTrustCache:   hostQuoteTrustCache,
+		RetryTimeMinutes:    5,
+		HostStatusStore:     hss,
+		HostStore:           hs,
+		HostTrustCache:      hostQuoteTrustCache,
+		NotificationManager: nm,
 	}
 	_, hf, err := hostfetcher.NewService(c, cfg.FVS.NumberOfDataFetchers)
 	if err != nil {
@@ -279,6 +383,26 @@ func initHostTrustManager(cfg *config.Configuration, dataStore *postgres.DataSto
 	return htm
 }
 
+// initNotificationService creates the service delivering trust change events. Webhook endpoints are
+// verified against the system trust store and the configured root CAs
+func initNotificationService(cfg *config.Configuration, dataStore *postgres.DataStore, certStore *models.CertificatesStore) (*notification.Service, error) {
+	defaultLog.Trace("server:initNotificationService() Entering")
+	defer defaultLog.Trace("server:initNotificationService() Leaving")
+
+	rootCAPool, err := x509.SystemCertPool()
+	if err != nil || rootCAPool == nil {
+		rootCAPool = x509.NewCertPool()
+	}
+	rootCAs := (*certStore)[models.CaCertTypesRootCa.String()]
+	for i := range rootCAs.Certificates {
+		rootCAPool.AddCert(&rootCAs.Certificates[i])
+	}
+
+	nss := postgres.NewNotificationSubscriptionStore(dataStore, getDecodedDek(cfg))
+	nds := postgres.NewNotificationDeadLetterStore(dataStore)
+	return notification.NewService(cfg.Notification, nss, nds, rootCAPool)
+}
+
 func (a *App) loadCertPathStore() *models.CertificatesPathStore {
 	// constants are used somewhere else in the repo
 	// change it into the configured paths after fixing all of them
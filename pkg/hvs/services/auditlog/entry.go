@@ -53,6 +53,14 @@ func (alp *auditLogDB) CreateEntry(action string, values ...interface{}) (*model
 		}
 		cols = append(cols, report2Cols(base, diff)...)
 		return entryHelper(base.ID, "report", action, cols), nil
+	case *hvs.FlavorRevision:
+		diff := base
+		if action == "update" {
+			if diff, ok = values[1].(*hvs.FlavorRevision); !ok {
+				return nil, errors.New("invalid input for audit log: incoherent input")
+			}
+		}
+		return entryHelper(base.FlavorID, "flavor", action, flavorRevision2Cols(base, diff)), nil
 	}
 }
 
@@ -102,6 +110,41 @@ func report2Cols(old, current *models.HVSReport) []models.AuditColumnData {
 	}
 }
 
+func flavorRevision2Cols(old, current *hvs.FlavorRevision) []models.AuditColumnData {
+	return []models.AuditColumnData{
+		{
+			Name:      "id",
+			Value:     current.FlavorID,
+			IsUpdated: old.FlavorID != current.FlavorID,
+		},
+		{
+			Name:      "revision",
+			Value:     current.Revision,
+			IsUpdated: old.Revision != current.Revision,
+		},
+		{
+			Name:      "content",
+			Value:     current.SignedFlavor.Flavor,
+			IsUpdated: !reflect.DeepEqual(old.SignedFlavor.Flavor, current.SignedFlavor.Flavor),
+		},
+		{
+			Name:      "signature",
+			Value:     current.SignedFlavor.Signature,
+			IsUpdated: old.SignedFlavor.Signature != current.SignedFlavor.Signature,
+		},
+		{
+			Name:      "created_by",
+			Value:     current.CreatedBy,
+			IsUpdated: old.CreatedBy != current.CreatedBy,
+		},
+		{
+			Name:      "comment",
+			Value:     current.Comment,
+			IsUpdated: old.Comment != current.Comment,
+		},
+	}
+}
+
 func hostStatus2Cols(old, current *hvs.HostStatus) []models.AuditColumnData {
 	return []models.AuditColumnData{
 		{
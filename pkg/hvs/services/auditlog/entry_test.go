@@ -93,3 +93,36 @@ func TestStructDiff(t *testing.T) {
 	t.Log(report2Cols(rx, ry))
 	t.Log(hostStatus2Cols(hssx, hssy))
 }
+
+func TestFlavorRevisionEntry(t *testing.T) {
+	alw := &auditLogDB{}
+	flavorId := uuid.New()
+	old := &hvs.FlavorRevision{
+		FlavorID:     flavorId,
+		Revision:     1,
+		SignedFlavor: hvs.SignedFlavor{Signature: "c2lnbmF0dXJlMQ=="},
+	}
+	current := &hvs.FlavorRevision{
+		FlavorID:     flavorId,
+		Revision:     2,
+		SignedFlavor: hvs.SignedFlavor{Signature: "c2lnbmF0dXJlMg=="},
+		CreatedBy:    "admin",
+	}
+	entry, err := alw.CreateEntry("update", old, current)
+	assert.NoError(t, err)
+	assert.Equal(t, flavorId, entry.EntityID)
+	assert.Equal(t, "flavor", entry.EntityType)
+
+	updated := map[string]bool{}
+	for _, col := range entry.Data.Columns {
+		updated[col.Name] = col.IsUpdated
+	}
+	assert.True(t, updated["revision"])
+	assert.True(t, updated["signature"])
+	assert.True(t, updated["created_by"])
+	assert.False(t, updated["id"])
+	assert.False(t, updated["comment"])
+
+	_, err = alw.CreateEntry("update", old)
+	assert.Error(t, err)
+}
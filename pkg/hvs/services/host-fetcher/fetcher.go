@@ -9,7 +9,9 @@ import (
 	"context"
 	"github.com/golang/groupcache/lru"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models/taskstage"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/metrics"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 
@@ -20,10 +22,12 @@ import (
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/notification"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/chnlworkq"
 	commLog "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
 	hc "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/util"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -53,6 +57,10 @@ type Service struct {
 	rqstChan chan interface{}
 	// work items (their id) is pulled out of a queue and fed to the workers
 	workChan chan interface{}
+	// low priority lane of the above queue. The workers only take work from it when the
+	// high priority lane is empty
+	lowRqstChan chan interface{}
+	lowWorkChan chan interface{}
 
 	retryRqstChan chan interface{}
 	retryWorkChan chan interface{}
@@ -74,6 +82,8 @@ type Service struct {
 	hss               domain.HostStatusStore
 	hs                domain.HostStore
 	hostTrustCache    *lru.Cache
+	// nm is notified when the state of a host changes
+	nm domain.NotificationManager
 }
 
 func NewService(cfg domain.HostDataFetcherConfig, workers int) (*Service, domain.HostDataFetcher, error) {
@@ -90,6 +100,7 @@ func NewService(cfg domain.HostDataFetcherConfig, workers int) (*Service, domain
 		hcCfg:             cfg.HostConnectionConfig,
 		hs:                cfg.HostStore,
 		hostTrustCache:    cfg.HostTrustCache,
+		nm:                cfg.NotificationManager,
 	}
 	if svc.hss == nil {
 		return nil, nil, errors.New("host status store cannot be empty")
@@ -103,12 +114,19 @@ func NewService(cfg domain.HostDataFetcherConfig, workers int) (*Service, domain
 
 	svc.Fetcher = svc
 	var err error
-	if svc.rqstChan, svc.workChan, err = chnlworkq.New(workers, workers, svc.addWorkToMap, nil, svc.quit, &svc.wg); err != nil {
+	procReq, procWork := metrics.QueueCallbacks(metrics.QueueHostFetch, svc.addWorkToMap)
+	if svc.rqstChan, svc.workChan, err = chnlworkq.New(workers, workers, procReq, procWork, svc.quit, &svc.wg); err != nil {
 		return nil, nil, errors.New("hostfetcher:NewService:error starting work queue")
 	}
-	if svc.retryRqstChan, svc.retryWorkChan, err = chnlworkq.New(workers, workers, nil, nil, svc.quit, &svc.wg); err != nil {
+	procReq, procWork = metrics.QueueCallbacks(metrics.QueueHostFetchLow, svc.addWorkToMap)
+	if svc.lowRqstChan, svc.lowWorkChan, err = chnlworkq.New(workers, workers, procReq, procWork, svc.quit, &svc.wg); err != nil {
+		return nil, nil, errors.New("hostfetcher:NewService:error starting work queue")
+	}
+	procReq, procWork = metrics.QueueCallbacks(metrics.QueueHostFetchRetry, nil)
+	if svc.retryRqstChan, svc.retryWorkChan, err = chnlworkq.New(workers, workers, procReq, procWork, svc.quit, &svc.wg); err != nil {
 		return nil, nil, errors.New("hostfetcher:NewService:error starting retry queue")
 	}
+	metrics.HostFetchWorkers.Set(float64(workers))
 
 	// start workers.. individual workers are spawned as go routines
 	svc.startWorkers(workers)
@@ -204,43 +222,57 @@ func (svc *Service) doWork() {
 	// receive id of queued work over the channel.
 	// Fetch work context from the map.
 	for {
+		// the low priority lane is only read when there is no work in the high priority lane
+		var id interface{}
 		select {
 		case <-svc.quit:
 			// we have received a quit. Don't process anymore items - just return
 			return
-		case id := <-svc.workChan:
-			hId, ok := id.(uuid.UUID)
-			var connUrl string
-			if !ok {
-				defaultLog.Error("hostfetcher:doWork:expecting uuid from channel - but got different type")
-			}
-			// iterate through work requests for this host. Usually, there will only be a single element in the
-			// work list.
-			svc.wmLock.Lock()
-			frs := svc.workMap[hId]
-			connUrl = frs[0].host.ConnectionString
-			preferHashMatch := frs[0].preferHashMatch
-			getData := false
-			for i, req := range frs {
-				select {
-				// remove the requests that have already been cancelled.
-				case <-req.ctx.Done():
-					frs = append(frs[:i], frs[i+1:]...)
-					continue
-				default:
-					getData = true
-					taskstage.StoreInContext(req.ctx, taskstage.GetHostDataStarted)
-				}
+		case id = <-svc.workChan:
+		default:
+			select {
+			case <-svc.quit:
+				return
+			case id = <-svc.workChan:
+			case id = <-svc.lowWorkChan:
 			}
-			svc.workMap[hId] = frs
+		}
+		hId, ok := id.(uuid.UUID)
+		var connUrl string
+		if !ok {
+			defaultLog.Error("hostfetcher:doWork:expecting uuid from channel - but got different type")
+		}
+		// iterate through work requests for this host. Usually, there will only be a single element in the
+		// work list.
+		svc.wmLock.Lock()
+		frs := svc.workMap[hId]
+		// the requests of the host could have been served when its id was pulled from the other lane
+		if len(frs) == 0 {
 			svc.wmLock.Unlock()
-
-			if getData {
-				svc.FetchDataAndRespond(hId, connUrl, preferHashMatch)
-			} else {
-				defaultLog.Info("Fetch data for ", hId, "cancelled")
+			continue
+		}
+		connUrl = frs[0].host.ConnectionString
+		preferHashMatch := frs[0].preferHashMatch
+		getData := false
+		for i, req := range frs {
+			select {
+			// remove the requests that have already been cancelled.
+			case <-req.ctx.Done():
+				frs = append(frs[:i], frs[i+1:]...)
+				continue
+			default:
+				getData = true
+				taskstage.StoreInContext(req.ctx, taskstage.GetHostDataStarted)
 			}
 		}
+		svc.workMap[hId] = frs
+		svc.wmLock.Unlock()
+
+		if getData {
+			svc.FetchDataAndRespond(hId, connUrl, preferHashMatch)
+		} else {
+			defaultLog.Info("Fetch data for ", hId, "cancelled")
+		}
 	}
 }
 
@@ -258,7 +290,7 @@ func (svc *Service) Retrieve(host hvs.Host) (*types.HostManifest, error) {
 		hostState := utils.DetermineHostState(err)
 		defaultLog.Warnf("hostfetcher/Service:Retrieve() Could not connect to host : %s", hostState.String())
 		hostStatus.HostStatusInformation.HostState = hostState
-		if err := svc.hss.Persist(hostStatus); err != nil {
+		if err := svc.persistHostStatus(hostStatus); err != nil {
 			defaultLog.Error("hostfetcher/Service:Retrieve() could not update host status to store")
 		}
 		return nil, err
@@ -268,7 +300,7 @@ func (svc *Service) Retrieve(host hvs.Host) (*types.HostManifest, error) {
 	hostStatus.HostStatusInformation.LastTimeConnected = time.Now()
 	hostStatus.HostManifest = *hostData
 	svc.updateMissingHostDetails(host.Id, hostData)
-	if err := svc.hss.Persist(hostStatus); err != nil {
+	if err := svc.persistHostStatus(hostStatus); err != nil {
 		defaultLog.Error("hostfetcher/Service:Retrieve() could not update host status and manifest to store")
 	}
 
@@ -283,8 +315,12 @@ func (svc *Service) RetrieveAsync(ctx context.Context, host hvs.Host, preferHash
 		return errors.New("Host Fetcher has been shut down - cannot accept any more requests")
 	}
 	fr := &fetchRequest{ctx, host, rcvrs, preferHashMatch}
-	// queue up the request
-	svc.rqstChan <- fr
+	// queue up the request in the lane of its priority
+	if models.QueuePriorityFromContext(ctx) == models.QueuePriorityLow {
+		svc.lowRqstChan <- fr
+	} else {
+		svc.rqstChan <- fr
+	}
 	return nil
 }
 
@@ -319,6 +355,7 @@ func (svc *Service) FetchDataAndRespond(hId uuid.UUID, connUrl string, preferHas
 			return
 		}
 		//TODO - presume that error is due to connection failure and we need to retry operation
+		metrics.HostFetchRetries.Inc()
 		svc.retryRqstChan <- retryRequest{
 			retryTime: time.Now().Add(time.Duration(svc.retryIntervalMins) * time.Minute),
 			hostId:    hId,
@@ -326,7 +363,7 @@ func (svc *Service) FetchDataAndRespond(hId uuid.UUID, connUrl string, preferHas
 		hostState := utils.DetermineHostState(err)
 		defaultLog.Warnf("hostfetcher/Service:FetchDataAndRespond() Could not connect to host : %s", hostState.String())
 
-		err = svc.hss.Persist(&hvs.HostStatus{
+		err = svc.persistHostStatus(&hvs.HostStatus{
 			HostID: hId,
 			HostStatusInformation: hvs.HostStatusInformation{
 				HostState: hostState,
@@ -345,7 +382,7 @@ func (svc *Service) FetchDataAndRespond(hId uuid.UUID, connUrl string, preferHas
 	delete(svc.workMap, hId)
 	svc.wmLock.Unlock()
 	svc.updateMissingHostDetails(hId, hostData)
-	err = svc.hss.Persist(&hvs.HostStatus{
+	err = svc.persistHostStatus(&hvs.HostStatus{
 		HostID: hId,
 		HostStatusInformation: hvs.HostStatusInformation{
 			HostState:         hvs.HostStateConnected,
@@ -407,7 +444,16 @@ func (svc *Service) GetHostData(connUrl string, pcrList []int) (*types.HostManif
 		return nil, err
 	}
 
+	vendor := strings.ToLower(util.GetVendorPrefix(connectionString).String())
+	metrics.HostFetchesInFlight.Inc()
+	start := time.Now()
 	data, err := connector.GetHostManifest(pcrList)
+	metrics.HostFetchesInFlight.Dec()
+	result := metrics.ResultSuccess
+	if err != nil {
+		result = metrics.ResultError
+	}
+	metrics.HostFetchDuration.WithLabelValues(vendor, result).Observe(time.Since(start).Seconds())
 	return &data, err
 }
 
@@ -440,3 +486,37 @@ func (svc *Service) updateMissingHostDetails(hostId uuid.UUID, manifest *types.H
 		}
 	}
 }
+
+// persistHostStatus stores the status of the host, and publishes a host state change event when the state of the host
+// differs from the previous one
+func (svc *Service) persistHostStatus(hostStatus *hvs.HostStatus) error {
+	var previous *hvs.HostStatusInformation
+	// the state change event is only published when the previous status is known
+	publishStateChange := svc.nm != nil
+	if publishStateChange {
+		hostStatuses, err := svc.hss.Search(&models.HostStatusFilterCriteria{
+			HostId:        hostStatus.HostID,
+			LatestPerHost: true,
+			Limit:         1,
+		})
+		if err != nil {
+			defaultLog.WithError(err).Errorf("hostfetcher/Service:persistHostStatus() Could not retrieve previous status of host %s,"+
+				" the host state change event will not be published", hostStatus.HostID)
+			publishStateChange = false
+		} else if len(hostStatuses) > 0 {
+			previous = &hostStatuses[0].HostStatusInformation
+		}
+	}
+
+	if err := svc.hss.Persist(hostStatus); err != nil {
+		return err
+	}
+
+	if publishStateChange {
+		if event := notification.HostStateChangeEvent(hostStatus.HostID, previous, hostStatus.HostStatusInformation,
+			hostStatus.HostManifest.HostInfo.HostName); event != nil {
+			svc.nm.Publish(event)
+		}
+	}
+	return nil
+}
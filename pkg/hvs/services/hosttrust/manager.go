@@ -11,14 +11,18 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models/taskstage"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/metrics"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/chnlworkq"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
 	commLog "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 )
 
 var defaultLog = commLog.GetDefaultLogger()
@@ -31,6 +35,7 @@ type verifyTrustJob struct {
 	storPersistId   uuid.UUID
 	getNewHostData  bool
 	preferHashMatch bool
+	priority        models.QueuePriority
 }
 
 type newHostFetch struct {
@@ -51,6 +56,12 @@ type Service struct {
 	rqstChan chan interface{}
 	// work items (their id) is pulled out of a queue and fed to the workers
 	workChan chan interface{}
+	// low priority lanes of the above queues. The workers only take work from them when the
+	// high priority lanes are empty
+	lowHfRqstChan chan interface{}
+	lowHfWorkChan chan interface{}
+	lowRqstChan   chan interface{}
+	lowWorkChan   chan interface{}
 	// map that holds all the hosts that needs trust verification.
 	hosts map[uuid.UUID]*verifyTrustJob
 	// mutex for map
@@ -81,12 +92,23 @@ func NewService(cfg domain.HostTrustMgrConfig) (*Service, domain.HostTrustManage
 	}
 	var err error
 	nw := cfg.Verifiers
-	if svc.rqstChan, svc.workChan, err = chnlworkq.New(nw, nw, nil, nil, svc.quit, &svc.wg); err != nil {
+	procReq, procWork := metrics.QueueCallbacks(metrics.QueueFlavorVerify, nil)
+	if svc.rqstChan, svc.workChan, err = chnlworkq.New(nw, nw, procReq, procWork, svc.quit, &svc.wg); err != nil {
 		return nil, nil, errors.New("hosttrust:NewService:Error starting work queue")
 	}
-	if svc.hfRqstChan, svc.hfWorkChan, err = chnlworkq.New(nw, nw, nil, nil, svc.quit, &svc.wg); err != nil {
+	procReq, procWork = metrics.QueueCallbacks(metrics.QueueHostDataVerify, nil)
+	if svc.hfRqstChan, svc.hfWorkChan, err = chnlworkq.New(nw, nw, procReq, procWork, svc.quit, &svc.wg); err != nil {
 		return nil, nil, errors.New("hosttrust:NewService:Error starting work queue")
 	}
+	procReq, procWork = metrics.QueueCallbacks(metrics.QueueFlavorVerifyLow, nil)
+	if svc.lowRqstChan, svc.lowWorkChan, err = chnlworkq.New(nw, nw, procReq, procWork, svc.quit, &svc.wg); err != nil {
+		return nil, nil, errors.New("hosttrust:NewService:Error starting work queue")
+	}
+	procReq, procWork = metrics.QueueCallbacks(metrics.QueueHostDataVerifyLow, nil)
+	if svc.lowHfRqstChan, svc.lowHfWorkChan, err = chnlworkq.New(nw, nw, procReq, procWork, svc.quit, &svc.wg); err != nil {
+		return nil, nil, errors.New("hosttrust:NewService:Error starting work queue")
+	}
+	metrics.VerificationWorkers.Set(float64(nw))
 
 	// start go routines
 	svc.startWorkers(cfg.Verifiers)
@@ -155,62 +177,76 @@ func (svc *Service) ProcessQueue() error {
 		return errors.Wrap(err, "An error occurred while searching for records in queue")
 	}
 
-	verifyWithFetchDataHostIds := map[uuid.UUID]bool{}
-	verifyHostIds := []uuid.UUID{}
+	// records are resumed by lane, the records of a lane being ordered by creation time
+	verifyWithFetchDataHostIds := map[models.QueuePriority]map[uuid.UUID]bool{}
+	verifyHostIds := map[models.QueuePriority][]uuid.UUID{}
 	if len(records) > 0 {
 		svc.mapmtx.Lock()
 		for _, queue := range records {
 			if queue.Params != nil {
-				var hostId uuid.UUID
-				fetchHostData := false
-				preferHashMatch := false
-				for key, value := range queue.Params {
-					if key == "host_id" {
-						if _, ok := value.(string); ok {
-							hostId, err = uuid.Parse(value.(string))
-							if err != nil {
-								return errors.Wrap(err, "hosttrust/manager:ProcessQueue() - parsing hostid failed")
-							}
-						} else {
-							hostId = value.(uuid.UUID)
-						}
-					}
-					if key == "fetch_host_data" {
-						fetchHostData = value.(bool)
-					}
-					if key == "prefer_hash_match" {
-						preferHashMatch = value.(bool)
-					}
+				hostId, err := queue.UUIDParam("host_id")
+				if err != nil {
+					svc.mapmtx.Unlock()
+					return errors.Wrap(err, "hosttrust/manager:ProcessQueue() - parsing hostid failed")
 				}
-				if fetchHostData {
-					verifyWithFetchDataHostIds[hostId] = preferHashMatch
+				fetchHostData := queue.BoolParam("fetch_host_data")
+				preferHashMatch := queue.BoolParam("prefer_hash_match")
+				priority := queue.Priority
+				if priority == models.QueuePriorityUnknown {
+					priority = models.QueuePriorityHigh
+				}
+
+				// the host data of the records that reached the flavor verification stage has already been
+				// fetched and persisted with the host status, these resume with the flavor verification
+				stage := taskstage.FlavorVerifyQueued
+				if fetchHostData && queue.Stage != taskstage.FlavorVerifyQueued && queue.Stage != taskstage.FlavorVerifyStarted {
+					stage = taskstage.GetHostDataQueued
+				}
+				if stage == taskstage.GetHostDataQueued {
+					if verifyWithFetchDataHostIds[priority] == nil {
+						verifyWithFetchDataHostIds[priority] = map[uuid.UUID]bool{}
+					}
+					verifyWithFetchDataHostIds[priority][hostId] = preferHashMatch
 				} else {
-					verifyHostIds = append(verifyHostIds, hostId)
+					verifyHostIds[priority] = append(verifyHostIds[priority], hostId)
 				}
-				ctx, cancel := context.WithCancel(context.Background())
+				if queue.Stage != stage {
+					svc.persistStage(queue.Id, stage)
+				}
+				ctx, cancel := svc.newJobContext(queue.Id, stage, priority)
 
 				// the host field is not filled at this stage since it requires a trip to the host store
 				svc.hosts[hostId] = &verifyTrustJob{ctx, cancel, nil, queue.Id,
-					fetchHostData, preferHashMatch}
+					stage == taskstage.GetHostDataQueued, preferHashMatch, priority}
 			}
 		}
 		svc.mapmtx.Unlock()
 	}
 
-	if len(verifyWithFetchDataHostIds) > 0 {
-		svc.wg.Add(1)
-		go svc.submitHostDataFetch(verifyWithFetchDataHostIds)
-	}
-	if len(verifyHostIds) > 0 {
-		go svc.queueFlavorVerify(verifyHostIds)
+	for _, priority := range []models.QueuePriority{models.QueuePriorityHigh, models.QueuePriorityLow} {
+		if len(verifyWithFetchDataHostIds[priority]) > 0 {
+			svc.wg.Add(1)
+			go svc.submitHostDataFetch(verifyWithFetchDataHostIds[priority])
+		}
+		if len(verifyHostIds[priority]) > 0 {
+			go svc.queueFlavorVerify(priority, verifyHostIds[priority])
+		}
 	}
 	return nil
 }
 
 func (svc *Service) VerifyHostsAsync(hostIds []uuid.UUID, fetchHostData, preferHashMatch bool) error {
+	return svc.VerifyHostsAsyncWithPriority(hostIds, fetchHostData, preferHashMatch, models.QueuePriorityHigh)
+}
+
+func (svc *Service) VerifyHostsAsyncWithPriority(hostIds []uuid.UUID, fetchHostData, preferHashMatch bool, priority models.QueuePriority) error {
 	defaultLog.Trace("hosttrust/manager:VerifyHostsAsync() Entering")
 	defer defaultLog.Trace("hosttrust/manager:VerifyHostsAsync() Leaving")
 
+	if priority == models.QueuePriorityUnknown {
+		priority = models.QueuePriorityHigh
+	}
+
 	// check if the service has already been shutdown
 	if svc.serviceDone {
 		return errors.New("hosttrust/manager:VerifyHostsAsync() Service already shutdown")
@@ -228,7 +264,10 @@ func (svc *Service) VerifyHostsAsync(hostIds []uuid.UUID, fetchHostData, preferH
 		if found {
 			prevJobStage, _ := taskstage.FromContext(vtj.ctx)
 			bothPreferHashMatch := preferHashMatch == vtj.preferHashMatch
-			if isDuplicateJob(fetchHostData, vtj.getNewHostData, bothPreferHashMatch, prevJobStage) {
+			// a job still waiting in a lower priority lane is replaced by the new one so that it moves to its lane
+			escalate := priority < vtj.priority && isQueuedStage(prevJobStage) &&
+				shouldCancelPrevJob(fetchHostData, vtj.getNewHostData)
+			if !escalate && isDuplicateJob(fetchHostData, vtj.getNewHostData, bothPreferHashMatch, prevJobStage) {
 				defaultLog.Debugf("hosttrust/manager:VerifyHostsAsync() Skipping dupe FVS job hostFetch - %s - for host %s", strconv.FormatBool(fetchHostData), hid.String())
 				continue
 			}
@@ -236,7 +275,7 @@ func (svc *Service) VerifyHostsAsync(hostIds []uuid.UUID, fetchHostData, preferH
 			if preferHashMatch && !vtj.preferHashMatch {
 				continue
 			}
-			if shouldCancelPrevJob(fetchHostData, vtj.getNewHostData) {
+			if escalate || shouldCancelPrevJob(fetchHostData, vtj.getNewHostData) {
 				// cancel the curr Job and make a new entry
 				vtj.cancelFn()
 				defaultLog.Debugf("hosttrust/manager:VerifyHostsAsync() Cancelling FVS job %s for host %s", vtj.storPersistId.String(), hid.String())
@@ -247,7 +286,7 @@ func (svc *Service) VerifyHostsAsync(hostIds []uuid.UUID, fetchHostData, preferH
 			adds = append(adds, hid)
 		}
 	}
-	if err := svc.persistToStore(adds, updates, fetchHostData, preferHashMatch); err != nil {
+	if err := svc.persistToStore(adds, updates, fetchHostData, preferHashMatch, priority); err != nil {
 		return errors.Wrap(err, "hosttrust/manager:VerifyHostsAsync() persistRequest - error in Persisting to Store")
 	}
 	verifyWithFetchDataHostIds := map[uuid.UUID]bool{}
@@ -259,7 +298,47 @@ func (svc *Service) VerifyHostsAsync(hostIds []uuid.UUID, fetchHostData, preferH
 		svc.wg.Add(1)
 		go svc.submitHostDataFetch(verifyWithFetchDataHostIds)
 	} else {
-		go svc.queueFlavorVerify(adds, updates)
+		go svc.queueFlavorVerify(priority, adds, updates)
+	}
+	return nil
+}
+
+// CancelVerification cancels the verification of the queue record with the given id, and removes the record from
+// the queue. Only the verifications waiting in one of the queues can be cancelled, the ones fetching the host data or
+// verifying it against the flavors have already started.
+func (svc *Service) CancelVerification(queueId uuid.UUID) error {
+	defaultLog.Trace("hosttrust/manager:CancelVerification() Entering")
+	defer defaultLog.Trace("hosttrust/manager:CancelVerification() Leaving")
+
+	svc.mapmtx.Lock()
+	for hId, vtj := range svc.hosts {
+		if vtj.storPersistId != queueId {
+			continue
+		}
+		if stage, _ := taskstage.FromContext(vtj.ctx); !isQueuedStage(stage) {
+			svc.mapmtx.Unlock()
+			return domain.ErrVerificationStarted
+		}
+		vtj.cancelFn()
+		delete(svc.hosts, hId)
+		svc.mapmtx.Unlock()
+		defaultLog.Debugf("hosttrust/manager:CancelVerification() Cancelled FVS job %s for host %s", queueId.String(), hId.String())
+		if err := svc.prstStor.Delete(queueId); err != nil {
+			return errors.Wrap(err, "hosttrust/manager:CancelVerification() - Could not delete queue record")
+		}
+		return nil
+	}
+	svc.mapmtx.Unlock()
+
+	// the record is not tracked by the service, remove it if it is left over in the store
+	if _, err := svc.prstStor.Retrieve(queueId); err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			return domain.ErrQueueEntryNotFound
+		}
+		return errors.Wrap(err, "hosttrust/manager:CancelVerification() - Could not retrieve queue record")
+	}
+	if err := svc.prstStor.Delete(queueId); err != nil {
+		return errors.Wrap(err, "hosttrust/manager:CancelVerification() - Could not delete queue record")
 	}
 	return nil
 }
@@ -296,31 +375,41 @@ func (svc *Service) submitHostDataFetch(hostLists map[uuid.UUID]bool) {
 	}
 }
 
-func (svc *Service) queueFlavorVerify(hostsLists ...[]uuid.UUID) {
+func (svc *Service) queueFlavorVerify(priority models.QueuePriority, hostsLists ...[]uuid.UUID) {
 	defaultLog.Trace("hosttrust/manager:queueFlavorVerify() Entering")
 	defer defaultLog.Trace("hosttrust/manager:queueFlavorVerify() Leaving")
 
+	rqstChan := svc.rqstChan
+	if priority == models.QueuePriorityLow {
+		rqstChan = svc.lowRqstChan
+	}
 	for _, hosts := range hostsLists {
 		// unlike the submitHostDataFetch, this one needs to be processed one at a time.
 		for _, hId := range hosts {
 			// here the map already has the information that we need to start the job. The host data
 			// is not available - but the worker thread should just retrieve it individually from the
 			// go routine. So, all we have to do is submit requests
-			svc.rqstChan <- hId
+			rqstChan <- hId
 			// the go routine that manages the work queue will process the request. It only blocks till the
 			// request is copied to the internal queue
 		}
 	}
 }
 
-func (svc *Service) persistToStore(additions, updates []uuid.UUID, fetchHostData, preferHashMatch bool) error {
+func (svc *Service) persistToStore(additions, updates []uuid.UUID, fetchHostData, preferHashMatch bool, priority models.QueuePriority) error {
 	defaultLog.Trace("hosttrust/manager:persistToStore() Entering")
 	defer defaultLog.Trace("hosttrust/manager:persistToStore() Leaving")
 
+	stage := taskstage.FlavorVerifyQueued
+	if fetchHostData {
+		stage = taskstage.GetHostDataQueued
+	}
 	persistRecords := func(lst []uuid.UUID, create bool) error {
 		strRec := &models.Queue{Action: "flavor-verify",
-			Params: map[string]interface{}{"host_id": uuid.Nil, "fetch_host_data": fetchHostData, "prefer_hash_match": preferHashMatch},
-			State:  models.QueueStatePending,
+			Params:   map[string]interface{}{"host_id": uuid.Nil, "fetch_host_data": fetchHostData, "prefer_hash_match": preferHashMatch},
+			State:    models.QueueStatePending,
+			Priority: priority,
+			Stage:    stage,
 		}
 
 		for _, hid := range lst {
@@ -368,17 +457,17 @@ func (svc *Service) persistToStore(additions, updates []uuid.UUID, fetchHostData
 			}
 			// update map ONLY if CRUD operation on queue store
 			if mapNeedsUpdate {
-				ctx, cancel := context.WithCancel(context.Background())
+				ctx, cancel := svc.newJobContext(strRec.Id, stage, priority)
 
 				// check if existing map has fetchHostData == true - then force update to true
 				if !create && svc.hosts[hid].getNewHostData && !fetchHostData {
 					// the host field is not filled at this stage since it requires a trip to the host store
 					svc.hosts[hid] = &verifyTrustJob{ctx, cancel, nil, strRec.Id,
-						true, preferHashMatch}
+						true, preferHashMatch, priority}
 				} else {
 					// the host field is not filled at this stage since it requires a trip to the host store
 					svc.hosts[hid] = &verifyTrustJob{ctx, cancel, nil, strRec.Id,
-						fetchHostData, preferHashMatch}
+						fetchHostData, preferHashMatch, priority}
 				}
 			}
 		}
@@ -396,6 +485,28 @@ func (svc *Service) persistToStore(additions, updates []uuid.UUID, fetchHostData
 	return nil
 }
 
+// newJobContext returns the context of a job, carrying its priority and its stage. The stages at which the job enters
+// or leaves one of the queues are persisted to the queue store, so that the queue can be inspected, the queued jobs
+// cancelled and the jobs resumed after a restart from the last queue they reached. The report creation stages are
+// not persisted, the record is deleted once the report is saved.
+func (svc *Service) newJobContext(queueId uuid.UUID, stage taskstage.Stage, priority models.QueuePriority) (context.Context, context.CancelFunc) {
+	ctx := models.NewQueuePriorityContext(context.Background(), priority)
+	ctx = taskstage.NewContextWithListener(ctx, stage, func(stage taskstage.Stage) {
+		if stage < taskstage.ReportCreationStarted {
+			svc.persistStage(queueId, stage)
+		}
+	})
+	return context.WithCancel(ctx)
+}
+
+func (svc *Service) persistStage(queueId uuid.UUID, stage taskstage.Stage) {
+	// the record could have been deleted when the job was cancelled or completed
+	if err := svc.prstStor.Update(&models.Queue{Id: queueId, Stage: stage}); err != nil {
+		defaultLog.WithError(err).Debugf("hosttrust/manager:persistStage() Could not persist stage %s of queue record %s",
+			stage.String(), queueId.String())
+	}
+}
+
 // function that does the actual work. There are two seperate channels that contains work.
 // First one is the flavor verification work submitted that does not require new host data
 // Second one is work that first requires new data from host.
@@ -416,40 +527,48 @@ func (svc *Service) doWork() {
 		newData := false
 		preferHashMatch := false
 
+		// the low priority lanes are only read when there is no work in the high priority lanes
+		var work interface{}
 		select {
-
 		case <-svc.quit:
 			// we have received a quit. Don't process anymore items - just return
 			return
-
-		case id := <-svc.workChan:
-			if hId, ok := id.(uuid.UUID); !ok {
-				defaultLog.Error("hosttrust/manager:doWork() expecting uuid from channel - but got different type")
+		case work = <-svc.workChan:
+		case work = <-svc.hfWorkChan:
+		default:
+			select {
+			case <-svc.quit:
 				return
-			} else {
-				hostStatusCollection, err := svc.hostStatusStore.Search(&models.HostStatusFilterCriteria{
-					HostId:        hId,
-					LatestPerHost: true,
-				})
-				if err != nil || len(hostStatusCollection) == 0 || hostStatusCollection[0].HostStatusInformation.HostState != hvs.HostStateConnected {
-					defaultLog.Error("hosttrust/manager:doWork() - could not retrieve host data from store - error :", err)
-					return
-				}
-				hostId = hId
-				hostData = &hostStatusCollection[0].HostManifest
+			case work = <-svc.workChan:
+			case work = <-svc.hfWorkChan:
+			case work = <-svc.lowWorkChan:
+			case work = <-svc.lowHfWorkChan:
 			}
+		}
 
-		case data := <-svc.hfWorkChan:
-			if hData, ok := data.(newHostFetch); !ok {
-				defaultLog.Error("hosttrust/manager:doWork() expecting newHostFetch type from channel - but got different one")
-				return
-			} else {
-				hostId = hData.hostId
-				hostData = hData.data
-				preferHashMatch = hData.preferHashMatch
-				newData = true
+		switch w := work.(type) {
+		case uuid.UUID:
+			hostStatusCollection, err := svc.hostStatusStore.Search(&models.HostStatusFilterCriteria{
+				HostId:        w,
+				LatestPerHost: true,
+			})
+			if err != nil || len(hostStatusCollection) == 0 || hostStatusCollection[0].HostStatusInformation.HostState != hvs.HostStateConnected {
+				defaultLog.Error("hosttrust/manager:doWork() - could not retrieve host data from store - error :", err)
+				svc.deleteEntry(w, nil)
+				continue
 			}
+			hostId = w
+			hostData = &hostStatusCollection[0].HostManifest
+
+		case newHostFetch:
+			hostId = w.hostId
+			hostData = w.data
+			preferHashMatch = w.preferHashMatch
+			newData = true
 
+		default:
+			defaultLog.Error("hosttrust/manager:doWork() expecting uuid or newHostFetch type from channel - but got different type")
+			continue
 		}
 		svc.verifyHostData(hostId, hostData, newData, preferHashMatch)
 	}
@@ -480,12 +599,18 @@ func (svc *Service) verifyHostData(hostId uuid.UUID, data *types.HostManifest, n
 	}
 	svc.mapmtx.Unlock()
 
+	metrics.VerificationsInFlight.Inc()
+	start := time.Now()
 	_, err := svc.verifier.Verify(hostId, data, newData, preferHashMatch)
+	metrics.VerificationsInFlight.Dec()
 	if err != nil {
+		metrics.VerificationDuration.WithLabelValues(metrics.ResultError).Observe(time.Since(start).Seconds())
 		defaultLog.WithError(err).Errorf("hosttrust/manager:verifyHostData() Error while verification")
+	} else {
+		metrics.VerificationDuration.WithLabelValues(metrics.ResultSuccess).Observe(time.Since(start).Seconds())
 	}
 	// verify is completed - delete the entry
-	svc.deleteEntry(hostId)
+	svc.deleteEntry(hostId, vtj.ctx)
 }
 
 // This function is the implementation of the HostDataReceiver interface method. Just create a new request
@@ -501,12 +626,17 @@ func (svc *Service) ProcessHostData(ctx context.Context, host hvs.Host, data *ty
 	}
 	// if there is an error - delete the entry
 	if err != nil {
-		svc.deleteEntry(host.Id)
+		svc.deleteEntry(host.Id, ctx)
+		return nil
 	}
 
 	// queue the new data to be processed by one of the worker threads by adding this to the queue
 	taskstage.StoreInContext(ctx, taskstage.FlavorVerifyQueued)
-	svc.hfRqstChan <- newHostFetch{
+	hfRqstChan := svc.hfRqstChan
+	if models.QueuePriorityFromContext(ctx) == models.QueuePriorityLow {
+		hfRqstChan = svc.lowHfRqstChan
+	}
+	hfRqstChan <- newHostFetch{
 		ctx:             ctx,
 		hostId:          host.Id,
 		data:            data,
@@ -529,6 +659,11 @@ func isDuplicateJob(newJobNeedFreshHostData, prevJobNeededFreshData, bothPreferH
 	return false
 }
 
+// isQueuedStage determines if a job is waiting in one of the queues, not being worked on
+func isQueuedStage(stage taskstage.Stage) bool {
+	return stage == taskstage.FlavorVerifyQueued || stage == taskstage.GetHostDataQueued
+}
+
 // shouldCancelPrevJob determines if the previous job can be cancelled out
 func shouldCancelPrevJob(newJobNeedFreshHostData, prevJobNeededFreshData bool) bool {
 	defaultLog.Trace("hosttrust/manager:shouldCancelPrevJob() Entering")
@@ -543,13 +678,15 @@ func shouldCancelPrevJob(newJobNeedFreshHostData, prevJobNeededFreshData bool) b
 	return true
 }
 
-func (svc *Service) deleteEntry(hostId uuid.UUID) {
+// deleteEntry removes the job of a host from the map and the queue store. When the context of the job is given,
+// the entry is only removed if it still belongs to that job and has not been replaced by a newer one.
+func (svc *Service) deleteEntry(hostId uuid.UUID, jobCtx context.Context) {
 	defaultLog.Trace("hosttrust/manager:deleteEntry() Entering")
 	defer defaultLog.Trace("hosttrust/manager:deleteEntry() Leaving")
 
 	var strRecId uuid.UUID
 	svc.mapmtx.Lock()
-	if strRec, exists := svc.hosts[hostId]; exists {
+	if strRec, exists := svc.hosts[hostId]; exists && (jobCtx == nil || strRec.ctx == jobCtx) {
 		strRecId = strRec.storPersistId
 		strRec.ctx.Done()
 		delete(svc.hosts, hostId)
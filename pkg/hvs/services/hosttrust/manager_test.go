@@ -16,6 +16,7 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models/taskstage"
 	hostfetcher "github.com/intel-secl/intel-secl/v3/pkg/hvs/services/host-fetcher"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust"
 	mocks2 "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/mocks"
@@ -264,3 +265,68 @@ func TestManager_VerifyNonExistentHost(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, service.VerifyHostsAsync([]uuid.UUID{newId}, false, false), "VerifyHostsAsync should error out when the Host does not exist")
 }
+
+func TestManager_CancelVerification(t *testing.T) {
+	SetupManagerTests()
+
+	// the host is not in the host store, so the job stays queued for the host data fetch
+	newId, err := uuid.NewRandom()
+	assert.NoError(t, err)
+	assert.NoError(t, service.VerifyHostsAsyncWithPriority([]uuid.UUID{newId}, true, false, models.QueuePriorityLow))
+
+	qrecs, err := qs.Search(&models.QueueFilterCriteria{HostId: newId})
+	assert.NoError(t, err)
+	assert.Len(t, qrecs, 1)
+	assert.Equal(t, taskstage.GetHostDataQueued, qrecs[0].Stage)
+	assert.Equal(t, models.QueuePriorityLow, qrecs[0].Priority)
+
+	assert.NoError(t, service.CancelVerification(qrecs[0].Id))
+	qrecs, err = qs.Search(&models.QueueFilterCriteria{HostId: newId})
+	assert.NoError(t, err)
+	assert.Len(t, qrecs, 0)
+
+	assert.Equal(t, domain.ErrQueueEntryNotFound, service.CancelVerification(uuid.New()))
+}
+
+func TestManager_ProcessQueueResumesStage(t *testing.T) {
+	SetupManagerTests()
+	// stop the workers so that the resumed jobs stay queued
+	assert.NoError(t, service.Shutdown())
+
+	fetchedHostId := uuid.New()
+	fetched, err := qs.Create(&models.Queue{Action: "flavor-verify",
+		Params:   map[string]interface{}{"host_id": fetchedHostId.String(), "fetch_host_data": true, "prefer_hash_match": false},
+		State:    models.QueueStatePending,
+		Priority: models.QueuePriorityLow,
+		Stage:    taskstage.FlavorVerifyStarted,
+	})
+	assert.NoError(t, err)
+	notFetchedHostId := uuid.New()
+	notFetched, err := qs.Create(&models.Queue{Action: "flavor-verify",
+		Params:   map[string]interface{}{"host_id": notFetchedHostId.String(), "fetch_host_data": true, "prefer_hash_match": false},
+		State:    models.QueueStatePending,
+		Priority: models.QueuePriorityHigh,
+		Stage:    taskstage.GetHostDataStarted,
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, service.ProcessQueue())
+
+	// the host data of the first record has already been fetched, it resumes with the flavor verification
+	qrec, err := qs.Retrieve(fetched.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, taskstage.FlavorVerifyQueued, qrec.Stage)
+	qrec, err = qs.Retrieve(notFetched.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, taskstage.GetHostDataQueued, qrec.Stage)
+
+	qrecs, err := qs.Search(&models.QueueFilterCriteria{Stages: []taskstage.Stage{taskstage.FlavorVerifyQueued,
+		taskstage.GetHostDataQueued}})
+	assert.NoError(t, err)
+	assert.Len(t, qrecs, 2)
+	assert.Equal(t, notFetched.Id, qrecs[0].Id, "the records of the high priority lane come first")
+
+	assert.NoError(t, service.CancelVerification(fetched.Id))
+	_, err = qs.Retrieve(fetched.Id)
+	assert.Error(t, err)
+}
@@ -25,6 +25,14 @@ func (mock *MockHostTrustManager) VerifyHostsAsync(hostIds []uuid.UUID, fetchHos
 	return nil
 }
 
+func (mock *MockHostTrustManager) VerifyHostsAsyncWithPriority(hostIds []uuid.UUID, fetchHostData, preferHashMatch bool, priority models.QueuePriority) error {
+	return mock.VerifyHostsAsync(hostIds, fetchHostData, preferHashMatch)
+}
+
+func (mock *MockHostTrustManager) CancelVerification(queueId uuid.UUID) error {
+	return nil
+}
+
 func (mock *MockHostTrustManager) ProcessQueue() error {
 	return nil
 }
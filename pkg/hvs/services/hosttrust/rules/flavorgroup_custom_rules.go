@@ -0,0 +1,60 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package rules
+
+import (
+	cf "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	verifierRules "github.com/intel-secl/intel-secl/v3/pkg/lib/verifier/rules"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// FlavorGroupCustomRules evaluates the site specific rules declared in a flavorgroup
+type FlavorGroupCustomRules struct {
+	CustomRules []model.CustomRule
+}
+
+func NewFlavorGroupCustomRules(customRules []model.CustomRule) *FlavorGroupCustomRules {
+	return &FlavorGroupCustomRules{
+		CustomRules: customRules,
+	}
+}
+
+// Apply evaluates the custom rules against the host manifest and adds their results to the trust report. The results
+// are marked with the flavor part of the rules, PLATFORM by default.
+func (r *FlavorGroupCustomRules) Apply(trustReport hvs.TrustReport, hostManifest *types.HostManifest) (*hvs.TrustReport, error) {
+
+	for _, customRule := range r.CustomRules {
+		marker := cf.FlavorPartPlatform
+		if customRule.FlavorPart != "" {
+			if err := (&marker).Parse(customRule.FlavorPart.String()); err != nil {
+				return nil, errors.Wrapf(err, "Invalid flavor part of custom rule '%s'", customRule.Name)
+			}
+		}
+
+		rule, err := verifierRules.NewCustomRule(customRule, marker)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to create flavorgroup custom rule")
+		}
+
+		result, err := rule.Apply(hostManifest)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to apply custom rule '%s'", customRule.Name)
+		}
+
+		if result != nil {
+			result.Trusted = result.IsTrusted()
+			if !result.Trusted {
+				defaultLog.Debugf("Flavorgroup custom rule %s is not satisfied", customRule.Name)
+			}
+			trustReport.AddResult(*result)
+		}
+	}
+
+	return &trustReport, nil
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/metrics"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust/rules"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
 	cf "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
@@ -171,6 +172,7 @@ func (v *Verifier) verifyFlavors(hostID uuid.UUID, flavors []hvs.SignedFlavor, h
 				if err != nil {
 					return &hvs.TrustReport{}, errors.Wrap(err, "hosttrust/trust_report:verifyFlavors() Error verifying flavor")
 				}
+				recordRuleFailures(individualTrustReport)
 				if individualTrustReport.Trusted {
 					if reflect.DeepEqual(collectiveTrustReport, hvs.TrustReport{}) {
 						collectiveTrustReport = *individualTrustReport
@@ -426,3 +428,12 @@ func getMeasurementLabels(hostManifest *types.HostManifest) ([]string, error) {
 	}
 	return measurementLabels, nil
 }
+
+// recordRuleFailures counts the rules that failed in a flavor verification
+func recordRuleFailures(report *hvs.TrustReport) {
+	for _, result := range report.Results {
+		if !result.Trusted {
+			metrics.RuleFailures.WithLabelValues(result.Rule.Name).Inc()
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust/rules"
 	cf "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	flavormodel "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
 	flavorVerifier "github.com/intel-secl/intel-secl/v3/pkg/lib/verifier"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
@@ -27,6 +28,7 @@ type flvGrpHostTrustReqs struct {
 	DefinedAndRequiredFlavorTypes   map[cf.FlavorPart]bool
 	FlavorPartMatchPolicy           map[cf.FlavorPart]hvs.MatchPolicy
 	SkipFlavorSignatureVerification bool
+	CustomRules                     []flavormodel.CustomRule
 }
 
 func NewFlvGrpHostTrustReqs(hostId uuid.UUID, definedUniqueFlavorParts map[cf.FlavorPart]bool, fg hvs.FlavorGroup, fs domain.FlavorStore, fgs domain.FlavorGroupStore, hostData *types.HostManifest, SkipFlavorSignatureVerification bool) (*flvGrpHostTrustReqs, error) {
@@ -40,6 +42,7 @@ func NewFlvGrpHostTrustReqs(hostId uuid.UUID, definedUniqueFlavorParts map[cf.Fl
 		//Initialize empty map.
 		DefinedAndRequiredFlavorTypes:   make(map[cf.FlavorPart]bool),
 		SkipFlavorSignatureVerification: SkipFlavorSignatureVerification,
+		CustomRules:                     fg.CustomRules,
 	}
 
 	var fgRequirePolicyMap map[hvs.FlavorRequiredPolicy][]cf.FlavorPart
@@ -12,6 +12,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/metrics"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust/rules"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/notification"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
@@ -38,6 +41,8 @@ type Verifier struct {
 	hostQuoteReportCache            map[uuid.UUID]*models.QuoteReportCache
 	pcrCacheLock                    sync.RWMutex
 	HostTrustCache                  *lru.Cache
+	NotificationManager             domain.NotificationManager
+	AikCertificateStore             domain.AikCertificateStore
 }
 
 func NewVerifier(cfg domain.HostTrustVerifierConfig) domain.HostTrustVerifier {
@@ -51,10 +56,44 @@ func NewVerifier(cfg domain.HostTrustVerifierConfig) domain.HostTrustVerifier {
 		SamlIssuer:                      cfg.SamlIssuerConfig,
 		SkipFlavorSignatureVerification: cfg.SkipFlavorSignatureVerification,
 		HostTrustCache:                  cfg.HostTrustCache,
+		NotificationManager:             cfg.NotificationManager,
+		AikCertificateStore:             cfg.AikCertificateStore,
 		hostQuoteReportCache:            make(map[uuid.UUID]*models.QuoteReportCache),
 	}
 }
 
+// linkAikCertificate records the hardware UUID of the host in the AIK certificate inventory, for the AIK certificates
+// that were issued before the EK of the host was registered
+func (v *Verifier) linkAikCertificate(hwUuid uuid.UUID, hostData *types.HostManifest) {
+	defaultLog.Trace("hosttrust/verifier:linkAikCertificate() Entering")
+	defer defaultLog.Trace("hosttrust/verifier:linkAikCertificate() Leaving")
+
+	if v.AikCertificateStore == nil || hostData.AIKCertificate == "" {
+		return
+	}
+	aik, err := hostData.GetAIKCertificate()
+	if err != nil {
+		defaultLog.WithError(err).Warn("hosttrust/verifier:linkAikCertificate() Error while parsing the AIK certificate")
+		return
+	}
+	aikCertificates, err := v.AikCertificateStore.Search(&models.AikCertificateFilterCriteria{
+		SerialNumberEqualTo: utils.GetAikCertificateSerialNumber(aik),
+	})
+	if err != nil {
+		defaultLog.WithError(err).Warn("hosttrust/verifier:linkAikCertificate() Error while searching the AIK certificate inventory")
+		return
+	}
+	for i := range aikCertificates {
+		if aikCertificates[i].HardwareUUID != nil {
+			continue
+		}
+		aikCertificates[i].HardwareUUID = &hwUuid
+		if _, err := v.AikCertificateStore.Update(&aikCertificates[i]); err != nil {
+			defaultLog.WithError(err).Warn("hosttrust/verifier:linkAikCertificate() Error while updating the AIK certificate inventory")
+		}
+	}
+}
+
 func getTrustPcrListReport(hostInfo taModel.HostInfo, report *hvs.TrustReport) []int {
 	defaultLog.Trace("hosttrust/verifier:getTrustPcrListReport() Entering")
 	defer defaultLog.Trace("hosttrust/verifier:getTrustPcrListReport() Leaving")
@@ -89,6 +128,10 @@ func (v *Verifier) Verify(hostId uuid.UUID, hostData *types.HostManifest, newDat
 		return nil, ErrManifestMissingHwUUID
 	}
 
+	if newData {
+		v.linkAikCertificate(hwUuid, hostData)
+	}
+
 	// check if the data has not changed
 	if preferHashMatch {
 		cacheEntry, ok := v.HostTrustCache.Get(hostId)
@@ -99,6 +142,7 @@ func (v *Verifier) Verify(hostId uuid.UUID, hostData *types.HostManifest, newDat
 				// retrieve the stored report
 				log.Debug("hosttrust/verifier:Verify() Quote values matches cached value - skipping flavor verification")
 				if report, err := v.refreshTrustReport(hostId, cachedQuote); err == nil {
+					metrics.HostTrustCacheRequests.WithLabelValues(metrics.ResultHit).Inc()
 					return report, err
 				} else {
 					// log warning message here - continue as normal and create a report from newly fetched data
@@ -106,6 +150,8 @@ func (v *Verifier) Verify(hostId uuid.UUID, hostData *types.HostManifest, newDat
 				}
 			}
 		}
+		// the cached report could not be reused, the flavors are verified against the host data
+		metrics.HostTrustCacheRequests.WithLabelValues(metrics.ResultMiss).Inc()
 	}
 	// TODO : remove this when we remove the intermediate collection
 	flvGroupIds, err := v.HostStore.SearchFlavorgroups(hostId)
@@ -163,6 +209,17 @@ func (v *Verifier) Verify(hostId uuid.UUID, hostData *types.HostManifest, newDat
 				return nil, errors.Wrap(err, "hosttrust/verifier:Verify() Error while creating flavorgroup report")
 			}
 		}
+		if len(fgTrustReqs.CustomRules) > 0 {
+			customRules := rules.NewFlavorGroupCustomRules(fgTrustReqs.CustomRules)
+			customRulesReport, err := customRules.Apply(fgTrustReport, hostData)
+			if err != nil {
+				return nil, errors.Wrap(err, "hosttrust/verifier:Verify() Error while applying flavorgroup custom rules")
+			}
+			if !customRulesReport.IsTrusted() {
+				finalReportValid = false
+			}
+			fgTrustReport = *customRulesReport
+		}
 		log.Debug("hosttrust/verifier:Verify() Trust status for host id ", hostId, " for flavorgroup ", fg.ID, " is ", fgTrustReport.IsTrusted())
 		// append the results
 		finalTrustReport.AddResults(fgTrustReport.Results)
@@ -186,6 +243,7 @@ func (v *Verifier) Verify(hostId uuid.UUID, hostData *types.HostManifest, newDat
 			TrustReport:  &finalTrustReport,
 		}
 		v.HostTrustCache.Add(hostId, newCacheEntry)
+		metrics.HostTrustCacheEntries.Set(float64(v.HostTrustCache.Len()))
 		hvsReport = v.storeTrustReport(hostId, &finalTrustReport, &samlReport)
 	}
 	return hvsReport, nil
@@ -225,6 +283,7 @@ func (v *Verifier) validateCachedFlavors(hostId uuid.UUID,
 		if err != nil {
 			return hostTrustCache{}, errors.Wrap(err, "hosttrust/verifier:validateCachedFlavors() Error from flavor verifier")
 		}
+		recordRuleFailures(report)
 		if report.Trusted {
 			htc.trustedFlavors = append(htc.trustedFlavors, cachedFlavor.Flavor)
 			collectiveReport.Results = append(collectiveReport.Results, report.Results...)
@@ -265,9 +324,28 @@ func (v *Verifier) storeTrustReport(hostID uuid.UUID, trustReport *hvs.TrustRepo
 		Expiration:  samlReport.ExpiryTime,
 		Saml:        samlReport.Assertion,
 	}
+
+	// the previous report is replaced by the update, so keep its trust status for change notifications
+	var previousTrustReport *hvs.TrustReport
+	if v.NotificationManager != nil {
+		previousReports, err := v.ReportStore.Search(&models.ReportFilterCriteria{HostID: hostID, LatestPerHost: true})
+		if err != nil {
+			log.WithError(err).Warnf("hosttrust/verifier:storeTrustReport() Failed to retrieve previous report for host %s", hostID)
+		} else if len(previousReports) > 0 {
+			previousTrustReport = &previousReports[0].TrustReport
+		}
+	}
+
 	report, err := v.ReportStore.Update(&hvsReport)
 	if err != nil {
 		log.WithError(err).Errorf("hosttrust/verifier:storeTrustReport() Failed to store Report")
+		return report
+	}
+
+	if v.NotificationManager != nil {
+		for _, event := range notification.TrustChangeEvents(hostID, report.ID, previousTrustReport, trustReport) {
+			v.NotificationManager.Publish(event)
+		}
 	}
 	return report
 }
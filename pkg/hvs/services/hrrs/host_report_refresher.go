@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/metrics"
 	commLog "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
 
 	"github.com/pkg/errors"
@@ -66,6 +68,10 @@ func (refresher *hostReportRefresherImpl) Run() error {
 			if err != nil {
 				// log any errors, but do not stop trying to refresh reports
 				defaultLog.Errorf("HRRS encountered an error while refreshing reports...\n%+v\n", err)
+				metrics.HrrsRefreshes.WithLabelValues(metrics.ResultError).Inc()
+			} else {
+				metrics.HrrsRefreshes.WithLabelValues(metrics.ResultSuccess).Inc()
+				metrics.HrrsLastRefresh.Set(float64(time.Now().Unix()))
 			}
 
 			select {
@@ -113,10 +119,12 @@ func (refresher *hostReportRefresherImpl) refreshReports() error {
 	defaultLog.Debugf("HRRS found %d hosts to refresh", len(hostIDs))
 
 	if len(hostIDs) > 0 {
-		err = refresher.hostTrustManager.VerifyHostsAsync(hostIDs, true, true)
+		// the refresh is queued behind the verifications requested through the API
+		err = refresher.hostTrustManager.VerifyHostsAsyncWithPriority(hostIDs, true, true, models.QueuePriorityLow)
 		if err != nil {
 			return errors.Wrap(err, "HRRS encountered an error calling the host trust manager")
 		}
+		metrics.HrrsHostsQueued.Add(float64(len(hostIDs)))
 	}
 
 	defaultLog.Infof("HRRS queued %d hosts from reports that were expiring between %s and %s", len(hostIDs), refresher.fromTime, toTime)
@@ -111,6 +111,14 @@ func (htm MockHostTrustManager) VerifyHost(hostId uuid.UUID, fetchHostData bool,
 	return nil, errors.New("VerifyHost is not implemented")
 }
 
+func (htm MockHostTrustManager) CancelVerification(queueId uuid.UUID) error {
+	return errors.New("CancelVerification is not implemented")
+}
+
+func (htm MockHostTrustManager) VerifyHostsAsyncWithPriority(hostIDs []uuid.UUID, fetchHostData, preferHashMatch bool, priority models.QueuePriority) error {
+	return htm.VerifyHostsAsync(hostIDs, fetchHostData, preferHashMatch)
+}
+
 func (htm MockHostTrustManager) ProcessQueue() error {
 	return errors.New("ProcessQueue is not implemented")
 }
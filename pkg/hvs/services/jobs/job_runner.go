@@ -0,0 +1,112 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	commLog "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
+	"github.com/pkg/errors"
+)
+
+var defaultLog = commLog.GetDefaultLogger()
+
+// JobInterruptedReason is the error of the jobs that were not finished when HVS was stopped
+const JobInterruptedReason = "The job was interrupted by a restart of HVS"
+
+// ErrJobRunnerBusy is returned when the queue of the job runner is full
+var ErrJobRunnerBusy = errors.New("Too many jobs are queued")
+
+// ErrJobRunnerStopped is returned when a job is submitted after the job runner has been stopped
+var ErrJobRunnerStopped = errors.New("The job runner is stopped")
+
+// JobRunner runs the jobs on a fixed number of workers. The context of the jobs is cancelled when the job runner is
+// stopped, and the jobs that are still queued are run with the cancelled context so that they are recorded as failed.
+type JobRunner struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	queue  chan func(context.Context)
+	wg     sync.WaitGroup
+
+	mtx     sync.RWMutex
+	stopped bool
+}
+
+// NewJobRunner marks the jobs interrupted by the last shutdown of HVS as failed, and starts the workers of the
+// job runner
+func NewJobRunner(jobStore domain.JobStore, workers, queueSize int) (*JobRunner, error) {
+	defaultLog.Trace("jobs/job_runner:NewJobRunner() Entering")
+	defer defaultLog.Trace("jobs/job_runner:NewJobRunner() Leaving")
+
+	count, err := jobStore.FailUnfinished(JobInterruptedReason)
+	if err != nil {
+		return nil, errors.Wrap(err, "jobs/job_runner:NewJobRunner() Failed to mark the interrupted jobs failed")
+	}
+	if count > 0 {
+		defaultLog.Warnf("jobs/job_runner:NewJobRunner() %d jobs interrupted by the last shutdown were marked failed", count)
+	}
+
+	if workers <= 0 {
+		workers = 1
+	}
+	runner := &JobRunner{queue: make(chan func(context.Context), queueSize)}
+	runner.ctx, runner.cancel = context.WithCancel(context.Background())
+	for i := 0; i < workers; i++ {
+		runner.wg.Add(1)
+		go func() {
+			defer runner.wg.Done()
+			for job := range runner.queue {
+				job(runner.ctx)
+			}
+		}()
+	}
+	return runner, nil
+}
+
+// Submit queues a job, it fails when the queue is full or the job runner is stopped
+func (runner *JobRunner) Submit(job func(context.Context)) error {
+	runner.mtx.RLock()
+	defer runner.mtx.RUnlock()
+
+	if runner.stopped {
+		return ErrJobRunnerStopped
+	}
+	select {
+	case runner.queue <- job:
+		return nil
+	default:
+		return ErrJobRunnerBusy
+	}
+}
+
+// Stop cancels the running jobs and waits up to the timeout for the workers to record them
+func (runner *JobRunner) Stop(timeout time.Duration) error {
+	defaultLog.Trace("jobs/job_runner:Stop() Entering")
+	defer defaultLog.Trace("jobs/job_runner:Stop() Leaving")
+
+	runner.mtx.Lock()
+	if !runner.stopped {
+		runner.stopped = true
+		runner.cancel()
+		close(runner.queue)
+	}
+	runner.mtx.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		runner.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return errors.New("jobs/job_runner:Stop() Timed out waiting for the running jobs")
+	}
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJobRunnerFailsInterruptedJobs(t *testing.T) {
+	jobStore := mocks.NewMockJobStore()
+	job, err := jobStore.Create(&hvs.Job{
+		Type:   hvs.JobTypeHostBulkRegistration,
+		Status: hvs.JobStatusRunning,
+		Total:  2,
+		Hosts: []hvs.JobHostEntry{
+			{HostName: "host1", Status: hvs.JobStatusCompleted},
+			{HostName: "host2", Status: hvs.JobStatusQueued},
+		},
+		Succeeded: 1,
+	})
+	assert.NoError(t, err)
+
+	runner, err := NewJobRunner(jobStore, 1, 1)
+	assert.NoError(t, err)
+	defer runner.Stop(time.Second)
+
+	job, err = jobStore.Retrieve(job.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, hvs.JobStatusFailed, job.Status)
+	assert.Equal(t, JobInterruptedReason, job.Error)
+	assert.Equal(t, 1, job.Failed)
+	assert.Equal(t, hvs.JobStatusCompleted, job.Hosts[0].Status)
+	assert.Equal(t, hvs.JobStatusFailed, job.Hosts[1].Status)
+}
+
+func TestJobRunnerStopCancelsJobs(t *testing.T) {
+	runner, err := NewJobRunner(mocks.NewMockJobStore(), 1, 1)
+	assert.NoError(t, err)
+
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	err = runner.Submit(func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		close(cancelled)
+	})
+	assert.NoError(t, err)
+	<-started
+
+	// the queue holds a single job
+	assert.NoError(t, runner.Submit(func(ctx context.Context) {}))
+	assert.Equal(t, ErrJobRunnerBusy, runner.Submit(func(ctx context.Context) {}))
+
+	assert.NoError(t, runner.Stop(time.Second))
+	select {
+	case <-cancelled:
+	default:
+		t.Error("The running job was not cancelled")
+	}
+	assert.Equal(t, ErrJobRunnerStopped, runner.Submit(func(ctx context.Context) {}))
+}
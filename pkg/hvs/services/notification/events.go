@@ -0,0 +1,105 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package notification
+
+import (
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+)
+
+// TrustChangeEvents compares the previous trust report of a host with the current one and returns an event for
+// the overall trust status and for every flavor part whose trust status changed. previous is nil when the host
+// had no report yet, in which case only the overall trust status is reported.
+func TrustChangeEvents(hostId uuid.UUID, reportId uuid.UUID, previous, current *hvs.TrustReport) []*hvs.NotificationEvent {
+	defaultLog.Trace("notification/events:TrustChangeEvents() Entering")
+	defer defaultLog.Trace("notification/events:TrustChangeEvents() Leaving")
+
+	if current == nil {
+		return nil
+	}
+	var events []*hvs.NotificationEvent
+	hostName := current.HostManifest.HostInfo.HostName
+	var reportIdRef *uuid.UUID
+	if reportId != uuid.Nil {
+		reportIdRef = &reportId
+	}
+
+	trusted := current.IsTrusted()
+	if previous == nil {
+		return append(events, &hvs.NotificationEvent{
+			Type:     hvs.EventHostTrustChanged,
+			HostID:   hostId,
+			HostName: hostName,
+			ReportID: reportIdRef,
+			Trusted:  &trusted,
+		})
+	}
+
+	previousTrusted := previous.IsTrusted()
+	if previousTrusted != trusted {
+		events = append(events, &hvs.NotificationEvent{
+			Type:            hvs.EventHostTrustChanged,
+			HostID:          hostId,
+			HostName:        hostName,
+			ReportID:        reportIdRef,
+			PreviousTrusted: &previousTrusted,
+			Trusted:         &trusted,
+		})
+	}
+
+	for _, flavorPart := range common.GetFlavorTypes() {
+		prevTrust := flavorPartTrust(previous, flavorPart)
+		currTrust := flavorPartTrust(current, flavorPart)
+		if prevTrust == nil && currTrust == nil {
+			continue
+		}
+		if prevTrust != nil && currTrust != nil && *prevTrust == *currTrust {
+			continue
+		}
+		events = append(events, &hvs.NotificationEvent{
+			Type:            hvs.EventFlavorPartTrustChanged,
+			HostID:          hostId,
+			HostName:        hostName,
+			FlavorPart:      flavorPart.String(),
+			ReportID:        reportIdRef,
+			PreviousTrusted: prevTrust,
+			Trusted:         currTrust,
+		})
+	}
+	return events
+}
+
+// HostStateChangeEvent returns an event if the host state differs from the previous one, nil otherwise.
+// previous is nil when the host had no status yet.
+func HostStateChangeEvent(hostId uuid.UUID, previous *hvs.HostStatusInformation, current hvs.HostStatusInformation, hostName string) *hvs.NotificationEvent {
+	defaultLog.Trace("notification/events:HostStateChangeEvent() Entering")
+	defer defaultLog.Trace("notification/events:HostStateChangeEvent() Leaving")
+
+	event := &hvs.NotificationEvent{
+		Type:      hvs.EventHostStateChanged,
+		HostID:    hostId,
+		HostName:  hostName,
+		HostState: current.HostState.String(),
+	}
+	if previous != nil {
+		if previous.HostState == current.HostState {
+			return nil
+		}
+		event.PreviousHostState = previous.HostState.String()
+	}
+	return event
+}
+
+// flavorPartTrust returns the trust status of a flavor part in the report, or nil if the report has no
+// results for it
+func flavorPartTrust(report *hvs.TrustReport, flavorPart common.FlavorPart) *bool {
+	if len(report.GetResultsForMarker(flavorPart.String())) == 0 {
+		return nil
+	}
+	trusted := report.IsTrustedForMarker(flavorPart.String())
+	return &trusted
+}
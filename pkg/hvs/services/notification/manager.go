@@ -0,0 +1,291 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package notification
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	commLog "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+var defaultLog = commLog.GetDefaultLogger()
+
+// delivery is a single event to be posted to a single webhook subscription
+type delivery struct {
+	subscription hvs.NotificationSubscription
+	event        *hvs.NotificationEvent
+	payload      []byte
+}
+
+// listener is an event stream consumer registered through Subscribe
+type listener struct {
+	filter *hvs.NotificationSubscription
+	events chan *hvs.NotificationEvent
+}
+
+// Service dispatches notification events to the webhook subscriptions stored in the
+// NotificationSubscriptionStore and to the in-process event stream listeners.
+type Service struct {
+	cfg               NotificationConfig
+	subscriptionStore domain.NotificationSubscriptionStore
+	deadLetterStore   domain.NotificationDeadLetterStore
+	client            *http.Client
+
+	// events waiting to be matched against the webhook subscriptions
+	eventChan chan *hvs.NotificationEvent
+	// deliveries waiting to be posted by one of the delivery workers
+	deliveryChan chan delivery
+
+	listeners      map[uint64]*listener
+	listenerMtx    sync.RWMutex
+	nextListenerId uint64
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+func NewService(cfg NotificationConfig, subscriptionStore domain.NotificationSubscriptionStore,
+	deadLetterStore domain.NotificationDeadLetterStore, rootCAs *x509.CertPool) (*Service, error) {
+	defaultLog.Trace("notification/manager:NewService() Entering")
+	defer defaultLog.Trace("notification/manager:NewService() Leaving")
+
+	if subscriptionStore == nil || deadLetterStore == nil {
+		return nil, errors.New("notification/manager:NewService() Subscription and dead letter stores must be provided")
+	}
+	if cfg.NumberOfDeliveryWorkers <= 0 {
+		cfg.NumberOfDeliveryWorkers = DefaultNumberOfDeliveryWorkers
+	}
+	if cfg.MaxDeliveryAttempts <= 0 {
+		cfg.MaxDeliveryAttempts = DefaultMaxDeliveryAttempts
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = DefaultRetryBackoff
+	}
+	if cfg.DeliveryTimeout <= 0 {
+		cfg.DeliveryTimeout = DefaultDeliveryTimeout
+	}
+	if cfg.EventBufferSize <= 0 {
+		cfg.EventBufferSize = DefaultEventBufferSize
+	}
+
+	svc := &Service{
+		cfg:               cfg,
+		subscriptionStore: subscriptionStore,
+		deadLetterStore:   deadLetterStore,
+		client: &http.Client{
+			Timeout: cfg.DeliveryTimeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					MinVersion: tls.VersionTLS12,
+					RootCAs:    rootCAs,
+				},
+			},
+		},
+		eventChan:    make(chan *hvs.NotificationEvent, cfg.EventBufferSize),
+		deliveryChan: make(chan delivery, cfg.EventBufferSize),
+		listeners:    make(map[uint64]*listener),
+		quit:         make(chan struct{}),
+	}
+
+	svc.wg.Add(1)
+	go svc.dispatch()
+	for i := 0; i < cfg.NumberOfDeliveryWorkers; i++ {
+		svc.wg.Add(1)
+		go svc.deliver()
+	}
+	return svc, nil
+}
+
+// Shutdown stops the dispatcher and the delivery workers. Pending deliveries are dropped.
+func (svc *Service) Shutdown() error {
+	defaultLog.Trace("notification/manager:Shutdown() Entering")
+	defer defaultLog.Trace("notification/manager:Shutdown() Leaving")
+
+	close(svc.quit)
+	svc.wg.Wait()
+
+	svc.listenerMtx.Lock()
+	for id, l := range svc.listeners {
+		close(l.events)
+		delete(svc.listeners, id)
+	}
+	svc.listenerMtx.Unlock()
+	return nil
+}
+
+func (svc *Service) Publish(event *hvs.NotificationEvent) {
+	defaultLog.Trace("notification/manager:Publish() Entering")
+	defer defaultLog.Trace("notification/manager:Publish() Leaving")
+
+	if event == nil {
+		return
+	}
+	// the events published while the service shuts down are dropped
+	select {
+	case <-svc.quit:
+		return
+	default:
+	}
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	if event.Created.IsZero() {
+		event.Created = time.Now().UTC()
+	}
+
+	svc.listenerMtx.RLock()
+	for id, l := range svc.listeners {
+		if !l.filter.Matches(event) {
+			continue
+		}
+		select {
+		case l.events <- event:
+		default:
+			defaultLog.Warnf("notification/manager:Publish() Event stream listener %d is not keeping up, dropping event %s", id, event.ID)
+		}
+	}
+	svc.listenerMtx.RUnlock()
+
+	select {
+	case svc.eventChan <- event:
+	default:
+		defaultLog.Errorf("notification/manager:Publish() Notification queue is full, dropping event %s of type %s for host %s",
+			event.ID, event.Type, event.HostID)
+	}
+}
+
+func (svc *Service) Subscribe(filter *hvs.NotificationSubscription) (<-chan *hvs.NotificationEvent, func()) {
+	defaultLog.Trace("notification/manager:Subscribe() Entering")
+	defer defaultLog.Trace("notification/manager:Subscribe() Leaving")
+
+	if filter == nil {
+		filter = &hvs.NotificationSubscription{}
+	}
+	l := &listener{
+		filter: filter,
+		events: make(chan *hvs.NotificationEvent, svc.cfg.EventBufferSize),
+	}
+
+	svc.listenerMtx.Lock()
+	id := svc.nextListenerId
+	svc.nextListenerId++
+	svc.listeners[id] = l
+	svc.listenerMtx.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			svc.listenerMtx.Lock()
+			if _, ok := svc.listeners[id]; ok {
+				delete(svc.listeners, id)
+				close(l.events)
+			}
+			svc.listenerMtx.Unlock()
+		})
+	}
+	return l.events, unsubscribe
+}
+
+// dispatch matches queued events against the webhook subscriptions and hands them to the delivery workers
+func (svc *Service) dispatch() {
+	defaultLog.Trace("notification/manager:dispatch() Entering")
+	defer defaultLog.Trace("notification/manager:dispatch() Leaving")
+
+	defer svc.wg.Done()
+	for {
+		select {
+		case <-svc.quit:
+			return
+		case event := <-svc.eventChan:
+			subscriptions, err := svc.subscriptionStore.Search(nil)
+			if err != nil {
+				defaultLog.WithError(err).Errorf("notification/manager:dispatch() Failed to retrieve subscriptions for event %s", event.ID)
+				continue
+			}
+			payload, err := json.Marshal(event)
+			if err != nil {
+				defaultLog.WithError(err).Errorf("notification/manager:dispatch() Failed to marshal event %s", event.ID)
+				continue
+			}
+			for _, subscription := range subscriptions {
+				if !subscription.Matches(event) {
+					continue
+				}
+				select {
+				case svc.deliveryChan <- delivery{subscription: subscription, event: event, payload: payload}:
+				case <-svc.quit:
+					return
+				}
+			}
+		}
+	}
+}
+
+// deliver posts events to webhooks, retrying with exponential backoff. Events that cannot be delivered
+// are recorded in the dead letter store.
+func (svc *Service) deliver() {
+	defaultLog.Trace("notification/manager:deliver() Entering")
+	defer defaultLog.Trace("notification/manager:deliver() Leaving")
+
+	defer svc.wg.Done()
+	for {
+		select {
+		case <-svc.quit:
+			return
+		case d := <-svc.deliveryChan:
+			var err error
+			attempts := 0
+			backoff := svc.cfg.RetryBackoff
+			for attempts < svc.cfg.MaxDeliveryAttempts {
+				attempts++
+				if err = postEvent(svc.client, d.subscription, d.event, d.payload); err == nil {
+					break
+				}
+				defaultLog.WithError(err).Debugf("notification/manager:deliver() Attempt %d to deliver event %s to subscription %s failed",
+					attempts, d.event.ID, d.subscription.ID)
+				if attempts == svc.cfg.MaxDeliveryAttempts {
+					break
+				}
+				select {
+				case <-time.After(backoff):
+					backoff *= 2
+				case <-svc.quit:
+					return
+				}
+			}
+			if err != nil {
+				svc.deadLetter(d, attempts, err)
+			}
+		}
+	}
+}
+
+func (svc *Service) deadLetter(d delivery, attempts int, deliveryErr error) {
+	defaultLog.Trace("notification/manager:deadLetter() Entering")
+	defer defaultLog.Trace("notification/manager:deadLetter() Leaving")
+
+	defaultLog.WithError(deliveryErr).Warnf("notification/manager:deadLetter() Giving up delivery of event %s to subscription %s after %d attempts",
+		d.event.ID, d.subscription.ID, attempts)
+	_, err := svc.deadLetterStore.Create(&hvs.NotificationDeadLetter{
+		SubscriptionID: d.subscription.ID,
+		CallbackURL:    d.subscription.CallbackURL,
+		Event:          *d.event,
+		Attempts:       attempts,
+		LastError:      deliveryErr.Error(),
+	})
+	if err != nil {
+		defaultLog.WithError(err).Errorf("notification/manager:deadLetter() Failed to record undelivered event %s", d.event.ID)
+	}
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package notification_test
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/notification"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecret = "0123456789abcdef0123"
+
+func newTestService(t *testing.T, server *httptest.Server) (*notification.Service, *mocks.MockNotificationSubscriptionStore, *mocks.MockNotificationDeadLetterStore) {
+	subscriptionStore := mocks.NewMockNotificationSubscriptionStore()
+	existing, _ := subscriptionStore.Search(nil)
+	for _, s := range existing {
+		assert.NoError(t, subscriptionStore.Delete(s.ID))
+	}
+	deadLetterStore := mocks.NewMockNotificationDeadLetterStore()
+	existingDeadLetters, _ := deadLetterStore.Search(nil)
+	for _, dl := range existingDeadLetters {
+		assert.NoError(t, deadLetterStore.Delete(dl.ID))
+	}
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+	svc, err := notification.NewService(notification.NotificationConfig{
+		MaxDeliveryAttempts: 3,
+		RetryBackoff:        10 * time.Millisecond,
+		DeliveryTimeout:     time.Second,
+	}, subscriptionStore, deadLetterStore, rootCAs)
+	assert.NoError(t, err)
+	return svc, subscriptionStore, deadLetterStore
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	received := make(chan hvs.NotificationEvent, 1)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.True(t, notification.VerifySignature(testSecret, r.Header.Get(notification.HeaderTimestamp), body, r.Header.Get(notification.HeaderSignature)))
+		assert.False(t, notification.VerifySignature("another-secret-value", r.Header.Get(notification.HeaderTimestamp), body, r.Header.Get(notification.HeaderSignature)))
+
+		var event hvs.NotificationEvent
+		assert.NoError(t, json.Unmarshal(body, &event))
+		assert.Equal(t, event.ID.String(), r.Header.Get(notification.HeaderEventId))
+		received <- event
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	svc, subscriptionStore, deadLetterStore := newTestService(t, server)
+	defer svc.Shutdown()
+
+	hostId := uuid.New()
+	_, err := subscriptionStore.Create(&hvs.NotificationSubscription{
+		CallbackURL: server.URL,
+		Secret:      testSecret,
+		EventTypes:  []hvs.NotificationEventType{hvs.EventHostTrustChanged},
+	})
+	assert.NoError(t, err)
+
+	trusted := false
+	svc.Publish(&hvs.NotificationEvent{Type: hvs.EventHostStateChanged, HostID: hostId, HostState: "CONNECTED"})
+	svc.Publish(&hvs.NotificationEvent{Type: hvs.EventHostTrustChanged, HostID: hostId, Trusted: &trusted})
+
+	select {
+	case event := <-received:
+		assert.Equal(t, hvs.EventHostTrustChanged, event.Type)
+		assert.Equal(t, hostId, event.HostID)
+		assert.False(t, *event.Trusted)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Event was not delivered to the webhook")
+	}
+
+	deadLetters, err := deadLetterStore.Search(&models.NotificationDeadLetterFilterCriteria{})
+	assert.NoError(t, err)
+	assert.Empty(t, deadLetters)
+}
+
+func TestWebhookDeliveryDeadLetter(t *testing.T) {
+	var attempts int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	svc, subscriptionStore, deadLetterStore := newTestService(t, server)
+	defer svc.Shutdown()
+
+	subscription, err := subscriptionStore.Create(&hvs.NotificationSubscription{
+		CallbackURL: server.URL,
+		Secret:      testSecret,
+	})
+	assert.NoError(t, err)
+
+	svc.Publish(&hvs.NotificationEvent{Type: hvs.EventHostStateChanged, HostID: uuid.New(), HostState: "CONNECTION_FAILURE"})
+
+	var deadLetters []hvs.NotificationDeadLetter
+	for i := 0; i < 50 && len(deadLetters) == 0; i++ {
+		time.Sleep(100 * time.Millisecond)
+		deadLetters, err = deadLetterStore.Search(&models.NotificationDeadLetterFilterCriteria{SubscriptionId: subscription.ID})
+		assert.NoError(t, err)
+	}
+	assert.Len(t, deadLetters, 1)
+	assert.Equal(t, 3, deadLetters[0].Attempts)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+	assert.Contains(t, deadLetters[0].LastError, "503")
+}
+
+func TestTrustChangeEvents(t *testing.T) {
+	hostId := uuid.New()
+	reportId := uuid.New()
+
+	newReport := func(platformTrusted, osTrusted bool) *hvs.TrustReport {
+		report := &hvs.TrustReport{}
+		report.Results = []hvs.RuleResult{
+			{Rule: hvs.RuleInfo{Name: "platform", Markers: []common.FlavorPart{common.FlavorPartPlatform}}, Trusted: platformTrusted},
+			{Rule: hvs.RuleInfo{Name: "os", Markers: []common.FlavorPart{common.FlavorPartOs}}, Trusted: osTrusted},
+		}
+		if !platformTrusted {
+			report.Results[0].Faults = []hvs.Fault{{Name: "PcrValueMismatch"}}
+		}
+		if !osTrusted {
+			report.Results[1].Faults = []hvs.Fault{{Name: "PcrValueMismatch"}}
+		}
+		return report
+	}
+
+	// first report of a host only reports the overall trust status
+	events := notification.TrustChangeEvents(hostId, reportId, nil, newReport(true, true))
+	assert.Len(t, events, 1)
+	assert.Equal(t, hvs.EventHostTrustChanged, events[0].Type)
+	assert.Nil(t, events[0].PreviousTrusted)
+	assert.True(t, *events[0].Trusted)
+
+	// no change, no event
+	events = notification.TrustChangeEvents(hostId, reportId, newReport(true, true), newReport(true, true))
+	assert.Empty(t, events)
+
+	// OS flavor part becomes untrusted
+	events = notification.TrustChangeEvents(hostId, reportId, newReport(true, true), newReport(true, false))
+	assert.Len(t, events, 2)
+	assert.Equal(t, hvs.EventHostTrustChanged, events[0].Type)
+	assert.True(t, *events[0].PreviousTrusted)
+	assert.False(t, *events[0].Trusted)
+	assert.Equal(t, hvs.EventFlavorPartTrustChanged, events[1].Type)
+	assert.Equal(t, common.FlavorPartOs.String(), events[1].FlavorPart)
+	assert.Equal(t, reportId, *events[1].ReportID)
+}
+
+func TestSubscriptionMatches(t *testing.T) {
+	hostId := uuid.New()
+	subscription := hvs.NotificationSubscription{
+		EventTypes:  []hvs.NotificationEventType{hvs.EventFlavorPartTrustChanged},
+		HostIDs:     []uuid.UUID{hostId},
+		FlavorParts: []string{common.FlavorPartPlatform.String()},
+	}
+	assert.True(t, subscription.Matches(&hvs.NotificationEvent{Type: hvs.EventFlavorPartTrustChanged, HostID: hostId, FlavorPart: "PLATFORM"}))
+	assert.False(t, subscription.Matches(&hvs.NotificationEvent{Type: hvs.EventFlavorPartTrustChanged, HostID: hostId, FlavorPart: "OS"}))
+	assert.False(t, subscription.Matches(&hvs.NotificationEvent{Type: hvs.EventFlavorPartTrustChanged, HostID: uuid.New(), FlavorPart: "PLATFORM"}))
+	assert.False(t, subscription.Matches(&hvs.NotificationEvent{Type: hvs.EventHostTrustChanged, HostID: hostId}))
+	assert.True(t, (&hvs.NotificationSubscription{}).Matches(&hvs.NotificationEvent{Type: hvs.EventHostStateChanged, HostID: hostId}))
+}
@@ -0,0 +1,36 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package notification
+
+import "time"
+
+var (
+	// DefaultRetryBackoff is the delay before the first webhook delivery retry. The delay is doubled
+	// for each further attempt.
+	DefaultRetryBackoff, _ = time.ParseDuration("5s")
+	// DefaultDeliveryTimeout is the time allowed for a webhook endpoint to accept a single delivery
+	DefaultDeliveryTimeout, _ = time.ParseDuration("10s")
+)
+
+const (
+	DefaultNumberOfDeliveryWorkers = 5
+	DefaultMaxDeliveryAttempts     = 5
+	DefaultEventBufferSize         = 1000
+)
+
+type NotificationConfig struct {
+	// NumberOfDeliveryWorkers is the number of concurrent webhook deliveries
+	NumberOfDeliveryWorkers int `yaml:"number-of-delivery-workers" mapstructure:"number-of-delivery-workers"`
+	// MaxDeliveryAttempts is the number of times a delivery is attempted before the event is moved to the
+	// dead letter table
+	MaxDeliveryAttempts int `yaml:"max-delivery-attempts" mapstructure:"max-delivery-attempts"`
+	// RetryBackoff is the delay before the first retry, doubled on every subsequent attempt
+	RetryBackoff time.Duration `yaml:"retry-backoff" mapstructure:"retry-backoff"`
+	// DeliveryTimeout is the HTTP timeout for a single webhook delivery
+	DeliveryTimeout time.Duration `yaml:"delivery-timeout" mapstructure:"delivery-timeout"`
+	// EventBufferSize is the number of events that can be queued for dispatch, and per event stream listener
+	EventBufferSize int `yaml:"event-buffer-size" mapstructure:"event-buffer-size"`
+}
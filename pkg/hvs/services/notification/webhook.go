@@ -0,0 +1,85 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package notification
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+const (
+	// HeaderSignature carries the HMAC-SHA384 of the timestamp and the payload, keyed with the subscription secret
+	HeaderSignature = "X-Hvs-Signature"
+	// HeaderTimestamp carries the unix time at which the delivery was signed
+	HeaderTimestamp = "X-Hvs-Timestamp"
+	HeaderEventId   = "X-Hvs-Event-Id"
+	HeaderEventType = "X-Hvs-Event-Type"
+
+	signaturePrefix = "sha384="
+)
+
+// SignPayload computes the value of the signature header for a webhook delivery. Receivers should recompute it
+// over "<timestamp>.<raw body>" with the shared secret and compare the result in constant time.
+func SignPayload(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha512.New384, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks the signature header of a webhook delivery
+func VerifySignature(secret string, timestamp string, payload []byte, signature string) bool {
+	return hmac.Equal([]byte(SignPayload(secret, timestamp, payload)), []byte(signature))
+}
+
+// postEvent performs a single delivery attempt. Any non 2xx response is considered a failure.
+func postEvent(client *http.Client, subscription hvs.NotificationSubscription, event *hvs.NotificationEvent, payload []byte) error {
+	defaultLog.Trace("notification/webhook:postEvent() Entering")
+	defer defaultLog.Trace("notification/webhook:postEvent() Leaving")
+
+	req, err := http.NewRequest(http.MethodPost, subscription.CallbackURL, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "Failed to create webhook request")
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", constants.HTTPMediaTypeJson)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderEventId, event.ID.String())
+	req.Header.Set(HeaderEventType, string(event.Type))
+	if subscription.Secret != "" {
+		req.Header.Set(HeaderSignature, SignPayload(subscription.Secret, timestamp, payload))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to post event to webhook")
+	}
+	defer func() {
+		// drain the body so that the connection can be reused
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		derr := resp.Body.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing response body")
+		}
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.Errorf("Webhook responded with status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hrrs"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/tasks"
 	commConfig "github.com/intel-secl/intel-secl/v3/pkg/lib/common/config"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/setup"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -143,8 +144,8 @@ func (a *App) setupTaskRunner() (*setup.Runner, error) {
 	runner.AddTask("download-cert-tls", "tls", &setup.DownloadCert{
 		KeyFile:      viper.GetString("tls-key-file"),
 		CertFile:     viper.GetString("tls-cert-file"),
-		KeyAlgorithm: constants.DefaultKeyAlgorithm,
-		KeyLength:    constants.DefaultKeyLength,
+		KeyAlgorithm: viper.GetString("tls-key-algorithm"),
+		KeyLength:    viper.GetInt("tls-key-length"),
 		Subject: pkix.Name{
 			CommonName: viper.GetString("tls-common-name"),
 		},
@@ -211,8 +212,10 @@ func (a *App) downloadCertTask(certType string) setup.Task {
 	return &setup.DownloadCert{
 		KeyFile:      viper.GetString(certType + "-key-file"),
 		CertFile:     viper.GetString(certType + "-cert-file"),
-		KeyAlgorithm: constants.DefaultKeyAlgorithm,
-		KeyLength:    constants.DefaultKeyLength,
+		KeyAlgorithm: viper.GetString(certType + "-key-algorithm"),
+		KeyLength:    viper.GetInt(certType + "-key-length"),
+		// the SAML reports and the flavors are signed with RSA keys
+		KeyAlgorithms: []string{crypt.KeyTypeRSA, crypt.KeyTypeRSAPSS},
 		Subject: pkix.Name{
 			CommonName: viper.GetString(certType + "-common-name"),
 		},
@@ -10,6 +10,7 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/config"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hrrs"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/notification"
 	commConfig "github.com/intel-secl/intel-secl/v3/pkg/lib/common/config"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/setup"
 	"github.com/pkg/errors"
@@ -30,23 +31,30 @@ type UpdateServiceConfig struct {
 const envHelpPrompt = "Following environment variables are required for update-service-config setup:"
 
 var envHelp = map[string]string{
-	"SERVICE_USERNAME":                       "The service username as configured in AAS",
-	"SERVICE_PASSWORD":                       "The service password as configured in AAS",
-	"LOG_LEVEL":                              "Log level",
-	"LOG_MAX_LENGTH":                         "Max length of log statement",
-	"LOG_ENABLE_STDOUT":                      "Enable console log",
-	"AAS_BASE_URL":                           "AAS Base URL",
-	"HRRS_REFRESH_PERIOD":                    "Host report refresh service period",
-	"VCSS_REFRESH_PERIOD":                    "VCenter refresh service perion ",
-	"FVS_NUMBER_OF_VERIFIERS":                "NUmber of Flavor verification verifier threads",
-	"FVS_NUMBER_OF_DATA_FETCHERS":            "Number of Flavor verification data fetcher threads",
-	"FVS_SKIP_FLAVOR_SIGNATURE_VERIFICATION": "Skips flavor signature verification when set to true",
-	"SERVER_PORT":                            "The Port on which Server Listens to",
-	"SERVER_READ_TIMEOUT":                    "Request Read Timeout Duration in Seconds",
-	"SERVER_READ_HEADER_TIMEOUT":             "Request Read Header Timeout Duration in Seconds",
-	"SERVER_WRITE_TIMEOUT":                   "Request Write Timeout Duration in Seconds",
-	"SERVER_IDLE_TIMEOUT":                    "Request Idle Timeout in Seconds",
-	"SERVER_MAX_HEADER_BYTES":                "Max Length Of Request Header in Bytes ",
+	"SERVICE_USERNAME":                        "The service username as configured in AAS",
+	"SERVICE_PASSWORD":                        "The service password as configured in AAS",
+	"LOG_LEVEL":                               "Log level",
+	"LOG_MAX_LENGTH":                          "Max length of log statement",
+	"LOG_ENABLE_STDOUT":                       "Enable console log",
+	"AAS_BASE_URL":                            "AAS Base URL",
+	"HRRS_REFRESH_PERIOD":                     "Host report refresh service period",
+	"VCSS_REFRESH_PERIOD":                     "VCenter refresh service perion ",
+	"FVS_NUMBER_OF_VERIFIERS":                 "NUmber of Flavor verification verifier threads",
+	"FVS_NUMBER_OF_DATA_FETCHERS":             "Number of Flavor verification data fetcher threads",
+	"FVS_SKIP_FLAVOR_SIGNATURE_VERIFICATION":  "Skips flavor signature verification when set to true",
+	"NOTIFICATION_NUMBER_OF_DELIVERY_WORKERS": "Number of notification webhook delivery threads",
+	"NOTIFICATION_MAX_DELIVERY_ATTEMPTS":      "Number of attempts to deliver an event to a webhook before it is dead lettered",
+	"NOTIFICATION_RETRY_BACKOFF":              "Delay before the first retry of a failed webhook delivery, doubled on every retry",
+	"NOTIFICATION_DELIVERY_TIMEOUT":           "Timeout of a single webhook delivery attempt",
+	"NOTIFICATION_EVENT_BUFFER_SIZE":          "Number of events that can be queued for delivery",
+	"SIMULATOR_ENABLED":                       "Allows the registration of simulated hosts when set to true",
+	"SIMULATOR_PROFILE_DIR":                   "Directory of the simulated host profile files",
+	"SERVER_PORT":                             "The Port on which Server Listens to",
+	"SERVER_READ_TIMEOUT":                     "Request Read Timeout Duration in Seconds",
+	"SERVER_READ_HEADER_TIMEOUT":              "Request Read Header Timeout Duration in Seconds",
+	"SERVER_WRITE_TIMEOUT":                    "Request Write Timeout Duration in Seconds",
+	"SERVER_IDLE_TIMEOUT":                     "Request Idle Timeout in Seconds",
+	"SERVER_MAX_HEADER_BYTES":                 "Max Length Of Request Header in Bytes ",
 }
 
 func (uc UpdateServiceConfig) Run() error {
@@ -85,6 +93,17 @@ func (uc UpdateServiceConfig) Run() error {
 		NumberOfDataFetchers:            viper.GetInt(constants.FvsNumberOfDataFetchers),
 		SkipFlavorSignatureVerification: viper.GetBool(constants.FvsSkipFlavorSignatureVerification),
 	}
+	(*uc.AppConfig).Simulator = config.SimulatorConfig{
+		Enabled:    viper.GetBool(constants.SimulatorEnabled),
+		ProfileDir: viper.GetString(constants.SimulatorProfileDir),
+	}
+	(*uc.AppConfig).Notification = notification.NotificationConfig{
+		NumberOfDeliveryWorkers: viper.GetInt(constants.NotificationNumberOfDeliveryWorkers),
+		MaxDeliveryAttempts:     viper.GetInt(constants.NotificationMaxDeliveryAttempts),
+		RetryBackoff:            viper.GetDuration(constants.NotificationRetryBackoff),
+		DeliveryTimeout:         viper.GetDuration(constants.NotificationDeliveryTimeout),
+		EventBufferSize:         viper.GetInt(constants.NotificationEventBufferSize),
+	}
 
 	return nil
 }
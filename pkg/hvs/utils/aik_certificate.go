@@ -0,0 +1,135 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package utils
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// GetAikCertificateSerialNumber returns the serial number of the AIK certificate as recorded in the AIK certificate
+// inventory
+func GetAikCertificateSerialNumber(aik *x509.Certificate) string {
+	return aik.SerialNumber.Text(16)
+}
+
+// AikCertificateInventory reports the status of the AIK certificates from the inventory of the privacy CA
+type AikCertificateInventory struct {
+	Store domain.AikCertificateStore
+}
+
+// IsRevoked returns true when the AIK certificate is revoked in the inventory. The AIK certificates that are not in the
+// inventory, because they were issued before it was introduced, are not revoked.
+func (inventory *AikCertificateInventory) IsRevoked(aik *x509.Certificate) (bool, error) {
+	defaultLog.Trace("utils/aik_certificate:IsRevoked() Entering")
+	defer defaultLog.Trace("utils/aik_certificate:IsRevoked() Leaving")
+
+	revoked := true
+	aikCertificates, err := inventory.Store.Search(&models.AikCertificateFilterCriteria{
+		SerialNumberEqualTo: GetAikCertificateSerialNumber(aik),
+		Revoked:             &revoked,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "Error while searching the AIK certificate inventory")
+	}
+	return len(aikCertificates) > 0, nil
+}
+
+// oidExtensionReasonCode is the OID of the reason code extension of the CRL entries
+var oidExtensionReasonCode = asn1.ObjectIdentifier{2, 5, 29, 21}
+
+// CreateAikCrl creates the DER encoded CRL of the privacy CA listing the revoked AIK certificates. The CRL is valid for
+// the given duration from its creation time, which is the this update time of the CRL.
+func CreateAikCrl(revokedAikCertificates []hvs.AikCertificate, privacyCaCert *x509.Certificate, privacyCaKey crypto.Signer, validity time.Duration) ([]byte, error) {
+	defaultLog.Trace("utils/aik_certificate:CreateAikCrl() Entering")
+	defer defaultLog.Trace("utils/aik_certificate:CreateAikCrl() Leaving")
+
+	now := time.Now().UTC()
+	var revokedCerts []pkix.RevokedCertificate
+	for _, aikCertificate := range revokedAikCertificates {
+		if !aikCertificate.Revoked {
+			continue
+		}
+		serialNumber, ok := new(big.Int).SetString(aikCertificate.SerialNumber, 16)
+		if !ok {
+			return nil, errors.Errorf("Invalid serial number %s of AIK certificate %s", aikCertificate.SerialNumber, aikCertificate.ID)
+		}
+		entry := pkix.RevokedCertificate{
+			SerialNumber:   serialNumber,
+			RevocationTime: aikCertificate.Issued,
+		}
+		if aikCertificate.RevocationTime != nil {
+			entry.RevocationTime = *aikCertificate.RevocationTime
+		}
+		// RFC 5280 recommends leaving out the reason code when it is unspecified
+		if reasonCode, ok := aikCertificate.RevocationReason.CrlReasonCode(); ok && reasonCode != 0 {
+			value, err := asn1.Marshal(asn1.Enumerated(reasonCode))
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error while encoding the revocation reason of AIK certificate %s", aikCertificate.ID)
+			}
+			entry.Extensions = []pkix.Extension{{Id: oidExtensionReasonCode, Value: value}}
+		}
+		revokedCerts = append(revokedCerts, entry)
+	}
+
+	crl, err := privacyCaCert.CreateCRL(rand.Reader, privacyCaKey, revokedCerts, now, now.Add(validity))
+	if err != nil {
+		return nil, errors.Wrap(err, "Error while signing the CRL of the privacy CA")
+	}
+	return crl, nil
+}
+
+// AikCrlCache keeps the last CRL signed by the privacy CA, so that it is not signed again on every request. The CRL is
+// created again once invalidated by a revocation, or when half of its validity has elapsed.
+type AikCrlCache struct {
+	mtx          sync.Mutex
+	crl          []byte
+	refreshAfter time.Time
+}
+
+// NewAikCrlCache creates an empty AikCrlCache
+func NewAikCrlCache() *AikCrlCache {
+	return &AikCrlCache{}
+}
+
+// Get returns the cached CRL. When there is none or it is stale, create is called to sign a new CRL valid for the given
+// duration, which replaces the cached one.
+func (cache *AikCrlCache) Get(validity time.Duration, create func() ([]byte, error)) ([]byte, error) {
+	defaultLog.Trace("utils/aik_certificate:Get() Entering")
+	defer defaultLog.Trace("utils/aik_certificate:Get() Leaving")
+
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	now := time.Now()
+	if cache.crl != nil && now.Before(cache.refreshAfter) {
+		return cache.crl, nil
+	}
+	crl, err := create()
+	if err != nil {
+		return nil, err
+	}
+	cache.crl = crl
+	cache.refreshAfter = now.Add(validity / 2)
+	return crl, nil
+}
+
+// Invalidate drops the cached CRL, so that the next call to Get creates a new one
+func (cache *AikCrlCache) Invalidate() {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+	cache.crl = nil
+}
@@ -8,6 +8,8 @@ import (
 	"crypto"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/verifier"
+	"github.com/pkg/errors"
 )
 
 func LoadCertificates(certificatePaths *models.CertificatesPathStore) *models.CertificatesStore {
@@ -82,3 +84,30 @@ func loadKey(keyFile string) crypto.PrivateKey {
 	}
 	return key
 }
+
+// GetVerifierCertificates returns the certificates the verifier uses to verify the host manifests and the flavor
+// signatures. The intermediate CAs of the flavor signing certificate chain are added to the flavor CA certificates.
+func GetVerifierCertificates(certStore *models.CertificatesStore) (*verifier.VerifierCertificates, error) {
+	defaultLog.Trace("utils/certificate_store:GetVerifierCertificates() Entering")
+	defer defaultLog.Trace("utils/certificate_store:GetVerifierCertificates() Leaving")
+
+	rootCAs := (*certStore)[models.CaCertTypesRootCa.String()]
+	tagCAs := (*certStore)[models.CaCertTypesTagCa.String()]
+	privacyCAs := (*certStore)[models.CaCertTypesPrivacyCa.String()]
+	signingCerts := (*certStore)[models.CertTypesFlavorSigning.String()]
+	if rootCAs == nil || tagCAs == nil || privacyCAs == nil || signingCerts == nil || len(signingCerts.Certificates) == 0 {
+		return nil, errors.New("utils/certificate_store:GetVerifierCertificates() Verifier certificates are not loaded")
+	}
+
+	rootCApool := crypt.GetCertPool(rootCAs.Certificates)
+	for _, val := range signingCerts.Certificates[1:] {
+		rootCApool.AddCert(&val) //Add intermediate CA
+	}
+
+	return &verifier.VerifierCertificates{
+		PrivacyCACertificates:    crypt.GetCertPool(privacyCAs.Certificates),
+		AssetTagCACertificates:   crypt.GetCertPool(tagCAs.Certificates),
+		FlavorSigningCertificate: &signingCerts.Certificates[0],
+		FlavorCACertificates:     rootCApool,
+	}, nil
+}
@@ -0,0 +1,283 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// NewComplianceReport summarizes the trust status of the hosts from their latest report. The reports of the hosts
+// that are not listed are ignored, the hosts without report are listed as never attested. At most topFailingRules
+// rules are listed, ordered by the number of hosts they failed for.
+func NewComplianceReport(hosts []*hvs.Host, reports []models.HVSReport, topFailingRules int) *hvs.ComplianceReport {
+	defaultLog.Trace("utils/compliance_report:NewComplianceReport() Entering")
+	defer defaultLog.Trace("utils/compliance_report:NewComplianceReport() Leaving")
+
+	latestReports := make(map[uuid.UUID]*models.HVSReport)
+	for i := range reports {
+		latest, ok := latestReports[reports[i].HostID]
+		if !ok || reports[i].CreatedAt.After(latest.CreatedAt) {
+			latestReports[reports[i].HostID] = &reports[i]
+		}
+	}
+
+	sortedHosts := make([]*hvs.Host, len(hosts))
+	copy(sortedHosts, hosts)
+	sort.SliceStable(sortedHosts, func(i, j int) bool {
+		return sortedHosts[i].HostName < sortedHosts[j].HostName
+	})
+
+	complianceReport := &hvs.ComplianceReport{
+		Created:            time.Now().UTC(),
+		HostCount:          len(sortedHosts),
+		FlavorParts:        []hvs.FlavorPartCompliance{},
+		TopFailingRules:    []hvs.FailingRule{},
+		UntrustedHosts:     []hvs.ComplianceHost{},
+		HostsNeverAttested: []hvs.ComplianceHost{},
+	}
+	flavorParts := make(map[common.FlavorPart]*hvs.FlavorPartCompliance)
+	failingRules := make(map[string]*failingRuleCount)
+	for _, host := range sortedHosts {
+		report, ok := latestReports[host.Id]
+		if !ok {
+			complianceReport.NeverAttestedHostCount++
+			complianceReport.HostsNeverAttested = append(complianceReport.HostsNeverAttested, hvs.ComplianceHost{
+				HostID:   host.Id,
+				HostName: host.HostName,
+			})
+			continue
+		}
+
+		if report.TrustReport.Trusted {
+			complianceReport.TrustedHostCount++
+		} else {
+			complianceReport.UntrustedHostCount++
+			reportId := report.ID
+			createdAt := report.CreatedAt
+			complianceReport.UntrustedHosts = append(complianceReport.UntrustedHosts, hvs.ComplianceHost{
+				HostID:       host.Id,
+				HostName:     host.HostName,
+				ReportID:     &reportId,
+				LastAttested: &createdAt,
+			})
+		}
+
+		trustReport := hvs.NewTrustReport(report.TrustReport)
+		for _, flavorPart := range common.GetFlavorTypes() {
+			if len(trustReport.GetResultsForMarker(flavorPart.String())) == 0 {
+				continue
+			}
+			if _, ok := flavorParts[flavorPart]; !ok {
+				flavorParts[flavorPart] = &hvs.FlavorPartCompliance{FlavorPart: flavorPart.String()}
+			}
+			if trustReport.IsTrustedForMarker(flavorPart.String()) {
+				flavorParts[flavorPart].TrustedCount++
+			} else {
+				flavorParts[flavorPart].UntrustedCount++
+			}
+		}
+
+		countFailingRules(failingRules, host.Id, report.TrustReport.Results)
+	}
+
+	for _, flavorPart := range common.GetFlavorTypes() {
+		if flavorPartCompliance, ok := flavorParts[flavorPart]; ok {
+			complianceReport.FlavorParts = append(complianceReport.FlavorParts, *flavorPartCompliance)
+		}
+	}
+	complianceReport.TopFailingRules = topFailingRuleCounts(failingRules, topFailingRules)
+	return complianceReport
+}
+
+// failingRuleCount counts the hosts a rule failed for, rules are told apart by name and markers
+type failingRuleCount struct {
+	rule   hvs.FailingRule
+	hosts  map[uuid.UUID]bool
+	faults map[string]bool
+}
+
+func countFailingRules(failingRules map[string]*failingRuleCount, hostId uuid.UUID, results []hvs.RuleResult) {
+	for i := range results {
+		if results[i].Trusted {
+			continue
+		}
+		flavorPart := ruleResultFlavorPart(&results[i])
+		key := results[i].Rule.Name + "|" + flavorPart
+		count, ok := failingRules[key]
+		if !ok {
+			count = &failingRuleCount{
+				rule:   hvs.FailingRule{RuleName: results[i].Rule.Name, FlavorPart: flavorPart},
+				hosts:  make(map[uuid.UUID]bool),
+				faults: make(map[string]bool),
+			}
+			failingRules[key] = count
+		}
+		count.hosts[hostId] = true
+		for _, fault := range results[i].Faults {
+			count.faults[fault.Name] = true
+		}
+	}
+}
+
+func topFailingRuleCounts(failingRules map[string]*failingRuleCount, top int) []hvs.FailingRule {
+	rules := make([]hvs.FailingRule, 0, len(failingRules))
+	for _, count := range failingRules {
+		rule := count.rule
+		rule.HostCount = len(count.hosts)
+		for faultName := range count.faults {
+			rule.FaultNames = append(rule.FaultNames, faultName)
+		}
+		sort.Strings(rule.FaultNames)
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].HostCount != rules[j].HostCount {
+			return rules[i].HostCount > rules[j].HostCount
+		}
+		if rules[i].RuleName != rules[j].RuleName {
+			return rules[i].RuleName < rules[j].RuleName
+		}
+		return rules[i].FlavorPart < rules[j].FlavorPart
+	})
+	if top >= 0 && len(rules) > top {
+		rules = rules[:top]
+	}
+	return rules
+}
+
+var complianceReportHTMLTemplate = template.Must(template.New("compliance-report").Funcs(template.FuncMap{
+	"formatTime": formatComplianceTime,
+	"join":       strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Host Attestation Compliance Report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #999; padding: 4px 8px; text-align: left; }
+</style>
+</head>
+<body>
+<h1>Host Attestation Compliance Report</h1>
+<table>
+<tr><th>Generated</th><td>{{formatTime .Created}}</td></tr>
+<tr><th>From</th><td>{{if .FromDate}}{{formatTime .FromDate}}{{else}}-{{end}}</td></tr>
+<tr><th>To</th><td>{{if .ToDate}}{{formatTime .ToDate}}{{else}}-{{end}}</td></tr>
+<tr><th>Host selector</th><td>{{if .HostSelector}}{{.HostSelector}}{{else}}all hosts{{end}}</td></tr>
+<tr><th>Hosts</th><td>{{.HostCount}}</td></tr>
+<tr><th>Trusted hosts</th><td>{{.TrustedHostCount}}</td></tr>
+<tr><th>Untrusted hosts</th><td>{{.UntrustedHostCount}}</td></tr>
+<tr><th>Hosts never attested</th><td>{{.NeverAttestedHostCount}}</td></tr>
+</table>
+<h2>Trust status per flavor part</h2>
+<table>
+<tr><th>Flavor part</th><th>Trusted</th><th>Untrusted</th></tr>
+{{range .FlavorParts}}<tr><td>{{.FlavorPart}}</td><td>{{.TrustedCount}}</td><td>{{.UntrustedCount}}</td></tr>
+{{end}}</table>
+<h2>Top failing rules</h2>
+<table>
+<tr><th>Rule</th><th>Flavor part</th><th>Hosts</th><th>Faults</th></tr>
+{{range .TopFailingRules}}<tr><td>{{.RuleName}}</td><td>{{.FlavorPart}}</td><td>{{.HostCount}}</td><td>{{join .FaultNames ", "}}</td></tr>
+{{end}}</table>
+<h2>Untrusted hosts</h2>
+<table>
+<tr><th>Host</th><th>Host ID</th><th>Report ID</th><th>Last attested</th></tr>
+{{range .UntrustedHosts}}<tr><td>{{.HostName}}</td><td>{{.HostID}}</td><td>{{.ReportID}}</td><td>{{formatTime .LastAttested}}</td></tr>
+{{end}}</table>
+<h2>Hosts never attested</h2>
+<table>
+<tr><th>Host</th><th>Host ID</th></tr>
+{{range .HostsNeverAttested}}<tr><td>{{.HostName}}</td><td>{{.HostID}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// RenderComplianceReportHTML renders the compliance report in a HTML document
+func RenderComplianceReportHTML(report *hvs.ComplianceReport) ([]byte, error) {
+	defaultLog.Trace("utils/compliance_report:RenderComplianceReportHTML() Entering")
+	defer defaultLog.Trace("utils/compliance_report:RenderComplianceReportHTML() Leaving")
+
+	var document bytes.Buffer
+	if err := complianceReportHTMLTemplate.Execute(&document, report); err != nil {
+		return nil, errors.Wrap(err, "utils/compliance_report:RenderComplianceReportHTML() Error rendering compliance report")
+	}
+	return document.Bytes(), nil
+}
+
+// RenderComplianceReportPDF renders the compliance report in a PDF document
+func RenderComplianceReportPDF(report *hvs.ComplianceReport) ([]byte, error) {
+	defaultLog.Trace("utils/compliance_report:RenderComplianceReportPDF() Entering")
+	defer defaultLog.Trace("utils/compliance_report:RenderComplianceReportPDF() Leaving")
+
+	doc := newPdfDocument("Host Attestation Compliance Report")
+	doc.addHeading("Host Attestation Compliance Report")
+	doc.addLine(fmt.Sprintf("Generated: %s", formatComplianceTime(report.Created)))
+	fromDate, toDate := "-", "-"
+	if report.FromDate != nil {
+		fromDate = formatComplianceTime(report.FromDate)
+	}
+	if report.ToDate != nil {
+		toDate = formatComplianceTime(report.ToDate)
+	}
+	doc.addLine(fmt.Sprintf("Time window: %s to %s", fromDate, toDate))
+	hostSelector := report.HostSelector
+	if hostSelector == "" {
+		hostSelector = "all hosts"
+	}
+	doc.addLine(fmt.Sprintf("Host selector: %s", hostSelector))
+	doc.addLine(fmt.Sprintf("Hosts: %d, trusted: %d, untrusted: %d, never attested: %d", report.HostCount,
+		report.TrustedHostCount, report.UntrustedHostCount, report.NeverAttestedHostCount))
+
+	doc.addHeading("Trust status per flavor part")
+	for _, flavorPart := range report.FlavorParts {
+		doc.addLine(fmt.Sprintf("%s: trusted %d, untrusted %d", flavorPart.FlavorPart, flavorPart.TrustedCount,
+			flavorPart.UntrustedCount))
+	}
+
+	doc.addHeading("Top failing rules")
+	for _, rule := range report.TopFailingRules {
+		doc.addLine(fmt.Sprintf("%s [%s]: %d hosts, faults: %s", rule.RuleName, rule.FlavorPart, rule.HostCount,
+			strings.Join(rule.FaultNames, ", ")))
+	}
+
+	doc.addHeading("Untrusted hosts")
+	for _, host := range report.UntrustedHosts {
+		doc.addLine(fmt.Sprintf("%s (%s), report %s, last attested %s", host.HostName, host.HostID, host.ReportID,
+			formatComplianceTime(host.LastAttested)))
+	}
+
+	doc.addHeading("Hosts never attested")
+	for _, host := range report.HostsNeverAttested {
+		doc.addLine(fmt.Sprintf("%s (%s)", host.HostName, host.HostID))
+	}
+	return doc.bytes(), nil
+}
+
+// formatComplianceTime formats the time and *time.Time values of the compliance report documents
+func formatComplianceTime(t interface{}) string {
+	switch t := t.(type) {
+	case time.Time:
+		return t.UTC().Format(time.RFC3339)
+	case *time.Time:
+		if t != nil {
+			return t.UTC().Format(time.RFC3339)
+		}
+	}
+	return "-"
+}
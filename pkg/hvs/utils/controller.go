@@ -7,8 +7,12 @@ package utils
 
 import (
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
 	"github.com/pkg/errors"
+	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -43,3 +47,57 @@ func ParseDateQueryParam(dt string) (time.Time, error) {
 	}
 	return pTime, nil
 }
+
+// ParsePageQueryParams returns the limit and the cursor given in the limit and after query parameters of a
+// paginated search. The limit is 0 when the parameter is not set.
+func ParsePageQueryParams(params url.Values) (int, *models.PageCursor, error) {
+	defaultLog.Trace("utils/controller:ParsePageQueryParams() Entering")
+	defer defaultLog.Trace("utils/controller:ParsePageQueryParams() Leaving")
+
+	var limit int
+	if rowLimit := strings.TrimSpace(params.Get("limit")); rowLimit != "" {
+		var err error
+		limit, err = strconv.Atoi(rowLimit)
+		if err != nil || limit <= 0 {
+			return 0, nil, errors.New("Limit must be an integer > 0")
+		}
+	}
+
+	var after *models.PageCursor
+	if cursor := strings.TrimSpace(params.Get("after")); cursor != "" {
+		var err error
+		after, err = models.ParsePageCursor(cursor)
+		if err != nil {
+			return 0, nil, errors.Wrap(err, "Invalid after query parameter")
+		}
+	}
+	return limit, after, nil
+}
+
+// ParseLabelSelectorQueryParam returns the host label selector given in the labelSelector query parameter, nil when the
+// parameter is not set
+func ParseLabelSelectorQueryParam(params url.Values) (*models.LabelSelector, error) {
+	defaultLog.Trace("utils/controller:ParseLabelSelectorQueryParam() Entering")
+	defer defaultLog.Trace("utils/controller:ParseLabelSelectorQueryParam() Leaving")
+
+	selector := strings.TrimSpace(params.Get("labelSelector"))
+	if selector == "" {
+		return nil, nil
+	}
+	labelSelector, err := models.ParseLabelSelector(selector)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid labelSelector query parameter")
+	}
+	return labelSelector, nil
+}
+
+// NextPageLink returns the link to the page following a page of count records that ends at the cursor. There is no
+// next page, and the link is empty, when the page holds less records than the limit.
+func NextPageLink(r *http.Request, limit, count int, cursor models.PageCursor) string {
+	if limit <= 0 || count < limit {
+		return ""
+	}
+	query := r.URL.Query()
+	query.Set("after", cursor.String())
+	return r.URL.Path + "?" + query.Encode()
+}
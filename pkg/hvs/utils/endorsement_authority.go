@@ -0,0 +1,245 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package utils
+
+import (
+	"bytes"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"strings"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+const (
+	pemTypeCertificate = "CERTIFICATE"
+	pemTypeCrl         = "X509 CRL"
+)
+
+// ErrEkCertificateRevoked is returned when the EK certificate, or a CA certificate of its chain, is revoked by a CRL of
+// an endorsement authority
+var ErrEkCertificateRevoked = errors.New("EK certificate is revoked")
+
+// EndorsementAuthorityBundle holds the parsed certificates and CRLs of an endorsement authority
+type EndorsementAuthorityBundle struct {
+	Authority    hvs.EndorsementAuthority
+	Certificates []*x509.Certificate
+	Crls         []*pkix.CertificateList
+	// crlIssuers holds the certificate of the bundle that signed each CRL
+	crlIssuers []*x509.Certificate
+}
+
+// ParseEndorsementAuthority parses the certificate bundle and the CRLs of the endorsement authority. The bundle must only
+// contain CA certificates, and each CRL must be signed by one of them.
+func ParseEndorsementAuthority(ea hvs.EndorsementAuthority) (*EndorsementAuthorityBundle, error) {
+	defaultLog.Trace("utils/endorsement_authority:ParseEndorsementAuthority() Entering")
+	defer defaultLog.Trace("utils/endorsement_authority:ParseEndorsementAuthority() Leaving")
+
+	bundle := EndorsementAuthorityBundle{Authority: ea}
+	rest := []byte(ea.Certificates)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != pemTypeCertificate {
+			return nil, errors.Errorf("Unexpected PEM block %s in the certificates", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to parse certificate")
+		}
+		if !cert.BasicConstraintsValid || !cert.IsCA {
+			return nil, errors.Errorf("Certificate %s is not a CA certificate", cert.Subject.String())
+		}
+		bundle.Certificates = append(bundle.Certificates, cert)
+	}
+	if len(bundle.Certificates) == 0 {
+		return nil, errors.New("No PEM encoded certificate found")
+	}
+	if strings.TrimSpace(string(rest)) != "" {
+		return nil, errors.New("Certificates contain data that is not PEM encoded")
+	}
+
+	rest = []byte(ea.Crls)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != pemTypeCrl {
+			return nil, errors.Errorf("Unexpected PEM block %s in the CRLs", block.Type)
+		}
+		crl, err := x509.ParseDERCRL(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to parse CRL")
+		}
+		crlIssuer := crlIssuerName(crl)
+		var issuer *x509.Certificate
+		for _, cert := range bundle.Certificates {
+			if crlIssuer == cert.Subject.String() && cert.CheckCRLSignature(crl) == nil {
+				issuer = cert
+				break
+			}
+		}
+		if issuer == nil {
+			return nil, errors.Errorf("CRL issued by %s is not signed by a certificate of the bundle", crlIssuer)
+		}
+		bundle.Crls = append(bundle.Crls, crl)
+		bundle.crlIssuers = append(bundle.crlIssuers, issuer)
+	}
+	if strings.TrimSpace(string(rest)) != "" {
+		return nil, errors.New("CRLs contain data that is not PEM encoded")
+	}
+	return &bundle, nil
+}
+
+// isRevoked checks if the certificate is listed in a CRL of the bundle issued by the issuer of the certificate
+func (bundle *EndorsementAuthorityBundle) isRevoked(cert *x509.Certificate) bool {
+	for i, crl := range bundle.Crls {
+		if crlIssuerName(crl) != cert.Issuer.String() || cert.CheckSignatureFrom(bundle.crlIssuers[i]) != nil {
+			continue
+		}
+		for _, entry := range crl.TBSCertList.RevokedCertificates {
+			if entry.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// crlIssuerName returns the distinguished name of the issuer of the CRL, in the format of pkix.Name.String
+func crlIssuerName(crl *pkix.CertificateList) string {
+	var name pkix.Name
+	name.FillFromRDNSequence(&crl.TBSCertList.Issuer)
+	return name.String()
+}
+
+// pools returns the trust anchors and the intermediates of the bundle. The self-signed certificates are the trust
+// anchors, unless the bundle has none, in which case all its certificates are.
+func (bundle *EndorsementAuthorityBundle) pools() (*x509.CertPool, *x509.CertPool) {
+	roots := x509.NewCertPool()
+	intermediates := x509.NewCertPool()
+	rootCount := 0
+	for _, cert := range bundle.Certificates {
+		if bytes.Equal(cert.RawSubject, cert.RawIssuer) && cert.CheckSignatureFrom(cert) == nil {
+			roots.AddCert(cert)
+			rootCount++
+		} else {
+			intermediates.AddCert(cert)
+		}
+	}
+	if rootCount == 0 {
+		return intermediates, x509.NewCertPool()
+	}
+	return roots, intermediates
+}
+
+// EkCertificateVerifier establishes the trust of EK certificates. An EK certificate is trusted when it chains up to the
+// certificates of an enabled endorsement authority, or when it is signed by a certificate of the endorsement CA
+// directory. It is rejected when it, or a CA certificate of its chain, is revoked by a CRL of the endorsement
+// authorities.
+type EkCertificateVerifier struct {
+	Authorities        []EndorsementAuthorityBundle
+	EndorsementCaCerts []x509.Certificate
+}
+
+// NewEkCertificateVerifier creates an EkCertificateVerifier from the enabled endorsement authorities and the certificates
+// of the endorsement CA directory
+func NewEkCertificateVerifier(authorities []hvs.EndorsementAuthority, endorsementCaCerts []x509.Certificate) *EkCertificateVerifier {
+	defaultLog.Trace("utils/endorsement_authority:NewEkCertificateVerifier() Entering")
+	defer defaultLog.Trace("utils/endorsement_authority:NewEkCertificateVerifier() Leaving")
+
+	verifier := EkCertificateVerifier{EndorsementCaCerts: endorsementCaCerts}
+	for _, authority := range authorities {
+		if authority.Disabled {
+			continue
+		}
+		bundle, err := ParseEndorsementAuthority(authority)
+		if err != nil {
+			defaultLog.WithError(err).Errorf("utils/endorsement_authority:NewEkCertificateVerifier() Skipping invalid"+
+				" endorsement authority %s", authority.Name)
+			continue
+		}
+		verifier.Authorities = append(verifier.Authorities, *bundle)
+	}
+	return &verifier
+}
+
+// Verify returns the provenance of the EK certificate, or nil when it is not trusted by any endorsement authority or
+// endorsement CA certificate. ErrEkCertificateRevoked is returned when the certificate is revoked.
+func (verifier *EkCertificateVerifier) Verify(ekCert *x509.Certificate) (*hvs.EndorsementProvenance, error) {
+	defaultLog.Trace("utils/endorsement_authority:Verify() Entering")
+	defer defaultLog.Trace("utils/endorsement_authority:Verify() Leaving")
+
+	if verifier.isRevoked(ekCert) {
+		return nil, errors.Wrapf(ErrEkCertificateRevoked, "EK certificate with serial number %s issued by %s",
+			ekCert.SerialNumber.String(), ekCert.Issuer.String())
+	}
+
+	// the subject alternative name of EK certificates is critical and only holds a directory name, which is
+	// not handled by the x509 package
+	ek := *ekCert
+	ek.UnhandledCriticalExtensions = nil
+
+	for i := range verifier.Authorities {
+		bundle := &verifier.Authorities[i]
+		roots, intermediates := bundle.pools()
+		chains, err := ek.Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		})
+		if err != nil {
+			defaultLog.Debugf("utils/endorsement_authority:Verify() EK certificate is not trusted by endorsement"+
+				" authority %s: %v", bundle.Authority.Name, err)
+			continue
+		}
+		for _, chain := range chains {
+			for _, cert := range chain[1:] {
+				if verifier.isRevoked(cert) {
+					return nil, errors.Wrapf(ErrEkCertificateRevoked, "CA certificate %s of the EK certificate chain",
+						cert.Subject.String())
+				}
+			}
+		}
+		provenance := hvs.EndorsementProvenance{
+			Source:        hvs.EndorsementSourceAuthority,
+			AuthorityID:   bundle.Authority.ID,
+			AuthorityName: bundle.Authority.Name,
+			Verified:      time.Now().UTC(),
+		}
+		for _, cert := range chains[0][1:] {
+			provenance.Chain = append(provenance.Chain, cert.Subject.String())
+		}
+		return &provenance, nil
+	}
+
+	for i := range verifier.EndorsementCaCerts {
+		if err := ekCert.CheckSignatureFrom(&verifier.EndorsementCaCerts[i]); err == nil {
+			return &hvs.EndorsementProvenance{
+				Source:   hvs.EndorsementSourceEndorsementCa,
+				Chain:    []string{verifier.EndorsementCaCerts[i].Subject.String()},
+				Verified: time.Now().UTC(),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (verifier *EkCertificateVerifier) isRevoked(cert *x509.Certificate) bool {
+	for i := range verifier.Authorities {
+		if verifier.Authorities[i].isRevoked(cert) {
+			return true
+		}
+	}
+	return false
+}
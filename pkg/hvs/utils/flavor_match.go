@@ -0,0 +1,102 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package utils
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	cf "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	fm "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	model "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
+)
+
+// hostInfoDescriptionKeys maps the keys of the flavor description to the host info values they are compared with
+var hostInfoDescriptionKeys = map[string]func(hostInfo *model.HostInfo) string{
+	fm.BiosName:    func(hostInfo *model.HostInfo) string { return hostInfo.BiosName },
+	fm.BiosVersion: func(hostInfo *model.HostInfo) string { return hostInfo.BiosVersion },
+	fm.OsName:      func(hostInfo *model.HostInfo) string { return hostInfo.OSName },
+	fm.OsVersion:   func(hostInfo *model.HostInfo) string { return hostInfo.OSVersion },
+	fm.VmmName:     func(hostInfo *model.HostInfo) string { return hostInfo.VMMName },
+	fm.VmmVersion:  func(hostInfo *model.HostInfo) string { return hostInfo.VMMVersion },
+}
+
+// FlavorMatchesHostManifest returns true if the description of the flavor matches the host manifest, that is if the
+// flavor can be selected when verifying the host. Only the attributes that the flavor search of the verifier filters
+// on are compared, so a match does not mean the host is trusted against the flavor.
+func FlavorMatchesHostManifest(flavor *hvs.Flavor, hostManifest *types.HostManifest) bool {
+	defaultLog.Trace("utils/flavor_match:FlavorMatchesHostManifest() Entering")
+	defer defaultLog.Trace("utils/flavor_match:FlavorMatchesHostManifest() Leaving")
+
+	description := flavor.Meta.Description
+	hostInfo := &hostManifest.HostInfo
+	flavorPart := descriptionValue(description, fm.FlavorPart)
+
+	switch flavorPart {
+	case cf.FlavorPartSoftware.String():
+		return softwareLabelMatches(descriptionValue(description, fm.Label), hostManifest)
+	case cf.FlavorPartHostUnique.String(), cf.FlavorPartAssetTag.String():
+		hardwareUUID := descriptionValue(description, fm.HardwareUUID)
+		if hardwareUUID != "" && !strings.EqualFold(hardwareUUID, strings.TrimSpace(hostInfo.HardwareUUID)) {
+			return false
+		}
+	}
+
+	for key, hostInfoValue := range hostInfoDescriptionKeys {
+		value := descriptionValue(description, key)
+		if value != "" && value != strings.TrimSpace(hostInfoValue(hostInfo)) {
+			return false
+		}
+	}
+
+	// tboot is only considered for the linux hosts, and not for the asset tag flavors
+	if flavorPart != cf.FlavorPartAssetTag.String() && IsLinuxHost(hostInfo) {
+		tbootInstalled := descriptionValue(description, fm.TbootInstalled)
+		if tbootInstalled != "" && !strings.EqualFold(tbootInstalled, fmt.Sprint(hostInfo.TbootInstalled)) {
+			return false
+		}
+	}
+	return true
+}
+
+// softwareLabelMatches returns true if one of the measurements of the host has the label of the software flavor. As in
+// the verifier, all the software flavors match a host without measurements.
+func softwareLabelMatches(label string, hostManifest *types.HostManifest) bool {
+	if len(hostManifest.MeasurementXmls) == 0 {
+		return true
+	}
+	for _, measurementXml := range hostManifest.MeasurementXmls {
+		var measurement model.Measurement
+		if err := xml.Unmarshal([]byte(measurementXml), &measurement); err != nil {
+			defaultLog.WithError(err).Debug("utils/flavor_match:softwareLabelMatches() Failed to parse measurement xml")
+			return true
+		}
+		if measurement.Label == label {
+			return true
+		}
+	}
+	return false
+}
+
+// descriptionValue returns the value of a flavor description key as a string. The values are strings, except for
+// tboot_installed which can be a boolean or a pointer to a boolean when the flavor has not been serialized yet.
+func descriptionValue(description map[string]interface{}, key string) string {
+	switch value := description[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(value)
+	case *bool:
+		if value == nil {
+			return ""
+		}
+		return fmt.Sprint(*value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package utils
+
+//
+// This file contains a minimal PDF writer, rendering lines of text in A4 pages with the standard
+// Helvetica fonts, that do not need to be embedded in the document.
+//
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	pdfPageWidth       = 595.0
+	pdfPageHeight      = 842.0
+	pdfMargin          = 50.0
+	pdfFontSize        = 10.0
+	pdfHeadingSize     = 14.0
+	pdfLineSpacing     = 1.4
+	pdfAverageCharEm   = 0.5
+	pdfRegularFont     = "F1"
+	pdfBoldFont        = "F2"
+	pdfRegularFontName = "Helvetica"
+	pdfBoldFontName    = "Helvetica-Bold"
+)
+
+type pdfDocument struct {
+	title string
+	pages []*bytes.Buffer
+	// y is the vertical position of the next line in the current page
+	y float64
+}
+
+func newPdfDocument(title string) *pdfDocument {
+	return &pdfDocument{title: title}
+}
+
+// addHeading adds a line of bold text, separated from the previous line
+func (doc *pdfDocument) addHeading(text string) {
+	if len(doc.pages) > 0 {
+		doc.y -= pdfFontSize
+	}
+	doc.addText(text, pdfBoldFont, pdfHeadingSize)
+}
+
+// addLine adds a line of regular text, wrapped on several lines when it does not fit in the page width
+func (doc *pdfDocument) addLine(text string) {
+	doc.addText(text, pdfRegularFont, pdfFontSize)
+}
+
+func (doc *pdfDocument) addText(text, font string, size float64) {
+	maxChars := int((pdfPageWidth - 2*pdfMargin) / (size * pdfAverageCharEm))
+	for _, line := range wrapPdfText(text, maxChars) {
+		lineHeight := size * pdfLineSpacing
+		if len(doc.pages) == 0 || doc.y-lineHeight < pdfMargin {
+			doc.pages = append(doc.pages, &bytes.Buffer{})
+			doc.y = pdfPageHeight - pdfMargin
+		}
+		doc.y -= lineHeight
+		fmt.Fprintf(doc.pages[len(doc.pages)-1], "BT /%s %g Tf %g %g Td (%s) Tj ET\n", font, size, pdfMargin,
+			doc.y, escapePdfText(line))
+	}
+}
+
+// bytes returns the PDF document, its objects are the catalog, the page tree, the fonts, the document information
+// and for each page the page and its content stream
+func (doc *pdfDocument) bytes() []byte {
+	if len(doc.pages) == 0 {
+		doc.pages = append(doc.pages, &bytes.Buffer{})
+	}
+
+	const firstPageObject = 6
+	var objects []string
+	var kids []string
+	for i := range doc.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", firstPageObject+2*i))
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(doc.pages)),
+		fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", pdfRegularFontName),
+		fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", pdfBoldFontName),
+		fmt.Sprintf("<< /Title (%s) /Producer (%s) >>", escapePdfText(doc.title), escapePdfText("Host Verification Service")),
+	)
+	for i, page := range doc.pages {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %g %g] /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+				pdfPageWidth, pdfPageHeight, pdfRegularFont, pdfBoldFont, firstPageObject+2*i+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()),
+		)
+	}
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = pdf.Len()
+		fmt.Fprintf(&pdf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xrefOffset := pdf.Len()
+	fmt.Fprintf(&pdf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&pdf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&pdf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xrefOffset)
+	return pdf.Bytes()
+}
+
+// wrapPdfText splits the text in lines of at most maxChars characters, on spaces when possible. The text is split on
+// rune boundaries, so that multi-byte characters are not cut.
+func wrapPdfText(text string, maxChars int) []string {
+	var lines []string
+	runes := []rune(text)
+	for len(runes) > maxChars {
+		cut := maxChars
+		for cut > 0 && runes[cut] != ' ' {
+			cut--
+		}
+		if cut <= 0 {
+			cut = maxChars
+		}
+		lines = append(lines, string(runes[:cut]))
+		runes = []rune(strings.TrimLeft(string(runes[cut:]), " "))
+	}
+	return append(lines, string(runes))
+}
+
+// escapePdfText escapes the characters that delimit the PDF strings, and replaces the characters that are not
+// printable ASCII characters
+func escapePdfText(text string) string {
+	var escaped strings.Builder
+	for _, c := range text {
+		switch {
+		case c == '\\' || c == '(' || c == ')':
+			escaped.WriteRune('\\')
+			escaped.WriteRune(c)
+		case c < 0x20 || c > 0x7e:
+			escaped.WriteRune('?')
+		default:
+			escaped.WriteRune(c)
+		}
+	}
+	return escaped.String()
+}
@@ -0,0 +1,371 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+)
+
+// DiffReports computes what changed in a report compared to an older report of the same host: the outcome of
+// the rules, the PCR values per bank, the TPM event log entries per PCR and the host info fields of the host manifest
+func DiffReports(report, against *models.HVSReport) *hvs.ReportDiff {
+	defaultLog.Trace("utils/report_diff:DiffReports() Entering")
+	defer defaultLog.Trace("utils/report_diff:DiffReports() Leaving")
+
+	currentManifest := &report.TrustReport.HostManifest
+	againstManifest := &against.TrustReport.HostManifest
+	return &hvs.ReportDiff{
+		ReportID:        report.ID,
+		AgainstReportID: against.ID,
+		HostID:          report.HostID,
+		Trusted:         report.TrustReport.Trusted,
+		AgainstTrusted:  against.TrustReport.Trusted,
+		RuleResults:     diffRuleResults(report.TrustReport.Results, against.TrustReport.Results),
+		PcrValues:       diffPcrValues(&currentManifest.PcrManifest, &againstManifest.PcrManifest),
+		EventLogs:       diffEventLogs(&currentManifest.PcrManifest.PcrEventLogMap, &againstManifest.PcrManifest.PcrEventLogMap),
+		HostManifest:    diffHostManifest(currentManifest, againstManifest),
+	}
+}
+
+// ruleResultKeys identifies rule results by rule name, markers and PCR. Repeated rules are told apart by
+// the order in which they appear in the report
+func ruleResultKeys(results []hvs.RuleResult) []string {
+	keys := make([]string, len(results))
+	occurrences := make(map[string]int)
+	for i, result := range results {
+		var markers []string
+		for _, marker := range result.Rule.Markers {
+			markers = append(markers, marker.String())
+		}
+		key := result.Rule.Name + "|" + strings.Join(markers, ",")
+		if pcr := ruleResultPcr(&result); pcr != nil {
+			key = fmt.Sprintf("%s|%s|%d", key, pcr.Bank, pcr.Index)
+		}
+		occurrences[key]++
+		keys[i] = fmt.Sprintf("%s|%d", key, occurrences[key])
+	}
+	return keys
+}
+
+func ruleResultPcr(result *hvs.RuleResult) *types.Pcr {
+	switch {
+	case result.Rule.ExpectedPcr != nil:
+		return &result.Rule.ExpectedPcr.Pcr
+	case result.Rule.ExpectedPcrEventLogEntry != nil:
+		return &result.Rule.ExpectedPcrEventLogEntry.Pcr
+	case result.Rule.PCR != nil:
+		return result.Rule.PCR
+	}
+	return nil
+}
+
+func diffRuleResults(current, against []hvs.RuleResult) []hvs.RuleResultDiff {
+	defaultLog.Trace("utils/report_diff:diffRuleResults() Entering")
+	defer defaultLog.Trace("utils/report_diff:diffRuleResults() Leaving")
+
+	againstKeys := ruleResultKeys(against)
+	againstResults := make(map[string]*hvs.RuleResult)
+	for i := range against {
+		againstResults[againstKeys[i]] = &against[i]
+	}
+
+	var diffs []hvs.RuleResultDiff
+	for i, key := range ruleResultKeys(current) {
+		cur := current[i]
+		trusted := cur.Trusted
+		diff := hvs.RuleResultDiff{
+			RuleName: cur.Rule.Name,
+			Markers:  cur.Rule.Markers,
+			Pcr:      ruleResultPcr(&cur),
+			FlavorId: cur.FlavorId,
+			Trusted:  &trusted,
+		}
+
+		prev, ok := againstResults[key]
+		if !ok {
+			diff.Change = hvs.DiffAdded
+			diff.FaultsAdded = cur.Faults
+			diff.MismatchFieldsAdded = cur.MismatchField
+			diffs = append(diffs, diff)
+			continue
+		}
+		delete(againstResults, key)
+
+		againstTrusted := prev.Trusted
+		diff.Change = hvs.DiffChanged
+		diff.AgainstTrusted = &againstTrusted
+		diff.AgainstFlavorId = prev.FlavorId
+		diff.FaultsAdded = subtractFaults(cur.Faults, prev.Faults)
+		diff.FaultsResolved = subtractFaults(prev.Faults, cur.Faults)
+		diff.MismatchFieldsAdded = subtractMismatchFields(cur.MismatchField, prev.MismatchField)
+		diff.MismatchFieldsResolved = subtractMismatchFields(prev.MismatchField, cur.MismatchField)
+		if cur.Trusted != prev.Trusted || !reflect.DeepEqual(cur.FlavorId, prev.FlavorId) ||
+			len(diff.FaultsAdded) > 0 || len(diff.FaultsResolved) > 0 ||
+			len(diff.MismatchFieldsAdded) > 0 || len(diff.MismatchFieldsResolved) > 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	// whatever is left was only evaluated for the report compared against
+	for i, key := range againstKeys {
+		if _, ok := againstResults[key]; !ok {
+			continue
+		}
+		prev := against[i]
+		againstTrusted := prev.Trusted
+		diffs = append(diffs, hvs.RuleResultDiff{
+			Change:          hvs.DiffRemoved,
+			RuleName:        prev.Rule.Name,
+			Markers:         prev.Rule.Markers,
+			Pcr:             ruleResultPcr(&prev),
+			AgainstFlavorId: prev.FlavorId,
+			AgainstTrusted:  &againstTrusted,
+		})
+	}
+	return diffs
+}
+
+// subtractFaults returns the faults in 'faults' that are not part of 'faultsToSubtract'
+func subtractFaults(faults, faultsToSubtract []hvs.Fault) []hvs.Fault {
+	var result []hvs.Fault
+	for _, fault := range faults {
+		found := false
+		for _, f := range faultsToSubtract {
+			if reflect.DeepEqual(fault, f) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, fault)
+		}
+	}
+	return result
+}
+
+// subtractMismatchFields returns the mismatch fields in 'fields' that are not part of 'fieldsToSubtract'
+func subtractMismatchFields(fields, fieldsToSubtract []hvs.MismatchField) []hvs.MismatchField {
+	var result []hvs.MismatchField
+	for _, field := range fields {
+		found := false
+		for _, f := range fieldsToSubtract {
+			if reflect.DeepEqual(field, f) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, field)
+		}
+	}
+	return result
+}
+
+type pcrKey struct {
+	bank  string
+	index int
+}
+
+func sortedPcrKeys(keys map[pcrKey]bool) []pcrKey {
+	sorted := make([]pcrKey, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].bank != sorted[j].bank {
+			return sorted[i].bank < sorted[j].bank
+		}
+		return sorted[i].index < sorted[j].index
+	})
+	return sorted
+}
+
+func diffPcrValues(current, against *types.PcrManifest) []hvs.PcrValueDiff {
+	defaultLog.Trace("utils/report_diff:diffPcrValues() Entering")
+	defer defaultLog.Trace("utils/report_diff:diffPcrValues() Leaving")
+
+	pcrValues := func(pcrManifest *types.PcrManifest) map[pcrKey]string {
+		values := make(map[pcrKey]string)
+		for _, pcrs := range [][]types.HostManifestPcrs{pcrManifest.Sha1Pcrs, pcrManifest.Sha256Pcrs} {
+			for _, pcr := range pcrs {
+				values[pcrKey{bank: string(pcr.PcrBank), index: int(pcr.Index)}] = pcr.Value
+			}
+		}
+		return values
+	}
+	currentValues := pcrValues(current)
+	againstValues := pcrValues(against)
+
+	keys := make(map[pcrKey]bool)
+	for k := range currentValues {
+		keys[k] = true
+	}
+	for k := range againstValues {
+		keys[k] = true
+	}
+
+	var diffs []hvs.PcrValueDiff
+	for _, k := range sortedPcrKeys(keys) {
+		value, inCurrent := currentValues[k]
+		againstValue, inAgainst := againstValues[k]
+		diff := hvs.PcrValueDiff{
+			PcrBank:      types.SHAAlgorithm(k.bank),
+			PcrIndex:     types.PcrIndex(k.index),
+			Value:        value,
+			AgainstValue: againstValue,
+		}
+		switch {
+		case !inAgainst:
+			diff.Change = hvs.DiffAdded
+		case !inCurrent:
+			diff.Change = hvs.DiffRemoved
+		case value != againstValue:
+			diff.Change = hvs.DiffChanged
+		default:
+			continue
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
+
+func diffEventLogs(current, against *types.PcrEventLogMap) []hvs.EventLogDiff {
+	defaultLog.Trace("utils/report_diff:diffEventLogs() Entering")
+	defer defaultLog.Trace("utils/report_diff:diffEventLogs() Leaving")
+
+	eventLogs := func(eventLogMap *types.PcrEventLogMap) map[pcrKey]types.TpmEventLog {
+		logs := make(map[pcrKey]types.TpmEventLog)
+		for _, tpmEventLogs := range [][]types.TpmEventLog{eventLogMap.Sha1EventLogs, eventLogMap.Sha256EventLogs} {
+			for _, tpmEventLog := range tpmEventLogs {
+				logs[pcrKey{bank: tpmEventLog.Pcr.Bank, index: tpmEventLog.Pcr.Index}] = tpmEventLog
+			}
+		}
+		return logs
+	}
+	currentLogs := eventLogs(current)
+	againstLogs := eventLogs(against)
+
+	keys := make(map[pcrKey]bool)
+	for k := range currentLogs {
+		keys[k] = true
+	}
+	for k := range againstLogs {
+		keys[k] = true
+	}
+
+	var diffs []hvs.EventLogDiff
+	for _, k := range sortedPcrKeys(keys) {
+		pcr := types.Pcr{Index: k.index, Bank: k.bank}
+		currentLog, ok := currentLogs[k]
+		if !ok {
+			currentLog = types.TpmEventLog{Pcr: pcr}
+		}
+		againstLog, ok := againstLogs[k]
+		if !ok {
+			againstLog = types.TpmEventLog{Pcr: pcr}
+		}
+
+		added, modified, err := currentLog.Subtract(&againstLog)
+		if err != nil {
+			defaultLog.WithError(err).Errorf("utils/report_diff:diffEventLogs() Failed to compare event logs of PCR %d in bank %s", k.index, k.bank)
+			continue
+		}
+		removed, _, err := againstLog.Subtract(&currentLog)
+		if err != nil {
+			defaultLog.WithError(err).Errorf("utils/report_diff:diffEventLogs() Failed to compare event logs of PCR %d in bank %s", k.index, k.bank)
+			continue
+		}
+		if len(added.TpmEvent) == 0 && len(removed.TpmEvent) == 0 && len(modified.TpmEvent) == 0 {
+			continue
+		}
+		diffs = append(diffs, hvs.EventLogDiff{
+			Pcr:      pcr,
+			Added:    added.TpmEvent,
+			Removed:  removed.TpmEvent,
+			Modified: modified.TpmEvent,
+		})
+	}
+	return diffs
+}
+
+func diffHostManifest(current, against *types.HostManifest) []hvs.FieldDiff {
+	defaultLog.Trace("utils/report_diff:diffHostManifest() Entering")
+	defer defaultLog.Trace("utils/report_diff:diffHostManifest() Leaving")
+
+	fields := func(hostManifest *types.HostManifest) map[string]interface{} {
+		values := map[string]interface{}{
+			"asset_tag_digest": hostManifest.AssetTagDigest,
+		}
+		hostInfo, err := json.Marshal(hostManifest.HostInfo)
+		if err != nil {
+			defaultLog.WithError(err).Error("utils/report_diff:diffHostManifest() Failed to marshal host info")
+			return values
+		}
+		var hostInfoMap map[string]interface{}
+		if err = json.Unmarshal(hostInfo, &hostInfoMap); err != nil {
+			defaultLog.WithError(err).Error("utils/report_diff:diffHostManifest() Failed to unmarshal host info")
+			return values
+		}
+		flattenFields("host_info", hostInfoMap, values)
+		return values
+	}
+	currentFields := fields(current)
+	againstFields := fields(against)
+
+	names := make(map[string]bool)
+	for name := range currentFields {
+		names[name] = true
+	}
+	for name := range againstFields {
+		names[name] = true
+	}
+	sortedNames := make([]string, 0, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	var diffs []hvs.FieldDiff
+	for _, name := range sortedNames {
+		value, inCurrent := currentFields[name]
+		againstValue, inAgainst := againstFields[name]
+		diff := hvs.FieldDiff{
+			Field:        name,
+			Value:        value,
+			AgainstValue: againstValue,
+		}
+		switch {
+		case !inAgainst:
+			diff.Change = hvs.DiffAdded
+		case !inCurrent:
+			diff.Change = hvs.DiffRemoved
+		case !reflect.DeepEqual(value, againstValue):
+			diff.Change = hvs.DiffChanged
+		default:
+			continue
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
+
+// flattenFields adds the values of a nested json object to 'fields' using dot separated names
+func flattenFields(prefix string, values map[string]interface{}, fields map[string]interface{}) {
+	for name, value := range values {
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenFields(prefix+"."+name, nested, fields)
+			continue
+		}
+		fields[prefix+"."+name] = value
+	}
+}
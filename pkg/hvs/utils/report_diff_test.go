@@ -0,0 +1,223 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package utils
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+func pcrMatchesResult(index int, trusted bool, faults ...hvs.Fault) hvs.RuleResult {
+	return hvs.RuleResult{
+		Rule: hvs.RuleInfo{
+			Name:        "com.intel.mtwilson.core.verifier.policy.rule.PcrMatchesConstant",
+			Markers:     []common.FlavorPart{common.FlavorPartPlatform},
+			ExpectedPcr: &types.FlavorPcrs{Pcr: types.Pcr{Index: index, Bank: "SHA256"}},
+		},
+		Faults:  faults,
+		Trusted: trusted,
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestDiffRuleResults(t *testing.T) {
+	pcrMismatch := hvs.Fault{Name: "com.intel.mtwilson.core.verifier.policy.fault.PcrValueMismatchSHA256", Description: "PCR 0 mismatch"}
+	eventLogMismatch := hvs.Fault{Name: "com.intel.mtwilson.core.verifier.policy.fault.PcrEventLogMissingExpectedEntries", Description: "PCR 0 missing entries"}
+	pcr0 := &types.Pcr{Index: 0, Bank: "SHA256"}
+	pcr7 := &types.Pcr{Index: 7, Bank: "SHA256"}
+	markers := []common.FlavorPart{common.FlavorPartPlatform}
+
+	tests := []struct {
+		name    string
+		current []hvs.RuleResult
+		against []hvs.RuleResult
+		want    []hvs.RuleResultDiff
+	}{
+		{
+			name:    "unchanged rules are not reported",
+			current: []hvs.RuleResult{pcrMatchesResult(0, true)},
+			against: []hvs.RuleResult{pcrMatchesResult(0, true)},
+			want:    nil,
+		},
+		{
+			name:    "added rule",
+			current: []hvs.RuleResult{pcrMatchesResult(0, true), pcrMatchesResult(7, false, pcrMismatch)},
+			against: []hvs.RuleResult{pcrMatchesResult(0, true)},
+			want: []hvs.RuleResultDiff{{
+				Change:      hvs.DiffAdded,
+				RuleName:    "com.intel.mtwilson.core.verifier.policy.rule.PcrMatchesConstant",
+				Markers:     markers,
+				Pcr:         pcr7,
+				Trusted:     boolPtr(false),
+				FaultsAdded: []hvs.Fault{pcrMismatch},
+			}},
+		},
+		{
+			name:    "removed rule",
+			current: []hvs.RuleResult{pcrMatchesResult(0, true)},
+			against: []hvs.RuleResult{pcrMatchesResult(0, true), pcrMatchesResult(7, true)},
+			want: []hvs.RuleResultDiff{{
+				Change:         hvs.DiffRemoved,
+				RuleName:       "com.intel.mtwilson.core.verifier.policy.rule.PcrMatchesConstant",
+				Markers:        markers,
+				Pcr:            pcr7,
+				AgainstTrusted: boolPtr(true),
+			}},
+		},
+		{
+			name:    "rule becomes untrusted",
+			current: []hvs.RuleResult{pcrMatchesResult(0, false, pcrMismatch)},
+			against: []hvs.RuleResult{pcrMatchesResult(0, true)},
+			want: []hvs.RuleResultDiff{{
+				Change:         hvs.DiffChanged,
+				RuleName:       "com.intel.mtwilson.core.verifier.policy.rule.PcrMatchesConstant",
+				Markers:        markers,
+				Pcr:            pcr0,
+				Trusted:        boolPtr(false),
+				AgainstTrusted: boolPtr(true),
+				FaultsAdded:    []hvs.Fault{pcrMismatch},
+			}},
+		},
+		{
+			name:    "rule becomes trusted",
+			current: []hvs.RuleResult{pcrMatchesResult(0, true)},
+			against: []hvs.RuleResult{pcrMatchesResult(0, false, pcrMismatch)},
+			want: []hvs.RuleResultDiff{{
+				Change:         hvs.DiffChanged,
+				RuleName:       "com.intel.mtwilson.core.verifier.policy.rule.PcrMatchesConstant",
+				Markers:        markers,
+				Pcr:            pcr0,
+				Trusted:        boolPtr(true),
+				AgainstTrusted: boolPtr(false),
+				FaultsResolved: []hvs.Fault{pcrMismatch},
+			}},
+		},
+		{
+			name:    "faults change while the rule stays untrusted",
+			current: []hvs.RuleResult{pcrMatchesResult(0, false, eventLogMismatch)},
+			against: []hvs.RuleResult{pcrMatchesResult(0, false, pcrMismatch)},
+			want: []hvs.RuleResultDiff{{
+				Change:         hvs.DiffChanged,
+				RuleName:       "com.intel.mtwilson.core.verifier.policy.rule.PcrMatchesConstant",
+				Markers:        markers,
+				Pcr:            pcr0,
+				Trusted:        boolPtr(false),
+				AgainstTrusted: boolPtr(false),
+				FaultsAdded:    []hvs.Fault{eventLogMismatch},
+				FaultsResolved: []hvs.Fault{pcrMismatch},
+			}},
+		},
+		{
+			name:    "faults in a different order are not reported",
+			current: []hvs.RuleResult{pcrMatchesResult(0, false, eventLogMismatch, pcrMismatch)},
+			against: []hvs.RuleResult{pcrMatchesResult(0, false, pcrMismatch, eventLogMismatch)},
+			want:    nil,
+		},
+		{
+			name:    "repeated rules are matched by their order",
+			current: []hvs.RuleResult{pcrMatchesResult(0, true), pcrMatchesResult(0, false, pcrMismatch)},
+			against: []hvs.RuleResult{pcrMatchesResult(0, true), pcrMatchesResult(0, true)},
+			want: []hvs.RuleResultDiff{{
+				Change:         hvs.DiffChanged,
+				RuleName:       "com.intel.mtwilson.core.verifier.policy.rule.PcrMatchesConstant",
+				Markers:        markers,
+				Pcr:            pcr0,
+				Trusted:        boolPtr(false),
+				AgainstTrusted: boolPtr(true),
+				FaultsAdded:    []hvs.Fault{pcrMismatch},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, diffRuleResults(tt.current, tt.against))
+		})
+	}
+}
+
+func TestDiffReports(t *testing.T) {
+	hostID := uuid.New()
+	newReport := func(trusted bool, pcr0Value string, results ...hvs.RuleResult) *models.HVSReport {
+		return &models.HVSReport{
+			ID:     uuid.New(),
+			HostID: hostID,
+			TrustReport: hvs.TrustReport{
+				Results: results,
+				Trusted: trusted,
+				HostManifest: types.HostManifest{
+					PcrManifest: types.PcrManifest{
+						Sha256Pcrs: []types.HostManifestPcrs{{Index: types.PCR0, Value: pcr0Value, PcrBank: types.SHA256}},
+					},
+				},
+			},
+		}
+	}
+	fault := hvs.Fault{Name: "com.intel.mtwilson.core.verifier.policy.fault.PcrValueMismatchSHA256"}
+
+	tests := []struct {
+		name           string
+		report         *models.HVSReport
+		against        *models.HVSReport
+		wantTrusted    bool
+		wantAgainst    bool
+		wantRules      int
+		wantPcrChanges []hvs.PcrValueDiff
+	}{
+		{
+			name:        "no changes",
+			report:      newReport(true, "aa", pcrMatchesResult(0, true)),
+			against:     newReport(true, "aa", pcrMatchesResult(0, true)),
+			wantTrusted: true,
+			wantAgainst: true,
+		},
+		{
+			name:        "host becomes untrusted",
+			report:      newReport(false, "bb", pcrMatchesResult(0, false, fault)),
+			against:     newReport(true, "aa", pcrMatchesResult(0, true)),
+			wantTrusted: false,
+			wantAgainst: true,
+			wantRules:   1,
+			wantPcrChanges: []hvs.PcrValueDiff{{
+				Change: hvs.DiffChanged, PcrBank: types.SHA256, PcrIndex: types.PCR0, Value: "bb", AgainstValue: "aa",
+			}},
+		},
+		{
+			name:        "host becomes trusted",
+			report:      newReport(true, "aa", pcrMatchesResult(0, true)),
+			against:     newReport(false, "bb", pcrMatchesResult(0, false, fault)),
+			wantTrusted: true,
+			wantAgainst: false,
+			wantRules:   1,
+			wantPcrChanges: []hvs.PcrValueDiff{{
+				Change: hvs.DiffChanged, PcrBank: types.SHA256, PcrIndex: types.PCR0, Value: "aa", AgainstValue: "bb",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := DiffReports(tt.report, tt.against)
+			assert.Equal(t, tt.report.ID, diff.ReportID)
+			assert.Equal(t, tt.against.ID, diff.AgainstReportID)
+			assert.Equal(t, hostID, diff.HostID)
+			assert.Equal(t, tt.wantTrusted, diff.Trusted)
+			assert.Equal(t, tt.wantAgainst, diff.AgainstTrusted)
+			assert.Len(t, diff.RuleResults, tt.wantRules)
+			assert.Equal(t, tt.wantPcrChanges, diff.PcrValues)
+			assert.Empty(t, diff.EventLogs)
+			assert.Empty(t, diff.HostManifest)
+		})
+	}
+}
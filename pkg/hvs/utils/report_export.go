@@ -0,0 +1,59 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package utils
+
+import (
+	"strings"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+)
+
+// ExportReportRows flattens a report in one row per rule result, along with the host and the overall trust status
+// of the report. A report without rule results is flattened in a single row without rule.
+func ExportReportRows(report *models.HVSReport) []hvs.ReportExportRow {
+	hostInfo := &report.TrustReport.HostManifest.HostInfo
+	reportRow := hvs.ReportExportRow{
+		ReportID:     report.ID,
+		HostID:       report.HostID,
+		HostName:     hostInfo.HostName,
+		HardwareUUID: hostInfo.HardwareUUID,
+		CreatedAt:    report.CreatedAt,
+		Expiration:   report.Expiration,
+		Trusted:      report.TrustReport.Trusted,
+	}
+	if len(report.TrustReport.Results) == 0 {
+		return []hvs.ReportExportRow{reportRow}
+	}
+
+	rows := make([]hvs.ReportExportRow, 0, len(report.TrustReport.Results))
+	for i := range report.TrustReport.Results {
+		result := &report.TrustReport.Results[i]
+		row := reportRow
+		row.FlavorPart = ruleResultFlavorPart(result)
+		row.RuleName = result.Rule.Name
+		trusted := result.Trusted
+		row.RuleTrusted = &trusted
+		row.FlavorID = result.FlavorId
+		if row.FlavorID == nil {
+			row.FlavorID = result.Rule.FlavorID
+		}
+		for _, fault := range result.Faults {
+			row.FaultNames = append(row.FaultNames, fault.Name)
+			row.FaultDescriptions = append(row.FaultDescriptions, fault.Description)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// ruleResultFlavorPart returns the markers of the rule, separated by commas
+func ruleResultFlavorPart(result *hvs.RuleResult) string {
+	var markers []string
+	for _, marker := range result.Rule.Markers {
+		markers = append(markers, marker.String())
+	}
+	return strings.Join(markers, ",")
+}
@@ -0,0 +1,359 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvssim
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/clients/hvsclient"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvssim/version"
+	commLog "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
+	cf "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/simulator"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+var defaultLog = commLog.GetDefaultLogger()
+
+const (
+	bearerTokenEnv = "BEARER_TOKEN"
+	// goldenHostSuffix is appended to the host name prefix to name the simulated host the flavors are created from
+	goldenHostSuffix = "golden"
+)
+
+const helpStr = `Usage:
+
+hvs-sim <command> [arguments]
+
+Registers simulated hosts with HVS, to load test the host trust verification and the host report refresh.
+HVS must be configured with SIMULATOR_ENABLED=true. The bearer token is read from the BEARER_TOKEN environment variable.
+
+Available Commands:
+	flavors                Create the PLATFORM and OS flavors from a simulated host of the profile
+	register               Register the simulated hosts of the profile, and report the registration throughput
+	drift                  Inject the drift in the registered simulated hosts, or remove it with -drift ""
+	status                 Summarize the trust status of the registered simulated hosts
+	help|-h|--help         Show this help message
+	version                Print the current version
+
+Arguments:
+	-url                   HVS base URL, e.g. https://hvs.server:8443/hvs/v2
+	-ca-dir                Directory of the CA certificates trusted to connect to HVS
+	-profile               Simulated host profile (default rhel-suefi)
+	-prefix                Host name prefix of the simulated hosts (default sim-host)
+	-count                 Number of simulated hosts of register and drift (default 100)
+	-concurrency           Number of concurrent HVS requests of register and drift (default 10)
+	-flavorgroup           Flavorgroup of the flavors and the hosts (default automatic)
+	-drift                 Comma separated drifts of drift: bios-upgrade, event-log-change, event-log-tamper,
+	                       expired-aik
+`
+
+// options are the arguments of the commands
+type options struct {
+	url         string
+	caDir       string
+	profile     string
+	prefix      string
+	count       int
+	concurrency int
+	flavorgroup string
+	drifts      []simulator.Drift
+}
+
+func (opts *options) hostName(i int) string {
+	return fmt.Sprintf("%s-%06d", opts.prefix, i)
+}
+
+// Run runs the command of the arguments, printing its results to the writer
+func Run(args []string, w io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(w, helpStr)
+		return errors.New("The command is missing")
+	}
+	command := args[0]
+	switch command {
+	case "help", "-h", "--help":
+		fmt.Fprint(w, helpStr)
+		return nil
+	case "version", "-version", "--version":
+		fmt.Fprintln(w, "Current build version: ", version.Version)
+		fmt.Fprintln(w, "Build date: ", version.BuildDate)
+		return nil
+	}
+
+	opts, err := parseOptions(command, args[1:])
+	if err != nil {
+		return err
+	}
+	hostsClient, flavorsClient, err := newClients(opts)
+	if err != nil {
+		return err
+	}
+	switch command {
+	case "flavors":
+		return createFlavors(opts, flavorsClient, w)
+	case "register":
+		return registerHosts(opts, hostsClient, w)
+	case "drift":
+		return injectDrift(opts, hostsClient, w)
+	case "status":
+		return printStatus(opts, hostsClient, w)
+	}
+	return errors.Errorf("Unknown command %s", command)
+}
+
+func parseOptions(command string, args []string) (*options, error) {
+	opts := &options{}
+	var drift string
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	flags.StringVar(&opts.url, "url", "", "HVS base URL")
+	flags.StringVar(&opts.caDir, "ca-dir", "", "directory of the trusted CA certificates")
+	flags.StringVar(&opts.profile, "profile", simulator.ProfileRhelSuefi, "simulated host profile")
+	flags.StringVar(&opts.prefix, "prefix", "sim-host", "host name prefix")
+	flags.IntVar(&opts.count, "count", 100, "number of hosts")
+	flags.IntVar(&opts.concurrency, "concurrency", 10, "number of concurrent requests")
+	flags.StringVar(&opts.flavorgroup, "flavorgroup", models.FlavorGroupsAutomatic.String(), "flavorgroup name")
+	flags.StringVar(&drift, "drift", "", "comma separated drifts")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if opts.url == "" {
+		return nil, errors.New("The HVS base URL is required")
+	}
+	if opts.count < 1 || opts.concurrency < 1 {
+		return nil, errors.New("The count and the concurrency must be positive")
+	}
+	if opts.prefix == "" || strings.ContainsAny(opts.prefix, "/?;:") {
+		return nil, errors.Errorf("Invalid host name prefix '%s'", opts.prefix)
+	}
+	drifts, err := simulator.ParseDrift(drift)
+	if err != nil {
+		return nil, err
+	}
+	opts.drifts = drifts
+	return opts, nil
+}
+
+func newClients(opts *options) (hvsclient.HostsClient, hvsclient.FlavorsClient, error) {
+	bearerToken := os.Getenv(bearerTokenEnv)
+	if bearerToken == "" {
+		return nil, nil, errors.Errorf("%s is not set", bearerTokenEnv)
+	}
+	factory, err := hvsclient.NewVSClientFactory(opts.url, bearerToken, opts.caDir)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Error creating HVS client")
+	}
+	hostsClient, err := factory.HostsClient()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Error creating HVS hosts client")
+	}
+	flavorsClient, err := factory.FlavorsClient()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Error creating HVS flavors client")
+	}
+	return hostsClient, flavorsClient, nil
+}
+
+// createFlavors creates the PLATFORM and OS flavors of the golden host of the profile. All the hosts of the profile
+// have the same measurements, so they are trusted by these flavors until a drift is injected.
+func createFlavors(opts *options, flavorsClient hvsclient.FlavorsClient, w io.Writer) error {
+	defaultLog.Trace("hvssim/hvs_sim:createFlavors() Entering")
+	defer defaultLog.Trace("hvssim/hvs_sim:createFlavors() Leaving")
+
+	goldenHost := opts.prefix + "-" + goldenHostSuffix
+	flavors, err := flavorsClient.CreateFlavor(&models.FlavorCreateRequest{
+		ConnectionString: simulator.ConnectionString(goldenHost, opts.profile),
+		FlavorgroupNames: []string{opts.flavorgroup},
+		FlavorParts:      []cf.FlavorPart{cf.FlavorPartPlatform, cf.FlavorPartOs},
+	})
+	if err != nil {
+		return errors.Wrap(err, "Error creating flavors")
+	}
+	fmt.Fprintf(w, "Created %d flavors of profile %s in flavorgroup %s\n", len(flavors.Flavors), opts.profile,
+		opts.flavorgroup)
+	return nil
+}
+
+// registerHosts registers count hosts of the profile with concurrent requests, and prints the throughput and the
+// latency of the requests
+func registerHosts(opts *options, hostsClient hvsclient.HostsClient, w io.Writer) error {
+	defaultLog.Trace("hvssim/hvs_sim:registerHosts() Entering")
+	defer defaultLog.Trace("hvssim/hvs_sim:registerHosts() Leaving")
+
+	stats := runConcurrently(opts, func(i int) error {
+		hostName := opts.hostName(i)
+		_, err := hostsClient.CreateHost(&hvs.HostCreateRequest{
+			HostName:         hostName,
+			Description:      "Simulated host of profile " + opts.profile,
+			ConnectionString: simulator.ConnectionString(hostName, opts.profile, opts.drifts...),
+			FlavorgroupNames: []string{opts.flavorgroup},
+			Labels:           map[string]string{"simulator-profile": opts.profile},
+		})
+		return err
+	})
+	stats.print("Registered", w)
+	if stats.failed > 0 {
+		return errors.Errorf("%d host registrations failed", stats.failed)
+	}
+	return nil
+}
+
+// injectDrift updates the connection string of the first count registered hosts with the drift. The drift is seen
+// by HVS on the next trust verification of the hosts.
+func injectDrift(opts *options, hostsClient hvsclient.HostsClient, w io.Writer) error {
+	defaultLog.Trace("hvssim/hvs_sim:injectDrift() Entering")
+	defer defaultLog.Trace("hvssim/hvs_sim:injectDrift() Leaving")
+
+	hosts, err := simulatedHosts(opts, hostsClient)
+	if err != nil {
+		return err
+	}
+	if len(hosts) > opts.count {
+		hosts = hosts[:opts.count]
+	}
+	opts.count = len(hosts)
+
+	stats := runConcurrently(opts, func(i int) error {
+		host := hosts[i]
+		hostName, profile, _, err := simulator.ParseUrl(strings.SplitN(strings.TrimPrefix(host.ConnectionString,
+			"simulator:"), ";", 2)[0])
+		if err != nil {
+			return err
+		}
+		_, err = hostsClient.UpdateHost(&hvs.Host{
+			Id:               host.Id,
+			HostName:         host.HostName,
+			ConnectionString: simulator.ConnectionString(hostName, profile, opts.drifts...),
+		})
+		return err
+	})
+	stats.print("Updated", w)
+	if stats.failed > 0 {
+		return errors.Errorf("%d host updates failed", stats.failed)
+	}
+	return nil
+}
+
+// printStatus prints the number of trusted, untrusted and not yet verified simulated hosts
+func printStatus(opts *options, hostsClient hvsclient.HostsClient, w io.Writer) error {
+	defaultLog.Trace("hvssim/hvs_sim:printStatus() Entering")
+	defer defaultLog.Trace("hvssim/hvs_sim:printStatus() Leaving")
+
+	hosts, err := simulatedHosts(opts, hostsClient)
+	if err != nil {
+		return err
+	}
+	counts := make(map[bool]int)
+	for _, trusted := range []bool{true, false} {
+		trusted := trusted
+		it := hostsClient.IterateHosts(&models.HostFilterCriteria{NameContains: opts.prefix, Trusted: &trusted})
+		for it.Next() {
+			if strings.HasPrefix(it.Host().ConnectionString, "simulator:") {
+				counts[trusted]++
+			}
+		}
+		if it.Err() != nil {
+			return errors.Wrap(it.Err(), "Error searching hosts")
+		}
+	}
+	fmt.Fprintf(w, "Simulated hosts: %d, trusted: %d, untrusted: %d, not verified: %d\n", len(hosts), counts[true],
+		counts[false], len(hosts)-counts[true]-counts[false])
+	return nil
+}
+
+// simulatedHosts returns the registered simulated hosts of the host name prefix, ordered by host name
+func simulatedHosts(opts *options, hostsClient hvsclient.HostsClient) ([]hvs.Host, error) {
+	var hosts []hvs.Host
+	it := hostsClient.IterateHosts(&models.HostFilterCriteria{NameContains: opts.prefix})
+	for it.Next() {
+		host := it.Host()
+		if strings.HasPrefix(host.HostName, opts.prefix+"-") && strings.HasPrefix(host.ConnectionString, "simulator:") &&
+			host.HostName != opts.prefix+"-"+goldenHostSuffix {
+			hosts = append(hosts, *host)
+		}
+	}
+	if it.Err() != nil {
+		return nil, errors.Wrap(it.Err(), "Error searching hosts")
+	}
+	sort.Slice(hosts, func(i, j int) bool {
+		return hosts[i].HostName < hosts[j].HostName
+	})
+	return hosts, nil
+}
+
+// requestStats are the results of concurrent requests
+type requestStats struct {
+	succeeded int
+	failed    int
+	elapsed   time.Duration
+	latencies []time.Duration
+}
+
+// runConcurrently runs the request for 0 to count-1, with concurrency requests at a time
+func runConcurrently(opts *options, request func(i int) error) *requestStats {
+	stats := &requestStats{}
+	var lock sync.Mutex
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	start := time.Now()
+	for w := 0; w < opts.concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				requestStart := time.Now()
+				err := request(i)
+				latency := time.Since(requestStart)
+
+				lock.Lock()
+				if err != nil {
+					defaultLog.WithError(err).Errorf("hvssim/hvs_sim:runConcurrently() Request %d failed", i)
+					stats.failed++
+				} else {
+					stats.succeeded++
+					stats.latencies = append(stats.latencies, latency)
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+	for i := 0; i < opts.count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	stats.elapsed = time.Since(start)
+	return stats
+}
+
+// percentile returns the latency below which are p percent of the latencies of the successful requests
+func (stats *requestStats) percentile(p int) time.Duration {
+	if len(stats.latencies) == 0 {
+		return 0
+	}
+	return stats.latencies[(len(stats.latencies)-1)*p/100]
+}
+
+func (stats *requestStats) print(action string, w io.Writer) {
+	sort.Slice(stats.latencies, func(i, j int) bool {
+		return stats.latencies[i] < stats.latencies[j]
+	})
+	fmt.Fprintf(w, "%s %d hosts in %s, %d failed, %.1f hosts/s\n", action, stats.succeeded,
+		stats.elapsed.Round(time.Millisecond), stats.failed, float64(stats.succeeded)/stats.elapsed.Seconds())
+	fmt.Fprintf(w, "Latency p50: %s, p95: %s, p99: %s, max: %s\n", stats.percentile(50).Round(time.Millisecond),
+		stats.percentile(95).Round(time.Millisecond), stats.percentile(99).Round(time.Millisecond),
+		stats.percentile(100).Round(time.Millisecond))
+}
@@ -0,0 +1,16 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package version
+
+// Automatically filled in by linker
+
+// Version holds the build revision for the hvs-sim binary
+var Version = ""
+
+// GitHash holds the commit hash for the hvs-sim binary
+var GitHash = ""
+
+// BuildDate holds the build timestamp for the hvs-sim binary
+var BuildDate = ""
@@ -216,5 +216,34 @@ func (app *App) status() error {
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Env = os.Environ()
-	return cmd.Run()
+	err = cmd.Run()
+
+	app.printEndpointStatus()
+	return err
+}
+
+func (app *App) printEndpointStatus() {
+	statuses, err := loadEndpointStatus(app.homeDir())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			fmt.Fprintln(app.errorWriter(), "Could not read endpoint sync status:", err.Error())
+		}
+		return
+	}
+
+	fmt.Fprintln(app.consoleWriter(), "\nEndpoint sync status:")
+	for _, status := range statuses {
+		lastSync, lastSuccessfulSync := "never", "never"
+		if status.LastSyncTime != nil {
+			lastSync = status.LastSyncTime.Local().Format(time.RFC3339)
+		}
+		if status.LastSuccessfulSync != nil {
+			lastSuccessfulSync = status.LastSuccessfulSync.Local().Format(time.RFC3339)
+		}
+		fmt.Fprintf(app.consoleWriter(), "  %s (%s %s)\n    last sync: %s, last successful sync: %s, consecutive failures: %d\n",
+			status.Name, status.Type, status.URL, lastSync, lastSuccessfulSync, status.ConsecutiveFailures)
+		if status.LastError != "" {
+			fmt.Fprintf(app.consoleWriter(), "    last error: %s\n", status.LastError)
+		}
+	}
 }
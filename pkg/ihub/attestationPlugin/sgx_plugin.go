@@ -73,6 +73,9 @@ func initializeSKCClient(con *config.Configuration, certDirectory string) (*skch
 	log.Trace("attestationPlugin/sgx_plugin:initializeSKCClient() Entering")
 	defer log.Trace("attestationPlugin/sgx_plugin:initializeSKCClient() Leaving")
 
+	clientMutex.Lock()
+	defer clientMutex.Unlock()
+
 	if SGXClient != nil && SGXClient.AASURL != nil && SGXClient.BaseURL != nil {
 		return SGXClient, nil
 	}
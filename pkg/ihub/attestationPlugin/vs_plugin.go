@@ -5,6 +5,7 @@
 package attestationPlugin
 
 import (
+	"bytes"
 	"crypto/x509"
 	"encoding/xml"
 	"fmt"
@@ -16,7 +17,12 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/os"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/saml"
 	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
 	"net/url"
+	"strconv"
+	"sync"
+	"time"
 )
 
 var log = commonLog.GetDefaultLogger()
@@ -24,9 +30,17 @@ var log = commonLog.GetDefaultLogger()
 //CertArray Array of Certificates
 var CertArray []x509.Certificate
 
+// ErrTooManyUpdatedReports is returned when the updated reports exceed the change feed limit, in which case they
+// cannot all be retrieved in one query
+var ErrTooManyUpdatedReports = errors.New("Number of updated reports exceeds the change feed limit")
+
 //VsClient Client for VS
 var VsClient = &vs.Client{}
 
+// clientMutex guards the initialization of VsClient, SGXClient and CertArray, which are shared by the syncers of
+// all the endpoints
+var clientMutex sync.Mutex
+
 //loadCertificates method is used to read the certificates from files
 func loadCertificates(certDirectory string) error {
 	log.Trace("attestationPlugin/vs_plugin:loadCertificates() Entering")
@@ -52,6 +66,9 @@ func initializeClient(con *config.Configuration, certDirectory string) (*vs.Clie
 	log.Trace("attestationPlugin/vs_plugin:initializeClient() Entering")
 	defer log.Trace("attestationPlugin/vs_plugin:initializeClient() Leaving")
 
+	clientMutex.Lock()
+	defer clientMutex.Unlock()
+
 	if VsClient != nil && VsClient.AASURL != nil && VsClient.BaseURL != nil {
 		return VsClient, nil
 	}
@@ -132,6 +149,93 @@ func GetHostReports(h string, conf *config.Configuration, certDirectory, samlCer
 	return samlReportUnmarshalled, nil
 }
 
+//GetUpdatedHostReports method is used to retrieve the latest SAML reports of the hosts attested since fromDate
+func GetUpdatedHostReports(fromDate time.Time, conf *config.Configuration, certDirectory, samlCertPath string) ([]*saml.Saml, error) {
+	log.Trace("attestationPlugin/vs_plugin:GetUpdatedHostReports() Entering")
+	defer log.Trace("attestationPlugin/vs_plugin:GetUpdatedHostReports() Leaving")
+
+	reportUrl := conf.AttestationService.AttestationURL + "/reports?latestPerHost=true&fromDate=" +
+		url.QueryEscape(fromDate.UTC().Format(time.RFC3339Nano)) + "&limit=" + strconv.Itoa(constants.ChangeFeedReportLimit)
+
+	log.Debug("attestationPlugin/vs_plugin:GetUpdatedHostReports() Reports URL : " + reportUrl)
+
+	vClient, err := initializeClient(conf, certDirectory)
+	if err != nil {
+		return nil, errors.Wrap(err, "attestationPlugin/vs_plugin:GetUpdatedHostReports() Error in initializing vsclient")
+	}
+
+	samlReportBytes, err := vClient.GetSamlReports(reportUrl)
+	if err != nil {
+		return nil, errors.Wrap(err, "attestationPlugin/vs_plugin:GetUpdatedHostReports() Error in fetching SAML reports")
+	}
+
+	samlAssertions, err := splitSamlReports(samlReportBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "attestationPlugin/vs_plugin:GetUpdatedHostReports() Error in parsing SAML reports")
+	}
+	if len(samlAssertions) == 0 {
+		return nil, nil
+	}
+	if len(samlAssertions) >= constants.ChangeFeedReportLimit {
+		return nil, ErrTooManyUpdatedReports
+	}
+
+	samlCertPem, err := ioutil.ReadFile(samlCertPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "attestationPlugin/vs_plugin:GetUpdatedHostReports() Error in reading SAML certificate")
+	}
+
+	var samlReports []*saml.Saml
+	for _, samlAssertion := range samlAssertions {
+		var samlReport *saml.Saml
+		err = xml.Unmarshal([]byte(samlAssertion), &samlReport)
+		if err != nil {
+			log.WithError(err).Error("attestationPlugin/vs_plugin:GetUpdatedHostReports() Error unmarshalling SAML report, skipping")
+			continue
+		}
+		if !saml.VerifySamlSignatureWithCertPem(samlAssertion, samlCertPem, certDirectory) {
+			log.Errorf("attestationPlugin/vs_plugin:GetUpdatedHostReports() SAML verification failed for report of host %s, skipping",
+				GetSamlAttribute(samlReport, constants.SamlHostNameAttribute))
+			continue
+		}
+		samlReports = append(samlReports, samlReport)
+	}
+	return samlReports, nil
+}
+
+// splitSamlReports splits the SAML assertions concatenated in a report search response
+func splitSamlReports(samlReportBytes []byte) ([]string, error) {
+	var samlAssertions []string
+	decoder := xml.NewDecoder(bytes.NewReader(samlReportBytes))
+	for {
+		start := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return samlAssertions, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := token.(xml.StartElement); !ok {
+			continue
+		}
+		if err = decoder.Skip(); err != nil {
+			return nil, err
+		}
+		samlAssertions = append(samlAssertions, string(samlReportBytes[start:decoder.InputOffset()]))
+	}
+}
+
+// GetSamlAttribute returns the value of an attribute of the SAML report, or an empty string if not present
+func GetSamlAttribute(samlReport *saml.Saml, name string) string {
+	for _, attribute := range samlReport.Attribute {
+		if attribute.Name == name {
+			return attribute.AttributeValue
+		}
+	}
+	return ""
+}
+
 // GetCaCerts method is used to get all the CA certs of HVS
 func GetCaCerts(domain string, conf *config.Configuration, certDirectory string) ([]byte, error) {
 	log.Trace("attestationPlugin/vs_plugin:GetCaCerts() Entering")
@@ -9,6 +9,7 @@ import (
 	"encoding/xml"
 	"io/ioutil"
 	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/intel-secl/intel-secl/v3/pkg/clients/vs"
@@ -300,3 +301,85 @@ func Test_initializeClient(t *testing.T) {
 		})
 	}
 }
+
+func Test_initializeClientConcurrentEndpoints(t *testing.T) {
+	server, portString := testutility.MockServer(t)
+	defer func() {
+		derr := server.Close()
+		if derr != nil {
+			t.Errorf("Error closing mock server: %v", derr)
+		}
+	}()
+
+	c := testutility.SetupMockK8sConfiguration(t, portString)
+	c.Endpoints = []config.Endpoint{
+		{
+			Name: "openstack-region-1",
+			Type: "OPENSTACK",
+			URL:  "http://localhost" + portString + "/openstack/",
+		},
+	}
+	endpoints := c.GetEndpoints()
+	if len(endpoints) != 2 {
+		t.Fatalf("attestationPlugin/vs_plugin_test:initializeClient() expected 2 endpoints, got %d", len(endpoints))
+	}
+
+	VsClient = &vs.Client{}
+	clients := make([]*vs.Client, len(endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func(i int, endpointConfig *config.Configuration) {
+			defer wg.Done()
+			client, err := initializeClient(endpointConfig, "")
+			if err != nil {
+				t.Errorf("attestationPlugin/vs_plugin_test:initializeClient() Error in initializing client : %v", err)
+				return
+			}
+			clients[i] = client
+		}(i, c.ForEndpoint(endpoint))
+	}
+	wg.Wait()
+
+	for i, client := range clients {
+		if client == nil || client.AASURL == nil || client.BaseURL == nil {
+			t.Fatalf("attestationPlugin/vs_plugin_test:initializeClient() client of endpoint %d is not initialized: %+v", i, client)
+		}
+	}
+	if clients[0] != clients[1] {
+		t.Error("attestationPlugin/vs_plugin_test:initializeClient() endpoints should share the same client")
+	}
+}
+
+func TestSplitSamlReports(t *testing.T) {
+	report, err := ioutil.ReadFile(sampleSamlReportPath)
+	if err != nil {
+		t.Fatalf("attestationPlugin/vs_plugin_test:TestSplitSamlReports() : Unable to read file: %v", err)
+	}
+
+	samlAssertions, err := splitSamlReports(append(append(report, '\n'), report...))
+	if err != nil {
+		t.Fatalf("attestationPlugin/vs_plugin_test:TestSplitSamlReports() : Unexpected error: %v", err)
+	}
+	if len(samlAssertions) != 2 {
+		t.Fatalf("attestationPlugin/vs_plugin_test:TestSplitSamlReports() : Expected 2 reports, got %d", len(samlAssertions))
+	}
+	for _, samlAssertion := range samlAssertions {
+		if samlAssertion != string(report) {
+			t.Errorf("attestationPlugin/vs_plugin_test:TestSplitSamlReports() : Report is not split at the assertion boundaries")
+		}
+	}
+
+	samlReport := &saml.Saml{}
+	if err = xml.Unmarshal([]byte(samlAssertions[1]), samlReport); err != nil {
+		t.Fatalf("attestationPlugin/vs_plugin_test:TestSplitSamlReports() : Unable to unmarshal report: %v", err)
+	}
+	if GetSamlAttribute(samlReport, "HostName") == "" || GetSamlAttribute(samlReport, "HardwareUUID") == "" {
+		t.Errorf("attestationPlugin/vs_plugin_test:TestSplitSamlReports() : Host attributes not found in report")
+	}
+
+	samlAssertions, err = splitSamlReports(nil)
+	if err != nil || len(samlAssertions) != 0 {
+		t.Errorf("attestationPlugin/vs_plugin_test:TestSplitSamlReports() : Expected no reports for empty response")
+	}
+}
@@ -6,8 +6,11 @@ package config
 
 import (
 	"github.com/intel-secl/intel-secl/v3/pkg/ihub/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/search"
 	log "github.com/sirupsen/logrus"
 	"os"
+	"strings"
+	"time"
 
 	commConfig "github.com/intel-secl/intel-secl/v3/pkg/lib/common/config"
 	"github.com/pkg/errors"
@@ -26,7 +29,11 @@ type Configuration struct {
 	IHUB               commConfig.ServiceConfig `yaml:"ihub" mapstructure:"ihub"`
 	AttestationService AttestationConfig        `yaml:"attestation-service" mapstructure:"attestation-service"`
 	Endpoint           Endpoint                 `yaml:"end-point" mapstructure:"end-point"`
+	ChangeFeed         ChangeFeedConfig         `yaml:"change-feed" mapstructure:"change-feed"`
 	TLS                commConfig.TLSCertConfig `yaml:"tls" mapstructure:"tls"`
+
+	// Endpoints lists further tenants to be synced by the same IHUB instance, next to Endpoint
+	Endpoints []Endpoint `yaml:"end-points,omitempty" mapstructure:"end-points"`
 }
 
 type AttestationConfig struct {
@@ -34,7 +41,23 @@ type AttestationConfig struct {
 	AttestationType string `yaml:"attestation-type" mapstructure:"attestation-type"`
 }
 
+// ChangeFeedConfig enables pushing only the hosts whose reports were updated in HVS since the previous sync, with
+// a full sync of all the hosts at every reconciliation interval
+type ChangeFeedConfig struct {
+	Enabled                       bool `yaml:"enabled" mapstructure:"enabled"`
+	ReconciliationIntervalMinutes int  `yaml:"reconciliation-interval-minutes" mapstructure:"reconciliation-interval-minutes"`
+}
+
+// GetReconciliationInterval returns the interval between full syncs, the default is used when not configured
+func (c ChangeFeedConfig) GetReconciliationInterval() time.Duration {
+	if c.ReconciliationIntervalMinutes <= 0 {
+		return time.Minute * constants.DefaultReconciliationIntervalMinutes
+	}
+	return time.Minute * time.Duration(c.ReconciliationIntervalMinutes)
+}
+
 type Endpoint struct {
+	Name     string `yaml:"name,omitempty" mapstructure:"name"`
 	Type     string `yaml:"type" mapstructure:"type"`
 	URL      string `yaml:"url" mapstructure:"url"`
 	CRDName  string `yaml:"crd-name" mapstructure:"crd-name"`
@@ -43,6 +66,55 @@ type Endpoint struct {
 	Password string `yaml:"password" mapstructure:"password"`
 	AuthURL  string `yaml:"auth-url" mapstructure:"auth-url"`
 	CertFile string `yaml:"cert-file" mapstructure:"cert-file"`
+	// HostFilters restricts the hosts pushed to the tenant to the ones whose name matches one of the wildcard
+	// patterns, all the hosts are pushed when empty
+	HostFilters []string `yaml:"host-filters,omitempty" mapstructure:"host-filters"`
+	// PollIntervalMinutes overrides the global poll interval for this endpoint when set
+	PollIntervalMinutes int `yaml:"poll-interval-minutes,omitempty" mapstructure:"poll-interval-minutes"`
+}
+
+// GetName returns the name identifying the endpoint in logs and sync status, derived from the endpoint type and
+// URL when not configured
+func (e Endpoint) GetName() string {
+	if e.Name != "" {
+		return e.Name
+	}
+	return strings.ToLower(e.Type) + "@" + e.URL
+}
+
+// MatchesHost returns true if the host passes the host filters of the endpoint
+func (e Endpoint) MatchesHost(hostName string) bool {
+	if len(e.HostFilters) == 0 {
+		return true
+	}
+	for _, filter := range e.HostFilters {
+		if strings.EqualFold(filter, hostName) || search.WildcardMatched(hostName, filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetEndpoints returns all the configured tenant endpoints, the single end-point set up by the tenant connection
+// task comes first when present
+func (c *Configuration) GetEndpoints() []Endpoint {
+	var endpoints []Endpoint
+	if c.Endpoint.Type != "" {
+		endpoints = append(endpoints, c.Endpoint)
+	}
+	return append(endpoints, c.Endpoints...)
+}
+
+// ForEndpoint returns a copy of the configuration dedicated to a single endpoint, so that the tenant plugins which
+// read Config.Endpoint can be run for each of the configured endpoints
+func (c *Configuration) ForEndpoint(endpoint Endpoint) *Configuration {
+	endpointConfig := *c
+	endpointConfig.Endpoint = endpoint
+	endpointConfig.Endpoints = nil
+	if endpoint.PollIntervalMinutes > 0 {
+		endpointConfig.PollIntervalMinutes = endpoint.PollIntervalMinutes
+	}
+	return &endpointConfig
 }
 
 // this function sets the configure file name and type
@@ -8,7 +8,9 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/intel-secl/intel-secl/v3/pkg/ihub/constants"
 	"github.com/spf13/viper"
 )
 
@@ -115,3 +117,62 @@ func TestSaveConfiguration(t *testing.T) {
 		})
 	}
 }
+
+func TestGetEndpoints(t *testing.T) {
+
+	c := Configuration{
+		PollIntervalMinutes: 5,
+		Endpoint: Endpoint{
+			Type: "KUBERNETES",
+			URL:  "https://k8s-1:6443/",
+		},
+		Endpoints: []Endpoint{
+			{
+				Name:                "openstack-region-1",
+				Type:                "OPENSTACK",
+				URL:                 "https://openstack:8778/",
+				HostFilters:         []string{"compute-*"},
+				PollIntervalMinutes: 10,
+			},
+		},
+	}
+
+	endpoints := c.GetEndpoints()
+	if len(endpoints) != 2 {
+		t.Fatalf("config/config_test:TestGetEndpoints() expected 2 endpoints, got %d", len(endpoints))
+	}
+	if endpoints[0].GetName() != "kubernetes@https://k8s-1:6443/" || endpoints[1].GetName() != "openstack-region-1" {
+		t.Errorf("config/config_test:TestGetEndpoints() unexpected endpoint names %s, %s", endpoints[0].GetName(), endpoints[1].GetName())
+	}
+
+	if !endpoints[0].MatchesHost("worker-node1") {
+		t.Error("config/config_test:TestGetEndpoints() endpoint without host filters should match all hosts")
+	}
+	if !endpoints[1].MatchesHost("compute-01") || endpoints[1].MatchesHost("controller-01") {
+		t.Error("config/config_test:TestGetEndpoints() host filters are not applied")
+	}
+
+	endpointConfig := c.ForEndpoint(endpoints[1])
+	if endpointConfig.Endpoint.Name != "openstack-region-1" || endpointConfig.PollIntervalMinutes != 10 || endpointConfig.Endpoints != nil {
+		t.Errorf("config/config_test:TestGetEndpoints() unexpected endpoint configuration %+v", endpointConfig)
+	}
+	if c.Endpoint.Type != "KUBERNETES" || c.PollIntervalMinutes != 5 {
+		t.Error("config/config_test:TestGetEndpoints() the global configuration must not be modified")
+	}
+
+	c.Endpoint = Endpoint{}
+	if len(c.GetEndpoints()) != 1 {
+		t.Error("config/config_test:TestGetEndpoints() unset end-point should be ignored")
+	}
+}
+
+func TestGetReconciliationInterval(t *testing.T) {
+	changeFeed := ChangeFeedConfig{Enabled: true}
+	if changeFeed.GetReconciliationInterval() != time.Minute*constants.DefaultReconciliationIntervalMinutes {
+		t.Error("config/config_test:TestGetReconciliationInterval() expected the default interval when not configured")
+	}
+	changeFeed.ReconciliationIntervalMinutes = 15
+	if changeFeed.GetReconciliationInterval() != 15*time.Minute {
+		t.Error("config/config_test:TestGetReconciliationInterval() configured interval is not applied")
+	}
+}
@@ -4,6 +4,8 @@
  */
 package constants
 
+import "time"
+
 const (
 	ServiceName                 = "ihub"
 	ExplicitServiceName         = "Integration Hub"
@@ -11,6 +13,7 @@ const (
 	HomeDir                     = "/opt/ihub/"
 	ConfigDir                   = "/etc/ihub/"
 	DefaultConfigFilePath       = ConfigDir + "config.yml"
+	EndpointStatusFile          = "endpoint-status.json"
 	ExecLinkPath                = "/usr/bin/ihub"
 	RunDirPath                  = "/run/ihub"
 	LogDir                      = "/var/log/ihub/"
@@ -50,6 +53,18 @@ const (
 	OpenStackAPIVersion         = "placement 1.23"
 )
 
+const (
+	/*HVS change feed constants */
+	DefaultReconciliationIntervalMinutes = 60
+	// ChangeFeedClockSkew is subtracted from the watermark so that reports created while the previous query was
+	// running, or stamped by an HVS clock running behind, are not missed
+	ChangeFeedClockSkew = 1 * time.Minute
+	// ChangeFeedReportLimit bounds the reports fetched per change feed query, a full sync is done when reached
+	ChangeFeedReportLimit     = 10000
+	SamlHostNameAttribute     = "HostName"
+	SamlHardwareUUIDAttribute = "HardwareUUID"
+)
+
 // State represents whether or not a daemon is running or not
 type State bool
 
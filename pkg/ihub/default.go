@@ -17,12 +17,16 @@ import (
 func init() {
 	viper.SetDefault("attestation-type", constants.DefaultAttestationType)
 	viper.SetDefault("poll-interval-minutes", constants.PollingIntervalMinutes)
+	viper.SetDefault("change-feed-enabled", false)
+	viper.SetDefault("change-feed-reconciliation-interval-minutes", constants.DefaultReconciliationIntervalMinutes)
 
 	//Set default values for TLS
 	viper.SetDefault("tls-cert-file", constants.DefaultTLSCertFile)
 	viper.SetDefault("tls-key-file", constants.DefaultTLSKeyFile)
 	viper.SetDefault("tls-common-name", constants.DefaultIHUBTlsCn)
 	viper.SetDefault("tls-san-list", constants.DefaultTLSSan)
+	viper.SetDefault("tls-key-algorithm", constants.DefaultKeyAlgorithm)
+	viper.SetDefault("tls-key-length", constants.DefaultKeyLength)
 
 	//Set default values for log
 	viper.SetDefault("log-max-length", constants.DefaultLogEntryMaxlength)
@@ -51,6 +55,10 @@ func defaultConfig() *config.Configuration {
 			AttestationType: viper.GetString("attestation-type"),
 			AttestationURL:  viper.GetString("attestation-service-url"),
 		},
+		ChangeFeed: config.ChangeFeedConfig{
+			Enabled:                       viper.GetBool("change-feed-enabled"),
+			ReconciliationIntervalMinutes: viper.GetInt("change-feed-reconciliation-interval-minutes"),
+		},
 		Log: commConfig.LogConfig{
 			MaxLength:    viper.GetInt("log-max-length"),
 			Level:        viper.GetString("log-level"),
@@ -0,0 +1,322 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package ihub
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/clients/k8s"
+	"github.com/intel-secl/intel-secl/v3/pkg/clients/openstack"
+	vsPlugin "github.com/intel-secl/intel-secl/v3/pkg/ihub/attestationPlugin"
+	"github.com/intel-secl/intel-secl/v3/pkg/ihub/config"
+	"github.com/intel-secl/intel-secl/v3/pkg/ihub/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/ihub/k8splugin"
+	types "github.com/intel-secl/intel-secl/v3/pkg/ihub/model"
+	"github.com/intel-secl/intel-secl/v3/pkg/ihub/openstackplugin"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/saml"
+	"github.com/pkg/errors"
+)
+
+// endpointSyncer pushes the host trust data to a single tenant endpoint at the poll interval of that endpoint.
+// Each endpoint is synced by its own syncer so that a failing endpoint does not hold up the others.
+type endpointSyncer struct {
+	name        string
+	config      *config.Configuration
+	statusStore *endpointStatusStore
+
+	k8sDetails       *k8splugin.KubernetesDetails
+	openstackDetails *openstackplugin.OpenstackDetails
+
+	// watermark is the start time of the last successful sync, the reports updated since then are fetched from HVS
+	// when the change feed is enabled
+	watermark    time.Time
+	lastFullSync time.Time
+}
+
+func newEndpointSyncer(conf *config.Configuration, endpoint config.Endpoint, statusStore *endpointStatusStore) *endpointSyncer {
+	endpointConfig := conf.ForEndpoint(endpoint)
+	if endpointConfig.PollIntervalMinutes < constants.PollingIntervalMinutes {
+		secLog.Infof("startService:newEndpointSyncer() Poll interval of endpoint %s is less than %v mins. Setting it to "+
+			"%v mins", endpoint.GetName(), constants.PollingIntervalMinutes, constants.PollingIntervalMinutes)
+		endpointConfig.PollIntervalMinutes = constants.PollingIntervalMinutes
+	}
+	return &endpointSyncer{
+		name:        endpoint.GetName(),
+		config:      endpointConfig,
+		statusStore: statusStore,
+	}
+}
+
+func (s *endpointSyncer) pollInterval() time.Duration {
+	return time.Minute * time.Duration(s.config.PollIntervalMinutes)
+}
+
+// run syncs the endpoint right away and then at every poll interval until stop is closed
+func (s *endpointSyncer) run(stop <-chan struct{}, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	s.syncAndRecordStatus()
+
+	tick := time.NewTicker(s.pollInterval())
+	defer tick.Stop()
+	secLog.Infof("startService:run() Scheduler for endpoint %s will start at : %v", s.name, time.Now().Local().Add(s.pollInterval()))
+	for {
+		select {
+		case <-stop:
+			return
+		case t := <-tick.C:
+			secLog.Debugf("startService:run() Scheduler for endpoint %s started at : %v", s.name, t)
+			s.syncAndRecordStatus()
+		}
+	}
+}
+
+func (s *endpointSyncer) syncAndRecordStatus() {
+	err := s.sync()
+	if err != nil {
+		log.WithError(err).Errorf("startService:syncAndRecordStatus() Failed to sync endpoint %s", s.name)
+	}
+	s.statusStore.record(s.name, s.config.Endpoint, err)
+}
+
+// sync pushes the host trust data to the endpoint, the clients of the endpoint are initialized on first use and
+// again after an initialization failure. When the change feed is enabled only the hosts with reports updated since
+// the previous sync are pushed, until the reconciliation interval elapses and all the hosts are pushed again.
+func (s *endpointSyncer) sync() (err error) {
+	log.Trace("startService:sync() Entering")
+	defer log.Trace("startService:sync() Leaving")
+
+	// a panic in a plugin must not take down the syncers of the other endpoints
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("startService:sync() Recovered from panic while syncing endpoint %s: %v", s.name, r)
+		}
+	}()
+
+	var syncAllHosts func() error
+	var syncUpdatedHosts func([]*saml.Saml) error
+	var pushError string
+	switch s.config.Endpoint.Type {
+	case constants.OpenStackTenant:
+		if s.openstackDetails == nil {
+			if s.openstackDetails, err = initOpenstackDetails(s.config); err != nil {
+				return err
+			}
+		}
+		syncAllHosts = func() error {
+			return openstackplugin.SyncAllHosts(s.openstackDetails)
+		}
+		syncUpdatedHosts = func(samlReports []*saml.Saml) error {
+			return openstackplugin.SyncUpdatedHosts(s.openstackDetails, samlReports)
+		}
+		pushError = "startService:sync() Error in pushing OpenStack traits"
+	case constants.K8sTenant:
+		if s.k8sDetails == nil {
+			if s.k8sDetails, err = initKubernetesDetails(s.config); err != nil {
+				return err
+			}
+		}
+		syncAllHosts = func() error {
+			return k8splugin.SyncAllHosts(s.k8sDetails)
+		}
+		syncUpdatedHosts = func(samlReports []*saml.Saml) error {
+			return k8splugin.SyncUpdatedHosts(s.k8sDetails, samlReports)
+		}
+		pushError = "startService:sync() Error in pushing Kubernetes CRDs"
+	default:
+		return errors.Errorf("startService:sync() Endpoint type '%s' is not supported", s.config.Endpoint.Type)
+	}
+
+	syncStart := time.Now()
+	if s.useChangeFeed(syncStart) {
+		samlReports, err := vsPlugin.GetUpdatedHostReports(s.watermark.Add(-constants.ChangeFeedClockSkew), s.config,
+			constants.TrustedCAsStoreDir, constants.SamlCertFilePath)
+		if err == nil {
+			if err = syncUpdatedHosts(samlReports); err != nil {
+				return errors.Wrap(err, pushError)
+			}
+			s.watermark = syncStart
+			return nil
+		}
+		if errors.Cause(err) != vsPlugin.ErrTooManyUpdatedReports {
+			return errors.Wrap(err, "startService:sync() Error in fetching updated reports from HVS")
+		}
+		log.Infof("startService:sync() Too many updated reports for endpoint %s, pushing all the hosts", s.name)
+	}
+
+	if err = syncAllHosts(); err != nil {
+		return errors.Wrap(err, pushError)
+	}
+	s.watermark = syncStart
+	s.lastFullSync = syncStart
+	return nil
+}
+
+// useChangeFeed returns true if only the updated hosts are to be pushed, this requires a previous full sync within
+// the reconciliation interval. SGX attestation does not provide a change feed.
+func (s *endpointSyncer) useChangeFeed(now time.Time) bool {
+	return s.config.ChangeFeed.Enabled &&
+		s.config.AttestationService.AttestationType == constants.DefaultAttestationType &&
+		!s.lastFullSync.IsZero() &&
+		now.Sub(s.lastFullSync) < s.config.ChangeFeed.GetReconciliationInterval()
+}
+
+func initOpenstackDetails(conf *config.Configuration) (*openstackplugin.OpenstackDetails, error) {
+	o := openstackplugin.OpenstackDetails{Config: conf}
+
+	authUrl, err := url.Parse(conf.Endpoint.AuthURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "startService:initOpenstackDetails() unable to parse OpenStack auth url")
+	}
+
+	apiUrl, err := url.Parse(conf.Endpoint.URL)
+	if err != nil {
+		return nil, errors.Wrap(err, "startService:initOpenstackDetails() unable to parse OpenStack api url")
+	}
+
+	openstackClient, err := openstack.NewOpenstackClient(authUrl, apiUrl, conf.Endpoint.UserName, conf.Endpoint.Password, conf.Endpoint.CertFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "startService:initOpenstackDetails() Error in initializing the OpenStack client")
+	}
+	o.OpenstackClient = openstackClient
+
+	o.TrustedCAsStoreDir = constants.TrustedCAsStoreDir
+	if _, err := os.Stat(o.TrustedCAsStoreDir); err != nil {
+		return nil, errors.Wrap(err, "startService:initOpenstackDetails() Error in initializing the OpenStack client")
+	}
+
+	o.SamlCertFilePath = constants.SamlCertFilePath
+	if _, err := os.Stat(o.SamlCertFilePath); err != nil && conf.AttestationService.AttestationType == constants.DefaultAttestationType {
+		return nil, errors.Wrap(err, "startService:initOpenstackDetails() Error in initializing the OpenStack client")
+	}
+	return &o, nil
+}
+
+func initKubernetesDetails(conf *config.Configuration) (*k8splugin.KubernetesDetails, error) {
+	k := k8splugin.KubernetesDetails{Config: conf}
+
+	privateKey, err := crypt.GetPrivateKeyFromPKCS8File(constants.PrivatekeyLocation)
+	if err != nil {
+		return nil, errors.Wrap(err, "startService:initKubernetesDetails() Error in reading the ihub private key from file")
+	}
+	k.PrivateKey = privateKey
+
+	publicKeyBytes, err := ioutil.ReadFile(constants.PublickeyLocation)
+	if err != nil {
+		return nil, errors.Wrap(err, "startService:initKubernetesDetails() : Error in reading the ihub public key from file")
+	}
+
+	block, _ := pem.Decode(publicKeyBytes)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.New("startService:initKubernetesDetails() : Error while decoding ihub certificate in pem format")
+	}
+	k.PublicKeyBytes = block.Bytes
+
+	apiUrl, err := url.Parse(conf.Endpoint.URL)
+	if err != nil {
+		return nil, errors.Wrap(err, "startService:initKubernetesDetails() Unable to parse Kubernetes api url")
+	}
+
+	k8sClient, err := k8s.NewK8sClient(apiUrl, conf.Endpoint.Token, conf.Endpoint.CertFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "startService:initKubernetesDetails() Error in initializing the Kubernetes client")
+	}
+	k.K8sClient = k8sClient
+
+	k.TrustedCAsStoreDir = constants.TrustedCAsStoreDir
+	if _, err := os.Stat(k.TrustedCAsStoreDir); err != nil {
+		return nil, errors.Wrap(err, "startService:initKubernetesDetails() Error in initializing the Kubernetes client")
+	}
+
+	k.SamlCertFilePath = constants.SamlCertFilePath
+	if _, err := os.Stat(k.SamlCertFilePath); err != nil && conf.AttestationService.AttestationType == constants.DefaultAttestationType {
+		return nil, errors.Wrap(err, "startService:initKubernetesDetails() Error in initializing the Kubernetes client")
+	}
+	return &k, nil
+}
+
+// endpointStatusStore keeps the last sync status of every endpoint and persists it to a file, so that it can be
+// displayed by the status command
+type endpointStatusStore struct {
+	path     string
+	mutex    sync.Mutex
+	statuses map[string]*types.EndpointStatus
+}
+
+func newEndpointStatusStore(path string) *endpointStatusStore {
+	return &endpointStatusStore{
+		path:     path,
+		statuses: make(map[string]*types.EndpointStatus),
+	}
+}
+
+func (store *endpointStatusStore) record(name string, endpoint config.Endpoint, syncErr error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	status, ok := store.statuses[name]
+	if !ok {
+		status = &types.EndpointStatus{
+			Name: name,
+			Type: endpoint.Type,
+			URL:  endpoint.URL,
+		}
+		store.statuses[name] = status
+	}
+	now := time.Now().UTC()
+	status.LastSyncTime = &now
+	if syncErr != nil {
+		status.LastError = syncErr.Error()
+		status.ConsecutiveFailures++
+	} else {
+		status.LastSuccessfulSync = &now
+		status.LastError = ""
+		status.ConsecutiveFailures = 0
+	}
+
+	statuses := make([]types.EndpointStatus, 0, len(store.statuses))
+	for _, s := range store.statuses {
+		statuses = append(statuses, *s)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	statusJson, err := json.MarshalIndent(statuses, "", "  ")
+	if err != nil {
+		log.WithError(err).Error("startService:record() Failed to marshal endpoint status")
+		return
+	}
+	// write to a temporary file first so that readers never see a partially written status
+	tempPath := store.path + ".tmp"
+	if err = ioutil.WriteFile(tempPath, statusJson, 0640); err != nil {
+		log.WithError(err).Error("startService:record() Failed to write endpoint status")
+		return
+	}
+	if err = os.Rename(tempPath, store.path); err != nil {
+		log.WithError(err).Error("startService:record() Failed to write endpoint status")
+	}
+}
+
+// loadEndpointStatus reads the endpoint status persisted by the running daemon
+func loadEndpointStatus(homeDir string) ([]types.EndpointStatus, error) {
+	statusJson, err := ioutil.ReadFile(filepath.Join(homeDir, constants.EndpointStatusFile))
+	if err != nil {
+		return nil, err
+	}
+	var statuses []types.EndpointStatus
+	if err = json.Unmarshal(statusJson, &statuses); err != nil {
+		return nil, errors.Wrap(err, "startService:loadEndpointStatus() Failed to parse endpoint status")
+	}
+	return statuses, nil
+}
@@ -18,7 +18,7 @@ Available Commands:
 	-v|--version           Show the version of current ihub build
 	setup <task>           Run setup task
 	start                  Start ihub
-	status                 Show the status of ihub
+	status                 Show the status of ihub and the last sync status of its endpoints
 	stop                   Stop ihub
 	uninstall [--purge]    Uninstall ihub
 		--purge            all configuration and data files will be removed if this flag is set
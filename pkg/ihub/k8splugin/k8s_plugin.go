@@ -20,6 +20,7 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/ihub/config"
 	"github.com/intel-secl/intel-secl/v3/pkg/ihub/constants"
 	types "github.com/intel-secl/intel-secl/v3/pkg/ihub/model"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/saml"
 	model "github.com/intel-secl/intel-secl/v3/pkg/model/k8s"
 
 	"io/ioutil"
@@ -45,6 +46,9 @@ type KubernetesDetails struct {
 	K8sClient          *k8s.Client
 	TrustedCAsStoreDir string
 	SamlCertFilePath   string
+	// TenantHosts holds the worker nodes listed by the last full sync, keyed by hardware UUID, so that updated
+	// reports can be pushed without listing the nodes again
+	TenantHosts map[uuid.UUID]types.HostDetails
 }
 
 var log = commonLog.GetDefaultLogger()
@@ -116,6 +120,10 @@ func GetHosts(k8sDetails *KubernetesDetails) error {
 				}
 			}
 
+			if !conf.Endpoint.MatchesHost(hostDetails.HostName) {
+				log.Debugf("k8splugin/k8s_plugin:GetHosts() Host %s does not match the endpoint host filters, skipping", hostDetails.HostName)
+				continue
+			}
 			hostDetailMap[hostDetails.HostIP] = hostDetails
 		}
 
@@ -135,6 +143,12 @@ func FilterHostReports(k8sDetails *KubernetesDetails, hostDetails *types.HostDet
 		return errors.Wrap(err, "k8splugin/k8s_plugin:FilterHostReports() : Error in getting the host report")
 	}
 
+	setHostDetailsFromSamlReport(hostDetails, samlReport)
+	return nil
+}
+
+// setHostDetailsFromSamlReport sets the trust, asset tags and hardware features of the host from its SAML report
+func setHostDetailsFromSamlReport(hostDetails *types.HostDetails, samlReport *saml.Saml) {
 	trustMap := make(map[string]string)
 	hardwareFeaturesMap := make(map[string]string)
 	assetTagsMap := make(map[string]string)
@@ -161,8 +175,6 @@ func FilterHostReports(k8sDetails *KubernetesDetails, hostDetails *types.HostDet
 	hostDetails.HardwareFeatures = hardwareFeaturesMap
 	hostDetails.Trusted = overAllTrust
 	hostDetails.ValidTo = samlReport.Subject.NotOnOrAfter
-
-	return nil
 }
 
 //GetSignedTrustReport Creates a Signed trust-report based on the host details
@@ -402,23 +414,34 @@ func PostCRD(k8sDetails *KubernetesDetails, crd *model.CRD) error {
 
 //SendDataToEndPoint pushes host trust data to Kubernetes
 func SendDataToEndPoint(kubernetes KubernetesDetails) error {
+	return SyncAllHosts(&kubernetes)
+}
+
+//SyncAllHosts lists the Kubernetes nodes and pushes the trust data of all of them, the node list is kept for
+//SyncUpdatedHosts
+func SyncAllHosts(kubernetes *KubernetesDetails) error {
 
-	log.Trace("k8splugin/k8s_plugin:SendDataToEndPoint() Entering")
-	defer log.Trace("k8splugin/k8s_plugin:SendDataToEndPoint() Leaving")
+	log.Trace("k8splugin/k8s_plugin:SyncAllHosts() Entering")
+	defer log.Trace("k8splugin/k8s_plugin:SyncAllHosts() Leaving")
 
 	var sgxData types.PlatformDataSGX
 
-	err := GetHosts(&kubernetes)
+	err := GetHosts(kubernetes)
 	if err != nil {
-		return errors.Wrap(err, "k8splugin/k8s_plugin:SendDataToEndPoint() Error in getting the Hosts from kubernetes")
+		return errors.Wrap(err, "k8splugin/k8s_plugin:SyncAllHosts() Error in getting the Hosts from kubernetes")
+	}
+
+	kubernetes.TenantHosts = make(map[uuid.UUID]types.HostDetails)
+	for _, hostDetails := range kubernetes.HostDetailsMap {
+		kubernetes.TenantHosts[hostDetails.HostID] = hostDetails
 	}
 
 	if kubernetes.Config.AttestationService.AttestationType == "HVS" {
 		for key := range kubernetes.HostDetailsMap {
 			hostDetails := kubernetes.HostDetailsMap[key]
-			err := FilterHostReports(&kubernetes, &hostDetails, kubernetes.TrustedCAsStoreDir, kubernetes.SamlCertFilePath)
+			err := FilterHostReports(kubernetes, &hostDetails, kubernetes.TrustedCAsStoreDir, kubernetes.SamlCertFilePath)
 			if err != nil {
-				log.WithError(err).Error("k8splugin/k8s_plugin:SendDataToEndPoint() Error in Filtering Report for Hosts")
+				log.WithError(err).Error("k8splugin/k8s_plugin:SyncAllHosts() Error in Filtering Report for Hosts")
 				//host doesn't exist remove from the map
 				delete(kubernetes.HostDetailsMap, key)
 				continue
@@ -430,7 +453,7 @@ func SendDataToEndPoint(kubernetes KubernetesDetails) error {
 			hostDetails := kubernetes.HostDetailsMap[key]
 			platformData, err := vsPlugin.GetHostPlatformData(hostDetails.HostName, kubernetes.Config, kubernetes.TrustedCAsStoreDir)
 			if err != nil {
-				log.Infof("k8splugin/k8s_plugin:SendDataToEndPoint() Host %s doesn't exist in SHVS: removing from map", hostDetails.HostID)
+				log.Infof("k8splugin/k8s_plugin:SyncAllHosts() Host %s doesn't exist in SHVS: removing from map", hostDetails.HostID)
 				//host doesn't exist remove from the map
 				delete(kubernetes.HostDetailsMap, key)
 				continue
@@ -438,13 +461,13 @@ func SendDataToEndPoint(kubernetes KubernetesDetails) error {
 
 			err = json.Unmarshal(platformData, &sgxData)
 			if err != nil {
-				log.WithError(err).Error("k8splugin/k8s_plugin:SendDataToEndPoint() SGX Platform data unmarshal failed")
+				log.WithError(err).Error("k8splugin/k8s_plugin:SyncAllHosts() SGX Platform data unmarshal failed")
 				continue
 			}
 
 			// need to validate contents of EpcSize
 			if !regexp.MustCompile(constants.RegexEpcSize).MatchString(sgxData[0].EpcSize) {
-				log.WithError(err).Error("k8splugin/k8s_plugin:SendDataToEndPoint() Invalid EPC Size value")
+				log.WithError(err).Error("k8splugin/k8s_plugin:SyncAllHosts() Invalid EPC Size value")
 				continue
 			}
 			hostDetails.EpcSize = sgxData[0].EpcSize
@@ -457,14 +480,55 @@ func SendDataToEndPoint(kubernetes KubernetesDetails) error {
 			kubernetes.HostDetailsMap[key] = hostDetails
 		}
 	} else {
-		return errors.New("k8splugin/k8s_plugin:SendDataToEndPoint() Given Attestation type is invalid")
+		return errors.New("k8splugin/k8s_plugin:SyncAllHosts() Given Attestation type is invalid")
 	}
 
 	if len(kubernetes.HostDetailsMap) > 0 {
-		err = UpdateCRD(&kubernetes)
+		err = UpdateCRD(kubernetes)
+		if err != nil {
+			return errors.Wrap(err, "k8splugin/k8s_plugin:SyncAllHosts() Error in Updating CRDs for Kubernetes")
+		}
+	}
+	return nil
+}
+
+//SyncUpdatedHosts pushes the trust data of the nodes listed by the last full sync for which an updated SAML report
+//is given, the CRD is left untouched if none of the reports belong to the tenant
+func SyncUpdatedHosts(kubernetes *KubernetesDetails, samlReports []*saml.Saml) error {
+	log.Trace("k8splugin/k8s_plugin:SyncUpdatedHosts() Entering")
+	defer log.Trace("k8splugin/k8s_plugin:SyncUpdatedHosts() Leaving")
+
+	if kubernetes.TenantHosts == nil {
+		return errors.New("k8splugin/k8s_plugin:SyncUpdatedHosts() Hosts have not been fetched from kubernetes yet")
+	}
+	if kubernetes.HostDetailsMap == nil {
+		kubernetes.HostDetailsMap = make(map[string]types.HostDetails)
+	}
+
+	updatedHosts := 0
+	for _, samlReport := range samlReports {
+		hardwareUUID, err := uuid.Parse(vsPlugin.GetSamlAttribute(samlReport, constants.SamlHardwareUUIDAttribute))
 		if err != nil {
-			return errors.Wrap(err, "k8splugin/k8s_plugin:SendDataToEndPoint() Error in Updating CRDs for Kubernetes")
+			log.WithError(err).Debug("k8splugin/k8s_plugin:SyncUpdatedHosts() Report without valid hardware UUID, skipping")
+			continue
+		}
+		hostDetails, ok := kubernetes.TenantHosts[hardwareUUID]
+		if !ok {
+			continue
 		}
+		setHostDetailsFromSamlReport(&hostDetails, samlReport)
+		kubernetes.HostDetailsMap[hostDetails.HostIP] = hostDetails
+		updatedHosts++
+	}
+
+	if updatedHosts == 0 {
+		log.Debug("k8splugin/k8s_plugin:SyncUpdatedHosts() No updated reports for the kubernetes hosts")
+		return nil
+	}
+	log.Infof("k8splugin/k8s_plugin:SyncUpdatedHosts() Pushing updated reports of %d kubernetes hosts", updatedHosts)
+	err := UpdateCRD(kubernetes)
+	if err != nil {
+		return errors.Wrap(err, "k8splugin/k8s_plugin:SyncUpdatedHosts() Error in Updating CRDs for Kubernetes")
 	}
 	return nil
 }
@@ -0,0 +1,20 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package model
+
+import (
+	"time"
+)
+
+// EndpointStatus contains the outcome of the latest sync of a tenant endpoint
+type EndpointStatus struct {
+	Name                string     `json:"name"`
+	Type                string     `json:"type"`
+	URL                 string     `json:"url"`
+	LastSyncTime        *time.Time `json:"last_sync_time,omitempty"`
+	LastSuccessfulSync  *time.Time `json:"last_successful_sync,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+}
@@ -92,6 +92,10 @@ func getHostsFromOpenstack(openstackDetails *OpenstackDetails) error {
 	log.Debug("openstackplugin/openstack_plugin:GetHostsFromOpenstack() getting host details list from resource providers")
 	for _, actualObject := range openStackResources.ResourceProviders {
 
+		if !openstackDetails.Config.Endpoint.MatchesHost(actualObject.Name) {
+			log.Debugf("openstackplugin/openstack_plugin:GetHostsFromOpenstack() Host %s does not match the endpoint host filters, skipping", actualObject.Name)
+			continue
+		}
 		hostDetails := openstackHostDetails{}
 		hostDetails.HostID = actualObject.HostID
 		hostDetails.HostName = actualObject.Name
@@ -270,29 +274,10 @@ func updateOpenstackTraits(openstackDetails *OpenstackDetails) error {
 	defer log.Trace("openstackplugin/openstack_plugin:UpdateOpenstackTraits() Leaving")
 
 	for index := range openstackDetails.HostDetails {
-
-		log.Debug("openstackplugin/openstack_plugin:UpdateOpenstackTraits() fetching all the traits for the resource")
-		err := getTraitsForResource(&openstackDetails.HostDetails[index], openstackDetails)
+		err := updateOpenstackTraitsForHost(&openstackDetails.HostDetails[index], openstackDetails)
 		if err != nil {
-			return errors.Wrap(err, "openstackplugin/openstack_plugin:UpdateOpenstackTraits() Error in getting Traits for the resource")
+			return errors.Wrap(err, "openstackplugin/openstack_plugin:UpdateOpenstackTraits() Error in updating traits for the resource")
 		}
-
-		if len(openstackDetails.HostDetails[index].CustomTraits) > 0 {
-
-			log.Debug("openstackplugin/openstack_plugin:UpdateOpenstackTraits() creating custom traits")
-			err := createCustomTraits(openstackDetails.HostDetails[index].CustomTraits, openstackDetails)
-			if err != nil {
-				return errors.Wrap(err, "openstackplugin/openstack_plugin:UpdateOpenstackTraits() Error in creating custom traits")
-			}
-
-		}
-
-		log.Debug("openstackplugin/openstack_plugin:UpdateOpenstackTraits() Associating traits to resource")
-		err = associateTraitsForResource(&openstackDetails.HostDetails[index], openstackDetails)
-		if err != nil {
-			return errors.Wrap(err, "openstackplugin/openstack_plugin:UpdateOpenstackTraits() Error in Associating custom traits")
-		}
-
 	}
 
 	log.Debug("openstackplugin/openstack_plugin:UpdateOpenstackTraits() Fetch All the custom traits")
@@ -312,6 +297,37 @@ func updateOpenstackTraits(openstackDetails *OpenstackDetails) error {
 	return nil
 }
 
+//updateOpenstackTraitsForHost Update the traits of a single resource
+func updateOpenstackTraitsForHost(hostDetails *openstackHostDetails, openstackDetails *OpenstackDetails) error {
+
+	log.Trace("openstackplugin/openstack_plugin:updateOpenstackTraitsForHost() Entering")
+	defer log.Trace("openstackplugin/openstack_plugin:updateOpenstackTraitsForHost() Leaving")
+
+	log.Debug("openstackplugin/openstack_plugin:updateOpenstackTraitsForHost() fetching all the traits for the resource")
+	hostDetails.DefaultTraits = nil
+	err := getTraitsForResource(hostDetails, openstackDetails)
+	if err != nil {
+		return errors.Wrap(err, "openstackplugin/openstack_plugin:updateOpenstackTraitsForHost() Error in getting Traits for the resource")
+	}
+
+	if len(hostDetails.CustomTraits) > 0 {
+
+		log.Debug("openstackplugin/openstack_plugin:updateOpenstackTraitsForHost() creating custom traits")
+		err := createCustomTraits(hostDetails.CustomTraits, openstackDetails)
+		if err != nil {
+			return errors.Wrap(err, "openstackplugin/openstack_plugin:updateOpenstackTraitsForHost() Error in creating custom traits")
+		}
+
+	}
+
+	log.Debug("openstackplugin/openstack_plugin:updateOpenstackTraitsForHost() Associating traits to resource")
+	err = associateTraitsForResource(hostDetails, openstackDetails)
+	if err != nil {
+		return errors.Wrap(err, "openstackplugin/openstack_plugin:updateOpenstackTraitsForHost() Error in Associating custom traits")
+	}
+	return nil
+}
+
 //getTraitsForResource Get traits for the Openstack Resources
 func getTraitsForResource(hostDetails *openstackHostDetails, openstackDetails *OpenstackDetails) error {
 
@@ -571,30 +587,73 @@ func deleteNonAssociatedTraits(openstackDetails *OpenstackDetails) error {
 
 //SendDataToEndPoint pushes host trust data to OpenStack
 func SendDataToEndPoint(openstack OpenstackDetails) error {
-	log.Trace("openstackplugin/openstack_plugin:SendDataToEndPoint() Entering")
-	defer log.Trace("openstackplugin/openstack_plugin:SendDataToEndPoint() Leaving")
+	return SyncAllHosts(&openstack)
+}
+
+//SyncAllHosts lists the OpenStack resource providers and pushes the trust data of all of them, the resource
+//providers are kept for SyncUpdatedHosts
+func SyncAllHosts(openstack *OpenstackDetails) error {
+	log.Trace("openstackplugin/openstack_plugin:SyncAllHosts() Entering")
+	defer log.Trace("openstackplugin/openstack_plugin:SyncAllHosts() Leaving")
 
-	log.Debug("openstackplugin/openstack_plugin:SendDataToEndPoint() Fetching Hosts from Openstack")
-	err := getHostsFromOpenstack(&openstack)
+	log.Debug("openstackplugin/openstack_plugin:SyncAllHosts() Fetching Hosts from Openstack")
+	err := getHostsFromOpenstack(openstack)
 	if err != nil {
-		return errors.Wrap(err, "openstackplugin/openstack_plugin:SendDataToEndPoint() Error in getting Hosts from Openstack")
+		return errors.Wrap(err, "openstackplugin/openstack_plugin:SyncAllHosts() Error in getting Hosts from Openstack")
 	}
 
-	log.Debug("openstackplugin/openstack_plugin:SendDataToEndPoint() Filtering Hosts from Openstack")
+	log.Debug("openstackplugin/openstack_plugin:SyncAllHosts() Filtering Hosts from Openstack")
 
 	for index := range openstack.HostDetails {
-		err := filterHostReportsForOpenstack(&openstack.HostDetails[index], &openstack)
+		err := filterHostReportsForOpenstack(&openstack.HostDetails[index], openstack)
 		if err != nil {
-			log.WithError(err).Errorf("openstackplugin/openstack_plugin:SendDataToEndPoint() Error in Filtering"+
+			log.WithError(err).Errorf("openstackplugin/openstack_plugin:SyncAllHosts() Error in Filtering"+
 				" Host details for Openstack host %s", openstack.HostDetails[index].HostID.String())
 		}
 	}
 
-	log.Info("openstackplugin/openstack_plugin:SendDataToEndPoint() Updating traits to Openstack for host : ", openstack.HostDetails)
-	err = updateOpenstackTraits(&openstack)
+	log.Info("openstackplugin/openstack_plugin:SyncAllHosts() Updating traits to Openstack for host : ", openstack.HostDetails)
+	err = updateOpenstackTraits(openstack)
 	if err != nil {
-		return errors.Wrap(err, "openstackplugin/openstack_plugin:SendDataToEndPoint() Error in Filtering Host details for Openstack")
+		return errors.Wrap(err, "openstackplugin/openstack_plugin:SyncAllHosts() Error in Filtering Host details for Openstack")
 	}
 
 	return nil
 }
+
+//SyncUpdatedHosts updates the traits of the resource providers listed by the last full sync for which an updated
+//SAML report is given. Traits no longer in use are left for the next full sync to clean up.
+func SyncUpdatedHosts(openstack *OpenstackDetails, samlReports []*saml.Saml) error {
+	log.Trace("openstackplugin/openstack_plugin:SyncUpdatedHosts() Entering")
+	defer log.Trace("openstackplugin/openstack_plugin:SyncUpdatedHosts() Leaving")
+
+	samlReportsByHostName := make(map[string]*saml.Saml)
+	for _, samlReport := range samlReports {
+		hostName := vsPlugin.GetSamlAttribute(samlReport, constants.SamlHostNameAttribute)
+		if hostName != "" {
+			samlReportsByHostName[strings.ToLower(hostName)] = samlReport
+		}
+	}
+
+	updatedHosts := 0
+	for index := range openstack.HostDetails {
+		hostDetails := &openstack.HostDetails[index]
+		samlReport, ok := samlReportsByHostName[strings.ToLower(hostDetails.HostName)]
+		if !ok {
+			continue
+		}
+		err := getCustomTraitsFromSAMLReport(hostDetails, samlReport)
+		if err != nil {
+			log.WithError(err).Errorf("openstackplugin/openstack_plugin:SyncUpdatedHosts() Error in generating custom traits for"+
+				" Openstack host %s", hostDetails.HostID.String())
+			continue
+		}
+		err = updateOpenstackTraitsForHost(hostDetails, openstack)
+		if err != nil {
+			return errors.Wrap(err, "openstackplugin/openstack_plugin:SyncUpdatedHosts() Error in updating traits for the resource")
+		}
+		updatedHosts++
+	}
+	log.Infof("openstackplugin/openstack_plugin:SyncUpdatedHosts() Updated traits of %d Openstack hosts", updatedHosts)
+	return nil
+}
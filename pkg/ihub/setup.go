@@ -127,8 +127,8 @@ func (app *App) setupTaskRunner() (*setup.Runner, error) {
 	runner.AddTask("download-cert-tls", "tls", &setup.DownloadCert{
 		KeyFile:      viper.GetString("tls-key-file"),
 		CertFile:     viper.GetString("tls-cert-file"),
-		KeyAlgorithm: constants.DefaultKeyAlgorithm,
-		KeyLength:    constants.DefaultKeyLength,
+		KeyAlgorithm: viper.GetString("tls-key-algorithm"),
+		KeyLength:    viper.GetInt("tls-key-length"),
 		Subject: pkix.Name{
 			CommonName: viper.GetString("tls-common-name"),
 		},
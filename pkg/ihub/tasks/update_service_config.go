@@ -26,12 +26,14 @@ type UpdateServiceConfig struct {
 const envHelpPrompt = "Following environment variables are required for update-service-config setup:"
 
 var envHelp = map[string]string{
-	"SERVICE_USERNAME":  "The service username as configured in AAS",
-	"SERVICE_PASSWORD":  "The service password as configured in AAS",
-	"LOG_LEVEL":         "Log level",
-	"LOG_MAX_LENGTH":    "Max length of log statement",
-	"LOG_ENABLE_STDOUT": "Enable console log",
-	"AAS_BASE_URL":      "AAS Base URL",
+	"SERVICE_USERNAME":    "The service username as configured in AAS",
+	"SERVICE_PASSWORD":    "The service password as configured in AAS",
+	"LOG_LEVEL":           "Log level",
+	"LOG_MAX_LENGTH":      "Max length of log statement",
+	"LOG_ENABLE_STDOUT":   "Enable console log",
+	"AAS_BASE_URL":        "AAS Base URL",
+	"CHANGE_FEED_ENABLED": "Push only the hosts with reports updated in HVS since the previous sync (true/false)",
+	"CHANGE_FEED_RECONCILIATION_INTERVAL_MINUTES": "Interval at which all the hosts are pushed when the change feed is enabled",
 }
 
 func (uc UpdateServiceConfig) Run() error {
@@ -54,6 +56,10 @@ func (uc UpdateServiceConfig) Run() error {
 
 	(*uc.AppConfig).IHUB = uc.ServiceConfig
 	(*uc.AppConfig).AASApiUrl = uc.AASApiUrl
+	(*uc.AppConfig).ChangeFeed = config.ChangeFeedConfig{
+		Enabled:                       viper.GetBool("change-feed-enabled"),
+		ReconciliationIntervalMinutes: viper.GetInt("change-feed-reconciliation-interval-minutes"),
+	}
 	(*uc.AppConfig).Log = commConfig.LogConfig{
 		MaxLength:    viper.GetInt("log-max-length"),
 		EnableStdout: viper.GetBool("log-enable-stdout"),
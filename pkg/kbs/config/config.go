@@ -6,6 +6,7 @@ package config
 
 import (
 	"os"
+	"time"
 
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/constants"
 	commConfig "github.com/intel-secl/intel-secl/v3/pkg/lib/common/config"
@@ -26,13 +27,18 @@ type Configuration struct {
 
 	EndpointURL string `yaml:"endpoint-url" mapstructure:"endpoint-url"`
 	KeyManager  string `yaml:"key-manager" mapstructure:"key-manager"`
+	DataStore   string `yaml:"data-store" mapstructure:"data-store"`
 
 	TLS    commConfig.TLSCertConfig `yaml:"tls" mapstructure:"tls"`
 	Log    commConfig.LogConfig     `yaml:"log" mapstructure:"log"`
 	Server commConfig.ServerConfig  `yaml:"server" mapstructure:"server"`
+	DB     commConfig.DBConfig      `yaml:"db" mapstructure:"db"`
 
-	Kmip KmipConfig `yaml:"kmip" mapstructure:"kmip"`
-	Skc  SKCConfig  `yaml:"skc" mapstructure:"skc"`
+	Kmip        KmipConfig        `yaml:"kmip" mapstructure:"kmip"`
+	Skc         SKCConfig         `yaml:"skc" mapstructure:"skc"`
+	KeyRotation KeyRotationConfig `yaml:"key-rotation" mapstructure:"key-rotation"`
+
+	AikRevocation AikRevocationConfig `yaml:"aik-revocation" mapstructure:"aik-revocation"`
 }
 
 type KBSConfig struct {
@@ -55,6 +61,18 @@ type SKCConfig struct {
 	SessionExpiryTime int    `yaml:"session-expiry-time" mapstructure:"session-expiry-time"`
 }
 
+type KeyRotationConfig struct {
+	GracePeriod   time.Duration `yaml:"grace-period" mapstructure:"grace-period"`
+	CheckInterval time.Duration `yaml:"check-interval" mapstructure:"check-interval"`
+}
+
+// AikRevocationConfig configures the CRL of the privacy CA the AIK certificates of the hosts are checked against, the
+// check is disabled when CrlUrl is not set
+type AikRevocationConfig struct {
+	CrlUrl          string        `yaml:"crl-url" mapstructure:"crl-url"`
+	RefreshInterval time.Duration `yaml:"refresh-interval" mapstructure:"refresh-interval"`
+}
+
 // init sets the configuration file name and type
 func init() {
 	viper.SetConfigName(constants.ConfigFile)
@@ -65,10 +65,26 @@ const (
 	DefaultMaxHeaderBytes    = 1 << 20
 	DefaultKBSListenerPort   = 9443
 
+	// key rotation constants
+	DefaultKeyRotationGracePeriod   = 7 * 24 * time.Hour
+	DefaultKeyRotationCheckInterval = time.Hour
+
+	// aik revocation constants
+	DefaultAikCrlRefreshInterval = time.Hour
+
 	// keymanager constants
 	DirectoryKeyManager = "directory"
 	KmipKeyManager      = "kmip"
 
+	// data store constants
+	DirectoryDataStore = "directory"
+	PostgresDataStore  = "postgres"
+	DefaultDataStore   = DirectoryDataStore
+
+	// certificate type constants
+	SamlCertType        = "saml"
+	TpmIdentityCertType = "tpm-identity"
+
 	// algorithm constants
 	CRYPTOALG_AES = "AES"
 	CRYPTOALG_RSA = "RSA"
@@ -81,6 +97,27 @@ const (
 	KMIP_CLIENT_SUCCESS = 0x00
 
 	NonceLength = 32
+
+	// key version constants
+	LatestKeyVersion = "latest"
+	KeyVersionHeader = "Key-Version"
+)
+
+// db constants
+const (
+	DBTypePostgres = "postgres"
+
+	DefaultDBName              = "kbs_db"
+	DefaultSSLCertFilePath     = ConfigDir + "kbsdbsslcert.pem"
+	DefaultDbConnRetryAttempts = 4
+	DefaultDbConnRetryTime     = 1
+
+	//Postgres connection SslModes
+	SslModeAllow      = "allow"
+	SslModePrefer     = "prefer"
+	SslModeVerifyCa   = "verify-ca"
+	SslModeRequire    = "require"
+	SslModeVerifyFull = "verify-full"
 )
 
 ///SKC Specific constants
@@ -14,6 +14,7 @@ const (
 	KeySearch   = "keys:search"
 	KeyRegister = "keys:register"
 	KeyTransfer = "keys:transfer"
+	KeyRotate   = "keys:rotate"
 
 	SamlCertCreate   = "saml_certificates:create"
 	SamlCertRetrieve = "saml_certificates:retrieve"
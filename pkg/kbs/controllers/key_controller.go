@@ -55,6 +55,7 @@ func NewKeyController(rm *keymanager.RemoteManager, ps domain.KeyTransferPolicyS
 }
 
 var keySearchParams = map[string]bool{"algorithm": true, "keyLength": true, "curveType": true, "transferPolicyId": true}
+var keyTransferParams = map[string]bool{"version": true}
 var allowedAlgorithms = map[string]bool{"AES": true, "RSA": true, "EC": true, "aes": true, "rsa": true, "ec": true}
 var allowedCurveTypes = map[string]bool{"secp256r1": true, "secp384r1": true, "secp521r1": true, "prime256v1": true}
 var allowedKeyLengths = map[int]bool{128: true, 192: true, 256: true, 2048: true, 3072: true, 4096: true, 7680: true, 15360: true}
@@ -190,6 +191,27 @@ func (kc KeyController) Delete(responseWriter http.ResponseWriter, request *http
 	return nil, http.StatusNoContent, nil
 }
 
+//Rotate : Function to create a new version of a key
+func (kc KeyController) Rotate(responseWriter http.ResponseWriter, request *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/key_controller:Rotate() Entering")
+	defer defaultLog.Trace("controllers/key_controller:Rotate() Leaving")
+
+	id := uuid.MustParse(mux.Vars(request)["id"])
+	key, err := kc.remoteManager.RotateKey(id, kc.config.KeyRotationGracePeriod)
+	if err != nil {
+		if err.Error() == commErr.RecordNotFound {
+			defaultLog.Error("controllers/key_controller:Rotate() Key with specified id could not be located")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Key with specified id does not exist"}
+		} else {
+			defaultLog.WithError(err).Error("controllers/key_controller:Rotate() Key rotate failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to rotate key"}
+		}
+	}
+
+	secLog.WithField("Id", id).Infof("controllers/key_controller:Rotate() %s: Key rotated to version %d by: %s", commLogMsg.PrivilegeModified, key.Version, request.RemoteAddr)
+	return key, http.StatusOK, nil
+}
+
 //Search : Function to search keys
 func (kc KeyController) Search(responseWriter http.ResponseWriter, request *http.Request) (interface{}, int, error) {
 	defaultLog.Trace("controllers/key_controller:Search() Entering")
@@ -227,6 +249,12 @@ func (kc KeyController) Transfer(responseWriter http.ResponseWriter, request *ht
 		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
 	}
 
+	version, err := getKeyVersion(request.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/key_controller:Transfer() %s : Invalid key version", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
 	if request.ContentLength == 0 {
 		secLog.Error("controllers/key_controller:Transfer() The request body was not provided")
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body was not provided"}
@@ -249,7 +277,7 @@ func (kc KeyController) Transfer(responseWriter http.ResponseWriter, request *ht
 
 	// Wrap key with public key
 	id := uuid.MustParse(mux.Vars(request)["id"])
-	wrappedKey, status, err := kc.wrapSecretKey(id, envelopeKey, sha512.New384(), nil)
+	wrappedKey, transferredVersion, status, err := kc.wrapSecretKey(id, version, envelopeKey, sha512.New384(), nil)
 	if err != nil {
 		return nil, status, err
 	}
@@ -257,6 +285,7 @@ func (kc KeyController) Transfer(responseWriter http.ResponseWriter, request *ht
 	transferKeyResponse := kbs.KeyTransferAttributes{
 		KeyId:   id,
 		KeyData: base64.StdEncoding.EncodeToString(wrappedKey.([]byte)),
+		Version: transferredVersion,
 	}
 
 	secLog.WithField("Id", id).Infof("controllers/key_controller:Transfer() %s: Key transferred using Envelope key by: %s", commLogMsg.PrivilegeModified, request.RemoteAddr)
@@ -272,6 +301,12 @@ func (kc KeyController) TransferWithSaml(responseWriter http.ResponseWriter, req
 		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
 	}
 
+	version, err := getKeyVersion(request.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/key_controller:TransferWithSaml() %s : Invalid key version", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
 	if request.ContentLength == 0 {
 		secLog.Error("controllers/key_controller:Create() The request body was not provided")
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body was not provided"}
@@ -302,27 +337,31 @@ func (kc KeyController) TransferWithSaml(responseWriter http.ResponseWriter, req
 	envelopeKey := bindingCert.PublicKey.(*rsa.PublicKey)
 
 	// Wrap key with binding key
-	wrappedKey, status, err := kc.wrapSecretKey(id, envelopeKey, sha256.New(), []byte("TPM2\000"))
+	wrappedKey, transferredVersion, status, err := kc.wrapSecretKey(id, version, envelopeKey, sha256.New(), []byte("TPM2\000"))
 	if err != nil {
 		return nil, status, err
 	}
+	responseWriter.Header().Set(consts.KeyVersionHeader, strconv.Itoa(transferredVersion))
 
 	secLog.WithField("Id", id).Infof("controllers/key_controller:TransferWithSaml() %s: Key transferred using saml report by: %s", commLogMsg.PrivilegeModified, request.RemoteAddr)
 	return wrappedKey, http.StatusOK, nil
 }
 
-func (kc KeyController) wrapSecretKey(id uuid.UUID, publicKey *rsa.PublicKey, hash hash.Hash, label []byte) (interface{}, int, error) {
+func (kc KeyController) wrapSecretKey(id uuid.UUID, version int, publicKey *rsa.PublicKey, hash hash.Hash, label []byte) (interface{}, int, int, error) {
 	defaultLog.Trace("controllers/key_controller:wrapSecretKey() Entering")
 	defer defaultLog.Trace("controllers/key_controller:wrapSecretKey() Leaving")
 
-	secretKey, err := kc.remoteManager.TransferKey(id)
+	secretKey, transferredVersion, err := kc.remoteManager.TransferKey(id, version)
 	if err != nil {
 		if err.Error() == commErr.RecordNotFound {
 			defaultLog.Error("controllers/key_controller:wrapSecretKey() Key with specified id could not be located")
-			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Key with specified id does not exist"}
+			return nil, 0, http.StatusNotFound, &commErr.ResourceError{Message: "Key with specified id does not exist"}
+		} else if errors.Cause(err) == models.ErrKeyVersionUnavailable {
+			defaultLog.WithField("version", version).Error("controllers/key_controller:wrapSecretKey() Key version could not be located")
+			return nil, 0, http.StatusNotFound, &commErr.ResourceError{Message: "Key version does not exist or is no longer available"}
 		} else {
 			defaultLog.WithError(err).Error("controllers/key_controller:wrapSecretKey() Key transfer failed")
-			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to transfer Key"}
+			return nil, 0, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to transfer Key"}
 		}
 	}
 
@@ -330,10 +369,31 @@ func (kc KeyController) wrapSecretKey(id uuid.UUID, publicKey *rsa.PublicKey, ha
 	wrappedKey, err := rsa.EncryptOAEP(hash, rand.Reader, publicKey, secretKey, label)
 	if err != nil {
 		defaultLog.WithError(err).Error("controllers/key_controller:wrapSecretKey() Wrap key failed")
-		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to wrap key"}
+		return nil, 0, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to wrap key"}
 	}
 
-	return wrappedKey, http.StatusOK, nil
+	return wrappedKey, transferredVersion, http.StatusOK, nil
+}
+
+//getKeyVersion returns the key version requested for a transfer, 0 refers to the latest version
+func getKeyVersion(params url.Values) (int, error) {
+	defaultLog.Trace("controllers/key_controller:getKeyVersion() Entering")
+	defer defaultLog.Trace("controllers/key_controller:getKeyVersion() Leaving")
+
+	if err := utils.ValidateQueryParams(params, keyTransferParams); err != nil {
+		return 0, err
+	}
+
+	param := strings.TrimSpace(params.Get("version"))
+	if param == "" || param == consts.LatestKeyVersion {
+		return 0, nil
+	}
+
+	version, err := strconv.Atoi(param)
+	if err != nil || version < 1 {
+		return 0, errors.New("Invalid version query param value, must be a positive Integer or latest")
+	}
+	return version, nil
 }
 
 //validateKeyCreateRequest checks for various attributes in the Key Create request and returns a boolean value
@@ -14,6 +14,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -77,11 +78,13 @@ var _ = Describe("KeyController", func() {
 		policyStore = mocks.NewFakeKeyTransferPolicyStore()
 		newId, err := uuid.NewRandom()
 		Expect(err).NotTo(HaveOccurred())
+		certStore := mocks.NewFakeCertificateStore()
 		keyControllerConfig = domain.KeyControllerConfig{
-			SamlCertsDir:            samlCertsDir,
+			SamlCertStore:           certStore,
 			TrustedCaCertsDir:       trustedCaCertsDir,
-			TpmIdentityCertsDir:     tpmIdentityCertsDir,
+			TpmIdentityCertStore:    certStore,
 			DefaultTransferPolicyId: newId,
+			KeyRotationGracePeriod:  time.Hour,
 		}
 
 		keyManager := &keymanager.DirectoryManager{}
@@ -560,6 +563,116 @@ var _ = Describe("KeyController", func() {
 		})
 	})
 
+	// Specs for HTTP Post to "/keys/{id}/rotate"
+	Describe("Rotate an existing Key", func() {
+		Context("Rotate Key by ID", func() {
+			It("Should create a new version of the Key", func() {
+				router.Handle("/keys/{id}/rotate", kbsRoutes.ErrorHandler(kbsRoutes.JsonResponseHandler(keyController.Rotate))).Methods("POST")
+				req, err := http.NewRequest("POST", "/keys/ee37c360-7eae-4250-a677-6ee12adce8e2/rotate", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var keyResponse kbs.KeyResponse
+				err = json.Unmarshal(w.Body.Bytes(), &keyResponse)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keyResponse.KeyInformation.ID).To(Equal(uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")))
+				Expect(keyResponse.Version).To(Equal(2))
+				Expect(keyResponse.RotatedAt).NotTo(BeNil())
+				Expect(keyResponse.PreviousVersions).To(HaveLen(1))
+				Expect(keyResponse.PreviousVersions[0].Version).To(Equal(1))
+			})
+		})
+		Context("Rotate Key by non-existent ID", func() {
+			It("Should fail to rotate Key", func() {
+				router.Handle("/keys/{id}/rotate", kbsRoutes.ErrorHandler(kbsRoutes.JsonResponseHandler(keyController.Rotate))).Methods("POST")
+				req, err := http.NewRequest("POST", "/keys/73755fda-c910-46be-821f-e8ddeab189e9/rotate", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+		Context("Transfer a previous version of a rotated Key", func() {
+			It("Should transfer the requested version of the Key", func() {
+				_, err := remoteManager.RotateKey(uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2"), keyControllerConfig.KeyRotationGracePeriod)
+				Expect(err).NotTo(HaveOccurred())
+
+				router.Handle("/keys/{id}/transfer", kbsRoutes.ErrorHandler(kbsRoutes.JsonResponseHandler(keyController.Transfer))).Methods("POST")
+				req, err := http.NewRequest(
+					"POST",
+					"/keys/ee37c360-7eae-4250-a677-6ee12adce8e2/transfer?version=1",
+					strings.NewReader(string(validEnvelopeKey)),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypePlain)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var transferResponse kbs.KeyTransferAttributes
+				err = json.Unmarshal(w.Body.Bytes(), &transferResponse)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(transferResponse.Version).To(Equal(1))
+			})
+		})
+		Context("Transfer a non-existent version of a Key", func() {
+			It("Should fail to transfer Key", func() {
+				router.Handle("/keys/{id}/transfer", kbsRoutes.ErrorHandler(kbsRoutes.JsonResponseHandler(keyController.Transfer))).Methods("POST")
+				req, err := http.NewRequest(
+					"POST",
+					"/keys/ee37c360-7eae-4250-a677-6ee12adce8e2/transfer?version=5",
+					strings.NewReader(string(validEnvelopeKey)),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypePlain)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+		Context("Transfer an expired version of a rotated Key", func() {
+			It("Should fail to transfer Key", func() {
+				_, err := remoteManager.RotateKey(uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2"), 0)
+				Expect(err).NotTo(HaveOccurred())
+
+				router.Handle("/keys/{id}/transfer", kbsRoutes.ErrorHandler(kbsRoutes.JsonResponseHandler(keyController.Transfer))).Methods("POST")
+				req, err := http.NewRequest(
+					"POST",
+					"/keys/ee37c360-7eae-4250-a677-6ee12adce8e2/transfer?version=1",
+					strings.NewReader(string(validEnvelopeKey)),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypePlain)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+		Context("Transfer an invalid version of a Key", func() {
+			It("Should fail to transfer Key", func() {
+				router.Handle("/keys/{id}/transfer", kbsRoutes.ErrorHandler(kbsRoutes.JsonResponseHandler(keyController.Transfer))).Methods("POST")
+				req, err := http.NewRequest(
+					"POST",
+					"/keys/ee37c360-7eae-4250-a677-6ee12adce8e2/transfer?version=first",
+					strings.NewReader(string(validEnvelopeKey)),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypePlain)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
 	// Specs for HTTP Get to "/keys"
 	Describe("Search for all the Keys", func() {
 		Context("Get all the Keys", func() {
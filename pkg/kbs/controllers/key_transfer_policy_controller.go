@@ -60,6 +60,11 @@ func (ktpc KeyTransferPolicyController) Create(responseWriter http.ResponseWrite
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "sgx_enclave_issuer_anyof and sgx_enclave_issuer_product_id_anyof must be specified"}
 	}
 
+	if requestPolicy.KeyRotationIntervalDays < 0 {
+		secLog.Errorf("controllers/key_transfer_policy_controller:Create() %s : key_rotation_interval_days must not be negative", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "key_rotation_interval_days must not be negative"}
+	}
+
 	createdPolicy, err := ktpc.policyStore.Create(&requestPolicy)
 	if err != nil {
 		defaultLog.WithError(err).Error("controllers/key_transfer_policy_controller:Create() Key transfer policy create failed")
@@ -13,6 +13,7 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/config"
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/constants"
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain/models"
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/keymanager"
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/keytransfer"
 
@@ -52,6 +53,11 @@ func (kc *SKCController) TransferApplicationKey(responseWriter http.ResponseWrit
 	}
 
 	keyID := uuid.MustParse(mux.Vars(request)["id"])
+	version, err := getKeyVersion(request.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/skc_controller:TransferApplicationKey() %s : Invalid key version", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
 
 	keyInfo := keytransfer.GetKeyInfo()
 
@@ -141,8 +147,12 @@ func (kc *SKCController) TransferApplicationKey(responseWriter http.ResponseWrit
 		}
 
 		defaultLog.Debug("Session is valid. Hence directly transfer the key")
-		keyData, err := kc.remoteManager.TransferKey(keyID)
+		keyData, transferredVersion, err := kc.remoteManager.TransferKey(keyID, version)
 		if err != nil {
+			if errors.Cause(err) == models.ErrKeyVersionUnavailable {
+				defaultLog.WithField("version", version).Error("controllers/skc_controller:TransferApplicationKey() Key version could not be located")
+				return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Key version does not exist or is no longer available"}
+			}
 			defaultLog.WithError(err).Error("controllers/skc_controller:TransferApplicationKey() Key retrieve failed")
 			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve key"}
 		}
@@ -160,6 +170,7 @@ func (kc *SKCController) TransferApplicationKey(responseWriter http.ResponseWrit
 		outputKeyData.KeyInfo.KeyId = keyID
 		outputKeyData.KeyInfo.KeyData = applicationKey
 		outputKeyData.KeyInfo.KeyLength = key.KeyInformation.KeyLength
+		outputKeyData.KeyInfo.Version = transferredVersion
 		outputKeyData.KeyInfo.Policy.Link.KeyTransfer.Href = url
 		outputKeyData.KeyInfo.Policy.Link.KeyTransfer.Method = "get"
 		outputKeyData.Operation = constants.KeyTransferOpertaion
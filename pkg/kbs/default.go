@@ -17,12 +17,15 @@ import (
 func init() {
 	viper.SetDefault("endpoint-url", constants.DefaultEndpointUrl)
 	viper.SetDefault("key-manager", constants.DefaultKeyManager)
+	viper.SetDefault("data-store", constants.DefaultDataStore)
 
 	// Set default values for tls
 	viper.SetDefault("tls-cert-file", constants.DefaultTLSCertPath)
 	viper.SetDefault("tls-key-file", constants.DefaultTLSKeyPath)
 	viper.SetDefault("tls-common-name", constants.DefaultKbsTlsCn)
 	viper.SetDefault("tls-san-list", constants.DefaultKbsTlsSan)
+	viper.SetDefault("tls-key-algorithm", constants.DefaultKeyAlgorithm)
+	viper.SetDefault("tls-key-length", constants.DefaultKeyLength)
 
 	// Set default values for log
 	viper.SetDefault("log-max-length", constants.DefaultLogMaxlength)
@@ -40,6 +43,23 @@ func init() {
 	viper.SetDefault("server-idle-timeout", constants.DefaultIdleTimeout)
 	viper.SetDefault("server-max-header-bytes", constants.DefaultMaxHeaderBytes)
 
+	// Set default values for database
+	viper.SetDefault("db-vendor", constants.DBTypePostgres)
+	viper.SetDefault("db-host", "localhost")
+	viper.SetDefault("db-port", 5432)
+	viper.SetDefault("db-name", constants.DefaultDBName)
+	viper.SetDefault("db-ssl-mode", constants.SslModeVerifyFull)
+	viper.SetDefault("db-ssl-cert", constants.DefaultSSLCertFilePath)
+	viper.SetDefault("db-conn-retry-attempts", constants.DefaultDbConnRetryAttempts)
+	viper.SetDefault("db-conn-retry-time", constants.DefaultDbConnRetryTime)
+
+	// Set default values for key rotation
+	viper.SetDefault("key-rotation-grace-period", constants.DefaultKeyRotationGracePeriod)
+	viper.SetDefault("key-rotation-check-interval", constants.DefaultKeyRotationCheckInterval)
+
+	// Set default values for aik revocation
+	viper.SetDefault("aik-revocation-refresh-interval", constants.DefaultAikCrlRefreshInterval)
+
 }
 
 func defaultConfig() *config.Configuration {
@@ -51,6 +71,7 @@ func defaultConfig() *config.Configuration {
 
 		EndpointURL: viper.GetString("endpoint-url"),
 		KeyManager:  viper.GetString("key-manager"),
+		DataStore:   viper.GetString("data-store"),
 
 		KBS: config.KBSConfig{
 			UserName: viper.GetString("kbs-service-username"),
@@ -88,13 +109,43 @@ func defaultConfig() *config.Configuration {
 			SQVSUrl:           viper.GetString("sqvs-url"),
 			SessionExpiryTime: viper.GetInt("session-expiry-time"),
 		},
+		KeyRotation: config.KeyRotationConfig{
+			GracePeriod:   viper.GetDuration("key-rotation-grace-period"),
+			CheckInterval: viper.GetDuration("key-rotation-check-interval"),
+		},
+		AikRevocation: config.AikRevocationConfig{
+			CrlUrl:          viper.GetString("aik-revocation-crl-url"),
+			RefreshInterval: viper.GetDuration("aik-revocation-refresh-interval"),
+		},
+		DB: commConfig.DBConfig{
+			Vendor:   viper.GetString("db-vendor"),
+			Host:     viper.GetString("db-host"),
+			Port:     viper.GetInt("db-port"),
+			DBName:   viper.GetString("db-name"),
+			Username: viper.GetString("db-username"),
+			Password: viper.GetString("db-password"),
+			SSLMode:  viper.GetString("db-ssl-mode"),
+			SSLCert:  viper.GetString("db-ssl-cert"),
+
+			ConnectionRetryAttempts: viper.GetInt("db-conn-retry-attempts"),
+			ConnectionRetryTime:     viper.GetInt("db-conn-retry-time"),
+		},
 	}
 }
 
 func loadAlias() {
 	alias := map[string]string{
-		"tls-san-list": "SAN_LIST",
-		"aas-base-url": "AAS_API_URL",
+		"db-host":            "KBS_DB_HOSTNAME",
+		"db-vendor":          "KBS_DB_VENDOR",
+		"db-port":            "KBS_DB_PORT",
+		"db-name":            "KBS_DB_NAME",
+		"db-username":        "KBS_DB_USERNAME",
+		"db-password":        "KBS_DB_PASSWORD",
+		"db-ssl-cert":        "KBS_DB_SSLCERT",
+		"db-ssl-cert-source": "KBS_DB_SSLCERTSRC",
+		"db-ssl-mode":        "KBS_DB_SSL_MODE",
+		"tls-san-list":       "SAN_LIST",
+		"aas-base-url":       "AAS_API_URL",
 	}
 	for k, v := range alias {
 		if env := os.Getenv(v); env != "" {
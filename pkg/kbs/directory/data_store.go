@@ -0,0 +1,48 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package directory
+
+import (
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain"
+)
+
+// DataStore provides the stores persisting keys, key transfer policies and certificates as files in directories
+type DataStore struct {
+	KeysDir               string
+	KeysTransferPolicyDir string
+	SamlCertsDir          string
+	TpmIdentityCertsDir   string
+}
+
+// NewDataStore returns a DataStore using the default KBS directories
+func NewDataStore() *DataStore {
+	return &DataStore{
+		KeysDir:               constants.KeysDir,
+		KeysTransferPolicyDir: constants.KeysTransferPolicyDir,
+		SamlCertsDir:          constants.SamlCertsDir,
+		TpmIdentityCertsDir:   constants.TpmIdentityCertsDir,
+	}
+}
+
+func (ds *DataStore) KeyStore() domain.KeyStore {
+	return NewKeyStore(ds.KeysDir)
+}
+
+func (ds *DataStore) KeyTransferPolicyStore() domain.KeyTransferPolicyStore {
+	return NewKeyTransferPolicyStore(ds.KeysTransferPolicyDir)
+}
+
+func (ds *DataStore) SamlCertificateStore() domain.CertificateStore {
+	return NewCertificateStore(ds.SamlCertsDir)
+}
+
+func (ds *DataStore) TpmIdentityCertificateStore() domain.CertificateStore {
+	return NewCertificateStore(ds.TpmIdentityCertsDir)
+}
+
+// Close is a no-op as the directory stores do not hold any open resources
+func (ds *DataStore) Close() {
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sync"
 
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain/models"
@@ -17,6 +18,9 @@ import (
 	"github.com/pkg/errors"
 )
 
+// keyUpdateLock serializes the locked key updates, the key files are only used by a single KBS instance
+var keyUpdateLock sync.Mutex
+
 type KeyStore struct {
 	dir string
 }
@@ -64,6 +68,49 @@ func (ks *KeyStore) Retrieve(id uuid.UUID) (*models.KeyAttributes, error) {
 	return &key, nil
 }
 
+func (ks *KeyStore) Update(key *models.KeyAttributes) (*models.KeyAttributes, error) {
+	defaultLog.Trace("directory/key_store:Update() Entering")
+	defer defaultLog.Trace("directory/key_store:Update() Leaving")
+
+	keyFile := filepath.Join(ks.dir, key.ID.String())
+	if _, err := os.Stat(keyFile); err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.New(commErr.RecordNotFound)
+		} else {
+			return nil, errors.Wrapf(err, "directory/key_store:Update() Unable to stat key file : %s", key.ID.String())
+		}
+	}
+
+	bytes, err := json.Marshal(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "directory/key_store:Update() Failed to marshal key attributes")
+	}
+
+	err = ioutil.WriteFile(keyFile, bytes, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "directory/key_store:Update() Failed to store key attributes in file")
+	}
+
+	return key, nil
+}
+
+func (ks *KeyStore) UpdateWithLock(id uuid.UUID, update func(*models.KeyAttributes) error) (*models.KeyAttributes, error) {
+	defaultLog.Trace("directory/key_store:UpdateWithLock() Entering")
+	defer defaultLog.Trace("directory/key_store:UpdateWithLock() Leaving")
+
+	keyUpdateLock.Lock()
+	defer keyUpdateLock.Unlock()
+
+	key, err := ks.Retrieve(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := update(key); err != nil {
+		return nil, err
+	}
+	return ks.Update(key)
+}
+
 func (ks *KeyStore) Delete(id uuid.UUID) error {
 	defaultLog.Trace("directory/key_store:Delete() Entering")
 	defer defaultLog.Trace("directory/key_store:Delete() Leaving")
@@ -4,11 +4,18 @@
  */
 package domain
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type KeyControllerConfig struct {
-	SamlCertsDir            string
+	SamlCertStore           CertificateStore
 	TrustedCaCertsDir       string
-	TpmIdentityCertsDir     string
+	TpmIdentityCertStore    CertificateStore
 	DefaultTransferPolicyId uuid.UUID
+	KeyRotationGracePeriod  time.Duration
+	// AikRevocationList is nil when the revocation of the AIK certificates is not checked
+	AikRevocationList AikRevocationList
 }
@@ -5,17 +5,32 @@
 package domain
 
 import (
+	"crypto/x509"
+
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain/models"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/kbs"
 )
 
 type (
+	// DataStore provides the stores of the backend selected for persisting keys, key transfer policies and certificates
+	DataStore interface {
+		KeyStore() KeyStore
+		KeyTransferPolicyStore() KeyTransferPolicyStore
+		SamlCertificateStore() CertificateStore
+		TpmIdentityCertificateStore() CertificateStore
+		Close()
+	}
+
 	KeyStore interface {
 		Create(*models.KeyAttributes) (*models.KeyAttributes, error)
 		Retrieve(uuid.UUID) (*models.KeyAttributes, error)
+		Update(*models.KeyAttributes) (*models.KeyAttributes, error)
 		Delete(uuid.UUID) error
 		Search(criteria *models.KeyFilterCriteria) ([]models.KeyAttributes, error)
+		// UpdateWithLock retrieves a key while holding a lock on it, so that the key is not modified concurrently, and
+		// stores the attributes modified by update. Nothing is stored when update fails
+		UpdateWithLock(id uuid.UUID, update func(*models.KeyAttributes) error) (*models.KeyAttributes, error)
 	}
 
 	KeyTransferPolicyStore interface {
@@ -31,4 +46,9 @@ type (
 		Delete(uuid.UUID) error
 		Search(criteria *models.CertificateFilterCriteria) ([]kbs.Certificate, error)
 	}
+
+	// AikRevocationList reports if an AIK certificate was revoked by the privacy CA that issued it
+	AikRevocationList interface {
+		IsRevoked(aik *x509.Certificate) (bool, error)
+	}
 )
@@ -37,6 +37,29 @@ func (store *MockKeyStore) Retrieve(id uuid.UUID) (*models.KeyAttributes, error)
 	return nil, errors.New(commErr.RecordNotFound)
 }
 
+// Update replaces a Key in the store
+func (store *MockKeyStore) Update(k *models.KeyAttributes) (*models.KeyAttributes, error) {
+	if _, ok := store.KeyStore[k.ID]; !ok {
+		return nil, errors.New(commErr.RecordNotFound)
+	}
+	store.KeyStore[k.ID] = k
+	return k, nil
+}
+
+// UpdateWithLock applies update to a copy of a Key and replaces the Key with it
+func (store *MockKeyStore) UpdateWithLock(id uuid.UUID, update func(*models.KeyAttributes) error) (*models.KeyAttributes, error) {
+	k, ok := store.KeyStore[id]
+	if !ok {
+		return nil, errors.New(commErr.RecordNotFound)
+	}
+	updated := *k
+	if err := update(&updated); err != nil {
+		return nil, err
+	}
+	store.KeyStore[id] = &updated
+	return &updated, nil
+}
+
 // Delete deletes Key from the store
 func (store *MockKeyStore) Delete(id uuid.UUID) error {
 	if _, ok := store.KeyStore[id]; ok {
@@ -9,8 +9,12 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/kbs"
+	"github.com/pkg/errors"
 )
 
+// ErrKeyVersionUnavailable is returned when a key version does not exist or its grace period has ended
+var ErrKeyVersionUnavailable = errors.New("key version does not exist or is no longer available")
+
 // KeyAttributes - Contains all possible key attributes.
 type KeyAttributes struct {
 	ID               uuid.UUID `json:"id"`
@@ -26,6 +30,65 @@ type KeyAttributes struct {
 	CreatedAt        time.Time `json:"created_at,omitempty"`
 	Label            string    `json:"label,omitempty"`
 	Usage            string    `json:"usage,omitempty"`
+	Version          int       `json:"version,omitempty"`
+	RotatedAt        time.Time `json:"rotated_at,omitempty"`
+	// PreviousVersions holds the key material replaced by rotations, kept until the end of their grace period
+	PreviousVersions []KeyVersion `json:"previous_versions,omitempty"`
+}
+
+// KeyVersion - Contains the key material of a version of a key replaced by a rotation.
+type KeyVersion struct {
+	Version    int       `json:"version"`
+	KeyData    string    `json:"key,omitempty"`
+	PublicKey  string    `json:"public_key,omitempty"`
+	PrivateKey string    `json:"private_key,omitempty"`
+	KmipKeyID  string    `json:"kmip_key_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// CurrentVersion returns the version of the current key material, keys created before
+// versioning was introduced are at version 1
+func (ka *KeyAttributes) CurrentVersion() int {
+	if ka.Version == 0 {
+		return 1
+	}
+	return ka.Version
+}
+
+// LastRotation returns the time at which the current key material was created
+func (ka *KeyAttributes) LastRotation() time.Time {
+	if ka.RotatedAt.IsZero() {
+		return ka.CreatedAt
+	}
+	return ka.RotatedAt
+}
+
+// AtVersion returns a copy of the key attributes holding the key material of the given version.
+// Version 0 refers to the current key material
+func (ka *KeyAttributes) AtVersion(version int) (*KeyAttributes, error) {
+	keyAttributes := *ka
+	keyAttributes.PreviousVersions = nil
+	if version == 0 || version == ka.CurrentVersion() {
+		return &keyAttributes, nil
+	}
+
+	for _, keyVersion := range ka.PreviousVersions {
+		if keyVersion.Version != version {
+			continue
+		}
+		if !keyVersion.ExpiresAt.After(time.Now()) {
+			break
+		}
+		keyAttributes.Version = keyVersion.Version
+		keyAttributes.KeyData = keyVersion.KeyData
+		keyAttributes.PublicKey = keyVersion.PublicKey
+		keyAttributes.PrivateKey = keyVersion.PrivateKey
+		keyAttributes.KmipKeyID = keyVersion.KmipKeyID
+		keyAttributes.RotatedAt = keyVersion.CreatedAt
+		return &keyAttributes, nil
+	}
+	return nil, ErrKeyVersionUnavailable
 }
 
 func (ka *KeyAttributes) ToKeyResponse() *kbs.KeyResponse {
@@ -45,6 +108,19 @@ func (ka *KeyAttributes) ToKeyResponse() *kbs.KeyResponse {
 		CreatedAt:        ka.CreatedAt,
 		Label:            ka.Label,
 		Usage:            ka.Usage,
+		Version:          ka.CurrentVersion(),
+	}
+
+	if !ka.RotatedAt.IsZero() {
+		rotatedAt := ka.RotatedAt
+		keyResponse.RotatedAt = &rotatedAt
+	}
+	for _, keyVersion := range ka.PreviousVersions {
+		keyResponse.PreviousVersions = append(keyResponse.PreviousVersions, kbs.KeyVersionInformation{
+			Version:   keyVersion.Version,
+			CreatedAt: keyVersion.CreatedAt,
+			ExpiresAt: keyVersion.ExpiresAt,
+		})
 	}
 
 	return &keyResponse
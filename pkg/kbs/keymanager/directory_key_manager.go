@@ -177,6 +177,37 @@ func (dm *DirectoryManager) TransferKey(attributes *models.KeyAttributes) ([]byt
 	return base64.StdEncoding.DecodeString(key)
 }
 
+func (dm *DirectoryManager) RotateKey(attributes *models.KeyAttributes) (*models.KeyAttributes, error) {
+	defaultLog.Trace("keymanager/directory_key_manager:RotateKey() Entering")
+	defer defaultLog.Trace("keymanager/directory_key_manager:RotateKey() Leaving")
+
+	request := &kbs.KeyRequest{
+		KeyInformation: &kbs.KeyInformation{
+			Algorithm: attributes.Algorithm,
+			KeyLength: attributes.KeyLength,
+			CurveType: attributes.CurveType,
+		},
+	}
+	if request.KeyInformation.Algorithm == constants.CRYPTOALG_RSA && request.KeyInformation.KeyLength == 0 {
+		// registered RSA keys do not always record the key length, derive it from the current key
+		privateKeyBytes, err := base64.StdEncoding.DecodeString(attributes.PrivateKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode private key")
+		}
+		private, err := x509.ParsePKCS8PrivateKey(privateKeyBytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse private key")
+		}
+		rsaKey, ok := private.(*rsa.PrivateKey)
+		if !ok {
+			return nil, errors.New("private key is not RSA key")
+		}
+		request.KeyInformation.KeyLength = rsaKey.N.BitLen()
+	}
+
+	return dm.CreateKey(request)
+}
+
 func generateAESKey(length int) ([]byte, error) {
 	defaultLog.Trace("keymanager/directory_key_manager:generateAESKey() Entering")
 	defer defaultLog.Trace("keymanager/directory_key_manager:generateAESKey() Leaving")
@@ -39,4 +39,6 @@ type KeyManager interface {
 	DeleteKey(*models.KeyAttributes) error
 	RegisterKey(*kbs.KeyRequest) (*models.KeyAttributes, error)
 	TransferKey(*models.KeyAttributes) ([]byte, error)
+	// RotateKey generates new key material for an existing key, the returned attributes only carry the new material
+	RotateKey(*models.KeyAttributes) (*models.KeyAttributes, error)
 }
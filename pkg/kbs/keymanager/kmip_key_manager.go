@@ -103,3 +103,23 @@ func (km *KmipManager) TransferKey(attributes *models.KeyAttributes) ([]byte, er
 		return nil, errors.Errorf("%s algorithm is not supported", attributes.Algorithm)
 	}
 }
+
+func (km *KmipManager) RotateKey(attributes *models.KeyAttributes) (*models.KeyAttributes, error) {
+	defaultLog.Trace("keymanager/kmip_key_manager:RotateKey() Entering")
+	defer defaultLog.Trace("keymanager/kmip_key_manager:RotateKey() Leaving")
+
+	if attributes.Algorithm != constants.CRYPTOALG_AES {
+		return nil, errors.Errorf("%s algorithm is not supported", attributes.Algorithm)
+	}
+
+	kmipId, err := km.client.CreateSymmetricKey(constants.KMIP_CRYPTOALG_AES, attributes.KeyLength)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.KeyAttributes{
+		Algorithm: attributes.Algorithm,
+		KeyLength: attributes.KeyLength,
+		KmipKeyID: kmipId,
+	}, nil
+}
@@ -7,13 +7,18 @@ package keymanager
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain"
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain/models"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/kbs"
+	"github.com/pkg/errors"
 )
 
+// errKeyNotModified is returned from a locked key update when another KBS instance already made the update
+var errKeyNotModified = errors.New("The key does not need to be modified")
+
 type RemoteManager struct {
 	store       domain.KeyStore
 	manager     KeyManager
@@ -71,6 +76,8 @@ func (rm *RemoteManager) DeleteKey(keyId uuid.UUID) error {
 		return err
 	}
 
+	rm.deleteKeyVersions(keyAttributes, keyAttributes.PreviousVersions)
+
 	return rm.store.Delete(keyId)
 }
 
@@ -109,16 +116,215 @@ func (rm *RemoteManager) RegisterKey(request *kbs.KeyRequest) (*kbs.KeyResponse,
 	return storedKey.ToKeyResponse(), nil
 }
 
-func (rm *RemoteManager) TransferKey(keyId uuid.UUID) ([]byte, error) {
+// TransferKey returns the key material of the requested version of a key along with the version transferred.
+// Version 0 refers to the latest version
+func (rm *RemoteManager) TransferKey(keyId uuid.UUID, version int) ([]byte, int, error) {
 	defaultLog.Trace("keymanager/remote_key_manager:TransferKey() Entering")
 	defer defaultLog.Trace("keymanager/remote_key_manager:TransferKey() Leaving")
 
 	keyAttributes, err := rm.store.Retrieve(keyId)
 	if err != nil {
+		return nil, 0, err
+	}
+
+	versionAttributes, err := keyAttributes.AtVersion(version)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	key, err := rm.manager.TransferKey(versionAttributes)
+	if err != nil {
+		return nil, 0, err
+	}
+	return key, versionAttributes.CurrentVersion(), nil
+}
+
+// RotateKey creates a new version of a key while keeping its ID. The replaced version remains
+// available for transfer until the grace period ends
+func (rm *RemoteManager) RotateKey(keyId uuid.UUID, gracePeriod time.Duration) (*kbs.KeyResponse, error) {
+	defaultLog.Trace("keymanager/remote_key_manager:RotateKey() Entering")
+	defer defaultLog.Trace("keymanager/remote_key_manager:RotateKey() Leaving")
+
+	rotatedKey, err := rm.rotateKey(keyId, gracePeriod, nil)
+	if err != nil {
+		return nil, err
+	}
+	return rotatedKey.ToKeyResponse(), nil
+}
+
+// RotateDueKeys rotates the keys whose transfer policy requires a scheduled rotation and removes the
+// key versions whose grace period has ended
+func (rm *RemoteManager) RotateDueKeys(policyStore domain.KeyTransferPolicyStore, gracePeriod time.Duration) error {
+	defaultLog.Trace("keymanager/remote_key_manager:RotateDueKeys() Entering")
+	defer defaultLog.Trace("keymanager/remote_key_manager:RotateDueKeys() Leaving")
+
+	keys, err := rm.store.Search(nil)
+	if err != nil {
+		return errors.Wrap(err, "keymanager/remote_key_manager:RotateDueKeys() Failed to search keys")
+	}
+
+	rotationIntervals := make(map[uuid.UUID]int)
+	for i := range keys {
+		keyAttributes := &keys[i]
+
+		interval, ok := rotationIntervals[keyAttributes.TransferPolicyId]
+		if !ok {
+			policy, err := policyStore.Retrieve(keyAttributes.TransferPolicyId)
+			if err != nil {
+				defaultLog.WithError(err).Warnf("keymanager/remote_key_manager:RotateDueKeys() Failed to retrieve transfer policy of key %s", keyAttributes.ID)
+			} else if policy != nil {
+				interval = policy.KeyRotationIntervalDays
+			}
+			rotationIntervals[keyAttributes.TransferPolicyId] = interval
+		}
+
+		// another KBS instance may rotate the key concurrently, so the rotation is checked again once the key is locked
+		isDue := func(keyAttributes *models.KeyAttributes) bool {
+			return interval > 0 && !keyAttributes.LastRotation().AddDate(0, 0, interval).After(time.Now())
+		}
+		if isDue(keyAttributes) {
+			rotatedKey, err := rm.rotateKey(keyAttributes.ID, gracePeriod, isDue)
+			if err == errKeyNotModified {
+				continue
+			}
+			if err != nil {
+				defaultLog.WithError(err).Errorf("keymanager/remote_key_manager:RotateDueKeys() Failed to rotate key %s", keyAttributes.ID)
+			} else {
+				defaultLog.Infof("keymanager/remote_key_manager:RotateDueKeys() Rotated key %s to version %d", rotatedKey.ID, rotatedKey.CurrentVersion())
+			}
+			continue
+		}
+
+		if _, expiredVersions := splitExpiredVersions(keyAttributes); len(expiredVersions) == 0 {
+			continue
+		}
+		var expiredVersions []models.KeyVersion
+		updatedKey, err := rm.store.UpdateWithLock(keyAttributes.ID, func(keyAttributes *models.KeyAttributes) error {
+			keyAttributes.PreviousVersions, expiredVersions = splitExpiredVersions(keyAttributes)
+			if len(expiredVersions) == 0 {
+				return errKeyNotModified
+			}
+			return nil
+		})
+		if err == errKeyNotModified {
+			continue
+		}
+		if err != nil {
+			defaultLog.WithError(err).Errorf("keymanager/remote_key_manager:RotateDueKeys() Failed to remove expired versions of key %s", keyAttributes.ID)
+			continue
+		}
+		rm.deleteKeyVersions(updatedKey, expiredVersions)
+	}
+	return nil
+}
+
+// ScheduleKeyRotation runs RotateDueKeys at every interval until stop is closed
+func (rm *RemoteManager) ScheduleKeyRotation(policyStore domain.KeyTransferPolicyStore, interval, gracePeriod time.Duration, stop <-chan struct{}) {
+	defaultLog.Trace("keymanager/remote_key_manager:ScheduleKeyRotation() Entering")
+	defer defaultLog.Trace("keymanager/remote_key_manager:ScheduleKeyRotation() Leaving")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := rm.RotateDueKeys(policyStore, gracePeriod); err != nil {
+				defaultLog.WithError(err).Error("keymanager/remote_key_manager:ScheduleKeyRotation() Scheduled key rotation failed")
+			}
+		}
+	}
+}
+
+// rotateKey replaces the key material of a key with a new version while the key is locked. When isDue is set, the
+// rotation is skipped with errKeyNotModified unless isDue reports the locked key as due for rotation
+func (rm *RemoteManager) rotateKey(keyId uuid.UUID, gracePeriod time.Duration, isDue func(*models.KeyAttributes) bool) (*models.KeyAttributes, error) {
+	defaultLog.Trace("keymanager/remote_key_manager:rotateKey() Entering")
+	defer defaultLog.Trace("keymanager/remote_key_manager:rotateKey() Leaving")
+
+	var newKey *models.KeyAttributes
+	var expiredVersions []models.KeyVersion
+	rotatedKey, err := rm.store.UpdateWithLock(keyId, func(keyAttributes *models.KeyAttributes) error {
+		if isDue != nil && !isDue(keyAttributes) {
+			return errKeyNotModified
+		}
+
+		var err error
+		newKey, err = rm.manager.RotateKey(keyAttributes)
+		if err != nil {
+			return errors.Wrap(err, "keymanager/remote_key_manager:rotateKey() Failed to create new key version")
+		}
+
+		now := time.Now().UTC()
+		var previousVersions []models.KeyVersion
+		previousVersions, expiredVersions = splitExpiredVersions(keyAttributes)
+		previousVersions = append(previousVersions, models.KeyVersion{
+			Version:    keyAttributes.CurrentVersion(),
+			KeyData:    keyAttributes.KeyData,
+			PublicKey:  keyAttributes.PublicKey,
+			PrivateKey: keyAttributes.PrivateKey,
+			KmipKeyID:  keyAttributes.KmipKeyID,
+			CreatedAt:  keyAttributes.LastRotation(),
+			ExpiresAt:  now.Add(gracePeriod),
+		})
+
+		keyAttributes.Version = keyAttributes.CurrentVersion() + 1
+		keyAttributes.RotatedAt = now
+		keyAttributes.KeyLength = newKey.KeyLength
+		keyAttributes.KeyData = newKey.KeyData
+		keyAttributes.PublicKey = newKey.PublicKey
+		keyAttributes.PrivateKey = newKey.PrivateKey
+		keyAttributes.KmipKeyID = newKey.KmipKeyID
+		keyAttributes.PreviousVersions = previousVersions
+		return nil
+	})
+	if err != nil {
+		// the new key material is not referenced by any stored key version when the update failed
+		if newKey != nil {
+			if derr := rm.manager.DeleteKey(newKey); derr != nil {
+				defaultLog.WithError(derr).Warnf("keymanager/remote_key_manager:rotateKey() Failed to delete new key material of key %s", keyId)
+			}
+		}
 		return nil, err
 	}
 
-	return rm.manager.TransferKey(keyAttributes)
+	rm.deleteKeyVersions(rotatedKey, expiredVersions)
+	return rotatedKey, nil
+}
+
+// splitExpiredVersions returns the previous versions of a key whose grace period has not ended and the expired ones
+func splitExpiredVersions(keyAttributes *models.KeyAttributes) ([]models.KeyVersion, []models.KeyVersion) {
+	var previousVersions, expiredVersions []models.KeyVersion
+	now := time.Now()
+	for _, keyVersion := range keyAttributes.PreviousVersions {
+		if keyVersion.ExpiresAt.After(now) {
+			previousVersions = append(previousVersions, keyVersion)
+		} else {
+			expiredVersions = append(expiredVersions, keyVersion)
+		}
+	}
+	return previousVersions, expiredVersions
+}
+
+// deleteKeyVersions removes the key material of versions of a key from the backing key manager, it is called once
+// the versions are no longer referenced by the stored key
+func (rm *RemoteManager) deleteKeyVersions(keyAttributes *models.KeyAttributes, keyVersions []models.KeyVersion) {
+	for _, keyVersion := range keyVersions {
+		rm.deleteKeyVersion(keyAttributes, keyVersion)
+	}
+}
+
+func (rm *RemoteManager) deleteKeyVersion(keyAttributes *models.KeyAttributes, keyVersion models.KeyVersion) {
+	defaultLog.Trace("keymanager/remote_key_manager:deleteKeyVersion() Entering")
+	defer defaultLog.Trace("keymanager/remote_key_manager:deleteKeyVersion() Leaving")
+
+	versionAttributes := *keyAttributes
+	versionAttributes.PreviousVersions = nil
+	versionAttributes.KmipKeyID = keyVersion.KmipKeyID
+	if err := rm.manager.DeleteKey(&versionAttributes); err != nil {
+		defaultLog.WithError(err).Warnf("keymanager/remote_key_manager:deleteKeyVersion() Failed to delete version %d of key %s", keyVersion.Version, keyAttributes.ID)
+	}
 }
 
 func (rm *RemoteManager) getTransferLink(keyId uuid.UUID) string {
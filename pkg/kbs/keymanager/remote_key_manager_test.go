@@ -0,0 +1,70 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package keymanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/kmipclient"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// failingKeyStore fails the locked key updates once the update has been applied
+type failingKeyStore struct {
+	*mocks.MockKeyStore
+}
+
+func (store *failingKeyStore) UpdateWithLock(id uuid.UUID, update func(*models.KeyAttributes) error) (*models.KeyAttributes, error) {
+	keyAttributes, err := store.Retrieve(id)
+	if err != nil {
+		return nil, err
+	}
+	updated := *keyAttributes
+	if err := update(&updated); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("Failed to update key")
+}
+
+func TestRemoteManager_RotateKey(t *testing.T) {
+	assert := assert.New(t)
+
+	keyId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+	mockClient := kmipclient.NewMockKmipClient()
+	mockClient.On("CreateSymmetricKey", mock.Anything, mock.Anything).Return("2", nil)
+
+	remoteManager := NewRemoteManager(mocks.NewFakeKeyStore(), &KmipManager{mockClient}, "https://localhost:9443/kbs/v1/")
+
+	keyResponse, err := remoteManager.RotateKey(keyId, time.Hour)
+	assert.NoError(err)
+	assert.Equal(2, keyResponse.Version)
+	mockClient.AssertNotCalled(t, "DeleteKey", mock.Anything)
+}
+
+func TestRemoteManager_RotateKeyStoreFailure(t *testing.T) {
+	assert := assert.New(t)
+
+	keyId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+	mockClient := kmipclient.NewMockKmipClient()
+	mockClient.On("CreateSymmetricKey", mock.Anything, mock.Anything).Return("2", nil)
+	mockClient.On("DeleteKey", "2").Return(nil)
+
+	keyStore := &failingKeyStore{mocks.NewFakeKeyStore()}
+	remoteManager := NewRemoteManager(keyStore, &KmipManager{mockClient}, "https://localhost:9443/kbs/v1/")
+
+	_, err := remoteManager.RotateKey(keyId, time.Hour)
+	assert.Error(err)
+	mockClient.AssertCalled(t, "DeleteKey", "2")
+
+	keyAttributes, err := keyStore.Retrieve(keyId)
+	assert.NoError(err)
+	assert.Equal("1", keyAttributes.KmipKeyID)
+}
@@ -0,0 +1,169 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package keytransfer
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/clients"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
+	"github.com/pkg/errors"
+)
+
+// AikCrl is the CRL of the privacy CA pulled from HVS, it implements domain.AikRevocationList. The revocation check
+// fails closed: until a valid CRL is downloaded, or once the last CRL is past its next update, no AIK is accepted.
+type AikCrl struct {
+	crlUrl               string
+	caCertsDir           string
+	tpmIdentityCertStore domain.CertificateStore
+
+	mtx sync.RWMutex
+	crl *pkix.CertificateList
+}
+
+// NewAikCrl creates an AikCrl downloading the CRL from crlUrl over TLS, trusting the CA certificates in caCertsDir.
+// The CRL must be signed by one of the certificates of tpmIdentityCertStore.
+func NewAikCrl(crlUrl, caCertsDir string, tpmIdentityCertStore domain.CertificateStore) *AikCrl {
+	return &AikCrl{crlUrl: crlUrl, caCertsDir: caCertsDir, tpmIdentityCertStore: tpmIdentityCertStore}
+}
+
+// IsRevoked returns true when the AIK certificate is listed in the CRL. The AIK certificates issued by another CA than
+// the issuer of the CRL are not revoked.
+func (ac *AikCrl) IsRevoked(aik *x509.Certificate) (bool, error) {
+	defaultLog.Trace("keytransfer/aik_revocation:IsRevoked() Entering")
+	defer defaultLog.Trace("keytransfer/aik_revocation:IsRevoked() Leaving")
+
+	ac.mtx.RLock()
+	defer ac.mtx.RUnlock()
+
+	if ac.crl == nil {
+		return false, errors.New("The CRL of the privacy CA is not available")
+	}
+	if ac.crl.HasExpired(time.Now()) {
+		return false, errors.Errorf("The CRL of the privacy CA expired at %s", ac.crl.TBSCertList.NextUpdate)
+	}
+	var crlIssuer pkix.Name
+	crlIssuer.FillFromRDNSequence(&ac.crl.TBSCertList.Issuer)
+	if crlIssuer.String() != aik.Issuer.String() {
+		return false, nil
+	}
+	for _, entry := range ac.crl.TBSCertList.RevokedCertificates {
+		if entry.SerialNumber.Cmp(aik.SerialNumber) == 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Refresh downloads the CRL and replaces the current one once its signature is verified
+func (ac *AikCrl) Refresh() error {
+	defaultLog.Trace("keytransfer/aik_revocation:Refresh() Entering")
+	defer defaultLog.Trace("keytransfer/aik_revocation:Refresh() Leaving")
+
+	crlPem, err := ac.download()
+	if err != nil {
+		return err
+	}
+	block, _ := pem.Decode(crlPem)
+	if block == nil || block.Type != "X509 CRL" {
+		return errors.New("keytransfer/aik_revocation:Refresh() The response does not contain a PEM encoded CRL")
+	}
+	crl, err := x509.ParseDERCRL(block.Bytes)
+	if err != nil {
+		return errors.Wrap(err, "keytransfer/aik_revocation:Refresh() Error while parsing the CRL")
+	}
+	if err := ac.verifyCrlSignature(crl); err != nil {
+		return err
+	}
+	if crl.HasExpired(time.Now()) {
+		return errors.Errorf("keytransfer/aik_revocation:Refresh() The CRL expired at %s", crl.TBSCertList.NextUpdate)
+	}
+
+	ac.mtx.Lock()
+	defer ac.mtx.Unlock()
+	if ac.crl != nil && crl.TBSCertList.ThisUpdate.Before(ac.crl.TBSCertList.ThisUpdate) {
+		return errors.New("keytransfer/aik_revocation:Refresh() The CRL is older than the current CRL")
+	}
+	ac.crl = crl
+	return nil
+}
+
+// ScheduleRefresh refreshes the CRL at every interval until stop is closed
+func (ac *AikCrl) ScheduleRefresh(interval time.Duration, stop <-chan struct{}) {
+	defaultLog.Trace("keytransfer/aik_revocation:ScheduleRefresh() Entering")
+	defer defaultLog.Trace("keytransfer/aik_revocation:ScheduleRefresh() Leaving")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := ac.Refresh(); err != nil {
+				defaultLog.WithError(err).Error("keytransfer/aik_revocation:ScheduleRefresh() Scheduled CRL refresh failed")
+			}
+		}
+	}
+}
+
+func (ac *AikCrl) download() ([]byte, error) {
+	caCerts, err := crypt.GetCertsFromDir(ac.caCertsDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "keytransfer/aik_revocation:download() Error while getting certs from %s", ac.caCertsDir)
+	}
+	client, err := clients.HTTPClientWithCA(caCerts)
+	if err != nil {
+		return nil, errors.Wrap(err, "keytransfer/aik_revocation:download() Error while creating HTTP client")
+	}
+
+	req, err := http.NewRequest(http.MethodGet, ac.crlUrl, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "keytransfer/aik_revocation:download() Error while creating request")
+	}
+	req.Header.Set("Accept", consts.HTTPMediaTypePemFile)
+	rsp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "keytransfer/aik_revocation:download() Error while downloading the CRL from %s", ac.crlUrl)
+	}
+	defer func() {
+		derr := rsp.Body.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing response body")
+		}
+	}()
+	if rsp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("keytransfer/aik_revocation:download() Unexpected status %d while downloading the CRL from %s", rsp.StatusCode, ac.crlUrl)
+	}
+	return ioutil.ReadAll(rsp.Body)
+}
+
+func (ac *AikCrl) verifyCrlSignature(crl *pkix.CertificateList) error {
+	signingCertificates, err := ac.tpmIdentityCertStore.Search(nil)
+	if err != nil {
+		return errors.Wrap(err, "keytransfer/aik_revocation:verifyCrlSignature() Error retrieving signing certificates")
+	}
+	for _, signingCertificate := range signingCertificates {
+		certs, err := crypt.GetSubjectCertsMapFromPem(signingCertificate.Certificate)
+		if err != nil {
+			defaultLog.WithError(err).Warnf("keytransfer/aik_revocation:verifyCrlSignature() Error decoding signing certificate %s", signingCertificate.ID)
+			continue
+		}
+		for i := range certs {
+			if certs[i].CheckCRLSignature(crl) == nil {
+				return nil
+			}
+		}
+	}
+	return errors.New("keytransfer/aik_revocation:verifyCrlSignature() CRL not signed by any trusted privacy CA")
+}
@@ -8,8 +8,6 @@ import (
 	"crypto/x509"
 	"encoding/asn1"
 	"encoding/base64"
-	"io/ioutil"
-	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -45,7 +43,7 @@ func IsTrustedByHvs(saml string, samlReport *samlLib.Saml, keyId uuid.UUID, conf
 	//Remove Indentation from Request body
 	pattern := regexp.MustCompile(`( *)<`)
 	saml = pattern.ReplaceAllString(saml, "<")
-	verified := verifySamlSignature(saml, config.SamlCertsDir, config.TrustedCaCertsDir)
+	verified := verifySamlSignature(saml, config.SamlCertStore, config.TrustedCaCertsDir)
 	if !verified {
 		defaultLog.Error("keytransfer/transfer_with_saml:IsTrustedByHvs() Invalid signature on trust report")
 		return false, nil
@@ -104,12 +102,24 @@ func IsTrustedByHvs(saml string, samlReport *samlLib.Saml, keyId uuid.UUID, conf
 		return false, nil
 	}
 
-	verified = verifySignature(aikCert, config.TpmIdentityCertsDir)
+	verified = verifySignature(aikCert, config.TpmIdentityCertStore)
 	if !verified {
 		defaultLog.Error("keytransfer/transfer_with_saml:IsTrustedByHvs() AIK certificate not verified by any trusted authority")
 		return false, nil
 	}
 
+	if config.AikRevocationList != nil {
+		revoked, err := config.AikRevocationList.IsRevoked(aikCert)
+		if err != nil {
+			defaultLog.WithError(err).Error("keytransfer/transfer_with_saml:IsTrustedByHvs() Unable to check the revocation of the AIK certificate")
+			return false, nil
+		}
+		if revoked {
+			defaultLog.Error("keytransfer/transfer_with_saml:IsTrustedByHvs() AIK certificate is revoked")
+			return false, nil
+		}
+	}
+
 	if len(bindingKeyCertBytes) == 0 {
 		defaultLog.Error("keytransfer/transfer_with_saml:IsTrustedByHvs() No binding key certificate in trust report")
 		return false, nil
@@ -121,7 +131,7 @@ func IsTrustedByHvs(saml string, samlReport *samlLib.Saml, keyId uuid.UUID, conf
 		return false, nil
 	}
 
-	verified = verifySignature(bindingKeyCert, config.TpmIdentityCertsDir)
+	verified = verifySignature(bindingKeyCert, config.TpmIdentityCertStore)
 	if !verified {
 		defaultLog.Error("keytransfer/transfer_with_saml:IsTrustedByHvs() Binding key certificate not verified by any trusted authority")
 		return false, nil
@@ -152,19 +162,19 @@ func IsTrustedByHvs(saml string, samlReport *samlLib.Saml, keyId uuid.UUID, conf
 }
 
 //verifySamlSignature verifies signature of the saml report
-func verifySamlSignature(saml, samlCertsDir, trustedCaCertsDir string) bool {
+func verifySamlSignature(saml string, samlCertStore domain.CertificateStore, trustedCaCertsDir string) bool {
 	defaultLog.Trace("keytransfer/transfer_with_saml:VerifySamlSignature() Entering")
 	defer defaultLog.Trace("keytransfer/transfer_with_saml:VerifySamlSignature() Leaving")
 
-	samlCertFiles, err := ioutil.ReadDir(samlCertsDir)
+	samlCerts, err := samlCertStore.Search(nil)
 	if err != nil {
-		defaultLog.WithError(err).Error("keytransfer/transfer_with_saml:VerifySamlSignature() Error while reading the directory: ")
+		defaultLog.WithError(err).Error("keytransfer/transfer_with_saml:VerifySamlSignature() Error while retrieving the saml certificates")
 		return false
 	}
 
 	var verified bool
-	for _, samlCertFile := range samlCertFiles {
-		if isValidSaml := samlLib.VerifySamlSignature(saml, filepath.Join(samlCertsDir, samlCertFile.Name()), trustedCaCertsDir); isValidSaml {
+	for _, samlCert := range samlCerts {
+		if isValidSaml := samlLib.VerifySamlSignatureWithCertPem(saml, samlCert.Certificate, trustedCaCertsDir); isValidSaml {
 			verified = true
 		}
 	}
@@ -173,16 +183,26 @@ func verifySamlSignature(saml, samlCertsDir, trustedCaCertsDir string) bool {
 }
 
 //verifySignature verifies the signature of certificate
-func verifySignature(cert *x509.Certificate, signingCertsDir string) bool {
+func verifySignature(cert *x509.Certificate, signingCertStore domain.CertificateStore) bool {
 	defaultLog.Trace("keytransfer/transfer_with_saml:VerifySignature() Entering")
 	defer defaultLog.Trace("keytransfer/transfer_with_saml:VerifySignature() Leaving")
 
-	signingCerts, err := crypt.GetCertsFromDir(signingCertsDir)
+	signingCertificates, err := signingCertStore.Search(nil)
 	if err != nil {
-		defaultLog.WithError(err).Errorf("keytransfer/transfer_with_saml:VerifySignature() Error retrieving signing certificates from %s", signingCertsDir)
+		defaultLog.WithError(err).Error("keytransfer/transfer_with_saml:VerifySignature() Error retrieving signing certificates")
 		return false
 	}
 
+	var signingCerts []x509.Certificate
+	for _, signingCertificate := range signingCertificates {
+		certs, err := crypt.GetSubjectCertsMapFromPem(signingCertificate.Certificate)
+		if err != nil {
+			defaultLog.WithError(err).Warnf("keytransfer/transfer_with_saml:VerifySignature() Error decoding signing certificate %s", signingCertificate.ID)
+			continue
+		}
+		signingCerts = append(signingCerts, certs...)
+	}
+
 	verifyRootCAOpts := x509.VerifyOptions{
 		Roots: crypt.GetCertPool(signingCerts),
 	}
@@ -0,0 +1,189 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package postgres
+
+import (
+	"crypto/sha512"
+	"database/sql"
+	"encoding/hex"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/kbs"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+// CertificateStore persists the certificates of a single certificate type
+type CertificateStore struct {
+	Store    *DataStore
+	certType string
+}
+
+func NewCertificateStore(store *DataStore, certType string) *CertificateStore {
+	return &CertificateStore{store, certType}
+}
+
+func (cs *CertificateStore) Create(cert *kbs.Certificate) (*kbs.Certificate, error) {
+	defaultLog.Trace("postgres/certificate_store:Create() Entering")
+	defer defaultLog.Trace("postgres/certificate_store:Create() Leaving")
+
+	newUuid, err := uuid.NewRandom()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/certificate_store:Create() failed to create new UUID")
+	}
+	cert.ID = newUuid
+
+	return cs.Import(cert)
+}
+
+// Import stores a certificate keeping its ID, used when migrating from another data store
+func (cs *CertificateStore) Import(cert *kbs.Certificate) (*kbs.Certificate, error) {
+	defaultLog.Trace("postgres/certificate_store:Import() Entering")
+	defer defaultLog.Trace("postgres/certificate_store:Import() Leaving")
+
+	x509Cert, err := crypt.GetCertFromPem(cert.Certificate)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/certificate_store:Import() Error in decoding the certificate")
+	}
+
+	fingerprint := sha512.Sum384(x509Cert.Raw)
+	dbCertificate := certificate{
+		ID:          cert.ID,
+		Type:        cs.certType,
+		Certificate: cert.Certificate,
+		Subject:     x509Cert.Subject.CommonName,
+		Issuer:      x509Cert.Issuer.CommonName,
+		NotBefore:   x509Cert.NotBefore,
+		NotAfter:    x509Cert.NotAfter,
+		Revoked:     cert.Revoked,
+		Digest:      hex.EncodeToString(fingerprint[:]),
+	}
+
+	if err := cs.Store.Db.Create(&dbCertificate).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/certificate_store:Import() Failed to create certificate")
+	}
+
+	return toCertificate(&dbCertificate), nil
+}
+
+func (cs *CertificateStore) Retrieve(id uuid.UUID) (*kbs.Certificate, error) {
+	defaultLog.Trace("postgres/certificate_store:Retrieve() Entering")
+	defer defaultLog.Trace("postgres/certificate_store:Retrieve() Leaving")
+
+	var dbCertificate certificate
+	row := cs.Store.Db.Model(&certificate{}).Where("id = ? AND type = ?", id, cs.certType).Row()
+	if err := scanCertificate(row, &dbCertificate); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New(commErr.RecordNotFound)
+		}
+		return nil, errors.Wrapf(err, "postgres/certificate_store:Retrieve() Failed to retrieve certificate : %s", id.String())
+	}
+
+	return toCertificate(&dbCertificate), nil
+}
+
+func (cs *CertificateStore) Delete(id uuid.UUID) error {
+	defaultLog.Trace("postgres/certificate_store:Delete() Entering")
+	defer defaultLog.Trace("postgres/certificate_store:Delete() Leaving")
+
+	tx := cs.Store.Db.Where("id = ? AND type = ?", id, cs.certType).Delete(&certificate{})
+	if tx.Error != nil {
+		return errors.Wrapf(tx.Error, "postgres/certificate_store:Delete() Failed to delete certificate : %s", id.String())
+	}
+	if tx.RowsAffected == 0 {
+		return errors.New(commErr.RecordNotFound)
+	}
+
+	return nil
+}
+
+func (cs *CertificateStore) Search(criteria *models.CertificateFilterCriteria) ([]kbs.Certificate, error) {
+	defaultLog.Trace("postgres/certificate_store:Search() Entering")
+	defer defaultLog.Trace("postgres/certificate_store:Search() Leaving")
+
+	rows, err := buildCertificateSearchQuery(cs.Store.Db, cs.certType, criteria).Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/certificate_store:Search() Failed to retrieve certificates from db")
+	}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing rows")
+		}
+	}()
+
+	var certificates = []kbs.Certificate{}
+	for rows.Next() {
+		var dbCertificate certificate
+		if err := scanCertificate(rows, &dbCertificate); err != nil {
+			return nil, errors.Wrap(err, "postgres/certificate_store:Search() Failed to scan record")
+		}
+		certificates = append(certificates, *toCertificate(&dbCertificate))
+	}
+
+	return certificates, nil
+}
+
+// buildCertificateSearchQuery helper function to build the query object for a certificate search.
+func buildCertificateSearchQuery(tx *gorm.DB, certType string, criteria *models.CertificateFilterCriteria) *gorm.DB {
+	defaultLog.Trace("postgres/certificate_store:buildCertificateSearchQuery() Entering")
+	defer defaultLog.Trace("postgres/certificate_store:buildCertificateSearchQuery() Leaving")
+
+	tx = tx.Model(&certificate{}).Where("type = ?", certType).Order("subject")
+	if criteria == nil {
+		return tx
+	}
+
+	if criteria.SubjectEqualTo != "" {
+		tx = tx.Where("subject = ?", criteria.SubjectEqualTo)
+	}
+	if criteria.SubjectContains != "" {
+		tx = tx.Where("subject like ?", "%"+criteria.SubjectContains+"%")
+	}
+	if criteria.IssuerEqualTo != "" {
+		tx = tx.Where("lower(issuer) = ?", strings.ToLower(criteria.IssuerEqualTo))
+	}
+	if criteria.IssuerContains != "" {
+		tx = tx.Where("lower(issuer) like ?", "%"+strings.ToLower(criteria.IssuerContains)+"%")
+	}
+	if !criteria.ValidBefore.IsZero() {
+		tx = tx.Where("notafter < ?", criteria.ValidBefore)
+	}
+	if !criteria.ValidAfter.IsZero() {
+		tx = tx.Where("notbefore > ?", criteria.ValidAfter)
+	}
+	if !criteria.ValidOn.IsZero() {
+		tx = tx.Where("notbefore < ? AND notafter > ?", criteria.ValidOn, criteria.ValidOn)
+	}
+
+	return tx
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanCertificate(row rowScanner, c *certificate) error {
+	return row.Scan(&c.ID, &c.Type, &c.Certificate, &c.Subject, &c.Issuer, &c.NotBefore, &c.NotAfter, &c.Revoked, &c.Digest)
+}
+
+func toCertificate(c *certificate) *kbs.Certificate {
+	notBefore := c.NotBefore
+	notAfter := c.NotAfter
+	return &kbs.Certificate{
+		ID:          c.ID,
+		Certificate: c.Certificate,
+		Subject:     c.Subject,
+		Issuer:      c.Issuer,
+		NotBefore:   &notBefore,
+		NotAfter:    &notAfter,
+		Revoked:     c.Revoked,
+		Digest:      c.Digest,
+	}
+}
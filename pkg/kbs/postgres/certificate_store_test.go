@@ -0,0 +1,163 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package postgres
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/kbs"
+	"github.com/stretchr/testify/assert"
+)
+
+var certificateColumns = []string{"id", "type", "certificate", "subject", "issuer", "notbefore", "notafter", "revoked", "digest"}
+
+// newTestCertificate returns a PEM encoded self-signed certificate and its parsed form
+func newTestCertificate(t *testing.T, commonName string) ([]byte, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour).UTC().Truncate(time.Second),
+		NotAfter:     time.Now().AddDate(1, 0, 0).UTC().Truncate(time.Second),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), cert
+}
+
+func TestCertificateStore_Import(t *testing.T) {
+	dataStore, mock, err := NewSQLMockDataStore()
+	assert.NoError(t, err)
+	certStore := NewCertificateStore(dataStore, constants.SamlCertType)
+	certPem, x509Cert := newTestCertificate(t, "SAML Signing")
+	fingerprint := sha512.Sum384(x509Cert.Raw)
+	id := uuid.New()
+
+	// the certificates are stored with their type and the attributes they are searched by
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "certificate"`).
+		WithArgs(id, constants.SamlCertType, certPem, "SAML Signing", "SAML Signing", x509Cert.NotBefore,
+			x509Cert.NotAfter, true, hex.EncodeToString(fingerprint[:])).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+	mock.ExpectCommit()
+
+	imported, err := certStore.Import(&kbs.Certificate{ID: id, Certificate: certPem, Revoked: true})
+	assert.NoError(t, err)
+	assert.Equal(t, id, imported.ID)
+	assert.Equal(t, "SAML Signing", imported.Subject)
+	assert.Equal(t, x509Cert.NotAfter, *imported.NotAfter)
+	assert.True(t, imported.Revoked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCertificateStore_Import_InvalidCertificate(t *testing.T) {
+	dataStore, mock, err := NewSQLMockDataStore()
+	assert.NoError(t, err)
+	certStore := NewCertificateStore(dataStore, constants.SamlCertType)
+
+	_, err = certStore.Import(&kbs.Certificate{ID: uuid.New(), Certificate: []byte("not a certificate")})
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCertificateStore_Retrieve(t *testing.T) {
+	dataStore, mock, err := NewSQLMockDataStore()
+	assert.NoError(t, err)
+	certStore := NewCertificateStore(dataStore, constants.TpmIdentityCertType)
+	certPem, x509Cert := newTestCertificate(t, "Privacy CA")
+	id := uuid.New()
+
+	mock.ExpectQuery(`SELECT \* FROM "certificate"  WHERE \(id = \$1 AND type = \$2\)`).
+		WithArgs(id, constants.TpmIdentityCertType).
+		WillReturnRows(sqlmock.NewRows(certificateColumns).AddRow(id, constants.TpmIdentityCertType, certPem,
+			"Privacy CA", "Privacy CA", x509Cert.NotBefore, x509Cert.NotAfter, false, "digest"))
+
+	retrieved, err := certStore.Retrieve(id)
+	assert.NoError(t, err)
+	assert.Equal(t, id, retrieved.ID)
+	assert.Equal(t, certPem, retrieved.Certificate)
+	assert.Equal(t, "digest", retrieved.Digest)
+
+	// the certificates of the other types are not found
+	mock.ExpectQuery(`SELECT \* FROM "certificate"  WHERE \(id = \$1 AND type = \$2\)`).
+		WithArgs(id, constants.TpmIdentityCertType).
+		WillReturnRows(sqlmock.NewRows(certificateColumns))
+
+	_, err = certStore.Retrieve(id)
+	assert.EqualError(t, err, commErr.RecordNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCertificateStore_Delete(t *testing.T) {
+	dataStore, mock, err := NewSQLMockDataStore()
+	assert.NoError(t, err)
+	certStore := NewCertificateStore(dataStore, constants.SamlCertType)
+	id := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "certificate"  WHERE \(id = \$1 AND type = \$2\)`).
+		WithArgs(id, constants.SamlCertType).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, certStore.Delete(id))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "certificate"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	assert.EqualError(t, certStore.Delete(id), commErr.RecordNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCertificateStore_Search(t *testing.T) {
+	dataStore, mock, err := NewSQLMockDataStore()
+	assert.NoError(t, err)
+	certStore := NewCertificateStore(dataStore, constants.SamlCertType)
+	certPem, x509Cert := newTestCertificate(t, "SAML Signing")
+	id := uuid.New()
+	validOn := time.Now().UTC()
+
+	mock.ExpectQuery(`SELECT \* FROM "certificate"  WHERE \(type = \$1\) AND \(subject like \$2\) AND \(lower\(issuer\) = \$3\) AND \(notbefore < \$4 AND notafter > \$5\) ORDER BY "subject"`).
+		WithArgs(constants.SamlCertType, "%SAML%", "saml signing", validOn, validOn).
+		WillReturnRows(sqlmock.NewRows(certificateColumns).AddRow(id, constants.SamlCertType, certPem,
+			"SAML Signing", "SAML Signing", x509Cert.NotBefore, x509Cert.NotAfter, false, "digest"))
+
+	certs, err := certStore.Search(&models.CertificateFilterCriteria{
+		SubjectContains: "SAML",
+		IssuerEqualTo:   "SAML Signing",
+		ValidOn:         validOn,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, certs, 1)
+	assert.Equal(t, id, certs[0].ID)
+
+	mock.ExpectQuery(`SELECT \* FROM "certificate"  WHERE \(type = \$1\) AND \(notafter < \$2\) ORDER BY "subject"`).
+		WithArgs(constants.SamlCertType, validOn).
+		WillReturnRows(sqlmock.NewRows(certificateColumns))
+
+	certs, err = certStore.Search(&models.CertificateFilterCriteria{ValidBefore: validOn})
+	assert.NoError(t, err)
+	assert.Empty(t, certs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
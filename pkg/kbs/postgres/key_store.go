@@ -0,0 +1,193 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+type KeyStore struct {
+	Store *DataStore
+}
+
+func NewKeyStore(store *DataStore) *KeyStore {
+	return &KeyStore{store}
+}
+
+func (ks *KeyStore) Create(ka *models.KeyAttributes) (*models.KeyAttributes, error) {
+	defaultLog.Trace("postgres/key_store:Create() Entering")
+	defer defaultLog.Trace("postgres/key_store:Create() Leaving")
+
+	dbKey := key{
+		ID:               ka.ID,
+		Algorithm:        ka.Algorithm,
+		KeyLength:        ka.KeyLength,
+		CurveType:        ka.CurveType,
+		TransferPolicyId: ka.TransferPolicyId,
+		Attributes:       PGKeyAttributes(*ka),
+		CreatedAt:        ka.CreatedAt,
+	}
+
+	if err := ks.Store.Db.Create(&dbKey).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/key_store:Create() Failed to create key")
+	}
+
+	return ka, nil
+}
+
+func (ks *KeyStore) Retrieve(id uuid.UUID) (*models.KeyAttributes, error) {
+	defaultLog.Trace("postgres/key_store:Retrieve() Entering")
+	defer defaultLog.Trace("postgres/key_store:Retrieve() Leaving")
+
+	var ka models.KeyAttributes
+	row := ks.Store.Db.Model(&key{}).Select("attributes").Where("id = ?", id).Row()
+	if err := row.Scan((*PGKeyAttributes)(&ka)); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New(commErr.RecordNotFound)
+		}
+		return nil, errors.Wrapf(err, "postgres/key_store:Retrieve() Failed to retrieve key : %s", id.String())
+	}
+
+	return &ka, nil
+}
+
+func (ks *KeyStore) Update(ka *models.KeyAttributes) (*models.KeyAttributes, error) {
+	defaultLog.Trace("postgres/key_store:Update() Entering")
+	defer defaultLog.Trace("postgres/key_store:Update() Leaving")
+
+	tx := ks.Store.Db.Model(&key{}).Where("id = ?", ka.ID).Updates(map[string]interface{}{
+		"algorithm":          ka.Algorithm,
+		"key_length":         ka.KeyLength,
+		"curve_type":         ka.CurveType,
+		"transfer_policy_id": ka.TransferPolicyId,
+		"attributes":         PGKeyAttributes(*ka),
+	})
+	if tx.Error != nil {
+		return nil, errors.Wrapf(tx.Error, "postgres/key_store:Update() Failed to update key : %s", ka.ID.String())
+	}
+	if tx.RowsAffected == 0 {
+		return nil, errors.New(commErr.RecordNotFound)
+	}
+
+	return ka, nil
+}
+
+// UpdateWithLock holds a row lock on the key until the modified attributes are committed, which serializes the
+// updates across the KBS instances sharing the database
+func (ks *KeyStore) UpdateWithLock(id uuid.UUID, update func(*models.KeyAttributes) error) (*models.KeyAttributes, error) {
+	defaultLog.Trace("postgres/key_store:UpdateWithLock() Entering")
+	defer defaultLog.Trace("postgres/key_store:UpdateWithLock() Leaving")
+
+	tx := ks.Store.Db.Begin()
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "postgres/key_store:UpdateWithLock() Failed to begin transaction")
+	}
+
+	var ka models.KeyAttributes
+	row := tx.Set("gorm:query_option", "FOR UPDATE").Model(&key{}).Select("attributes").Where("id = ?", id).Row()
+	if err := row.Scan((*PGKeyAttributes)(&ka)); err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return nil, errors.New(commErr.RecordNotFound)
+		}
+		return nil, errors.Wrapf(err, "postgres/key_store:UpdateWithLock() Failed to retrieve key : %s", id.String())
+	}
+
+	if err := update(&ka); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	db := tx.Model(&key{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"algorithm":          ka.Algorithm,
+		"key_length":         ka.KeyLength,
+		"curve_type":         ka.CurveType,
+		"transfer_policy_id": ka.TransferPolicyId,
+		"attributes":         PGKeyAttributes(ka),
+	})
+	if db.Error != nil {
+		tx.Rollback()
+		return nil, errors.Wrapf(db.Error, "postgres/key_store:UpdateWithLock() Failed to update key : %s", id.String())
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.Wrapf(err, "postgres/key_store:UpdateWithLock() Failed to commit update of key : %s", id.String())
+	}
+
+	return &ka, nil
+}
+
+func (ks *KeyStore) Delete(id uuid.UUID) error {
+	defaultLog.Trace("postgres/key_store:Delete() Entering")
+	defer defaultLog.Trace("postgres/key_store:Delete() Leaving")
+
+	tx := ks.Store.Db.Where("id = ?", id).Delete(&key{})
+	if tx.Error != nil {
+		return errors.Wrapf(tx.Error, "postgres/key_store:Delete() Failed to delete key : %s", id.String())
+	}
+	if tx.RowsAffected == 0 {
+		return errors.New(commErr.RecordNotFound)
+	}
+
+	return nil
+}
+
+func (ks *KeyStore) Search(criteria *models.KeyFilterCriteria) ([]models.KeyAttributes, error) {
+	defaultLog.Trace("postgres/key_store:Search() Entering")
+	defer defaultLog.Trace("postgres/key_store:Search() Leaving")
+
+	rows, err := buildKeySearchQuery(ks.Store.Db, criteria).Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/key_store:Search() Failed to retrieve keys from db")
+	}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing rows")
+		}
+	}()
+
+	var keys = []models.KeyAttributes{}
+	for rows.Next() {
+		var ka models.KeyAttributes
+		if err := rows.Scan((*PGKeyAttributes)(&ka)); err != nil {
+			return nil, errors.Wrap(err, "postgres/key_store:Search() Failed to scan record")
+		}
+		keys = append(keys, ka)
+	}
+
+	return keys, nil
+}
+
+// buildKeySearchQuery helper function to build the query object for a key search.
+func buildKeySearchQuery(tx *gorm.DB, criteria *models.KeyFilterCriteria) *gorm.DB {
+	defaultLog.Trace("postgres/key_store:buildKeySearchQuery() Entering")
+	defer defaultLog.Trace("postgres/key_store:buildKeySearchQuery() Leaving")
+
+	tx = tx.Model(&key{}).Select("attributes").Order("created_at")
+	if criteria == nil {
+		return tx
+	}
+
+	if criteria.Algorithm != "" {
+		tx = tx.Where("algorithm = ?", criteria.Algorithm)
+	}
+	if criteria.KeyLength != 0 {
+		tx = tx.Where("key_length = ?", criteria.KeyLength)
+	}
+	if criteria.CurveType != "" {
+		tx = tx.Where("curve_type = ?", criteria.CurveType)
+	}
+	if criteria.TransferPolicyId != uuid.Nil {
+		tx = tx.Where("transfer_policy_id = ?", criteria.TransferPolicyId)
+	}
+
+	return tx
+}
@@ -0,0 +1,216 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package postgres
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestKey() *models.KeyAttributes {
+	return &models.KeyAttributes{
+		ID:               uuid.New(),
+		Algorithm:        "AES",
+		KeyLength:        256,
+		KeyData:          "a2V5LWRhdGE=",
+		TransferPolicyId: uuid.New(),
+		CreatedAt:        time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func TestKeyStore_Create(t *testing.T) {
+	dataStore, mock, err := NewSQLMockDataStore()
+	assert.NoError(t, err)
+	keyStore := NewKeyStore(dataStore)
+	key := newTestKey()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "key"`).
+		WithArgs(key.ID, key.Algorithm, key.KeyLength, key.CurveType, key.TransferPolicyId, sqlmock.AnyArg(), key.CreatedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(key.ID))
+	mock.ExpectCommit()
+
+	created, err := keyStore.Create(key)
+	assert.NoError(t, err)
+	assert.Equal(t, key.ID, created.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestKeyStore_Create_DuplicateKey(t *testing.T) {
+	dataStore, mock, err := NewSQLMockDataStore()
+	assert.NoError(t, err)
+	keyStore := NewKeyStore(dataStore)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "key"`).WillReturnError(errors.New("duplicate key value violates unique constraint"))
+	mock.ExpectRollback()
+
+	_, err = keyStore.Create(newTestKey())
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestKeyStore_Retrieve(t *testing.T) {
+	dataStore, mock, err := NewSQLMockDataStore()
+	assert.NoError(t, err)
+	keyStore := NewKeyStore(dataStore)
+	key := newTestKey()
+	attributes, err := json.Marshal(key)
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(`SELECT attributes FROM "key"  WHERE \(id = \$1\)`).
+		WithArgs(key.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"attributes"}).AddRow(attributes))
+
+	retrieved, err := keyStore.Retrieve(key.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, key, retrieved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestKeyStore_Retrieve_NotFound(t *testing.T) {
+	dataStore, mock, err := NewSQLMockDataStore()
+	assert.NoError(t, err)
+	keyStore := NewKeyStore(dataStore)
+	id := uuid.New()
+
+	mock.ExpectQuery(`SELECT attributes FROM "key"  WHERE \(id = \$1\)`).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"attributes"}))
+
+	_, err = keyStore.Retrieve(id)
+	assert.EqualError(t, err, commErr.RecordNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestKeyStore_Update(t *testing.T) {
+	dataStore, mock, err := NewSQLMockDataStore()
+	assert.NoError(t, err)
+	keyStore := NewKeyStore(dataStore)
+	key := newTestKey()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "key" SET .* WHERE \(id = \$6\)`).
+		WithArgs(key.Algorithm, sqlmock.AnyArg(), key.CurveType, key.KeyLength, key.TransferPolicyId, key.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	_, err = keyStore.Update(key)
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "key" SET`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	_, err = keyStore.Update(key)
+	assert.EqualError(t, err, commErr.RecordNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestKeyStore_UpdateWithLock(t *testing.T) {
+	dataStore, mock, err := NewSQLMockDataStore()
+	assert.NoError(t, err)
+	keyStore := NewKeyStore(dataStore)
+	key := newTestKey()
+	attributes, err := json.Marshal(key)
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT attributes FROM "key"  WHERE \(id = \$1\) FOR UPDATE`).
+		WithArgs(key.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"attributes"}).AddRow(attributes))
+	mock.ExpectExec(`UPDATE "key" SET`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	updated, err := keyStore.UpdateWithLock(key.ID, func(ka *models.KeyAttributes) error {
+		ka.Version++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, key.Version+1, updated.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestKeyStore_UpdateWithLock_UpdateFailure(t *testing.T) {
+	dataStore, mock, err := NewSQLMockDataStore()
+	assert.NoError(t, err)
+	keyStore := NewKeyStore(dataStore)
+	key := newTestKey()
+	attributes, err := json.Marshal(key)
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT attributes FROM "key"  WHERE \(id = \$1\) FOR UPDATE`).
+		WithArgs(key.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"attributes"}).AddRow(attributes))
+	mock.ExpectRollback()
+
+	_, err = keyStore.UpdateWithLock(key.ID, func(ka *models.KeyAttributes) error {
+		return errors.New("rotation failed")
+	})
+	assert.EqualError(t, err, "rotation failed")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestKeyStore_Delete(t *testing.T) {
+	dataStore, mock, err := NewSQLMockDataStore()
+	assert.NoError(t, err)
+	keyStore := NewKeyStore(dataStore)
+	id := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "key"  WHERE \(id = \$1\)`).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, keyStore.Delete(id))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "key"  WHERE \(id = \$1\)`).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	assert.EqualError(t, keyStore.Delete(id), commErr.RecordNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestKeyStore_Search(t *testing.T) {
+	dataStore, mock, err := NewSQLMockDataStore()
+	assert.NoError(t, err)
+	keyStore := NewKeyStore(dataStore)
+	key := newTestKey()
+	attributes, err := json.Marshal(key)
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(`SELECT attributes FROM "key"  WHERE \(algorithm = \$1\) AND \(key_length = \$2\) AND \(transfer_policy_id = \$3\) ORDER BY created_at`).
+		WithArgs(key.Algorithm, key.KeyLength, key.TransferPolicyId).
+		WillReturnRows(sqlmock.NewRows([]string{"attributes"}).AddRow(attributes))
+
+	keys, err := keyStore.Search(&models.KeyFilterCriteria{
+		Algorithm:        key.Algorithm,
+		KeyLength:        key.KeyLength,
+		TransferPolicyId: key.TransferPolicyId,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.KeyAttributes{*key}, keys)
+
+	mock.ExpectQuery(`SELECT attributes FROM "key"   ORDER BY created_at`).
+		WillReturnRows(sqlmock.NewRows([]string{"attributes"}))
+
+	keys, err = keyStore.Search(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/kbs"
+	"github.com/pkg/errors"
+)
+
+type KeyTransferPolicyStore struct {
+	Store *DataStore
+}
+
+func NewKeyTransferPolicyStore(store *DataStore) *KeyTransferPolicyStore {
+	return &KeyTransferPolicyStore{store}
+}
+
+func (ktps *KeyTransferPolicyStore) Create(policy *kbs.KeyTransferPolicyAttributes) (*kbs.KeyTransferPolicyAttributes, error) {
+	defaultLog.Trace("postgres/key_transfer_policy_store:Create() Entering")
+	defer defaultLog.Trace("postgres/key_transfer_policy_store:Create() Leaving")
+
+	newUuid, err := uuid.NewRandom()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/key_transfer_policy_store:Create() failed to create new UUID")
+	}
+	policy.ID = newUuid
+	policy.CreatedAt = time.Now().UTC()
+
+	return ktps.Import(policy)
+}
+
+// Import stores a key transfer policy keeping its ID and creation time, used when migrating from another data store
+func (ktps *KeyTransferPolicyStore) Import(policy *kbs.KeyTransferPolicyAttributes) (*kbs.KeyTransferPolicyAttributes, error) {
+	defaultLog.Trace("postgres/key_transfer_policy_store:Import() Entering")
+	defer defaultLog.Trace("postgres/key_transfer_policy_store:Import() Leaving")
+
+	dbPolicy := keyTransferPolicy{
+		ID:        policy.ID,
+		Content:   PGKeyTransferPolicy(*policy),
+		CreatedAt: policy.CreatedAt,
+	}
+
+	if err := ktps.Store.Db.Create(&dbPolicy).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/key_transfer_policy_store:Import() Failed to create key transfer policy")
+	}
+
+	return policy, nil
+}
+
+func (ktps *KeyTransferPolicyStore) Retrieve(id uuid.UUID) (*kbs.KeyTransferPolicyAttributes, error) {
+	defaultLog.Trace("postgres/key_transfer_policy_store:Retrieve() Entering")
+	defer defaultLog.Trace("postgres/key_transfer_policy_store:Retrieve() Leaving")
+
+	var policy kbs.KeyTransferPolicyAttributes
+	row := ktps.Store.Db.Model(&keyTransferPolicy{}).Select("content").Where("id = ?", id).Row()
+	if err := row.Scan((*PGKeyTransferPolicy)(&policy)); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New(commErr.RecordNotFound)
+		}
+		return nil, errors.Wrapf(err, "postgres/key_transfer_policy_store:Retrieve() Failed to retrieve key transfer policy : %s", id.String())
+	}
+
+	return &policy, nil
+}
+
+func (ktps *KeyTransferPolicyStore) Delete(id uuid.UUID) error {
+	defaultLog.Trace("postgres/key_transfer_policy_store:Delete() Entering")
+	defer defaultLog.Trace("postgres/key_transfer_policy_store:Delete() Leaving")
+
+	tx := ktps.Store.Db.Where("id = ?", id).Delete(&keyTransferPolicy{})
+	if tx.Error != nil {
+		return errors.Wrapf(tx.Error, "postgres/key_transfer_policy_store:Delete() Failed to delete key transfer policy : %s", id.String())
+	}
+	if tx.RowsAffected == 0 {
+		return errors.New(commErr.RecordNotFound)
+	}
+
+	return nil
+}
+
+func (ktps *KeyTransferPolicyStore) Search(criteria *models.KeyTransferPolicyFilterCriteria) ([]kbs.KeyTransferPolicyAttributes, error) {
+	defaultLog.Trace("postgres/key_transfer_policy_store:Search() Entering")
+	defer defaultLog.Trace("postgres/key_transfer_policy_store:Search() Leaving")
+
+	// KeyTransferPolicyFilterCriteria does not define any criteria yet, all the policies are returned
+	rows, err := ktps.Store.Db.Model(&keyTransferPolicy{}).Select("content").Order("created_at").Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/key_transfer_policy_store:Search() Failed to retrieve key transfer policies from db")
+	}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing rows")
+		}
+	}()
+
+	var policies = []kbs.KeyTransferPolicyAttributes{}
+	for rows.Next() {
+		var policy kbs.KeyTransferPolicyAttributes
+		if err := rows.Scan((*PGKeyTransferPolicy)(&policy)); err != nil {
+			return nil, errors.Wrap(err, "postgres/key_transfer_policy_store:Search() Failed to scan record")
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package postgres
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/kbs"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestKeyTransferPolicy() *kbs.KeyTransferPolicyAttributes {
+	return &kbs.KeyTransferPolicyAttributes{
+		ID:                    uuid.New(),
+		CreatedAt:             time.Now().UTC().Truncate(time.Second),
+		SGXEnclaveIssuerAnyof: []string{"cd171c56941c6ce49690b455f691d9c8a04c2e43e0a4d30f752fa5285c7ee96f"},
+		AttestationTypeAnyof:  []string{"SGX"},
+	}
+}
+
+func TestKeyTransferPolicyStore_Create(t *testing.T) {
+	dataStore, mock, err := NewSQLMockDataStore()
+	assert.NoError(t, err)
+	policyStore := NewKeyTransferPolicyStore(dataStore)
+	policy := newTestKeyTransferPolicy()
+	policy.ID = uuid.Nil
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "key_transfer_policy"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectCommit()
+
+	created, err := policyStore.Create(policy)
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, created.ID)
+	assert.False(t, created.CreatedAt.IsZero())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestKeyTransferPolicyStore_Import(t *testing.T) {
+	dataStore, mock, err := NewSQLMockDataStore()
+	assert.NoError(t, err)
+	policyStore := NewKeyTransferPolicyStore(dataStore)
+	policy := newTestKeyTransferPolicy()
+
+	// the imported policies keep their ID and creation time
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "key_transfer_policy"`).
+		WithArgs(policy.ID, sqlmock.AnyArg(), policy.CreatedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(policy.ID))
+	mock.ExpectCommit()
+
+	imported, err := policyStore.Import(policy)
+	assert.NoError(t, err)
+	assert.Equal(t, policy, imported)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestKeyTransferPolicyStore_Retrieve(t *testing.T) {
+	dataStore, mock, err := NewSQLMockDataStore()
+	assert.NoError(t, err)
+	policyStore := NewKeyTransferPolicyStore(dataStore)
+	policy := newTestKeyTransferPolicy()
+	content, err := json.Marshal(policy)
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(`SELECT content FROM "key_transfer_policy"  WHERE \(id = \$1\)`).
+		WithArgs(policy.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"content"}).AddRow(content))
+
+	retrieved, err := policyStore.Retrieve(policy.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, policy, retrieved)
+
+	mock.ExpectQuery(`SELECT content FROM "key_transfer_policy"  WHERE \(id = \$1\)`).
+		WithArgs(policy.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"content"}))
+
+	_, err = policyStore.Retrieve(policy.ID)
+	assert.EqualError(t, err, commErr.RecordNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestKeyTransferPolicyStore_Delete(t *testing.T) {
+	dataStore, mock, err := NewSQLMockDataStore()
+	assert.NoError(t, err)
+	policyStore := NewKeyTransferPolicyStore(dataStore)
+	id := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "key_transfer_policy"  WHERE \(id = \$1\)`).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, policyStore.Delete(id))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "key_transfer_policy"  WHERE \(id = \$1\)`).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	assert.EqualError(t, policyStore.Delete(id), commErr.RecordNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestKeyTransferPolicyStore_Search(t *testing.T) {
+	dataStore, mock, err := NewSQLMockDataStore()
+	assert.NoError(t, err)
+	policyStore := NewKeyTransferPolicyStore(dataStore)
+	policy := newTestKeyTransferPolicy()
+	content, err := json.Marshal(policy)
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(`SELECT content FROM "key_transfer_policy"   ORDER BY created_at`).
+		WillReturnRows(sqlmock.NewRows([]string{"content"}).AddRow(content))
+
+	policies, err := policyStore.Search(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []kbs.KeyTransferPolicyAttributes{*policy}, policies)
+
+	mock.ExpectQuery(`SELECT content FROM "key_transfer_policy"`).
+		WillReturnRows(sqlmock.NewRows([]string{"content"}).AddRow("not json"))
+
+	_, err = policyStore.Search(nil)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
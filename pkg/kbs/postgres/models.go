@@ -0,0 +1,78 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package postgres
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/kbs"
+	"github.com/pkg/errors"
+)
+
+// Define all struct types here
+type (
+	PGKeyAttributes     models.KeyAttributes
+	PGKeyTransferPolicy kbs.KeyTransferPolicyAttributes
+
+	// key holds the complete key attributes along with the columns the key search criteria are applied on
+	key struct {
+		ID               uuid.UUID       `gorm:"primary_key;type:uuid"`
+		Algorithm        string          `gorm:"not null;index:idx_key_algorithm"`
+		KeyLength        int             `gorm:"column:key_length"`
+		CurveType        string          `gorm:"column:curve_type"`
+		TransferPolicyId uuid.UUID       `gorm:"column:transfer_policy_id;type:uuid;index:idx_key_transfer_policy_id"`
+		Attributes       PGKeyAttributes `gorm:"not null" sql:"type:JSONB"`
+		CreatedAt        time.Time
+	}
+
+	keyTransferPolicy struct {
+		ID        uuid.UUID           `gorm:"primary_key;type:uuid"`
+		Content   PGKeyTransferPolicy `gorm:"not null" sql:"type:JSONB"`
+		CreatedAt time.Time
+	}
+
+	// certificate holds the SAML and TPM identity certificates, distinguished by type
+	certificate struct {
+		ID          uuid.UUID `gorm:"primary_key;type:uuid"`
+		Type        string    `gorm:"not null;index:idx_certificate_type"`
+		Certificate []byte    `gorm:"not null;type:bytea"`
+		Subject     string    `gorm:"not null"`
+		Issuer      string    `gorm:"not null"`
+		NotBefore   time.Time `gorm:"not null;column:notbefore"`
+		NotAfter    time.Time `gorm:"not null;column:notafter"`
+		Revoked     bool      `gorm:"not null"`
+		Digest      string    `gorm:"not null"`
+	}
+)
+
+func (ka PGKeyAttributes) Value() (driver.Value, error) {
+	return json.Marshal(ka)
+}
+
+func (ka *PGKeyAttributes) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("postgres/models:PGKeyAttributes_Scan() - type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &ka)
+}
+
+func (ktp PGKeyTransferPolicy) Value() (driver.Value, error) {
+	return json.Marshal(ktp)
+}
+
+func (ktp *PGKeyTransferPolicy) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("postgres/models:PGKeyTransferPolicy_Scan() - type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &ktp)
+}
@@ -8,17 +8,17 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/constants"
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/controllers"
-	"github.com/intel-secl/intel-secl/v3/pkg/kbs/directory"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
 )
 
 //setKeyTransferPolicyRoutes registers routes to perform KeyTransferPolicy CRUD operations
-func setKeyTransferPolicyRoutes(router *mux.Router) *mux.Router {
+func setKeyTransferPolicyRoutes(router *mux.Router, dataStore domain.DataStore) *mux.Router {
 	defaultLog.Trace("router/key_transfer_policy:setKeyTransferPolicyRoutes() Entering")
 	defer defaultLog.Trace("router/key_transfer_policy:setKeyTransferPolicyRoutes() Leaving")
 
-	keyStore := directory.NewKeyStore(constants.KeysDir)
-	policyStore := directory.NewKeyTransferPolicyStore(constants.KeysTransferPolicyDir)
+	keyStore := dataStore.KeyStore()
+	policyStore := dataStore.KeyTransferPolicyStore()
 	transferPolicyController := controllers.NewKeyTransferPolicyController(policyStore, keyStore)
 	keyTransferPolicyIdExpr := "/key-transfer-policies/" + validation.IdReg
 
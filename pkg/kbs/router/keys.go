@@ -9,7 +9,6 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/config"
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/constants"
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/controllers"
-	"github.com/intel-secl/intel-secl/v3/pkg/kbs/directory"
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain"
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/keymanager"
 	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
@@ -17,12 +16,12 @@ import (
 )
 
 //setKeyRoutes registers routes to perform Key CRUD operations
-func setKeyRoutes(router *mux.Router, endpointUrl string, config domain.KeyControllerConfig, keyManager keymanager.KeyManager) *mux.Router {
+func setKeyRoutes(router *mux.Router, endpointUrl string, dataStore domain.DataStore, config domain.KeyControllerConfig, keyManager keymanager.KeyManager) *mux.Router {
 	defaultLog.Trace("router/keys:setKeyRoutes() Entering")
 	defer defaultLog.Trace("router/keys:setKeyRoutes() Leaving")
 
-	keyStore := directory.NewKeyStore(constants.KeysDir)
-	policyStore := directory.NewKeyTransferPolicyStore(constants.KeysTransferPolicyDir)
+	keyStore := dataStore.KeyStore()
+	policyStore := dataStore.KeyTransferPolicyStore()
 	remoteManager := keymanager.NewRemoteManager(keyStore, keyManager, endpointUrl)
 	keyController := controllers.NewKeyController(remoteManager, policyStore, config)
 	keyIdExpr := "/keys/" + validation.IdReg
@@ -47,16 +46,20 @@ func setKeyRoutes(router *mux.Router, endpointUrl string, config domain.KeyContr
 		ErrorHandler(permissionsHandler(JsonResponseHandler(keyController.Transfer),
 			[]string{constants.KeyTransfer}))).Methods("POST")
 
+	router.Handle(keyIdExpr+"/rotate",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(keyController.Rotate),
+			[]string{constants.KeyRotate}))).Methods("POST")
+
 	return router
 }
 
 //setKeyTransferRoutes registers routes to perform Key Transfer operations
-func setKeyTransferRoutes(router *mux.Router, endpointUrl string, config domain.KeyControllerConfig, keyManager keymanager.KeyManager) *mux.Router {
+func setKeyTransferRoutes(router *mux.Router, endpointUrl string, dataStore domain.DataStore, config domain.KeyControllerConfig, keyManager keymanager.KeyManager) *mux.Router {
 	defaultLog.Trace("router/keys:setKeyTransferRoutes() Entering")
 	defer defaultLog.Trace("router/keys:setKeyTransferRoutes() Leaving")
 
-	keyStore := directory.NewKeyStore(constants.KeysDir)
-	policyStore := directory.NewKeyTransferPolicyStore(constants.KeysTransferPolicyDir)
+	keyStore := dataStore.KeyStore()
+	policyStore := dataStore.KeyTransferPolicyStore()
 	remoteManager := keymanager.NewRemoteManager(keyStore, keyManager, endpointUrl)
 	keyController := controllers.NewKeyController(remoteManager, policyStore, config)
 	keyIdExpr := "/keys/" + validation.IdReg
@@ -68,12 +71,12 @@ func setKeyTransferRoutes(router *mux.Router, endpointUrl string, config domain.
 }
 
 //setSKCKeyTransferRoutes registers routes to perform SKC Transfer operations
-func setSKCKeyTransferRoutes(router *mux.Router, kbsConfig *config.Configuration, keyManager keymanager.KeyManager) *mux.Router {
+func setSKCKeyTransferRoutes(router *mux.Router, kbsConfig *config.Configuration, dataStore domain.DataStore, keyManager keymanager.KeyManager) *mux.Router {
 	defaultLog.Trace("router/keys:setSKCKeyTransferRoutes() Entering")
 	defer defaultLog.Trace("router/keys:setSKCKeyTransferRoutes() Leaving")
 
-	keyStore := directory.NewKeyStore(constants.KeysDir)
-	policyStore := directory.NewKeyTransferPolicyStore(constants.KeysTransferPolicyDir)
+	keyStore := dataStore.KeyStore()
+	policyStore := dataStore.KeyTransferPolicyStore()
 	remoteManager := keymanager.NewRemoteManager(keyStore, keyManager, kbsConfig.EndpointURL)
 	skcController := controllers.NewSKCController(remoteManager, policyStore, kbsConfig, constants.TrustedCaCertsDir)
 	keyIdExpr := "/keys/" + validation.IdReg
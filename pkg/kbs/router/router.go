@@ -32,7 +32,7 @@ type Router struct {
 }
 
 // InitRoutes registers all routes for the application.
-func InitRoutes(cfg *config.Configuration, keyConfig domain.KeyControllerConfig, keyManager keymanager.KeyManager) *mux.Router {
+func InitRoutes(cfg *config.Configuration, dataStore domain.DataStore, keyConfig domain.KeyControllerConfig, keyManager keymanager.KeyManager) *mux.Router {
 	defaultLog.Trace("router/router:InitRoutes() Entering")
 	defer defaultLog.Trace("router/router:InitRoutes() Leaving")
 
@@ -43,22 +43,22 @@ func InitRoutes(cfg *config.Configuration, keyConfig domain.KeyControllerConfig,
 	router.SkipClean(true)
 
 	// Define sub routes for path /kbs/v1
-	defineSubRoutes(router, "/"+strings.ToLower(constants.ServiceName)+constants.ApiVersion, cfg, keyConfig, keyManager)
+	defineSubRoutes(router, "/"+strings.ToLower(constants.ServiceName)+constants.ApiVersion, cfg, dataStore, keyConfig, keyManager)
 
 	// Define sub routes for path /v1
-	defineSubRoutes(router, constants.ApiVersion, cfg, keyConfig, keyManager)
+	defineSubRoutes(router, constants.ApiVersion, cfg, dataStore, keyConfig, keyManager)
 
 	return router
 }
 
-func defineSubRoutes(router *mux.Router, serviceApi string, cfg *config.Configuration, keyConfig domain.KeyControllerConfig, keyManager keymanager.KeyManager) {
+func defineSubRoutes(router *mux.Router, serviceApi string, cfg *config.Configuration, dataStore domain.DataStore, keyConfig domain.KeyControllerConfig, keyManager keymanager.KeyManager) {
 	defaultLog.Trace("router/router:defineSubRoutes() Entering")
 	defer defaultLog.Trace("router/router:defineSubRoutes() Leaving")
 
 	subRouter := router.PathPrefix(serviceApi).Subrouter()
 	subRouter = setVersionRoutes(subRouter)
-	subRouter = setKeyTransferRoutes(subRouter, cfg.EndpointURL, keyConfig, keyManager)
-	subRouter = setSKCKeyTransferRoutes(subRouter, cfg, keyManager)
+	subRouter = setKeyTransferRoutes(subRouter, cfg.EndpointURL, dataStore, keyConfig, keyManager)
+	subRouter = setSKCKeyTransferRoutes(subRouter, cfg, dataStore, keyManager)
 	subRouter = setSessionRoutes(subRouter, cfg)
 	subRouter = router.PathPrefix(serviceApi).Subrouter()
 	cfgRouter := Router{cfg: cfg}
@@ -67,10 +67,10 @@ func defineSubRoutes(router *mux.Router, serviceApi string, cfg *config.Configur
 	subRouter.Use(cmw.NewTokenAuth(constants.TrustedJWTSigningCertsDir,
 		constants.TrustedCaCertsDir, cfgRouter.fnGetJwtCerts,
 		cacheTime))
-	subRouter = setKeyRoutes(subRouter, cfg.EndpointURL, keyConfig, keyManager)
-	subRouter = setKeyTransferPolicyRoutes(subRouter)
-	subRouter = setSamlCertRoutes(subRouter)
-	subRouter = setTpmIdentityCertRoutes(subRouter)
+	subRouter = setKeyRoutes(subRouter, cfg.EndpointURL, dataStore, keyConfig, keyManager)
+	subRouter = setKeyTransferPolicyRoutes(subRouter, dataStore)
+	subRouter = setSamlCertRoutes(subRouter, dataStore)
+	subRouter = setTpmIdentityCertRoutes(subRouter, dataStore)
 }
 
 // Fetch JWT certificate from AAS
@@ -12,13 +12,18 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gorilla/handlers"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/config"
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/directory"
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain"
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/keymanager"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/keytransfer"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/postgres"
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/router"
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/utils"
 	commLog "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
@@ -42,12 +47,33 @@ func (app *App) startServer() error {
 		return err
 	}
 
+	// Initialize DataStore
+	dataStore, err := initDataStore(configuration)
+	if err != nil {
+		return err
+	}
+	defer dataStore.Close()
+
 	// Initialize KeyControllerConfig
-	kcc, err := initKeyControllerConfig()
+	kcc, err := initKeyControllerConfig(configuration, dataStore)
 	if err != nil {
 		return err
 	}
 
+	// Check the AIK certificates against the CRL of the privacy CA, if configured
+	stopAikCrlRefresh := make(chan struct{})
+	defer close(stopAikCrlRefresh)
+	if configuration.AikRevocation.CrlUrl != "" {
+		aikCrl := keytransfer.NewAikCrl(configuration.AikRevocation.CrlUrl, constants.TrustedCaCertsDir, kcc.TpmIdentityCertStore)
+		if err := aikCrl.Refresh(); err != nil {
+			defaultLog.WithError(err).Error("kbs/server:startServer() Failed to download the CRL of the privacy CA, keys will not be transferred until it is available")
+		}
+		if configuration.AikRevocation.RefreshInterval > 0 {
+			go aikCrl.ScheduleRefresh(configuration.AikRevocation.RefreshInterval, stopAikCrlRefresh)
+		}
+		kcc.AikRevocationList = aikCrl
+	}
+
 	// Initialize KeyManager
 	km, err := keymanager.NewKeyManager(&configuration.Kmip, configuration.KeyManager)
 	if err != nil {
@@ -55,7 +81,15 @@ func (app *App) startServer() error {
 	}
 
 	// Initialize routes
-	routes := router.InitRoutes(configuration, kcc, km)
+	routes := router.InitRoutes(configuration, dataStore, kcc, km)
+
+	// Rotate keys as per the rotation interval of their transfer policies
+	stopKeyRotation := make(chan struct{})
+	defer close(stopKeyRotation)
+	if configuration.KeyRotation.CheckInterval > 0 {
+		remoteManager := keymanager.NewRemoteManager(dataStore.KeyStore(), km, configuration.EndpointURL)
+		go remoteManager.ScheduleKeyRotation(dataStore.KeyTransferPolicyStore(), configuration.KeyRotation.CheckInterval, kcc.KeyRotationGracePeriod, stopKeyRotation)
+	}
 
 	defaultLog.Info("kbs/server:startServer() Starting server")
 	tlsConfig := &tls.Config{
@@ -106,7 +140,21 @@ func (app *App) startServer() error {
 	return nil
 }
 
-func initKeyControllerConfig() (domain.KeyControllerConfig, error) {
+func initDataStore(configuration *config.Configuration) (domain.DataStore, error) {
+	defaultLog.Trace("server:initDataStore() Entering")
+	defer defaultLog.Trace("server:initDataStore() Leaving")
+
+	if strings.ToLower(configuration.DataStore) == constants.PostgresDataStore {
+		dataStore, err := postgres.InitDatabase(&configuration.DB)
+		if err != nil {
+			return nil, errors.Wrap(err, "server:initDataStore() Failed to initialize postgres data store")
+		}
+		return dataStore, nil
+	}
+	return directory.NewDataStore(), nil
+}
+
+func initKeyControllerConfig(configuration *config.Configuration, dataStore domain.DataStore) (domain.KeyControllerConfig, error) {
 	defaultLog.Trace("server:initKeyControllerConfig() Entering")
 	defer defaultLog.Trace("server:initKeyControllerConfig() Leaving")
 
@@ -116,10 +164,11 @@ func initKeyControllerConfig() (domain.KeyControllerConfig, error) {
 	}
 
 	kcc := domain.KeyControllerConfig{
-		SamlCertsDir:            constants.SamlCertsDir,
+		SamlCertStore:           dataStore.SamlCertificateStore(),
 		TrustedCaCertsDir:       constants.TrustedCaCertsDir,
-		TpmIdentityCertsDir:     constants.TpmIdentityCertsDir,
+		TpmIdentityCertStore:    dataStore.TpmIdentityCertificateStore(),
 		DefaultTransferPolicyId: id,
+		KeyRotationGracePeriod:  configuration.KeyRotation.GracePeriod,
 	}
 	return kcc, nil
 }
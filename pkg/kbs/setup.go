@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/directory"
 	"github.com/intel-secl/intel-secl/v3/pkg/kbs/tasks"
 	commConfig "github.com/intel-secl/intel-secl/v3/pkg/lib/common/config"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/setup"
@@ -125,8 +126,8 @@ func (app *App) setupTaskRunner() (*setup.Runner, error) {
 	runner.AddTask("download-cert-tls", "tls", &setup.DownloadCert{
 		KeyFile:      viper.GetString("tls-key-file"),
 		CertFile:     viper.GetString("tls-cert-file"),
-		KeyAlgorithm: constants.DefaultKeyAlgorithm,
-		KeyLength:    constants.DefaultKeyLength,
+		KeyAlgorithm: viper.GetString("tls-key-algorithm"),
+		KeyLength:    viper.GetInt("tls-key-length"),
 		Subject: pkix.Name{
 			CommonName: viper.GetString("tls-common-name"),
 		},
@@ -137,6 +138,19 @@ func (app *App) setupTaskRunner() (*setup.Runner, error) {
 		CmsBaseURL:    viper.GetString("cms-base-url"),
 		BearerToken:   viper.GetString("bearer-token"),
 	})
+	runner.AddTask("database", "", &tasks.DBSetup{
+		DBConfigPtr:   &app.Config.DB,
+		DBConfig:      app.Config.DB,
+		SSLCertSource: viper.GetString("db-ssl-cert-source"),
+		DataStore:     viper.GetString("data-store"),
+		ConsoleWriter: app.consoleWriter(),
+	})
+	runner.AddTask("migrate-directory-store", "", &tasks.MigrateDirectoryStore{
+		DataStore:     viper.GetString("data-store"),
+		DBConfigPtr:   &app.Config.DB,
+		Source:        directory.NewDataStore(),
+		ConsoleWriter: app.consoleWriter(),
+	})
 	runner.AddTask("create-default-key-transfer-policy", "", &tasks.CreateDefaultTransferPolicy{
 		DefaultTransferPolicyFile: constants.DefaultTransferPolicyFile,
 		ConsoleWriter:             app.consoleWriter(),
@@ -166,8 +180,8 @@ func (app *App) downloadCertTask(certType string) setup.Task {
 	return &setup.DownloadCert{
 		KeyFile:      viper.GetString(certType + "-key-file"),
 		CertFile:     viper.GetString(certType + "-cert-file"),
-		KeyAlgorithm: constants.DefaultKeyAlgorithm,
-		KeyLength:    constants.DefaultKeyLength,
+		KeyAlgorithm: viper.GetString(certType + "-key-algorithm"),
+		KeyLength:    viper.GetInt(certType + "-key-length"),
 		Subject: pkix.Name{
 			CommonName: viper.GetString(certType + "-common-name"),
 		},
@@ -0,0 +1,226 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package tasks
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/postgres"
+	commConfig "github.com/intel-secl/intel-secl/v3/pkg/lib/common/config"
+	cos "github.com/intel-secl/intel-secl/v3/pkg/lib/common/os"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/setup"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
+	"github.com/pkg/errors"
+)
+
+// DBSetup configures the database used by the postgres data store, it is a no-op for the directory data store
+type DBSetup struct {
+	// embedded structure for holding new configuation
+	commConfig.DBConfig
+	SSLCertSource string
+	DataStore     string
+
+	// the pointer to configuration structure
+	DBConfigPtr   *commConfig.DBConfig
+	ConsoleWriter io.Writer
+
+	envPrefix   string
+	commandName string
+}
+
+const DbEnvHelpPrompt = "Following environment variables are required for Database related setups:"
+
+var DbEnvHelp = map[string]string{
+	"DATA_STORE":             "Data store used to persist keys, key transfer policies and certificates, directory or postgres",
+	"DB_VENDOR":              "Vendor of database, or use KBS_DB_VENDOR alternatively",
+	"DB_HOST":                "Database host name, or use KBS_DB_HOSTNAME alternatively",
+	"DB_PORT":                "Database port, or use KBS_DB_PORT alternatively",
+	"DB_NAME":                "Database name, or use KBS_DB_NAME alternatively",
+	"DB_USERNAME":            "Database username, or use KBS_DB_USERNAME alternatively",
+	"DB_PASSWORD":            "Database password, or use KBS_DB_PASSWORD alternatively",
+	"DB_SSL_MODE":            "Database SSL mode, or use KBS_DB_SSL_MODE alternatively",
+	"DB_SSL_CERT":            "Database SSL certificate, or use KBS_DB_SSLCERT alternatively",
+	"DB_SSL_CERT_SOURCE":     "Database SSL certificate to be copied from, or use KBS_DB_SSLCERTSRC alternatively",
+	"DB_CONN_RETRY_ATTEMPTS": "Database connection retry attempts",
+	"DB_CONN_RETRY_TIME":     "Database connection retry time",
+}
+
+func (t *DBSetup) Run() error {
+	if !isPostgresDataStore(t.DataStore) {
+		fmt.Fprintln(t.ConsoleWriter, "Data store is not postgres, skipping database setup")
+		return nil
+	}
+	if t.DBConfigPtr == nil {
+		return errors.New("Pointer to database configuration structure can not be nil")
+	}
+	// validate input values
+	if t.Vendor == "" {
+		return errors.New("DB_VENDOR is not set, or use KBS_DB_VENDOR alternatively")
+	}
+	if t.Host == "" {
+		return errors.New("DB_HOST is not set, or use KBS_DB_HOSTNAME alternatively")
+	}
+	if t.Port == 0 {
+		return errors.New("DB_PORT is not set, or use KBS_DB_PORT alternatively")
+	}
+	if t.DBName == "" {
+		return errors.New("DB_NAME is not set, or use KBS_DB_NAME alternatively")
+	}
+	if t.Username == "" {
+		return errors.New("DB_USERNAME is not set, or use KBS_DB_USERNAME alternatively")
+	}
+	if t.Password == "" {
+		return errors.New("DB_PASSWORD is not set, or use KBS_DB_PASSWORD alternatively")
+	}
+	if t.SSLMode == "" {
+		t.SSLMode = constants.SslModeAllow
+	}
+	if t.ConnectionRetryAttempts < 0 {
+		t.ConnectionRetryAttempts = constants.DefaultDbConnRetryAttempts
+	}
+	if t.ConnectionRetryTime < 0 {
+		t.ConnectionRetryTime = constants.DefaultDbConnRetryTime
+	}
+	// set to default value
+	if t.SSLCert == "" {
+		t.SSLCert = constants.DefaultSSLCertFilePath
+	}
+	// populates the configuration structure
+	t.DBConfigPtr.Vendor = t.Vendor
+	t.DBConfigPtr.Host = t.Host
+	t.DBConfigPtr.Port = t.Port
+	t.DBConfigPtr.DBName = t.DBName
+	t.DBConfigPtr.Username = t.Username
+	t.DBConfigPtr.Password = t.Password
+
+	t.DBConfigPtr.ConnectionRetryAttempts = t.ConnectionRetryAttempts
+	t.DBConfigPtr.ConnectionRetryTime = t.ConnectionRetryTime
+
+	var validErr error
+	validErr = validation.ValidateHostname(t.DBConfig.Host)
+	if validErr != nil {
+		return errors.Wrap(validErr, "setup database: Validation failed on db host")
+	}
+	validErr = validation.ValidateAccount(t.DBConfig.Username, t.DBConfig.Password)
+	if validErr != nil {
+		return errors.Wrap(validErr, "setup database: Validation failed on db credentials")
+	}
+	validErr = validation.ValidateIdentifier(t.DBConfig.DBName)
+	if validErr != nil {
+		return errors.Wrap(validErr, "setup database: Validation failed on db name")
+	}
+
+	t.DBConfigPtr.SSLMode, t.DBConfigPtr.SSLCert, validErr = configureDBSSLParams(
+		t.SSLMode, t.SSLCertSource, t.SSLCert)
+	if validErr != nil {
+		return errors.Wrap(validErr, "setup database: Validation failed on ssl settings")
+	}
+	// test connection and create schemas
+	fmt.Fprintln(t.ConsoleWriter, "Connecting to DB and create schemas")
+	dataStore, err := postgres.New(pgConfig(t.DBConfigPtr))
+	if err != nil {
+		return errors.Wrap(err, "Failed to connect database")
+	}
+	defer dataStore.Close()
+	dataStore.Migrate()
+	return nil
+}
+
+func (t *DBSetup) Validate() error {
+	if !isPostgresDataStore(t.DataStore) {
+		return nil
+	}
+	if t.DBConfigPtr == nil {
+		return errors.New("Pointer to database configuration structure can not be nil")
+	}
+	fmt.Fprintln(t.ConsoleWriter, "Validating DB args")
+	// check everything set
+	if t.DBConfigPtr.Vendor == "" ||
+		t.DBConfigPtr.Host == "" ||
+		t.DBConfigPtr.Port == 0 ||
+		t.DBConfigPtr.DBName == "" ||
+		t.DBConfigPtr.Username == "" ||
+		t.DBConfigPtr.Password == "" ||
+		t.DBConfigPtr.SSLMode == "" ||
+		t.DBConfigPtr.SSLCert == "" {
+		return errors.New("invalid database configuration")
+	}
+	// check if SSL certificate exists
+	if t.DBConfigPtr.SSLMode == constants.SslModeVerifyCa ||
+		t.DBConfigPtr.SSLMode == constants.SslModeVerifyFull {
+		if _, err := os.Stat(t.DBConfigPtr.SSLCert); os.IsNotExist(err) {
+			return err
+		}
+	}
+	// test connection
+	dataStore, err := postgres.New(pgConfig(t.DBConfigPtr))
+	if err != nil {
+		return errors.Wrap(err, "Failed to connect database")
+	}
+	dataStore.Close()
+	return nil
+}
+
+func (t *DBSetup) PrintHelp(w io.Writer) {
+	setup.PrintEnvHelp(w, DbEnvHelpPrompt, t.envPrefix, DbEnvHelp)
+	fmt.Fprintln(w, "")
+}
+
+func (t *DBSetup) SetName(n, e string) {
+	t.commandName = n
+	t.envPrefix = setup.PrefixUnderscroll(e)
+}
+
+func isPostgresDataStore(dataStore string) bool {
+	return strings.ToLower(strings.TrimSpace(dataStore)) == constants.PostgresDataStore
+}
+
+func configureDBSSLParams(sslMode, sslCertSrc, sslCert string) (string, string, error) {
+	sslMode = strings.TrimSpace(strings.ToLower(sslMode))
+	sslCert = strings.TrimSpace(sslCert)
+	sslCertSrc = strings.TrimSpace(sslCertSrc)
+
+	if sslMode != constants.SslModeAllow && sslMode != constants.SslModePrefer &&
+		sslMode != constants.SslModeVerifyCa && sslMode != constants.SslModeRequire {
+		sslMode = constants.SslModeVerifyFull
+	}
+
+	if sslMode == constants.SslModeVerifyCa || sslMode == constants.SslModeVerifyFull {
+		// cover different scenarios
+		if sslCertSrc == "" && sslCert != "" {
+			if _, err := os.Stat(sslCert); os.IsNotExist(err) {
+				return "", "", errors.Wrapf(err, "certificate source file not specified and sslcert %s does not exist", sslCert)
+			}
+			return sslMode, sslCert, nil
+		}
+		if sslCertSrc == "" {
+			return "", "", errors.New("verify-ca or verify-full needs a source cert file to copy from unless db-sslcert exists")
+		}
+		if _, err := os.Stat(sslCertSrc); os.IsNotExist(err) {
+			return "", "", errors.Wrapf(err, "certificate source file not specified and sslcert %s does not exist", sslCertSrc)
+		}
+		// at this point if sslCert destination is not passed it, lets set to default
+		if sslCert == "" {
+			sslCert = constants.DefaultSSLCertFilePath
+		}
+		// lets try to copy the file now. If copy does not succeed return the file copy error
+		if err := cos.Copy(sslCertSrc, sslCert); err != nil {
+			return "", "", errors.Wrap(err, "failed to copy file")
+		}
+		// set permissions so that non root users can read the copied file
+		if err := os.Chmod(sslCert, 0644); err != nil {
+			return "", "", errors.Wrapf(err, "could not apply permissions to %s", sslCert)
+		}
+	}
+	return sslMode, sslCert, nil
+}
+
+func pgConfig(t *commConfig.DBConfig) *postgres.Config {
+	return postgres.NewDatabaseConfig(t.Vendor, t)
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package tasks
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/directory"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/postgres"
+	commConfig "github.com/intel-secl/intel-secl/v3/pkg/lib/common/config"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	"github.com/pkg/errors"
+)
+
+// MigrateDirectoryStore imports the keys, key transfer policies and certificates of an existing
+// directory data store into the postgres data store. Records already present in postgres are skipped,
+// so the task can be run again safely.
+type MigrateDirectoryStore struct {
+	DataStore     string
+	DBConfigPtr   *commConfig.DBConfig
+	Source        *directory.DataStore
+	ConsoleWriter io.Writer
+
+	commandName string
+}
+
+func (t *MigrateDirectoryStore) Run() error {
+	if !isPostgresDataStore(t.DataStore) {
+		fmt.Fprintln(t.ConsoleWriter, "Data store is not postgres, skipping directory store migration")
+		return nil
+	}
+	if t.DBConfigPtr == nil || t.Source == nil {
+		return errors.New("tasks/migrate_directory_store:Run() Database configuration and source directory store must be set")
+	}
+
+	dataStore, err := postgres.New(pgConfig(t.DBConfigPtr))
+	if err != nil {
+		return errors.Wrap(err, "tasks/migrate_directory_store:Run() Failed to connect database")
+	}
+	defer dataStore.Close()
+	dataStore.Migrate()
+
+	return t.migrate(dataStore)
+}
+
+func (t *MigrateDirectoryStore) migrate(dataStore *postgres.DataStore) error {
+	// policies are migrated first as the keys refer to them
+	if err := t.migrateKeyTransferPolicies(dataStore); err != nil {
+		return err
+	}
+	if err := t.migrateKeys(dataStore); err != nil {
+		return err
+	}
+	if err := t.migrateCertificates("SAML", t.Source.SamlCertsDir, t.Source.SamlCertificateStore(),
+		postgres.NewCertificateStore(dataStore, constants.SamlCertType)); err != nil {
+		return err
+	}
+	return t.migrateCertificates("TPM identity", t.Source.TpmIdentityCertsDir, t.Source.TpmIdentityCertificateStore(),
+		postgres.NewCertificateStore(dataStore, constants.TpmIdentityCertType))
+}
+
+func (t *MigrateDirectoryStore) migrateKeyTransferPolicies(dataStore *postgres.DataStore) error {
+	if !dirExists(t.Source.KeysTransferPolicyDir) {
+		return nil
+	}
+
+	policies, err := t.Source.KeyTransferPolicyStore().Search(nil)
+	if err != nil {
+		return errors.Wrap(err, "tasks/migrate_directory_store:migrateKeyTransferPolicies() Failed to read key transfer policies")
+	}
+
+	policyStore := postgres.NewKeyTransferPolicyStore(dataStore)
+	migrated := 0
+	for i := range policies {
+		_, err = policyStore.Retrieve(policies[i].ID)
+		if err == nil {
+			continue
+		}
+		if err.Error() != commErr.RecordNotFound {
+			return errors.Wrapf(err, "tasks/migrate_directory_store:migrateKeyTransferPolicies() Failed to retrieve key transfer policy %s", policies[i].ID)
+		}
+		if _, err = policyStore.Import(&policies[i]); err != nil {
+			return errors.Wrapf(err, "tasks/migrate_directory_store:migrateKeyTransferPolicies() Failed to import key transfer policy %s", policies[i].ID)
+		}
+		migrated++
+	}
+	fmt.Fprintf(t.ConsoleWriter, "Migrated %d of %d key transfer policies\n", migrated, len(policies))
+	return nil
+}
+
+func (t *MigrateDirectoryStore) migrateKeys(dataStore *postgres.DataStore) error {
+	if !dirExists(t.Source.KeysDir) {
+		return nil
+	}
+
+	keys, err := t.Source.KeyStore().Search(nil)
+	if err != nil {
+		return errors.Wrap(err, "tasks/migrate_directory_store:migrateKeys() Failed to read keys")
+	}
+
+	keyStore := postgres.NewKeyStore(dataStore)
+	migrated := 0
+	for i := range keys {
+		_, err = keyStore.Retrieve(keys[i].ID)
+		if err == nil {
+			continue
+		}
+		if err.Error() != commErr.RecordNotFound {
+			return errors.Wrapf(err, "tasks/migrate_directory_store:migrateKeys() Failed to retrieve key %s", keys[i].ID)
+		}
+		if _, err = keyStore.Create(&keys[i]); err != nil {
+			return errors.Wrapf(err, "tasks/migrate_directory_store:migrateKeys() Failed to import key %s", keys[i].ID)
+		}
+		migrated++
+	}
+	fmt.Fprintf(t.ConsoleWriter, "Migrated %d of %d keys\n", migrated, len(keys))
+	return nil
+}
+
+func (t *MigrateDirectoryStore) migrateCertificates(certType, certsDir string, source domain.CertificateStore, certStore *postgres.CertificateStore) error {
+	if !dirExists(certsDir) {
+		return nil
+	}
+
+	certs, err := source.Search(nil)
+	if err != nil {
+		return errors.Wrapf(err, "tasks/migrate_directory_store:migrateCertificates() Failed to read %s certificates", certType)
+	}
+
+	migrated := 0
+	for i := range certs {
+		_, err = certStore.Retrieve(certs[i].ID)
+		if err == nil {
+			continue
+		}
+		if err.Error() != commErr.RecordNotFound {
+			return errors.Wrapf(err, "tasks/migrate_directory_store:migrateCertificates() Failed to retrieve %s certificate %s", certType, certs[i].ID)
+		}
+		if _, err = certStore.Import(&certs[i]); err != nil {
+			return errors.Wrapf(err, "tasks/migrate_directory_store:migrateCertificates() Failed to import %s certificate %s", certType, certs[i].ID)
+		}
+		migrated++
+	}
+	fmt.Fprintf(t.ConsoleWriter, "Migrated %d of %d %s certificates\n", migrated, len(certs), certType)
+	return nil
+}
+
+func (t *MigrateDirectoryStore) Validate() error {
+	return nil
+}
+
+func (t *MigrateDirectoryStore) PrintHelp(w io.Writer) {
+	fmt.Fprintln(w, "Imports the keys, key transfer policies and certificates of the directory data store into the postgres data store.")
+	fmt.Fprintln(w, "Requires DATA_STORE=postgres and the database settings of the database setup task")
+	fmt.Fprintln(w, "")
+}
+
+func (t *MigrateDirectoryStore) SetName(n, e string) {
+	t.commandName = n
+}
+
+func dirExists(dir string) bool {
+	info, err := os.Stat(dir)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package tasks
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/directory"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/kbs/postgres"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/kbs"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	selectPolicyQuery = `SELECT content FROM "key_transfer_policy"  WHERE \(id = \$1\)`
+	selectKeyQuery    = `SELECT attributes FROM "key"  WHERE \(id = \$1\)`
+)
+
+// newTestDirectoryStore returns a directory store holding a key transfer policy and a key referring to it,
+// the certificate directories are not created so that their migration is skipped
+func newTestDirectoryStore(t *testing.T, dir string) (*directory.DataStore, *kbs.KeyTransferPolicyAttributes, *models.KeyAttributes) {
+	source := &directory.DataStore{
+		KeysDir:               filepath.Join(dir, "keys"),
+		KeysTransferPolicyDir: filepath.Join(dir, "keys-transfer-policy"),
+		SamlCertsDir:          filepath.Join(dir, "saml"),
+		TpmIdentityCertsDir:   filepath.Join(dir, "tpm-identity"),
+	}
+	assert.NoError(t, os.Mkdir(source.KeysDir, 0700))
+	assert.NoError(t, os.Mkdir(source.KeysTransferPolicyDir, 0700))
+
+	policy, err := source.KeyTransferPolicyStore().Create(&kbs.KeyTransferPolicyAttributes{
+		SGXEnclaveIssuerAnyof: []string{"cd171c56941c6ce49690b455f691d9c8a04c2e43e0a4d30f752fa5285c7ee96f"},
+	})
+	assert.NoError(t, err)
+
+	key, err := source.KeyStore().Create(&models.KeyAttributes{
+		ID:               uuid.New(),
+		Algorithm:        "AES",
+		KeyLength:        256,
+		KeyData:          "a2V5LWRhdGE=",
+		TransferPolicyId: policy.ID,
+		CreatedAt:        time.Now().UTC(),
+	})
+	assert.NoError(t, err)
+	return source, policy, key
+}
+
+func TestMigrateDirectoryStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kbs-migrate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	source, policy, key := newTestDirectoryStore(t, dir)
+
+	dataStore, mock, err := postgres.NewSQLMockDataStore()
+	assert.NoError(t, err)
+	var console bytes.Buffer
+	task := MigrateDirectoryStore{Source: source, ConsoleWriter: &console}
+
+	mock.ExpectQuery(selectPolicyQuery).WithArgs(policy.ID).WillReturnRows(sqlmock.NewRows([]string{"content"}))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "key_transfer_policy"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(policy.ID))
+	mock.ExpectCommit()
+	mock.ExpectQuery(selectKeyQuery).WithArgs(key.ID).WillReturnRows(sqlmock.NewRows([]string{"attributes"}))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "key"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(key.ID))
+	mock.ExpectCommit()
+
+	assert.NoError(t, task.migrate(dataStore))
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, "Migrated 1 of 1 key transfer policies\nMigrated 1 of 1 keys\n", console.String())
+}
+
+func TestMigrateDirectoryStore_PartialFailureAndRerun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kbs-migrate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	source, policy, key := newTestDirectoryStore(t, dir)
+	policyContent, err := json.Marshal(policy)
+	assert.NoError(t, err)
+
+	// the policy is imported, the key import fails and the migration is aborted
+	dataStore, mock, err := postgres.NewSQLMockDataStore()
+	assert.NoError(t, err)
+	var console bytes.Buffer
+	task := MigrateDirectoryStore{Source: source, ConsoleWriter: &console}
+
+	mock.ExpectQuery(selectPolicyQuery).WithArgs(policy.ID).WillReturnRows(sqlmock.NewRows([]string{"content"}))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "key_transfer_policy"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(policy.ID))
+	mock.ExpectCommit()
+	mock.ExpectQuery(selectKeyQuery).WithArgs(key.ID).WillReturnRows(sqlmock.NewRows([]string{"attributes"}))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "key"`).WillReturnError(errors.New("connection reset by peer"))
+	mock.ExpectRollback()
+
+	err = task.migrate(dataStore)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), key.ID.String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, "Migrated 1 of 1 key transfer policies\n", console.String())
+
+	// re-running the migration skips the already imported policy and imports the key
+	dataStore, mock, err = postgres.NewSQLMockDataStore()
+	assert.NoError(t, err)
+	console.Reset()
+
+	mock.ExpectQuery(selectPolicyQuery).WithArgs(policy.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"content"}).AddRow(policyContent))
+	mock.ExpectQuery(selectKeyQuery).WithArgs(key.ID).WillReturnRows(sqlmock.NewRows([]string{"attributes"}))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "key"`).
+		WithArgs(key.ID, key.Algorithm, key.KeyLength, key.CurveType, policy.ID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(key.ID))
+	mock.ExpectCommit()
+
+	assert.NoError(t, task.migrate(dataStore))
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, "Migrated 0 of 1 key transfer policies\nMigrated 1 of 1 keys\n", console.String())
+}
+
+func TestMigrateDirectoryStore_RetrieveFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kbs-migrate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	source, policy, _ := newTestDirectoryStore(t, dir)
+
+	// lookup errors other than a missing record abort the migration instead of importing duplicates
+	dataStore, mock, err := postgres.NewSQLMockDataStore()
+	assert.NoError(t, err)
+	task := MigrateDirectoryStore{Source: source, ConsoleWriter: ioutil.Discard}
+
+	mock.ExpectQuery(selectPolicyQuery).WithArgs(policy.ID).WillReturnError(errors.New("connection reset by peer"))
+
+	assert.Error(t, task.migrate(dataStore))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
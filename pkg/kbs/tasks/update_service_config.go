@@ -28,26 +28,29 @@ type UpdateServiceConfig struct {
 const envHelpPrompt = "Following environment variables are required for update-service-config setup:"
 
 var envHelp = map[string]string{
-	"SERVICE_USERNAME":           "The service username as configured in AAS",
-	"SERVICE_PASSWORD":           "The service password as configured in AAS",
-	"LOG_LEVEL":                  "Log level",
-	"LOG_MAX_LENGTH":             "Max length of log statement",
-	"LOG_ENABLE_STDOUT":          "Enable console log",
-	"AAS_BASE_URL":               "AAS Base URL",
-	"KMIP_SERVER_IP":             "IP of KMIP server",
-	"KMIP_SERVER_PORT":           "PORT of KMIP server",
-	"KMIP_CLIENT_CERT_PATH":      "KMIP Client certificate path",
-	"KMIP_CLIENT_KEY_PATH":       "KMIP Client key path",
-	"KMIP_ROOT_CERT_PATH":        "KMIP Root Certificate path",
-	"SKC_CHALLENGE_TYPE":         "SKC challenge type",
-	"SQVS_URL":                   "SQVS URL",
-	"SESSION_EXPIRY_TIME":        "Session Expiry Time",
-	"SERVER_PORT":                "The Port on which Server Listens to",
-	"SERVER_READ_TIMEOUT":        "Request Read Timeout Duration in Seconds",
-	"SERVER_READ_HEADER_TIMEOUT": "Request Read Header Timeout Duration in Seconds",
-	"SERVER_WRITE_TIMEOUT":       "Request Write Timeout Duration in Seconds",
-	"SERVER_IDLE_TIMEOUT":        "Request Idle Timeout in Seconds",
-	"SERVER_MAX_HEADER_BYTES":    "Max Length Of Request Header in Bytes ",
+	"SERVICE_USERNAME":            "The service username as configured in AAS",
+	"SERVICE_PASSWORD":            "The service password as configured in AAS",
+	"LOG_LEVEL":                   "Log level",
+	"LOG_MAX_LENGTH":              "Max length of log statement",
+	"LOG_ENABLE_STDOUT":           "Enable console log",
+	"AAS_BASE_URL":                "AAS Base URL",
+	"KMIP_SERVER_IP":              "IP of KMIP server",
+	"KMIP_SERVER_PORT":            "PORT of KMIP server",
+	"KMIP_CLIENT_CERT_PATH":       "KMIP Client certificate path",
+	"KMIP_CLIENT_KEY_PATH":        "KMIP Client key path",
+	"KMIP_ROOT_CERT_PATH":         "KMIP Root Certificate path",
+	"SKC_CHALLENGE_TYPE":          "SKC challenge type",
+	"SQVS_URL":                    "SQVS URL",
+	"SESSION_EXPIRY_TIME":         "Session Expiry Time",
+	"SERVER_PORT":                 "The Port on which Server Listens to",
+	"SERVER_READ_TIMEOUT":         "Request Read Timeout Duration in Seconds",
+	"SERVER_READ_HEADER_TIMEOUT":  "Request Read Header Timeout Duration in Seconds",
+	"SERVER_WRITE_TIMEOUT":        "Request Write Timeout Duration in Seconds",
+	"SERVER_IDLE_TIMEOUT":         "Request Idle Timeout in Seconds",
+	"SERVER_MAX_HEADER_BYTES":     "Max Length Of Request Header in Bytes ",
+	"KEY_ROTATION_GRACE_PERIOD":   "Duration for which a key version replaced by a rotation can still be transferred",
+	"KEY_ROTATION_CHECK_INTERVAL": "Interval at which keys due for scheduled rotation are rotated",
+	"DATA_STORE":                  "Data store for keys, key transfer policies and certificates, directory or postgres",
 }
 
 func (uc UpdateServiceConfig) Run() error {
@@ -73,6 +76,7 @@ func (uc UpdateServiceConfig) Run() error {
 		Level:        viper.GetString("log-level"),
 	}
 	(*uc.AppConfig).EndpointURL = viper.GetString("endpoint-url")
+	(*uc.AppConfig).DataStore = viper.GetString("data-store")
 	(*uc.AppConfig).Kmip = config.KmipConfig{
 		Version:    viper.GetString("kmip-version"),
 		ServerIP:   viper.GetString("kmip-server-ip"),
@@ -86,6 +90,14 @@ func (uc UpdateServiceConfig) Run() error {
 		SQVSUrl:           viper.GetString("sqvs-url"),
 		SessionExpiryTime: viper.GetInt("session-expiry-time"),
 	}
+	(*uc.AppConfig).KeyRotation = config.KeyRotationConfig{
+		GracePeriod:   viper.GetDuration("key-rotation-grace-period"),
+		CheckInterval: viper.GetDuration("key-rotation-check-interval"),
+	}
+	(*uc.AppConfig).AikRevocation = config.AikRevocationConfig{
+		CrlUrl:          viper.GetString("aik-revocation-crl-url"),
+		RefreshInterval: viper.GetDuration("aik-revocation-refresh-interval"),
+	}
 	return nil
 }
 
@@ -12,4 +12,9 @@ const (
 	HTTPMediaTypeSaml        = "application/samlassertion+xml"
 	HTTPMediaTypePemFile     = "application/x-pem-file"
 	HTTPMediaTypeOctetStream = "application/octet-stream"
+	HTTPMediaTypeEventStream = "text/event-stream"
+	HTTPMediaTypeCsv         = "text/csv"
+	HTTPMediaTypeJsonLines   = "application/x-ndjson"
+	HTTPMediaTypeHtml        = "text/html"
+	HTTPMediaTypePdf         = "application/pdf"
 )
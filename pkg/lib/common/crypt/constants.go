@@ -10,3 +10,10 @@ const (
 	EncryptionHeaderVersion   = "V1"
 	GCMEncryptionAlgorithm    = "GCM-256"
 )
+
+// Key types supported when generating key pairs and certificate requests
+const (
+	KeyTypeRSA    = "rsa"
+	KeyTypeRSAPSS = "rsa-pss"
+	KeyTypeECDSA  = "ecdsa"
+)
@@ -28,10 +28,12 @@ import (
 	"time"
 )
 
+// GenerateKeyPair generates a key pair of the given type. "rsa" and "rsa-pss" keys share the same RSA key
+// material, the difference only lies in the signature scheme selected by GetSignatureAlgorithmForKeyType.
 func GenerateKeyPair(keyType string, keyLength int) (crypto.PrivateKey, crypto.PublicKey, error) {
 
 	switch strings.ToLower(keyType) {
-	case "rsa":
+	case KeyTypeRSA, KeyTypeRSAPSS:
 		if keyLength != 4096 {
 			keyLength = 3072
 		}
@@ -43,12 +45,10 @@ func GenerateKeyPair(keyType string, keyLength int) (crypto.PrivateKey, crypto.P
 	// if the keytype is not "rsa", then we will always use ecdsa as this is the preferred
 	//
 	default:
+		// the key length usually defaults to an RSA one, ECDSA keys are P-384 unless P-256 is requested
 		keyCurve := elliptic.P384()
-		// below is not the correct check. we should check if the keylength == 521. But people might
-		// be intending 512 for a stronger elliptical curve or 4096 which is meant for RSA.
-		// so we will just check for keyLength >= 512
-		if keyLength >= 512 {
-			keyCurve = elliptic.P521()
+		if keyLength == 256 {
+			keyCurve = elliptic.P256()
 		}
 		k, err := ecdsa.GenerateKey(keyCurve, rand.Reader)
 		if err != nil {
@@ -71,8 +71,6 @@ func GetSignatureAlgorithm(pubKey crypto.PublicKey) (x509.SignatureAlgorithm, er
 			return x509.ECDSAWithSHA384, nil
 		case 521, 512:
 			return x509.ECDSAWithSHA512, nil
-			// we should not really get into the 256 case as long as Generate keypair only support ecdsa keylength of 384 or 512.
-			// just in case
 		case 256:
 			return x509.ECDSAWithSHA256, nil
 		default:
@@ -84,6 +82,15 @@ func GetSignatureAlgorithm(pubKey crypto.PublicKey) (x509.SignatureAlgorithm, er
 	}
 }
 
+// GetSignatureAlgorithmForKeyType returns the signature algorithm to be used with a key pair generated for the
+// given key type. RSA keys are used with PKCS #1 v1.5 signatures unless the key type is "rsa-pss".
+func GetSignatureAlgorithmForKeyType(keyType string, pubKey crypto.PublicKey) (x509.SignatureAlgorithm, error) {
+	if _, ok := pubKey.(*rsa.PublicKey); ok && strings.ToLower(keyType) == KeyTypeRSAPSS {
+		return x509.SHA384WithRSAPSS, nil
+	}
+	return GetSignatureAlgorithm(pubKey)
+}
+
 // CreateKeyPairAndCertificateRequest taken in parameters for certificate request and return der bytes for the CSR
 // and a PKCS8 private key. We are using PKCS8 since we could can have a single package for ecdsa or rsa keys.
 func CreateKeyPairAndCertificateRequest(subject pkix.Name, hostList, keyType string, keyLength int) (certReq []byte, pkcs8Der []byte, err error) {
@@ -103,7 +110,7 @@ func CreateKeyPairAndCertificateRequest(subject pkix.Name, hostList, keyType str
 			Locality:     subject.Locality,
 		},
 	}
-	template.SignatureAlgorithm, err = GetSignatureAlgorithm(pubKey)
+	template.SignatureAlgorithm, err = GetSignatureAlgorithmForKeyType(keyType, pubKey)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -157,7 +164,7 @@ func CreateKeyPairAndCertificate(subject, hostList, keyType string, keyLength in
 		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
 		BasicConstraintsValid: true,
 	}
-	template.SignatureAlgorithm, err = GetSignatureAlgorithm(pubKey)
+	template.SignatureAlgorithm, err = GetSignatureAlgorithmForKeyType(keyType, pubKey)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -276,22 +283,26 @@ func GetCertFromPemFile(path string) (*x509.Certificate, error) {
 
 func GetSubjectCertsMapFromPemFile(path string) ([]x509.Certificate, error) {
 	log.Debugf("crypt/x509:GetSubjectCertsMapFromPemFile() Loading certificates from  %s", path)
-	var certificates []x509.Certificate
 	certsBytes, err := ioutil.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
+	return GetSubjectCertsMapFromPem(certsBytes)
+}
 
+// GetSubjectCertsMapFromPem returns all the certificates in the PEM encoded bytes
+func GetSubjectCertsMapFromPem(certsBytes []byte) ([]x509.Certificate, error) {
+	var certificates []x509.Certificate
 	block, rest := pem.Decode(certsBytes)
 	if block == nil {
 		return nil, fmt.Errorf("Unable to decode pem bytes")
 	}
 	certAuth, err := x509.ParseCertificate(block.Bytes)
 	if err != nil {
-		log.WithError(err).Warn("crypt/x509:GetSubjectCertsMapFromPemFile() Failed to parse certificate")
+		log.WithError(err).Warn("crypt/x509:GetSubjectCertsMapFromPem() Failed to parse certificate")
 	} else {
 		certificates = append(certificates, *certAuth)
-		log.Debugf("crypt/x509:GetSubjectCertsMapFromPemFile() CommonName %s", certAuth.Subject.CommonName)
+		log.Debugf("crypt/x509:GetSubjectCertsMapFromPem() CommonName %s", certAuth.Subject.CommonName)
 	}
 
 	// Return if no more certificates present in path file
@@ -306,11 +317,11 @@ func GetSubjectCertsMapFromPemFile(path string) ([]x509.Certificate, error) {
 		}
 		certAuth, err = x509.ParseCertificate(block.Bytes)
 		if err != nil {
-			log.WithError(err).Warn("crypt/x509:GetSubjectCertsMapFromPemFile() Failed to parse certificate")
+			log.WithError(err).Warn("crypt/x509:GetSubjectCertsMapFromPem() Failed to parse certificate")
 			continue
 		}
 		certificates = append(certificates, *certAuth)
-		log.Debugf("crypt/x509:GetSubjectCertsMapFromPemFile() CommonName %s", certAuth.Subject.CommonName)
+		log.Debugf("crypt/x509:GetSubjectCertsMapFromPem() CommonName %s", certAuth.Subject.CommonName)
 	}
 	return certificates, nil
 }
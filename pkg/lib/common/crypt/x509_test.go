@@ -0,0 +1,54 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package crypt
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateKeyPairAndCertificateRequest(t *testing.T) {
+	assertions := assert.New(t)
+
+	tests := []struct {
+		keyType            string
+		keyLength          int
+		signatureAlgorithm x509.SignatureAlgorithm
+		curve              string
+	}{
+		{KeyTypeRSA, 3072, x509.SHA384WithRSA, ""},
+		{KeyTypeRSAPSS, 3072, x509.SHA384WithRSAPSS, ""},
+		{KeyTypeECDSA, 256, x509.ECDSAWithSHA256, "P-256"},
+		{KeyTypeECDSA, 384, x509.ECDSAWithSHA384, "P-384"},
+		{KeyTypeECDSA, 3072, x509.ECDSAWithSHA384, "P-384"},
+	}
+	for _, test := range tests {
+		csrDer, keyDer, err := CreateKeyPairAndCertificateRequest(pkix.Name{CommonName: "test"}, "127.0.0.1,localhost", test.keyType, test.keyLength)
+		assertions.NoError(err)
+
+		csr, err := x509.ParseCertificateRequest(csrDer)
+		assertions.NoError(err)
+		assertions.NoError(csr.CheckSignature())
+		assertions.Equal(test.signatureAlgorithm, csr.SignatureAlgorithm)
+		assertions.Equal([]string{"localhost"}, csr.DNSNames)
+
+		key, err := x509.ParsePKCS8PrivateKey(keyDer)
+		assertions.NoError(err)
+		switch k := key.(type) {
+		case *rsa.PrivateKey:
+			assertions.Empty(test.curve)
+			assertions.Equal(test.keyLength, k.N.BitLen())
+		case *ecdsa.PrivateKey:
+			assertions.Equal(test.curve, k.Curve.Params().Name)
+		default:
+			assertions.Fail("unexpected private key type")
+		}
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NewHTTPMiddleware registers request count and latency metrics, named with the given prefix, and returns a
+// middleware recording them per route template, so that path parameters do not create a series per resource
+func NewHTTPMiddleware(registry prometheus.Registerer, prefix string) mux.MiddlewareFunc {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: prefix + "_http_requests_total",
+		Help: "Number of HTTP requests by route, method and status code",
+	}, []string{"route", "method", "code"})
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    prefix + "_http_request_duration_seconds",
+		Help:    "Latency of HTTP requests by route and method",
+		Buckets: DefBuckets,
+	}, []string{"route", "method"})
+	registry.MustRegister(requests, duration)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(sw, r)
+
+			route := "unmatched"
+			if currentRoute := mux.CurrentRoute(r); currentRoute != nil {
+				if template, err := currentRoute.GetPathTemplate(); err == nil {
+					route = template
+				}
+			}
+			requests.WithLabelValues(route, r.Method, strconv.Itoa(sw.status)).Inc()
+			duration.WithLabelValues(route, r.Method).Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+// statusWriter records the status code written by a handler
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush sends the buffered data of streaming handlers to the client
+func (w *statusWriter) Flush() {
+	w.wroteHeader = true
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the wrapped ResponseWriter, so that http.ResponseController can reach its deadline controls
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPMiddleware(t *testing.T) {
+	assertions := assert.New(t)
+
+	registry := prometheus.NewRegistry()
+	router := mux.NewRouter()
+	router.Use(NewHTTPMiddleware(registry, "test"))
+	router.HandleFunc("/hosts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}).Methods("GET")
+	router.Handle("/metrics", Handler(registry)).Methods("GET")
+
+	for _, id := range []string{"1", "2"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/hosts/"+id, nil))
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	assertions.Equal(http.StatusOK, recorder.Code)
+	assertions.True(strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/plain"))
+	assertions.True(strings.Contains(recorder.Body.String(), `test_http_requests_total{code="404",method="GET",route="/hosts/{id}"} 2`))
+	assertions.True(strings.Contains(recorder.Body.String(), `test_http_request_duration_seconds_count{method="GET",route="/hosts/{id}"} 2`))
+}
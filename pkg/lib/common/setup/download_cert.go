@@ -30,6 +30,7 @@ type DownloadCert struct {
 	CertFile      string
 	KeyAlgorithm  string
 	KeyLength     int
+	KeyAlgorithms []string // restricts the allowed key algorithms, any supported one when empty
 	Subject       pkix.Name
 	SanList       string
 	CertType      string
@@ -47,9 +48,11 @@ type DownloadCert struct {
 const downloadCAEnvHelpPrompt = "Following environment variables are optionally used in "
 
 var downloadCAEnvCommonHelp = map[string]string{
-	"CERT_FILE":   "The file to which certificate is saved",
-	"KEY_FILE":    "The file to which private key is saved",
-	"COMMON_NAME": "The common name of signed certificate",
+	"CERT_FILE":     "The file to which certificate is saved",
+	"KEY_FILE":      "The file to which private key is saved",
+	"COMMON_NAME":   "The common name of signed certificate",
+	"KEY_ALGORITHM": "The algorithm of the generated key pair, one of rsa, rsa-pss or ecdsa",
+	"KEY_LENGTH":    "The length of the generated key pair, 3072 or 4096 for rsa and rsa-pss, 256 or 384 for ecdsa",
 }
 
 var downloadTlsCAEnvHelp = map[string]string{
@@ -87,6 +90,20 @@ func (dc *DownloadCert) Run() error {
 			}
 		}
 	}
+	keyAlgorithms := dc.KeyAlgorithms
+	if len(keyAlgorithms) == 0 {
+		keyAlgorithms = []string{crypt.KeyTypeRSA, crypt.KeyTypeRSAPSS, crypt.KeyTypeECDSA}
+	}
+	keyAlgorithmAllowed := false
+	for _, keyAlgorithm := range keyAlgorithms {
+		if strings.ToLower(dc.KeyAlgorithm) == keyAlgorithm {
+			keyAlgorithmAllowed = true
+			break
+		}
+	}
+	if !keyAlgorithmAllowed {
+		return errors.New("Unsupported key algorithm " + dc.KeyAlgorithm + ", should be one of " + strings.Join(keyAlgorithms, ", "))
+	}
 	printToWriter(dc.ConsoleWriter, dc.commandName, "Start downloading certificate")
 	key, cert, err := getCertificateFromCMS(dc.CertType, dc.KeyAlgorithm, dc.KeyLength, dc.CmsBaseURL, dc.Subject, dc.SanList, dc.CaCertDirPath, dc.BearerToken)
 	if err != nil {
@@ -0,0 +1,36 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package model
+
+import (
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+)
+
+// CustomRuleTypeJsonQuery is the type of the custom rules evaluating a 'jsonquery' statement against the host manifest
+const CustomRuleTypeJsonQuery = "jsonquery"
+
+// CustomRule is a site specific verification rule, declared in a flavor template or a flavorgroup, that is
+// evaluated against the host manifest along with the rules of the flavor parts
+type CustomRule struct {
+	// Name identifies the rule in the trust report, it is unique among the rules of a flavor template or a flavorgroup
+	Name string `json:"name"`
+	// Type is the type of the rule in the custom rule registry of the verifier, "jsonquery" when empty
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Query is the statement evaluated by the rule. The "jsonquery" rules are satisfied when the statement selects
+	// at least one node of the host manifest, e.g. "//host_info/hardware_features/UEFI/meta/secure_boot_enabled[.='true']"
+	Query string `json:"query"`
+	// FlavorPart is the marker of the results of a flavorgroup rule, PLATFORM when empty. The results of the rules
+	// of a flavor template are marked with the flavor part of the template.
+	FlavorPart common.FlavorPart `json:"flavor_part,omitempty"`
+}
+
+// GetType returns the type of the custom rule, defaulting to CustomRuleTypeJsonQuery
+func (rule CustomRule) GetType() string {
+	if rule.Type == "" {
+		return CustomRuleTypeJsonQuery
+	}
+	return rule.Type
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package model
+
+// Hardware features that can be required by a HostInfoPolicy, the SECURE_BOOT feature is the UEFI Secure Boot
+const (
+	HostInfoFeatureTXT        = "TXT"
+	HostInfoFeatureTPM        = "TPM"
+	HostInfoFeatureCBNT       = "CBNT"
+	HostInfoFeatureUEFI       = "UEFI"
+	HostInfoFeatureSecureBoot = "SECURE_BOOT"
+	HostInfoFeaturePFR        = "PFR"
+	HostInfoFeatureBMC        = "BMC"
+)
+
+// Formats of the versions compared by a HostInfoPolicy
+const (
+	// VersionFormatDotted compares the numbers of the versions one by one, e.g. "2.10.1" > "2.9"
+	VersionFormatDotted = "dotted"
+	// VersionFormatSemver compares semantic versions, a pre-release is lower than its release, e.g. "v1.2.0-rc1" < "1.2.0"
+	VersionFormatSemver = "semver"
+	// VersionFormatIntelBios compares Intel BIOS versions of the same board, e.g. "SE5C620.86B.00.01.6016.032720190737"
+	VersionFormatIntelBios = "intel_bios"
+)
+
+// HostInfoPolicy is the section of a PLATFORM flavor with the requirements on the host info of the host manifest,
+// that are verified along with the PCR rules
+type HostInfoPolicy struct {
+	// FeaturesEnabled are the hardware features that must be enabled, e.g. ["TXT", "TPM", "SECURE_BOOT"]
+	FeaturesEnabled []string `json:"features_enabled,omitempty"`
+	// MinimumVersions are the host info versions that must be at or above a minimum version
+	MinimumVersions []HostInfoVersion `json:"minimum_versions,omitempty"`
+	// Matches are the host info attributes that must match a value
+	Matches []HostInfoMatch `json:"matches,omitempty"`
+}
+
+// HostInfoVersion requires a host info attribute to be at or above a minimum version
+type HostInfoVersion struct {
+	// Attribute is the host info attribute holding the version: bios_version, os_version, vmm_version, tpm_version or
+	// bmc_firmware_version
+	Attribute string `json:"attribute"`
+	Minimum   string `json:"minimum"`
+	// Format is the format of the version, "dotted" when empty
+	Format string `json:"format,omitempty"`
+}
+
+// HostInfoMatch requires a host info attribute to be equal to a value, or to match a regular expression
+type HostInfoMatch struct {
+	// Attribute is the host info attribute: os_name, os_version, bios_name, bios_version, vmm_name, vmm_version,
+	// processor_info, tpm_version, cbnt_profile or bmc_firmware_version
+	Attribute string `json:"attribute"`
+	Value     string `json:"value,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+}
@@ -145,6 +145,8 @@ func (pf HostPlatformFlavor) getPlatformFlavor() ([]cm.Flavor, error) {
 
 	// Assemble the Platform Flavor
 	platformFlavor := cm.NewFlavor(newMeta, newBios, newHW, allPcrDetails, nil, nil)
+	platformFlavor.CustomRules = getCustomRules(cf.FlavorPartPlatform, pf.FlavorTemplates)
+	platformFlavor.HostInfoPolicy = getHostInfoPolicy(pf.FlavorTemplates)
 
 	log.Debugf("flavor/types/host_platform_flavor:getPlatformFlavor()  New PlatformFlavor: %v", platformFlavor)
 
@@ -184,6 +186,7 @@ func (pf HostPlatformFlavor) getOsFlavor() ([]cm.Flavor, error) {
 
 	// Assemble the OS Flavor
 	osFlavor := cm.NewFlavor(newMeta, newBios, nil, allPcrDetails, nil, nil)
+	osFlavor.CustomRules = getCustomRules(cf.FlavorPartOs, pf.FlavorTemplates)
 
 	log.Debugf("flavor/types/host_platform_flavor:getOSFlavor()  New OS Flavor: %v", osFlavor)
 
@@ -226,6 +229,7 @@ func (pf HostPlatformFlavor) getHostUniqueFlavor() ([]cm.Flavor, error) {
 
 	// Assemble the Host Unique Flavor
 	hostUniqueFlavor := cm.NewFlavor(newMeta, newBios, nil, allPcrDetails, nil, nil)
+	hostUniqueFlavor.CustomRules = getCustomRules(cf.FlavorPartHostUnique, pf.FlavorTemplates)
 
 	log.Debugf("flavor/types/host_platform_flavor:getHostUniqueFlavor() New Host unique flavor: %v", hostUniqueFlavor)
 
@@ -397,6 +401,79 @@ func UpdateMetaSectionDetails(flavorPart cf.FlavorPart, newMeta *cm.Meta, flavor
 	return newMeta
 }
 
+// getCustomRules returns the custom rules of the flavor part from the flavor templates
+func getCustomRules(flavorPart cf.FlavorPart, flavorTemplates []hvs.FlavorTemplate) []cm.CustomRule {
+	log.Trace("flavor/types/host_platform_flavor:getCustomRules() Entering")
+	defer log.Trace("flavor/types/host_platform_flavor:getCustomRules() Leaving")
+
+	var customRules []cm.CustomRule
+	for _, flavorTemplate := range flavorTemplates {
+		if flavorTemplate.FlavorParts == nil {
+			continue
+		}
+		var flavor *hvs.FlavorPart
+		switch flavorPart {
+		case cf.FlavorPartPlatform:
+			flavor = flavorTemplate.FlavorParts.Platform
+		case cf.FlavorPartOs:
+			flavor = flavorTemplate.FlavorParts.OS
+		case cf.FlavorPartHostUnique:
+			flavor = flavorTemplate.FlavorParts.HostUnique
+		}
+
+		if flavor != nil {
+			customRules = append(customRules, flavor.CustomRules...)
+		}
+	}
+	return customRules
+}
+
+// getHostInfoPolicy merges the host info policies of the PLATFORM flavor part of the flavor templates. When several
+// templates require the same feature or host info attribute, the requirement of the first template is kept.
+func getHostInfoPolicy(flavorTemplates []hvs.FlavorTemplate) *cm.HostInfoPolicy {
+	log.Trace("flavor/types/host_platform_flavor:getHostInfoPolicy() Entering")
+	defer log.Trace("flavor/types/host_platform_flavor:getHostInfoPolicy() Leaving")
+
+	var hostInfoPolicy *cm.HostInfoPolicy
+	features := make(map[string]bool)
+	versions := make(map[string]bool)
+	matches := make(map[string]bool)
+	for _, flavorTemplate := range flavorTemplates {
+		if flavorTemplate.FlavorParts == nil || flavorTemplate.FlavorParts.Platform == nil ||
+			flavorTemplate.FlavorParts.Platform.HostInfoPolicy == nil {
+			continue
+		}
+		if hostInfoPolicy == nil {
+			hostInfoPolicy = &cm.HostInfoPolicy{}
+		}
+
+		policy := flavorTemplate.FlavorParts.Platform.HostInfoPolicy
+		for _, feature := range policy.FeaturesEnabled {
+			if !features[feature] {
+				features[feature] = true
+				hostInfoPolicy.FeaturesEnabled = append(hostInfoPolicy.FeaturesEnabled, feature)
+			}
+		}
+		for _, minimumVersion := range policy.MinimumVersions {
+			if versions[minimumVersion.Attribute] {
+				log.Warnf("flavor/types/host_platform_flavor:getHostInfoPolicy() Ignoring minimum version of %s from flavor template %s", minimumVersion.Attribute, flavorTemplate.ID)
+				continue
+			}
+			versions[minimumVersion.Attribute] = true
+			hostInfoPolicy.MinimumVersions = append(hostInfoPolicy.MinimumVersions, minimumVersion)
+		}
+		for _, match := range policy.Matches {
+			if matches[match.Attribute] {
+				log.Warnf("flavor/types/host_platform_flavor:getHostInfoPolicy() Ignoring match of %s from flavor template %s", match.Attribute, flavorTemplate.ID)
+				continue
+			}
+			matches[match.Attribute] = true
+			hostInfoPolicy.Matches = append(hostInfoPolicy.Matches, match)
+		}
+	}
+	return hostInfoPolicy
+}
+
 //getVendorName This method is used to get the vendor name
 func (pf HostPlatformFlavor) getVendorName() hcConstants.Vendor {
 	var vendorName hcConstants.Vendor
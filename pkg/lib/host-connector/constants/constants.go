@@ -17,10 +17,12 @@ const (
 	VendorIntel
 	VendorVMware
 	VendorMicrosoft
+	VendorLinux
+	VendorSimulator
 )
 
 func (vendor Vendor) String() string {
-	return [...]string{"UNKNOWN", "INTEL", "VMWARE", "MICROSOFT"}[vendor]
+	return [...]string{"UNKNOWN", "INTEL", "VMWARE", "MICROSOFT", "LINUX", "SIMULATOR"}[vendor]
 }
 
 func (vendor *Vendor) GetVendorFromOSName(osName string) error {
@@ -55,6 +57,10 @@ func (vendor *Vendor) UnmarshalJSON(b []byte) error {
 		*vendor = VendorVMware
 	case "INTEL":
 		*vendor = VendorIntel
+	case "LINUX":
+		*vendor = VendorLinux
+	case "SIMULATOR":
+		*vendor = VendorSimulator
 	default:
 		*vendor = VendorUnknown
 		err = errors.Errorf("Provided vendor is not supported. Vendor : '%s'", jsonValue)
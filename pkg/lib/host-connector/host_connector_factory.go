@@ -8,6 +8,7 @@ import (
 	"crypto/x509"
 	commLog "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/simulator"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/util"
 	"github.com/pkg/errors"
 )
@@ -23,10 +24,17 @@ type HostConnectorProvider interface {
 type HostConnectorFactory struct {
 	aasApiUrl      string
 	trustedCaCerts []x509.Certificate
+	simulator      *simulator.Simulator
 }
 
 func NewHostConnectorFactory(aasApiUrl string, trustedCaCerts []x509.Certificate) *HostConnectorFactory {
-	return &HostConnectorFactory{aasApiUrl, trustedCaCerts}
+	return &HostConnectorFactory{aasApiUrl: aasApiUrl, trustedCaCerts: trustedCaCerts}
+}
+
+// EnableSimulator lets the factory connect to the hosts of the simulator, with the connection strings of the
+// simulator vendor. They are rejected when the simulator is not enabled.
+func (htcFactory *HostConnectorFactory) EnableSimulator(sim *simulator.Simulator) {
+	htcFactory.simulator = sim
 }
 
 func (htcFactory *HostConnectorFactory) NewHostConnector(connectionString string) (HostConnector, error) {
@@ -46,6 +54,12 @@ func (htcFactory *HostConnectorFactory) NewHostConnector(connectionString string
 	case constants.VendorVMware:
 		log.Debug("host_connector/host_connector_factory:NewHostConnector() Connector type for provided connection string is VMWARE")
 		connectorFactory = &VmwareConnectorFactory{}
+	case constants.VendorLinux:
+		log.Debug("host_connector/host_connector_factory:NewHostConnector() Connector type for provided connection string is LINUX")
+		connectorFactory = &LinuxConnectorFactory{}
+	case constants.VendorSimulator:
+		log.Debug("host_connector/host_connector_factory:NewHostConnector() Connector type for provided connection string is SIMULATOR")
+		connectorFactory = &SimulatorConnectorFactory{simulator: htcFactory.simulator}
 	default:
 		return nil, errors.New("host_connector_factory:NewHostConnector() Vendor not supported yet: " + vendorConnector.Vendor.String())
 	}
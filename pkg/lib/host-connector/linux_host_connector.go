@@ -0,0 +1,195 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package host_connector
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/util"
+	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/vim25/mo"
+)
+
+// LinuxHostClient runs the operations of the agentless Linux host protocol on a host, and returns their JSON
+// response
+type LinuxHostClient interface {
+	Run(operation string, args ...string) ([]byte, error)
+}
+
+// LinuxConnector attests the Linux hosts without Trust Agent, with the agentless Linux host protocol
+type LinuxConnector struct {
+	client LinuxHostClient
+}
+
+// NewLinuxConnector returns a LinuxConnector running the protocol operations with the client
+func NewLinuxConnector(client LinuxHostClient) *LinuxConnector {
+	return &LinuxConnector{client: client}
+}
+
+func (lc *LinuxConnector) GetHostDetails() (taModel.HostInfo, error) {
+
+	log.Trace("linux_host_connector:GetHostDetails() Entering")
+	defer log.Trace("linux_host_connector:GetHostDetails() Leaving")
+	var hostInfo taModel.HostInfo
+	response, err := lc.client.Run(LinuxHostInfoOperation)
+	if err != nil {
+		return taModel.HostInfo{}, errors.Wrap(err, "linux_host_connector:GetHostDetails() Error getting host details")
+	}
+	err = json.Unmarshal(response, &hostInfo)
+	if err != nil {
+		return taModel.HostInfo{}, errors.Wrap(err, "linux_host_connector:GetHostDetails() Error unmarshalling host details")
+	}
+	return hostInfo, nil
+}
+
+func (lc *LinuxConnector) GetHostManifest(pcrList []int) (types.HostManifest, error) {
+	log.Trace("linux_host_connector:GetHostManifest() Entering")
+	defer log.Trace("linux_host_connector:GetHostManifest() Leaving")
+
+	nonce, err := util.GenerateNonce(20)
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "linux_host_connector:GetHostManifest() Error generating "+
+			"nonce for TPM quote request")
+	}
+
+	hostManifest, err := lc.GetHostManifestAcceptNonce(nonce, pcrList)
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "linux_host_connector:GetHostManifest() Error creating "+
+			"host manifest")
+	}
+	return hostManifest, nil
+}
+
+// GetHostManifestAcceptNonce creates the host manifest from a quote of the SHA1 and SHA256 PCR banks, with the
+// base64 encoded nonce as qualifying data
+func (lc *LinuxConnector) GetHostManifestAcceptNonce(nonce string, pcrList []int) (types.HostManifest, error) {
+	log.Trace("linux_host_connector:GetHostManifestAcceptNonce() Entering")
+	defer log.Trace("linux_host_connector:GetHostManifestAcceptNonce() Leaving")
+
+	var hostManifest types.HostManifest
+	if len(pcrList) == 0 {
+		pcrList = []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23}
+	}
+
+	nonceInBytes, err := base64.StdEncoding.DecodeString(nonce)
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "linux_host_connector:GetHostManifestAcceptNonce() Base64 decode "+
+			"of TPM nonce failed")
+	}
+
+	hostManifest.HostInfo, err = lc.GetHostDetails()
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "linux_host_connector:GetHostManifestAcceptNonce() Error getting "+
+			"host details")
+	}
+
+	response, err := lc.client.Run(LinuxQuoteOperation, hex.EncodeToString(nonceInBytes), linuxPcrSelection(pcrList))
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "linux_host_connector:GetHostManifestAcceptNonce() Error getting "+
+			"TPM quote")
+	}
+	var quote types.LinuxHostQuote
+	err = json.Unmarshal(response, &quote)
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "linux_host_connector:GetHostManifestAcceptNonce() Error "+
+			"unmarshalling TPM quote")
+	}
+
+	aikPemBytes, err := base64.StdEncoding.DecodeString(quote.AikCertificate)
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "linux_host_connector:GetHostManifestAcceptNonce() Error decoding "+
+			"AIK certificate")
+	}
+	aikPem, _ := pem.Decode(aikPemBytes)
+	if aikPem == nil {
+		return types.HostManifest{}, errors.New("linux_host_connector:GetHostManifestAcceptNonce() Invalid AIK " +
+			"certificate returned by host")
+	}
+	aikCertificate, err := x509.ParseCertificate(aikPem.Bytes)
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "linux_host_connector:GetHostManifestAcceptNonce() Error parsing "+
+			"AIK certificate")
+	}
+
+	tpmQuoteInBytes, err := linuxTpmQuote(&quote)
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "linux_host_connector:GetHostManifestAcceptNonce() Error decoding "+
+			"TPM quote")
+	}
+
+	log.Info("linux_host_connector:GetHostManifestAcceptNonce() Verifying quote and retrieving PCR manifest from TPM quote " +
+		"response ...")
+	pcrManifest, pcrsDigest, err := util.VerifyQuoteAndGetPCRManifest(util.RawTcgEventLogPrefix+quote.EventLog, nonceInBytes,
+		tpmQuoteInBytes, aikCertificate)
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "linux_host_connector:GetHostManifestAcceptNonce() Error verifying "+
+			"TPM Quote")
+	}
+	log.Info("linux_host_connector:GetHostManifestAcceptNonce() Successfully retrieved PCR manifest from quote")
+
+	hostManifest.PcrManifest = pcrManifest
+	hostManifest.AIKCertificate = base64.StdEncoding.EncodeToString(aikPem.Bytes)
+	hostManifest.QuoteDigest = hex.EncodeToString(pcrsDigest)
+	log.Info("linux_host_connector:GetHostManifestAcceptNonce() Host manifest created successfully")
+	return hostManifest, nil
+}
+
+func (lc *LinuxConnector) DeployAssetTag(hardwareUUID, tag string) error {
+	return errors.New("linux_host_connector:DeployAssetTag() Operation not supported")
+}
+
+func (lc *LinuxConnector) DeploySoftwareManifest(manifest taModel.Manifest) error {
+	return errors.New("linux_host_connector:DeploySoftwareManifest() Operation not supported")
+}
+
+func (lc *LinuxConnector) GetMeasurementFromManifest(manifest taModel.Manifest) (taModel.Measurement, error) {
+	return taModel.Measurement{}, errors.New("linux_host_connector:GetMeasurementFromManifest() Operation not supported")
+}
+
+func (lc *LinuxConnector) GetClusterReference(clusterName string) ([]mo.HostSystem, error) {
+	return nil, errors.New("linux_host_connector:GetClusterReference() Operation not supported")
+}
+
+// linuxPcrSelection returns the tpm2-tools selection of the PCRs in the SHA1 and SHA256 banks
+func linuxPcrSelection(pcrList []int) string {
+	pcrs := make([]string, len(pcrList))
+	for i, pcr := range pcrList {
+		pcrs[i] = fmt.Sprint(pcr)
+	}
+	return fmt.Sprintf("sha1:%[1]s+sha256:%[1]s", strings.Join(pcrs, ","))
+}
+
+// linuxTpmQuote assembles the quote in the format of the Trust Agent, expected by VerifyQuoteAndGetPCRManifest: the
+// size of the TPMS_ATTEST structure, the structure, the TPMT_SIGNATURE structure and the PCR values
+func linuxTpmQuote(quote *types.LinuxHostQuote) ([]byte, error) {
+	var tpmQuote bytes.Buffer
+	for i, field := range []string{quote.Quote, quote.Signature, quote.Pcrs} {
+		decoded, err := base64.StdEncoding.DecodeString(field)
+		if err != nil {
+			return nil, err
+		}
+		if len(decoded) == 0 {
+			return nil, errors.New("Empty TPM quote field")
+		}
+		if i == 0 {
+			if len(decoded) > 0xffff {
+				return nil, errors.New("Invalid TPMS_ATTEST size")
+			}
+			_ = binary.Write(&tpmQuote, binary.BigEndian, uint16(len(decoded)))
+		}
+		tpmQuote.Write(decoded)
+	}
+	return tpmQuote.Bytes(), nil
+}
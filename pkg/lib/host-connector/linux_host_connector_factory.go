@@ -0,0 +1,114 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package host_connector
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"crypto/x509"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	linuxSshDefaultPort = "22"
+	linuxSshTimeout     = 30 * time.Second
+)
+
+type LinuxConnectorFactory struct {
+}
+
+// GetHostConnector returns a LinuxConnector running the protocol over SSH. The SSH host key must match the
+// fingerprint provided in the connection string.
+func (lcf *LinuxConnectorFactory) GetHostConnector(vendorConnector types.VendorConnector, aasApiUrl string,
+	trustedCaCerts []x509.Certificate) (HostConnector, error) {
+
+	log.Trace("linux_host_connector_factory:GetHostConnector() Entering")
+	defer log.Trace("linux_host_connector_factory:GetHostConnector() Leaving")
+	sshURL, err := url.Parse(vendorConnector.Url)
+	if err != nil || sshURL.Scheme != "ssh" || sshURL.Hostname() == "" {
+		return nil, errors.New("linux_host_connector_factory:GetHostConnector() Invalid SSH URL in connection string")
+	}
+	if vendorConnector.Configuration.HostKey == "" {
+		return nil, errors.New("linux_host_connector_factory:GetHostConnector() The SSH host key fingerprint must be " +
+			"provided in the connection string")
+	}
+	port := sshURL.Port()
+	if port == "" {
+		port = linuxSshDefaultPort
+	}
+
+	client := &sshLinuxHostClient{
+		address: net.JoinHostPort(sshURL.Hostname(), port),
+		config: &ssh.ClientConfig{
+			User:            vendorConnector.Configuration.Username,
+			Auth:            []ssh.AuthMethod{ssh.Password(vendorConnector.Configuration.Password)},
+			HostKeyCallback: sshHostKeyFingerprintCallback(vendorConnector.Configuration.HostKey),
+			Timeout:         linuxSshTimeout,
+		},
+	}
+	log.Debug("linux_host_connector_factory:GetHostConnector() SSH client created")
+	return NewLinuxConnector(client), nil
+}
+
+// sshHostKeyFingerprintCallback accepts only the host key with the SHA256 fingerprint, in the format of ssh-keygen -l
+func sshHostKeyFingerprintCallback(fingerprint string) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		if subtle.ConstantTimeCompare([]byte(ssh.FingerprintSHA256(key)), []byte(fingerprint)) != 1 {
+			secLog.Warnf("linux_host_connector_factory:sshHostKeyFingerprintCallback() The host key of %s does not "+
+				"match the fingerprint of the connection string", hostname)
+			return errors.New("SSH host key mismatch")
+		}
+		return nil
+	}
+}
+
+// sshLinuxHostClient runs the protocol script on the host in a new SSH connection for each operation
+type sshLinuxHostClient struct {
+	address string
+	config  *ssh.ClientConfig
+}
+
+func (client *sshLinuxHostClient) Run(operation string, args ...string) ([]byte, error) {
+	log.Trace("linux_host_connector_factory:Run() Entering")
+	defer log.Trace("linux_host_connector_factory:Run() Leaving")
+
+	connection, err := ssh.Dial("tcp", client.address, client.config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error connecting to %s", client.address)
+	}
+	defer connection.Close()
+
+	session, err := connection.NewSession()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating SSH session")
+	}
+	defer session.Close()
+
+	var stdout, stderr bytes.Buffer
+	session.Stdin = strings.NewReader(linuxHostProtocolScript)
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+	command := "sh -s -- " + shellQuote(append([]string{operation}, args...))
+	if err = session.Run(command); err != nil {
+		return nil, errors.Wrapf(err, "Error running operation %s: %s", operation, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// shellQuote quotes the arguments for the remote shell
+func shellQuote(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
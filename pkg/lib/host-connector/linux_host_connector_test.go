@@ -0,0 +1,94 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package host_connector_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/util"
+	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFakeLinuxHost(t *testing.T) *mocks.FakeLinuxHost {
+	host, err := mocks.NewFakeLinuxHost(taModel.HostInfo{
+		OSName:       "RedHatEnterprise",
+		OSVersion:    "8.2",
+		HostName:     "appliance1",
+		HardwareUUID: "8032632b-8fa4-e811-906e-00163566263e",
+	})
+	assert.NoError(t, err)
+	return host
+}
+
+func TestLinuxConnectorGetHostDetails(t *testing.T) {
+	connector := host_connector.NewLinuxConnector(newFakeLinuxHost(t))
+
+	hostInfo, err := connector.GetHostDetails()
+	assert.NoError(t, err)
+	assert.Equal(t, "appliance1", hostInfo.HostName)
+	assert.Equal(t, "8032632b-8fa4-e811-906e-00163566263e", hostInfo.HardwareUUID)
+}
+
+func TestLinuxConnectorGetHostManifest(t *testing.T) {
+	host := newFakeLinuxHost(t)
+	connector := host_connector.NewLinuxConnector(host)
+
+	hostManifest, err := connector.GetHostManifest(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "appliance1", hostManifest.HostInfo.HostName)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(host.AikCertificate()), hostManifest.AIKCertificate)
+	assert.NotEmpty(t, hostManifest.QuoteDigest)
+	assert.Len(t, hostManifest.PcrManifest.Sha1Pcrs, 24)
+	assert.Len(t, hostManifest.PcrManifest.Sha256Pcrs, 24)
+
+	// the event log replays to the quoted PCR values
+	for _, bank := range []types.SHAAlgorithm{types.SHA1, types.SHA256} {
+		for _, pcrIndex := range []types.PcrIndex{types.PCR0, types.PCR4, types.PCR7, types.PCR8} {
+			eventLog, _, _, err := hostManifest.PcrManifest.PcrEventLogMap.GetEventLogNew(string(bank), int(pcrIndex))
+			assert.NoError(t, err)
+			assert.NotEmpty(t, eventLog)
+			tpmEventLog := types.TpmEventLog{Pcr: types.Pcr{Index: int(pcrIndex), Bank: string(bank)}, TpmEvent: eventLog}
+			replayed, err := tpmEventLog.Replay()
+			assert.NoError(t, err)
+			pcr, err := hostManifest.PcrManifest.GetRequiredPcrValue(bank, pcrIndex)
+			assert.NoError(t, err)
+			assert.Equal(t, pcr.Value, replayed)
+		}
+	}
+
+	pcr4, _, _, _ := hostManifest.PcrManifest.PcrEventLogMap.GetEventLogNew(util.SHA256, 4)
+	assert.Equal(t, "EV_EFI_BOOT_SERVICES_APPLICATION", pcr4[len(pcr4)-1].TypeName)
+	assert.Equal(t, []string{`\EFI\BOOT\BOOTX64.EFI`}, pcr4[len(pcr4)-1].Tags)
+}
+
+func TestLinuxConnectorGetHostManifestAfterExtend(t *testing.T) {
+	host := newFakeLinuxHost(t)
+	connector := host_connector.NewLinuxConnector(host)
+
+	before, err := connector.GetHostManifest([]int{8, 9})
+	assert.NoError(t, err)
+	assert.Len(t, before.PcrManifest.Sha256Pcrs, 2)
+
+	host.Extend(8, util.EV_IPL, []byte("grub_cmd: linux /vmlinuz-rescue\x00"))
+	after, err := connector.GetHostManifest([]int{8, 9})
+	assert.NoError(t, err)
+	assert.NotEqual(t, before.PcrManifest.Sha256Pcrs[0].Value, after.PcrManifest.Sha256Pcrs[0].Value)
+	assert.Equal(t, before.PcrManifest.Sha256Pcrs[1].Value, after.PcrManifest.Sha256Pcrs[1].Value)
+}
+
+func TestLinuxConnectorUnsupportedOperations(t *testing.T) {
+	connector := host_connector.NewLinuxConnector(newFakeLinuxHost(t))
+
+	assert.Error(t, connector.DeployAssetTag("8032632b-8fa4-e811-906e-00163566263e", "tag"))
+	assert.Error(t, connector.DeploySoftwareManifest(taModel.Manifest{}))
+	_, err := connector.GetClusterReference("cluster")
+	assert.Error(t, err)
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package host_connector
+
+//
+// This file defines the agentless Linux host protocol. The protocol script is run on the host over SSH with
+// "sh -s -- <operation> [arguments]", and prints the response of the operation in JSON. It only needs a POSIX
+// shell, base64 and tpm2-tools 4.0 or later on the host:
+//
+//   host-info                  prints the platform information, in the format of the Trust Agent host info
+//   quote <nonce> <selection>  prints a LinuxHostQuote: the TPM quote of the PCRs of the tpm2-tools selection,
+//                              e.g. "sha1:0,1,2+sha256:0,1,2", with the hex encoded nonce as qualifying data,
+//                              signed by the AIK with RSASSA-SHA256, the AIK certificate and the TCG event log
+//
+// The AIK must be persisted at LinuxAikHandle, and its certificate issued by the HVS privacy CA stored in PEM
+// format at LinuxAikCertificatePath.
+//
+
+const (
+	LinuxHostInfoOperation  = "host-info"
+	LinuxQuoteOperation     = "quote"
+	LinuxAikHandle          = "0x81018000"
+	LinuxAikCertificatePath = "/etc/hvs-agentless/aik.pem"
+	LinuxEventLogPath       = "/sys/kernel/security/tpm0/binary_bios_measurements"
+)
+
+const linuxHostProtocolScript = `set -e
+AIK_HANDLE=` + LinuxAikHandle + `
+AIK_CERTIFICATE=` + LinuxAikCertificatePath + `
+EVENT_LOG=` + LinuxEventLogPath + `
+
+json_escape() {
+	printf '%s' "$1" | tr -d '\000-\037' | sed -e 's/\\/\\\\/g' -e 's/"/\\"/g'
+}
+
+read_file() {
+	if [ -r "$1" ]; then json_escape "$(cat "$1")"; fi
+}
+
+host_info() {
+	if command -v lsb_release > /dev/null 2>&1; then
+		os_name=$(lsb_release -si)
+		os_version=$(lsb_release -sr)
+	else
+		. /etc/os-release
+		os_name=$(printf '%s' "$NAME" | tr -d ' ')
+		os_version=$VERSION_ID
+		if [ "$ID" = "rhel" ]; then os_name=RedHatEnterprise; fi
+	fi
+	sockets=$(grep '^physical id' /proc/cpuinfo | sort -u | wc -l)
+	if [ "$sockets" -eq 0 ]; then sockets=1; fi
+	tpm_version=$(cat /sys/class/tpm/tpm0/tpm_version_major 2>/dev/null || true)
+	tpm_enabled=false
+	if [ -n "$tpm_version" ]; then tpm_enabled=true; tpm_version=$tpm_version.0; fi
+	uefi_enabled=false
+	secure_boot=false
+	if [ -d /sys/firmware/efi ]; then
+		uefi_enabled=true
+		secure_boot_var=$(ls /sys/firmware/efi/efivars/SecureBoot-* 2>/dev/null | head -n 1)
+		if [ -n "$secure_boot_var" ] && [ "$(od -An -t u1 -j 4 -N 1 "$secure_boot_var" | tr -d ' ')" = 1 ]; then
+			secure_boot=true
+		fi
+	fi
+	cat <<EOF
+{
+  "os_name": "$(json_escape "$os_name")",
+  "os_version": "$(json_escape "$os_version")",
+  "bios_name": "$(read_file /sys/class/dmi/id/bios_vendor)",
+  "bios_version": "$(read_file /sys/class/dmi/id/bios_version)",
+  "host_name": "$(json_escape "$(hostname)")",
+  "hardware_uuid": "$(read_file /sys/class/dmi/id/product_uuid | tr 'A-F' 'a-f')",
+  "processor_info": "$(json_escape "$(grep -m 1 '^model name' /proc/cpuinfo | cut -d: -f2- | sed 's/^ *//')")",
+  "process_flags": "$(json_escape "$(grep -m 1 '^flags' /proc/cpuinfo | cut -d: -f2- | sed 's/^ *//' | tr 'a-z' 'A-Z')")",
+  "no_of_sockets": "$sockets",
+  "tboot_installed": "false",
+  "is_docker_env": "false",
+  "hardware_features": {
+    "TPM": {"supported": "$tpm_enabled", "enabled": "$tpm_enabled", "meta": {"tpm_version": "$tpm_version"}},
+    "UEFI": {"supported": "$uefi_enabled", "enabled": "$uefi_enabled", "meta": {"secure_boot_enabled": $secure_boot}}
+  },
+  "installed_components": []
+}
+EOF
+}
+
+quote() {
+	dir=$(mktemp -d)
+	trap 'rm -rf "$dir"' EXIT
+	tpm2_quote -Q -c "$AIK_HANDLE" -l "$2" -q "$1" -g sha256 -m "$dir/quote" -s "$dir/signature" -o "$dir/pcrs" -F values
+	cat <<EOF
+{
+  "quote": "$(base64 "$dir/quote" | tr -d '\n')",
+  "signature": "$(base64 "$dir/signature" | tr -d '\n')",
+  "pcrs": "$(base64 "$dir/pcrs" | tr -d '\n')",
+  "aik_certificate": "$(base64 "$AIK_CERTIFICATE" | tr -d '\n')",
+  "event_log": "$(base64 "$EVENT_LOG" | tr -d '\n')"
+}
+EOF
+}
+
+case "$1" in
+` + LinuxHostInfoOperation + `) host_info ;;
+` + LinuxQuoteOperation + `) quote "$2" "$3" ;;
+*) echo "Unsupported operation: $1" >&2; exit 2 ;;
+esac
+`
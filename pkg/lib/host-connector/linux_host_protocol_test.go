@@ -0,0 +1,54 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package host_connector
+
+import (
+	"crypto/x509"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// localShellClient runs the protocol script in a local shell, as on the host over SSH
+type localShellClient struct{}
+
+func (localShellClient) Run(operation string, args ...string) ([]byte, error) {
+	command := exec.Command("sh", append([]string{"-s", "--", operation}, args...)...)
+	command.Stdin = strings.NewReader(linuxHostProtocolScript)
+	return command.Output()
+}
+
+func TestLinuxHostProtocolScriptHostInfo(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("No shell available")
+	}
+	hostname, err := os.Hostname()
+	assert.NoError(t, err)
+
+	hostInfo, err := NewLinuxConnector(localShellClient{}).GetHostDetails()
+	assert.NoError(t, err)
+	assert.Equal(t, hostname, hostInfo.HostName)
+	assert.NotEmpty(t, hostInfo.OSName)
+
+	_, err = localShellClient{}.Run("unknown")
+	assert.Error(t, err)
+}
+
+func TestLinuxConnectorFactory(t *testing.T) {
+	htcFactory := NewHostConnectorFactory("https://aas.url.com:8444/aas", []x509.Certificate{})
+
+	hostConnector, err := htcFactory.NewHostConnector("linux:ssh://appliance.com:22;" +
+		"k=SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8;u=root;p=password")
+	assert.NoError(t, err)
+	assert.IsType(t, &LinuxConnector{}, hostConnector)
+
+	// the host key fingerprint is required
+	_, err = htcFactory.NewHostConnector("linux:ssh://appliance.com:22;u=root;p=password")
+	assert.Error(t, err)
+}
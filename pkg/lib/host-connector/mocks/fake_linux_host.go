@@ -0,0 +1,104 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+package mocks
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/simulator"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/util"
+	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+// FakeLinuxHost is a test double of a Linux host implementing the agentless Linux host protocol, with a software TPM
+// holding the SHA1 and SHA256 PCR banks, its AIK and the TCG event log of the measurements. It is created with the
+// event log of a UEFI boot, and further measurements can be added with Extend.
+type FakeLinuxHost struct {
+	HostInfo taModel.HostInfo
+
+	tpm            *simulator.Tpm
+	aikCertificate []byte
+}
+
+// NewFakeLinuxHost creates a FakeLinuxHost with a self-signed AIK certificate
+func NewFakeLinuxHost(hostInfo taModel.HostInfo) (*FakeLinuxHost, error) {
+	aikKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error generating AIK")
+	}
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: hostInfo.HardwareUUID},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	aikCertificate, err := x509.CreateCertificate(rand.Reader, &template, &template, &aikKey.PublicKey, aikKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating AIK certificate")
+	}
+
+	host := &FakeLinuxHost{
+		HostInfo:       hostInfo,
+		tpm:            simulator.NewTpm(aikKey),
+		aikCertificate: aikCertificate,
+	}
+	host.tpm.Startup(3)
+	host.Extend(0, util.EV_S_CRTM_VERSION, simulator.Utf16Data("1.00"))
+	host.Extend(0, util.EV_POST_CODE, []byte("ACPI DATA"))
+	host.Extend(1, util.EV_EFI_VARIABLE_BOOT, simulator.EfiVariableData("BootOrder", []byte{0, 0}))
+	host.Extend(7, util.EV_EFI_VARIABLE_DRIVER_CONFIG, simulator.EfiVariableData("SecureBoot", []byte{1}))
+	for pcr := 0; pcr < 8; pcr++ {
+		host.Extend(pcr, util.EV_SEPARATOR, []byte{0, 0, 0, 0})
+	}
+	host.Extend(4, util.EV_EFI_BOOT_SERVICES_APPLICATION, simulator.EfiImageLoadData(`\EFI\BOOT\BOOTX64.EFI`))
+	host.Extend(8, util.EV_IPL, []byte("grub_cmd: linux /vmlinuz root=/dev/sda1\x00"))
+	return host, nil
+}
+
+// AikCertificate returns the DER encoded AIK certificate of the host
+func (host *FakeLinuxHost) AikCertificate() []byte {
+	return host.aikCertificate
+}
+
+// Extend measures the event data in the PCR of each bank, and adds the event to the event log
+func (host *FakeLinuxHost) Extend(pcrIndex int, eventType uint32, data []byte) {
+	host.tpm.Extend(pcrIndex, eventType, data)
+}
+
+// Run implements host_connector.LinuxHostClient
+func (host *FakeLinuxHost) Run(operation string, args ...string) ([]byte, error) {
+	switch operation {
+	case host_connector.LinuxHostInfoOperation:
+		return json.Marshal(host.HostInfo)
+	case host_connector.LinuxQuoteOperation:
+		if len(args) != 2 {
+			return nil, errors.New("The quote operation expects the nonce and the PCR selection")
+		}
+		nonce, err := hex.DecodeString(args[0])
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid nonce")
+		}
+		quote, err := host.tpm.Quote(nonce, args[1])
+		if err != nil {
+			return nil, err
+		}
+		aikPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: host.aikCertificate})
+		quote.AikCertificate = base64.StdEncoding.EncodeToString(aikPem)
+		return json.Marshal(quote)
+	}
+	return nil, errors.Errorf("Unsupported operation: %s", operation)
+}
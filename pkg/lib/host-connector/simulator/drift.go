@@ -0,0 +1,73 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+package simulator
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Drift is a change injected in a simulated host, making it diverge from the other hosts of its profile
+type Drift string
+
+const (
+	// DriftBiosUpgrade upgrades the BIOS version of the host info, changing the PCR 0 measurements
+	DriftBiosUpgrade Drift = "bios-upgrade"
+	// DriftEventLogChange changes the data of the last event measured in PCR 7 before the separator, as done by an
+	// update of the Secure Boot configuration
+	DriftEventLogChange Drift = "event-log-change"
+	// DriftEventLogTamper changes the event log entry of the same event without changing the PCRs, so that the event
+	// log does not replay to the PCR values
+	DriftEventLogTamper Drift = "event-log-tamper"
+	// DriftExpiredAik issues an AIK certificate that has expired
+	DriftExpiredAik Drift = "expired-aik"
+)
+
+const biosUpgradeSuffix = "-upgrade"
+
+// Drifts returns the drifts that can be injected in the simulated hosts
+func Drifts() []Drift {
+	return []Drift{DriftBiosUpgrade, DriftEventLogChange, DriftEventLogTamper, DriftExpiredAik}
+}
+
+// ParseDrift parses the comma separated list of drifts
+func ParseDrift(drift string) ([]Drift, error) {
+	var drifts []Drift
+	if drift == "" {
+		return drifts, nil
+	}
+	for _, name := range strings.Split(drift, ",") {
+		valid := false
+		for _, d := range Drifts() {
+			if Drift(name) == d {
+				valid = true
+			}
+		}
+		if !valid {
+			return nil, errors.Errorf("Unknown drift '%s'", name)
+		}
+		drifts = append(drifts, Drift(name))
+	}
+	return drifts, nil
+}
+
+// driftString returns the comma separated list of drifts
+func driftString(drifts []Drift) string {
+	names := make([]string, len(drifts))
+	for i, drift := range drifts {
+		names[i] = string(drift)
+	}
+	return strings.Join(names, ",")
+}
+
+func hasDrift(drifts []Drift, drift Drift) bool {
+	for _, d := range drifts {
+		if d == drift {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+package simulator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"unicode/utf16"
+)
+
+// efiGlobalVariableGuid is the vendor GUID of the EFI global variables, in the byte order of the event data
+var efiGlobalVariableGuid = []byte{0x61, 0xdf, 0xe4, 0x8b, 0xca, 0x93, 0xd2, 0x11, 0xaa, 0x0d, 0x00, 0xe0, 0x98, 0x03,
+	0x2b, 0x8c}
+
+// Utf16Data returns the text encoded in UTF-16LE, as in the EV_S_CRTM_VERSION events
+func Utf16Data(text string) []byte {
+	var data bytes.Buffer
+	writeFields(&data, binary.LittleEndian, utf16.Encode([]rune(text)))
+	return data.Bytes()
+}
+
+// EfiVariableData returns the UEFI_VARIABLE_DATA of the EFI global variable
+func EfiVariableData(name string, value []byte) []byte {
+	var data bytes.Buffer
+	data.Write(efiGlobalVariableGuid)
+	writeFields(&data, binary.LittleEndian, uint64(len(utf16.Encode([]rune(name)))), uint64(len(value)))
+	data.Write(Utf16Data(name))
+	data.Write(value)
+	return data.Bytes()
+}
+
+// EfiImageLoadData returns the UEFI_IMAGE_LOAD_EVENT of the EFI image loaded from the file, with a device path made
+// of the file path only
+func EfiImageLoadData(filePath string) []byte {
+	var devicePath bytes.Buffer
+	path := Utf16Data(filePath + "\x00")
+	writeFields(&devicePath, binary.LittleEndian, uint8(0x04), uint8(0x04), uint16(4+len(path)))
+	devicePath.Write(path)
+	// end of the device path
+	writeFields(&devicePath, binary.LittleEndian, uint8(0x7f), uint8(0xff), uint16(4))
+
+	var data bytes.Buffer
+	// image location in memory, image length, link time address and device path length
+	writeFields(&data, binary.LittleEndian, uint64(0x1000000), uint64(0x20000), uint64(0), uint64(devicePath.Len()))
+	data.Write(devicePath.Bytes())
+	return data.Bytes()
+}
@@ -0,0 +1,203 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+package simulator
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/util"
+	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ProfileRhelSuefi is the built-in profile of a RHEL server booted with UEFI Secure Boot
+	ProfileRhelSuefi = "rhel-suefi"
+	// ProfileRhelUefi is the built-in profile of a RHEL server booted with UEFI, without Secure Boot
+	ProfileRhelUefi = "rhel-uefi"
+)
+
+// ProfileEvent is a measurement of the boot of the hosts of a Profile. The event data is encoded as in the event
+// log of a real host for the well-known event types:
+//   - EV_S_CRTM_VERSION: the version in Data is encoded in UTF-16
+//   - EV_EFI_VARIABLE_*: Data is the name of the EFI global variable, and Value its hex encoded value
+//   - EV_EFI_BOOT_SERVICES_*, EV_EFI_RUNTIME_SERVICES_DRIVER: Data is the file path of the EFI image
+//   - EV_SEPARATOR: Data is ignored, the separator is measured as 4 zero bytes
+//
+// The event data of the other event types is the text of Data. ${bios_version} and ${os_version} in Data are replaced
+// by the versions of the host info of the host.
+type ProfileEvent struct {
+	Pcr   int    `json:"pcr"`
+	Type  string `json:"type"`
+	Data  string `json:"data,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// Profile describes a kind of simulated hosts: their host info, and the events measured during their boot. The host
+// name and the hardware UUID of the host info are set for each host.
+type Profile struct {
+	Name     string           `json:"name"`
+	HostInfo taModel.HostInfo `json:"host_info"`
+	Events   []ProfileEvent   `json:"events"`
+}
+
+// Validate checks the PCR indexes, the event types and the values of the events of the profile
+func (profile *Profile) Validate() error {
+	if profile.Name == "" || strings.ContainsAny(profile.Name, "/?;") {
+		return errors.Errorf("Invalid profile name '%s'", profile.Name)
+	}
+	for i, event := range profile.Events {
+		if event.Pcr < 0 || event.Pcr >= tpmPcrCount {
+			return errors.Errorf("Invalid PCR index %d of event %d of profile %s", event.Pcr, i, profile.Name)
+		}
+		if _, ok := util.TcgEventType(event.Type); !ok {
+			return errors.Errorf("Unknown event type %s of event %d of profile %s", event.Type, i, profile.Name)
+		}
+		if _, err := hex.DecodeString(event.Value); err != nil {
+			return errors.Errorf("Invalid value of event %d of profile %s", i, profile.Name)
+		}
+	}
+	return nil
+}
+
+// eventData returns the event data, with the versions of the host info
+func (event *ProfileEvent) eventData(hostInfo *taModel.HostInfo) []byte {
+	data := os.Expand(event.Data, func(name string) string {
+		switch name {
+		case "bios_version":
+			return hostInfo.BiosVersion
+		case "os_version":
+			return hostInfo.OSVersion
+		}
+		return ""
+	})
+	eventType, _ := util.TcgEventType(event.Type)
+	switch eventType {
+	case util.EV_S_CRTM_VERSION:
+		return Utf16Data(data + "\x00")
+	case util.EV_EFI_VARIABLE_DRIVER_CONFIG, util.EV_EFI_VARIABLE_BOOT, util.EV_EFI_VARIABLE_BOOT2,
+		util.EV_EFI_VARIABLE_AUTHORITY:
+		value, _ := hex.DecodeString(event.Value)
+		return EfiVariableData(data, value)
+	case util.EV_EFI_BOOT_SERVICES_APPLICATION, util.EV_EFI_BOOT_SERVICES_DRIVER, util.EV_EFI_RUNTIME_SERVICES_DRIVER:
+		return EfiImageLoadData(data)
+	case util.EV_SEPARATOR:
+		return []byte{0, 0, 0, 0}
+	}
+	return []byte(data)
+}
+
+// changed returns the event with a different event data. The value of the EFI variables is changed, not their name.
+func (event ProfileEvent) changed() ProfileEvent {
+	eventType, _ := util.TcgEventType(event.Type)
+	switch eventType {
+	case util.EV_EFI_VARIABLE_DRIVER_CONFIG, util.EV_EFI_VARIABLE_BOOT, util.EV_EFI_VARIABLE_BOOT2,
+		util.EV_EFI_VARIABLE_AUTHORITY:
+		event.Value += "ff"
+	default:
+		event.Data += " "
+	}
+	return event
+}
+
+// LoadProfiles loads the profiles of the JSON files of the directory
+func LoadProfiles(dir string) ([]Profile, error) {
+	log.Trace("simulator/profile:LoadProfiles() Entering")
+	defer log.Trace("simulator/profile:LoadProfiles() Leaving")
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, errors.Wrap(err, "Error listing the profile files")
+	}
+	var profiles []Profile
+	for _, file := range files {
+		profileJson, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error reading profile file %s", file)
+		}
+		var profile Profile
+		if err = json.Unmarshal(profileJson, &profile); err != nil {
+			return nil, errors.Wrapf(err, "Error unmarshalling profile file %s", file)
+		}
+		if err = profile.Validate(); err != nil {
+			return nil, errors.Wrapf(err, "Invalid profile file %s", file)
+		}
+		profiles = append(profiles, profile)
+	}
+	log.Debugf("simulator/profile:LoadProfiles() Loaded %d profiles from %s", len(profiles), dir)
+	return profiles, nil
+}
+
+// DefaultProfiles returns the built-in profiles of the RHEL servers booted with UEFI, with and without Secure Boot
+func DefaultProfiles() []Profile {
+	return []Profile{
+		rhelProfile(ProfileRhelSuefi, true),
+		rhelProfile(ProfileRhelUefi, false),
+	}
+}
+
+func rhelProfile(name string, secureBoot bool) Profile {
+	const kernel = "vmlinuz-4.18.0-193.el8.x86_64"
+	hostInfo := taModel.HostInfo{
+		OSName:          "RedHatEnterprise",
+		OSVersion:       "8.2",
+		BiosName:        "Intel Corporation",
+		BiosVersion:     "SE5C620.86B.00.01.0014.070920180847",
+		ProcessorInfo:   "54 06 05 00 FF FB EB BF",
+		ProcessorFlags:  "FPU VME DE PSE TSC MSR PAE MCE CX8 APIC SEP MTRR PGE MCA CMOV PAT PSE-36 CLFSH DS ACPI MMX",
+		NumberOfSockets: 2,
+	}
+	hostInfo.HardwareFeatures.TPM.Supported = true
+	hostInfo.HardwareFeatures.TPM.Enabled = true
+	hostInfo.HardwareFeatures.TPM.Meta.TPMVersion = "2.0"
+	hostInfo.HardwareFeatures.UEFI.Supported = true
+	hostInfo.HardwareFeatures.UEFI.Enabled = true
+	hostInfo.HardwareFeatures.UEFI.Meta.SecureBootEnabled = secureBoot
+
+	secureBootValue := "00"
+	if secureBoot {
+		secureBootValue = "01"
+	}
+	events := []ProfileEvent{
+		{Pcr: 0, Type: "EV_S_CRTM_VERSION", Data: "${bios_version}"},
+		{Pcr: 0, Type: "EV_EFI_PLATFORM_FIRMWARE_BLOB", Data: "Firmware volume ${bios_version}"},
+		{Pcr: 1, Type: "EV_EFI_VARIABLE_BOOT", Data: "BootOrder", Value: "00000100"},
+		{Pcr: 1, Type: "EV_EFI_VARIABLE_BOOT", Data: "Boot0000", Value: "090100002c0052006500640020004800610074000000"},
+		{Pcr: 7, Type: "EV_EFI_VARIABLE_DRIVER_CONFIG", Data: "SecureBoot", Value: secureBootValue},
+		{Pcr: 7, Type: "EV_EFI_VARIABLE_DRIVER_CONFIG", Data: "PK", Value: "a159c0a5e494a74a87b5ab155c2bf072"},
+		{Pcr: 7, Type: "EV_EFI_VARIABLE_DRIVER_CONFIG", Data: "KEK", Value: "a159c0a5e494a74a87b5ab155c2bf072"},
+		{Pcr: 7, Type: "EV_EFI_VARIABLE_DRIVER_CONFIG", Data: "db", Value: "a159c0a5e494a74a87b5ab155c2bf072"},
+		{Pcr: 7, Type: "EV_EFI_VARIABLE_DRIVER_CONFIG", Data: "dbx", Value: "2616c4c14c509240aca941f936934328"},
+	}
+	for pcr := 0; pcr < 8; pcr++ {
+		events = append(events, ProfileEvent{Pcr: pcr, Type: "EV_SEPARATOR"})
+	}
+	events = append(events,
+		ProfileEvent{Pcr: 4, Type: "EV_EFI_ACTION", Data: "Calling EFI Application from Boot Option"},
+		ProfileEvent{Pcr: 4, Type: "EV_EFI_BOOT_SERVICES_APPLICATION", Data: `\EFI\redhat\shimx64.efi`})
+	if secureBoot {
+		events = append(events,
+			ProfileEvent{Pcr: 7, Type: "EV_EFI_VARIABLE_AUTHORITY", Data: "db", Value: "a159c0a5e494a74a87b5ab155c2bf072"},
+			ProfileEvent{Pcr: 7, Type: "EV_EFI_VARIABLE_AUTHORITY", Data: "MokListRT", Value: "3a6c7e4f"})
+	}
+	events = append(events,
+		ProfileEvent{Pcr: 4, Type: "EV_EFI_BOOT_SERVICES_APPLICATION", Data: `\EFI\redhat\grubx64.efi`},
+		ProfileEvent{Pcr: 8, Type: "EV_IPL", Data: "grub_cmd: linux (hd0,gpt2)/" + kernel + " root=/dev/mapper/rhel-root ro"},
+		ProfileEvent{Pcr: 8, Type: "EV_IPL", Data: "kernel_cmdline: (hd0,gpt2)/" + kernel + " root=/dev/mapper/rhel-root ro"},
+		ProfileEvent{Pcr: 9, Type: "EV_IPL", Data: "(hd0,gpt2)/" + kernel},
+		ProfileEvent{Pcr: 9, Type: "EV_IPL", Data: "(hd0,gpt2)/initramfs-4.18.0-193.el8.x86_64.img"},
+		ProfileEvent{Pcr: 4, Type: "EV_EFI_BOOT_SERVICES_APPLICATION", Data: `\` + kernel})
+
+	return Profile{
+		Name:     name,
+		HostInfo: hostInfo,
+		Events:   events,
+	}
+}
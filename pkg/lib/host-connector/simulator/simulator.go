@@ -0,0 +1,269 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+package simulator
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"hash/fnv"
+	"math/big"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	commLog "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/util"
+	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+var log = commLog.GetDefaultLogger()
+
+const (
+	// UrlScheme is the scheme of the URL of the simulated hosts in the connection strings
+	UrlScheme = "sim"
+
+	// the simulated hosts share a pool of AIKs, so that thousands of hosts can be simulated without generating as many
+	// RSA keys. Each host has its own AIK certificate.
+	aikKeyPoolSize = 16
+	aikKeyLength   = 2048
+	aikValidity    = 365 * 24 * time.Hour
+)
+
+// hardwareUuidNamespace is the namespace of the name based hardware UUIDs of the simulated hosts
+var hardwareUuidNamespace = uuid.MustParse("5c0b4d2e-8a1f-4e7b-9d36-2f1c8e6a7b40")
+
+// Host is a simulated host of a Profile, with its own host name and hardware UUID, and a software TPM holding the
+// measurements of the boot of the hosts of the profile
+type Host struct {
+	HostInfo taModel.HostInfo
+
+	// key identifies the profile and the drift the host was created with
+	key            string
+	tpm            *Tpm
+	aikCertificate []byte
+}
+
+// AikCertificate returns the DER encoded AIK certificate of the host
+func (host *Host) AikCertificate() []byte {
+	return host.aikCertificate
+}
+
+// Quote returns the quote of the PCRs selected in the format of tpm2-tools, with the event log and the PEM encoded
+// AIK certificate of the host
+func (host *Host) Quote(nonce []byte, selection string) (*types.LinuxHostQuote, error) {
+	quote, err := host.tpm.Quote(nonce, selection)
+	if err != nil {
+		return nil, err
+	}
+	aikPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: host.aikCertificate})
+	quote.AikCertificate = base64.StdEncoding.EncodeToString(aikPem)
+	return quote, nil
+}
+
+// Simulator creates the simulated hosts of its profiles. The AIK certificates of the hosts are issued by the AIK CA,
+// which must be trusted by the verifier for the hosts to be trusted. The hosts are created on first use, and are kept
+// until they are requested with another profile or drift.
+type Simulator struct {
+	profiles         map[string]Profile
+	aikCaCertificate *x509.Certificate
+	aikCaKey         crypto.Signer
+
+	lock    sync.Mutex
+	aikKeys []*rsa.PrivateKey
+	hosts   map[string]*Host
+}
+
+// NewSimulator returns a Simulator of the profiles, issuing the AIK certificates with the AIK CA
+func NewSimulator(profiles []Profile, aikCaCertificate *x509.Certificate, aikCaKey crypto.PrivateKey) (*Simulator, error) {
+	log.Trace("simulator/simulator:NewSimulator() Entering")
+	defer log.Trace("simulator/simulator:NewSimulator() Leaving")
+
+	if aikCaCertificate == nil {
+		return nil, errors.New("The AIK CA certificate must be provided")
+	}
+	signer, ok := aikCaKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("The AIK CA key must be a private key")
+	}
+	sim := &Simulator{
+		profiles:         make(map[string]Profile),
+		aikCaCertificate: aikCaCertificate,
+		aikCaKey:         signer,
+		aikKeys:          make([]*rsa.PrivateKey, aikKeyPoolSize),
+		hosts:            make(map[string]*Host),
+	}
+	for _, profile := range profiles {
+		if err := profile.Validate(); err != nil {
+			return nil, err
+		}
+		if _, ok := sim.profiles[profile.Name]; ok {
+			return nil, errors.Errorf("Duplicate profile %s", profile.Name)
+		}
+		sim.profiles[profile.Name] = profile
+	}
+	return sim, nil
+}
+
+// Host returns the simulated host of the profile with the drift
+func (sim *Simulator) Host(hostname, profileName string, drifts []Drift) (*Host, error) {
+	log.Trace("simulator/simulator:Host() Entering")
+	defer log.Trace("simulator/simulator:Host() Leaving")
+
+	profile, ok := sim.profiles[profileName]
+	if !ok {
+		return nil, errors.Errorf("Unknown profile %s", profileName)
+	}
+	key := profileName + "?" + driftString(drifts)
+
+	sim.lock.Lock()
+	defer sim.lock.Unlock()
+	if host, ok := sim.hosts[hostname]; ok && host.key == key {
+		return host, nil
+	}
+
+	aikKey, err := sim.aikKey(hostname)
+	if err != nil {
+		return nil, err
+	}
+	host, err := sim.newHost(hostname, &profile, drifts, aikKey)
+	if err != nil {
+		return nil, err
+	}
+	host.key = key
+	sim.hosts[hostname] = host
+	log.Debugf("simulator/simulator:Host() Created host %s of profile %s with drift '%s'", hostname, profileName,
+		driftString(drifts))
+	return host, nil
+}
+
+// aikKey returns the AIK of the pool used by the host, generating it on first use
+func (sim *Simulator) aikKey(hostname string) (*rsa.PrivateKey, error) {
+	index := fnv.New32a()
+	_, _ = index.Write([]byte(hostname))
+	i := index.Sum32() % aikKeyPoolSize
+	if sim.aikKeys[i] == nil {
+		aikKey, err := rsa.GenerateKey(rand.Reader, aikKeyLength)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error generating AIK")
+		}
+		sim.aikKeys[i] = aikKey
+	}
+	return sim.aikKeys[i], nil
+}
+
+// newHost creates the host, and measures the events of the profile in its TPM
+func (sim *Simulator) newHost(hostname string, profile *Profile, drifts []Drift, aikKey *rsa.PrivateKey) (*Host, error) {
+	host := &Host{
+		HostInfo: profile.HostInfo,
+		tpm:      NewTpm(aikKey),
+	}
+	host.HostInfo.HostName = hostname
+	host.HostInfo.HardwareUUID = uuid.NewSHA1(hardwareUuidNamespace, []byte(hostname)).String()
+	if hasDrift(drifts, DriftBiosUpgrade) {
+		host.HostInfo.BiosVersion += biosUpgradeSuffix
+	}
+
+	changedEvent := -1
+	if hasDrift(drifts, DriftEventLogChange) || hasDrift(drifts, DriftEventLogTamper) {
+		changedEvent = lastPcr7Event(profile.Events)
+	}
+	for i, event := range profile.Events {
+		measuredEvent := event
+		if i == changedEvent && hasDrift(drifts, DriftEventLogChange) {
+			measuredEvent = event.changed()
+		}
+		loggedEvent := measuredEvent
+		if i == changedEvent && hasDrift(drifts, DriftEventLogTamper) {
+			loggedEvent = measuredEvent.changed()
+		}
+		eventType, _ := util.TcgEventType(event.Type)
+		host.tpm.extend(event.Pcr, eventType, measuredEvent.eventData(&host.HostInfo),
+			loggedEvent.eventData(&host.HostInfo))
+	}
+
+	notBefore := time.Now().Add(-time.Hour)
+	notAfter := notBefore.Add(aikValidity)
+	if hasDrift(drifts, DriftExpiredAik) {
+		notBefore = notBefore.Add(-2 * aikValidity)
+		notAfter = time.Now().Add(-24 * time.Hour)
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, errors.Wrap(err, "Error generating AIK certificate serial number")
+	}
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: host.HostInfo.HardwareUUID},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	host.aikCertificate, err = x509.CreateCertificate(rand.Reader, &template, sim.aikCaCertificate, &aikKey.PublicKey,
+		sim.aikCaKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error issuing AIK certificate")
+	}
+	return host, nil
+}
+
+// lastPcr7Event returns the index of the last event measured in PCR 7 before the separator, or of the last event of
+// the profile when PCR 7 has no such event
+func lastPcr7Event(events []ProfileEvent) int {
+	last := -1
+	for i, event := range events {
+		if event.Pcr == 7 {
+			if event.Type == "EV_SEPARATOR" {
+				break
+			}
+			last = i
+		}
+	}
+	if last == -1 {
+		last = len(events) - 1
+	}
+	return last
+}
+
+// ConnectionString returns the connection string of the simulated host of the profile with the drift
+func ConnectionString(hostname, profileName string, drifts ...Drift) string {
+	simUrl := url.URL{
+		Scheme: UrlScheme,
+		Host:   hostname,
+		Path:   "/" + profileName,
+	}
+	if len(drifts) > 0 {
+		simUrl.RawQuery = "drift=" + driftString(drifts)
+	}
+	return fmt.Sprintf("%s:%s", strings.ToLower(constants.VendorSimulator.String()), simUrl.String())
+}
+
+// ParseUrl returns the host name, the profile and the drift of the URL of the simulated host, in the format
+// sim://<host name>/<profile>?drift=<drift>,...
+func ParseUrl(simUrl string) (string, string, []Drift, error) {
+	parsedUrl, err := url.Parse(simUrl)
+	if err != nil || parsedUrl.Scheme != UrlScheme || parsedUrl.Hostname() == "" {
+		return "", "", nil, errors.Errorf("Invalid simulated host URL %s", simUrl)
+	}
+	profileName := strings.Trim(parsedUrl.Path, "/")
+	if profileName == "" {
+		return "", "", nil, errors.Errorf("The profile is missing from the simulated host URL %s", simUrl)
+	}
+	drifts, err := ParseDrift(parsedUrl.Query().Get("drift"))
+	if err != nil {
+		return "", "", nil, err
+	}
+	return parsedUrl.Hostname(), profileName, drifts, nil
+}
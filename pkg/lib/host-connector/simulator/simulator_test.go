@@ -0,0 +1,209 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package simulator_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor"
+	cf "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	flavorUtil "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/util"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/simulator"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/verifier"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+const templatesDir = "../../../../build/linux/hvs/templates/"
+
+type testFleet struct {
+	aikCa     *x509.Certificate
+	factory   *host_connector.HostConnectorFactory
+	simulator *simulator.Simulator
+}
+
+func newTestFleet(t *testing.T) *testFleet {
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Simulator Privacy CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(1, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caCertificate, err := x509.CreateCertificate(rand.Reader, &template, &template, &caKey.PublicKey, caKey)
+	assert.NoError(t, err)
+	aikCa, err := x509.ParseCertificate(caCertificate)
+	assert.NoError(t, err)
+
+	sim, err := simulator.NewSimulator(simulator.DefaultProfiles(), aikCa, caKey)
+	assert.NoError(t, err)
+	factory := host_connector.NewHostConnectorFactory("", nil)
+	factory.EnableSimulator(sim)
+	return &testFleet{aikCa: aikCa, factory: factory, simulator: sim}
+}
+
+func (fleet *testFleet) hostManifest(t *testing.T, hostname, profile string, drifts ...simulator.Drift) *types.HostManifest {
+	connector, err := fleet.factory.NewHostConnector(simulator.ConnectionString(hostname, profile, drifts...))
+	assert.NoError(t, err)
+	hostManifest, err := connector.GetHostManifest(nil)
+	assert.NoError(t, err)
+	return &hostManifest
+}
+
+// flavors creates the signed PLATFORM and OS flavors of the host manifest with the default UEFI templates
+func flavors(t *testing.T, hostManifest *types.HostManifest) []hvs.SignedFlavor {
+	var templates []hvs.FlavorTemplate
+	for _, file := range []string{"default-linux-tpm20-suefi.json", "default-uefi.json"} {
+		templateJson, err := ioutil.ReadFile(templatesDir + file)
+		assert.NoError(t, err)
+		var template hvs.FlavorTemplate
+		assert.NoError(t, json.Unmarshal(templateJson, &template))
+		templates = append(templates, template)
+	}
+	provider, err := flavor.NewPlatformFlavorProvider(hostManifest, nil, templates)
+	assert.NoError(t, err)
+	platformFlavor, err := provider.GetPlatformFlavor()
+	assert.NoError(t, err)
+
+	signingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	var signedFlavors []hvs.SignedFlavor
+	for _, flavorPart := range []cf.FlavorPart{cf.FlavorPartPlatform, cf.FlavorPartOs} {
+		unsignedFlavors, err := (*platformFlavor).GetFlavorPartRaw(flavorPart)
+		assert.NoError(t, err)
+		for _, unsignedFlavor := range unsignedFlavors {
+			signedFlavor, err := flavorUtil.PlatformFlavorUtil{}.GetSignedFlavor(&unsignedFlavor, signingKey)
+			assert.NoError(t, err)
+			signedFlavors = append(signedFlavors, *signedFlavor)
+		}
+	}
+	return signedFlavors
+}
+
+// verify returns the faults of the host manifest verified against the flavors
+func (fleet *testFleet) verify(t *testing.T, hostManifest *types.HostManifest, signedFlavors []hvs.SignedFlavor) []string {
+	privacyCAs := x509.NewCertPool()
+	privacyCAs.AddCert(fleet.aikCa)
+	v, err := verifier.NewVerifier(verifier.VerifierCertificates{
+		PrivacyCACertificates:    privacyCAs,
+		AssetTagCACertificates:   x509.NewCertPool(),
+		FlavorSigningCertificate: fleet.aikCa,
+		FlavorCACertificates:     x509.NewCertPool(),
+	})
+	assert.NoError(t, err)
+
+	var faults []string
+	for i := range signedFlavors {
+		trustReport, err := v.Verify(hostManifest, &signedFlavors[i], true)
+		assert.NoError(t, err)
+		for _, result := range trustReport.Results {
+			for _, fault := range result.Faults {
+				faults = append(faults, fault.Name)
+			}
+		}
+	}
+	return faults
+}
+
+func TestSimulatedHostsOfProfile(t *testing.T) {
+	fleet := newTestFleet(t)
+
+	host1 := fleet.hostManifest(t, "sim-host-0001", simulator.ProfileRhelSuefi)
+	host2 := fleet.hostManifest(t, "sim-host-0002", simulator.ProfileRhelSuefi)
+	assert.Equal(t, "sim-host-0001", host1.HostInfo.HostName)
+	assert.NotEqual(t, host1.HostInfo.HardwareUUID, host2.HostInfo.HardwareUUID)
+	assert.Equal(t, host1.HostInfo.HardwareUUID, fleet.hostManifest(t, "sim-host-0001",
+		simulator.ProfileRhelSuefi).HostInfo.HardwareUUID)
+	assert.Equal(t, host1.PcrManifest.Sha256Pcrs, host2.PcrManifest.Sha256Pcrs)
+	assert.Equal(t, host1.PcrManifest.Sha1Pcrs, host2.PcrManifest.Sha1Pcrs)
+
+	other := fleet.hostManifest(t, "sim-host-0003", simulator.ProfileRhelUefi)
+	assert.False(t, other.HostInfo.HardwareFeatures.UEFI.Meta.SecureBootEnabled)
+	pcr7, _ := host1.PcrManifest.GetPcrValue(types.SHA256, types.PCR7)
+	otherPcr7, _ := other.PcrManifest.GetPcrValue(types.SHA256, types.PCR7)
+	assert.NotEqual(t, pcr7.Value, otherPcr7.Value)
+}
+
+func TestSimulatedHostsTrust(t *testing.T) {
+	fleet := newTestFleet(t)
+	signedFlavors := flavors(t, fleet.hostManifest(t, "golden", simulator.ProfileRhelSuefi))
+	assert.NotEmpty(t, signedFlavors)
+
+	assert.Empty(t, fleet.verify(t, fleet.hostManifest(t, "sim-host-0001", simulator.ProfileRhelSuefi), signedFlavors))
+
+	testCases := map[simulator.Drift]string{
+		simulator.DriftBiosUpgrade:    constants.FaultPcrValueMismatchSHA256,
+		simulator.DriftEventLogChange: constants.FaultPcrValueMismatchSHA256,
+		simulator.DriftEventLogTamper: constants.FaultPcrEventLogInvalid,
+		simulator.DriftExpiredAik:     constants.FaultAikCertificateExpired,
+	}
+	for drift, fault := range testCases {
+		hostManifest := fleet.hostManifest(t, "sim-host-0001", simulator.ProfileRhelSuefi, drift)
+		assert.Contains(t, fleet.verify(t, hostManifest, signedFlavors), fault, "Drift %s", drift)
+	}
+
+	// the drift is removed when the host is requested without it
+	assert.Empty(t, fleet.verify(t, fleet.hostManifest(t, "sim-host-0001", simulator.ProfileRhelSuefi), signedFlavors))
+}
+
+func TestSimulatedHostsNotEnabled(t *testing.T) {
+	_, err := host_connector.NewHostConnectorFactory("", nil).NewHostConnector(
+		simulator.ConnectionString("sim-host-0001", simulator.ProfileRhelSuefi))
+	assert.Error(t, err)
+}
+
+func TestConnectionString(t *testing.T) {
+	connectionString := simulator.ConnectionString("sim-host-0001", simulator.ProfileRhelUefi,
+		simulator.DriftBiosUpgrade, simulator.DriftExpiredAik)
+	assert.Equal(t, "simulator:sim://sim-host-0001/rhel-uefi?drift=bios-upgrade,expired-aik", connectionString)
+
+	hostname, profile, drifts, err := simulator.ParseUrl(connectionString[len("simulator:"):])
+	assert.NoError(t, err)
+	assert.Equal(t, "sim-host-0001", hostname)
+	assert.Equal(t, simulator.ProfileRhelUefi, profile)
+	assert.Equal(t, []simulator.Drift{simulator.DriftBiosUpgrade, simulator.DriftExpiredAik}, drifts)
+
+	_, _, _, err = simulator.ParseUrl("sim://sim-host-0001/rhel-uefi?drift=unknown")
+	assert.Error(t, err)
+	_, _, _, err = simulator.ParseUrl("sim://sim-host-0001")
+	assert.Error(t, err)
+}
+
+func TestLoadProfiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "profiles")
+	assert.NoError(t, err)
+	profile := simulator.DefaultProfiles()[0]
+	profile.Name = "custom"
+	profileJson, err := json.Marshal(profile)
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(dir+"/custom.json", profileJson, 0600))
+
+	profiles, err := simulator.LoadProfiles(dir)
+	assert.NoError(t, err)
+	assert.Len(t, profiles, 1)
+	assert.Equal(t, "custom", profiles[0].Name)
+
+	profile.Events[0].Type = "EV_UNKNOWN"
+	profileJson, _ = json.Marshal(profile)
+	assert.NoError(t, ioutil.WriteFile(dir+"/custom.json", profileJson, 0600))
+	_, err = simulator.LoadProfiles(dir)
+	assert.Error(t, err)
+}
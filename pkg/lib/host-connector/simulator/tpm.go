@@ -0,0 +1,204 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+package simulator
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"hash"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	tpmPcrCount = 24
+
+	tpmGeneratedValue = 0xff544347
+	tpmStAttestQuote  = 0x8018
+	tpmAlgRsassa      = 0x0014
+)
+
+// pcrBank is a PCR bank of the Tpm, named as in the PCR selections of tpm2-tools
+type pcrBank struct {
+	name        string
+	algorithmId uint16
+	newHash     func() hash.Hash
+	pcrs        [tpmPcrCount][]byte
+}
+
+// Tpm is a software model of a TPM 2.0 with the SHA1 and SHA256 PCR banks. The measurements are recorded in a TCG
+// crypto-agile event log, and the quotes of the PCRs are signed with the RSA AIK.
+type Tpm struct {
+	lock     sync.Mutex
+	aikKey   *rsa.PrivateKey
+	banks    []*pcrBank
+	eventLog bytes.Buffer
+}
+
+// NewTpm returns a Tpm started from locality 0, signing the quotes with the AIK
+func NewTpm(aikKey *rsa.PrivateKey) *Tpm {
+	tpm := &Tpm{
+		aikKey: aikKey,
+		banks: []*pcrBank{
+			{name: "sha1", algorithmId: util.TPM_API_ALG_ID_SHA1, newHash: sha1.New},
+			{name: "sha256", algorithmId: util.TPM_API_ALG_ID_SHA256, newHash: sha256.New},
+		},
+	}
+	tpm.Startup(0)
+	return tpm
+}
+
+// Startup resets the PCRs and the event log, and adds the Spec ID event and the startup locality event to the event
+// log. PCR 0 is reset to the locality, as done by a H-CRTM started from locality 3.
+func (tpm *Tpm) Startup(locality byte) {
+	tpm.lock.Lock()
+	defer tpm.lock.Unlock()
+
+	tpm.eventLog.Reset()
+	var specId bytes.Buffer
+	specId.WriteString(util.TCG_SPEC_ID_EVENT_SIGNATURE)
+	// platform class, spec version 2.0 errata 2, uintn size and the digest sizes
+	writeFields(&specId, binary.LittleEndian, uint32(0), uint8(0), uint8(2), uint8(0), uint8(2), uint32(len(tpm.banks)))
+	for _, bank := range tpm.banks {
+		writeFields(&specId, binary.LittleEndian, bank.algorithmId, uint16(bank.newHash().Size()))
+	}
+	specId.WriteByte(0)
+	writeFields(&tpm.eventLog, binary.LittleEndian, uint32(0), uint32(util.EV_NO_ACTION))
+	tpm.eventLog.Write(make([]byte, util.SHA1_SIZE))
+	writeFields(&tpm.eventLog, binary.LittleEndian, uint32(specId.Len()))
+	tpm.eventLog.Write(specId.Bytes())
+
+	startupLocality := append([]byte(util.TCG_STARTUP_LOCALITY_SIGNATURE), locality)
+	writeFields(&tpm.eventLog, binary.LittleEndian, uint32(0), uint32(util.EV_NO_ACTION), uint32(len(tpm.banks)))
+	for _, bank := range tpm.banks {
+		for i := range bank.pcrs {
+			bank.pcrs[i] = make([]byte, bank.newHash().Size())
+		}
+		bank.pcrs[0][len(bank.pcrs[0])-1] = locality
+		writeFields(&tpm.eventLog, binary.LittleEndian, bank.algorithmId)
+		tpm.eventLog.Write(make([]byte, bank.newHash().Size()))
+	}
+	writeFields(&tpm.eventLog, binary.LittleEndian, uint32(len(startupLocality)))
+	tpm.eventLog.Write(startupLocality)
+}
+
+// Extend measures the event data in the PCR of each bank, and adds the event to the event log
+func (tpm *Tpm) Extend(pcrIndex int, eventType uint32, data []byte) {
+	tpm.extend(pcrIndex, eventType, data, data)
+}
+
+// extend measures the data in the PCRs, and adds the logged data to the event log with its digests. The event log
+// does not match the PCRs when the logged data is not the measured data.
+func (tpm *Tpm) extend(pcrIndex int, eventType uint32, measuredData, loggedData []byte) {
+	tpm.lock.Lock()
+	defer tpm.lock.Unlock()
+
+	writeFields(&tpm.eventLog, binary.LittleEndian, uint32(pcrIndex), eventType, uint32(len(tpm.banks)))
+	for _, bank := range tpm.banks {
+		logged := bank.newHash()
+		logged.Write(loggedData)
+		writeFields(&tpm.eventLog, binary.LittleEndian, bank.algorithmId)
+		tpm.eventLog.Write(logged.Sum(nil))
+
+		measured := bank.newHash()
+		measured.Write(measuredData)
+		extend := bank.newHash()
+		extend.Write(bank.pcrs[pcrIndex])
+		extend.Write(measured.Sum(nil))
+		bank.pcrs[pcrIndex] = extend.Sum(nil)
+	}
+	writeFields(&tpm.eventLog, binary.LittleEndian, uint32(len(loggedData)))
+	tpm.eventLog.Write(loggedData)
+}
+
+// Quote returns the quote of the PCRs selected in the format of tpm2-tools, e.g. "sha1:0,1+sha256:0,1", with the
+// nonce as qualifying data. The quote is returned with the event log, but without AIK certificate.
+func (tpm *Tpm) Quote(nonce []byte, selection string) (*types.LinuxHostQuote, error) {
+	tpm.lock.Lock()
+	defer tpm.lock.Unlock()
+
+	var pcrSelections bytes.Buffer
+	var pcrValues bytes.Buffer
+	bankSelections := strings.Split(selection, "+")
+	for _, bankSelection := range bankSelections {
+		parts := strings.SplitN(bankSelection, ":", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("Invalid PCR selection %s", bankSelection)
+		}
+		var bank *pcrBank
+		for _, b := range tpm.banks {
+			if b.name == parts[0] {
+				bank = b
+			}
+		}
+		if bank == nil {
+			return nil, errors.Errorf("Unsupported PCR bank %s", parts[0])
+		}
+		pcrSelect := make([]byte, tpmPcrCount/8)
+		for _, pcr := range strings.Split(parts[1], ",") {
+			index, err := strconv.Atoi(pcr)
+			if err != nil || index < 0 || index >= tpmPcrCount {
+				return nil, errors.Errorf("Invalid PCR index %s", pcr)
+			}
+			pcrSelect[index/8] |= 1 << (uint(index) % 8)
+		}
+		for index := 0; index < tpmPcrCount; index++ {
+			if pcrSelect[index/8]&(1<<(uint(index)%8)) != 0 {
+				pcrValues.Write(bank.pcrs[index])
+			}
+		}
+		writeFields(&pcrSelections, binary.BigEndian, bank.algorithmId, uint8(len(pcrSelect)))
+		pcrSelections.Write(pcrSelect)
+	}
+	pcrDigest := sha256.Sum256(pcrValues.Bytes())
+
+	// TPMS_ATTEST: magic, type, qualified signer, extra data, clock info, firmware version and TPMS_QUOTE_INFO
+	var attest bytes.Buffer
+	writeFields(&attest, binary.BigEndian, uint32(tpmGeneratedValue), uint16(tpmStAttestQuote), uint16(0),
+		uint16(len(nonce)))
+	attest.Write(nonce)
+	writeFields(&attest, binary.BigEndian, uint64(time.Now().UnixNano()/1e6), uint32(0), uint32(0), uint8(1),
+		uint64(0), uint32(len(bankSelections)))
+	attest.Write(pcrSelections.Bytes())
+	writeFields(&attest, binary.BigEndian, uint16(len(pcrDigest)))
+	attest.Write(pcrDigest[:])
+
+	attestDigest := sha256.Sum256(attest.Bytes())
+	signature, err := rsa.SignPKCS1v15(rand.Reader, tpm.aikKey, crypto.SHA256, attestDigest[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "Error signing quote")
+	}
+	// TPMT_SIGNATURE
+	var tpmtSignature bytes.Buffer
+	writeFields(&tpmtSignature, binary.BigEndian, uint16(tpmAlgRsassa), uint16(util.TPM_API_ALG_ID_SHA256),
+		uint16(len(signature)))
+	tpmtSignature.Write(signature)
+
+	return &types.LinuxHostQuote{
+		Quote:     base64.StdEncoding.EncodeToString(attest.Bytes()),
+		Signature: base64.StdEncoding.EncodeToString(tpmtSignature.Bytes()),
+		Pcrs:      base64.StdEncoding.EncodeToString(pcrValues.Bytes()),
+		EventLog:  base64.StdEncoding.EncodeToString(tpm.eventLog.Bytes()),
+	}, nil
+}
+
+// writeFields writes the fixed size values in the byte order
+func writeFields(buffer *bytes.Buffer, order binary.ByteOrder, values ...interface{}) {
+	for _, value := range values {
+		_ = binary.Write(buffer, order, value)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package host_connector
+
+import (
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/simulator"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/pkg/errors"
+)
+
+type SimulatorConnectorFactory struct {
+	simulator *simulator.Simulator
+}
+
+// GetHostConnector returns a LinuxConnector running the agentless Linux host protocol on the simulated host of the
+// connection string
+func (scf *SimulatorConnectorFactory) GetHostConnector(vendorConnector types.VendorConnector, aasApiUrl string,
+	trustedCaCerts []x509.Certificate) (HostConnector, error) {
+
+	log.Trace("simulator_host_connector_factory:GetHostConnector() Entering")
+	defer log.Trace("simulator_host_connector_factory:GetHostConnector() Leaving")
+	if scf.simulator == nil {
+		return nil, errors.New("simulator_host_connector_factory:GetHostConnector() Simulated hosts are not enabled")
+	}
+	hostname, profile, drifts, err := simulator.ParseUrl(vendorConnector.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "simulator_host_connector_factory:GetHostConnector() Invalid connection string")
+	}
+	host, err := scf.simulator.Host(hostname, profile, drifts)
+	if err != nil {
+		return nil, errors.Wrap(err, "simulator_host_connector_factory:GetHostConnector() Error creating simulated host")
+	}
+	return NewLinuxConnector(&simulatedHostClient{host: host}), nil
+}
+
+// simulatedHostClient runs the operations of the agentless Linux host protocol on a simulated host
+type simulatedHostClient struct {
+	host *simulator.Host
+}
+
+func (client *simulatedHostClient) Run(operation string, args ...string) ([]byte, error) {
+	switch operation {
+	case LinuxHostInfoOperation:
+		return json.Marshal(client.host.HostInfo)
+	case LinuxQuoteOperation:
+		if len(args) != 2 {
+			return nil, errors.New("The quote operation expects the nonce and the PCR selection")
+		}
+		nonce, err := hex.DecodeString(args[0])
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid nonce")
+		}
+		quote, err := client.host.Quote(nonce, args[1])
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(quote)
+	}
+	return nil, errors.Errorf("Unsupported operation: %s", operation)
+}
@@ -0,0 +1,21 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package types
+
+// LinuxHostQuote is the response of the quote operation of the agentless Linux host protocol. The fields are
+// base64 encoded.
+type LinuxHostQuote struct {
+	// Quote is the TPMS_ATTEST structure, as output by tpm2_quote --message
+	Quote string `json:"quote"`
+	// Signature is the TPMT_SIGNATURE structure, as output by tpm2_quote --signature
+	Signature string `json:"signature"`
+	// Pcrs are the values of the quoted PCRs, as output by tpm2_quote --pcr --pcrs_format values
+	Pcrs string `json:"pcrs"`
+	// AikCertificate is the AIK certificate, in PEM format
+	AikCertificate string `json:"aik_certificate"`
+	// EventLog is the raw TCG event log, as exposed by the kernel
+	EventLog string `json:"event_log"`
+}
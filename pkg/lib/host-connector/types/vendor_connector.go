@@ -13,5 +13,7 @@ type VendorConnector struct {
 		Hostname string
 		Username string
 		Password string
+		// HostKey is the SHA256 fingerprint of the SSH host key of the agentless Linux hosts
+		HostKey string
 	}
 }
@@ -353,9 +353,21 @@ func getPcrEventLog(eventLog string) (types.PcrEventLogMap, error) {
 
 	log.Trace("util/aik_quote_verifier:getPcrEventLog() Entering")
 	defer log.Trace("util/aik_quote_verifier:getPcrEventLog() Leaving")
+	rawEventLog, ok, err := decodeRawEventLog(eventLog)
+	if err != nil {
+		return types.PcrEventLogMap{}, errors.Wrap(err, "util/aik_quote_verifier:getPcrEventLog() Error decoding raw TCG event log")
+	}
+	if ok {
+		pcrEventLogMap, err := ParseTcgEventLog(rawEventLog)
+		if err != nil {
+			return types.PcrEventLogMap{}, errors.Wrap(err, "util/aik_quote_verifier:getPcrEventLog() Error parsing raw TCG event log")
+		}
+		return pcrEventLogMap, nil
+	}
+
 	var pcrEventLogMap types.PcrEventLogMap
 	var measureLogs []types.MeasureLog
-	err := json.Unmarshal([]byte(eventLog), &measureLogs)
+	err = json.Unmarshal([]byte(eventLog), &measureLogs)
 	if err != nil {
 		return types.PcrEventLogMap{}, errors.Wrap(err, "util/aik_quote_verifier:getPcrEventLog() Error unmarshalling measureLog")
 	}
@@ -41,6 +41,7 @@ func GetConnectorDetails(connectionString string) (types.VendorConnector, error)
 	}
 	vendorConnector.Url, vendorConnector.Configuration.Username, vendorConnector.Configuration.Password,
 		vendorConnector.Configuration.Hostname = ParseConnectionString(vendorURL)
+	vendorConnector.Configuration.HostKey = parseHostKey(vendorURL)
 
 	if _, err := url.Parse(vendorConnector.Url); err != nil {
 		return types.VendorConnector{}, err
@@ -70,6 +71,10 @@ func GetVendorPrefix(connectionString string) constants.Vendor {
 		return constants.VendorVMware
 	} else if strings.HasPrefix(strings.ToLower(connectionString), strings.ToLower(constants.VendorMicrosoft.String()+":")) {
 		return constants.VendorMicrosoft
+	} else if strings.HasPrefix(strings.ToLower(connectionString), strings.ToLower(constants.VendorLinux.String()+":")) {
+		return constants.VendorLinux
+	} else if strings.HasPrefix(strings.ToLower(connectionString), strings.ToLower(constants.VendorSimulator.String()+":")) {
+		return constants.VendorSimulator
 	}
 	return constants.VendorUnknown
 }
@@ -112,6 +117,17 @@ func parseCredentials(credentials string) (string, string, string) {
 	return username, password, hostname
 }
 
+// parseHostKey returns the fingerprint of the SSH host key, provided with "k=" in the connection string of the
+// agentless Linux hosts
+func parseHostKey(vendorURL string) string {
+	for _, part := range strings.Split(vendorURL, ";")[1:] {
+		if strings.HasPrefix(part, "k=") {
+			return strings.TrimPrefix(part, "k=")
+		}
+	}
+	return ""
+}
+
 // getHostIP verifies that the hostname provided in the connection string can be resolved to an IPV4 address
 // since this will be required for the nonce verification
 func GetHostIP(hostRef string) (string, error) {
@@ -17,6 +17,7 @@ func TestGetConnectorDetails(t *testing.T) {
 	sampleUrl3 := "vmware:https://vsphere.com:443/sdk;h=hostName;u=admin.local;p=password"
 	sampleUrl4 := "https://vsphere.com:443/sdk;h=hostName;u=admin.local;p=password"
 	sampleUrl5 := "microsoft:https://microsoft.com:1443;u=admin.local;p=password"
+	sampleUrl6 := "linux:ssh://appliance.com:22;k=SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8;u=root;p=password"
 
 	invalidUrl := "https:// abcde"
 
@@ -40,6 +41,13 @@ func TestGetConnectorDetails(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, constants.VendorMicrosoft, connectorDetails.Vendor)
 
+	connectorDetails, err = GetConnectorDetails(sampleUrl6)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.VendorLinux, connectorDetails.Vendor)
+	assert.Equal(t, "ssh://appliance.com:22", connectorDetails.Url)
+	assert.Equal(t, "SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8", connectorDetails.Configuration.HostKey)
+	assert.Equal(t, "root", connectorDetails.Configuration.Username)
+
 	connectorDetails, err = GetConnectorDetails(invalidUrl)
 	assert.Error(t, err)
 }
@@ -0,0 +1,540 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package util
+
+//
+// This file parses the raw TCG binary event log, as exposed by the kernel in
+// /sys/kernel/security/tpm0/binary_bios_measurements. The log starts with a TCG_PCClientPCREvent
+// in the SHA1 format. When it is the "Spec ID Event03" EV_NO_ACTION event, the log is crypto-agile and
+// the next events are TCG_PCR_EVENT2 structures holding a digest for each PCR bank, otherwise all the
+// events are in the SHA1 format (TPM 1.2).
+//
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	EV_PREBOOT_CERT                  = 0x00000000
+	EV_POST_CODE                     = 0x00000001
+	EV_UNUSED                        = 0x00000002
+	EV_NO_ACTION                     = 0x00000003
+	EV_SEPARATOR                     = 0x00000004
+	EV_ACTION                        = 0x00000005
+	EV_EVENT_TAG                     = 0x00000006
+	EV_S_CRTM_CONTENTS               = 0x00000007
+	EV_S_CRTM_VERSION                = 0x00000008
+	EV_CPU_MICROCODE                 = 0x00000009
+	EV_PLATFORM_CONFIG_FLAGS         = 0x0000000A
+	EV_TABLE_OF_DEVICES              = 0x0000000B
+	EV_COMPACT_HASH                  = 0x0000000C
+	EV_IPL                           = 0x0000000D
+	EV_IPL_PARTITION_DATA            = 0x0000000E
+	EV_NONHOST_CODE                  = 0x0000000F
+	EV_NONHOST_CONFIG                = 0x00000010
+	EV_NONHOST_INFO                  = 0x00000011
+	EV_OMIT_BOOT_DEVICE_EVENTS       = 0x00000012
+	EV_EFI_VARIABLE_DRIVER_CONFIG    = 0x80000001
+	EV_EFI_VARIABLE_BOOT             = 0x80000002
+	EV_EFI_BOOT_SERVICES_APPLICATION = 0x80000003
+	EV_EFI_BOOT_SERVICES_DRIVER      = 0x80000004
+	EV_EFI_RUNTIME_SERVICES_DRIVER   = 0x80000005
+	EV_EFI_GPT_EVENT                 = 0x80000006
+	EV_EFI_ACTION                    = 0x80000007
+	EV_EFI_PLATFORM_FIRMWARE_BLOB    = 0x80000008
+	EV_EFI_HANDOFF_TABLES            = 0x80000009
+	EV_EFI_PLATFORM_FIRMWARE_BLOB2   = 0x8000000A
+	EV_EFI_HANDOFF_TABLES2           = 0x8000000B
+	EV_EFI_VARIABLE_BOOT2            = 0x8000000C
+	EV_EFI_HCRTM_EVENT               = 0x80000010
+	EV_EFI_VARIABLE_AUTHORITY        = 0x800000E0
+	EV_EFI_SPDM_FIRMWARE_BLOB        = 0x800000E1
+	EV_EFI_SPDM_FIRMWARE_CONFIG      = 0x800000E2
+
+	TCG_SPEC_ID_EVENT_SIGNATURE    = "Spec ID Event03\x00"
+	TCG_STARTUP_LOCALITY_SIGNATURE = "StartupLocality\x00"
+	// TCG_MAX_EVENT_SIZE bounds the size of the event data, the largest events are the EFI signature databases
+	TCG_MAX_EVENT_SIZE = 1024 * 1024
+	// TCG_MAX_DIGEST_COUNT bounds the number of digests of an event, that is the number of PCR banks
+	TCG_MAX_DIGEST_COUNT = 16
+)
+
+// RawTcgEventLogPrefix marks the event logs supplied by the connectors that are raw TCG event logs, base64 encoded
+// after the prefix, in place of the JSON measure log of the Trust Agent
+const RawTcgEventLogPrefix = "tcg-event-log:"
+
+var tcgEventTypeNames = map[uint32]string{
+	EV_PREBOOT_CERT:                  "EV_PREBOOT_CERT",
+	EV_POST_CODE:                     "EV_POST_CODE",
+	EV_UNUSED:                        "EV_UNUSED",
+	EV_NO_ACTION:                     "EV_NO_ACTION",
+	EV_SEPARATOR:                     "EV_SEPARATOR",
+	EV_ACTION:                        "EV_ACTION",
+	EV_EVENT_TAG:                     "EV_EVENT_TAG",
+	EV_S_CRTM_CONTENTS:               "EV_S_CRTM_CONTENTS",
+	EV_S_CRTM_VERSION:                "EV_S_CRTM_VERSION",
+	EV_CPU_MICROCODE:                 "EV_CPU_MICROCODE",
+	EV_PLATFORM_CONFIG_FLAGS:         "EV_PLATFORM_CONFIG_FLAGS",
+	EV_TABLE_OF_DEVICES:              "EV_TABLE_OF_DEVICES",
+	EV_COMPACT_HASH:                  "EV_COMPACT_HASH",
+	EV_IPL:                           "EV_IPL",
+	EV_IPL_PARTITION_DATA:            "EV_IPL_PARTITION_DATA",
+	EV_NONHOST_CODE:                  "EV_NONHOST_CODE",
+	EV_NONHOST_CONFIG:                "EV_NONHOST_CONFIG",
+	EV_NONHOST_INFO:                  "EV_NONHOST_INFO",
+	EV_OMIT_BOOT_DEVICE_EVENTS:       "EV_OMIT_BOOT_DEVICE_EVENTS",
+	EV_EFI_VARIABLE_DRIVER_CONFIG:    "EV_EFI_VARIABLE_DRIVER_CONFIG",
+	EV_EFI_VARIABLE_BOOT:             "EV_EFI_VARIABLE_BOOT",
+	EV_EFI_BOOT_SERVICES_APPLICATION: "EV_EFI_BOOT_SERVICES_APPLICATION",
+	EV_EFI_BOOT_SERVICES_DRIVER:      "EV_EFI_BOOT_SERVICES_DRIVER",
+	EV_EFI_RUNTIME_SERVICES_DRIVER:   "EV_EFI_RUNTIME_SERVICES_DRIVER",
+	EV_EFI_GPT_EVENT:                 "EV_EFI_GPT_EVENT",
+	EV_EFI_ACTION:                    "EV_EFI_ACTION",
+	EV_EFI_PLATFORM_FIRMWARE_BLOB:    "EV_EFI_PLATFORM_FIRMWARE_BLOB",
+	EV_EFI_HANDOFF_TABLES:            "EV_EFI_HANDOFF_TABLES",
+	EV_EFI_PLATFORM_FIRMWARE_BLOB2:   "EV_EFI_PLATFORM_FIRMWARE_BLOB2",
+	EV_EFI_HANDOFF_TABLES2:           "EV_EFI_HANDOFF_TABLES2",
+	EV_EFI_VARIABLE_BOOT2:            "EV_EFI_VARIABLE_BOOT2",
+	EV_EFI_HCRTM_EVENT:               "EV_EFI_HCRTM_EVENT",
+	EV_EFI_VARIABLE_AUTHORITY:        "EV_EFI_VARIABLE_AUTHORITY",
+	EV_EFI_SPDM_FIRMWARE_BLOB:        "EV_EFI_SPDM_FIRMWARE_BLOB",
+	EV_EFI_SPDM_FIRMWARE_CONFIG:      "EV_EFI_SPDM_FIRMWARE_CONFIG",
+}
+
+var tcgDigestAlgorithms = map[uint16]types.SHAAlgorithm{
+	TPM_API_ALG_ID_SHA1:   types.SHA1,
+	TPM_API_ALG_ID_SHA256: types.SHA256,
+	TPM_API_ALG_ID_SHA384: types.SHA384,
+	TPM_API_ALG_ID_SHA512: types.SHA512,
+}
+
+// TcgEventType returns the event type with the name, e.g. "EV_SEPARATOR"
+func TcgEventType(name string) (uint32, bool) {
+	for eventType, eventTypeName := range tcgEventTypeNames {
+		if eventTypeName == name {
+			return eventType, true
+		}
+	}
+	return 0, false
+}
+
+// TcgEvent is an event of the TCG binary event log. Digests holds the hex encoded digest of the event for each
+// PCR bank, the digests of the banks other than SHA1, SHA256, SHA384 and SHA512 are skipped.
+type TcgEvent struct {
+	PcrIndex  int
+	EventType uint32
+	Digests   map[types.SHAAlgorithm]string
+	Data      []byte
+}
+
+// TypeID returns the event type in the hexadecimal format of the Trust Agent event log, e.g. "0x80000001"
+func (event *TcgEvent) TypeID() string {
+	return fmt.Sprintf("0x%x", event.EventType)
+}
+
+// TypeName returns the name of the event type, or the event type in hexadecimal when it is not known
+func (event *TcgEvent) TypeName() string {
+	if name, ok := tcgEventTypeNames[event.EventType]; ok {
+		return name
+	}
+	return event.TypeID()
+}
+
+// Tags decodes the event data of the well-known event types into the tags of the Trust Agent event log: the name
+// of the EFI variables, the file path of the EFI images, the locality of the startup event and the text of the
+// events describing an action, a boot loader or the S-CRTM.
+func (event *TcgEvent) Tags() []string {
+	var tag string
+	switch event.EventType {
+	case EV_NO_ACTION:
+		if event.isStartupLocalityEvent() {
+			tag = fmt.Sprintf("%s%d", strings.TrimRight(TCG_STARTUP_LOCALITY_SIGNATURE, "\x00"),
+				event.Data[len(TCG_STARTUP_LOCALITY_SIGNATURE)])
+		}
+	case EV_EFI_VARIABLE_DRIVER_CONFIG, EV_EFI_VARIABLE_BOOT, EV_EFI_VARIABLE_BOOT2, EV_EFI_VARIABLE_AUTHORITY:
+		tag = efiVariableName(event.Data)
+	case EV_EFI_BOOT_SERVICES_APPLICATION, EV_EFI_BOOT_SERVICES_DRIVER, EV_EFI_RUNTIME_SERVICES_DRIVER:
+		tag = efiImageFilePath(event.Data)
+	case EV_S_CRTM_CONTENTS, EV_ACTION, EV_EFI_ACTION, EV_IPL, EV_COMPACT_HASH:
+		tag = printableEventData(event.Data)
+	}
+	if tag == "" {
+		return nil
+	}
+	return []string{tag}
+}
+
+// isStartupLocalityEvent returns true for the EV_NO_ACTION event holding the locality the TPM was started from
+func (event *TcgEvent) isStartupLocalityEvent() bool {
+	return event.EventType == EV_NO_ACTION && len(event.Data) > len(TCG_STARTUP_LOCALITY_SIGNATURE) &&
+		bytes.HasPrefix(event.Data, []byte(TCG_STARTUP_LOCALITY_SIGNATURE))
+}
+
+// ParseTcgEventLog parses the raw TCG binary event log into the PCR event log map of the host manifest. Only the
+// SHA1 and SHA256 banks are kept, as in the PCR manifest. The EV_NO_ACTION events are skipped, except for the
+// startup locality event that is needed to replay PCR 0.
+func ParseTcgEventLog(eventLog []byte) (types.PcrEventLogMap, error) {
+	log.Trace("util/tcg_event_log:ParseTcgEventLog() Entering")
+	defer log.Trace("util/tcg_event_log:ParseTcgEventLog() Leaving")
+
+	events, err := ParseTcgEvents(eventLog)
+	if err != nil {
+		return types.PcrEventLogMap{}, err
+	}
+
+	var pcrEventLogMap types.PcrEventLogMap
+	for _, bank := range []types.SHAAlgorithm{types.SHA1, types.SHA256} {
+		// group the events per PCR, in the order of the first event extending the PCR
+		var measureLogs []types.MeasureLog
+		pcrMeasureLogs := make(map[int]int)
+		for i := range events {
+			digest, ok := events[i].Digests[bank]
+			if !ok {
+				continue
+			}
+			if events[i].EventType == EV_NO_ACTION && !events[i].isStartupLocalityEvent() {
+				continue
+			}
+			if _, ok := pcrMeasureLogs[events[i].PcrIndex]; !ok {
+				pcrMeasureLogs[events[i].PcrIndex] = len(measureLogs)
+				measureLogs = append(measureLogs, types.MeasureLog{
+					Pcr: types.Pcr{Index: events[i].PcrIndex, Bank: string(bank)},
+				})
+			}
+			measureLog := &measureLogs[pcrMeasureLogs[events[i].PcrIndex]]
+			measureLog.TpmEvents = append(measureLog.TpmEvents, types.EventLog{
+				TypeID:      events[i].TypeID(),
+				TypeName:    events[i].TypeName(),
+				Tags:        events[i].Tags(),
+				Measurement: digest,
+			})
+		}
+		for _, measureLog := range measureLogs {
+			pcrEventLogMap = addPcrEntry(measureLog, pcrEventLogMap)
+		}
+	}
+	log.Debugf("util/tcg_event_log:ParseTcgEventLog() Successfully parsed %d events", len(events))
+	return pcrEventLogMap, nil
+}
+
+// ParseTcgEvents parses the events of the raw TCG binary event log, in the SHA1 or crypto-agile format. The "Spec
+// ID Event03" header of the crypto-agile logs is not returned.
+func ParseTcgEvents(eventLog []byte) ([]TcgEvent, error) {
+	log.Trace("util/tcg_event_log:ParseTcgEvents() Entering")
+	defer log.Trace("util/tcg_event_log:ParseTcgEvents() Leaving")
+
+	reader := &tcgEventLogReader{data: eventLog}
+	firstEvent, err := reader.readSha1Event()
+	if err != nil {
+		return nil, errors.Wrap(err, "util/tcg_event_log:ParseTcgEvents() Error parsing the first event of the event log")
+	}
+
+	if firstEvent.EventType != EV_NO_ACTION || !bytes.HasPrefix(firstEvent.Data, []byte(TCG_SPEC_ID_EVENT_SIGNATURE)) {
+		// SHA1 event log: all the events have the format of the first event
+		events := []TcgEvent{*firstEvent}
+		for !reader.done() {
+			event, err := reader.readSha1Event()
+			if err != nil {
+				return nil, errors.Wrapf(err, "util/tcg_event_log:ParseTcgEvents() Error parsing event %d of the SHA1 event log", len(events))
+			}
+			events = append(events, *event)
+		}
+		return events, nil
+	}
+
+	digestSizes, err := parseSpecIdEvent(firstEvent.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "util/tcg_event_log:ParseTcgEvents() Error parsing the Spec ID event")
+	}
+	var events []TcgEvent
+	for !reader.done() {
+		event, err := reader.readCryptoAgileEvent(digestSizes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "util/tcg_event_log:ParseTcgEvents() Error parsing event %d of the crypto-agile event log", len(events)+1)
+		}
+		events = append(events, *event)
+	}
+	return events, nil
+}
+
+// decodeRawEventLog returns the raw TCG event log supplied by a connector in place of the JSON measure log of the
+// Trust Agent. The event log is raw when it is marked with RawTcgEventLogPrefix, or when it starts with the Spec ID
+// event of a crypto-agile event log, either base64 encoded or as is. The other event logs are JSON measure logs.
+func decodeRawEventLog(eventLog string) ([]byte, bool, error) {
+	if strings.HasPrefix(eventLog, RawTcgEventLogPrefix) {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(strings.TrimPrefix(eventLog, RawTcgEventLogPrefix)))
+		if err != nil {
+			return nil, true, errors.Wrap(err, "util/tcg_event_log:decodeRawEventLog() Error decoding the raw TCG event log")
+		}
+		return decoded, true, nil
+	}
+
+	trimmed := strings.TrimSpace(eventLog)
+	if trimmed == "" || strings.HasPrefix(trimmed, "[") {
+		return nil, false, nil
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(trimmed); err == nil && isCryptoAgileEventLog(decoded) {
+		return decoded, true, nil
+	}
+	if isCryptoAgileEventLog([]byte(eventLog)) {
+		return []byte(eventLog), true, nil
+	}
+	return nil, false, nil
+}
+
+// isCryptoAgileEventLog checks if the event log starts with a valid Spec ID event
+func isCryptoAgileEventLog(eventLog []byte) bool {
+	reader := &tcgEventLogReader{data: eventLog}
+	firstEvent, err := reader.readSha1Event()
+	if err != nil || firstEvent.EventType != EV_NO_ACTION ||
+		!bytes.HasPrefix(firstEvent.Data, []byte(TCG_SPEC_ID_EVENT_SIGNATURE)) {
+		return false
+	}
+	_, err = parseSpecIdEvent(firstEvent.Data)
+	return err == nil
+}
+
+// parseSpecIdEvent returns the digest size of each PCR bank declared in the TCG_EfiSpecIdEvent
+func parseSpecIdEvent(data []byte) (map[uint16]int, error) {
+	reader := &tcgEventLogReader{data: data}
+	// signature, platform class, spec version minor, major and errata, uintn size
+	if _, err := reader.read(len(TCG_SPEC_ID_EVENT_SIGNATURE) + 4 + 4); err != nil {
+		return nil, err
+	}
+	algorithmCount, err := reader.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	if algorithmCount == 0 || algorithmCount > TCG_MAX_DIGEST_COUNT {
+		return nil, errors.Errorf("Invalid number of algorithms %d", algorithmCount)
+	}
+	digestSizes := make(map[uint16]int)
+	for i := 0; i < int(algorithmCount); i++ {
+		algorithmId, err := reader.readUint16()
+		if err != nil {
+			return nil, err
+		}
+		digestSize, err := reader.readUint16()
+		if err != nil {
+			return nil, err
+		}
+		digestSizes[algorithmId] = int(digestSize)
+	}
+	return digestSizes, nil
+}
+
+// tcgEventLogReader reads the little endian structures of the event log, with bounds checking
+type tcgEventLogReader struct {
+	data   []byte
+	offset int
+}
+
+func (reader *tcgEventLogReader) done() bool {
+	return reader.offset >= len(reader.data)
+}
+
+func (reader *tcgEventLogReader) read(size int) ([]byte, error) {
+	if size < 0 || size > len(reader.data)-reader.offset {
+		return nil, errors.Errorf("Event log is truncated at offset %d, %d bytes expected", reader.offset, size)
+	}
+	data := reader.data[reader.offset : reader.offset+size]
+	reader.offset += size
+	return data, nil
+}
+
+func (reader *tcgEventLogReader) readUint16() (uint16, error) {
+	data, err := reader.read(2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint16(data), nil
+}
+
+func (reader *tcgEventLogReader) readUint32() (uint32, error) {
+	data, err := reader.read(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(data), nil
+}
+
+// readEventHeader reads the PCR index and event type that start both event formats
+func (reader *tcgEventLogReader) readEventHeader() (*TcgEvent, error) {
+	pcrIndex, err := reader.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	if pcrIndex > uint32(types.PCR23) {
+		return nil, errors.Errorf("Invalid PCR index %d", pcrIndex)
+	}
+	eventType, err := reader.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	return &TcgEvent{PcrIndex: int(pcrIndex), EventType: eventType, Digests: make(map[types.SHAAlgorithm]string)}, nil
+}
+
+// readEventData reads the event size and data that end both event formats
+func (reader *tcgEventLogReader) readEventData(event *TcgEvent) error {
+	eventSize, err := reader.readUint32()
+	if err != nil {
+		return err
+	}
+	if eventSize > TCG_MAX_EVENT_SIZE {
+		return errors.Errorf("Invalid event size %d", eventSize)
+	}
+	event.Data, err = reader.read(int(eventSize))
+	return err
+}
+
+// readSha1Event reads a TCG_PCClientPCREvent
+func (reader *tcgEventLogReader) readSha1Event() (*TcgEvent, error) {
+	event, err := reader.readEventHeader()
+	if err != nil {
+		return nil, err
+	}
+	digest, err := reader.read(SHA1_SIZE)
+	if err != nil {
+		return nil, err
+	}
+	event.Digests[types.SHA1] = hex.EncodeToString(digest)
+	if err = reader.readEventData(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// readCryptoAgileEvent reads a TCG_PCR_EVENT2, the size of the digests are declared in the Spec ID event
+func (reader *tcgEventLogReader) readCryptoAgileEvent(digestSizes map[uint16]int) (*TcgEvent, error) {
+	event, err := reader.readEventHeader()
+	if err != nil {
+		return nil, err
+	}
+	digestCount, err := reader.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	if digestCount > TCG_MAX_DIGEST_COUNT {
+		return nil, errors.Errorf("Invalid number of digests %d", digestCount)
+	}
+	for i := 0; i < int(digestCount); i++ {
+		algorithmId, err := reader.readUint16()
+		if err != nil {
+			return nil, err
+		}
+		digestSize, ok := digestSizes[algorithmId]
+		if !ok {
+			return nil, errors.Errorf("Digest algorithm 0x%x is not declared in the Spec ID event", algorithmId)
+		}
+		digest, err := reader.read(digestSize)
+		if err != nil {
+			return nil, err
+		}
+		if bank, ok := tcgDigestAlgorithms[algorithmId]; ok {
+			event.Digests[bank] = hex.EncodeToString(digest)
+		}
+	}
+	if err = reader.readEventData(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// efiVariableName returns the name of the variable of a UEFI_VARIABLE_DATA event: the variable GUID, the name
+// length in characters and the data length, followed by the UTF-16 name and the data
+func efiVariableName(data []byte) string {
+	reader := &tcgEventLogReader{data: data}
+	if _, err := reader.read(16); err != nil {
+		return ""
+	}
+	nameLength, err := reader.read(8)
+	if err != nil {
+		return ""
+	}
+	if _, err = reader.read(8); err != nil {
+		return ""
+	}
+	length := binary.LittleEndian.Uint64(nameLength)
+	if length > uint64(len(data)) {
+		return ""
+	}
+	name, err := reader.read(2 * int(length))
+	if err != nil {
+		return ""
+	}
+	return decodeUtf16(name)
+}
+
+// efiImageFilePath returns the file path of the device path of a UEFI_IMAGE_LOAD_EVENT: the image address, length
+// and link time address, the device path length, followed by the device path nodes
+func efiImageFilePath(data []byte) string {
+	reader := &tcgEventLogReader{data: data}
+	if _, err := reader.read(3 * 8); err != nil {
+		return ""
+	}
+	devicePathLength, err := reader.read(8)
+	if err != nil {
+		return ""
+	}
+	length := binary.LittleEndian.Uint64(devicePathLength)
+	if length > uint64(len(data)) {
+		return ""
+	}
+	devicePath, err := reader.read(int(length))
+	if err != nil {
+		return ""
+	}
+
+	// collect the media file path nodes (type 0x04, sub type 0x04), up to the end of the device path (type 0x7f)
+	var filePaths []string
+	nodes := &tcgEventLogReader{data: devicePath}
+	for !nodes.done() {
+		header, err := nodes.read(4)
+		if err != nil || header[0] == 0x7f {
+			break
+		}
+		nodeLength := int(binary.LittleEndian.Uint16(header[2:4]))
+		if nodeLength < 4 {
+			break
+		}
+		node, err := nodes.read(nodeLength - 4)
+		if err != nil {
+			break
+		}
+		if header[0] == 0x04 && header[1] == 0x04 {
+			filePaths = append(filePaths, decodeUtf16(node))
+		}
+	}
+	return strings.Join(filePaths, "")
+}
+
+// printableEventData returns the event data when it is a printable ASCII string, with the trailing NUL characters
+// removed
+func printableEventData(data []byte) string {
+	text := strings.TrimRight(string(data), "\x00")
+	for _, c := range text {
+		if c < 0x20 || c > 0x7e {
+			return ""
+		}
+	}
+	return text
+}
+
+func decodeUtf16(data []byte) string {
+	chars := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		chars = append(chars, binary.LittleEndian.Uint16(data[i:i+2]))
+	}
+	return strings.TrimRight(string(utf16.Decode(chars)), "\x00")
+}
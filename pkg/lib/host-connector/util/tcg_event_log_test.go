@@ -0,0 +1,225 @@
+/*
+ *  Copyright (C) 2021 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package util
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type testTcgEvent struct {
+	pcrIndex  uint32
+	eventType uint32
+	data      []byte
+}
+
+func writeLittleEndian(buffer *bytes.Buffer, values ...interface{}) {
+	for _, value := range values {
+		_ = binary.Write(buffer, binary.LittleEndian, value)
+	}
+}
+
+func utf16Bytes(text string) []byte {
+	var buffer bytes.Buffer
+	writeLittleEndian(&buffer, utf16.Encode([]rune(text)))
+	return buffer.Bytes()
+}
+
+func efiVariableData(name string, data []byte) []byte {
+	var buffer bytes.Buffer
+	buffer.Write(make([]byte, 16))
+	writeLittleEndian(&buffer, uint64(len([]rune(name))), uint64(len(data)))
+	buffer.Write(utf16Bytes(name))
+	buffer.Write(data)
+	return buffer.Bytes()
+}
+
+func efiImageLoadData(filePath string) []byte {
+	var devicePath bytes.Buffer
+	path := utf16Bytes(filePath + "\x00")
+	writeLittleEndian(&devicePath, uint8(0x04), uint8(0x04), uint16(4+len(path)))
+	devicePath.Write(path)
+	writeLittleEndian(&devicePath, uint8(0x7f), uint8(0xff), uint16(4))
+
+	var buffer bytes.Buffer
+	writeLittleEndian(&buffer, uint64(0x1000), uint64(0x2000), uint64(0), uint64(devicePath.Len()))
+	buffer.Write(devicePath.Bytes())
+	return buffer.Bytes()
+}
+
+func testTcgEvents() []testTcgEvent {
+	return []testTcgEvent{
+		{0, EV_NO_ACTION, append([]byte(TCG_STARTUP_LOCALITY_SIGNATURE), 3)},
+		{0, EV_S_CRTM_CONTENTS, []byte("Boot Guard Measured S-CRTM\x00")},
+		{0, EV_POST_CODE, []byte{0x00, 0x00, 0xff, 0xff}},
+		{7, EV_EFI_VARIABLE_DRIVER_CONFIG, efiVariableData("SecureBoot", []byte{1})},
+		{7, EV_EFI_VARIABLE_DRIVER_CONFIG, efiVariableData("PK", []byte("pk"))},
+		{0, EV_SEPARATOR, []byte{0, 0, 0, 0}},
+		{7, EV_SEPARATOR, []byte{0, 0, 0, 0}},
+		{4, EV_EFI_BOOT_SERVICES_APPLICATION, efiImageLoadData(`\EFI\redhat\shimx64.efi`)},
+		{8, EV_IPL, []byte("grub_cmd: linux /vmlinuz root=/dev/sda1\x00")},
+		{14, 0x12345678, []byte{0x42}},
+	}
+}
+
+// newCryptoAgileEventLog builds a crypto-agile event log with SHA1, SHA256 and SHA384 digests of the event data
+func newCryptoAgileEventLog(events []testTcgEvent) []byte {
+	var specId bytes.Buffer
+	specId.WriteString(TCG_SPEC_ID_EVENT_SIGNATURE)
+	writeLittleEndian(&specId, uint32(0), uint8(0), uint8(2), uint8(0), uint8(2), uint32(3),
+		uint16(TPM_API_ALG_ID_SHA1), uint16(SHA1_SIZE), uint16(TPM_API_ALG_ID_SHA256), uint16(SHA256_SIZE),
+		uint16(TPM_API_ALG_ID_SHA384), uint16(SHA384_SIZE), uint8(0))
+
+	var eventLog bytes.Buffer
+	writeLittleEndian(&eventLog, uint32(0), uint32(EV_NO_ACTION), make([]byte, SHA1_SIZE), uint32(specId.Len()))
+	eventLog.Write(specId.Bytes())
+	for _, event := range events {
+		sha1Digest := sha1.Sum(event.data)
+		sha256Digest := sha256.Sum256(event.data)
+		sha384Digest := sha512.Sum384(event.data)
+		if event.eventType == EV_NO_ACTION {
+			sha1Digest, sha256Digest, sha384Digest = [20]byte{}, [32]byte{}, [48]byte{}
+		}
+		writeLittleEndian(&eventLog, event.pcrIndex, event.eventType, uint32(3),
+			uint16(TPM_API_ALG_ID_SHA1), sha1Digest, uint16(TPM_API_ALG_ID_SHA256), sha256Digest,
+			uint16(TPM_API_ALG_ID_SHA384), sha384Digest, uint32(len(event.data)))
+		eventLog.Write(event.data)
+	}
+	return eventLog.Bytes()
+}
+
+// newSha1EventLog builds an event log in the SHA1 format
+func newSha1EventLog(events []testTcgEvent) []byte {
+	var eventLog bytes.Buffer
+	for _, event := range events {
+		digest := sha1.Sum(event.data)
+		writeLittleEndian(&eventLog, event.pcrIndex, event.eventType, digest, uint32(len(event.data)))
+		eventLog.Write(event.data)
+	}
+	return eventLog.Bytes()
+}
+
+func TestParseTcgEventsCryptoAgile(t *testing.T) {
+	events, err := ParseTcgEvents(newCryptoAgileEventLog(testTcgEvents()))
+	assert.NoError(t, err)
+	assert.Len(t, events, len(testTcgEvents()))
+
+	sha384Digest := sha512.Sum384(testTcgEvents()[1].data)
+	assert.Equal(t, hex.EncodeToString(sha384Digest[:]), events[1].Digests[types.SHA384])
+	assert.Equal(t, "EV_S_CRTM_CONTENTS", events[1].TypeName())
+	assert.Equal(t, []string{"Boot Guard Measured S-CRTM"}, events[1].Tags())
+	assert.Nil(t, events[2].Tags())
+	assert.Equal(t, "0x80000001", events[3].TypeID())
+	assert.Equal(t, []string{"SecureBoot"}, events[3].Tags())
+	assert.Equal(t, []string{`\EFI\redhat\shimx64.efi`}, events[7].Tags())
+	assert.Equal(t, []string{"grub_cmd: linux /vmlinuz root=/dev/sda1"}, events[8].Tags())
+	assert.Equal(t, "0x12345678", events[9].TypeName())
+}
+
+func TestParseTcgEventLogCryptoAgile(t *testing.T) {
+	pcrEventLogMap, err := ParseTcgEventLog(newCryptoAgileEventLog(testTcgEvents()))
+	assert.NoError(t, err)
+	assert.Len(t, pcrEventLogMap.Sha1EventLogs, 5)
+	assert.Len(t, pcrEventLogMap.Sha256EventLogs, 5)
+
+	pcr0 := pcrEventLogMap.Sha256EventLogs[0]
+	assert.Equal(t, types.Pcr{Index: 0, Bank: SHA256}, pcr0.Pcr)
+	assert.Len(t, pcr0.TpmEvent, 4)
+	assert.Equal(t, types.StartupLocalityEvent, pcr0.TpmEvent[0].TypeName)
+	assert.Equal(t, []string{types.StartupLocalityTag}, pcr0.TpmEvent[0].Tags)
+
+	// the startup locality is the initial value of PCR 0
+	expected := make([]byte, SHA256_SIZE)
+	expected[SHA256_SIZE-1] = 3
+	for _, event := range testTcgEvents()[1:] {
+		if event.pcrIndex == 0 {
+			digest := sha256.Sum256(event.data)
+			extended := sha256.Sum256(append(expected, digest[:]...))
+			expected = extended[:]
+		}
+	}
+	replayed, err := pcr0.Replay()
+	assert.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(expected), replayed)
+
+	pcr7 := pcrEventLogMap.Sha1EventLogs[1]
+	assert.Equal(t, types.Pcr{Index: 7, Bank: SHA1}, pcr7.Pcr)
+	assert.Equal(t, []string{"PK"}, pcr7.TpmEvent[1].Tags)
+}
+
+func TestParseTcgEventLogSha1(t *testing.T) {
+	// the SHA1 event logs do not have a startup locality event
+	pcrEventLogMap, err := ParseTcgEventLog(newSha1EventLog(testTcgEvents()[1:]))
+	assert.NoError(t, err)
+	assert.Len(t, pcrEventLogMap.Sha1EventLogs, 5)
+	assert.Empty(t, pcrEventLogMap.Sha256EventLogs)
+	assert.Equal(t, "EV_S_CRTM_CONTENTS", pcrEventLogMap.Sha1EventLogs[0].TpmEvent[0].TypeName)
+}
+
+func TestParseTcgEventLogTruncated(t *testing.T) {
+	eventLog := newCryptoAgileEventLog(testTcgEvents())
+	_, err := ParseTcgEventLog(eventLog[:len(eventLog)-1])
+	assert.Error(t, err)
+
+	_, err = ParseTcgEventLog(eventLog[:10])
+	assert.Error(t, err)
+}
+
+func TestGetPcrEventLogRawEventLog(t *testing.T) {
+	eventLog := newCryptoAgileEventLog(testTcgEvents())
+	expected, err := ParseTcgEventLog(eventLog)
+	assert.NoError(t, err)
+
+	pcrEventLogMap, err := getPcrEventLog(base64.StdEncoding.EncodeToString(eventLog))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, pcrEventLogMap)
+
+	pcrEventLogMap, err = getPcrEventLog(string(eventLog))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, pcrEventLogMap)
+
+	pcrEventLogMap, err = getPcrEventLog(RawTcgEventLogPrefix + base64.StdEncoding.EncodeToString(eventLog))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, pcrEventLogMap)
+}
+
+func TestGetPcrEventLogRawSha1EventLog(t *testing.T) {
+	eventLog := newSha1EventLog(testTcgEvents()[1:])
+	expected, err := ParseTcgEventLog(eventLog)
+	assert.NoError(t, err)
+
+	// the SHA1 event logs have no Spec ID event, they are only parsed when marked as raw
+	pcrEventLogMap, err := getPcrEventLog(RawTcgEventLogPrefix + base64.StdEncoding.EncodeToString(eventLog))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, pcrEventLogMap)
+
+	_, err = getPcrEventLog(base64.StdEncoding.EncodeToString(eventLog))
+	assert.Error(t, err)
+
+	_, err = getPcrEventLog(RawTcgEventLogPrefix + "not base64")
+	assert.Error(t, err)
+}
+
+func TestGetPcrEventLogMeasureLog(t *testing.T) {
+	// "null" is valid base64, but not a TCG event log
+	pcrEventLogMap, err := getPcrEventLog("null")
+	assert.NoError(t, err)
+	assert.Equal(t, types.PcrEventLogMap{}, pcrEventLogMap)
+
+	pcrEventLogMap, err = getPcrEventLog(`[{"pcr": {"index": 17, "bank": "SHA256"}, "tpm_events": []}]`)
+	assert.NoError(t, err)
+	assert.Len(t, pcrEventLogMap.Sha256EventLogs, 1)
+}
@@ -123,6 +123,24 @@ func (factory *ruleFactory) GetVerificationRules() ([]rules.Rule, string, error)
 		}
 	}
 
+	// add the host info policy rules of the platform flavor
+	if flavorPart == common.FlavorPartPlatform && factory.signedFlavor.Flavor.HostInfoPolicy != nil {
+		policyRules, err := rules.NewHostInfoPolicyRules(factory.signedFlavor.Flavor.HostInfoPolicy, flavorPart)
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "Error creating host info policy rules for flavor '%s'", factory.signedFlavor.Flavor.Meta.ID)
+		}
+		requiredRules = append(requiredRules, policyRules...)
+	}
+
+	// add the site specific rules copied from the flavor templates
+	for _, customRule := range factory.signedFlavor.Flavor.CustomRules {
+		rule, err := rules.NewCustomRule(customRule, flavorPart)
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "Error creating custom rules for flavor '%s'", factory.signedFlavor.Flavor.Meta.ID)
+		}
+		requiredRules = append(requiredRules, rule)
+	}
+
 	// if skip flavor signing verification is enabled, add the FlavorTrusted.
 	if !factory.skipSignedFlavorVerification {
 		var flavorPart common.FlavorPart
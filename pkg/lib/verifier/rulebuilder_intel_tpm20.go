@@ -52,7 +52,8 @@ func (builder *ruleBuilderIntelTpm20) GetAikCertificateTrustedRule(flavorPart co
 	//
 	// Add 'AikCertificateTrusted' rule...
 	//
-	aikCertificateTrusted, err := rules.NewAikCertificateTrusted(builder.verifierCertificates.PrivacyCACertificates, flavorPart)
+	aikCertificateTrusted, err := rules.NewAikCertificateTrusted(builder.verifierCertificates.PrivacyCACertificates,
+		builder.verifierCertificates.AikCertificateStatus, builder.verifierCertificates.AikCertificateExpiryWarning, flavorPart)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error in getting AikCertificateTrusted rule")
 	}
@@ -19,7 +19,16 @@ import (
 	"time"
 )
 
-func NewAikCertificateTrusted(privacyCACertificates *x509.CertPool, marker common.FlavorPart) (Rule, error) {
+// AikCertificateStatus reports the status of the AIK certificates in the inventory of the privacy CA
+type AikCertificateStatus interface {
+	// IsRevoked returns true when the privacy CA revoked the AIK certificate
+	IsRevoked(aik *x509.Certificate) (bool, error)
+}
+
+// NewAikCertificateTrusted creates the rule validating the AIK of the host manifest against the privacy CAs. When
+// aikStatus is not nil the AIK certificates revoked by the privacy CA are faulted, and when expiryWarning is not zero
+// the AIK certificates that expire within expiryWarning are faulted.
+func NewAikCertificateTrusted(privacyCACertificates *x509.CertPool, aikStatus AikCertificateStatus, expiryWarning time.Duration, marker common.FlavorPart) (Rule, error) {
 
 	if privacyCACertificates == nil {
 		return nil, errors.New("The privacy CAs cannot be nil")
@@ -27,6 +36,8 @@ func NewAikCertificateTrusted(privacyCACertificates *x509.CertPool, marker commo
 
 	rule := aikCertTrusted{
 		privacyCACertificates: privacyCACertificates,
+		aikStatus:             aikStatus,
+		expiryWarning:         expiryWarning,
 		marker:                marker,
 	}
 	return &rule, nil
@@ -34,6 +45,8 @@ func NewAikCertificateTrusted(privacyCACertificates *x509.CertPool, marker commo
 
 type aikCertTrusted struct {
 	privacyCACertificates *x509.CertPool
+	aikStatus             AikCertificateStatus
+	expiryWarning         time.Duration
 	marker                common.FlavorPart
 }
 
@@ -41,6 +54,8 @@ type aikCertTrusted struct {
 // - if the host cert is not valid, raise 'aik expired' or 'aik not yet valid' faults
 // - check the host's aik against the trustedAuthority certs and raise 'not trusted' fault
 //   if none are valid
+// - if the privacy CA revoked the aik, raise 'aik revoked' fault
+// - if the aik expires within the expiry warning, raise 'aik expiring soon' fault
 func (rule *aikCertTrusted) Apply(hostManifest *types.HostManifest) (*hvs.RuleResult, error) {
 
 	var fault *hvs.Fault
@@ -82,6 +97,23 @@ func (rule *aikCertTrusted) Apply(hostManifest *types.HostManifest) (*hvs.RuleRe
 					Name:        constants.FaultAikCertificateNotTrusted,
 					Description: "AIK certificate is not signed by any trusted CA",
 				}
+			} else if rule.aikStatus != nil {
+				revoked, err := rule.aikStatus.IsRevoked(aik)
+				if err != nil {
+					return nil, errors.Wrap(err, "Could not retrieve the revocation status of the AIK to validate rule AikCertificateTrusted")
+				}
+				if revoked {
+					fault = &hvs.Fault{
+						Name:        constants.FaultAikCertificateRevoked,
+						Description: fmt.Sprintf("AIK certificate with serial number '%x' is revoked by the privacy CA", aik.SerialNumber),
+					}
+				}
+			}
+			if fault == nil && rule.expiryWarning > 0 && time.Now().Add(rule.expiryWarning).After(aik.NotAfter) {
+				fault = &hvs.Fault{
+					Name:        constants.FaultAikCertificateExpiringSoon,
+					Description: fmt.Sprintf("AIK certificate expires on '%s', it must be renewed by provisioning a new AIK", aik.NotAfter),
+				}
 			}
 		}
 	}
@@ -124,7 +124,7 @@ func TestAikCertificateTrustedNoFault(t *testing.T) {
 		AIKCertificate: base64.StdEncoding.EncodeToString([]byte(aikBytes)),
 	}
 
-	rule, err := NewAikCertificateTrusted(trustedAuthorityCerts, "PLATFORM")
+	rule, err := NewAikCertificateTrusted(trustedAuthorityCerts, nil, 0, "PLATFORM")
 	assert.NoError(t, err)
 
 	result, err := rule.Apply(&hostManifest)
@@ -143,7 +143,7 @@ func TestAikCertificateTrustedMissingFault(t *testing.T) {
 		AIKCertificate: "",
 	}
 
-	rule, err := NewAikCertificateTrusted(&trustedAuthorityCerts, "PLATFORM")
+	rule, err := NewAikCertificateTrusted(&trustedAuthorityCerts, nil, 0, "PLATFORM")
 	assert.NoError(t, err)
 
 	result, err := rule.Apply(&hostManifest)
@@ -174,7 +174,7 @@ func TestAikCertificateTrustedExpiredFault(t *testing.T) {
 		AIKCertificate: base64.StdEncoding.EncodeToString([]byte(aikBytes)),
 	}
 
-	rule, err := NewAikCertificateTrusted(&trustedAuthorityCerts, "PLATFORM")
+	rule, err := NewAikCertificateTrusted(&trustedAuthorityCerts, nil, 0, "PLATFORM")
 	assert.NoError(t, err)
 
 	result, err := rule.Apply(&hostManifest)
@@ -205,7 +205,7 @@ func TestAikCertificateTrustedNotBeforeFault(t *testing.T) {
 		AIKCertificate: base64.StdEncoding.EncodeToString([]byte(aikBytes)),
 	}
 
-	rule, err := NewAikCertificateTrusted(&trustedAuthorityCerts, "PLATFORM")
+	rule, err := NewAikCertificateTrusted(&trustedAuthorityCerts, nil, 0, "PLATFORM")
 	assert.NoError(t, err)
 
 	result, err := rule.Apply(&hostManifest)
@@ -231,7 +231,7 @@ func TestAikCertificateTrustedNotTrustedFault(t *testing.T) {
 		AIKCertificate: base64.StdEncoding.EncodeToString([]byte(aikBytes)),
 	}
 
-	rule, err := NewAikCertificateTrusted(&trustedAuthorityCerts, "PLATFORM")
+	rule, err := NewAikCertificateTrusted(&trustedAuthorityCerts, nil, 0, "PLATFORM")
 	assert.NoError(t, err)
 
 	result, err := rule.Apply(&hostManifest)
@@ -241,3 +241,108 @@ func TestAikCertificateTrustedNotTrustedFault(t *testing.T) {
 	assert.Equal(t, result.Faults[0].Name, constants.FaultAikCertificateNotTrusted)
 	t.Logf("Fault description: %s", result.Faults[0].Description)
 }
+
+type testAikCertificateStatus struct {
+	revokedSerialNumbers []*big.Int
+}
+
+func (status *testAikCertificateStatus) IsRevoked(aik *x509.Certificate) (bool, error) {
+	for _, serialNumber := range status.revokedSerialNumbers {
+		if serialNumber.Cmp(aik.SerialNumber) == 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// newPrivacyCASignedAik returns the privacy CA certificates and the base64 encoded AIK certificate signed by the
+// privacy CA
+func newPrivacyCASignedAik(t *testing.T, aikCertificate *x509.Certificate) (*x509.CertPool, string) {
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	caTemplate := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "HVS Privacy Certificate"},
+		NotBefore:             time.Now().AddDate(-1, 0, 0),
+		NotAfter:              time.Now().AddDate(1, 0, 0),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+	}
+	caBytes, err := x509.CreateCertificate(rand.Reader, &caTemplate, &caTemplate, &caKey.PublicKey, caKey)
+	assert.NoError(t, err)
+	caCertificate, err := x509.ParseCertificate(caBytes)
+	assert.NoError(t, err)
+
+	aikKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	aikBytes, err := x509.CreateCertificate(rand.Reader, aikCertificate, caCertificate, &aikKey.PublicKey, caKey)
+	assert.NoError(t, err)
+
+	privacyCACertificates := x509.NewCertPool()
+	privacyCACertificates.AddCert(caCertificate)
+	return privacyCACertificates, base64.StdEncoding.EncodeToString(aikBytes)
+}
+
+func newAikCertificateTemplate(notAfter time.Time) *x509.Certificate {
+	return &x509.Certificate{
+		SerialNumber: big.NewInt(2021),
+		Subject:      pkix.Name{CommonName: "HVS Privacy Certificate"},
+		NotBefore:    time.Now().AddDate(-1, 0, 0),
+		NotAfter:     notAfter,
+	}
+}
+
+func TestAikCertificateTrustedRevokedFault(t *testing.T) {
+
+	aikCertificate := newAikCertificateTemplate(time.Now().AddDate(1, 0, 0))
+	privacyCACertificates, aik := newPrivacyCASignedAik(t, aikCertificate)
+	hostManifest := types.HostManifest{
+		AIKCertificate: aik,
+	}
+
+	// the aik is trusted as long as the privacy CA did not revoke it
+	rule, err := NewAikCertificateTrusted(privacyCACertificates, &testAikCertificateStatus{}, 0, "PLATFORM")
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, len(result.Faults), 0)
+
+	rule, err = NewAikCertificateTrusted(privacyCACertificates,
+		&testAikCertificateStatus{revokedSerialNumbers: []*big.Int{aikCertificate.SerialNumber}}, 0, "PLATFORM")
+	assert.NoError(t, err)
+
+	result, err = rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, len(result.Faults), 1)
+	assert.Equal(t, result.Faults[0].Name, constants.FaultAikCertificateRevoked)
+	t.Logf("Fault description: %s", result.Faults[0].Description)
+}
+
+func TestAikCertificateTrustedExpiringSoonFault(t *testing.T) {
+
+	// the aik expires in ten days
+	privacyCACertificates, aik := newPrivacyCASignedAik(t, newAikCertificateTemplate(time.Now().AddDate(0, 0, 10)))
+	hostManifest := types.HostManifest{
+		AIKCertificate: aik,
+	}
+
+	rule, err := NewAikCertificateTrusted(privacyCACertificates, nil, 7*24*time.Hour, "PLATFORM")
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, len(result.Faults), 0)
+
+	rule, err = NewAikCertificateTrusted(privacyCACertificates, nil, 30*24*time.Hour, "PLATFORM")
+	assert.NoError(t, err)
+
+	result, err = rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, len(result.Faults), 1)
+	assert.Equal(t, result.Faults[0].Name, constants.FaultAikCertificateExpiringSoon)
+	t.Logf("Fault description: %s", result.Faults[0].Description)
+}
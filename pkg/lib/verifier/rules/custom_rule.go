@@ -0,0 +1,92 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+//
+// Registry of the custom rule types, used to create the rules evaluating the site specific
+// rules declared in the flavor templates and flavorgroups.
+//
+
+import (
+	"regexp"
+	"sync"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/pkg/errors"
+)
+
+// CustomRuleBuilder creates the Rule evaluating a custom rule, with results marked with the flavor part
+type CustomRuleBuilder func(customRule model.CustomRule, marker common.FlavorPart) (Rule, error)
+
+var (
+	customRuleBuilders = map[string]CustomRuleBuilder{
+		model.CustomRuleTypeJsonQuery: NewHostManifestQuery,
+	}
+	customRuleBuildersLock sync.RWMutex
+
+	customRuleNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_.\-]{1,255}$`)
+)
+
+// RegisterCustomRuleType adds a type of custom rules to the registry. The custom rules of this type are evaluated
+// with the rules created by the builder.
+func RegisterCustomRuleType(ruleType string, builder CustomRuleBuilder) error {
+	if ruleType == "" || builder == nil {
+		return errors.New("The custom rule type and builder must be provided")
+	}
+
+	customRuleBuildersLock.Lock()
+	defer customRuleBuildersLock.Unlock()
+
+	if _, ok := customRuleBuilders[ruleType]; ok {
+		return errors.Errorf("The custom rule type '%s' is already registered", ruleType)
+	}
+	customRuleBuilders[ruleType] = builder
+	return nil
+}
+
+// NewCustomRule creates the Rule evaluating the custom rule with the builder registered for its type
+func NewCustomRule(customRule model.CustomRule, marker common.FlavorPart) (Rule, error) {
+	if !customRuleNameRegex.MatchString(customRule.Name) {
+		return nil, errors.Errorf("Invalid custom rule name '%s'", customRule.Name)
+	}
+
+	customRuleBuildersLock.RLock()
+	builder, ok := customRuleBuilders[customRule.GetType()]
+	customRuleBuildersLock.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("Unknown type '%s' of custom rule '%s'", customRule.GetType(), customRule.Name)
+	}
+
+	rule, err := builder(customRule, marker)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error creating custom rule '%s'", customRule.Name)
+	}
+	return rule, nil
+}
+
+// ValidateCustomRules checks that the custom rules have unique names, a valid flavor part and a registered type, and
+// that their rules can be created
+func ValidateCustomRules(customRules []model.CustomRule) error {
+	names := make(map[string]bool)
+	for _, customRule := range customRules {
+		if names[customRule.Name] {
+			return errors.Errorf("Duplicate custom rule name '%s'", customRule.Name)
+		}
+		names[customRule.Name] = true
+
+		marker := common.FlavorPartPlatform
+		if customRule.FlavorPart != "" {
+			if err := (&marker).Parse(customRule.FlavorPart.String()); err != nil {
+				return errors.Wrapf(err, "Invalid flavor part of custom rule '%s'", customRule.Name)
+			}
+		}
+
+		if _, err := NewCustomRule(customRule, marker); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"testing"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+type cbntProfileRule struct {
+	marker common.FlavorPart
+}
+
+func (rule *cbntProfileRule) Apply(hostManifest *types.HostManifest) (*hvs.RuleResult, error) {
+	result := hvs.RuleResult{Trusted: true}
+	result.Rule.Markers = append(result.Rule.Markers, rule.marker)
+	if hostManifest.HostInfo.HardwareFeatures.CBNT.Meta.Profile != "BTGP5" {
+		result.Faults = append(result.Faults, hvs.Fault{Name: "CbntProfileMismatch"})
+	}
+	return &result, nil
+}
+
+func TestRegisterCustomRuleType(t *testing.T) {
+	err := RegisterCustomRuleType("cbnt_profile", func(customRule model.CustomRule, marker common.FlavorPart) (Rule, error) {
+		return &cbntProfileRule{marker: marker}, nil
+	})
+	assert.NoError(t, err)
+
+	// the types can be registered only once
+	err = RegisterCustomRuleType(model.CustomRuleTypeJsonQuery, NewHostManifestQuery)
+	assert.Error(t, err)
+
+	rule, err := NewCustomRule(model.CustomRule{Name: "CbntProfile5", Type: "cbnt_profile"}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	hostManifest := types.HostManifest{}
+	hostManifest.HostInfo.HardwareFeatures.CBNT.Meta.Profile = "BTGP3"
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+}
+
+func TestNewCustomRuleDefaultType(t *testing.T) {
+	rule, err := NewCustomRule(model.CustomRule{Name: "Linux", Query: "//host_info[os_name='RedHatEnterprise']"}, common.FlavorPartOs)
+	assert.NoError(t, err)
+	assert.IsType(t, &hostManifestQuery{}, rule)
+
+	_, err = NewCustomRule(model.CustomRule{Name: "Unknown", Type: "unknown", Query: "//host_info"}, common.FlavorPartOs)
+	assert.Error(t, err)
+
+	_, err = NewCustomRule(model.CustomRule{Name: "invalid name", Query: "//host_info"}, common.FlavorPartOs)
+	assert.Error(t, err)
+}
+
+func TestValidateCustomRules(t *testing.T) {
+	validRule := model.CustomRule{Name: "SecureBootEnabled", Query: "//host_info/hardware_features/UEFI/meta[secure_boot_enabled='true']"}
+
+	assert.NoError(t, ValidateCustomRules([]model.CustomRule{validRule}))
+	assert.Error(t, ValidateCustomRules([]model.CustomRule{validRule, validRule}))
+
+	invalidFlavorPart := validRule
+	invalidFlavorPart.FlavorPart = "UNKNOWN"
+	assert.Error(t, ValidateCustomRules([]model.CustomRule{invalidFlavorPart}))
+}
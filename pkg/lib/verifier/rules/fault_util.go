@@ -106,3 +106,10 @@ func newPcrManifestMissingFault() hvs.Fault {
 		Description: "Host report does not include a PCR Manifest",
 	}
 }
+
+func newHostInfoMissingFault(attribute string) hvs.Fault {
+	return hvs.Fault{
+		Name:        faultsConst.FaultHostInfoMissing,
+		Description: fmt.Sprintf("Host report does not include host info %s", attribute),
+	}
+}
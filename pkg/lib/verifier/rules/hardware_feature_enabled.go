@@ -0,0 +1,63 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"fmt"
+
+	constants "github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+// NewHardwareFeatureEnabled creates the rule verifying that a hardware feature of the host info is enabled
+func NewHardwareFeatureEnabled(feature string, marker common.FlavorPart) (Rule, error) {
+	if _, _, err := getHardwareFeature(&taModel.HostInfo{}, feature); err != nil {
+		return nil, errors.Wrap(err, "Could not create the hardware feature enabled rule")
+	}
+
+	return &hardwareFeatureEnabled{
+		feature: feature,
+		marker:  marker,
+	}, nil
+}
+
+type hardwareFeatureEnabled struct {
+	feature string
+	marker  common.FlavorPart
+}
+
+// - If the hardware feature is not supported by the host, create a FaultHardwareFeatureNotSupported.
+// - If the hardware feature is not enabled, create a FaultHardwareFeatureNotEnabled.
+func (rule *hardwareFeatureEnabled) Apply(hostManifest *types.HostManifest) (*hvs.RuleResult, error) {
+
+	result := hvs.RuleResult{}
+	result.Trusted = true
+	result.Rule.Name = constants.RuleHardwareFeatureEnabled
+	result.Rule.Markers = append(result.Rule.Markers, rule.marker)
+	result.Rule.Attribute = rule.feature
+
+	supported, enabled, err := getHardwareFeature(&hostManifest.HostInfo, rule.feature)
+	if err != nil {
+		return nil, err
+	}
+
+	if !supported {
+		result.Faults = append(result.Faults, hvs.Fault{
+			Name:        constants.FaultHardwareFeatureNotSupported,
+			Description: fmt.Sprintf("Host does not support the required hardware feature %s", rule.feature),
+		})
+	} else if !enabled {
+		result.Faults = append(result.Faults, hvs.Fault{
+			Name:        constants.FaultHardwareFeatureNotEnabled,
+			Description: fmt.Sprintf("Required hardware feature %s is not enabled on the host", rule.feature),
+		})
+	}
+
+	return &result, nil
+}
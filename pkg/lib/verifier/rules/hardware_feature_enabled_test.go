@@ -0,0 +1,59 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"testing"
+
+	constants "github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHardwareFeatureEnabledNoFault(t *testing.T) {
+	hostManifest := types.HostManifest{}
+	hostManifest.HostInfo.HardwareFeatures.TXT.Supported = true
+	hostManifest.HostInfo.HardwareFeatures.TXT.Enabled = true
+
+	rule, err := NewHardwareFeatureEnabled(model.HostInfoFeatureTXT, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.True(t, result.Trusted)
+	assert.Equal(t, 0, len(result.Faults))
+	assert.Equal(t, model.HostInfoFeatureTXT, result.Rule.Attribute)
+}
+
+func TestHardwareFeatureEnabledNotEnabledFault(t *testing.T) {
+	hostManifest := types.HostManifest{}
+	hostManifest.HostInfo.HardwareFeatures.UEFI.Supported = true
+	hostManifest.HostInfo.HardwareFeatures.UEFI.Enabled = true
+
+	rule, err := NewHardwareFeatureEnabled(model.HostInfoFeatureSecureBoot, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultHardwareFeatureNotEnabled, result.Faults[0].Name)
+}
+
+func TestHardwareFeatureEnabledNotSupportedFault(t *testing.T) {
+	hostManifest := types.HostManifest{}
+
+	rule, err := NewHardwareFeatureEnabled(model.HostInfoFeatureTPM, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultHardwareFeatureNotSupported, result.Faults[0].Name)
+
+	_, err = NewHardwareFeatureEnabled("SGX", common.FlavorPartPlatform)
+	assert.Error(t, err)
+}
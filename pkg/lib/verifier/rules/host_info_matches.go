@@ -0,0 +1,86 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"fmt"
+	"regexp"
+
+	constants "github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	flavormodel "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+// NewHostInfoMatches creates the rule verifying that a host info attribute is equal to a value, or matches a
+// regular expression
+func NewHostInfoMatches(match flavormodel.HostInfoMatch, marker common.FlavorPart) (Rule, error) {
+	if _, err := getHostInfoAttribute(&taModel.HostInfo{}, match.Attribute); err != nil {
+		return nil, errors.Wrap(err, "Could not create the host info matches rule")
+	}
+
+	if (match.Value == "") == (match.Pattern == "") {
+		return nil, errors.Errorf("Either the value or the pattern of host info %s must be provided", match.Attribute)
+	}
+
+	var pattern *regexp.Regexp
+	if match.Pattern != "" {
+		var err error
+		pattern, err = regexp.Compile(match.Pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid pattern of host info %s", match.Attribute)
+		}
+	}
+
+	return &hostInfoMatches{
+		match:   match,
+		pattern: pattern,
+		marker:  marker,
+	}, nil
+}
+
+type hostInfoMatches struct {
+	match   flavormodel.HostInfoMatch
+	pattern *regexp.Regexp
+	marker  common.FlavorPart
+}
+
+// - If the host info does not have the attribute, create a FaultHostInfoMissing.
+// - If the attribute is not equal to the value, or does not match the pattern, create a FaultHostInfoMismatch.
+func (rule *hostInfoMatches) Apply(hostManifest *types.HostManifest) (*hvs.RuleResult, error) {
+
+	result := hvs.RuleResult{}
+	result.Trusted = true
+	result.Rule.Name = constants.RuleHostInfoMatches
+	result.Rule.Markers = append(result.Rule.Markers, rule.marker)
+	result.Rule.Attribute = rule.match.Attribute
+
+	expected := rule.match.Value
+	if rule.pattern != nil {
+		expected = rule.match.Pattern
+	}
+	result.Rule.ExpectedValue = &expected
+
+	actual, err := getHostInfoAttribute(&hostManifest.HostInfo, rule.match.Attribute)
+	if err != nil {
+		return nil, err
+	}
+
+	if actual == "" {
+		result.Faults = append(result.Faults, newHostInfoMissingFault(rule.match.Attribute))
+	} else if (rule.pattern != nil && !rule.pattern.MatchString(actual)) || (rule.pattern == nil && actual != rule.match.Value) {
+		result.Faults = append(result.Faults, hvs.Fault{
+			Name:          constants.FaultHostInfoMismatch,
+			Description:   fmt.Sprintf("Host info %s '%s' does not match expected value '%s'", rule.match.Attribute, actual, expected),
+			ExpectedValue: &expected,
+			ActualValue:   &actual,
+		})
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"testing"
+
+	constants "github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostInfoMatchesNoFault(t *testing.T) {
+	hostManifest := types.HostManifest{}
+	hostManifest.HostInfo.BiosName = "Intel Corporation"
+	hostManifest.HostInfo.OSName = "RedHatEnterprise"
+
+	rule, err := NewHostInfoMatches(model.HostInfoMatch{Attribute: "bios_name", Value: "Intel Corporation"}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(result.Faults))
+
+	rule, err = NewHostInfoMatches(model.HostInfoMatch{Attribute: "os_name", Pattern: "^RedHat"}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+	result, err = rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(result.Faults))
+}
+
+func TestHostInfoMatchesMismatchFault(t *testing.T) {
+	hostManifest := types.HostManifest{}
+	hostManifest.HostInfo.OSName = "Ubuntu"
+
+	rule, err := NewHostInfoMatches(model.HostInfoMatch{Attribute: "os_name", Pattern: "^RedHat"}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultHostInfoMismatch, result.Faults[0].Name)
+	assert.Equal(t, "Ubuntu", *result.Faults[0].ActualValue)
+
+	rule, err = NewHostInfoMatches(model.HostInfoMatch{Attribute: "cbnt_profile", Value: "BTGP5"}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+	result, err = rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultHostInfoMissing, result.Faults[0].Name)
+}
+
+func TestNewHostInfoMatchesInvalid(t *testing.T) {
+	_, err := NewHostInfoMatches(model.HostInfoMatch{Attribute: "os_name"}, common.FlavorPartPlatform)
+	assert.Error(t, err)
+
+	_, err = NewHostInfoMatches(model.HostInfoMatch{Attribute: "os_name", Pattern: "[RedHat"}, common.FlavorPartPlatform)
+	assert.Error(t, err)
+
+	_, err = NewHostInfoMatches(model.HostInfoMatch{Attribute: "hostname", Value: "host1"}, common.FlavorPartPlatform)
+	assert.Error(t, err)
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	flavormodel "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/pkg/errors"
+)
+
+// NewHostInfoPolicyRules creates the rules verifying the hardware features, minimum versions and attribute matches of
+// a host info policy
+func NewHostInfoPolicyRules(policy *flavormodel.HostInfoPolicy, marker common.FlavorPart) ([]Rule, error) {
+	var policyRules []Rule
+	if policy == nil {
+		return policyRules, nil
+	}
+
+	features := make(map[string]bool)
+	for _, feature := range policy.FeaturesEnabled {
+		if features[feature] {
+			return nil, errors.Errorf("Duplicate hardware feature '%s' in host info policy", feature)
+		}
+		features[feature] = true
+
+		rule, err := NewHardwareFeatureEnabled(feature, marker)
+		if err != nil {
+			return nil, err
+		}
+		policyRules = append(policyRules, rule)
+	}
+
+	versions := make(map[string]bool)
+	for _, minimumVersion := range policy.MinimumVersions {
+		if versions[minimumVersion.Attribute] {
+			return nil, errors.Errorf("Duplicate minimum version of host info %s in host info policy", minimumVersion.Attribute)
+		}
+		versions[minimumVersion.Attribute] = true
+
+		rule, err := NewHostInfoVersionAtLeast(minimumVersion, marker)
+		if err != nil {
+			return nil, err
+		}
+		policyRules = append(policyRules, rule)
+	}
+
+	matches := make(map[string]bool)
+	for _, match := range policy.Matches {
+		if matches[match.Attribute] {
+			return nil, errors.Errorf("Duplicate match of host info %s in host info policy", match.Attribute)
+		}
+		matches[match.Attribute] = true
+
+		rule, err := NewHostInfoMatches(match, marker)
+		if err != nil {
+			return nil, err
+		}
+		policyRules = append(policyRules, rule)
+	}
+
+	return policyRules, nil
+}
+
+// ValidateHostInfoPolicy checks that the rules of a host info policy can be created
+func ValidateHostInfoPolicy(policy *flavormodel.HostInfoPolicy) error {
+	_, err := NewHostInfoPolicyRules(policy, common.FlavorPartPlatform)
+	return err
+}
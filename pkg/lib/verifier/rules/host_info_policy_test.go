@@ -0,0 +1,47 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"testing"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHostInfoPolicyRules(t *testing.T) {
+	policy := model.HostInfoPolicy{
+		FeaturesEnabled: []string{model.HostInfoFeatureTXT, model.HostInfoFeatureSecureBoot},
+		MinimumVersions: []model.HostInfoVersion{{Attribute: "tpm_version", Minimum: "2.0"}},
+		Matches:         []model.HostInfoMatch{{Attribute: "os_name", Pattern: "^RedHat"}},
+	}
+
+	policyRules, err := NewHostInfoPolicyRules(&policy, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(policyRules))
+
+	policyRules, err = NewHostInfoPolicyRules(nil, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(policyRules))
+}
+
+func TestValidateHostInfoPolicy(t *testing.T) {
+	assert.Error(t, ValidateHostInfoPolicy(&model.HostInfoPolicy{
+		FeaturesEnabled: []string{model.HostInfoFeatureTXT, model.HostInfoFeatureTXT},
+	}))
+	assert.Error(t, ValidateHostInfoPolicy(&model.HostInfoPolicy{
+		FeaturesEnabled: []string{"SGX"},
+	}))
+	assert.Error(t, ValidateHostInfoPolicy(&model.HostInfoPolicy{
+		MinimumVersions: []model.HostInfoVersion{{Attribute: "bios_version", Minimum: "1.0", Format: "unknown"}},
+	}))
+	assert.Error(t, ValidateHostInfoPolicy(&model.HostInfoPolicy{
+		Matches: []model.HostInfoMatch{{Attribute: "bios_name", Value: "Intel", Pattern: "^Intel"}},
+	}))
+	assert.NoError(t, ValidateHostInfoPolicy(&model.HostInfoPolicy{
+		MinimumVersions: []model.HostInfoVersion{{Attribute: "bios_version", Minimum: "SE5C620.86B.00.01.6016.032720190737", Format: model.VersionFormatIntelBios}},
+	}))
+}
@@ -0,0 +1,226 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+//
+// This file contains utility functions that support the rules verifying the host info
+// of the host manifest.
+//
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	flavormodel "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+// getHostInfoAttribute returns the value of a host info attribute, named after its json name
+func getHostInfoAttribute(hostInfo *taModel.HostInfo, attribute string) (string, error) {
+	switch attribute {
+	case "os_name":
+		return hostInfo.OSName, nil
+	case "os_version":
+		return hostInfo.OSVersion, nil
+	case "bios_name":
+		return hostInfo.BiosName, nil
+	case "bios_version":
+		return hostInfo.BiosVersion, nil
+	case "vmm_name":
+		return hostInfo.VMMName, nil
+	case "vmm_version":
+		return hostInfo.VMMVersion, nil
+	case "processor_info":
+		return hostInfo.ProcessorInfo, nil
+	case "tpm_version":
+		return hostInfo.HardwareFeatures.TPM.Meta.TPMVersion, nil
+	case "cbnt_profile":
+		return hostInfo.HardwareFeatures.CBNT.Meta.Profile, nil
+	case "bmc_firmware_version":
+		return hostInfo.HardwareFeatures.BMC.Meta.FirmwareVersion, nil
+	}
+	return "", errors.Errorf("Unknown host info attribute '%s'", attribute)
+}
+
+// getHardwareFeature returns whether a hardware feature of the host info is supported and enabled. The Secure Boot is
+// supported when UEFI is enabled.
+func getHardwareFeature(hostInfo *taModel.HostInfo, feature string) (bool, bool, error) {
+	features := hostInfo.HardwareFeatures
+	switch feature {
+	case flavormodel.HostInfoFeatureTXT:
+		return features.TXT.Supported, features.TXT.Enabled, nil
+	case flavormodel.HostInfoFeatureTPM:
+		return features.TPM.Supported, features.TPM.Enabled, nil
+	case flavormodel.HostInfoFeatureCBNT:
+		return features.CBNT.Supported, features.CBNT.Enabled, nil
+	case flavormodel.HostInfoFeatureUEFI:
+		return features.UEFI.Supported, features.UEFI.Enabled, nil
+	case flavormodel.HostInfoFeatureSecureBoot:
+		return features.UEFI.Enabled, features.UEFI.Meta.SecureBootEnabled, nil
+	case flavormodel.HostInfoFeaturePFR:
+		return features.PFR.Supported, features.PFR.Enabled, nil
+	case flavormodel.HostInfoFeatureBMC:
+		return features.BMC.Supported, features.BMC.Enabled, nil
+	}
+	return false, false, errors.Errorf("Unknown hardware feature '%s'", feature)
+}
+
+var (
+	dottedVersionRegex    = regexp.MustCompile(`\d+`)
+	semverRegex           = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:-([0-9A-Za-z.\-]+))?(?:\+[0-9A-Za-z.\-]+)?$`)
+	intelBiosVersionRegex = regexp.MustCompile(`^(\w+)\.(\w+)\.(\d+)\.(\d+)\.(\d+)(?:\.(\d+))?$`)
+)
+
+// compareVersions compares two versions of the given format, and returns -1, 0 or 1 when the version is lower than,
+// equal to or greater than the other version
+func compareVersions(version, other, format string) (int, error) {
+	switch format {
+	case "", flavormodel.VersionFormatDotted:
+		return compareDottedVersions(version, other)
+	case flavormodel.VersionFormatSemver:
+		return compareSemanticVersions(version, other)
+	case flavormodel.VersionFormatIntelBios:
+		return compareIntelBiosVersions(version, other)
+	}
+	return 0, errors.Errorf("Unknown version format '%s'", format)
+}
+
+// compareDottedVersions compares the numbers of the versions one by one, the missing numbers are zeros
+func compareDottedVersions(version, other string) (int, error) {
+	numbers, err := parseVersionNumbers(dottedVersionRegex.FindAllString(version, -1))
+	if err != nil || len(numbers) == 0 {
+		return 0, errors.Errorf("Invalid version '%s'", version)
+	}
+	otherNumbers, err := parseVersionNumbers(dottedVersionRegex.FindAllString(other, -1))
+	if err != nil || len(otherNumbers) == 0 {
+		return 0, errors.Errorf("Invalid version '%s'", other)
+	}
+	return compareVersionNumbers(numbers, otherNumbers), nil
+}
+
+// compareSemanticVersions compares the major, minor and patch numbers of the versions, then their pre-release.
+// A pre-release is lower than its release, the build metadata is ignored.
+func compareSemanticVersions(version, other string) (int, error) {
+	parts := semverRegex.FindStringSubmatch(strings.TrimSpace(version))
+	if parts == nil {
+		return 0, errors.Errorf("Invalid semantic version '%s'", version)
+	}
+	otherParts := semverRegex.FindStringSubmatch(strings.TrimSpace(other))
+	if otherParts == nil {
+		return 0, errors.Errorf("Invalid semantic version '%s'", other)
+	}
+
+	numbers, _ := parseVersionNumbers(parts[1:4])
+	otherNumbers, _ := parseVersionNumbers(otherParts[1:4])
+	if result := compareVersionNumbers(numbers, otherNumbers); result != 0 {
+		return result, nil
+	}
+
+	preRelease, otherPreRelease := parts[4], otherParts[4]
+	switch {
+	case preRelease == otherPreRelease:
+		return 0, nil
+	case preRelease == "":
+		return 1, nil
+	case otherPreRelease == "":
+		return -1, nil
+	}
+	return comparePreReleases(strings.Split(preRelease, "."), strings.Split(otherPreRelease, ".")), nil
+}
+
+// comparePreReleases compares the dot separated identifiers of the pre-releases one by one. Numeric identifiers are
+// compared numerically and are lower than alphanumeric identifiers, that are compared in ASCII order. When all the
+// identifiers of the shorter pre-release are equal, the longer pre-release is greater, e.g.
+// "alpha" < "alpha.1" < "alpha.beta" < "beta.2" < "beta.11" < "rc.1"
+func comparePreReleases(identifiers, otherIdentifiers []string) int {
+	for i := 0; i < len(identifiers) && i < len(otherIdentifiers); i++ {
+		identifier, otherIdentifier := identifiers[i], otherIdentifiers[i]
+		number, err := strconv.ParseUint(identifier, 10, 64)
+		isNumeric := err == nil
+		otherNumber, err := strconv.ParseUint(otherIdentifier, 10, 64)
+		isOtherNumeric := err == nil
+
+		switch {
+		case isNumeric && isOtherNumeric:
+			if number < otherNumber {
+				return -1
+			} else if number > otherNumber {
+				return 1
+			}
+		case isNumeric:
+			return -1
+		case isOtherNumeric:
+			return 1
+		case identifier < otherIdentifier:
+			return -1
+		case identifier > otherIdentifier:
+			return 1
+		}
+	}
+
+	if len(identifiers) < len(otherIdentifiers) {
+		return -1
+	} else if len(identifiers) > len(otherIdentifiers) {
+		return 1
+	}
+	return 0
+}
+
+// compareIntelBiosVersions compares the major, minor and build numbers of Intel BIOS versions
+// <board>.<vendor>.<major>.<minor>.<build>.<release date>, that must be versions of the same board
+func compareIntelBiosVersions(version, other string) (int, error) {
+	parts := intelBiosVersionRegex.FindStringSubmatch(strings.TrimSpace(version))
+	if parts == nil {
+		return 0, errors.Errorf("Invalid Intel BIOS version '%s'", version)
+	}
+	otherParts := intelBiosVersionRegex.FindStringSubmatch(strings.TrimSpace(other))
+	if otherParts == nil {
+		return 0, errors.Errorf("Invalid Intel BIOS version '%s'", other)
+	}
+
+	if parts[1] != otherParts[1] || parts[2] != otherParts[2] {
+		return 0, errors.Errorf("The BIOS versions '%s' and '%s' are not versions of the same board", version, other)
+	}
+
+	numbers, _ := parseVersionNumbers(parts[3:6])
+	otherNumbers, _ := parseVersionNumbers(otherParts[3:6])
+	return compareVersionNumbers(numbers, otherNumbers), nil
+}
+
+func parseVersionNumbers(parts []string) ([]uint64, error) {
+	var numbers []uint64
+	for _, part := range parts {
+		if part == "" {
+			numbers = append(numbers, 0)
+			continue
+		}
+		number, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, nil
+}
+
+func compareVersionNumbers(numbers, otherNumbers []uint64) int {
+	for i := 0; i < len(numbers) || i < len(otherNumbers); i++ {
+		var number, otherNumber uint64
+		if i < len(numbers) {
+			number = numbers[i]
+		}
+		if i < len(otherNumbers) {
+			otherNumber = otherNumbers[i]
+		}
+		if number < otherNumber {
+			return -1
+		} else if number > otherNumber {
+			return 1
+		}
+	}
+	return 0
+}
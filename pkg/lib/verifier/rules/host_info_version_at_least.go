@@ -0,0 +1,82 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"fmt"
+
+	constants "github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	flavormodel "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+// NewHostInfoVersionAtLeast creates the rule verifying that a version of the host info is at or above a minimum
+// version
+func NewHostInfoVersionAtLeast(minimumVersion flavormodel.HostInfoVersion, marker common.FlavorPart) (Rule, error) {
+	if _, err := getHostInfoAttribute(&taModel.HostInfo{}, minimumVersion.Attribute); err != nil {
+		return nil, errors.Wrap(err, "Could not create the host info version rule")
+	}
+
+	if _, err := compareVersions(minimumVersion.Minimum, minimumVersion.Minimum, minimumVersion.Format); err != nil {
+		return nil, errors.Wrapf(err, "Could not create the host info version rule for %s", minimumVersion.Attribute)
+	}
+
+	return &hostInfoVersionAtLeast{
+		minimumVersion: minimumVersion,
+		marker:         marker,
+	}, nil
+}
+
+type hostInfoVersionAtLeast struct {
+	minimumVersion flavormodel.HostInfoVersion
+	marker         common.FlavorPart
+}
+
+// - If the host info does not have the version, create a FaultHostInfoMissing.
+// - If the version of the host info cannot be compared, create a FaultHostInfoVersionInvalid.
+// - If the version of the host info is lower than the minimum version, create a FaultHostInfoVersionBelowMinimum.
+func (rule *hostInfoVersionAtLeast) Apply(hostManifest *types.HostManifest) (*hvs.RuleResult, error) {
+
+	result := hvs.RuleResult{}
+	result.Trusted = true
+	result.Rule.Name = constants.RuleHostInfoVersionAtLeast
+	result.Rule.Markers = append(result.Rule.Markers, rule.marker)
+	result.Rule.Attribute = rule.minimumVersion.Attribute
+	result.Rule.ExpectedValue = &rule.minimumVersion.Minimum
+
+	version, err := getHostInfoAttribute(&hostManifest.HostInfo, rule.minimumVersion.Attribute)
+	if err != nil {
+		return nil, err
+	}
+
+	if version == "" {
+		result.Faults = append(result.Faults, newHostInfoMissingFault(rule.minimumVersion.Attribute))
+		return &result, nil
+	}
+
+	comparison, err := compareVersions(version, rule.minimumVersion.Minimum, rule.minimumVersion.Format)
+	if err != nil {
+		log.WithError(err).Debugf("Could not compare host info %s", rule.minimumVersion.Attribute)
+		result.Faults = append(result.Faults, hvs.Fault{
+			Name:          constants.FaultHostInfoVersionInvalid,
+			Description:   fmt.Sprintf("Host info %s '%s' could not be compared to minimum version '%s'", rule.minimumVersion.Attribute, version, rule.minimumVersion.Minimum),
+			ExpectedValue: &rule.minimumVersion.Minimum,
+			ActualValue:   &version,
+		})
+	} else if comparison < 0 {
+		result.Faults = append(result.Faults, hvs.Fault{
+			Name:          constants.FaultHostInfoVersionBelowMinimum,
+			Description:   fmt.Sprintf("Host info %s '%s' is below minimum version '%s'", rule.minimumVersion.Attribute, version, rule.minimumVersion.Minimum),
+			ExpectedValue: &rule.minimumVersion.Minimum,
+			ActualValue:   &version,
+		})
+	}
+
+	return &result, nil
+}
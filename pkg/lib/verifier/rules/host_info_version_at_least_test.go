@@ -0,0 +1,103 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"testing"
+
+	constants "github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostInfoVersionAtLeastNoFault(t *testing.T) {
+	hostManifest := types.HostManifest{}
+	hostManifest.HostInfo.BiosVersion = "SE5C620.86B.00.01.6016.032720190737"
+	hostManifest.HostInfo.VMMVersion = "19.03.13"
+
+	rule, err := NewHostInfoVersionAtLeast(model.HostInfoVersion{Attribute: "bios_version", Minimum: "SE5C620.86B.00.01.0015.110720180833", Format: model.VersionFormatIntelBios}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(result.Faults))
+
+	rule, err = NewHostInfoVersionAtLeast(model.HostInfoVersion{Attribute: "vmm_version", Minimum: "19.3"}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+	result, err = rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(result.Faults))
+}
+
+func TestHostInfoVersionAtLeastBelowMinimumFault(t *testing.T) {
+	hostManifest := types.HostManifest{}
+	hostManifest.HostInfo.VMMVersion = "v2.1.0-rc1"
+
+	rule, err := NewHostInfoVersionAtLeast(model.HostInfoVersion{Attribute: "vmm_version", Minimum: "2.1.0", Format: model.VersionFormatSemver}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultHostInfoVersionBelowMinimum, result.Faults[0].Name)
+	assert.Equal(t, "v2.1.0-rc1", *result.Faults[0].ActualValue)
+}
+
+func TestHostInfoVersionAtLeastInvalidAndMissingFaults(t *testing.T) {
+	hostManifest := types.HostManifest{}
+	hostManifest.HostInfo.BiosVersion = "S2600WF.0.00.01.0015.110720180833"
+
+	rule, err := NewHostInfoVersionAtLeast(model.HostInfoVersion{Attribute: "bios_version", Minimum: "SE5C620.86B.00.01.0015.110720180833", Format: model.VersionFormatIntelBios}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultHostInfoVersionInvalid, result.Faults[0].Name)
+
+	rule, err = NewHostInfoVersionAtLeast(model.HostInfoVersion{Attribute: "os_version", Minimum: "8.2"}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+	result, err = rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultHostInfoMissing, result.Faults[0].Name)
+
+	_, err = NewHostInfoVersionAtLeast(model.HostInfoVersion{Attribute: "os_version", Minimum: "latest"}, common.FlavorPartPlatform)
+	assert.Error(t, err)
+}
+
+func TestHostInfoVersionAtLeastBmcFirmwareVersion(t *testing.T) {
+	hostManifest := types.HostManifest{}
+	hostManifest.HostInfo.HardwareFeatures.BMC.Meta.FirmwareVersion = "1.2.0-beta.11"
+
+	rule, err := NewHostInfoVersionAtLeast(model.HostInfoVersion{Attribute: "bmc_firmware_version", Minimum: "1.2.0-beta.2", Format: model.VersionFormatSemver}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(result.Faults))
+
+	rule, err = NewHostInfoVersionAtLeast(model.HostInfoVersion{Attribute: "bmc_firmware_version", Minimum: "1.2.0-rc.1", Format: model.VersionFormatSemver}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+	result, err = rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultHostInfoVersionBelowMinimum, result.Faults[0].Name)
+}
+
+func TestCompareSemanticVersionsPreRelease(t *testing.T) {
+	ordered := []string{"1.0.0-1", "1.0.0-2", "1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.2", "1.0.0-alpha.10",
+		"1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-rc.1", "1.0.0"}
+	for i := 0; i < len(ordered)-1; i++ {
+		result, err := compareSemanticVersions(ordered[i], ordered[i+1])
+		assert.NoError(t, err)
+		assert.Equal(t, -1, result, "%s < %s", ordered[i], ordered[i+1])
+		result, err = compareSemanticVersions(ordered[i+1], ordered[i])
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result, "%s > %s", ordered[i+1], ordered[i])
+	}
+
+	result, err := compareSemanticVersions("v1.0.0-alpha.1+build.5", "1.0.0-alpha.1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result)
+}
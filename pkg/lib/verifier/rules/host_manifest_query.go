@@ -0,0 +1,102 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/antchfx/jsonquery"
+	constants "github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// NewHostManifestQuery creates the rule evaluating a "jsonquery" custom rule, that is satisfied when its query
+// selects at least one node of the host manifest
+func NewHostManifestQuery(customRule model.CustomRule, marker common.FlavorPart) (Rule, error) {
+	if strings.TrimSpace(customRule.Query) == "" {
+		return nil, errors.New("The query of the custom rule must be provided")
+	}
+
+	// check the syntax of the query
+	emptyDoc, err := jsonquery.Parse(strings.NewReader("{}"))
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to parse json query")
+	}
+	if _, err = jsonquery.Query(emptyDoc, customRule.Query); err != nil {
+		return nil, errors.Wrapf(err, "Invalid syntax in query '%s'", customRule.Query)
+	}
+
+	return &hostManifestQuery{
+		customRule: customRule,
+		marker:     marker,
+	}, nil
+}
+
+type hostManifestQuery struct {
+	customRule model.CustomRule
+	marker     common.FlavorPart
+}
+
+// - If the host manifest cannot be queried, create a FaultCustomRuleEvaluationFailed.
+// - If the query does not select any node of the host manifest, create a FaultCustomRuleNotSatisfied.
+func (rule *hostManifestQuery) Apply(hostManifest *types.HostManifest) (*hvs.RuleResult, error) {
+
+	result := hvs.RuleResult{}
+	result.Trusted = true // default to true, set to false in fault logic
+	result.Rule.Name = constants.RuleCustomPrefix + rule.customRule.Name
+	result.Rule.Markers = append(result.Rule.Markers, rule.marker)
+	result.Rule.ExpectedValue = &rule.customRule.Query
+
+	var node *jsonquery.Node
+	hostManifestDoc, err := parseHostManifest(hostManifest)
+	if err == nil {
+		node, err = jsonquery.Query(hostManifestDoc, rule.customRule.Query)
+	}
+	if err != nil {
+		log.WithError(err).Errorf("CustomRuleEvaluationFailed fault: Could not evaluate custom rule '%s'", rule.customRule.Name)
+		result.Faults = append(result.Faults, hvs.Fault{
+			Name:        constants.FaultCustomRuleEvaluationFailed,
+			Description: fmt.Sprintf("Custom rule '%s' could not be evaluated against the host manifest", rule.customRule.Name),
+		})
+		result.Trusted = false
+	} else if node == nil {
+		description := fmt.Sprintf("Host manifest does not satisfy custom rule '%s'", rule.customRule.Name)
+		if rule.customRule.Description != "" {
+			description = description + ": " + rule.customRule.Description
+		}
+		result.Faults = append(result.Faults, hvs.Fault{
+			Name:        constants.FaultCustomRuleNotSatisfied,
+			Description: description,
+		})
+		result.Trusted = false
+	}
+
+	return &result, nil
+}
+
+// parseHostManifest parses the JSON document of the host manifest that the queries are evaluated against
+func parseHostManifest(hostManifest *types.HostManifest) (*jsonquery.Node, error) {
+	if hostManifest == nil {
+		return nil, errors.New("The host manifest cannot be nil")
+	}
+
+	hostManifestBytes, err := json.Marshal(hostManifest)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error marshalling the host manifest")
+	}
+
+	hostManifestDoc, err := jsonquery.Parse(bytes.NewReader(hostManifestBytes))
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing the host manifest")
+	}
+	return hostManifestDoc, nil
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"testing"
+
+	constants "github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCustomRuleHostManifest(secureBootEnabled bool, biosVersion string) *types.HostManifest {
+	hostManifest := types.HostManifest{}
+	hostManifest.HostInfo.BiosVersion = biosVersion
+	hostManifest.HostInfo.HardwareFeatures.UEFI.Meta.SecureBootEnabled = secureBootEnabled
+	return &hostManifest
+}
+
+func TestHostManifestQueryNoFault(t *testing.T) {
+	rule, err := NewHostManifestQuery(model.CustomRule{
+		Name:  "SecureBootEnabled",
+		Query: "//host_info/hardware_features/UEFI/meta[secure_boot_enabled='true']",
+	}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(newCustomRuleHostManifest(true, "2.1"))
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 0, len(result.Faults))
+	assert.True(t, result.Trusted)
+	assert.Equal(t, constants.RuleCustomPrefix+"SecureBootEnabled", result.Rule.Name)
+	assert.Equal(t, []common.FlavorPart{common.FlavorPartPlatform}, result.Rule.Markers)
+}
+
+func TestHostManifestQueryNotSatisfiedFault(t *testing.T) {
+	rule, err := NewHostManifestQuery(model.CustomRule{
+		Name:        "MinimumBiosVersion",
+		Description: "BIOS version must be at least 2.0",
+		Query:       "//host_info[bios_version >= 2.0]",
+	}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(newCustomRuleHostManifest(true, "1.5"))
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultCustomRuleNotSatisfied, result.Faults[0].Name)
+	assert.False(t, result.Trusted)
+	t.Logf("Fault description: %s", result.Faults[0].Description)
+
+	result, err = rule.Apply(newCustomRuleHostManifest(true, "2.1"))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(result.Faults))
+	assert.True(t, result.Trusted)
+}
+
+func TestHostManifestQueryEvaluationFailedFault(t *testing.T) {
+	rule, err := NewHostManifestQuery(model.CustomRule{
+		Name:  "SecureBootEnabled",
+		Query: "//host_info/hardware_features/UEFI/meta[secure_boot_enabled='true']",
+	}, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultCustomRuleEvaluationFailed, result.Faults[0].Name)
+	assert.False(t, result.Trusted)
+}
+
+func TestHostManifestQueryInvalidQuery(t *testing.T) {
+	_, err := NewHostManifestQuery(model.CustomRule{Name: "Invalid", Query: "//host_info[bios_version"}, common.FlavorPartPlatform)
+	assert.Error(t, err)
+
+	_, err = NewHostManifestQuery(model.CustomRule{Name: "Empty"}, common.FlavorPartPlatform)
+	assert.Error(t, err)
+}
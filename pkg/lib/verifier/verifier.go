@@ -11,8 +11,11 @@ package verifier
 
 import (
 	"crypto/x509"
+	"time"
+
 	commLog "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/verifier/rules"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"github.com/pkg/errors"
 )
@@ -24,6 +27,10 @@ type VerifierCertificates struct {
 	AssetTagCACertificates   *x509.CertPool
 	FlavorSigningCertificate *x509.Certificate
 	FlavorCACertificates     *x509.CertPool
+	// AikCertificateStatus is optional, when set the AIK certificates revoked by the privacy CA are not trusted
+	AikCertificateStatus rules.AikCertificateStatus
+	// AikCertificateExpiryWarning is optional, when set the AIK certificates expiring within the duration are faulted
+	AikCertificateExpiryWarning time.Duration
 }
 
 // Verifier The interface that exposes the verification of a host manifest
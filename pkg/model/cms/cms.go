@@ -0,0 +1,25 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package cms
+
+import "time"
+
+// IssuedCertificate is the inventory record kept by CMS for every certificate it issues
+type IssuedCertificate struct {
+	SerialNumber     string     `json:"serial_number"`
+	Subject          string     `json:"subject"`
+	CertType         string     `json:"cert_type"`
+	IssuingCa        string     `json:"issuing_ca"`
+	NotBefore        time.Time  `json:"not_before"`
+	NotAfter         time.Time  `json:"not_after"`
+	Revoked          bool       `json:"revoked"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	RevocationReason string     `json:"revocation_reason,omitempty"`
+}
+
+type RevokeCertificateRequest struct {
+	// Reason: keyCompromise
+	Reason string `json:"reason,omitempty"`
+}
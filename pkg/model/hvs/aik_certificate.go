@@ -0,0 +1,67 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AikCertificate is the inventory record of an AIK certificate issued by the privacy CA
+type AikCertificate struct {
+	// swagger:strfmt uuid
+	ID uuid.UUID `json:"id"`
+	// HardwareUUID is the hardware UUID of the host, known once the EK is registered or the host is verified
+	// swagger:strfmt uuid
+	HardwareUUID *uuid.UUID `json:"hardware_uuid,omitempty"`
+	// SerialNumber is the hex encoded serial number of the AIK certificate
+	SerialNumber string `json:"serial_number"`
+	// EkCertificateDigest is the hex encoded SHA384 digest of the EK certificate the AIK was certified with
+	EkCertificateDigest string `json:"ek_certificate_digest"`
+	// swagger:strfmt base64
+	Certificate      []byte              `json:"certificate"`
+	Issued           time.Time           `json:"issued"`
+	Expiry           time.Time           `json:"expiry"`
+	Revoked          bool                `json:"revoked"`
+	RevocationTime   *time.Time          `json:"revocation_time,omitempty"`
+	RevocationReason AikRevocationReason `json:"revocation_reason,omitempty"`
+}
+
+type AikCertificateCollection struct {
+	AikCertificates []AikCertificate `json:"aik_certificates"`
+}
+
+// AikCertificateRevocation is the request to revoke an AIK certificate
+type AikCertificateRevocation struct {
+	Reason AikRevocationReason `json:"reason"`
+}
+
+// AikRevocationReason is the reason an AIK certificate was revoked, as listed in the CRL of the privacy CA
+type AikRevocationReason string
+
+const (
+	AikRevocationReasonUnspecified          AikRevocationReason = "unspecified"
+	AikRevocationReasonKeyCompromise        AikRevocationReason = "key_compromise"
+	AikRevocationReasonAffiliationChanged   AikRevocationReason = "affiliation_changed"
+	AikRevocationReasonSuperseded           AikRevocationReason = "superseded"
+	AikRevocationReasonCessationOfOperation AikRevocationReason = "cessation_of_operation"
+)
+
+// crlReasonCodes maps the revocation reasons to the CRLReason codes of RFC 5280
+var crlReasonCodes = map[AikRevocationReason]int{
+	AikRevocationReasonUnspecified:          0,
+	AikRevocationReasonKeyCompromise:        1,
+	AikRevocationReasonAffiliationChanged:   3,
+	AikRevocationReasonSuperseded:           4,
+	AikRevocationReasonCessationOfOperation: 5,
+}
+
+// CrlReasonCode returns the CRLReason code of the revocation reason, and false if the reason is not supported
+func (reason AikRevocationReason) CrlReasonCode() (int, bool) {
+	code, ok := crlReasonCodes[reason]
+	return code, ok
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// ComplianceReport summarizes the trust status of the hosts matching a host selector, from their latest report in a
+// time window
+type ComplianceReport struct {
+	Created  time.Time  `json:"created"`
+	FromDate *time.Time `json:"from_date,omitempty"`
+	ToDate   *time.Time `json:"to_date,omitempty"`
+	// HostSelector describes the query parameters the hosts are selected with, e.g. "labelSelector=env=prod"
+	HostSelector           string                 `json:"host_selector,omitempty"`
+	HostCount              int                    `json:"host_count"`
+	TrustedHostCount       int                    `json:"trusted_host_count"`
+	UntrustedHostCount     int                    `json:"untrusted_host_count"`
+	NeverAttestedHostCount int                    `json:"never_attested_host_count"`
+	FlavorParts            []FlavorPartCompliance `json:"flavor_parts"`
+	TopFailingRules        []FailingRule          `json:"top_failing_rules"`
+	UntrustedHosts         []ComplianceHost       `json:"untrusted_hosts"`
+	// HostsNeverAttested are the hosts without report in the time window, or without report at all when no time
+	// window is given
+	HostsNeverAttested []ComplianceHost `json:"hosts_never_attested"`
+}
+
+// FlavorPartCompliance counts the hosts trusted and untrusted for a flavor part
+type FlavorPartCompliance struct {
+	FlavorPart     string `json:"flavor_part"`
+	TrustedCount   int    `json:"trusted_count"`
+	UntrustedCount int    `json:"untrusted_count"`
+}
+
+// FailingRule counts the hosts a rule failed for, along with the faults the rule reported
+type FailingRule struct {
+	RuleName   string   `json:"rule_name"`
+	FlavorPart string   `json:"flavor_part,omitempty"`
+	HostCount  int      `json:"host_count"`
+	FaultNames []string `json:"fault_names,omitempty"`
+}
+
+// ComplianceHost is a host listed in a compliance report, with its latest report in the time window if any
+type ComplianceHost struct {
+	// swagger:strfmt uuid
+	HostID   uuid.UUID `json:"host_id"`
+	HostName string    `json:"host_name"`
+	// swagger:strfmt uuid
+	ReportID     *uuid.UUID `json:"report_id,omitempty"`
+	LastAttested *time.Time `json:"last_attested,omitempty"`
+}
+
+// SignedComplianceReport combines the ComplianceReport along with its signature, made with the SAML signing key
+type SignedComplianceReport struct {
+	Report    ComplianceReport `json:"report"`
+	Signature string           `json:"signature"`
+	// SigningCertificate is the PEM encoded certificate of the key the report is signed with
+	SigningCertificate string `json:"signing_certificate"`
+}
+
+// NewSignedComplianceReport Provided an existing compliance report, a private key and its certificate, create a
+// SignedComplianceReport
+func NewSignedComplianceReport(report *ComplianceReport, privateKey *rsa.PrivateKey, cert *x509.Certificate) (*SignedComplianceReport, error) {
+	if report == nil {
+		return nil, errors.New("The compliance report must be provided and cannot be nil")
+	}
+	if cert == nil {
+		return nil, errors.New("The signing certificate must be provided and cannot be nil")
+	}
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred attempting to convert the compliance report to json")
+	}
+
+	signature, err := SignComplianceDocument(reportJSON, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedComplianceReport{
+		Report:             *report,
+		Signature:          signature,
+		SigningCertificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})),
+	}, nil
+}
+
+// Verify checks that the compliance report is signed with the key of the given certificate
+func (signedReport *SignedComplianceReport) Verify(cert *x509.Certificate) error {
+	reportJSON, err := json.Marshal(signedReport.Report)
+	if err != nil {
+		return errors.Wrap(err, "Could not verify the compliance report: An error occurred attempting to convert the report to json")
+	}
+	return VerifyComplianceDocument(reportJSON, signedReport.Signature, cert)
+}
+
+// SignComplianceDocument returns the base64 encoded RSA PKCS1v15 signature of the SHA384 hash of a compliance
+// report document
+func SignComplianceDocument(document []byte, privateKey *rsa.PrivateKey) (string, error) {
+	if privateKey == nil || privateKey.Validate() != nil {
+		return "", errors.New("Valid private key must be provided and cannot be nil")
+	}
+
+	digest := sha512.Sum384(document)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA384, digest[:])
+	if err != nil {
+		return "", errors.Wrap(err, "An error occurred while signing the compliance report")
+	}
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// VerifyComplianceDocument checks the base64 encoded signature of a compliance report document against the public
+// key of the given certificate
+func VerifyComplianceDocument(document []byte, signature string, cert *x509.Certificate) error {
+	publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("Could not verify the compliance report: The signing certificate does not have a RSA public key")
+	}
+
+	signatureBytes, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return errors.Wrap(err, "Could not verify the compliance report: An error occurred attempting to decode the signature")
+	}
+
+	digest := sha512.Sum384(document)
+	if err = rsa.VerifyPKCS1v15(publicKey, crypto.SHA384, digest[:], signatureBytes); err != nil {
+		return errors.Wrap(err, "Could not verify the compliance report: PKCS1 verification failed")
+	}
+	return nil
+}
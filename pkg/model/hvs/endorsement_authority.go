@@ -0,0 +1,68 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EndorsementAuthority is a bundle of the root and intermediate CA certificates of a TPM manufacturer, along with the
+// CRLs published by the manufacturer, that EK certificates are verified against during AIK certification
+type EndorsementAuthority struct {
+	// swagger:strfmt uuid
+	ID           uuid.UUID `json:"id,omitempty"`
+	Name         string    `json:"name"`
+	Manufacturer string    `json:"manufacturer,omitempty"`
+	// Certificates is the PEM encoded bundle of root and intermediate CA certificates
+	Certificates string `json:"certificates"`
+	// Crls is the PEM encoded list of CRLs issued by the CA certificates of the bundle
+	Crls     string    `json:"crls,omitempty"`
+	Disabled bool      `json:"disabled"`
+	Created  time.Time `json:"created,omitempty"`
+	Updated  time.Time `json:"updated,omitempty"`
+}
+
+// EndorsementAuthorityUpdateRequest holds the attributes of an endorsement authority to update. The attributes that are
+// not provided are kept unchanged.
+type EndorsementAuthorityUpdateRequest struct {
+	Name         string `json:"name,omitempty"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+	Certificates string `json:"certificates,omitempty"`
+	// Crls replaces the CRLs of the endorsement authority, an empty string removes them
+	Crls     *string `json:"crls,omitempty"`
+	Disabled *bool   `json:"disabled,omitempty"`
+}
+
+type EndorsementAuthorityCollection struct {
+	EndorsementAuthorities []EndorsementAuthority `json:"endorsement_authorities"`
+}
+
+// EndorsementSource identifies how the EK certificate of a TPM was established as trusted
+type EndorsementSource string
+
+const (
+	// EndorsementSourceAuthority is used when the EK certificate chains up to an endorsement authority
+	EndorsementSourceAuthority EndorsementSource = "ENDORSEMENT_AUTHORITY"
+	// EndorsementSourceEndorsementCa is used when the EK certificate is signed by a certificate of the endorsement CA
+	// directory
+	EndorsementSourceEndorsementCa EndorsementSource = "ENDORSEMENT_CA"
+	// EndorsementSourceRegistered is used when the EK certificate is only trusted because it is registered as a
+	// TpmEndorsement
+	EndorsementSourceRegistered EndorsementSource = "REGISTERED"
+)
+
+// EndorsementProvenance records the trust anchor of the EK certificate of a TPM the last time it was verified
+type EndorsementProvenance struct {
+	Source EndorsementSource `json:"source"`
+	// swagger:strfmt uuid
+	AuthorityID   uuid.UUID `json:"authority_id,omitempty"`
+	AuthorityName string    `json:"authority_name,omitempty"`
+	// Chain lists the subjects of the CA certificates from the issuer of the EK certificate up to the trust anchor
+	Chain    []string  `json:"chain,omitempty"`
+	Verified time.Time `json:"verified"`
+}
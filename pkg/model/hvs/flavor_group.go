@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"github.com/google/uuid"
 	cf "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
 )
 
 type FlavorgroupCollection struct {
@@ -29,6 +30,10 @@ type FlavorGroup struct {
 	FlavorIds     []uuid.UUID         `json:"flavorIds,omitempty"`
 	Flavors       []Flavor            `json:"flavors,omitempty"`
 	MatchPolicies FlavorMatchPolicies `json:"flavor_match_policies,omitempty"`
+	// HostLabelSelector links the flavorgroup to the hosts registered with labels matching the selector
+	HostLabelSelector string `json:"host_label_selector,omitempty"`
+	// CustomRules are site specific rules evaluated against the host manifest of the hosts linked to the flavorgroup
+	CustomRules []model.CustomRule `json:"custom_rules,omitempty"`
 }
 
 type FlavorMatchPolicy struct {
@@ -85,12 +90,16 @@ func (r FlavorGroup) MarshalJSON() ([]byte, error) {
 		FlavorIds                   []uuid.UUID                 `json:"flavorIds,omitempty"`
 		Flavors                     []Flavor                    `json:"flavors,omitempty"`
 		FlavorMatchPolicyCollection FlavorMatchPolicyCollection `json:"flavor_match_policy_collection,omitempty"`
+		HostLabelSelector           string                      `json:"host_label_selector,omitempty"`
+		CustomRules                 []model.CustomRule          `json:"custom_rules,omitempty"`
 	}{
 		ID:                          r.ID,
 		Name:                        r.Name,
 		FlavorIds:                   r.FlavorIds,
 		Flavors:                     r.Flavors,
 		FlavorMatchPolicyCollection: FlavorMatchPolicyCollection{r.MatchPolicies},
+		HostLabelSelector:           r.HostLabelSelector,
+		CustomRules:                 r.CustomRules,
 	})
 }
 
@@ -101,6 +110,8 @@ func (r *FlavorGroup) UnmarshalJSON(b []byte) error {
 		FlavorIds                   []uuid.UUID                 `json:"flavorIds,omitempty"`
 		Flavors                     []Flavor                    `json:"flavors,omitempty"`
 		FlavorMatchPolicyCollection FlavorMatchPolicyCollection `json:"flavor_match_policy_collection,omitempty"`
+		HostLabelSelector           string                      `json:"host_label_selector,omitempty"`
+		CustomRules                 []model.CustomRule          `json:"custom_rules,omitempty"`
 	})
 	err := json.Unmarshal(b, decoded)
 	if err == nil {
@@ -109,6 +120,8 @@ func (r *FlavorGroup) UnmarshalJSON(b []byte) error {
 		r.FlavorIds = decoded.FlavorIds
 		r.Flavors = decoded.Flavors
 		r.MatchPolicies = decoded.FlavorMatchPolicyCollection.FlavorMatchPolicies
+		r.HostLabelSelector = decoded.HostLabelSelector
+		r.CustomRules = decoded.CustomRules
 	}
 	return err
 }
@@ -0,0 +1,48 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"github.com/google/uuid"
+)
+
+// FlavorImpactOperation is a flavor change whose impact on the trust of the hosts can be evaluated
+type FlavorImpactOperation string
+
+const (
+	// FlavorImpactLink links a flavor to a flavorgroup
+	FlavorImpactLink FlavorImpactOperation = "link"
+	// FlavorImpactUnlink removes a flavor from a flavorgroup
+	FlavorImpactUnlink FlavorImpactOperation = "unlink"
+	// FlavorImpactDelete deletes a flavor
+	FlavorImpactDelete FlavorImpactOperation = "delete"
+)
+
+// FlavorImpactRequest describes a flavor change to evaluate without applying it
+type FlavorImpactRequest struct {
+	Operation FlavorImpactOperation `json:"operation"`
+	// FlavorID is the flavor to link, unlink or delete
+	// swagger:strfmt uuid
+	FlavorID uuid.UUID `json:"flavor_id,omitempty"`
+	// FlavorgroupID is the flavorgroup the flavor is linked to or unlinked from
+	// swagger:strfmt uuid
+	FlavorgroupID uuid.UUID `json:"flavorgroup_id,omitempty"`
+	// Flavor can be set instead of FlavorID to evaluate the link of a flavor that has not been created yet
+	Flavor *Flavor `json:"flavor,omitempty"`
+}
+
+// FlavorImpact lists the hosts that would be re-verified after a flavor change. The other hosts of the flavorgroups
+// keep their trust status, since the flavor does not match their manifest and is not in their trust cache.
+type FlavorImpact struct {
+	Operation FlavorImpactOperation `json:"operation"`
+	// swagger:strfmt uuid
+	FlavorID       uuid.UUID   `json:"flavor_id"`
+	FlavorgroupIDs []uuid.UUID `json:"flavorgroup_ids"`
+	// LinkedHostCount is the number of hosts of the flavorgroups
+	LinkedHostCount   int         `json:"linked_host_count"`
+	ImpactedHostCount int         `json:"impacted_host_count"`
+	ImpactedHosts     []uuid.UUID `json:"impacted_hosts"`
+}
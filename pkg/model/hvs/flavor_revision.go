@@ -0,0 +1,41 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FlavorRevision is an immutable snapshot of a signed flavor. A new revision is recorded every time
+// a flavor is created, updated or rolled back
+type FlavorRevision struct {
+	// swagger:strfmt uuid
+	FlavorID     uuid.UUID    `json:"flavor_id"`
+	Revision     int          `json:"revision"`
+	SignedFlavor SignedFlavor `json:"signed_flavor"`
+	CreatedBy    string       `json:"created_by,omitempty"`
+	Comment      string       `json:"comment,omitempty"`
+	Created      time.Time    `json:"created"`
+}
+
+// FlavorRevisionCollection is a list of FlavorRevision objects
+type FlavorRevisionCollection struct {
+	FlavorRevisions []FlavorRevision `json:"flavor_revisions"`
+}
+
+// FlavorUpdateRequest is the request body for replacing the content of an existing flavor
+type FlavorUpdateRequest struct {
+	Flavor  Flavor `json:"flavor"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// FlavorRollbackRequest is the request body for restoring a flavor to one of its prior revisions
+type FlavorRollbackRequest struct {
+	Revision int    `json:"revision"`
+	Comment  string `json:"comment,omitempty"`
+}
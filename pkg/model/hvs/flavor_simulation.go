@@ -0,0 +1,71 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"github.com/google/uuid"
+	cf "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+)
+
+// FlavorSimulationRequest describes candidate flavors to verify against the latest host manifests of a set of hosts.
+// The flavors are either given as content, or generated from a reference host manifest with flavor templates.
+type FlavorSimulationRequest struct {
+	// FlavorCollection is the candidate unsigned flavors
+	FlavorCollection *FlavorCollection `json:"flavor_collection,omitempty"`
+	// HostManifest is the reference manifest the candidate flavors are generated from
+	HostManifest *types.HostManifest `json:"host_manifest,omitempty"`
+	// FlavorTemplateIDs are the templates applied to the reference manifest, the templates whose conditions match the
+	// manifest are applied when empty
+	FlavorTemplateIDs []uuid.UUID `json:"flavor_template_ids,omitempty"`
+	// FlavorParts are the flavor parts generated from the reference manifest, PLATFORM and OS by default
+	FlavorParts []cf.FlavorPart `json:"flavor_parts,omitempty"`
+	// FlavorgroupID selects the hosts linked to the flavorgroup, whose flavor match policies are applied to the
+	// candidate flavors. The policies of the automatic flavorgroup are applied when it is not set.
+	// swagger:strfmt uuid
+	FlavorgroupID uuid.UUID `json:"flavorgroup_id,omitempty"`
+	// HostLabelSelector selects the hosts with matching labels, it is combined with FlavorgroupID when both are set
+	HostLabelSelector string `json:"host_label_selector,omitempty"`
+}
+
+// FlavorSimulation is the outcome of the verification of candidate flavors against the selected hosts. Nothing is
+// persisted, the flavors, the reports and the trust of the hosts are left unchanged.
+type FlavorSimulation struct {
+	Flavors            []Flavor               `json:"flavors"`
+	HostCount          int                    `json:"host_count"`
+	TrustedHostCount   int                    `json:"trusted_host_count"`
+	UntrustedHostCount int                    `json:"untrusted_host_count"`
+	SkippedHostCount   int                    `json:"skipped_host_count"`
+	Hosts              []HostFlavorSimulation `json:"hosts"`
+}
+
+// HostFlavorSimulation is the outcome of the verification of the candidate flavors against the latest manifest of a host
+type HostFlavorSimulation struct {
+	// swagger:strfmt uuid
+	HostID   uuid.UUID `json:"host_id"`
+	HostName string    `json:"host_name"`
+	// Trusted is true when the candidate flavors matching the host manifest meet the flavor match policies of the
+	// flavorgroup, and at least one of them is evaluated
+	Trusted       bool                     `json:"trusted"`
+	FlavorResults []FlavorSimulationResult `json:"flavor_results,omitempty"`
+	// MissingFlavorParts are the required flavor parts defined by the candidate flavors that no flavor matches
+	MissingFlavorParts []string `json:"missing_flavor_parts,omitempty"`
+	// Error is set when the host was skipped, because it has no manifest or could not be verified
+	Error string `json:"error,omitempty"`
+}
+
+// FlavorSimulationResult is the trust of a host against a candidate flavor
+type FlavorSimulationResult struct {
+	// swagger:strfmt uuid
+	FlavorID   uuid.UUID `json:"flavor_id"`
+	FlavorPart string    `json:"flavor_part"`
+	Label      string    `json:"label,omitempty"`
+	// Matched is true when the flavor matches the host manifest, that is when the flavor would be selected to
+	// verify the host
+	Matched     bool         `json:"matched"`
+	Trusted     bool         `json:"trusted"`
+	RuleResults []RuleResult `json:"rule_results"`
+}
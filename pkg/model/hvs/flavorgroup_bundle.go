@@ -0,0 +1,236 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// FlavorgroupBundle is a self-contained copy of a flavorgroup, that can be imported in another HVS. The flavorgroup
+// is exported without its flavor ids, the flavors are listed in SignedFlavors.
+type FlavorgroupBundle struct {
+	Flavorgroup     FlavorGroup      `json:"flavorgroup"`
+	SignedFlavors   []SignedFlavor   `json:"signed_flavors"`
+	FlavorTemplates []FlavorTemplate `json:"flavor_templates,omitempty"`
+	// SigningCertificates is the PEM encoded certificate chain of the key the bundle and its flavors are signed
+	// with, starting with the signing certificate
+	SigningCertificates []string  `json:"signing_certificates"`
+	Created             time.Time `json:"created"`
+}
+
+// NewFlavorgroupBundle creates a bundle of the flavorgroup with its flavors, the flavor templates the flavors are
+// generated from and the certificate chain of the key the flavors are signed with
+func NewFlavorgroupBundle(flavorgroup FlavorGroup, signedFlavors []SignedFlavor, flavorTemplates []FlavorTemplate, signingCerts []x509.Certificate) *FlavorgroupBundle {
+	// the flavors are bundled apart from the flavorgroup
+	flavorgroup.FlavorIds = nil
+	flavorgroup.Flavors = nil
+
+	var pemCerts []string
+	for _, cert := range signingCerts {
+		pemCerts = append(pemCerts, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})))
+	}
+
+	return &FlavorgroupBundle{
+		Flavorgroup:         flavorgroup,
+		SignedFlavors:       signedFlavors,
+		FlavorTemplates:     flavorTemplates,
+		SigningCertificates: pemCerts,
+		Created:             time.Now().UTC(),
+	}
+}
+
+// SignedFlavorgroupBundle combines the FlavorgroupBundle along with the cryptographically signed hash that
+// authenticates its source
+type SignedFlavorgroupBundle struct {
+	Bundle    FlavorgroupBundle `json:"bundle"`
+	Signature string            `json:"signature"`
+}
+
+// NewSignedFlavorgroupBundle Provided an existing bundle and a private key, create a SignedFlavorgroupBundle
+func NewSignedFlavorgroupBundle(bundle *FlavorgroupBundle, privateKey *rsa.PrivateKey) (*SignedFlavorgroupBundle, error) {
+	if bundle == nil {
+		return nil, errors.New("The flavorgroup bundle must be provided and cannot be nil")
+	}
+
+	if privateKey == nil || privateKey.Validate() != nil {
+		return nil, errors.New("Valid private key must be provided and cannot be nil")
+	}
+
+	bundleDigest, err := bundle.getBundleDigest()
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred while creating the signed flavorgroup bundle")
+	}
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA384, bundleDigest)
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred while signing the flavorgroup bundle")
+	}
+
+	return &SignedFlavorgroupBundle{
+		Bundle:    *bundle,
+		Signature: base64.StdEncoding.EncodeToString(signature),
+	}, nil
+}
+
+// Verify checks that the signing certificate of the bundle chains up to one of the flavor CAs, and that the bundle
+// and all its flavors are signed with the key of the signing certificate
+func (signedBundle *SignedFlavorgroupBundle) Verify(flavorCAs *x509.CertPool) error {
+	if len(signedBundle.Signature) == 0 {
+		return errors.New("Could not verify the flavorgroup bundle: The bundle does not have a signature")
+	}
+
+	signingCerts, err := signedBundle.Bundle.GetSigningCertificates()
+	if err != nil {
+		return errors.Wrap(err, "Could not verify the flavorgroup bundle")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range signingCerts[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err = signingCerts[0].Verify(x509.VerifyOptions{
+		Roots:         flavorCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return errors.Wrap(err, "Could not verify the flavorgroup bundle: The signing certificate is not trusted")
+	}
+
+	publicKey, ok := signingCerts[0].PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("Could not verify the flavorgroup bundle: The signing certificate does not have a RSA public key")
+	}
+
+	signatureBytes, err := base64.StdEncoding.DecodeString(signedBundle.Signature)
+	if err != nil {
+		return errors.Wrap(err, "Could not verify the flavorgroup bundle: An error occurred attempting to decode the bundle signature")
+	}
+
+	bundleDigest, err := signedBundle.Bundle.getBundleDigest()
+	if err != nil {
+		return errors.Wrap(err, "Could not verify the flavorgroup bundle: An error occurred collecting the bundle digest")
+	}
+
+	err = rsa.VerifyPKCS1v15(publicKey, crypto.SHA384, bundleDigest, signatureBytes)
+	if err != nil {
+		return errors.Wrap(err, "Could not verify the flavorgroup bundle: PKCS1 verification failed")
+	}
+
+	for i := range signedBundle.Bundle.SignedFlavors {
+		if err = signedBundle.Bundle.SignedFlavors[i].Verify(publicKey); err != nil {
+			return errors.Wrapf(err, "Could not verify the flavorgroup bundle: Flavor %s",
+				signedBundle.Bundle.SignedFlavors[i].Flavor.Meta.ID)
+		}
+	}
+	return nil
+}
+
+// GetSigningCertificates decodes the signing certificate chain of the bundle
+func (bundle *FlavorgroupBundle) GetSigningCertificates() ([]*x509.Certificate, error) {
+	if len(bundle.SigningCertificates) == 0 {
+		return nil, errors.New("The bundle does not have a signing certificate")
+	}
+
+	var certs []*x509.Certificate
+	for _, pemCert := range bundle.SigningCertificates {
+		block, _ := pem.Decode([]byte(pemCert))
+		if block == nil || block.Type != "CERTIFICATE" {
+			return nil, errors.New("The signing certificate is not a PEM encoded certificate")
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to parse the signing certificate")
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+// getBundleDigest calculates the SHA384 hash of the bundle's json data for use when signing/verifying the bundle
+func (bundle *FlavorgroupBundle) getBundleDigest() ([]byte, error) {
+	bundleJSON, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred attempting to convert the bundle to json")
+	}
+
+	hashEntity := sha512.New384()
+	_, err = hashEntity.Write(bundleJSON)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error writing bundle hash")
+	}
+	return hashEntity.Sum(nil), nil
+}
+
+// FlavorgroupImportStatus is the outcome of the import of an item of a flavorgroup bundle
+type FlavorgroupImportStatus string
+
+const (
+	// FlavorgroupImportCreated is an item that did not exist and is created
+	FlavorgroupImportCreated FlavorgroupImportStatus = "created"
+	// FlavorgroupImportDuplicate is an item that already exists with the same content and is reused
+	FlavorgroupImportDuplicate FlavorgroupImportStatus = "duplicate"
+	// FlavorgroupImportConflict is an item that already exists with a different content
+	FlavorgroupImportConflict FlavorgroupImportStatus = "conflict"
+)
+
+// FlavorgroupImportItemType is the type of an item of a flavorgroup bundle
+type FlavorgroupImportItemType string
+
+const (
+	FlavorgroupImportItemFlavorgroup    FlavorgroupImportItemType = "flavorgroup"
+	FlavorgroupImportItemFlavor         FlavorgroupImportItemType = "flavor"
+	FlavorgroupImportItemFlavorTemplate FlavorgroupImportItemType = "flavor_template"
+)
+
+// FlavorgroupImportItem is the outcome of the import of a flavorgroup, flavor or flavor template of a bundle
+type FlavorgroupImportItem struct {
+	Type FlavorgroupImportItemType `json:"type"`
+	// ID is the id of the item in the bundle
+	// swagger:strfmt uuid
+	ID     uuid.UUID               `json:"id"`
+	Name   string                  `json:"name"`
+	Status FlavorgroupImportStatus `json:"status"`
+	// ExistingID is the id of the item the bundle item is a duplicate of, or conflicts with
+	// swagger:strfmt uuid
+	ExistingID uuid.UUID `json:"existing_id,omitempty"`
+	Message    string    `json:"message,omitempty"`
+}
+
+// FlavorgroupImport is the outcome of the import of a flavorgroup bundle. Nothing is imported when there is a
+// conflict.
+type FlavorgroupImport struct {
+	// swagger:strfmt uuid
+	FlavorgroupID  uuid.UUID               `json:"flavorgroup_id,omitempty"`
+	Imported       bool                    `json:"imported"`
+	CreatedCount   int                     `json:"created_count"`
+	DuplicateCount int                     `json:"duplicate_count"`
+	ConflictCount  int                     `json:"conflict_count"`
+	Items          []FlavorgroupImportItem `json:"items"`
+}
+
+// AddItem adds the outcome of the import of an item and updates the counts
+func (fi *FlavorgroupImport) AddItem(item FlavorgroupImportItem) {
+	switch item.Status {
+	case FlavorgroupImportCreated:
+		fi.CreatedCount++
+	case FlavorgroupImportDuplicate:
+		fi.DuplicateCount++
+	case FlavorgroupImportConflict:
+		fi.ConflictCount++
+	}
+	fi.Items = append(fi.Items, item)
+}
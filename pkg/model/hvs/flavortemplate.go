@@ -6,6 +6,7 @@ package hvs
 
 import (
 	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
 )
 
 //PCR - To store PCR index with respective PCR bank.
@@ -36,6 +37,12 @@ type FlavorPart struct {
 	// Meta is key:value pair section used to define flavorparts with its own meta fields.
 	Meta     map[string]interface{} `json:"meta,omitempty"`
 	PcrRules []PcrRules             `json:"pcr_rules"`
+	// CustomRules are site specific rules copied to the flavors generated from the template, and evaluated against
+	// the host manifest along with the PCR rules.
+	CustomRules []model.CustomRule `json:"custom_rules,omitempty"`
+	// HostInfoPolicy are the hardware features, minimum versions and host info values required by the PLATFORM
+	// flavors generated from the template. It is ignored in the other flavor parts.
+	HostInfoPolicy *model.HostInfoPolicy `json:"host_info_policy,omitempty"`
 }
 
 // swagger:parameters FlavorParts
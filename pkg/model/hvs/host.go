@@ -8,6 +8,8 @@ import "github.com/google/uuid"
 
 type HostCollection struct {
 	Hosts []*Host `json:"hosts" xml:"host"`
+	// Next is the link to the next page of a paginated search, empty on the last page
+	Next string `json:"next,omitempty" xml:"next,omitempty"`
 }
 
 type Host struct {
@@ -19,16 +21,23 @@ type Host struct {
 	// swagger:strfmt uuid
 	HardwareUuid     *uuid.UUID             `json:"hardware_uuid,omitempty"`
 	FlavorgroupNames []string               `json:"flavorgroup_names,omitempty"`
+	Labels           map[string]string      `json:"labels,omitempty"`
 	Report           *TrustReport           `json:"report,omitempty"`
 	Trusted          *bool                  `json:"trusted,omitempty"`
 	ConnectionStatus *HostStatusInformation `json:"status,omitempty"`
 }
 
 type HostCreateRequest struct {
-	HostName         string   `json:"host_name"`
-	Description      string   `json:"description,omitempty"`
-	ConnectionString string   `json:"connection_string"`
-	FlavorgroupNames []string `json:"flavorgroup_names,omitempty"`
+	HostName         string            `json:"host_name"`
+	Description      string            `json:"description,omitempty"`
+	ConnectionString string            `json:"connection_string"`
+	FlavorgroupNames []string          `json:"flavorgroup_names,omitempty"`
+	Labels           map[string]string `json:"labels,omitempty"`
+}
+
+// HostLabels is the body of the host labels API, labels are key/value pairs that can be used to select hosts
+type HostLabels struct {
+	Labels map[string]string `json:"labels"`
 }
 
 type HostFlavorgroupCollection struct {
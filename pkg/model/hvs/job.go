@@ -0,0 +1,69 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobType identifies the operation run by a Job
+type JobType string
+
+const (
+	// JobTypeHostBulkRegistration registers the hosts of a bulk host create request
+	JobTypeHostBulkRegistration JobType = "HOST_BULK_REGISTRATION"
+)
+
+// JobStatus is the progress state of a Job or of one of its entries
+type JobStatus string
+
+const (
+	JobStatusQueued    JobStatus = "QUEUED"
+	JobStatusRunning   JobStatus = "RUNNING"
+	JobStatusCompleted JobStatus = "COMPLETED"
+	JobStatusFailed    JobStatus = "FAILED"
+)
+
+// Job tracks an asynchronous operation started by an API request
+type Job struct {
+	// swagger:strfmt uuid
+	Id        uuid.UUID      `json:"id"`
+	Type      JobType        `json:"type"`
+	Status    JobStatus      `json:"status"`
+	Total     int            `json:"total"`
+	Succeeded int            `json:"succeeded"`
+	Failed    int            `json:"failed"`
+	Hosts     []JobHostEntry `json:"hosts,omitempty"`
+	// Error is set when the job could not be run to completion
+	Error   string    `json:"error,omitempty"`
+	Created time.Time `json:"created"`
+	Updated time.Time `json:"updated"`
+}
+
+// JobHostEntry is the status of the registration of a single host of a bulk host create request
+type JobHostEntry struct {
+	HostName string    `json:"host_name"`
+	Status   JobStatus `json:"status"`
+	// swagger:strfmt uuid
+	HostId *uuid.UUID `json:"host_id,omitempty"`
+	Error  string     `json:"error,omitempty"`
+}
+
+// HostBulkCreateRequest is the JSON body of the bulk host create API
+type HostBulkCreateRequest struct {
+	Hosts []HostCreateRequest `json:"hosts"`
+}
+
+// HostFlavorgroupBulkCreateRequest is the JSON body of the bulk host flavorgroup link API, each of the hosts is linked
+// to all the flavorgroups
+type HostFlavorgroupBulkCreateRequest struct {
+	// swagger:strfmt uuid
+	HostIds []uuid.UUID `json:"host_ids"`
+	// swagger:strfmt uuid
+	FlavorgroupIds []uuid.UUID `json:"flavorgroup_ids"`
+}
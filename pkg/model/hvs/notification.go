@@ -0,0 +1,134 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationEventType identifies the kind of change described by a NotificationEvent
+type NotificationEventType string
+
+const (
+	// EventHostTrustChanged is raised when the overall trust status of a host changes
+	EventHostTrustChanged NotificationEventType = "HOST_TRUST_CHANGED"
+	// EventFlavorPartTrustChanged is raised when the trust status of a single flavor part of a host changes
+	EventFlavorPartTrustChanged NotificationEventType = "FLAVOR_PART_TRUST_CHANGED"
+	// EventHostStateChanged is raised when the connection state of a host changes
+	EventHostStateChanged NotificationEventType = "HOST_STATE_CHANGED"
+)
+
+var notificationEventTypes = map[NotificationEventType]bool{
+	EventHostTrustChanged:       true,
+	EventFlavorPartTrustChanged: true,
+	EventHostStateChanged:       true,
+}
+
+// Valid checks if the event type is one of the supported notification event types
+func (t NotificationEventType) Valid() bool {
+	return notificationEventTypes[t]
+}
+
+// NotificationEvent describes a change in trust or connection state of a host
+type NotificationEvent struct {
+	// swagger:strfmt uuid
+	ID   uuid.UUID             `json:"id"`
+	Type NotificationEventType `json:"type"`
+	// swagger:strfmt uuid
+	HostID     uuid.UUID `json:"host_id"`
+	HostName   string    `json:"host_name,omitempty"`
+	FlavorPart string    `json:"flavor_part,omitempty"`
+	// swagger:strfmt uuid
+	ReportID          *uuid.UUID `json:"report_id,omitempty"`
+	PreviousTrusted   *bool      `json:"previous_trusted,omitempty"`
+	Trusted           *bool      `json:"trusted,omitempty"`
+	PreviousHostState string     `json:"previous_host_state,omitempty"`
+	HostState         string     `json:"host_state,omitempty"`
+	Created           time.Time  `json:"created"`
+}
+
+// NotificationSubscription is a registration of a webhook that receives NotificationEvents matching its filter
+type NotificationSubscription struct {
+	// swagger:strfmt uuid
+	ID          uuid.UUID `json:"id"`
+	CallbackURL string    `json:"callback_url"`
+	// Secret is used to compute the HMAC signature of every payload delivered to the callback URL.
+	// It is never returned by the API.
+	Secret      string                  `json:"secret,omitempty"`
+	EventTypes  []NotificationEventType `json:"event_types,omitempty"`
+	HostIDs     []uuid.UUID             `json:"host_ids,omitempty"`
+	FlavorParts []string                `json:"flavor_parts,omitempty"`
+	Created     time.Time               `json:"created"`
+}
+
+// NotificationSubscriptionCollection holds a collection of NotificationSubscription in response to an API query
+type NotificationSubscriptionCollection struct {
+	NotificationSubscriptions []NotificationSubscription `json:"notification_subscriptions"`
+}
+
+// NotificationDeadLetter records an event that could not be delivered to a subscriber after all retries
+type NotificationDeadLetter struct {
+	// swagger:strfmt uuid
+	ID uuid.UUID `json:"id"`
+	// swagger:strfmt uuid
+	SubscriptionID uuid.UUID         `json:"subscription_id"`
+	CallbackURL    string            `json:"callback_url"`
+	Event          NotificationEvent `json:"event"`
+	Attempts       int               `json:"attempts"`
+	LastError      string            `json:"last_error"`
+	Created        time.Time         `json:"created"`
+}
+
+// NotificationDeadLetterCollection holds a collection of NotificationDeadLetter in response to an API query
+type NotificationDeadLetterCollection struct {
+	NotificationDeadLetters []NotificationDeadLetter `json:"notification_dead_letters"`
+}
+
+// Matches checks whether the event passes the filter of the subscription. Empty filter fields match everything.
+func (s *NotificationSubscription) Matches(event *NotificationEvent) bool {
+	if event == nil {
+		return false
+	}
+	if len(s.EventTypes) > 0 {
+		found := false
+		for _, t := range s.EventTypes {
+			if t == event.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(s.HostIDs) > 0 {
+		found := false
+		for _, id := range s.HostIDs {
+			if id == event.HostID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(s.FlavorParts) > 0 && event.Type == EventFlavorPartTrustChanged {
+		found := false
+		for _, fp := range s.FlavorParts {
+			if fp == event.FlavorPart {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
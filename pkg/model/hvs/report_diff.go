@@ -0,0 +1,83 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+)
+
+// DiffChange describes how an entry of a report differs from the report it is compared against
+type DiffChange string
+
+const (
+	// DiffAdded is used for entries that only exist in the report
+	DiffAdded DiffChange = "ADDED"
+	// DiffRemoved is used for entries that only exist in the report compared against
+	DiffRemoved DiffChange = "REMOVED"
+	// DiffChanged is used for entries that exist in both reports with different content
+	DiffChanged DiffChange = "CHANGED"
+)
+
+// ReportDiff is the structured difference between a report and an older report it is compared against
+type ReportDiff struct {
+	// swagger:strfmt uuid
+	ReportID uuid.UUID `json:"report_id"`
+	// swagger:strfmt uuid
+	AgainstReportID uuid.UUID `json:"against_report_id"`
+	// swagger:strfmt uuid
+	HostID         uuid.UUID        `json:"host_id"`
+	Trusted        bool             `json:"trusted"`
+	AgainstTrusted bool             `json:"against_trusted"`
+	RuleResults    []RuleResultDiff `json:"rule_results,omitempty"`
+	PcrValues      []PcrValueDiff   `json:"pcr_values,omitempty"`
+	EventLogs      []EventLogDiff   `json:"event_logs,omitempty"`
+	HostManifest   []FieldDiff      `json:"host_manifest,omitempty"`
+}
+
+// RuleResultDiff describes the change in the outcome of a single rule
+type RuleResultDiff struct {
+	Change   DiffChange          `json:"change"`
+	RuleName string              `json:"rule_name"`
+	Markers  []common.FlavorPart `json:"markers,omitempty"`
+	Pcr      *types.Pcr          `json:"pcr,omitempty"`
+	// swagger:strfmt uuid
+	FlavorId *uuid.UUID `json:"flavor_id,omitempty"`
+	// swagger:strfmt uuid
+	AgainstFlavorId        *uuid.UUID      `json:"against_flavor_id,omitempty"`
+	Trusted                *bool           `json:"trusted,omitempty"`
+	AgainstTrusted         *bool           `json:"against_trusted,omitempty"`
+	FaultsAdded            []Fault         `json:"faults_added,omitempty"`
+	FaultsResolved         []Fault         `json:"faults_resolved,omitempty"`
+	MismatchFieldsAdded    []MismatchField `json:"mismatch_fields_added,omitempty"`
+	MismatchFieldsResolved []MismatchField `json:"mismatch_fields_resolved,omitempty"`
+}
+
+// PcrValueDiff describes the change of a PCR value in a PCR bank
+type PcrValueDiff struct {
+	Change       DiffChange         `json:"change"`
+	PcrBank      types.SHAAlgorithm `json:"pcr_bank"`
+	PcrIndex     types.PcrIndex     `json:"pcr_index"`
+	Value        string             `json:"value,omitempty"`
+	AgainstValue string             `json:"against_value,omitempty"`
+}
+
+// EventLogDiff lists the event log entries of a PCR that were added, removed or modified
+type EventLogDiff struct {
+	Pcr      types.Pcr        `json:"pcr"`
+	Added    []types.EventLog `json:"added,omitempty"`
+	Removed  []types.EventLog `json:"removed,omitempty"`
+	Modified []types.EventLog `json:"modified,omitempty"`
+}
+
+// FieldDiff describes the change of a single host manifest field
+type FieldDiff struct {
+	Change       DiffChange  `json:"change"`
+	Field        string      `json:"field"`
+	Value        interface{} `json:"value,omitempty"`
+	AgainstValue interface{} `json:"against_value,omitempty"`
+}
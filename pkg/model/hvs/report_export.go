@@ -0,0 +1,67 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReportExportRow is the outcome of a single rule of a report, flattened for the CSV and JSON-lines exports. A report
+// without rule results is exported as a single row without rule.
+type ReportExportRow struct {
+	// swagger:strfmt uuid
+	ReportID uuid.UUID `json:"report_id"`
+	// swagger:strfmt uuid
+	HostID       uuid.UUID `json:"host_id"`
+	HostName     string    `json:"host_name"`
+	HardwareUUID string    `json:"hardware_uuid,omitempty"`
+	CreatedAt    time.Time `json:"created"`
+	Expiration   time.Time `json:"expiration"`
+	// Trusted is the overall trust status of the report
+	Trusted     bool   `json:"trusted"`
+	FlavorPart  string `json:"flavor_part,omitempty"`
+	RuleName    string `json:"rule_name,omitempty"`
+	RuleTrusted *bool  `json:"rule_trusted,omitempty"`
+	// swagger:strfmt uuid
+	FlavorID          *uuid.UUID `json:"flavor_id,omitempty"`
+	FaultNames        []string   `json:"fault_names,omitempty"`
+	FaultDescriptions []string   `json:"fault_descriptions,omitempty"`
+}
+
+// ReportExportCSVHeader is the header line of the CSV report export, in the order of ReportExportRow.CSVRecord
+var ReportExportCSVHeader = []string{"report_id", "host_id", "host_name", "hardware_uuid", "created", "expiration",
+	"trusted", "flavor_part", "rule_name", "rule_trusted", "flavor_id", "fault_names", "fault_descriptions"}
+
+// CSVRecord returns the fields of the row in the order of ReportExportCSVHeader. The faults are separated by
+// semicolons.
+func (row *ReportExportRow) CSVRecord() []string {
+	var ruleTrusted, flavorId string
+	if row.RuleTrusted != nil {
+		ruleTrusted = strconv.FormatBool(*row.RuleTrusted)
+	}
+	if row.FlavorID != nil {
+		flavorId = row.FlavorID.String()
+	}
+	return []string{
+		row.ReportID.String(),
+		row.HostID.String(),
+		row.HostName,
+		row.HardwareUUID,
+		row.CreatedAt.UTC().Format(time.RFC3339),
+		row.Expiration.UTC().Format(time.RFC3339),
+		strconv.FormatBool(row.Trusted),
+		row.FlavorPart,
+		row.RuleName,
+		ruleTrusted,
+		flavorId,
+		strings.Join(row.FaultNames, ";"),
+		strings.Join(row.FaultDescriptions, ";"),
+	}
+}
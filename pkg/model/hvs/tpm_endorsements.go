@@ -18,8 +18,12 @@ type TpmEndorsement struct {
 	Certificate       string    `json:"certificate"`
 	Comment           string    `json:"comment,omitempty"`
 	CertificateDigest string    `json:"certificate_digest,omitempty"`
+	// EndorsementProvenance is recorded by the privacy CA when the EK certificate is verified, it cannot be set by clients
+	EndorsementProvenance *EndorsementProvenance `json:"endorsement_provenance,omitempty"`
 }
 
 type TpmEndorsementCollection struct {
 	TpmEndorsement []*TpmEndorsement `json:"tpmendorsements"`
+	// Next is the link to the next page of a paginated search, empty on the last page
+	Next string `json:"next,omitempty"`
 }
@@ -55,6 +55,8 @@ type RuleInfo struct {
 	Exclude_Tags             []string               `json:"excluding_tag,omitempty"`
 	ExpectedTag              []byte                 `json:"expected_tag,omitempty"`
 	Tags                     map[string]string      `json:"tags,omitempty"`
+	// Attribute is the host info attribute or hardware feature verified by the host info rules
+	Attribute string `json:"attribute,omitempty"`
 }
 
 type Fault struct {
@@ -140,6 +142,17 @@ func (t *TrustReport) CheckResultExists(targetRuleResult RuleResult) bool {
 				} else {
 					continue
 				}
+			case constants.RuleHardwareFeatureEnabled,
+				constants.RuleHostInfoVersionAtLeast,
+				constants.RuleHostInfoMatches:
+				// These rules are repeated for each host info attribute, compare the attribute they verify
+				if targetRuleResult.Rule.Attribute != ruleResult.Rule.Attribute {
+					continue
+				}
+				if len(targetRuleResult.Faults) > 0 {
+					return false
+				}
+				return true
 			default:
 				if len(targetRuleResult.Faults) > 0 {
 					return false
@@ -0,0 +1,34 @@
+/*
+ * Copyright (C) 2021 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VerificationQueueEntryCollection is the result of a search of the host trust verification queue
+type VerificationQueueEntryCollection struct {
+	QueueEntries []VerificationQueueEntry `json:"queue_entries"`
+}
+
+// VerificationQueueEntry is a host trust verification waiting or in progress in the verification queue
+type VerificationQueueEntry struct {
+	// swagger:strfmt uuid
+	Id uuid.UUID `json:"id"`
+	// swagger:strfmt uuid
+	HostId uuid.UUID `json:"host_id"`
+	// Stage is the stage reached by the verification: GET_HOST_DATA_QUEUED, GET_HOST_DATA_STARTED,
+	// FLAVOR_VERIFY_QUEUED or FLAVOR_VERIFY_STARTED
+	Stage string `json:"stage"`
+	// Priority is HIGH for the verifications requested through the API and LOW for the refresh of expiring reports
+	Priority        string    `json:"priority"`
+	FetchHostData   bool      `json:"fetch_host_data"`
+	PreferHashMatch bool      `json:"prefer_hash_match"`
+	Created         time.Time `json:"created"`
+	Updated         time.Time `json:"updated"`
+}
@@ -34,11 +34,22 @@ type KeyRequest struct {
 type KeyResponse struct {
 	KeyInformation *KeyInformation `json:"key_information"`
 	// swagger:strfmt uuid
-	TransferPolicyID uuid.UUID `json:"transfer_policy_id"`
-	TransferLink     string    `json:"transfer_link"`
-	CreatedAt        time.Time `json:"created_at"`
-	Label            string    `json:"label,omitempty"`
-	Usage            string    `json:"usage,omitempty"`
+	TransferPolicyID uuid.UUID  `json:"transfer_policy_id"`
+	TransferLink     string     `json:"transfer_link"`
+	CreatedAt        time.Time  `json:"created_at"`
+	Label            string     `json:"label,omitempty"`
+	Usage            string     `json:"usage,omitempty"`
+	Version          int        `json:"version,omitempty"`
+	RotatedAt        *time.Time `json:"rotated_at,omitempty"`
+	// PreviousVersions lists the versions replaced by a rotation that are still available for transfer
+	PreviousVersions []KeyVersionInformation `json:"previous_versions,omitempty"`
+}
+
+// KeyVersionInformation - Describes a version of a key replaced by a rotation.
+type KeyVersionInformation struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 // KeyTransferAttributes - Contains all possible key transfer attributes.
@@ -49,6 +60,7 @@ type KeyTransferAttributes struct {
 	KeyData      string     `json:"payload,omitempty"`
 	KeyAlgorithm string     `json:"algorithm,omitempty"`
 	KeyLength    int        `json:"key_length,omitempty"`
+	Version      int        `json:"version,omitempty"`
 	CreatedAt    *time.Time `json:"created_at,omitempty"`
 	Policy       struct {
 		Link struct {
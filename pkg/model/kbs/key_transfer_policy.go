@@ -27,4 +27,6 @@ type KeyTransferPolicyAttributes struct {
 	TLSClientCertificateSANAllof           []string  `json:"client_permissions_allof,omitempty"`
 	AttestationTypeAnyof                   []string  `json:"attestation_type_anyof,omitempty"`
 	SGXEnforceTCBUptoDate                  bool      `json:"sgx_enforce_tcb_up_to_date,omitempty"`
+	// KeyRotationIntervalDays enables the scheduled rotation of the keys using the policy
+	KeyRotationIntervalDays int `json:"key_rotation_interval_days,omitempty"`
 }
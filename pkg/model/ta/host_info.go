@@ -31,6 +31,13 @@ type UEFI struct {
 	} `json:"meta"`
 }
 
+type BMC struct {
+	HardwareFeature
+	Meta struct {
+		FirmwareVersion string `json:"firmware_version,omitempty"`
+	} `json:"meta"`
+}
+
 type HostInfo struct {
 	OSName              string           `json:"os_name"`
 	OSVersion           string           `json:"os_version"`
@@ -55,5 +62,5 @@ type HardwareFeatures struct {
 	CBNT CBNT            `json:"CBNT"`
 	UEFI UEFI            `json:"UEFI"`
 	PFR  HardwareFeature `json:"PFR"`
-	BMC  HardwareFeature `json:"BMC"`
+	BMC  BMC             `json:"BMC"`
 }